@@ -0,0 +1,175 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gring
+
+import (
+	"sync"
+)
+
+// Buffer is a bounded, concurrent-safe ring buffer for multiple producers and consumers.
+//
+// Unlike Ring, which is a circular linked list, Buffer is backed by a contiguous slice
+// and designed for FIFO usage like recent-events buffers and metrics windows.
+// Batch operations acquire the lock only once for all given items, which keeps the lock
+// contention low under high concurrency.
+type Buffer struct {
+	mu          sync.Mutex
+	items       []interface{} // Underlying storage.
+	head        int           // Read position, which is the position of the oldest item.
+	size        int           // Count of items currently stored.
+	overwrite   bool          // Overwrite the oldest item if buffer is full.
+	overwritten int64         // Count of items that were dropped due to overwriting.
+}
+
+// NewBuffer creates and returns a Buffer of `capacity` items.
+// The optional parameter `overwrite` specifies whether the oldest item is overwritten
+// when putting to a full buffer, which is false in default, and the putting fails in that case.
+func NewBuffer(capacity int, overwrite ...bool) *Buffer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	b := &Buffer{
+		items: make([]interface{}, capacity),
+	}
+	if len(overwrite) > 0 {
+		b.overwrite = overwrite[0]
+	}
+	return b
+}
+
+// Put appends `value` to the tail of the buffer.
+// It returns false if the buffer is full and it is not in overwrite mode.
+func (b *Buffer) Put(value interface{}) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.doPut(value)
+}
+
+// PutBatch appends `values` to the tail of the buffer in order within one lock.
+// It returns the count of values that were put, which is less than len(values)
+// only if the buffer gets full and it is not in overwrite mode.
+func (b *Buffer) PutBatch(values ...interface{}) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, value := range values {
+		if !b.doPut(value) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// doPut puts `value` to the buffer without locking.
+func (b *Buffer) doPut(value interface{}) bool {
+	capacity := len(b.items)
+	if b.size == capacity {
+		if !b.overwrite {
+			return false
+		}
+		// Drop the oldest item.
+		b.items[b.head] = nil
+		b.head = (b.head + 1) % capacity
+		b.size--
+		b.overwritten++
+	}
+	b.items[(b.head+b.size)%capacity] = value
+	b.size++
+	return true
+}
+
+// Get removes and returns the oldest item from the buffer.
+// The second returned value is false if the buffer is empty.
+func (b *Buffer) Get() (value interface{}, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size == 0 {
+		return nil, false
+	}
+	value = b.items[b.head]
+	b.items[b.head] = nil
+	b.head = (b.head + 1) % len(b.items)
+	b.size--
+	return value, true
+}
+
+// GetBatch removes and returns at most `max` oldest items from the buffer within one lock.
+// It returns all items if `max` <= 0.
+func (b *Buffer) GetBatch(max int) []interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.size
+	if max > 0 && max < n {
+		n = max
+	}
+	values := b.copyTo(make([]interface{}, n))
+	for i := 0; i < n; i++ {
+		b.items[(b.head+i)%len(b.items)] = nil
+	}
+	b.head = (b.head + n) % len(b.items)
+	b.size -= n
+	return values
+}
+
+// Snapshot returns a copy of all items from oldest to newest without removing them.
+func (b *Buffer) Snapshot() []interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.copyTo(make([]interface{}, b.size))
+}
+
+// copyTo copies items from oldest to `dst` without locking, which copies at most len(dst) items.
+func (b *Buffer) copyTo(dst []interface{}) []interface{} {
+	n := copy(dst, b.items[b.head:minInt(b.head+len(dst), len(b.items))])
+	if n < len(dst) {
+		copy(dst[n:], b.items)
+	}
+	return dst
+}
+
+// Len returns the count of items currently stored in the buffer.
+func (b *Buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// Cap returns the capacity of the buffer.
+func (b *Buffer) Cap() int {
+	return len(b.items)
+}
+
+// IsFull checks and returns whether the buffer is full.
+func (b *Buffer) IsFull() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size == len(b.items)
+}
+
+// Overwritten returns the total count of items dropped by overwriting.
+func (b *Buffer) Overwritten() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.overwritten
+}
+
+// Clear removes all items from the buffer.
+func (b *Buffer) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := range b.items {
+		b.items[i] = nil
+	}
+	b.head = 0
+	b.size = 0
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
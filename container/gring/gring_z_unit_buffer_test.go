@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gring_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gring"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestBuffer_PutGet(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		b := gring.NewBuffer(3)
+		t.Assert(b.Cap(), 3)
+		t.Assert(b.Put(1), true)
+		t.Assert(b.Put(2), true)
+		t.Assert(b.Put(3), true)
+		t.Assert(b.Put(4), false)
+		t.Assert(b.IsFull(), true)
+		t.Assert(b.Len(), 3)
+
+		v, ok := b.Get()
+		t.Assert(ok, true)
+		t.Assert(v, 1)
+		t.Assert(b.Put(4), true)
+		t.Assert(b.Snapshot(), []interface{}{2, 3, 4})
+
+		b.Clear()
+		v, ok = b.Get()
+		t.Assert(ok, false)
+		t.Assert(v, nil)
+	})
+}
+
+func TestBuffer_Overwrite(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		b := gring.NewBuffer(3, true)
+		t.Assert(b.PutBatch(1, 2, 3, 4, 5), 5)
+		t.Assert(b.Len(), 3)
+		t.Assert(b.Overwritten(), 2)
+		t.Assert(b.Snapshot(), []interface{}{3, 4, 5})
+	})
+}
+
+func TestBuffer_Batch(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		b := gring.NewBuffer(4)
+		t.Assert(b.PutBatch(1, 2, 3, 4, 5), 4)
+		t.Assert(b.GetBatch(3), []interface{}{1, 2, 3})
+		t.Assert(b.PutBatch(6, 7), 2)
+		// Wrapped around.
+		t.Assert(b.Snapshot(), []interface{}{4, 6, 7})
+		t.Assert(b.GetBatch(0), []interface{}{4, 6, 7})
+		t.Assert(b.Len(), 0)
+		t.Assert(len(b.GetBatch(10)), 0)
+	})
+}
+
+func TestBuffer_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			b     = gring.NewBuffer(1000)
+			wg    sync.WaitGroup
+			total = 0
+			mu    sync.Mutex
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					b.Put(j)
+				}
+			}()
+		}
+		wg.Wait()
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n := len(b.GetBatch(10))
+				mu.Lock()
+				total += n
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		t.Assert(total, 100)
+		t.Assert(b.Len(), 900)
+	})
+}
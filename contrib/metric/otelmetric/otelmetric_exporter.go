@@ -0,0 +1,129 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package otelmetric
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+const (
+	exporterProtocolGRPC = "grpc"
+	exporterProtocolHTTP = "http"
+)
+
+func init() {
+	gmetric.RegisterExporter(gmetric.DefaultExporter, newOTLPExporterProvider)
+}
+
+// newOTLPExporterProvider creates and returns a Provider pushing metrics to OTLP backend periodically,
+// which is registered as the default exporter of gmetric.Bootstrap.
+func newOTLPExporterProvider(ctx context.Context, config gmetric.ExporterConfig) (gmetric.Provider, error) {
+	exporter, err := newOTLPExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	res, err := newExporterResource(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return NewProvider(
+		WithResource(res),
+		WithReader(metric.NewPeriodicReader(
+			exporter,
+			metric.WithInterval(config.Interval),
+			metric.WithTimeout(config.Timeout),
+		)),
+	)
+}
+
+// newOTLPExporter creates and returns the OTLP exporter by protocol of `config`.
+func newOTLPExporter(ctx context.Context, config gmetric.ExporterConfig) (metric.Exporter, error) {
+	var (
+		exporter metric.Exporter
+		err      error
+	)
+	switch strings.ToLower(config.Protocol) {
+	case exporterProtocolGRPC:
+		options := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(config.Endpoint),
+			otlpmetricgrpc.WithTimeout(config.Timeout),
+		}
+		if config.Insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlpmetricgrpc.WithHeaders(config.Headers))
+		}
+		exporter, err = otlpmetricgrpc.New(ctx, options...)
+
+	case exporterProtocolHTTP:
+		options := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(config.Endpoint),
+			otlpmetrichttp.WithTimeout(config.Timeout),
+		}
+		if config.Path != "" {
+			options = append(options, otlpmetrichttp.WithURLPath(config.Path))
+		}
+		if config.Insecure {
+			options = append(options, otlpmetrichttp.WithInsecure())
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlpmetrichttp.WithHeaders(config.Headers))
+		}
+		exporter, err = otlpmetrichttp.New(ctx, options...)
+
+	default:
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidConfiguration,
+			`unsupported OTLP exporter protocol "%s", it should be "%s" or "%s"`,
+			config.Protocol, exporterProtocolGRPC, exporterProtocolHTTP,
+		)
+	}
+	if err != nil {
+		return nil, gerror.WrapCodef(
+			gcode.CodeInternalError, err, `create OTLP %s exporter failed`, config.Protocol,
+		)
+	}
+	return exporter, nil
+}
+
+// newExporterResource creates and returns the resource with service name and resource attributes of `config`.
+func newExporterResource(ctx context.Context, config gmetric.ExporterConfig) (*resource.Resource, error) {
+	var attributes = make([]attribute.KeyValue, 0, len(config.ResourceAttributes)+1)
+	if config.ServiceName != "" {
+		attributes = append(attributes, semconv.ServiceNameKey.String(config.ServiceName))
+	}
+	for k, v := range config.ResourceAttributes {
+		attributes = append(attributes, attribute.String(k, v))
+	}
+	res, err := resource.New(
+		ctx,
+		resource.WithFromEnv(),
+		resource.WithProcess(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(attributes...),
+	)
+	// The partial resource is usable, which misses only some detected attributes.
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `create metric resource failed`)
+	}
+	return res, nil
+}
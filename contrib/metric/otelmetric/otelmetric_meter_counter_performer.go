@@ -61,5 +61,5 @@ func (l *localCounterPerformer) Inc(ctx context.Context, option ...gmetric.Optio
 
 // Add adds the given value to the counter. It panics if the value is < 0.
 func (l *localCounterPerformer) Add(ctx context.Context, increment float64, option ...gmetric.Option) {
-	l.Float64Counter.Add(ctx, increment, generateAddOptions(l.MeterOption, l.constOption, option...)...)
+	l.Float64Counter.Add(contextWithExemplar(ctx, option...), increment, generateAddOptions(l.MeterOption, l.constOption, option...)...)
 }
@@ -8,6 +8,7 @@ package otelmetric
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/otel/metric"
 
@@ -25,18 +26,37 @@ type localHistogramPerformer struct {
 	constOption metric.MeasurementOption
 }
 
+const (
+	defaultExponentialHistogramMaxSize  = 160
+	defaultExponentialHistogramMaxScale = 20
+)
+
+// exponentialHistograms holds the exponential histogram options of histograms,
+// mapping the key created by exponentialHistogramKey to gmetric.ExponentialHistogramOption.
+var exponentialHistograms sync.Map
+
 // newHistogramPerformer creates and returns a HistogramPerformer that truly takes action to implement Histogram.
 func (l *localMeterPerformer) newHistogramPerformer(
 	meter metric.Meter,
 	metricName string,
 	metricOption gmetric.MetricOption,
 ) (gmetric.HistogramPerformer, error) {
-	histogram, err := meter.Float64Histogram(
-		metricName,
+	histogramOptions := []metric.Float64HistogramOption{
 		metric.WithDescription(metricOption.Help),
 		metric.WithUnit(metricOption.Unit),
-		metric.WithExplicitBucketBoundaries(metricOption.Buckets...),
-	)
+	}
+	if metricOption.ExponentialHistogram != nil {
+		// The exponential aggregation is applied by the view created by createViewForExponentialHistograms,
+		// which should be registered before the histogram creation.
+		exponentialHistograms.Store(
+			exponentialHistogramKey(l.Instrument, metricName), *metricOption.ExponentialHistogram,
+		)
+	} else {
+		histogramOptions = append(
+			histogramOptions, metric.WithExplicitBucketBoundaries(metricOption.Buckets...),
+		)
+	}
+	histogram, err := meter.Float64Histogram(metricName, histogramOptions...)
 	if err != nil {
 		return nil, gerror.WrapCodef(
 			gcode.CodeInternalError,
@@ -54,9 +74,10 @@ func (l *localMeterPerformer) newHistogramPerformer(
 }
 
 // Record adds a single value to the histogram. The value is usually positive or zero.
+// The exemplar in `option` is passed to OpenTelemetry SDK using the context of recording.
 func (l *localHistogramPerformer) Record(increment float64, option ...gmetric.Option) {
 	l.Float64Histogram.Record(
-		context.Background(),
+		contextWithExemplar(context.Background(), option...),
 		increment,
 		l.generateRecordOptions(option...)...,
 	)
@@ -82,3 +103,8 @@ func (l *localHistogramPerformer) generateRecordOptions(option ...gmetric.Option
 	}
 	return recordOptions
 }
+
+// exponentialHistogramKey returns the key of histogram `name` of instrument `instrument`.
+func exponentialHistogramKey(instrument, name string) string {
+	return instrument + "@" + name
+}
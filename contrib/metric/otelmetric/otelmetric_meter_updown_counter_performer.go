@@ -66,5 +66,5 @@ func (l *localUpDownCounterPerformer) Dec(ctx context.Context, option ...gmetric
 
 // Add adds the given value to the counter.
 func (l *localUpDownCounterPerformer) Add(ctx context.Context, increment float64, option ...gmetric.Option) {
-	l.Float64UpDownCounter.Add(ctx, increment, generateAddOptions(l.MeterOption, l.constOption, option...)...)
+	l.Float64UpDownCounter.Add(contextWithExemplar(ctx, option...), increment, generateAddOptions(l.MeterOption, l.constOption, option...)...)
 }
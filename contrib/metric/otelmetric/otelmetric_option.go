@@ -8,6 +8,7 @@ package otelmetric
 
 import (
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -38,6 +39,7 @@ type providerConfig struct {
 	viewOption            metric.Option
 	readerOption          metric.Option
 	resourceOption        metric.Option
+	exemplarOption        metric.Option
 	enabledBuiltInMetrics bool
 }
 
@@ -58,6 +60,9 @@ func (cfg providerConfig) MetricOptions() []metric.Option {
 	if cfg.resourceOption != nil {
 		metricOptions = append(metricOptions, cfg.resourceOption)
 	}
+	if cfg.exemplarOption != nil {
+		metricOptions = append(metricOptions, cfg.exemplarOption)
+	}
 	return metricOptions
 }
 
@@ -106,3 +111,18 @@ func WithView(views ...metric.View) Option {
 		return cfg
 	})
 }
+
+// WithExemplarFilter configures the filter deciding which recordings are offered to the exemplar reservoir.
+//
+// By default, if this option is not used, the exemplars in gmetric.Option are only attached to the
+// recordings in sampled traces, which can also be changed by environment OTEL_METRICS_EXEMPLAR_FILTER.
+// The exemplars can be disabled by using exemplar.AlwaysOffFilter.
+func WithExemplarFilter(filter exemplar.Filter) Option {
+	return optionFunc(func(cfg providerConfig) providerConfig {
+		if filter == nil {
+			return cfg
+		}
+		cfg.exemplarOption = metric.WithExemplarFilter(filter)
+		return cfg
+	})
+}
@@ -86,6 +86,7 @@ func (l *localProvider) MeterPerformer(option gmetric.MeterOption) gmetric.Meter
 // createViewsForBuiltInMetrics creates and returns views for builtin metrics.
 func createViewsForBuiltInMetrics() []metric.View {
 	var views = make([]metric.View, 0)
+	views = append(views, createViewForExponentialHistograms())
 	views = append(views, metric.NewView(
 		metric.Instrument{
 			Name: "process.runtime.go.gc.pause_ns",
@@ -117,6 +118,39 @@ func createViewsForBuiltInMetrics() []metric.View {
 	return views
 }
 
+// createViewForExponentialHistograms creates and returns the view applying base2 exponential aggregation
+// for the histograms configured with gmetric.MetricOption.ExponentialHistogram.
+func createViewForExponentialHistograms() metric.View {
+	return func(instrument metric.Instrument) (metric.Stream, bool) {
+		if instrument.Kind != metric.InstrumentKindHistogram {
+			return metric.Stream{}, false
+		}
+		v, ok := exponentialHistograms.Load(exponentialHistogramKey(instrument.Scope.Name, instrument.Name))
+		if !ok {
+			return metric.Stream{}, false
+		}
+		var (
+			option      = v.(gmetric.ExponentialHistogramOption)
+			aggregation = metric.AggregationBase2ExponentialHistogram{
+				MaxSize:  defaultExponentialHistogramMaxSize,
+				MaxScale: defaultExponentialHistogramMaxScale,
+			}
+		)
+		if option.MaxSize > 0 {
+			aggregation.MaxSize = option.MaxSize
+		}
+		if option.MaxScale > 0 {
+			aggregation.MaxScale = option.MaxScale
+		}
+		return metric.Stream{
+			Name:        instrument.Name,
+			Description: instrument.Description,
+			Unit:        instrument.Unit,
+			Aggregation: aggregation,
+		}, true
+	}
+}
+
 // initializeMetrics initializes all metrics in provider creating.
 // The initialization replaces the underlying metric performer using noop-performer with truly performer
 // that implements operations for types of metric.
@@ -7,8 +7,11 @@
 package otelmetric
 
 import (
+	"context"
+
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -41,6 +44,26 @@ func generateAddOptions(
 	return addOptions
 }
 
+// contextWithExemplar returns a context carrying the span of the exemplar in `option`,
+// which is used by the exemplar reservoir of OpenTelemetry SDK to attach the exemplar to the recording.
+// It returns `ctx` itself if `ctx` already has a valid span or there's no exemplar in `option`.
+func contextWithExemplar(ctx context.Context, option ...gmetric.Option) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(option) == 0 || option[0].Exemplar == nil {
+		return ctx
+	}
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	spanCtx := option[0].Exemplar.SpanContext()
+	if !spanCtx.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, spanCtx)
+}
+
 func getGlobalAttributesOption(option gmetric.GetGlobalAttributesOption) metric.MeasurementOption {
 	var (
 		globalAttributesOption metric.MeasurementOption
@@ -0,0 +1,28 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package otelmetric_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/gogf/gf/contrib/metric/otelmetric/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Bootstrap_OTLP(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gmetric.Bootstrap(ctx, gmetric.ExporterConfig{
+			Protocol: "unknown",
+			Endpoint: "127.0.0.1:4318",
+		})
+		t.AssertNE(err, nil)
+		t.Assert(gmetric.IsEnabled(), false)
+	})
+}
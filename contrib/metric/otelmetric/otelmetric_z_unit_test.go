@@ -8,9 +8,11 @@ package otelmetric_test
 
 import (
 	"context"
+	"encoding/hex"
 	"testing"
 
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/gogf/gf/contrib/metric/otelmetric/v2"
@@ -318,3 +320,161 @@ func Test_GlobalAttributes(t *testing.T) {
 		t.Assert(gstr.Count(metricsJsonContent, `{"Key":"dynamic_label_4","Value":{"Type":"STRING","Value":"4"}}`), 1)
 	})
 }
+
+func Test_HistogramBuckets(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = gctx.New()
+			meter = gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+				Instrument:        "github.com/gogf/gf/example/metric/histogram",
+				InstrumentVersion: "v1.0",
+			})
+			cacheHistogram = meter.MustHistogram(
+				"goframe.metric.demo.cache.duration",
+				gmetric.MetricOption{
+					Unit:    "us",
+					Buckets: []float64{1000, 5000},
+				},
+			)
+			jobHistogram = meter.MustHistogram(
+				"goframe.metric.demo.job.duration",
+				gmetric.MetricOption{
+					Unit: "s",
+					ExponentialHistogram: &gmetric.ExponentialHistogramOption{
+						MaxSize: 20,
+					},
+				},
+			)
+		)
+		gmetric.SetHistogramOption("goframe.metric.demo.cache.duration", gmetric.HistogramOption{
+			Buckets: gmetric.ExponentialBuckets(1, 10, 3),
+		})
+
+		reader := metric.NewManualReader()
+		provider := otelmetric.MustProvider(otelmetric.WithReader(reader))
+		defer provider.Shutdown(ctx)
+
+		t.Assert(cacheHistogram.Buckets(), []float64{1, 10, 100})
+		cacheHistogram.Record(5)
+		cacheHistogram.Record(50)
+		jobHistogram.Record(3)
+		jobHistogram.Record(300)
+
+		rm := metricdata.ResourceMetrics{}
+		err := reader.Collect(ctx, &rm)
+		t.AssertNil(err)
+
+		var (
+			cacheData metricdata.Histogram[float64]
+			jobData   metricdata.ExponentialHistogram[float64]
+		)
+		for _, scopeMetrics := range rm.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				switch m.Name {
+				case "goframe.metric.demo.cache.duration":
+					cacheData = m.Data.(metricdata.Histogram[float64])
+				case "goframe.metric.demo.job.duration":
+					jobData = m.Data.(metricdata.ExponentialHistogram[float64])
+				}
+			}
+		}
+		t.Assert(len(cacheData.DataPoints), 1)
+		t.Assert(cacheData.DataPoints[0].Bounds, []float64{1, 10, 100})
+		t.Assert(cacheData.DataPoints[0].BucketCounts, []uint64{0, 1, 1, 0})
+		t.Assert(len(jobData.DataPoints), 1)
+		t.Assert(jobData.DataPoints[0].Count, 2)
+		t.Assert(jobData.DataPoints[0].Sum, 303)
+		t.Assert(len(jobData.DataPoints[0].PositiveBucket.Counts) <= 20, true)
+	})
+}
+
+func Test_Histogram_Exemplar(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = gctx.New()
+			meter = gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+				Instrument:        "github.com/gogf/gf/example/metric/exemplar",
+				InstrumentVersion: "v1.0",
+			})
+			histogram = meter.MustHistogram(
+				"goframe.metric.demo.exemplar.duration",
+				gmetric.MetricOption{
+					Unit:    "ms",
+					Buckets: []float64{10, 100},
+				},
+			)
+			exemplar = &gmetric.Exemplar{
+				TraceId: "4bf92f3577b34da6a3ce929d0e0e4736",
+				SpanId:  "00f067aa0ba902b7",
+			}
+		)
+		reader := metric.NewManualReader()
+		provider := otelmetric.MustProvider(otelmetric.WithReader(reader))
+		defer provider.Shutdown(ctx)
+
+		histogram.Record(50, gmetric.Option{Exemplar: exemplar})
+
+		rm := metricdata.ResourceMetrics{}
+		err := reader.Collect(ctx, &rm)
+		t.AssertNil(err)
+
+		var data metricdata.Histogram[float64]
+		for _, scopeMetrics := range rm.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				if m.Name == "goframe.metric.demo.exemplar.duration" {
+					data = m.Data.(metricdata.Histogram[float64])
+				}
+			}
+		}
+		t.Assert(len(data.DataPoints), 1)
+		t.Assert(len(data.DataPoints[0].Exemplars), 1)
+		t.Assert(data.DataPoints[0].Exemplars[0].Value, 50)
+		t.Assert(hex.EncodeToString(data.DataPoints[0].Exemplars[0].TraceID), exemplar.TraceId)
+		t.Assert(hex.EncodeToString(data.DataPoints[0].Exemplars[0].SpanID), exemplar.SpanId)
+	})
+}
+
+func Test_Histogram_Exemplar_Filter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = gctx.New()
+			meter = gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+				Instrument:        "github.com/gogf/gf/example/metric/exemplar/filter",
+				InstrumentVersion: "v1.0",
+			})
+			histogram = meter.MustHistogram(
+				"goframe.metric.demo.exemplar.filter.duration",
+				gmetric.MetricOption{
+					Unit:    "ms",
+					Buckets: []float64{10, 100},
+				},
+			)
+		)
+		reader := metric.NewManualReader()
+		provider := otelmetric.MustProvider(
+			otelmetric.WithReader(reader),
+			otelmetric.WithExemplarFilter(exemplar.AlwaysOffFilter),
+		)
+		defer provider.Shutdown(ctx)
+
+		histogram.Record(50, gmetric.Option{Exemplar: &gmetric.Exemplar{
+			TraceId: "4bf92f3577b34da6a3ce929d0e0e4736",
+			SpanId:  "00f067aa0ba902b7",
+		}})
+
+		rm := metricdata.ResourceMetrics{}
+		err := reader.Collect(ctx, &rm)
+		t.AssertNil(err)
+
+		var data metricdata.Histogram[float64]
+		for _, scopeMetrics := range rm.ScopeMetrics {
+			for _, m := range scopeMetrics.Metrics {
+				if m.Name == "goframe.metric.demo.exemplar.filter.duration" {
+					data = m.Data.(metricdata.Histogram[float64])
+				}
+			}
+		}
+		t.Assert(len(data.DataPoints), 1)
+		t.Assert(len(data.DataPoints[0].Exemplars), 0)
+	})
+}
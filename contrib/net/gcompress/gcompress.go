@@ -0,0 +1,57 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gcompress provides the brotli and zstd encoders for the compression middleware of ghttp,
+// which are registered automatically by importing this package.
+package gcompress
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+const (
+	// DefaultBrotliLevel is the brotli compression level in default, which balances the speed and ratio
+	// for dynamic content.
+	DefaultBrotliLevel = 4
+
+	// DefaultZstdLevel is the zstd compression level in default.
+	DefaultZstdLevel = zstd.SpeedDefault
+)
+
+func init() {
+	ghttp.RegisterCompressEncoder(ghttp.CompressEncodingBrotli, NewBrotliEncoder(DefaultBrotliLevel))
+	ghttp.RegisterCompressEncoder(ghttp.CompressEncodingZstd, NewZstdEncoder(DefaultZstdLevel))
+}
+
+// NewBrotliEncoder returns the provider of brotli encoder with compression `level` from 0 to 11,
+// which can be registered to replace the default one using ghttp.RegisterCompressEncoder.
+func NewBrotliEncoder(level int) ghttp.CompressEncoderProvider {
+	return func(w io.Writer) (ghttp.CompressEncoder, error) {
+		return brotli.NewWriterLevel(w, level), nil
+	}
+}
+
+// NewZstdEncoder returns the provider of zstd encoder with compression `level`,
+// which can be registered to replace the default one using ghttp.RegisterCompressEncoder.
+func NewZstdEncoder(level zstd.EncoderLevel) ghttp.CompressEncoderProvider {
+	return func(w io.Writer) (ghttp.CompressEncoder, error) {
+		encoder, err := zstd.NewWriter(
+			w,
+			zstd.WithEncoderLevel(level),
+			// The content is compressed synchronously in the serving goroutine.
+			zstd.WithEncoderConcurrency(1),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return encoder, nil
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+
+	_ "github.com/gogf/gf/contrib/net/gcompress/v2"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_MiddlewareCompress(t *testing.T) {
+	var content = gstr.Repeat("hello world ", 1000)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompress())
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "text/plain")
+			r.Response.Write(content)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Header(g.MapStrStr{
+			"Accept-Encoding": "gzip, br",
+		}).Get(ctx, "/")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Encoding"), "br")
+		b, err := io.ReadAll(brotli.NewReader(resp.Body))
+		t.AssertNil(err)
+		t.Assert(string(b), content)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Header(g.MapStrStr{
+			"Accept-Encoding": "gzip, br, zstd",
+		}).Get(ctx, "/")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Encoding"), "zstd")
+		decoder, err := zstd.NewReader(resp.Body)
+		t.AssertNil(err)
+		defer decoder.Close()
+		b, err := io.ReadAll(decoder)
+		t.AssertNil(err)
+		t.Assert(string(b), content)
+	})
+}
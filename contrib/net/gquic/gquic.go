@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gquic provides QUIC server and client implementations with the familiar
+// connection API of package gtcp.
+//
+// Each QUIC stream is wrapped as a gtcp.Conn, so that the data sending/receiving, simple
+// package protocol, message codecs and asynchronous send queue of gtcp are all available
+// for streams.
+package gquic
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+const (
+	// FreePortAddress marks the server listens using random free port.
+	FreePortAddress = ":0"
+
+	// DefaultNextProto is the ALPN protocol used if no NextProtos configured in TLS configuration.
+	DefaultNextProto = "gf-quic"
+)
+
+// Config is the configuration for QUIC server and client.
+type Config struct {
+	// EnableEarlyData enables 0-RTT data, which reduces the handshake latency for resumed
+	// connections. Note that 0-RTT data may be replayed by attackers, so it should be used only
+	// for idempotent operations.
+	EnableEarlyData bool
+
+	// HandshakeTimeout is the idle timeout before completion of the handshake. Default is 5 seconds.
+	HandshakeTimeout time.Duration
+
+	// MaxIdleTimeout is the max duration without any network activity before closing the connection.
+	// Default is 30 seconds.
+	MaxIdleTimeout time.Duration
+
+	// KeepAlivePeriod specifies the period of keep-alive packets. Zero disables keep-alive.
+	KeepAlivePeriod time.Duration
+}
+
+// quicConfig converts and returns the configuration for underlying QUIC implementation.
+func (c Config) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: c.HandshakeTimeout,
+		MaxIdleTimeout:       c.MaxIdleTimeout,
+		KeepAlivePeriod:      c.KeepAlivePeriod,
+		Allow0RTT:            c.EnableEarlyData,
+	}
+}
+
+// getConfig returns the configuration from optional parameter.
+func getConfig(config ...Config) Config {
+	if len(config) > 0 {
+		return config[0]
+	}
+	return Config{}
+}
+
+// prepareTLSConfig returns a copy of `tlsConfig` that is usable for QUIC,
+// which requires TLS 1.3 and ALPN protocols.
+func prepareTLSConfig(tlsConfig *tls.Config) *tls.Config {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	if len(tlsConfig.NextProtos) == 0 {
+		tlsConfig.NextProtos = []string{DefaultNextProto}
+	}
+	if tlsConfig.MinVersion < tls.VersionTLS13 {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+	return tlsConfig
+}
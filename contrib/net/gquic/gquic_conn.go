@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gquic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtcp"
+)
+
+// Conn is the QUIC connection, which multiplexes multiple streams.
+type Conn struct {
+	quic.Connection // Underlying QUIC connection.
+}
+
+// Stream is a bidirectional QUIC stream, which provides the same data sending/receiving
+// API as gtcp.Conn.
+type Stream struct {
+	*gtcp.Conn             // Connection API for the stream.
+	stream     quic.Stream // Underlying QUIC stream.
+}
+
+// streamNetConn adapts quic.Stream to net.Conn.
+type streamNetConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var (
+	// defaultClientSessionCache is the TLS session cache shared by client connections,
+	// which is required for session resumption and 0-RTT.
+	defaultClientSessionCache = tls.NewLRUClientSessionCache(0)
+)
+
+// NewConn dials and returns a QUIC connection to `address` like "127.0.0.1:8000".
+//
+// If Config.EnableEarlyData is true, the streams opened before the handshake completes send
+// data in 0-RTT for resumed connections.
+func NewConn(ctx context.Context, address string, tlsConfig *tls.Config, config ...Config) (*Conn, error) {
+	var (
+		c          = getConfig(config...)
+		conn       quic.Connection
+		err        error
+		clientConf = prepareTLSConfig(tlsConfig)
+	)
+	if clientConf.ClientSessionCache == nil {
+		clientConf.ClientSessionCache = defaultClientSessionCache
+	}
+	if c.EnableEarlyData {
+		conn, err = quic.DialAddrEarly(ctx, address, clientConf, c.quicConfig())
+	} else {
+		conn, err = quic.DialAddr(ctx, address, clientConf, c.quicConfig())
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `dial QUIC connection failed for address "%s"`, address)
+	}
+	return &Conn{Connection: conn}, nil
+}
+
+// OpenStream opens a new bidirectional stream, which blocks until the stream can be opened
+// or `ctx` is done.
+func (c *Conn) OpenStream(ctx context.Context) (*Stream, error) {
+	stream, err := c.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, `open QUIC stream failed`)
+	}
+	return newStream(c.Connection, stream), nil
+}
+
+// AcceptStream blocks until the remote peer opens a stream or `ctx` is done.
+func (c *Conn) AcceptStream(ctx context.Context) (*Stream, error) {
+	stream, err := c.Connection.AcceptStream(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, `accept QUIC stream failed`)
+	}
+	return newStream(c.Connection, stream), nil
+}
+
+// Used0RTT checks and returns whether the connection used 0-RTT data.
+func (c *Conn) Used0RTT() bool {
+	return c.ConnectionState().Used0RTT
+}
+
+// Close closes the connection and all its streams.
+func (c *Conn) Close() error {
+	return c.CloseWithError(0, "")
+}
+
+// StreamID returns the id of the stream.
+func (s *Stream) StreamID() int64 {
+	return int64(s.stream.StreamID())
+}
+
+// newStream creates and returns a Stream.
+func newStream(conn quic.Connection, stream quic.Stream) *Stream {
+	return &Stream{
+		Conn: gtcp.NewConnByNetConn(&streamNetConn{
+			Stream: stream,
+			conn:   conn,
+		}),
+		stream: stream,
+	}
+}
+
+// LocalAddr implements interface net.Conn.
+func (c *streamNetConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr implements interface net.Conn.
+func (c *streamNetConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close implements interface net.Conn, which closes both directions of the stream.
+func (c *streamNetConn) Close() error {
+	c.Stream.CancelRead(0)
+	return c.Stream.Close()
+}
@@ -0,0 +1,59 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gquic
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+func init() {
+	// It registers the HTTP/3 transport for gclient.ProtocolHTTP3.
+	gclient.RegisterHTTP3Transport(func(tlsConfig *tls.Config) http.RoundTripper {
+		return NewHTTP3Transport(tlsConfig)
+	})
+	// It registers the HTTP/3 server for ghttp.Server.EnableHTTP3.
+	ghttp.RegisterHTTP3Server(func(handler http.Handler, tlsConfig *tls.Config) ghttp.HTTP3Server {
+		return NewHTTP3Server(handler, tlsConfig)
+	})
+}
+
+// NewHTTP3Server creates and returns an HTTP/3 server over QUIC serving `handler`,
+// which serves on the UDP connection passed to its Serve method.
+func NewHTTP3Server(handler http.Handler, tlsConfig *tls.Config, config ...Config) *http3.Server {
+	var c = getConfig(config...)
+	if tlsConfig != nil {
+		// The ALPN protocol of HTTP/3 is set by the server.
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.NextProtos = nil
+	}
+	return &http3.Server{
+		Handler:    handler,
+		TLSConfig:  tlsConfig,
+		QUICConfig: c.quicConfig(),
+	}
+}
+
+// NewHTTP3Transport creates and returns an HTTP/3 round tripper over QUIC,
+// which can be used as the Transport of http.Client.
+func NewHTTP3Transport(tlsConfig *tls.Config, config ...Config) *http3.Transport {
+	var c = getConfig(config...)
+	if tlsConfig != nil {
+		// The ALPN protocol of HTTP/3 is set by the transport.
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.NextProtos = nil
+	}
+	return &http3.Transport{
+		TLSClientConfig: tlsConfig,
+		QUICConfig:      c.quicConfig(),
+	}
+}
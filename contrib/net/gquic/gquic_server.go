@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gquic
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// Server is a QUIC server.
+type Server struct {
+	mu            sync.Mutex         // Used for Server.listener concurrent safety.
+	listener      quicListener       // QUIC listener.
+	address       string             // Server listening address.
+	tlsConfig     *tls.Config        // TLS configuration, which is required for QUIC.
+	config        Config             // QUIC configuration.
+	handler       func(*Conn)        // Connection handler.
+	streamHandler func(*Stream)      // Stream handler, which handles each stream of connections.
+	cancel        context.CancelFunc // Cancels the accepting of connections and streams.
+}
+
+// quicListener is the common interface of quic.Listener and quic.EarlyListener.
+type quicListener interface {
+	Close() error
+	Addr() net.Addr
+}
+
+// NewServer creates and returns a new QUIC server listening on `address`.
+// The parameter `tlsConfig` is required for QUIC, which should contain the server certificates.
+func NewServer(address string, tlsConfig *tls.Config, config ...Config) *Server {
+	return &Server{
+		address:   address,
+		tlsConfig: tlsConfig,
+		config:    getConfig(config...),
+	}
+}
+
+// SetHandler sets the connection handler for server, which handles the streams of
+// connection by itself.
+func (s *Server) SetHandler(handler func(conn *Conn)) {
+	s.handler = handler
+}
+
+// SetStreamHandler sets the stream handler for server, the server accepts streams of each
+// connection and calls the handler in new goroutine for each stream.
+// It is ignored if connection handler is set using SetHandler.
+func (s *Server) SetStreamHandler(handler func(stream *Stream)) {
+	s.streamHandler = handler
+}
+
+// Run starts running the QUIC server, which blocks until the server is closed.
+func (s *Server) Run() (err error) {
+	if s.handler == nil && s.streamHandler == nil {
+		return gerror.NewCode(gcode.CodeMissingConfiguration, "start running failed: handler not defined")
+	}
+	if s.tlsConfig == nil {
+		return gerror.NewCode(gcode.CodeMissingConfiguration, "start running failed: TLS configuration not defined")
+	}
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		tlsConfig   = prepareTLSConfig(s.tlsConfig)
+		accept      func(ctx context.Context) (quic.Connection, error)
+	)
+	defer cancel()
+	s.mu.Lock()
+	if s.config.EnableEarlyData {
+		var listener *quic.EarlyListener
+		if listener, err = quic.ListenAddrEarly(s.address, tlsConfig, s.config.quicConfig()); err == nil {
+			s.listener = listener
+			accept = func(ctx context.Context) (quic.Connection, error) {
+				return listener.Accept(ctx)
+			}
+		}
+	} else {
+		var listener *quic.Listener
+		if listener, err = quic.ListenAddr(s.address, tlsConfig, s.config.quicConfig()); err == nil {
+			s.listener = listener
+			accept = listener.Accept
+		}
+	}
+	s.cancel = cancel
+	s.mu.Unlock()
+	if err != nil {
+		return gerror.Wrapf(err, `QUIC listen failed for address "%s"`, s.address)
+	}
+	for {
+		conn, err := accept(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return gerror.Wrap(err, `QUIC Listener.Accept failed`)
+		}
+		go s.handleConn(ctx, &Conn{Connection: conn})
+	}
+}
+
+// handleConn handles the accepted connection.
+func (s *Server) handleConn(ctx context.Context, conn *Conn) {
+	if s.handler != nil {
+		s.handler(conn)
+		return
+	}
+	for {
+		stream, err := conn.AcceptStream(ctx)
+		if err != nil {
+			return
+		}
+		go s.streamHandler(stream)
+	}
+}
+
+// Close closes the listener and shutdowns the server.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+// GetListenedAddress retrieves and returns the address string which are listened by current server.
+func (s *Server) GetListenedAddress() string {
+	if !gstr.Contains(s.address, FreePortAddress) {
+		return s.address
+	}
+	return gstr.Replace(s.address, FreePortAddress, fmt.Sprintf(`:%d`, s.GetListenedPort()))
+}
+
+// GetListenedPort retrieves and returns one port which is listened to by current server.
+func (s *Server) GetListenedPort() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener != nil {
+		return s.listener.Addr().(*net.UDPAddr).Port
+	}
+	return -1
+}
@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gquic_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_HTTP3(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &http3.Server{
+		TLSConfig: http3.ConfigureTLSConfig(newServerTLSConfig()),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto))
+		}),
+	}
+	go s.Serve(conn)
+	defer s.Close()
+
+	var (
+		ctx = context.Background()
+		url = fmt.Sprintf("https://%s/proto", conn.LocalAddr().String())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Protocol(gclient.ProtocolHTTP3)
+		t.Assert(client.GetContent(ctx, url), "HTTP/3.0")
+
+		resp, err := client.Get(ctx, url)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Proto, "HTTP/3.0")
+	})
+}
+
+func Test_Server_HTTP3(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/proto", func(r *ghttp.Request) {
+		r.Response.Write(r.Proto)
+	})
+	s.SetTLSConfig(newServerTLSConfig())
+	s.EnableHTTP3(true)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx = context.Background()
+		url = fmt.Sprintf("https://127.0.0.1:%d/proto", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Get(ctx, url)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "HTTP/1.1")
+		t.Assert(resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%d"; ma=86400`, s.GetListenedPort()))
+
+		t.Assert(g.Client().Protocol(gclient.ProtocolHTTP3).GetContent(ctx, url), "HTTP/3.0")
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gquic_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/contrib/net/gquic/v2"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func newServerTLSConfig() *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
+
+func startEchoServer(config ...gquic.Config) *gquic.Server {
+	s := gquic.NewServer("127.0.0.1:0", newServerTLSConfig(), config...)
+	s.SetStreamHandler(func(stream *gquic.Stream) {
+		defer stream.Close()
+		for {
+			data, err := stream.RecvPkg()
+			if err != nil {
+				break
+			}
+			stream.SendPkg(data)
+		}
+	})
+	go s.Run()
+	time.Sleep(100 * time.Millisecond)
+	return s
+}
+
+func Test_Stream_SendRecv(t *testing.T) {
+	s := startEchoServer()
+	defer s.Close()
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		conn, err := gquic.NewConn(ctx, s.GetListenedAddress(), &tls.Config{InsecureSkipVerify: true})
+		t.AssertNil(err)
+		defer conn.Close()
+
+		stream1, err := conn.OpenStream(ctx)
+		t.AssertNil(err)
+		stream2, err := conn.OpenStream(ctx)
+		t.AssertNil(err)
+		t.AssertNE(stream1.StreamID(), stream2.StreamID())
+
+		result, err := stream1.SendRecvPkg([]byte("hello"))
+		t.AssertNil(err)
+		t.Assert(result, "hello")
+		result, err = stream2.SendRecvPkg([]byte("world"))
+		t.AssertNil(err)
+		t.Assert(result, "world")
+		t.AssertNil(stream1.Close())
+	})
+}
+
+func Test_Conn_Handler(t *testing.T) {
+	s := gquic.NewServer("127.0.0.1:0", newServerTLSConfig())
+	s.SetHandler(func(conn *gquic.Conn) {
+		// The server opens stream to client.
+		stream, err := conn.OpenStream(context.Background())
+		if err != nil {
+			return
+		}
+		stream.SendPkg([]byte("welcome"))
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		conn, err := gquic.NewConn(ctx, s.GetListenedAddress(), &tls.Config{InsecureSkipVerify: true})
+		t.AssertNil(err)
+		defer conn.Close()
+		stream, err := conn.AcceptStream(ctx)
+		t.AssertNil(err)
+		data, err := stream.RecvPkg()
+		t.AssertNil(err)
+		t.Assert(data, "welcome")
+	})
+}
+
+func Test_EarlyData(t *testing.T) {
+	config := gquic.Config{EnableEarlyData: true}
+	s := startEchoServer(config)
+	defer s.Close()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx       = context.Background()
+			tlsConfig = &tls.Config{
+				InsecureSkipVerify: true,
+				ClientSessionCache: tls.NewLRUClientSessionCache(1),
+			}
+		)
+		for i := 0; i < 2; i++ {
+			conn, err := gquic.NewConn(ctx, s.GetListenedAddress(), tlsConfig, config)
+			t.AssertNil(err)
+			stream, err := conn.OpenStream(ctx)
+			t.AssertNil(err)
+			result, err := stream.SendRecvPkg([]byte("hello"))
+			t.AssertNil(err)
+			t.Assert(result, "hello")
+			// The session ticket is received after the first connection, the second one resumes with 0-RTT.
+			if i == 1 {
+				t.Assert(conn.Used0RTT(), true)
+			}
+			conn.Close()
+		}
+	})
+}
+
+func Test_Server_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gquic.NewServer("127.0.0.1:0", nil)
+		t.AssertNE(s.Run(), nil)
+		s.SetStreamHandler(func(stream *gquic.Stream) {})
+		t.AssertNE(s.Run(), nil)
+		t.Assert(s.GetListenedPort(), -1)
+		t.AssertNil(s.Close())
+	})
+}
@@ -266,11 +266,12 @@ func (c *Conn) traceSpanEnd(ctx context.Context, span trace.Span, item *traceIte
 		attribute.String(traceAttrRedisAddress, c.redis.config.Address),
 		attribute.Int(traceAttrRedisDb, c.redis.config.Db),
 	)
+	span.SetAttributes(gtrace.PeerAttributes(c.redis.config.Address)...)
 
 	jsonBytes, _ := gjson.Marshal(item.args)
 	span.AddEvent(traceEventRedisExecution, trace.WithAttributes(
 		attribute.String(traceEventRedisExecutionCommand, item.command),
 		attribute.String(traceEventRedisExecutionCost, fmt.Sprintf(`%d ms`, item.costMilli)),
-		attribute.String(traceEventRedisExecutionArguments, string(jsonBytes)),
+		attribute.String(traceEventRedisExecutionArguments, gtrace.Redact(string(jsonBytes))),
 	))
 }
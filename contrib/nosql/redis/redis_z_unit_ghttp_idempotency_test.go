@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_IdempotencyStorageRedis(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key     = guid.S()
+			storage = ghttp.NewIdempotencyStorageRedis(redis)
+		)
+		defer redis.Del(ctx, ghttp.DefaultIdempotencyStorageRedisPrefix+key)
+
+		record, locked, err := storage.Lock(ctx, key, time.Second)
+		t.AssertNil(err)
+		t.AssertNil(record)
+		t.Assert(locked, true)
+
+		// Locked by another request.
+		record, locked, err = storage.Lock(ctx, key, time.Second)
+		t.AssertNil(err)
+		t.AssertNil(record)
+		t.Assert(locked, false)
+
+		err = storage.Save(ctx, key, &ghttp.IdempotencyRecord{
+			Fingerprint: "fingerprint",
+			Status:      http.StatusCreated,
+			Header:      http.Header{"X-Order": []string{"1"}},
+			Body:        []byte("order 1"),
+		}, time.Minute)
+		t.AssertNil(err)
+
+		record, locked, err = storage.Lock(ctx, key, time.Second)
+		t.AssertNil(err)
+		t.Assert(locked, false)
+		t.Assert(record.Fingerprint, "fingerprint")
+		t.Assert(record.Status, http.StatusCreated)
+		t.Assert(record.Header.Get("X-Order"), "1")
+		t.Assert(record.Body, "order 1")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key     = guid.S()
+			storage = ghttp.NewIdempotencyStorageRedis(redis, "IdempotencyTest:")
+		)
+		_, locked, err := storage.Lock(ctx, key, time.Second)
+		t.AssertNil(err)
+		t.Assert(locked, true)
+		t.AssertNil(storage.Unlock(ctx, key))
+
+		// Retried after unlocking.
+		_, locked, err = storage.Lock(ctx, key, time.Second)
+		t.AssertNil(err)
+		t.Assert(locked, true)
+		t.AssertNil(storage.Unlock(ctx, key))
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_RateLimitStorageRedis_TokenBucket(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key     = guid.S()
+			storage = ghttp.NewRateLimitStorageRedis(redis)
+			rule    = ghttp.RateLimitRule{
+				Algorithm: ghttp.RateLimitTokenBucket,
+				Limit:     10,
+				Window:    time.Second,
+				Burst:     2,
+			}
+		)
+		defer redis.Del(ctx, ghttp.DefaultRateLimitStorageRedisPrefix+key)
+
+		result, err := storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Limit, 2)
+		t.Assert(result.Remaining, 1)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, false)
+		t.AssertGT(result.RetryAfter, 0)
+		t.AssertLE(result.RetryAfter, 100*time.Millisecond)
+
+		time.Sleep(result.RetryAfter + 10*time.Millisecond)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+	})
+}
+
+func Test_RateLimitStorageRedis_SlidingWindow(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key     = guid.S()
+			storage = ghttp.NewRateLimitStorageRedis(redis, "RateLimitTest:")
+			rule    = ghttp.RateLimitRule{
+				Algorithm: ghttp.RateLimitSlidingWindow,
+				Limit:     2,
+				Window:    200 * time.Millisecond,
+			}
+		)
+		defer redis.Del(ctx, "RateLimitTest:"+key)
+
+		result, err := storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 1)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, false)
+		t.AssertGT(result.RetryAfter, 0)
+		t.AssertLE(result.RetryAfter, 200*time.Millisecond)
+
+		time.Sleep(result.RetryAfter + 10*time.Millisecond)
+		result, err = storage.Allow(ctx, key, rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpcx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// GatewayRoute is the REST route transcoded to a unary gRPC method.
+type GatewayRoute struct {
+	// Pattern is the route pattern of ghttp like "POST:/v1/users/{id}".
+	Pattern string
+
+	// Method is the full name of gRPC method like "/helloworld.Greeter/SayHello".
+	Method string
+}
+
+// gatewayFrameHeaderLength is the length of the compressed flag and message length prefixing gRPC message.
+const gatewayFrameHeaderLength = 5
+
+// gatewayHttpStatusMap is the HTTP statuses of gRPC codes for transcoded REST routes.
+var gatewayHttpStatusMap = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// gatewayResponseWriter is the in-memory response writer for the gRPC requests of transcoded REST routes.
+type gatewayResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+// Gateway serves the gRPC requests of current server on the listeners of `httpServer`, so that the gRPC
+// and REST APIs share the same port. The gRPC requests are distinguished by their content type.
+//
+// The optional parameter `routes` specifies the REST routes transcoded to the unary gRPC methods, whose request
+// messages are created from the JSON body, query and router parameters, and the response messages are written
+// as JSON. The routes are declared explicitly, as the google.api.http annotations of proto files are not read.
+//
+// The transcoded calls are served in process by the underlying gRPC server, so they run through the same
+// interceptors and stats handlers as the gRPC requests, including the ones of grpc.UnaryInterceptor.
+//
+// Note that it should be called before `httpServer` starts, and it's not necessary to start current server.
+func (s *GrpcServer) Gateway(httpServer *ghttp.Server, routes ...GatewayRoute) error {
+	for _, route := range routes {
+		handler, err := s.newGatewayHandler(route.Method)
+		if err != nil {
+			return err
+		}
+		httpServer.BindHandler(route.Pattern, handler)
+	}
+	httpServer.SetGrpcHandler(s.Server)
+	return nil
+}
+
+// newGatewayHandler creates and returns the handler of REST route transcoded to gRPC method `fullMethod`.
+func (s *GrpcServer) newGatewayHandler(fullMethod string) (ghttp.HandlerFunc, error) {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid gRPC method "%s", it should be like "/package.Service/Method"`,
+			fullMethod,
+		)
+	}
+	serviceInfo, ok := s.Server.GetServiceInfo()[serviceName]
+	if !ok {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `gRPC service "%s" is not registered`, serviceName,
+		)
+	}
+	var found bool
+	for _, method := range serviceInfo.Methods {
+		if method.Name == methodName && !method.IsClientStream && !method.IsServerStream {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`unary gRPC method "%s" not found, note that stream methods cannot be transcoded`,
+			fullMethod,
+		)
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, gerror.WrapCodef(
+			gcode.CodeInvalidParameter, err, `descriptor of gRPC service "%s" not found`, serviceName,
+		)
+	}
+	methodDesc := descriptor.(protoreflect.ServiceDescriptor).Methods().ByName(protoreflect.Name(methodName))
+	return func(r *ghttp.Request) {
+		var (
+			in  = dynamicpb.NewMessage(methodDesc.Input())
+			out = dynamicpb.NewMessage(methodDesc.Output())
+		)
+		if err := decodeGatewayRequest(r, in); err != nil {
+			writeGatewayError(r, err)
+			return
+		}
+		if err := s.invokeGateway(r, fullMethod, in, out); err != nil {
+			writeGatewayError(r, err)
+			return
+		}
+		content, err := protojson.Marshal(out)
+		if err != nil {
+			writeGatewayError(r, err)
+			return
+		}
+		r.Response.Header().Set("Content-Type", "application/json")
+		r.Response.Write(content)
+	}, nil
+}
+
+// invokeGateway invokes gRPC method `fullMethod` of request `r` with message `in` by the underlying gRPC server
+// in process, and decodes the response message to `out`. The headers of `r` are passed as the incoming metadata.
+func (s *GrpcServer) invokeGateway(r *ghttp.Request, fullMethod string, in, out proto.Message) error {
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	// The message is framed with the compressed flag and the length as gRPC over HTTP/2.
+	var body = make([]byte, gatewayFrameHeaderLength+len(data))
+	binary.BigEndian.PutUint32(body[1:gatewayFrameHeaderLength], uint32(len(data)))
+	copy(body[gatewayFrameHeaderLength:], data)
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, fullMethod, bytes.NewReader(body))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2.0", 2, 0
+	req.Host = r.Host
+	req.RemoteAddr = r.RemoteAddr
+	req.Header = r.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", "application/grpc")
+	otel.GetTextMapPropagator().Inject(r.Context(), propagation.HeaderCarrier(req.Header))
+
+	writer := &gatewayResponseWriter{header: make(http.Header)}
+	s.Server.ServeHTTP(writer, req)
+	switch code := writer.header.Get("Grpc-Status"); code {
+	case "":
+		return status.Error(codes.Unavailable, "gRPC server is not serving")
+	case "0":
+	default:
+		message := writer.header.Get("Grpc-Message")
+		if v, err := url.PathUnescape(message); err == nil {
+			message = v
+		}
+		return status.Error(codes.Code(gconv.Uint32(code)), message)
+	}
+	payload := writer.body.Bytes()
+	if len(payload) < gatewayFrameHeaderLength || payload[0] != 0 {
+		return status.Error(codes.Internal, "invalid or compressed gRPC response message")
+	}
+	length := int(binary.BigEndian.Uint32(payload[1:gatewayFrameHeaderLength]))
+	if len(payload) < gatewayFrameHeaderLength+length {
+		return status.Error(codes.Internal, "incomplete gRPC response message")
+	}
+	if err = proto.Unmarshal(payload[gatewayFrameHeaderLength:gatewayFrameHeaderLength+length], out); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// Header implements the interface http.ResponseWriter.
+func (w *gatewayResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write implements the interface http.ResponseWriter.
+func (w *gatewayResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// WriteHeader implements the interface http.ResponseWriter.
+func (w *gatewayResponseWriter) WriteHeader(int) {}
+
+// Flush implements the interface http.Flusher, which is required by the gRPC server.
+func (w *gatewayResponseWriter) Flush() {}
+
+// decodeGatewayRequest decodes the request of `r` to `message` from the JSON body,
+// then the query and router parameters in order, in which the latter overwrites the former.
+func decodeGatewayRequest(r *ghttp.Request, message proto.Message) error {
+	if body := r.GetBody(); len(body) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, message); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	var reflectMessage = message.ProtoReflect()
+	for key, value := range r.GetQueryMap() {
+		setGatewayField(reflectMessage, key, gconv.String(value))
+	}
+	for key, value := range r.GetRouterMap() {
+		setGatewayField(reflectMessage, key, value)
+	}
+	return nil
+}
+
+// setGatewayField sets the scalar field `name` of `message` with `value`, which is ignored if the field
+// does not exist or is not a scalar one.
+func setGatewayField(message protoreflect.Message, name, value string) {
+	var (
+		fields = message.Descriptor().Fields()
+		field  = fields.ByJSONName(name)
+	)
+	if field == nil {
+		field = fields.ByName(protoreflect.Name(name))
+	}
+	if field == nil || field.IsList() || field.IsMap() {
+		return
+	}
+	var fieldValue protoreflect.Value
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		fieldValue = protoreflect.ValueOfBool(gconv.Bool(value))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		fieldValue = protoreflect.ValueOfInt32(gconv.Int32(value))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		fieldValue = protoreflect.ValueOfInt64(gconv.Int64(value))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		fieldValue = protoreflect.ValueOfUint32(gconv.Uint32(value))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		fieldValue = protoreflect.ValueOfUint64(gconv.Uint64(value))
+	case protoreflect.FloatKind:
+		fieldValue = protoreflect.ValueOfFloat32(gconv.Float32(value))
+	case protoreflect.DoubleKind:
+		fieldValue = protoreflect.ValueOfFloat64(gconv.Float64(value))
+	case protoreflect.StringKind:
+		fieldValue = protoreflect.ValueOfString(value)
+	case protoreflect.BytesKind:
+		fieldValue = protoreflect.ValueOfBytes([]byte(value))
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			fieldValue = protoreflect.ValueOfEnum(enumValue.Number())
+		} else {
+			fieldValue = protoreflect.ValueOfEnum(protoreflect.EnumNumber(gconv.Int32(value)))
+		}
+	default:
+		return
+	}
+	message.Set(field, fieldValue)
+}
+
+// writeGatewayError writes `err` as JSON with the HTTP status mapped from its gRPC code.
+func writeGatewayError(r *ghttp.Request, err error) {
+	var (
+		grpcStatus, _ = status.FromError(err)
+		httpStatus    = gatewayHttpStatusMap[grpcStatus.Code()]
+	)
+	if httpStatus == 0 {
+		httpStatus = http.StatusInternalServerError
+	}
+	r.Response.WriteStatus(httpStatus)
+	r.Response.ClearBuffer()
+	r.Response.WriteJson(map[string]interface{}{
+		"code":    int(grpcStatus.Code()),
+		"message": grpcStatus.Message(),
+	})
+}
@@ -44,7 +44,10 @@ func (s modServer) UnaryError(
 	res, err := handler(ctx, req)
 	if err != nil {
 		code := gerror.Code(err)
-		if code.Code() != -1 {
+		if grpcCode, ok := gcode.GrpcCode(code); ok {
+			// The registered code is mapped to its gRPC code.
+			err = status.Error(codes.Code(grpcCode), err.Error())
+		} else if code.Code() != -1 {
 			err = status.Error(codes.Code(code.Code()), err.Error())
 		}
 	}
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpcx_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gogf/gf/contrib/rpc/grpcx/v2"
+	"github.com/gogf/gf/contrib/rpc/grpcx/v2/testdata/controller"
+	"github.com/gogf/gf/contrib/rpc/grpcx/v2/testdata/protobuf"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Grpcx_Gateway(t *testing.T) {
+	var (
+		ctx        = gctx.New()
+		grpcServer = grpcx.Server.New()
+		httpServer = g.Server(guid.S())
+	)
+	controller.Register(grpcServer)
+	httpServer.BindHandler("/ping", func(r *ghttp.Request) {
+		r.Response.Write("pong")
+	})
+	err := grpcServer.Gateway(httpServer,
+		grpcx.GatewayRoute{Pattern: "POST:/v1/hello", Method: "/protobuf.Greeter/SayHello"},
+		grpcx.GatewayRoute{Pattern: "GET:/v1/hello/{name}", Method: "/protobuf.Greeter/SayHello"},
+	)
+	gtest.AssertNil(err)
+	httpServer.SetDumpRouterMap(false)
+	httpServer.Start()
+	defer httpServer.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	address := fmt.Sprintf("127.0.0.1:%d", httpServer.GetListenedPort())
+
+	// gRPC over h2c.
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		t.AssertNil(err)
+		defer conn.Close()
+		res, err := protobuf.NewGreeterClient(conn).SayHello(ctx, &protobuf.HelloRequest{Name: "World"})
+		t.AssertNil(err)
+		t.Assert(res.Message, "Hello World")
+	})
+	// REST on the same port.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix("http://" + address)
+		t.Assert(client.GetContent(ctx, "/ping"), "pong")
+		t.Assert(client.PostContent(ctx, "/v1/hello", `{"name":"John"}`), `{"message":"Hello John"}`)
+		t.Assert(client.GetContent(ctx, "/v1/hello/Jane"), `{"message":"Hello Jane"}`)
+		t.Assert(client.GetContent(ctx, "/v1/hello/Jane?name=Jim"), `{"message":"Hello Jane"}`)
+
+		resp, err := client.Post(ctx, "/v1/hello", `{"name":`)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 400)
+	})
+	// Invalid routes.
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(grpcServer.Gateway(g.Server(guid.S()), grpcx.GatewayRoute{
+			Pattern: "/v1/unknown", Method: "/protobuf.Unknown/SayHello",
+		}), nil)
+		t.AssertNE(grpcServer.Gateway(g.Server(guid.S()), grpcx.GatewayRoute{
+			Pattern: "/v1/unknown", Method: "/protobuf.Greeter/Unknown",
+		}), nil)
+	})
+}
+
+func Test_Grpcx_Gateway_Interceptor(t *testing.T) {
+	var (
+		ctx    = gctx.New()
+		config = grpcx.Server.NewConfig()
+	)
+	config.Options = append(config.Options, grpc.UnaryInterceptor(func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-token")) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}))
+	var (
+		grpcServer = grpcx.Server.New(config)
+		httpServer = g.Server(guid.S())
+	)
+	controller.Register(grpcServer)
+	err := grpcServer.Gateway(httpServer,
+		grpcx.GatewayRoute{Pattern: "GET:/v1/hello/{name}", Method: "/protobuf.Greeter/SayHello"},
+	)
+	gtest.AssertNil(err)
+	httpServer.SetDumpRouterMap(false)
+	httpServer.Start()
+	defer httpServer.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	address := fmt.Sprintf("127.0.0.1:%d", httpServer.GetListenedPort())
+
+	// The transcoded routes are served with the interceptors of grpc.UnaryInterceptor.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix("http://" + address)
+		resp, err := client.Get(ctx, "/v1/hello/Jane")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 401)
+
+		client.SetHeader("X-Token", "token")
+		t.Assert(client.GetContent(ctx, "/v1/hello/Jane"), `{"message":"Hello Jane"}`)
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package balancer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testSubConn struct {
+	balancer.SubConn
+}
+
+// newTestPickerBuildInfo creates and returns the picker build info of ready `conns` by address.
+func newTestPickerBuildInfo(conns map[string]balancer.SubConn) base.PickerBuildInfo {
+	info := base.PickerBuildInfo{
+		ReadySCs: make(map[balancer.SubConn]base.SubConnInfo),
+	}
+	for address, conn := range conns {
+		svc := &gsvc.LocalService{
+			Name:      "test",
+			Endpoints: gsvc.NewEndpoints(address),
+		}
+		info.ReadySCs[conn] = base.SubConnInfo{
+			Address: resolver.Address{
+				Addr:       address,
+				Attributes: attributes.New(rawSvcKeyInSubConnInfo, svc),
+			},
+		}
+	}
+	return info
+}
+
+func Test_Builder_SlowStart(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			conn1   = &testSubConn{}
+			conn2   = &testSubConn{}
+			builder = &Builder{
+				builder: gsel.NewBuilderSlowStart(gsel.NewBuilderRoundRobin(), gsel.SlowStartConfig{
+					Window: time.Minute,
+				}),
+			}
+		)
+		builder.Build(newTestPickerBuildInfo(map[string]balancer.SubConn{
+			"127.0.0.1:1": conn1,
+		}))
+		builder.Build(newTestPickerBuildInfo(map[string]balancer.SubConn{
+			"127.0.0.1:1": conn1,
+			"127.0.0.1:2": conn2,
+		}))
+		// The picker is rebuilt for any state change of connections, in which the new node keeps warming up.
+		var (
+			count  int
+			picker = builder.Build(newTestPickerBuildInfo(map[string]balancer.SubConn{
+				"127.0.0.1:1": conn1,
+				"127.0.0.1:2": conn2,
+			}))
+		)
+		for i := 0; i < 300; i++ {
+			result, err := picker.Pick(balancer.PickInfo{Ctx: context.Background()})
+			t.AssertNil(err)
+			if result.SubConn == conn2 {
+				count++
+			}
+		}
+		t.AssertLT(count, 50)
+	})
+}
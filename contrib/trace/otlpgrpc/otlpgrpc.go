@@ -23,6 +23,7 @@ import (
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/gipv4"
+	"github.com/gogf/gf/v2/net/gtrace"
 )
 
 const (
@@ -33,6 +34,8 @@ const (
 //
 // The output parameter `Shutdown` is used for waiting exported trace spans to be uploaded,
 // which is useful if your program is ending, and you do not want to lose recent spans.
+//
+// The sampling of spans is configured by gtrace.SetSampler before calling Init.
 func Init(serviceName, endpoint, traceToken string) (func(ctx context.Context), error) {
 	// Try retrieving host ip for tracing info.
 	var (
@@ -79,12 +82,7 @@ func Init(serviceName, endpoint, traceToken string) (func(ctx context.Context),
 		return nil, err
 	}
 
-	bsp := trace.NewBatchSpanProcessor(traceExp)
-	tracerProvider := trace.NewTracerProvider(
-		trace.WithSampler(trace.AlwaysSample()),
-		trace.WithResource(res),
-		trace.WithSpanProcessor(bsp),
-	)
+	tracerProvider := newTracerProvider(traceExp, res)
 
 	// Set the global propagator to traceContext (not set by default).
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -100,3 +98,16 @@ func Init(serviceName, endpoint, traceToken string) (func(ctx context.Context),
 		}
 	}, nil
 }
+
+// newTracerProvider creates and returns the tracer provider exporting spans using `exporter`.
+func newTracerProvider(exporter trace.SpanExporter, res *resource.Resource) *trace.TracerProvider {
+	return trace.NewTracerProvider(
+		trace.WithSampler(gtrace.GetSampler()),
+		trace.WithResource(res),
+		// It exports the error spans that are recorded but not sampled by the sampler.
+		// It is registered before the batch span processor, as the processors are shut down in order,
+		// and the batch span processor shuts down the shared exporter.
+		trace.WithSpanProcessor(gtrace.NewErrorSpanProcessor(exporter)),
+		trace.WithSpanProcessor(trace.NewBatchSpanProcessor(exporter)),
+	)
+}
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package otlphttp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// testSpanExporter records the names of exported spans, which refuses exporting after shut down
+// like the OTLP exporter.
+type testSpanExporter struct {
+	mu     sync.Mutex
+	names  []string
+	closed bool
+}
+
+func (e *testSpanExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return errors.New("exporter is shut down")
+	}
+	for _, span := range spans {
+		e.names = append(e.names, span.Name())
+	}
+	return nil
+}
+
+func (e *testSpanExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	return nil
+}
+
+func Test_TracerProvider_Shutdown(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		gtrace.SetSampler(gtrace.NewSampler(gtrace.SamplerConfig{
+			Rules:        []gtrace.SamplingRule{{Name: "sampled", Ratio: 1}},
+			Fallback:     trace.NeverSample(),
+			SampleErrors: true,
+		}))
+		defer gtrace.SetSampler(nil)
+		var (
+			ctx      = context.Background()
+			exporter = &testSpanExporter{}
+			provider = newTracerProvider(exporter, resource.Empty())
+			tracer   = provider.Tracer("test")
+		)
+		_, span := tracer.Start(ctx, "sampled")
+		span.End()
+		_, span = tracer.Start(ctx, "failure")
+		span.SetStatus(codes.Error, "failure")
+		span.End()
+
+		// The error span is exported before the exporter is shut down.
+		t.AssertNil(provider.Shutdown(ctx))
+		t.AssertIN("failure", exporter.names)
+		t.AssertIN("sampled", exporter.names)
+		t.Assert(len(exporter.names), 2)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// EncryptGCM encrypts `plainText` using GCM mode, which is an authenticated encryption
+// that both encrypts the content and verifies its integrity.
+// Note that the key must be 16/24/32 bit length.
+//
+// A random nonce is generated for each encryption, which is prepended to the returned cipher text.
+// The optional parameter `aad` is the additional authenticated data, which is authenticated but not
+// encrypted, like the identity of the record the content belongs to. The same `aad` should be given
+// in decryption.
+func EncryptGCM(plainText []byte, key []byte, aad ...[]byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate nonce failed`)
+	}
+	return gcm.Seal(nonce, nonce, plainText, getAAD(aad)), nil
+}
+
+// DecryptGCM decrypts `cipherText` that is encrypted by EncryptGCM.
+// Note that the key must be 16/24/32 bit length.
+// The optional parameter `aad` should be the same additional authenticated data given in encryption.
+func DecryptGCM(cipherText []byte, key []byte, aad ...[]byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := gcm.NonceSize()
+	if len(cipherText) < nonceSize+gcm.Overhead() {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "cipherText too short")
+	}
+	plainText, err := gcm.Open(nil, cipherText[:nonceSize], cipherText[nonceSize:], getAAD(aad))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `cipherText authentication failed`)
+	}
+	return plainText, nil
+}
+
+// newGCM creates and returns the GCM cipher of `key`.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		err = gerror.WrapCodef(gcode.CodeInvalidParameter, err, `aes.NewCipher failed for key length %d`, len(key))
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `cipher.NewGCM failed`)
+	}
+	return gcm, nil
+}
+
+// getAAD joins and returns the additional authenticated data.
+// The single part is used as it is, which is compatible with other GCM implementations.
+// Each of multiple parts is prefixed with its length in 4 bytes big-endian, so that the
+// parts cannot be shifted across their boundaries, like "ab"+"c" and "a"+"bc".
+func getAAD(aad [][]byte) []byte {
+	switch len(aad) {
+	case 0:
+		return nil
+	case 1:
+		return aad[0]
+	default:
+		var size int
+		for _, part := range aad {
+			size += 4 + len(part)
+		}
+		joined := make([]byte, 0, size)
+		for _, part := range aad {
+			joined = binary.BigEndian.AppendUint32(joined, uint32(len(part)))
+			joined = append(joined, part...)
+		}
+		return joined
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaes
+
+import (
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// KeyRing manages multiple keys for GCM encryption, which makes key rotation possible.
+//
+// The cipher text encrypted by KeyRing carries the id of the key, so that it can be decrypted
+// by the same key after the primary key is rotated, as long as the old key is still in the KeyRing.
+//
+// The cipher text format: version(1 byte) + key id length(1 byte) + key id + nonce + sealed content.
+type KeyRing struct {
+	mu      sync.RWMutex
+	keys    map[string][]byte // Key id to key.
+	primary string            // The key id used for encryption.
+}
+
+const (
+	keyRingVersion     byte = 1   // Version of the cipher text format of KeyRing.
+	keyRingMaxKeyIdLen      = 255 // Max length of key id, which is stored in one byte.
+)
+
+// NewKeyRing creates and returns a new KeyRing.
+func NewKeyRing() *KeyRing {
+	return &KeyRing{
+		keys: make(map[string][]byte),
+	}
+}
+
+// Add adds `key` with id `keyId` to the key ring.
+// The first added key is automatically the primary key.
+// It returns error if `keyId` already exists, as the cipher text encrypted by the existing key
+// cannot be decrypted if it is replaced.
+// Note that the key must be 16/24/32 bit length, and the key id should be no longer than 255 bytes.
+func (r *KeyRing) Add(keyId string, key []byte) error {
+	if keyId == "" || len(keyId) > keyRingMaxKeyIdLen {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid key id "%s", its length should be in range [1, %d]`, keyId, keyRingMaxKeyIdLen,
+		)
+	}
+	if _, err := newGCM(key); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.keys[keyId]; ok {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `key "%s" already exists`, keyId)
+	}
+	r.keys[keyId] = append([]byte(nil), key...)
+	if r.primary == "" {
+		r.primary = keyId
+	}
+	return nil
+}
+
+// Remove removes the key with id `keyId` from the key ring.
+// The cipher text encrypted by the removed key can no longer be decrypted.
+// It returns error if `keyId` is the primary key.
+func (r *KeyRing) Remove(keyId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if keyId == r.primary {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `cannot remove primary key "%s"`, keyId)
+	}
+	delete(r.keys, keyId)
+	return nil
+}
+
+// SetPrimary sets the key with id `keyId` as the primary key, which is used for encryption.
+func (r *KeyRing) SetPrimary(keyId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.keys[keyId]; !ok {
+		return gerror.NewCodef(gcode.CodeNotFound, `key "%s" not found`, keyId)
+	}
+	r.primary = keyId
+	return nil
+}
+
+// Primary returns the id of the primary key.
+func (r *KeyRing) Primary() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.primary
+}
+
+// Encrypt encrypts `plainText` using the primary key in GCM mode.
+// The optional parameter `aad` is the additional authenticated data, see EncryptGCM.
+func (r *KeyRing) Encrypt(plainText []byte, aad ...[]byte) ([]byte, error) {
+	r.mu.RLock()
+	keyId, key := r.primary, r.keys[r.primary]
+	r.mu.RUnlock()
+	if keyId == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, `no key in key ring`)
+	}
+	header := make([]byte, 0, 2+len(keyId))
+	header = append(header, keyRingVersion, byte(len(keyId)))
+	header = append(header, keyId...)
+	// The header is also authenticated, so that the key id cannot be tampered.
+	cipherText, err := EncryptGCM(plainText, key, append([][]byte{header}, aad...)...)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, cipherText...), nil
+}
+
+// Decrypt decrypts `cipherText` encrypted by Encrypt using the key of the id it carries.
+// The optional parameter `aad` should be the same additional authenticated data given in encryption.
+func (r *KeyRing) Decrypt(cipherText []byte, aad ...[]byte) ([]byte, error) {
+	keyId, err := KeyIdOf(cipherText)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	key, ok := r.keys[keyId]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `key "%s" not found`, keyId)
+	}
+	headerLen := 2 + len(keyId)
+	return DecryptGCM(cipherText[headerLen:], key, append([][]byte{cipherText[:headerLen]}, aad...)...)
+}
+
+// KeyIdOf returns the id of the key which encrypts `cipherText` by KeyRing.
+func KeyIdOf(cipherText []byte) (string, error) {
+	if len(cipherText) < 2 || cipherText[0] != keyRingVersion {
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `invalid key ring cipherText`)
+	}
+	headerLen := 2 + int(cipherText[1])
+	if len(cipherText) < headerLen {
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `invalid key ring cipherText`)
+	}
+	return string(cipherText[2:headerLen]), nil
+}
@@ -165,3 +165,122 @@ func TestDecryptCFB(t *testing.T) {
 		t.Assert(decrypt, content)
 	})
 }
+
+func TestEncryptGCM(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, key := range [][]byte{key_16, key_24, key_32} {
+			data, err := gaes.EncryptGCM(content, key)
+			t.AssertNil(err)
+			decrypt, err := gaes.DecryptGCM(data, key)
+			t.AssertNil(err)
+			t.Assert(decrypt, content)
+		}
+	})
+	// Random nonce.
+	gtest.C(t, func(t *gtest.T) {
+		data1, err := gaes.EncryptGCM(content, key_16)
+		t.AssertNil(err)
+		data2, err := gaes.EncryptGCM(content, key_16)
+		t.AssertNil(err)
+		t.AssertNE(data1, data2)
+	})
+	// Additional authenticated data.
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gaes.EncryptGCM(content, key_16, []byte("user"), []byte("1"))
+		t.AssertNil(err)
+		decrypt, err := gaes.DecryptGCM(data, key_16, []byte("user"), []byte("1"))
+		t.AssertNil(err)
+		t.Assert(decrypt, content)
+
+		_, err = gaes.DecryptGCM(data, key_16, []byte("user"), []byte("2"))
+		t.AssertNE(err, nil)
+		// The boundaries of parts are authenticated.
+		_, err = gaes.DecryptGCM(data, key_16, []byte("user1"))
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(data, key_16, []byte("use"), []byte("r1"))
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(data, key_16)
+		t.AssertNE(err, nil)
+	})
+	// Tampered or error.
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gaes.EncryptGCM(content, key_16)
+		t.AssertNil(err)
+		data[len(data)-1] ^= 1
+		_, err = gaes.DecryptGCM(data, key_16)
+		t.AssertNE(err, nil)
+
+		_, err = gaes.DecryptGCM(data[:10], key_16)
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(data, keys)
+		t.AssertNE(err, nil)
+		_, err = gaes.EncryptGCM(content, key_err)
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestKeyRing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ring := gaes.NewKeyRing()
+		_, err := ring.Encrypt(content)
+		t.AssertNE(err, nil)
+
+		t.AssertNil(ring.Add("v1", key_16))
+		t.Assert(ring.Primary(), "v1")
+		dataV1, err := ring.Encrypt(content, []byte("aad"))
+		t.AssertNil(err)
+		keyId, err := gaes.KeyIdOf(dataV1)
+		t.AssertNil(err)
+		t.Assert(keyId, "v1")
+
+		// Rotation.
+		t.AssertNil(ring.Add("v2", key_32))
+		t.Assert(ring.Primary(), "v1")
+		t.AssertNil(ring.SetPrimary("v2"))
+		dataV2, err := ring.Encrypt(content, []byte("aad"))
+		t.AssertNil(err)
+		keyId, err = gaes.KeyIdOf(dataV2)
+		t.AssertNil(err)
+		t.Assert(keyId, "v2")
+
+		decrypt, err := ring.Decrypt(dataV1, []byte("aad"))
+		t.AssertNil(err)
+		t.Assert(decrypt, content)
+		decrypt, err = ring.Decrypt(dataV2, []byte("aad"))
+		t.AssertNil(err)
+		t.Assert(decrypt, content)
+		_, err = ring.Decrypt(dataV2)
+		t.AssertNE(err, nil)
+
+		// Retirement.
+		t.AssertNE(ring.Remove("v2"), nil)
+		t.AssertNil(ring.Remove("v1"))
+		_, err = ring.Decrypt(dataV1, []byte("aad"))
+		t.AssertNE(err, nil)
+	})
+	// Tampered key id.
+	gtest.C(t, func(t *gtest.T) {
+		ring := gaes.NewKeyRing()
+		t.AssertNil(ring.Add("k1", key_16))
+		t.AssertNil(ring.Add("k2", key_16))
+		data, err := ring.Encrypt(content)
+		t.AssertNil(err)
+		data[3] = '2'
+		_, err = ring.Decrypt(data)
+		t.AssertNE(err, nil)
+	})
+	// Invalid parameters.
+	gtest.C(t, func(t *gtest.T) {
+		ring := gaes.NewKeyRing()
+		t.AssertNE(ring.Add("", key_16), nil)
+		t.AssertNE(ring.Add("v1", key_err), nil)
+		t.AssertNE(ring.SetPrimary("v1"), nil)
+		// Duplicated key id.
+		t.AssertNil(ring.Add("v1", key_16))
+		t.AssertNE(ring.Add("v1", key_32), nil)
+		_, err := ring.Decrypt([]byte{1, 10, 'a'})
+		t.AssertNE(err, nil)
+		_, err = gaes.KeyIdOf(nil)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,222 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package genvelope provides envelope encryption, in which the content is encrypted using a random
+// data key generated locally, and the data key is wrapped by a registered KeyWrapper like KMS.
+//
+// The cipher text is in a standard envelope format carrying the wrapper name, the master key id and the
+// wrapped data key, so the master keys can be moved to another KeyWrapper without changing the format.
+//
+// The envelope format:
+// "GFE" + version(1 byte) +
+// wrapper name length(1 byte) + wrapper name +
+// key id length(1 byte) + key id +
+// wrapped key length(2 bytes, big endian) + wrapped key +
+// data encrypted by AES-256-GCM.
+package genvelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Envelope is the parsed envelope of cipher text.
+type Envelope struct {
+	Wrapper    string // Name of the KeyWrapper that wraps the data key.
+	KeyId      string // Id of the master key that wraps the data key.
+	WrappedKey []byte // Wrapped data key.
+	Data       []byte // Data encrypted by the data key.
+}
+
+const (
+	envelopeMagic   = "GFE"
+	envelopeVersion = 1
+	dataKeySize     = 32 // AES-256.
+)
+
+// Encrypt encrypts `plainText` using a new random data key, which is wrapped by the KeyWrapper registered
+// with `wrapperName`, and returns the cipher text in envelope format.
+// The optional parameter `aad` is the additional authenticated data, see gaes.EncryptGCM.
+func Encrypt(ctx context.Context, wrapperName string, plainText []byte, aad ...[]byte) ([]byte, error) {
+	wrapper, err := getKeyWrapper(wrapperName)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err = io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate data key failed`)
+	}
+	defer clearKey(dataKey)
+
+	keyId, wrappedKey, err := wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `wrap data key using key wrapper "%s" failed`, wrapperName)
+	}
+	data, err := gaes.EncryptGCM(plainText, dataKey, dataAAD(aad)...)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &Envelope{
+		Wrapper:    wrapperName,
+		KeyId:      keyId,
+		WrappedKey: wrappedKey,
+		Data:       data,
+	}
+	return envelope.Bytes()
+}
+
+// Decrypt decrypts `cipherText` in envelope format created by Encrypt, using the KeyWrapper
+// registered with the wrapper name in the envelope.
+// The optional parameter `aad` should be the same additional authenticated data given in encryption.
+func Decrypt(ctx context.Context, cipherText []byte, aad ...[]byte) ([]byte, error) {
+	envelope, err := Parse(cipherText)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := envelope.unwrapKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer clearKey(dataKey)
+	return gaes.DecryptGCM(envelope.Data, dataKey, dataAAD(aad)...)
+}
+
+// Rewrap unwraps the data key of `cipherText` and wraps it again using the KeyWrapper registered with
+// `wrapperName`, which is usually used for master key rotation or migration to another KeyWrapper.
+// The encrypted data is kept unchanged.
+func Rewrap(ctx context.Context, cipherText []byte, wrapperName string) ([]byte, error) {
+	envelope, err := Parse(cipherText)
+	if err != nil {
+		return nil, err
+	}
+	wrapper, err := getKeyWrapper(wrapperName)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := envelope.unwrapKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer clearKey(dataKey)
+
+	keyId, wrappedKey, err := wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `wrap data key using key wrapper "%s" failed`, wrapperName)
+	}
+	envelope.Wrapper, envelope.KeyId, envelope.WrappedKey = wrapperName, keyId, wrappedKey
+	return envelope.Bytes()
+}
+
+// Parse parses and returns the Envelope of `cipherText`.
+func Parse(cipherText []byte) (*Envelope, error) {
+	var (
+		envelope = &Envelope{}
+		reader   = envelopeReader{data: cipherText}
+	)
+	if string(reader.next(len(envelopeMagic))) != envelopeMagic {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid envelope cipherText`)
+	}
+	if version := reader.next(1); len(version) == 0 || version[0] != envelopeVersion {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `unsupported envelope version`)
+	}
+	envelope.Wrapper = string(reader.nextWithLength(1))
+	envelope.KeyId = string(reader.nextWithLength(1))
+	envelope.WrappedKey = reader.nextWithLength(2)
+	envelope.Data = reader.data
+	if reader.failed || envelope.Wrapper == "" || len(envelope.WrappedKey) == 0 || len(envelope.Data) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid envelope cipherText`)
+	}
+	return envelope, nil
+}
+
+// Bytes returns the envelope format bytes of the Envelope.
+func (e *Envelope) Bytes() ([]byte, error) {
+	if e.Wrapper == "" || len(e.Wrapper) > math.MaxUint8 || len(e.KeyId) > math.MaxUint8 {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid envelope wrapper "%s" or key id "%s", whose length should be no greater than %d`,
+			e.Wrapper, e.KeyId, math.MaxUint8,
+		)
+	}
+	if len(e.WrappedKey) == 0 || len(e.WrappedKey) > math.MaxUint16 {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid envelope wrapped key length %d`, len(e.WrappedKey),
+		)
+	}
+	buffer := make([]byte, 0, len(envelopeMagic)+5+len(e.Wrapper)+len(e.KeyId)+len(e.WrappedKey)+len(e.Data))
+	buffer = append(buffer, envelopeMagic...)
+	buffer = append(buffer, envelopeVersion, byte(len(e.Wrapper)))
+	buffer = append(buffer, e.Wrapper...)
+	buffer = append(buffer, byte(len(e.KeyId)))
+	buffer = append(buffer, e.KeyId...)
+	buffer = binary.BigEndian.AppendUint16(buffer, uint16(len(e.WrappedKey)))
+	buffer = append(buffer, e.WrappedKey...)
+	buffer = append(buffer, e.Data...)
+	return buffer, nil
+}
+
+// unwrapKey unwraps and returns the data key of the Envelope.
+func (e *Envelope) unwrapKey(ctx context.Context) ([]byte, error) {
+	wrapper, err := getKeyWrapper(e.Wrapper)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := wrapper.UnwrapKey(ctx, e.KeyId, e.WrappedKey)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `unwrap data key using key wrapper "%s" failed`, e.Wrapper)
+	}
+	return dataKey, nil
+}
+
+// dataAAD returns the additional authenticated data for data encryption, which binds the envelope
+// version to the data. The wrapper name and key id are not bound, so that the data key can be rewrapped.
+func dataAAD(aad [][]byte) [][]byte {
+	return append([][]byte{[]byte(envelopeMagic), {envelopeVersion}}, aad...)
+}
+
+// clearKey overwrites the data key in memory after use.
+func clearKey(key []byte) {
+	for i := range key {
+		key[i] = 0
+	}
+}
+
+// envelopeReader reads the fields of envelope sequentially.
+type envelopeReader struct {
+	data   []byte
+	failed bool
+}
+
+// next reads and returns the next `n` bytes.
+func (r *envelopeReader) next(n int) []byte {
+	if r.failed || len(r.data) < n {
+		r.failed = true
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+// nextWithLength reads the length in `size` bytes and then the bytes of the length.
+func (r *envelopeReader) nextWithLength(size int) []byte {
+	lengthBytes := r.next(size)
+	if r.failed {
+		return nil
+	}
+	length := int(lengthBytes[0])
+	if size == 2 {
+		length = int(binary.BigEndian.Uint16(lengthBytes))
+	}
+	return r.next(length)
+}
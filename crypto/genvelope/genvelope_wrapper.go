@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genvelope
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// KeyWrapper wraps and unwraps the data keys using the master keys it manages,
+// which is usually implemented by KMS (Key Management Service).
+type KeyWrapper interface {
+	// WrapKey wraps (encrypts) `dataKey` using the current master key, and returns the id of
+	// the master key and the wrapped key.
+	WrapKey(ctx context.Context, dataKey []byte) (keyId string, wrappedKey []byte, err error)
+
+	// UnwrapKey unwraps (decrypts) `wrappedKey` using the master key of `keyId`, and returns the data key.
+	UnwrapKey(ctx context.Context, keyId string, wrappedKey []byte) (dataKey []byte, err error)
+}
+
+var (
+	// keyWrappers is the registered key wrappers, mapping name to KeyWrapper.
+	keyWrappers   = make(map[string]KeyWrapper)
+	keyWrappersMu sync.RWMutex
+)
+
+// RegisterKeyWrapper registers `wrapper` with `name`, which is stored in the envelope of cipher text,
+// so that the cipher text can be decrypted using the same wrapper.
+// The name should be no longer than 255 bytes.
+func RegisterKeyWrapper(name string, wrapper KeyWrapper) {
+	keyWrappersMu.Lock()
+	defer keyWrappersMu.Unlock()
+	keyWrappers[name] = wrapper
+}
+
+// GetKeyWrapper returns the KeyWrapper registered with `name`, or nil if it is not registered.
+func GetKeyWrapper(name string) KeyWrapper {
+	keyWrappersMu.RLock()
+	defer keyWrappersMu.RUnlock()
+	return keyWrappers[name]
+}
+
+// getKeyWrapper returns the registered KeyWrapper of `name`, or error if it is not registered.
+func getKeyWrapper(name string) (KeyWrapper, error) {
+	if wrapper := GetKeyWrapper(name); wrapper != nil {
+		return wrapper, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotFound, `key wrapper "%s" not registered`, name)
+}
+
+// localKeyWrapper is the KeyWrapper implementation using local master keys of gaes.KeyRing.
+type localKeyWrapper struct {
+	keyRing *gaes.KeyRing
+}
+
+// NewLocalKeyWrapper creates and returns a KeyWrapper using the local master keys of `keyRing`,
+// in which the data keys are wrapped using the primary key of `keyRing`.
+// It is usually used in development or before KMS is available.
+func NewLocalKeyWrapper(keyRing *gaes.KeyRing) KeyWrapper {
+	return &localKeyWrapper{
+		keyRing: keyRing,
+	}
+}
+
+// WrapKey implements interface KeyWrapper.
+func (w *localKeyWrapper) WrapKey(ctx context.Context, dataKey []byte) (keyId string, wrappedKey []byte, err error) {
+	if wrappedKey, err = w.keyRing.Encrypt(dataKey); err != nil {
+		return "", nil, err
+	}
+	if keyId, err = gaes.KeyIdOf(wrappedKey); err != nil {
+		return "", nil, err
+	}
+	return keyId, wrappedKey, nil
+}
+
+// UnwrapKey implements interface KeyWrapper.
+func (w *localKeyWrapper) UnwrapKey(ctx context.Context, keyId string, wrappedKey []byte) (dataKey []byte, err error) {
+	wrappedKeyId, err := gaes.KeyIdOf(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	if wrappedKeyId != keyId {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `key id "%s" does not match wrapped key id "%s"`, keyId, wrappedKeyId,
+		)
+	}
+	return w.keyRing.Decrypt(wrappedKey)
+}
@@ -0,0 +1,142 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genvelope_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/crypto/genvelope"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx     = context.Background()
+	content = []byte("GoFrame")
+)
+
+// failedKeyWrapper is the KeyWrapper that always fails, like an unavailable KMS.
+type failedKeyWrapper struct{}
+
+func (failedKeyWrapper) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	return "", nil, errors.New("kms unavailable")
+}
+
+func (failedKeyWrapper) UnwrapKey(ctx context.Context, keyId string, wrappedKey []byte) ([]byte, error) {
+	return nil, errors.New("kms unavailable")
+}
+
+func newKeyRing(t *gtest.T, keyId string) *gaes.KeyRing {
+	keyRing := gaes.NewKeyRing()
+	t.AssertNil(keyRing.Add(keyId, []byte("12345678912345678912345678912345")))
+	return keyRing
+}
+
+func Test_Encrypt_Decrypt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		genvelope.RegisterKeyWrapper("local", genvelope.NewLocalKeyWrapper(newKeyRing(t, "master-1")))
+		t.AssertNE(genvelope.GetKeyWrapper("local"), nil)
+
+		cipherText, err := genvelope.Encrypt(ctx, "local", content, []byte("user:1"))
+		t.AssertNil(err)
+
+		envelope, err := genvelope.Parse(cipherText)
+		t.AssertNil(err)
+		t.Assert(envelope.Wrapper, "local")
+		t.Assert(envelope.KeyId, "master-1")
+		t.AssertGT(len(envelope.WrappedKey), 0)
+		bytes, err := envelope.Bytes()
+		t.AssertNil(err)
+		t.Assert(bytes, cipherText)
+
+		plainText, err := genvelope.Decrypt(ctx, cipherText, []byte("user:1"))
+		t.AssertNil(err)
+		t.Assert(plainText, content)
+
+		_, err = genvelope.Decrypt(ctx, cipherText, []byte("user:2"))
+		t.AssertNE(err, nil)
+
+		// Different data keys for each encryption.
+		cipherText2, err := genvelope.Encrypt(ctx, "local", content, []byte("user:1"))
+		t.AssertNil(err)
+		envelope2, err := genvelope.Parse(cipherText2)
+		t.AssertNil(err)
+		t.AssertNE(envelope.WrappedKey, envelope2.WrappedKey)
+	})
+}
+
+func Test_Rewrap(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		genvelope.RegisterKeyWrapper("old", genvelope.NewLocalKeyWrapper(newKeyRing(t, "old-1")))
+		genvelope.RegisterKeyWrapper("new", genvelope.NewLocalKeyWrapper(newKeyRing(t, "new-1")))
+
+		cipherText, err := genvelope.Encrypt(ctx, "old", content)
+		t.AssertNil(err)
+		rewrapped, err := genvelope.Rewrap(ctx, cipherText, "new")
+		t.AssertNil(err)
+
+		oldEnvelope, err := genvelope.Parse(cipherText)
+		t.AssertNil(err)
+		newEnvelope, err := genvelope.Parse(rewrapped)
+		t.AssertNil(err)
+		t.Assert(newEnvelope.Wrapper, "new")
+		t.Assert(newEnvelope.KeyId, "new-1")
+		t.Assert(newEnvelope.Data, oldEnvelope.Data)
+
+		// Removing old wrapper does not affect rewrapped cipher text.
+		genvelope.RegisterKeyWrapper("old", nil)
+		_, err = genvelope.Decrypt(ctx, cipherText)
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		plainText, err := genvelope.Decrypt(ctx, rewrapped)
+		t.AssertNil(err)
+		t.Assert(plainText, content)
+	})
+}
+
+func Test_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := genvelope.Encrypt(ctx, "none", content)
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+
+		genvelope.RegisterKeyWrapper("failed", failedKeyWrapper{})
+		_, err = genvelope.Encrypt(ctx, "failed", content)
+		t.AssertNE(err, nil)
+
+		for _, invalid := range [][]byte{
+			nil,
+			[]byte("GFE"),
+			[]byte("GFE\x02"),
+			[]byte("XXX\x01\x05local"),
+			[]byte("GFE\x01\x05local\x00\x00\x01"),
+			[]byte("GFE\x01\x05local\x00\x00\x01k"),
+		} {
+			_, err = genvelope.Parse(invalid)
+			t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+		}
+		_, err = (&genvelope.Envelope{Wrapper: "local"}).Bytes()
+		t.AssertNE(err, nil)
+	})
+	// Tampered key id.
+	gtest.C(t, func(t *gtest.T) {
+		keyRing := newKeyRing(t, "k1")
+		t.AssertNil(keyRing.Add("k2", []byte("12345678912345678912345678912345")))
+		genvelope.RegisterKeyWrapper("tamper", genvelope.NewLocalKeyWrapper(keyRing))
+		cipherText, err := genvelope.Encrypt(ctx, "tamper", content)
+		t.AssertNil(err)
+		envelope, err := genvelope.Parse(cipherText)
+		t.AssertNil(err)
+		envelope.KeyId = "k2"
+		tampered, err := envelope.Bytes()
+		t.AssertNil(err)
+		_, err = genvelope.Decrypt(ctx, tampered)
+		t.AssertNE(err, nil)
+	})
+}
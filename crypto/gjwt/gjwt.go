@@ -0,0 +1,218 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjwt provides JSON Web Token (RFC 7519) creation and verification,
+// supporting HS256/HS384/HS512, RS256/RS384/RS512, ES256 and EdDSA algorithms.
+//
+// The keys for verification can be given directly or retrieved dynamically by KeyFunc,
+// like from the JWKS endpoint of identity provider using JWKS.
+package gjwt
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// Header is the JOSE header of token.
+type Header struct {
+	Alg string `json:"alg"`           // Algorithm of signature.
+	Typ string `json:"typ,omitempty"` // Type of token, which is "JWT".
+	Kid string `json:"kid,omitempty"` // Key id, which is used for choosing the verification key.
+}
+
+// KeyFunc returns the verification key for token of `header`, which is usually used for choosing key
+// by key id among multiple keys.
+type KeyFunc func(ctx context.Context, header *Header) (key interface{}, err error)
+
+// ParseOption is the option for token parsing and verification.
+type ParseOption struct {
+	// Key is the verification key, which is []byte for HS algorithms, *rsa.PublicKey for RS algorithms,
+	// *ecdsa.PublicKey for ES256 and ed25519.PublicKey for EdDSA.
+	// The private keys of asymmetric algorithms are also accepted.
+	Key interface{}
+
+	// KeyFunc returns the verification key dynamically, which is used if Key is nil.
+	KeyFunc KeyFunc
+
+	// Algorithms are the accepted algorithms. The algorithm in token header should also match the type
+	// of verification key, so it accepts all algorithms of the key type in default.
+	Algorithms []string
+
+	// Issuer is the expected issuer "iss" of token, which is not checked if it is empty.
+	Issuer string
+
+	// Audience is the expected audience "aud" of token, which is not checked if it is empty.
+	Audience string
+
+	// Leeway is the tolerance of clock skew between token issuer and verifier,
+	// which is applied to the checks of "exp", "nbf" and "iat".
+	Leeway time.Duration
+
+	// RequireExpiration rejects the token without expiration time "exp".
+	RequireExpiration bool
+}
+
+const (
+	tokenType          = "JWT"
+	tokenPartSeparator = "."
+)
+
+var (
+	// ErrTokenMalformed is returned if the token is not in valid JWT format.
+	ErrTokenMalformed = gerror.NewWithOption(gerror.Option{
+		Text: "token is malformed",
+		Code: gcode.CodeInvalidParameter,
+	})
+	// ErrTokenSignatureInvalid is returned if the signature of token is invalid.
+	ErrTokenSignatureInvalid = gerror.NewWithOption(gerror.Option{
+		Text: "token signature is invalid",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenExpired is returned if the token is expired.
+	ErrTokenExpired = gerror.NewWithOption(gerror.Option{
+		Text: "token is expired",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenNotValidYet is returned if the token is used before its "nbf" or "iat" time.
+	ErrTokenNotValidYet = gerror.NewWithOption(gerror.Option{
+		Text: "token is not valid yet",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenClaimsInvalid is returned if the issuer, audience or other claims of token are not as expected.
+	ErrTokenClaimsInvalid = gerror.NewWithOption(gerror.Option{
+		Text: "token claims are invalid",
+		Code: gcode.CodeNotAuthorized,
+	})
+)
+
+// Sign creates and returns the signed token of `claims` using `algorithm` and `key`.
+// The `key` is []byte for HS algorithms, *rsa.PrivateKey for RS algorithms, *ecdsa.PrivateKey for ES256
+// and ed25519.PrivateKey for EdDSA.
+// The optional parameter `keyId` specifies the key id "kid" in token header.
+func Sign(claims *Claims, algorithm string, key interface{}, keyId ...string) (string, error) {
+	method, err := getSigningMethod(algorithm)
+	if err != nil {
+		return "", err
+	}
+	header := Header{
+		Alg: algorithm,
+		Typ: tokenType,
+	}
+	if len(keyId) > 0 {
+		header.Kid = keyId[0]
+	}
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	if claims == nil {
+		claims = &Claims{}
+	}
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encoding.EncodeToString(headerJson) + tokenPartSeparator + encoding.EncodeToString(claimsJson)
+	signature, err := method.sign([]byte(signingInput), key)
+	if err != nil {
+		return "", err
+	}
+	return signingInput + tokenPartSeparator + encoding.EncodeToString(signature), nil
+}
+
+// Parse parses and verifies `token`, and returns its claims.
+// It verifies the signature, the time claims "exp", "nbf" and "iat", and the issuer and audience
+// configured in `option`.
+func Parse(ctx context.Context, token string, option ParseOption) (*Claims, error) {
+	header, claims, signingInput, signature, err := decode(token)
+	if err != nil {
+		return nil, err
+	}
+	key := option.Key
+	if key == nil {
+		if option.KeyFunc == nil {
+			return nil, gerror.NewCode(gcode.CodeMissingParameter, `verification key or key function is required`)
+		}
+		if key, err = option.KeyFunc(ctx, header); err != nil {
+			return nil, err
+		}
+	}
+	if err = verifySignature(header.Alg, option.Algorithms, signingInput, signature, key); err != nil {
+		return nil, err
+	}
+	if err = claims.Validate(option); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// ParseUnverified parses `token` without verification, and returns its header and claims.
+// It is usually used for inspecting the token before verification, like retrieving the issuer
+// to choose the verification key. The returned claims should never be trusted.
+func ParseUnverified(token string) (*Header, *Claims, error) {
+	header, claims, _, _, err := decode(token)
+	return header, claims, err
+}
+
+// verifySignature verifies `signature` of `signingInput` using `key` and the algorithm `alg` in header.
+func verifySignature(alg string, algorithms []string, signingInput, signature []byte, key interface{}) error {
+	if len(algorithms) > 0 && !containsString(algorithms, alg) {
+		return gerror.Wrapf(ErrTokenSignatureInvalid, `token algorithm "%s" is not accepted`, alg)
+	}
+	method, err := getSigningMethod(alg)
+	if err != nil {
+		return gerror.Wrapf(ErrTokenSignatureInvalid, `token algorithm "%s" is not supported`, alg)
+	}
+	return method.verify(signingInput, signature, key)
+}
+
+// decode decodes `token` and returns its parts.
+func decode(token string) (header *Header, claims *Claims, signingInput, signature []byte, err error) {
+	parts := strings.Split(token, tokenPartSeparator)
+	if len(parts) != 3 {
+		err = ErrTokenMalformed
+		return
+	}
+	headerJson, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		err = gerror.Wrap(ErrTokenMalformed, `invalid token header encoding`)
+		return
+	}
+	if err = json.Unmarshal(headerJson, &header); err != nil || header == nil {
+		err = gerror.Wrap(ErrTokenMalformed, `invalid token header`)
+		return
+	}
+	claimsJson, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		err = gerror.Wrap(ErrTokenMalformed, `invalid token claims encoding`)
+		return
+	}
+	claims = &Claims{}
+	if err = json.Unmarshal(claimsJson, claims); err != nil {
+		err = gerror.Wrap(ErrTokenMalformed, `invalid token claims`)
+		return
+	}
+	if signature, err = encoding.DecodeString(parts[2]); err != nil {
+		err = gerror.Wrap(ErrTokenMalformed, `invalid token signature encoding`)
+		return
+	}
+	signingInput = []byte(parts[0] + tokenPartSeparator + parts[1])
+	return
+}
+
+func containsString(array []string, s string) bool {
+	for _, v := range array {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
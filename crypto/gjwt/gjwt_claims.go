@@ -0,0 +1,178 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	internalJson "github.com/gogf/gf/v2/internal/json"
+)
+
+// Claims is the claims of token, which holds the registered claims defined in RFC 7519,
+// and the custom claims in Extra.
+// The zero value time claims are omitted in token.
+type Claims struct {
+	Issuer    string                 // Issuer "iss".
+	Subject   string                 // Subject "sub".
+	Audience  []string               // Audience "aud".
+	ExpiresAt time.Time              // Expiration time "exp".
+	NotBefore time.Time              // Not before time "nbf".
+	IssuedAt  time.Time              // Issued at time "iat".
+	Id        string                 // JWT id "jti".
+	Extra     map[string]interface{} // Custom claims.
+}
+
+const (
+	claimIssuer    = "iss"
+	claimSubject   = "sub"
+	claimAudience  = "aud"
+	claimExpiresAt = "exp"
+	claimNotBefore = "nbf"
+	claimIssuedAt  = "iat"
+	claimId        = "jti"
+)
+
+// Get returns the custom claim of `name`, or nil if it does not exist.
+func (c *Claims) Get(name string) interface{} {
+	return c.Extra[name]
+}
+
+// Set sets the custom claim of `name` with `value`.
+func (c *Claims) Set(name string, value interface{}) *Claims {
+	if c.Extra == nil {
+		c.Extra = make(map[string]interface{})
+	}
+	c.Extra[name] = value
+	return c
+}
+
+// Validate validates the time claims, the issuer and the audience using `option`.
+func (c *Claims) Validate(option ParseOption) error {
+	now := time.Now()
+	if c.ExpiresAt.IsZero() {
+		if option.RequireExpiration {
+			return gerror.Wrap(ErrTokenClaimsInvalid, `token expiration time is required`)
+		}
+	} else if !now.Before(c.ExpiresAt.Add(option.Leeway)) {
+		return gerror.Wrapf(ErrTokenExpired, `token expired at %s`, c.ExpiresAt.Format(time.RFC3339))
+	}
+	if !c.NotBefore.IsZero() && now.Add(option.Leeway).Before(c.NotBefore) {
+		return gerror.Wrapf(ErrTokenNotValidYet, `token is not valid before %s`, c.NotBefore.Format(time.RFC3339))
+	}
+	if !c.IssuedAt.IsZero() && now.Add(option.Leeway).Before(c.IssuedAt) {
+		return gerror.Wrapf(ErrTokenNotValidYet, `token is issued in future %s`, c.IssuedAt.Format(time.RFC3339))
+	}
+	if option.Issuer != "" && c.Issuer != option.Issuer {
+		return gerror.Wrapf(ErrTokenClaimsInvalid, `unexpected token issuer "%s"`, c.Issuer)
+	}
+	if option.Audience != "" && !containsString(c.Audience, option.Audience) {
+		return gerror.Wrapf(ErrTokenClaimsInvalid, `token audience does not contain "%s"`, option.Audience)
+	}
+	return nil
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(c.Extra)+7)
+	for k, v := range c.Extra {
+		m[k] = v
+	}
+	setStringClaim(m, claimIssuer, c.Issuer)
+	setStringClaim(m, claimSubject, c.Subject)
+	setStringClaim(m, claimId, c.Id)
+	setTimeClaim(m, claimExpiresAt, c.ExpiresAt)
+	setTimeClaim(m, claimNotBefore, c.NotBefore)
+	setTimeClaim(m, claimIssuedAt, c.IssuedAt)
+	switch len(c.Audience) {
+	case 0:
+		delete(m, claimAudience)
+	case 1:
+		m[claimAudience] = c.Audience[0]
+	default:
+		m[claimAudience] = c.Audience
+	}
+	return internalJson.Marshal(m)
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := internalJson.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*c = Claims{}
+	var err error
+	for k, v := range m {
+		switch k {
+		case claimIssuer:
+			err = internalJson.Unmarshal(v, &c.Issuer)
+		case claimSubject:
+			err = internalJson.Unmarshal(v, &c.Subject)
+		case claimId:
+			err = internalJson.Unmarshal(v, &c.Id)
+		case claimExpiresAt:
+			c.ExpiresAt, err = parseTimeClaim(v)
+		case claimNotBefore:
+			c.NotBefore, err = parseTimeClaim(v)
+		case claimIssuedAt:
+			c.IssuedAt, err = parseTimeClaim(v)
+		case claimAudience:
+			c.Audience, err = parseAudienceClaim(v)
+		default:
+			var value interface{}
+			if err = internalJson.UnmarshalUseNumber(v, &value); err == nil {
+				c.Set(k, value)
+			}
+		}
+		if err != nil {
+			return gerror.Wrapf(err, `invalid claim "%s"`, k)
+		}
+	}
+	return nil
+}
+
+func setStringClaim(m map[string]interface{}, name, value string) {
+	if value == "" {
+		delete(m, name)
+		return
+	}
+	m[name] = value
+}
+
+func setTimeClaim(m map[string]interface{}, name string, value time.Time) {
+	if value.IsZero() {
+		delete(m, name)
+		return
+	}
+	m[name] = value.Unix()
+}
+
+// parseTimeClaim parses the NumericDate claim, which is the seconds since epoch and can be fractional.
+func parseTimeClaim(data json.RawMessage) (time.Time, error) {
+	var seconds float64
+	if err := internalJson.Unmarshal(data, &seconds); err != nil {
+		return time.Time{}, err
+	}
+	integer, fraction := math.Modf(seconds)
+	return time.Unix(int64(integer), int64(fraction*1e9)), nil
+}
+
+// parseAudienceClaim parses the audience claim, which is a string or an array of strings.
+func parseAudienceClaim(data json.RawMessage) ([]string, error) {
+	var audience string
+	if err := internalJson.Unmarshal(data, &audience); err == nil {
+		return []string{audience}, nil
+	}
+	var audiences []string
+	if err := internalJson.Unmarshal(data, &audiences); err != nil {
+		return nil, err
+	}
+	return audiences, nil
+}
@@ -0,0 +1,209 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"context"
+	"crypto/rsa"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gsign"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/gclient"
+)
+
+// JWKS fetches the JSON Web Key Set from remote url, like the "jwks_uri" of OpenID provider,
+// and caches the keys for token verification.
+//
+// The keys are fetched again if the cache expires, or if the key id of token is not found in cache,
+// which makes the key rotation of provider transparent.
+type JWKS struct {
+	url        string
+	client     *gclient.Client
+	ttl        time.Duration
+	mu         sync.RWMutex
+	fetchMu    sync.Mutex             // Makes only one fetching at the same time.
+	keys       map[string]interface{} // Key id to key.
+	fetchedAt  time.Time              // Time of last successful fetching.
+	attemptAt  time.Time              // Time of last fetching attempt.
+	retryDelay time.Duration          // Minimum interval of fetching attempts.
+}
+
+// jwksKey is the key in JWKS, which also supports RSA key.
+type jwksKey struct {
+	gsign.JWK
+	N string `json:"n,omitempty"` // Modulus of RSA key.
+	E string `json:"e,omitempty"` // Exponent of RSA key.
+}
+
+const (
+	defaultJWKSTTL        = 10 * time.Minute
+	defaultJWKSRetryDelay = 30 * time.Second
+	jwkKtyRSA             = "RSA"
+)
+
+// NewJWKS creates and returns a JWKS fetching keys from `url`.
+// The optional parameter `ttl` specifies the cache duration of keys, which is 10 minutes in default.
+func NewJWKS(url string, ttl ...time.Duration) *JWKS {
+	jwks := &JWKS{
+		url:        url,
+		client:     gclient.New(),
+		ttl:        defaultJWKSTTL,
+		retryDelay: defaultJWKSRetryDelay,
+	}
+	if len(ttl) > 0 && ttl[0] > 0 {
+		jwks.ttl = ttl[0]
+	}
+	return jwks
+}
+
+// SetClient sets the HTTP client for fetching keys, which is usually used for customizing timeout or proxy.
+func (j *JWKS) SetClient(client *gclient.Client) {
+	j.client = client
+}
+
+// SetRetryDelay sets the minimum interval of fetching attempts triggered by unknown key id or cache expiration,
+// which prevents the provider from being flooded by tokens of forged key id. It is 30 seconds in default.
+func (j *JWKS) SetRetryDelay(delay time.Duration) {
+	j.retryDelay = delay
+}
+
+// KeyFunc implements KeyFunc, which can be used as ParseOption.KeyFunc.
+func (j *JWKS) KeyFunc(ctx context.Context, header *Header) (interface{}, error) {
+	return j.Key(ctx, header.Kid)
+}
+
+// Key returns the key of `keyId`. If `keyId` is empty, it returns the only key in the key set.
+func (j *JWKS) Key(ctx context.Context, keyId string) (interface{}, error) {
+	key, found, fresh := j.lookup(keyId)
+	if found && fresh {
+		return key, nil
+	}
+	if err := j.refresh(ctx, false); err != nil {
+		if found {
+			// The stale key is used if provider is unavailable.
+			intlog.Errorf(ctx, `%+v`, err)
+			return key, nil
+		}
+		return nil, err
+	}
+	if key, found, _ = j.lookup(keyId); found {
+		return key, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotFound, `key "%s" not found in JWKS "%s"`, keyId, j.url)
+}
+
+// Refresh fetches the keys from remote url immediately.
+func (j *JWKS) Refresh(ctx context.Context) error {
+	return j.refresh(ctx, true)
+}
+
+// lookup looks up the key of `keyId` in cache.
+func (j *JWKS) lookup(keyId string) (key interface{}, found, fresh bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	fresh = time.Since(j.fetchedAt) < j.ttl
+	if keyId == "" {
+		if len(j.keys) == 1 {
+			for _, key = range j.keys {
+				return key, true, fresh
+			}
+		}
+		return nil, false, fresh
+	}
+	key, found = j.keys[keyId]
+	return
+}
+
+// refresh fetches and caches the keys from remote url.
+// If `force` is false, it skips fetching if the last attempt is within the retry delay,
+// which also happens if another goroutine has just fetched the keys.
+func (j *JWKS) refresh(ctx context.Context, force bool) error {
+	j.fetchMu.Lock()
+	defer j.fetchMu.Unlock()
+	if !force {
+		j.mu.RLock()
+		attemptAt := j.attemptAt
+		j.mu.RUnlock()
+		if time.Since(attemptAt) < j.retryDelay {
+			return nil
+		}
+	}
+	j.mu.Lock()
+	j.attemptAt = time.Now()
+	j.mu.Unlock()
+
+	keys, err := j.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+// fetch fetches and parses the keys from remote url.
+func (j *JWKS) fetch(ctx context.Context) (map[string]interface{}, error) {
+	response, err := j.client.Get(ctx, j.url)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `fetch JWKS "%s" failed`, j.url)
+	}
+	defer response.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, gerror.NewCodef(
+			gcode.CodeOperationFailed, `fetch JWKS "%s" failed with status %d`, j.url, response.StatusCode,
+		)
+	}
+	var keySet struct {
+		Keys []jwksKey `json:"keys"`
+	}
+	if err = json.Unmarshal(response.ReadAll(), &keySet); err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid JWKS "%s"`, j.url)
+	}
+	keys := make(map[string]interface{}, len(keySet.Keys))
+	for _, k := range keySet.Keys {
+		// The keys not for signature are ignored.
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.key()
+		if err != nil {
+			// The unsupported keys are ignored, as the key set may contain keys of other algorithms.
+			intlog.Errorf(ctx, `ignore key "%s" of JWKS "%s": %+v`, k.Kid, j.url, err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+// key returns the public key of JWKS key.
+func (k *jwksKey) key() (interface{}, error) {
+	if k.Kty != jwkKtyRSA {
+		return k.JWK.Key()
+	}
+	n, err := encoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid RSA key modulus`)
+	}
+	e, err := encoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid RSA key exponent`)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for crypto.Hash.
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512 for crypto.Hash.
+	"encoding/base64"
+
+	"github.com/gogf/gf/v2/crypto/gsign"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	AlgorithmHS256 = "HS256" // HMAC using SHA-256.
+	AlgorithmHS384 = "HS384" // HMAC using SHA-384.
+	AlgorithmHS512 = "HS512" // HMAC using SHA-512.
+	AlgorithmRS256 = "RS256" // RSASSA-PKCS1-v1_5 using SHA-256.
+	AlgorithmRS384 = "RS384" // RSASSA-PKCS1-v1_5 using SHA-384.
+	AlgorithmRS512 = "RS512" // RSASSA-PKCS1-v1_5 using SHA-512.
+	AlgorithmES256 = "ES256" // ECDSA using P-256 and SHA-256.
+	AlgorithmEdDSA = "EdDSA" // EdDSA using Ed25519.
+)
+
+// signingMethod signs and verifies the signing input of token.
+type signingMethod interface {
+	sign(signingInput []byte, key interface{}) ([]byte, error)
+	verify(signingInput, signature []byte, key interface{}) error
+}
+
+// hmacMethod is the signing method of HS algorithms, whose key is []byte.
+type hmacMethod struct {
+	hash crypto.Hash
+}
+
+// rsaMethod is the signing method of RS algorithms, whose key is *rsa.PrivateKey or *rsa.PublicKey.
+type rsaMethod struct {
+	hash crypto.Hash
+}
+
+// gsignMethod is the signing method of ES256 and EdDSA algorithms implemented by package gsign.
+type gsignMethod struct {
+	algorithm string
+}
+
+// encoding is the base64url encoding without padding used in token.
+var encoding = base64.RawURLEncoding
+
+var signingMethods = map[string]signingMethod{
+	AlgorithmHS256: hmacMethod{hash: crypto.SHA256},
+	AlgorithmHS384: hmacMethod{hash: crypto.SHA384},
+	AlgorithmHS512: hmacMethod{hash: crypto.SHA512},
+	AlgorithmRS256: rsaMethod{hash: crypto.SHA256},
+	AlgorithmRS384: rsaMethod{hash: crypto.SHA384},
+	AlgorithmRS512: rsaMethod{hash: crypto.SHA512},
+	AlgorithmES256: gsignMethod{algorithm: gsign.AlgorithmES256},
+	AlgorithmEdDSA: gsignMethod{algorithm: gsign.AlgorithmEd25519},
+}
+
+// getSigningMethod returns the signing method of `algorithm`.
+func getSigningMethod(algorithm string) (signingMethod, error) {
+	if method, ok := signingMethods[algorithm]; ok {
+		return method, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported token algorithm "%s"`, algorithm)
+}
+
+func (m hmacMethod) sign(signingInput []byte, key interface{}) ([]byte, error) {
+	secret, ok := key.([]byte)
+	if !ok || len(secret) == 0 {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid HMAC key type %T, it should be []byte`, key)
+	}
+	mac := hmac.New(m.hash.New, secret)
+	mac.Write(signingInput)
+	return mac.Sum(nil), nil
+}
+
+func (m hmacMethod) verify(signingInput, signature []byte, key interface{}) error {
+	expected, err := m.sign(signingInput, key)
+	if err != nil {
+		return gerror.Wrap(ErrTokenSignatureInvalid, err.Error())
+	}
+	if !hmac.Equal(signature, expected) {
+		return ErrTokenSignatureInvalid
+	}
+	return nil
+}
+
+func (m rsaMethod) sign(signingInput []byte, key interface{}) ([]byte, error) {
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid RSA private key type %T`, key)
+	}
+	hash := m.hash.New()
+	hash.Write(signingInput)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, m.hash, hash.Sum(nil))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `RSA sign failed`)
+	}
+	return signature, nil
+}
+
+func (m rsaMethod) verify(signingInput, signature []byte, key interface{}) error {
+	var publicKey *rsa.PublicKey
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		publicKey = k
+	case *rsa.PrivateKey:
+		publicKey = &k.PublicKey
+	default:
+		return gerror.Wrapf(ErrTokenSignatureInvalid, `invalid RSA public key type %T`, key)
+	}
+	hash := m.hash.New()
+	hash.Write(signingInput)
+	if err := rsa.VerifyPKCS1v15(publicKey, m.hash, hash.Sum(nil), signature); err != nil {
+		return ErrTokenSignatureInvalid
+	}
+	return nil
+}
+
+func (m gsignMethod) sign(signingInput []byte, key interface{}) ([]byte, error) {
+	if gsign.Algorithm(key) != m.algorithm {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid %s private key type %T`, m.algorithm, key)
+	}
+	return gsign.Sign(key, signingInput)
+}
+
+func (m gsignMethod) verify(signingInput, signature []byte, key interface{}) error {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		key = k.Public()
+	case *ecdsa.PrivateKey:
+		key = &k.PublicKey
+	}
+	if gsign.Algorithm(key) != m.algorithm {
+		return gerror.Wrapf(ErrTokenSignatureInvalid, `invalid %s public key type %T`, m.algorithm, key)
+	}
+	ok, err := gsign.Verify(key, signingInput, signature)
+	if err != nil || !ok {
+		return ErrTokenSignatureInvalid
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/crypto/gsign"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx    = context.Background()
+	secret = []byte("GoFrame")
+)
+
+func newClaims() *gjwt.Claims {
+	return (&gjwt.Claims{
+		Issuer:    "goframe",
+		Subject:   "user-1",
+		Audience:  []string{"api"},
+		ExpiresAt: time.Now().Add(time.Hour),
+		IssuedAt:  time.Now(),
+		Id:        "token-1",
+	}).Set("role", "admin")
+}
+
+func Test_Sign_Parse(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	esKey, _ := gsign.GenerateKey(gsign.AlgorithmES256)
+	edKey, _ := gsign.GenerateKey(gsign.AlgorithmEd25519)
+	cases := []struct {
+		algorithm  string
+		signKey    interface{}
+		verifyKey  interface{}
+		invalidKey interface{}
+	}{
+		{gjwt.AlgorithmHS256, secret, secret, []byte("invalid")},
+		{gjwt.AlgorithmHS384, secret, secret, []byte("invalid")},
+		{gjwt.AlgorithmHS512, secret, secret, []byte("invalid")},
+		{gjwt.AlgorithmRS256, rsaKey, &rsaKey.PublicKey, secret},
+		{gjwt.AlgorithmRS384, rsaKey, rsaKey, secret},
+		{gjwt.AlgorithmRS512, rsaKey, &rsaKey.PublicKey, esKey.Public()},
+		{gjwt.AlgorithmES256, esKey, esKey.Public(), edKey.Public()},
+		{gjwt.AlgorithmEdDSA, edKey, edKey.Public(), esKey.Public()},
+	}
+	for _, c := range cases {
+		gtest.C(t, func(t *gtest.T) {
+			token, err := gjwt.Sign(newClaims(), c.algorithm, c.signKey, "key-1")
+			t.AssertNil(err)
+			t.Assert(strings.Count(token, "."), 2)
+
+			claims, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: c.verifyKey})
+			t.AssertNil(err)
+			t.Assert(claims.Issuer, "goframe")
+			t.Assert(claims.Subject, "user-1")
+			t.Assert(claims.Audience, []string{"api"})
+			t.Assert(claims.Id, "token-1")
+			t.Assert(claims.Get("role"), "admin")
+			t.Assert(claims.ExpiresAt.Unix(), newClaims().ExpiresAt.Unix())
+
+			header, _, err := gjwt.ParseUnverified(token)
+			t.AssertNil(err)
+			t.Assert(header.Alg, c.algorithm)
+			t.Assert(header.Typ, "JWT")
+			t.Assert(header.Kid, "key-1")
+
+			_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: c.invalidKey})
+			t.Assert(errors.Is(err, gjwt.ErrTokenSignatureInvalid), true)
+			t.Assert(gerror.Code(err), gcode.CodeNotAuthorized)
+
+			// Tampered claims.
+			parts := strings.Split(token, ".")
+			parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+			_, err = gjwt.Parse(ctx, strings.Join(parts, "."), gjwt.ParseOption{Key: c.verifyKey})
+			t.Assert(errors.Is(err, gjwt.ErrTokenSignatureInvalid), true)
+		})
+	}
+}
+
+func Test_Parse_Algorithm(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		token, err := gjwt.Sign(newClaims(), gjwt.AlgorithmHS256, secret)
+		t.AssertNil(err)
+
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{
+			Key:        secret,
+			Algorithms: []string{gjwt.AlgorithmHS512},
+		})
+		t.Assert(errors.Is(err, gjwt.ErrTokenSignatureInvalid), true)
+
+		// Algorithm "none" is never accepted.
+		parts := strings.Split(token, ".")
+		parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		parts[2] = ""
+		_, err = gjwt.Parse(ctx, strings.Join(parts, "."), gjwt.ParseOption{Key: secret})
+		t.Assert(errors.Is(err, gjwt.ErrTokenSignatureInvalid), true)
+
+		_, err = gjwt.Sign(newClaims(), "none", secret)
+		t.AssertNE(err, nil)
+		_, err = gjwt.Sign(newClaims(), gjwt.AlgorithmRS256, secret)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Parse_Claims(t *testing.T) {
+	sign := func(t *gtest.T, claims *gjwt.Claims) string {
+		token, err := gjwt.Sign(claims, gjwt.AlgorithmHS256, secret)
+		t.AssertNil(err)
+		return token
+	}
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(t, &gjwt.Claims{ExpiresAt: time.Now().Add(-time.Minute)})
+		_, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret})
+		t.Assert(errors.Is(err, gjwt.ErrTokenExpired), true)
+
+		// Clock skew tolerance.
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, Leeway: 2 * time.Minute})
+		t.AssertNil(err)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(t, &gjwt.Claims{NotBefore: time.Now().Add(time.Minute)})
+		_, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret})
+		t.Assert(errors.Is(err, gjwt.ErrTokenNotValidYet), true)
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, Leeway: 2 * time.Minute})
+		t.AssertNil(err)
+
+		token = sign(t, &gjwt.Claims{IssuedAt: time.Now().Add(time.Minute)})
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret})
+		t.Assert(errors.Is(err, gjwt.ErrTokenNotValidYet), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(t, &gjwt.Claims{})
+		_, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret})
+		t.AssertNil(err)
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, RequireExpiration: true})
+		t.Assert(errors.Is(err, gjwt.ErrTokenClaimsInvalid), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(t, &gjwt.Claims{Issuer: "goframe", Audience: []string{"api", "web"}})
+		_, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, Issuer: "goframe", Audience: "web"})
+		t.AssertNil(err)
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, Issuer: "other"})
+		t.Assert(errors.Is(err, gjwt.ErrTokenClaimsInvalid), true)
+		_, err = gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret, Audience: "admin"})
+		t.Assert(errors.Is(err, gjwt.ErrTokenClaimsInvalid), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, token := range []string{"", "a.b", "a.b.c", "e30.e30.!!", "bnVsbA.e30.e30"} {
+			_, err := gjwt.Parse(ctx, token, gjwt.ParseOption{Key: secret})
+			t.Assert(errors.Is(err, gjwt.ErrTokenMalformed), true)
+		}
+		_, err := gjwt.Parse(ctx, sign(t, nil), gjwt.ParseOption{})
+		t.Assert(gerror.Code(err), gcode.CodeMissingParameter)
+	})
+}
+
+func Test_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		esKey, _   = gsign.GenerateKey(gsign.AlgorithmES256)
+		esJwk, _   = gsign.EncodeJWK(esKey.Public(), "es-1")
+		fetchCount int32
+		keySet     atomic.Value
+	)
+	keySet.Store(fmt.Sprintf(`{"keys":[%s]}`, esJwk))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetchCount, 1)
+		_, _ = w.Write([]byte(keySet.Load().(string)))
+	}))
+	defer server.Close()
+
+	gtest.C(t, func(t *gtest.T) {
+		jwks := gjwt.NewJWKS(server.URL)
+		jwks.SetRetryDelay(0)
+		option := gjwt.ParseOption{KeyFunc: jwks.KeyFunc}
+
+		token, err := gjwt.Sign(newClaims(), gjwt.AlgorithmES256, esKey, "es-1")
+		t.AssertNil(err)
+		claims, err := gjwt.Parse(ctx, token, option)
+		t.AssertNil(err)
+		t.Assert(claims.Subject, "user-1")
+
+		// Cached.
+		_, err = gjwt.Parse(ctx, token, option)
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&fetchCount), 1)
+
+		// Key rotation, the unknown key id triggers fetching.
+		keySet.Store(fmt.Sprintf(
+			`{"keys":[%s,{"kty":"RSA","kid":"rs-1","use":"sig","n":"%s","e":"%s"},{"kty":"oct","kid":"x"}]}`,
+			esJwk,
+			base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		))
+		token, err = gjwt.Sign(newClaims(), gjwt.AlgorithmRS256, rsaKey, "rs-1")
+		t.AssertNil(err)
+		_, err = gjwt.Parse(ctx, token, option)
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&fetchCount), 2)
+
+		// Unknown key id.
+		token, err = gjwt.Sign(newClaims(), gjwt.AlgorithmRS256, rsaKey, "unknown")
+		t.AssertNil(err)
+		_, err = gjwt.Parse(ctx, token, option)
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+	})
+	// Throttled fetching for unknown key id.
+	gtest.C(t, func(t *gtest.T) {
+		atomic.StoreInt32(&fetchCount, 0)
+		jwks := gjwt.NewJWKS(server.URL)
+		for i := 0; i < 3; i++ {
+			_, err := jwks.Key(ctx, "unknown")
+			t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		}
+		t.Assert(atomic.LoadInt32(&fetchCount), 1)
+		t.AssertNil(jwks.Refresh(ctx))
+		t.Assert(atomic.LoadInt32(&fetchCount), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		jwks := gjwt.NewJWKS(server.URL + "/invalid")
+		keySet.Store(`invalid`)
+		_, err := jwks.Key(ctx, "es-1")
+		t.AssertNE(err, nil)
+	})
+}
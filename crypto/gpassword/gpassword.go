@@ -0,0 +1,217 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gpassword provides password hashing and verification using argon2id, bcrypt and scrypt.
+//
+// The hash it produces is self-describing, which contains the algorithm, the cost parameters and the salt,
+// so that it can be verified without any other information, and NeedsRehash can detect the hashes that
+// are created using outdated configuration.
+//
+// The hash formats:
+// argon2id: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+// bcrypt:   $2a$12$<salt and key>
+// scrypt:   $scrypt$ln=15,r=8,p=1$<salt>$<key>
+package gpassword
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Config is the configuration for password hashing.
+type Config struct {
+	// Algorithm is the algorithm for creating new hashes, which is AlgorithmArgon2id in default.
+	Algorithm string `json:"algorithm"`
+
+	// Argon2id is the cost parameters of argon2id algorithm.
+	Argon2id Argon2idOption `json:"argon2id"`
+
+	// Bcrypt is the cost parameters of bcrypt algorithm.
+	Bcrypt BcryptOption `json:"bcrypt"`
+
+	// Scrypt is the cost parameters of scrypt algorithm.
+	Scrypt ScryptOption `json:"scrypt"`
+}
+
+const (
+	AlgorithmArgon2id = "argon2id" // Argon2id algorithm, which is the default and recommended algorithm.
+	AlgorithmBcrypt   = "bcrypt"   // Bcrypt algorithm.
+	AlgorithmScrypt   = "scrypt"   // Scrypt algorithm.
+)
+
+const (
+	hashSeparator      = "$"
+	defaultSaltLength  = 16
+	defaultKeyLength   = 32
+	invalidHashMessage = `invalid password hash`
+)
+
+var (
+	// hashEncoding is the encoding for salt and key in hash, which is the same as PHC string format.
+	hashEncoding = base64.RawStdEncoding
+
+	config = Config{
+		Algorithm: AlgorithmArgon2id,
+		Argon2id:  defaultArgon2idOption,
+		Bcrypt:    defaultBcryptOption,
+		Scrypt:    defaultScryptOption,
+	}
+	configMu sync.RWMutex
+)
+
+// SetConfig sets the configuration for password hashing.
+// The zero values of `c` are replaced with default values.
+//
+// The hashes created using the previous configuration can still be verified,
+// and NeedsRehash reports true for them.
+func SetConfig(c Config) error {
+	switch c.Algorithm {
+	case "":
+		c.Algorithm = AlgorithmArgon2id
+	case AlgorithmArgon2id, AlgorithmBcrypt, AlgorithmScrypt:
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidConfiguration, `unsupported password hash algorithm "%s"`, c.Algorithm)
+	}
+	var err error
+	if c.Argon2id, err = c.Argon2id.normalize(); err != nil {
+		return err
+	}
+	if c.Bcrypt, err = c.Bcrypt.normalize(); err != nil {
+		return err
+	}
+	if c.Scrypt, err = c.Scrypt.normalize(); err != nil {
+		return err
+	}
+	configMu.Lock()
+	defer configMu.Unlock()
+	config = c
+	return nil
+}
+
+// GetConfig returns the configuration for password hashing.
+func GetConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+// Hash creates and returns the hash of `password` using the configured algorithm and cost parameters.
+func Hash(password string) (string, error) {
+	c := GetConfig()
+	switch c.Algorithm {
+	case AlgorithmBcrypt:
+		return HashBcrypt(password, c.Bcrypt)
+	case AlgorithmScrypt:
+		return HashScrypt(password, c.Scrypt)
+	default:
+		return HashArgon2id(password, c.Argon2id)
+	}
+}
+
+// MustHash creates and returns the hash of `password` like Hash, but it panics if any error occurs.
+func MustHash(password string) string {
+	hash, err := Hash(password)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// Verify checks whether `password` matches `hash`, which can be created by any supported algorithm.
+// It returns false if `password` does not match, and returns error if `hash` is invalid.
+// The comparison is constant-time.
+func Verify(password, hash string) (bool, error) {
+	switch Algorithm(hash) {
+	case AlgorithmArgon2id:
+		return verifyArgon2id(password, hash)
+	case AlgorithmBcrypt:
+		return verifyBcrypt(password, hash)
+	case AlgorithmScrypt:
+		return verifyScrypt(password, hash)
+	default:
+		return false, gerror.NewCode(gcode.CodeInvalidParameter, invalidHashMessage)
+	}
+}
+
+// NeedsRehash checks whether `hash` is created by an algorithm or cost parameters different from
+// the configuration, in which case the password should be hashed again, usually when user logs in
+// and the plain password is available.
+// It also returns true if `hash` is invalid.
+func NeedsRehash(hash string) bool {
+	c := GetConfig()
+	if Algorithm(hash) != c.Algorithm {
+		return true
+	}
+	switch c.Algorithm {
+	case AlgorithmArgon2id:
+		option, _, _, err := parseArgon2id(hash)
+		return err != nil || option != c.Argon2id
+	case AlgorithmBcrypt:
+		option, err := parseBcrypt(hash)
+		return err != nil || option != c.Bcrypt
+	case AlgorithmScrypt:
+		option, _, _, err := parseScrypt(hash)
+		return err != nil || option != c.Scrypt
+	}
+	return true
+}
+
+// Algorithm returns the algorithm of `hash`, or empty string if it is not supported.
+func Algorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, hashSeparator+AlgorithmArgon2id+hashSeparator):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(hash, hashSeparator+AlgorithmScrypt+hashSeparator):
+		return AlgorithmScrypt
+	case isBcryptHash(hash):
+		return AlgorithmBcrypt
+	}
+	return ""
+}
+
+// newSalt generates and returns random salt of `length` bytes.
+func newSalt(length uint32) ([]byte, error) {
+	salt := make([]byte, length)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate salt failed`)
+	}
+	return salt, nil
+}
+
+// splitHash splits `hash` in format "$algorithm$part1$part2..." and returns the parts after algorithm.
+func splitHash(hash string, count int) ([]string, error) {
+	parts := strings.Split(hash, hashSeparator)
+	if len(parts) != count+2 || parts[0] != "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, invalidHashMessage)
+	}
+	return parts[2:], nil
+}
+
+// decodeSaltAndKey decodes the encoded salt and key of hash.
+func decodeSaltAndKey(encodedSalt, encodedKey string) (salt, key []byte, err error) {
+	if salt, err = hashEncoding.DecodeString(encodedSalt); err != nil {
+		return nil, nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+	}
+	if key, err = hashEncoding.DecodeString(encodedKey); err != nil {
+		return nil, nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+	}
+	if len(salt) == 0 || len(key) == 0 {
+		return nil, nil, gerror.NewCode(gcode.CodeInvalidParameter, invalidHashMessage)
+	}
+	return salt, key, nil
+}
+
+// equalKeys compares the keys in constant time.
+func equalKeys(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Argon2idOption is the cost parameters of argon2id algorithm.
+type Argon2idOption struct {
+	Memory      uint32 `json:"memory"`      // Memory size in KiB, which is 65536 (64MiB) in default.
+	Iterations  uint32 `json:"iterations"`  // Number of passes over the memory, which is 3 in default.
+	Parallelism uint8  `json:"parallelism"` // Number of threads, which is 2 in default.
+	SaltLength  uint32 `json:"saltLength"`  // Length of random salt in bytes, which is 16 in default.
+	KeyLength   uint32 `json:"keyLength"`   // Length of derived key in bytes, which is 32 in default.
+}
+
+var defaultArgon2idOption = Argon2idOption{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  defaultSaltLength,
+	KeyLength:   defaultKeyLength,
+}
+
+// HashArgon2id creates and returns the hash of `password` using argon2id algorithm.
+// The optional parameter `option` specifies the cost parameters, or else the configured ones are used.
+func HashArgon2id(password string, option ...Argon2idOption) (string, error) {
+	o := GetConfig().Argon2id
+	if len(option) > 0 {
+		var err error
+		if o, err = option[0].normalize(); err != nil {
+			return "", err
+		}
+	}
+	salt, err := newSalt(o.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, o.Iterations, o.Memory, o.Parallelism, o.KeyLength)
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id, argon2.Version, o.Memory, o.Iterations, o.Parallelism,
+		hashEncoding.EncodeToString(salt), hashEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2id checks whether `password` matches argon2id `hash`.
+func verifyArgon2id(password, hash string) (bool, error) {
+	o, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	return equalKeys(argon2.IDKey([]byte(password), salt, o.Iterations, o.Memory, o.Parallelism, o.KeyLength), key), nil
+}
+
+// parseArgon2id parses argon2id `hash` and returns its cost parameters, salt and key.
+func parseArgon2id(hash string) (o Argon2idOption, salt, key []byte, err error) {
+	parts, err := splitHash(hash, 4)
+	if err != nil {
+		return
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		err = gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported argon2id version "%s"`, parts[0])
+		return
+	}
+	if _, err = fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &o.Memory, &o.Iterations, &o.Parallelism); err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+		return
+	}
+	if o.Memory == 0 || o.Iterations == 0 || o.Parallelism == 0 {
+		err = gerror.NewCode(gcode.CodeInvalidParameter, invalidHashMessage)
+		return
+	}
+	if salt, key, err = decodeSaltAndKey(parts[2], parts[3]); err != nil {
+		return
+	}
+	o.SaltLength, o.KeyLength = uint32(len(salt)), uint32(len(key))
+	return
+}
+
+// normalize replaces the zero values of option with default values.
+func (o Argon2idOption) normalize() (Argon2idOption, error) {
+	if o.Memory == 0 {
+		o.Memory = defaultArgon2idOption.Memory
+	}
+	if o.Iterations == 0 {
+		o.Iterations = defaultArgon2idOption.Iterations
+	}
+	if o.Parallelism == 0 {
+		o.Parallelism = defaultArgon2idOption.Parallelism
+	}
+	if o.SaltLength == 0 {
+		o.SaltLength = defaultArgon2idOption.SaltLength
+	}
+	if o.KeyLength == 0 {
+		o.KeyLength = defaultArgon2idOption.KeyLength
+	}
+	if o.Memory < 8*uint32(o.Parallelism) {
+		return o, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`argon2id memory %d should be at least 8 times of parallelism %d`, o.Memory, o.Parallelism,
+		)
+	}
+	return o, nil
+}
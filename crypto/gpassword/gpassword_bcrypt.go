@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// BcryptOption is the cost parameters of bcrypt algorithm.
+//
+// Note that bcrypt uses only the first 72 bytes of password, and the longer password results in error.
+type BcryptOption struct {
+	Cost int `json:"cost"` // Cost in range [4, 31], which is 12 in default.
+}
+
+var defaultBcryptOption = BcryptOption{
+	Cost: 12,
+}
+
+// HashBcrypt creates and returns the hash of `password` using bcrypt algorithm.
+// The optional parameter `option` specifies the cost parameters, or else the configured ones are used.
+func HashBcrypt(password string, option ...BcryptOption) (string, error) {
+	o := GetConfig().Bcrypt
+	if len(option) > 0 {
+		var err error
+		if o, err = option[0].normalize(); err != nil {
+			return "", err
+		}
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), o.Cost)
+	if err != nil {
+		return "", gerror.WrapCode(gcode.CodeInvalidParameter, err, `bcrypt hash failed`)
+	}
+	return string(hash), nil
+}
+
+// verifyBcrypt checks whether `password` matches bcrypt `hash`.
+func verifyBcrypt(password, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+	}
+}
+
+// parseBcrypt parses bcrypt `hash` and returns its cost parameters.
+func parseBcrypt(hash string) (o BcryptOption, err error) {
+	if o.Cost, err = bcrypt.Cost([]byte(hash)); err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+	}
+	return
+}
+
+// isBcryptHash checks whether `hash` is in bcrypt format, like "$2a$", "$2b$" or "$2y$".
+func isBcryptHash(hash string) bool {
+	return len(hash) > 4 && strings.HasPrefix(hash, "$2") && strings.IndexByte(hash[2:4], '$') >= 0
+}
+
+// normalize replaces the zero values of option with default values.
+func (o BcryptOption) normalize() (BcryptOption, error) {
+	if o.Cost == 0 {
+		o.Cost = defaultBcryptOption.Cost
+	}
+	if o.Cost < bcrypt.MinCost || o.Cost > bcrypt.MaxCost {
+		return o, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`bcrypt cost %d should be in range [%d, %d]`, o.Cost, bcrypt.MinCost, bcrypt.MaxCost,
+		)
+	}
+	return o, nil
+}
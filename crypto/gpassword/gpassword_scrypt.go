@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ScryptOption is the cost parameters of scrypt algorithm.
+type ScryptOption struct {
+	LogN       uint8  `json:"logN"`       // Base 2 logarithm of CPU/memory cost N, which is 15 (N=32768) in default.
+	R          uint32 `json:"r"`          // Block size, which is 8 in default.
+	P          uint32 `json:"p"`          // Parallelization, which is 1 in default.
+	SaltLength uint32 `json:"saltLength"` // Length of random salt in bytes, which is 16 in default.
+	KeyLength  uint32 `json:"keyLength"`  // Length of derived key in bytes, which is 32 in default.
+}
+
+var defaultScryptOption = ScryptOption{
+	LogN:       15,
+	R:          8,
+	P:          1,
+	SaltLength: defaultSaltLength,
+	KeyLength:  defaultKeyLength,
+}
+
+// HashScrypt creates and returns the hash of `password` using scrypt algorithm.
+// The optional parameter `option` specifies the cost parameters, or else the configured ones are used.
+func HashScrypt(password string, option ...ScryptOption) (string, error) {
+	o := GetConfig().Scrypt
+	if len(option) > 0 {
+		var err error
+		if o, err = option[0].normalize(); err != nil {
+			return "", err
+		}
+	}
+	salt, err := newSalt(o.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	key, err := o.deriveKey(password, salt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"$%s$ln=%d,r=%d,p=%d$%s$%s",
+		AlgorithmScrypt, o.LogN, o.R, o.P,
+		hashEncoding.EncodeToString(salt), hashEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyScrypt checks whether `password` matches scrypt `hash`.
+func verifyScrypt(password, hash string) (bool, error) {
+	o, salt, key, err := parseScrypt(hash)
+	if err != nil {
+		return false, err
+	}
+	derivedKey, err := o.deriveKey(password, salt)
+	if err != nil {
+		return false, err
+	}
+	return equalKeys(derivedKey, key), nil
+}
+
+// parseScrypt parses scrypt `hash` and returns its cost parameters, salt and key.
+func parseScrypt(hash string) (o ScryptOption, salt, key []byte, err error) {
+	parts, err := splitHash(hash, 3)
+	if err != nil {
+		return
+	}
+	if _, err = fmt.Sscanf(parts[0], "ln=%d,r=%d,p=%d", &o.LogN, &o.R, &o.P); err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, invalidHashMessage)
+		return
+	}
+	if salt, key, err = decodeSaltAndKey(parts[1], parts[2]); err != nil {
+		return
+	}
+	o.SaltLength, o.KeyLength = uint32(len(salt)), uint32(len(key))
+	return
+}
+
+// deriveKey derives the key of `password` and `salt` using the cost parameters.
+func (o ScryptOption) deriveKey(password string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(password), salt, 1<<o.LogN, int(o.R), int(o.P), int(o.KeyLength))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `scrypt derive key failed`)
+	}
+	return key, nil
+}
+
+// normalize replaces the zero values of option with default values.
+func (o ScryptOption) normalize() (ScryptOption, error) {
+	if o.LogN == 0 {
+		o.LogN = defaultScryptOption.LogN
+	}
+	if o.R == 0 {
+		o.R = defaultScryptOption.R
+	}
+	if o.P == 0 {
+		o.P = defaultScryptOption.P
+	}
+	if o.SaltLength == 0 {
+		o.SaltLength = defaultScryptOption.SaltLength
+	}
+	if o.KeyLength == 0 {
+		o.KeyLength = defaultScryptOption.KeyLength
+	}
+	if o.LogN > 30 {
+		return o, gerror.NewCodef(gcode.CodeInvalidParameter, `scrypt logN %d should be no greater than 30`, o.LogN)
+	}
+	return o, nil
+}
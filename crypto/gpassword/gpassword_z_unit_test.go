@@ -0,0 +1,160 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gpassword"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	password       = "p@ssw0rd"
+	argon2idOption = gpassword.Argon2idOption{Memory: 1024, Iterations: 1, Parallelism: 1}
+	bcryptOption   = gpassword.BcryptOption{Cost: 4}
+	scryptOption   = gpassword.ScryptOption{LogN: 4}
+)
+
+func Test_Argon2id(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		hash, err := gpassword.HashArgon2id(password, argon2idOption)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), true)
+		t.Assert(gpassword.Algorithm(hash), gpassword.AlgorithmArgon2id)
+
+		ok, err := gpassword.Verify(password, hash)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		ok, err = gpassword.Verify(password+"x", hash)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		hash2, err := gpassword.HashArgon2id(password, argon2idOption)
+		t.AssertNil(err)
+		t.AssertNE(hash, hash2)
+	})
+}
+
+func Test_Bcrypt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		hash, err := gpassword.HashBcrypt(password, bcryptOption)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$2a$04$"), true)
+		t.Assert(gpassword.Algorithm(hash), gpassword.AlgorithmBcrypt)
+
+		ok, err := gpassword.Verify(password, hash)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		ok, err = gpassword.Verify(password+"x", hash)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		_, err = gpassword.HashBcrypt(password, gpassword.BcryptOption{Cost: 32})
+		t.AssertNE(err, nil)
+		_, err = gpassword.HashBcrypt(strings.Repeat("x", 73), bcryptOption)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Scrypt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		hash, err := gpassword.HashScrypt(password, scryptOption)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$scrypt$ln=4,r=8,p=1$"), true)
+		t.Assert(gpassword.Algorithm(hash), gpassword.AlgorithmScrypt)
+
+		ok, err := gpassword.Verify(password, hash)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		ok, err = gpassword.Verify(password+"x", hash)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+	})
+}
+
+func Test_Verify_InvalidHash(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, hash := range []string{
+			"",
+			"plain",
+			"$md5$abc",
+			"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=1024,t=1,p=1$!!$a2V5",
+			"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+			"$scrypt$ln=x,r=8,p=1$c2FsdA$a2V5",
+			"$2a$04$short",
+		} {
+			ok, err := gpassword.Verify(password, hash)
+			t.Assert(ok, false)
+			t.AssertNE(err, nil)
+			t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+			t.Assert(gpassword.NeedsRehash(hash), true)
+		}
+	})
+}
+
+func Test_Hash_NeedsRehash(t *testing.T) {
+	defer gpassword.SetConfig(gpassword.Config{})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gpassword.SetConfig(gpassword.Config{
+			Argon2id: argon2idOption,
+			Bcrypt:   bcryptOption,
+			Scrypt:   scryptOption,
+		}))
+		t.Assert(gpassword.GetConfig().Algorithm, gpassword.AlgorithmArgon2id)
+		t.Assert(gpassword.GetConfig().Argon2id.SaltLength, 16)
+
+		hashArgon2id := gpassword.MustHash(password)
+		t.Assert(gpassword.Algorithm(hashArgon2id), gpassword.AlgorithmArgon2id)
+		t.Assert(gpassword.NeedsRehash(hashArgon2id), false)
+
+		// Upgrade cost parameters.
+		t.AssertNil(gpassword.SetConfig(gpassword.Config{
+			Argon2id: gpassword.Argon2idOption{Memory: 2048, Iterations: 1, Parallelism: 1},
+			Bcrypt:   bcryptOption,
+			Scrypt:   scryptOption,
+		}))
+		t.Assert(gpassword.NeedsRehash(hashArgon2id), true)
+		ok, err := gpassword.Verify(password, hashArgon2id)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		// Switch algorithm.
+		t.AssertNil(gpassword.SetConfig(gpassword.Config{
+			Algorithm: gpassword.AlgorithmBcrypt,
+			Bcrypt:    bcryptOption,
+		}))
+		t.Assert(gpassword.NeedsRehash(hashArgon2id), true)
+		hashBcrypt := gpassword.MustHash(password)
+		t.Assert(gpassword.Algorithm(hashBcrypt), gpassword.AlgorithmBcrypt)
+		t.Assert(gpassword.NeedsRehash(hashBcrypt), false)
+
+		t.AssertNil(gpassword.SetConfig(gpassword.Config{
+			Algorithm: gpassword.AlgorithmScrypt,
+			Scrypt:    scryptOption,
+		}))
+		t.Assert(gpassword.NeedsRehash(hashBcrypt), true)
+		hashScrypt := gpassword.MustHash(password)
+		t.Assert(gpassword.Algorithm(hashScrypt), gpassword.AlgorithmScrypt)
+		t.Assert(gpassword.NeedsRehash(hashScrypt), false)
+		t.Assert(gpassword.NeedsRehash(strings.Replace(hashScrypt, "r=8", "r=4", 1)), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gpassword.SetConfig(gpassword.Config{Algorithm: "md5"})
+		t.Assert(gerror.Code(err), gcode.CodeInvalidConfiguration)
+		t.AssertNE(gpassword.SetConfig(gpassword.Config{Bcrypt: gpassword.BcryptOption{Cost: 3}}), nil)
+		t.AssertNE(gpassword.SetConfig(gpassword.Config{Scrypt: gpassword.ScryptOption{LogN: 31}}), nil)
+		t.AssertNE(gpassword.SetConfig(gpassword.Config{
+			Argon2id: gpassword.Argon2idOption{Memory: 8, Parallelism: 2},
+		}), nil)
+	})
+}
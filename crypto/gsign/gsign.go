@@ -0,0 +1,138 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gsign provides digital signature API using Ed25519 and ECDSA P-256 algorithms,
+// including key generation, PEM/JWK encoding and detached signatures,
+// which are usually used for signing tokens and webhooks.
+//
+// The private keys are ed25519.PrivateKey or *ecdsa.PrivateKey, and the public keys are
+// ed25519.PublicKey or *ecdsa.PublicKey.
+package gsign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	AlgorithmEd25519 = "EdDSA" // Ed25519 algorithm, named as in JWS.
+	AlgorithmES256   = "ES256" // ECDSA using P-256 and SHA-256 algorithm, named as in JWS.
+)
+
+// es256CoordinateSize is the byte size of P-256 coordinates and signature components.
+const es256CoordinateSize = 32
+
+// GenerateKey generates and returns a new private key of `algorithm`,
+// which is AlgorithmEd25519 or AlgorithmES256.
+// The public key can be retrieved by Public method of the private key.
+func GenerateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case AlgorithmEd25519:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate Ed25519 key failed`)
+		}
+		return privateKey, nil
+	case AlgorithmES256:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate ECDSA P-256 key failed`)
+		}
+		return privateKey, nil
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported signature algorithm "%s"`, algorithm)
+	}
+}
+
+// Algorithm returns the signature algorithm of `key`, which can be private or public key.
+// It returns empty string if `key` is not supported.
+func Algorithm(key interface{}) string {
+	switch k := key.(type) {
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return AlgorithmEd25519
+	case *ecdsa.PrivateKey:
+		if k.Curve == elliptic.P256() {
+			return AlgorithmES256
+		}
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return AlgorithmES256
+		}
+	}
+	return ""
+}
+
+// Sign signs `data` using `privateKey` and returns the signature.
+//
+// The ECDSA signature is in fixed-length format r||s of 64 bytes as in JWS, rather than ASN.1 format.
+func Sign(privateKey crypto.PrivateKey, data []byte) ([]byte, error) {
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		if len(key) != ed25519.PrivateKeySize {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid Ed25519 private key size`)
+		}
+		return ed25519.Sign(key, data), nil
+	case *ecdsa.PrivateKey:
+		if Algorithm(key) != AlgorithmES256 {
+			return nil, gerror.NewCode(gcode.CodeNotSupported, `only P-256 curve is supported for ECDSA`)
+		}
+		digest := sha256.Sum256(data)
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInternalError, err, `ECDSA sign failed`)
+		}
+		signature := make([]byte, 2*es256CoordinateSize)
+		r.FillBytes(signature[:es256CoordinateSize])
+		s.FillBytes(signature[es256CoordinateSize:])
+		return signature, nil
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported private key type %T`, privateKey)
+	}
+}
+
+// Verify checks whether `signature` is the valid signature of `data` signed by private key of `publicKey`.
+// It returns false if the signature is invalid, and returns error if `publicKey` is not supported.
+func Verify(publicKey crypto.PublicKey, data, signature []byte) (bool, error) {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if len(key) != ed25519.PublicKeySize {
+			return false, gerror.NewCode(gcode.CodeInvalidParameter, `invalid Ed25519 public key size`)
+		}
+		return ed25519.Verify(key, data, signature), nil
+	case *ecdsa.PublicKey:
+		if Algorithm(key) != AlgorithmES256 {
+			return false, gerror.NewCode(gcode.CodeNotSupported, `only P-256 curve is supported for ECDSA`)
+		}
+		if len(signature) != 2*es256CoordinateSize {
+			return false, nil
+		}
+		var (
+			digest = sha256.Sum256(data)
+			r      = new(big.Int).SetBytes(signature[:es256CoordinateSize])
+			s      = new(big.Int).SetBytes(signature[es256CoordinateSize:])
+		)
+		return ecdsa.Verify(key, digest[:], r, s), nil
+	default:
+		return false, gerror.NewCodef(gcode.CodeNotSupported, `unsupported public key type %T`, publicKey)
+	}
+}
+
+// PublicKey returns the public key of `privateKey`.
+func PublicKey(privateKey crypto.PrivateKey) (crypto.PublicKey, error) {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok || Algorithm(privateKey) == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported private key type %T`, privateKey)
+	}
+	return signer.Public(), nil
+}
@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsign
+
+import (
+	"crypto"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// detachedHeader is the protected header of detached signature.
+type detachedHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// SignDetached signs `payload` using `privateKey` and returns the detached signature, which is
+// the JWS compact serialization with detached content defined in RFC 7515 Appendix F, like
+// "<header>..<signature>".
+//
+// The payload is not included in the signature, so it is usually used for signing the content
+// transferred separately, like the body of webhook request.
+// The optional parameter `keyId` specifies the key id "kid" in the header, which can be retrieved
+// by DetachedKeyId for choosing the verification key.
+func SignDetached(privateKey crypto.PrivateKey, payload []byte, keyId ...string) (string, error) {
+	header := detachedHeader{
+		Alg: Algorithm(privateKey),
+	}
+	if len(keyId) > 0 {
+		header.Kid = keyId[0]
+	}
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	encodedHeader := jwkEncoding.EncodeToString(headerJson)
+	signature, err := Sign(privateKey, detachedSigningInput(encodedHeader, payload))
+	if err != nil {
+		return "", err
+	}
+	return encodedHeader + ".." + jwkEncoding.EncodeToString(signature), nil
+}
+
+// VerifyDetached checks whether detached `signature` created by SignDetached is valid for `payload`
+// and `publicKey`. It returns error if `signature` is malformed or its algorithm does not match `publicKey`.
+func VerifyDetached(publicKey crypto.PublicKey, payload []byte, signature string) (bool, error) {
+	encodedHeader, header, rawSignature, err := parseDetached(signature)
+	if err != nil {
+		return false, err
+	}
+	if header.Alg != Algorithm(publicKey) {
+		return false, gerror.NewCodef(
+			gcode.CodeSecurityReason,
+			`detached signature algorithm "%s" does not match public key type %T`, header.Alg, publicKey,
+		)
+	}
+	return Verify(publicKey, detachedSigningInput(encodedHeader, payload), rawSignature)
+}
+
+// DetachedKeyId returns the key id "kid" in the header of detached `signature`.
+func DetachedKeyId(signature string) (string, error) {
+	_, header, _, err := parseDetached(signature)
+	if err != nil {
+		return "", err
+	}
+	return header.Kid, nil
+}
+
+// parseDetached parses detached `signature` and returns its encoded header, header and raw signature.
+func parseDetached(signature string) (encodedHeader string, header detachedHeader, rawSignature []byte, err error) {
+	parts := strings.Split(signature, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		err = gerror.NewCode(gcode.CodeInvalidParameter, `invalid detached signature`)
+		return
+	}
+	encodedHeader = parts[0]
+	headerJson, err := jwkEncoding.DecodeString(encodedHeader)
+	if err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid detached signature header`)
+		return
+	}
+	if err = json.Unmarshal(headerJson, &header); err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid detached signature header`)
+		return
+	}
+	if rawSignature, err = jwkEncoding.DecodeString(parts[2]); err != nil {
+		err = gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid detached signature`)
+	}
+	return
+}
+
+// detachedSigningInput returns the JWS signing input, which is "<header>.<base64url payload>".
+func detachedSigningInput(encodedHeader string, payload []byte) []byte {
+	return []byte(encodedHeader + "." + jwkEncoding.EncodeToString(payload))
+}
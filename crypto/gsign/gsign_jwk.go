@@ -0,0 +1,202 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsign
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// JWK is the JSON Web Key defined in RFC 7517, which holds Ed25519 or ECDSA P-256 key.
+// The private key parameter D is empty for public key.
+type JWK struct {
+	Kty string `json:"kty"`           // Key type, "OKP" for Ed25519 and "EC" for ECDSA.
+	Crv string `json:"crv"`           // Curve, "Ed25519" or "P-256".
+	X   string `json:"x"`             // Public key, or X coordinate of ECDSA public key.
+	Y   string `json:"y,omitempty"`   // Y coordinate of ECDSA public key.
+	D   string `json:"d,omitempty"`   // Private key, which is seed of Ed25519 key.
+	Alg string `json:"alg,omitempty"` // Algorithm, "EdDSA" or "ES256".
+	Kid string `json:"kid,omitempty"` // Key id, which is usually used for key rotation.
+	Use string `json:"use,omitempty"` // Public key use, like "sig".
+}
+
+const (
+	jwkKtyOKP     = "OKP"
+	jwkKtyEC      = "EC"
+	jwkCrvEd25519 = "Ed25519"
+	jwkCrvP256    = "P-256"
+	jwkUseSig     = "sig"
+)
+
+// jwkEncoding is the base64url encoding without padding used in JWK.
+var jwkEncoding = base64.RawURLEncoding
+
+// NewJWK creates and returns the JWK of `key`, which can be private or public key.
+// The optional parameter `keyId` specifies the key id "kid" of JWK.
+func NewJWK(key interface{}, keyId ...string) (*JWK, error) {
+	jwk := &JWK{
+		Alg: Algorithm(key),
+		Use: jwkUseSig,
+	}
+	if len(keyId) > 0 {
+		jwk.Kid = keyId[0]
+	}
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		jwk.setEd25519(k.Public().(ed25519.PublicKey))
+		jwk.D = jwkEncoding.EncodeToString(k.Seed())
+	case ed25519.PublicKey:
+		jwk.setEd25519(k)
+	case *ecdsa.PrivateKey:
+		if jwk.Alg == "" {
+			break
+		}
+		jwk.setES256(&k.PublicKey)
+		jwk.D = jwkEncoding.EncodeToString(k.D.FillBytes(make([]byte, es256CoordinateSize)))
+	case *ecdsa.PublicKey:
+		if jwk.Alg == "" {
+			break
+		}
+		jwk.setES256(k)
+	}
+	if jwk.Alg == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported key type %T`, key)
+	}
+	return jwk, nil
+}
+
+// EncodeJWK encodes `key` to JWK in JSON, see NewJWK.
+func EncodeJWK(key interface{}, keyId ...string) ([]byte, error) {
+	jwk, err := NewJWK(key, keyId...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jwk)
+}
+
+// DecodeJWK decodes the key from JWK `data` in JSON.
+// It returns private key if the JWK contains private key parameter, or else public key.
+func DecodeJWK(data []byte) (interface{}, error) {
+	var jwk *JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid JWK data`)
+	}
+	if jwk == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid JWK data`)
+	}
+	return jwk.Key()
+}
+
+// IsPrivate checks whether the JWK contains private key.
+func (jwk *JWK) IsPrivate() bool {
+	return jwk.D != ""
+}
+
+// Key returns the key of the JWK, which is private key if the JWK contains private key parameter,
+// or else public key.
+func (jwk *JWK) Key() (interface{}, error) {
+	switch {
+	case jwk.Kty == jwkKtyOKP && jwk.Crv == jwkCrvEd25519:
+		return jwk.ed25519Key()
+	case jwk.Kty == jwkKtyEC && jwk.Crv == jwkCrvP256:
+		return jwk.es256Key()
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported JWK kty "%s" crv "%s"`, jwk.Kty, jwk.Crv)
+	}
+}
+
+func (jwk *JWK) setEd25519(publicKey ed25519.PublicKey) {
+	jwk.Kty, jwk.Crv = jwkKtyOKP, jwkCrvEd25519
+	jwk.X = jwkEncoding.EncodeToString(publicKey)
+}
+
+func (jwk *JWK) setES256(publicKey *ecdsa.PublicKey) {
+	jwk.Kty, jwk.Crv = jwkKtyEC, jwkCrvP256
+	jwk.X = jwkEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, es256CoordinateSize)))
+	jwk.Y = jwkEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, es256CoordinateSize)))
+}
+
+func (jwk *JWK) ed25519Key() (interface{}, error) {
+	x, err := decodeJWKParameter("x", jwk.X, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, err
+	}
+	if !jwk.IsPrivate() {
+		return ed25519.PublicKey(x), nil
+	}
+	d, err := decodeJWKParameter("d", jwk.D, ed25519.SeedSize)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := ed25519.NewKeyFromSeed(d)
+	if !bytes.Equal(privateKey.Public().(ed25519.PublicKey), x) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `JWK private key does not match public key`)
+	}
+	return privateKey, nil
+}
+
+func (jwk *JWK) es256Key() (interface{}, error) {
+	x, err := decodeJWKParameter("x", jwk.X, es256CoordinateSize)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeJWKParameter("y", jwk.Y, es256CoordinateSize)
+	if err != nil {
+		return nil, err
+	}
+	// The uncompressed point is validated to be on the curve by ecdh.
+	point := append(append([]byte{4}, x...), y...)
+	if _, err = ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid JWK public key`)
+	}
+	publicKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !jwk.IsPrivate() {
+		return publicKey, nil
+	}
+	d, err := decodeJWKParameter("d", jwk.D, es256CoordinateSize)
+	if err != nil {
+		return nil, err
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid JWK private key`)
+	}
+	if !bytes.Equal(ecdhKey.PublicKey().Bytes(), point) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `JWK private key does not match public key`)
+	}
+	return &ecdsa.PrivateKey{
+		PublicKey: *publicKey,
+		D:         new(big.Int).SetBytes(d),
+	}, nil
+}
+
+// decodeJWKParameter decodes the base64url encoded JWK parameter `name` and checks its size.
+func decodeJWKParameter(name, value string, size int) ([]byte, error) {
+	data, err := jwkEncoding.DecodeString(value)
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid JWK parameter "%s"`, name)
+	}
+	if len(data) != size {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid JWK parameter "%s" size %d, expected %d`, name, len(data), size,
+		)
+	}
+	return data, nil
+}
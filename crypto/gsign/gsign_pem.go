@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsign
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	pemTypePrivateKey   = "PRIVATE KEY"    // PKCS #8 private key.
+	pemTypeECPrivateKey = "EC PRIVATE KEY" // SEC 1 EC private key.
+	pemTypePublicKey    = "PUBLIC KEY"     // PKIX public key.
+)
+
+// EncodePrivateKeyPEM encodes `privateKey` to PEM block in PKCS #8 format.
+func EncodePrivateKeyPEM(privateKey crypto.PrivateKey) ([]byte, error) {
+	if Algorithm(privateKey) == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported private key type %T`, privateKey)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `marshal private key failed`)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM encodes `publicKey` to PEM block in PKIX format.
+func EncodePublicKeyPEM(publicKey crypto.PublicKey) ([]byte, error) {
+	if Algorithm(publicKey) == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported public key type %T`, publicKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `marshal public key failed`)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM decodes the private key from PEM block `data`,
+// which is in PKCS #8 format, or SEC 1 format for ECDSA key.
+func DecodePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid PEM data`)
+	}
+	var (
+		key interface{}
+		err error
+	)
+	switch block.Type {
+	case pemTypePrivateKey:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case pemTypeECPrivateKey:
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unexpected PEM block type "%s"`, block.Type)
+	}
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `parse private key failed`)
+	}
+	if Algorithm(key) == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported private key type %T`, key)
+	}
+	return key.(crypto.Signer), nil
+}
+
+// DecodePublicKeyPEM decodes the public key from PEM block `data` in PKIX format.
+func DecodePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid PEM data`)
+	}
+	if block.Type != pemTypePublicKey {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unexpected PEM block type "%s"`, block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `parse public key failed`)
+	}
+	if Algorithm(key) == "" {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported public key type %T`, key)
+	}
+	return key, nil
+}
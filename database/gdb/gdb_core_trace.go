@@ -10,10 +10,10 @@ package gdb
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gogf/gf/v2/net/gtrace"
@@ -47,14 +47,20 @@ func (c *Core) traceSpanEnd(ctx context.Context, span trace.Span, sql *Sql) {
 	labels = append(labels, gtrace.CommonLabels()...)
 	labels = append(labels,
 		attribute.String(traceAttrDbType, c.db.GetConfig().Type),
-		semconv.DBStatement(sql.Format),
+		gtrace.RowsAffectedAttribute(sql.RowsAffected),
 	)
+	labels = append(labels, gtrace.DataAttributes(c.db.GetConfig().Type, sql.Format)...)
 	if c.db.GetConfig().Host != "" {
 		labels = append(labels, attribute.String(traceAttrDbHost, c.db.GetConfig().Host))
 	}
 	if c.db.GetConfig().Port != "" {
 		labels = append(labels, attribute.String(traceAttrDbPort, c.db.GetConfig().Port))
 	}
+	if c.db.GetConfig().Host != "" {
+		labels = append(labels, gtrace.PeerAttributes(
+			net.JoinHostPort(c.db.GetConfig().Host, c.db.GetConfig().Port),
+		)...)
+	}
 	if c.db.GetConfig().Name != "" {
 		labels = append(labels, attribute.String(traceAttrDbName, c.db.GetConfig().Name))
 	}
@@ -42,3 +42,14 @@ func Instance(name ...string) *Redis {
 	}
 	return nil
 }
+
+// RemoveInstance removes the instance of redis client with specified group from instance management,
+// so that a new instance is created by the next calling of Instance. It is commonly called after the
+// instance is closed. Note that it does not close the removed instance.
+func RemoveInstance(name ...string) {
+	group := DefaultGroupName
+	if len(name) > 0 && name[0] != "" {
+		group = name[0]
+	}
+	localInstances.Remove(group)
+}
@@ -0,0 +1,224 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdebug
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Goroutine is the goroutine parsed from the stack traces of all goroutines.
+type Goroutine struct {
+	Id        int64    // Id of goroutine.
+	State     string   // State of goroutine, like "running", "chan receive".
+	Functions []string // Functions of stack frames, the first is the top frame.
+	CreatedBy string   // Creation site of goroutine, like "main.main in goroutine 1\n\t/path/main.go:10".
+	Stack     string   // Stack trace of goroutine.
+}
+
+var (
+	// ignoredGoroutinesMu protects ignoredGoroutines.
+	ignoredGoroutinesMu sync.RWMutex
+
+	// ignoredGoroutines are the function prefixes of goroutines ignored in leak detection,
+	// which are the long-lived goroutines of runtime, testing and framework.
+	ignoredGoroutines = []string{
+		"testing.",
+		"runtime.ensureSigM",
+		"os/signal.",
+		"github.com/gogf/gf/v2/os/gtimer.",
+		"github.com/gogf/gf/v2/os/gfsnotify.",
+		"github.com/gogf/gf/v2/os/gproc.",
+		"github.com/fsnotify/fsnotify.",
+	}
+)
+
+// IgnoreGoroutines adds the function prefixes of goroutines ignored in leak detection, like
+// "github.com/gogf/gf/v2/os/gtimer.". The goroutine is ignored if any function of its stack
+// frames has any of the prefixes.
+func IgnoreGoroutines(functionPrefixes ...string) {
+	ignoredGoroutinesMu.Lock()
+	defer ignoredGoroutinesMu.Unlock()
+	ignoredGoroutines = append(ignoredGoroutines, functionPrefixes...)
+}
+
+// Goroutines returns all the current goroutines, sorted by id.
+func Goroutines() []*Goroutine {
+	buffer := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buffer, true)
+		if n < len(buffer) {
+			buffer = buffer[:n]
+			break
+		}
+		buffer = make([]byte, 2*len(buffer))
+	}
+	var goroutines []*Goroutine
+	for _, block := range bytes.Split(buffer, []byte("\n\n")) {
+		if goroutine := parseGoroutine(string(block)); goroutine != nil {
+			goroutines = append(goroutines, goroutine)
+		}
+	}
+	sort.Slice(goroutines, func(i, j int) bool {
+		return goroutines[i].Id < goroutines[j].Id
+	})
+	return goroutines
+}
+
+// GoroutineSnapshot is the snapshot of goroutines, which is used for goroutine leak detection.
+type GoroutineSnapshot struct {
+	ids map[int64]struct{}
+}
+
+// SnapshotGoroutines takes and returns the snapshot of current goroutines.
+//
+// Example:
+//
+//	snapshot := gdebug.SnapshotGoroutines()
+//	runTest()
+//	if leaked := snapshot.Leaked(time.Second); len(leaked) > 0 {
+//		fmt.Println(gdebug.FormatGoroutines(leaked))
+//	}
+func SnapshotGoroutines() *GoroutineSnapshot {
+	snapshot := &GoroutineSnapshot{
+		ids: make(map[int64]struct{}),
+	}
+	for _, goroutine := range Goroutines() {
+		snapshot.ids[goroutine.Id] = struct{}{}
+	}
+	return snapshot
+}
+
+// Leaked returns the goroutines created after the snapshot and still running, excluding the
+// ignored ones. It waits up to `timeout` for the goroutines exiting, as the goroutines might
+// be exiting asynchronously. The optional parameter `ignores` specifies more function prefixes
+// of goroutines ignored, see IgnoreGoroutines.
+func (s *GoroutineSnapshot) Leaked(timeout time.Duration, ignores ...string) []*Goroutine {
+	ignoredGoroutinesMu.RLock()
+	ignores = append(ignores, ignoredGoroutines...)
+	ignoredGoroutinesMu.RUnlock()
+	var (
+		leaked   []*Goroutine
+		deadline = time.Now().Add(timeout)
+		current  = currentGoroutineId()
+	)
+	for {
+		leaked = leaked[:0]
+		for _, goroutine := range Goroutines() {
+			if _, ok := s.ids[goroutine.Id]; ok || goroutine.Id == current {
+				continue
+			}
+			if goroutine.hasFunctionPrefix(ignores) {
+				continue
+			}
+			leaked = append(leaked, goroutine)
+		}
+		if len(leaked) == 0 || !time.Now().Before(deadline) {
+			return leaked
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// FormatGoroutines returns the readable report of `goroutines`, including their stacks
+// and creation sites.
+func FormatGoroutines(goroutines []*Goroutine) string {
+	buffer := bytes.NewBuffer(nil)
+	for i, goroutine := range goroutines {
+		if i > 0 {
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(fmt.Sprintf("%d. goroutine %d [%s]\n", i+1, goroutine.Id, goroutine.State))
+		if goroutine.CreatedBy != "" {
+			buffer.WriteString(fmt.Sprintf("created by %s\n", goroutine.CreatedBy))
+		}
+		buffer.WriteString(goroutine.Stack)
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+// hasFunctionPrefix checks whether any function of goroutine stack frames has any of `prefixes`.
+func (g *Goroutine) hasFunctionPrefix(prefixes []string) bool {
+	for _, function := range g.Functions {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(function, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseGoroutine parses the goroutine from its stack trace block, like:
+//
+//	goroutine 18 [chan receive]:
+//	main.worker(...)
+//		/path/main.go:20 +0x25
+//	created by main.main in goroutine 1
+//		/path/main.go:10 +0x1d
+func parseGoroutine(block string) *Goroutine {
+	block = strings.TrimSpace(block)
+	lines := strings.Split(block, "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "goroutine ") {
+		return nil
+	}
+	// Header like: goroutine 18 [chan receive, 2 minutes]:
+	header := strings.TrimSuffix(strings.TrimPrefix(lines[0], "goroutine "), ":")
+	idStr, state, _ := strings.Cut(header, " ")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return nil
+	}
+	state = strings.Trim(state, "[]")
+	if pos := strings.Index(state, ","); pos != -1 {
+		state = state[:pos]
+	}
+	goroutine := &Goroutine{
+		Id:    id,
+		State: state,
+		Stack: strings.Join(lines[1:], "\n"),
+	}
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "\t") || line == "" {
+			continue
+		}
+		if createdBy, ok := strings.CutPrefix(line, "created by "); ok {
+			goroutine.CreatedBy = createdBy
+			if i+1 < len(lines) {
+				goroutine.CreatedBy += "\n" + lines[i+1]
+			}
+			// The creation function is also considered for ignoring.
+			if function, _, _ := strings.Cut(createdBy, " in goroutine"); function != "" {
+				goroutine.Functions = append(goroutine.Functions, function)
+			}
+			break
+		}
+		// Function line like: main.worker(0xc000012345)
+		if pos := strings.LastIndex(line, "("); pos > 0 {
+			line = line[:pos]
+		}
+		goroutine.Functions = append(goroutine.Functions, line)
+	}
+	return goroutine
+}
+
+// currentGoroutineId returns the id of current goroutine.
+func currentGoroutineId() int64 {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+	idStr, _, _ := strings.Cut(strings.TrimPrefix(string(buffer), "goroutine "), " ")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	return id
+}
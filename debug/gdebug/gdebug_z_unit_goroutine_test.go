@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdebug_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func leakyWorker(ch chan struct{}) {
+	<-ch
+}
+
+func Test_Goroutines(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		goroutines := gdebug.Goroutines()
+		t.AssertGT(len(goroutines), 0)
+		for i := 1; i < len(goroutines); i++ {
+			t.AssertLT(goroutines[i-1].Id, goroutines[i].Id)
+		}
+	})
+}
+
+func Test_GoroutineSnapshot_Leaked(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ch       = make(chan struct{})
+			snapshot = gdebug.SnapshotGoroutines()
+		)
+		go leakyWorker(ch)
+
+		leaked := snapshot.Leaked(50 * time.Millisecond)
+		t.Assert(len(leaked), 1)
+		t.Assert(leaked[0].State, "chan receive")
+		t.Assert(leaked[0].Functions[0], "github.com/gogf/gf/v2/debug/gdebug_test.leakyWorker")
+		t.Assert(strings.Contains(leaked[0].CreatedBy, "Test_GoroutineSnapshot_Leaked"), true)
+
+		report := gdebug.FormatGoroutines(leaked)
+		t.Assert(strings.Contains(report, "[chan receive]"), true)
+		t.Assert(strings.Contains(report, "gdebug_z_unit_goroutine_test.go"), true)
+
+		// Ignored.
+		t.Assert(len(snapshot.Leaked(0, "github.com/gogf/gf/v2/debug/gdebug_test.leakyWorker")), 0)
+
+		// Exits asynchronously.
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(ch)
+		}()
+		t.Assert(len(snapshot.Leaked(time.Second)), 0)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcode
+
+import (
+	"sync"
+)
+
+// Registration holds the attributes of a registered error code,
+// which are used by the components mapping error codes to protocol statuses, like ghttp and grpcx.
+type Registration struct {
+	// HttpStatus is the HTTP status for the error code, like http.StatusNotFound.
+	// It is not mapped if it is not positive.
+	HttpStatus int
+
+	// GrpcCode is the gRPC code for the error code, like uint32(codes.NotFound).
+	// It is not mapped if it is 0, which is codes.OK of gRPC.
+	GrpcCode uint32
+
+	// Message is the default message for the error code,
+	// which is used if the error has neither text nor code message.
+	Message string
+}
+
+var (
+	// registrations is the registered error codes, mapping error code number to registration.
+	registrations   = make(map[int]Registration)
+	registrationsMu sync.RWMutex
+)
+
+// Register registers `code` with its HTTP status, gRPC code and default message,
+// so the errors having this code are mapped to the correct statuses automatically by components,
+// and it returns the given `code` for convenient declaration, like:
+//
+//	var CodeUserNotFound = gcode.Register(gcode.New(10001, "User Not Found", nil), gcode.Registration{
+//		HttpStatus: http.StatusNotFound,
+//		GrpcCode:   uint32(codes.NotFound),
+//	})
+//
+// Note that the registration of the same code number overwrites the previous one.
+func Register(code Code, registration Registration) Code {
+	if code == nil {
+		return code
+	}
+	registrationsMu.Lock()
+	defer registrationsMu.Unlock()
+	registrations[code.Code()] = registration
+	return code
+}
+
+// GetRegistration returns the registration of `code`.
+// It returns false if `code` is not registered.
+func GetRegistration(code Code) (registration Registration, ok bool) {
+	if code == nil {
+		return
+	}
+	registrationsMu.RLock()
+	defer registrationsMu.RUnlock()
+	registration, ok = registrations[code.Code()]
+	return
+}
+
+// HttpStatus returns the HTTP status of `code` that is registered.
+// It returns false if `code` is not registered or has no HTTP status mapped.
+func HttpStatus(code Code) (int, bool) {
+	registration, ok := GetRegistration(code)
+	if !ok || registration.HttpStatus <= 0 {
+		return 0, false
+	}
+	return registration.HttpStatus, true
+}
+
+// GrpcCode returns the gRPC code of `code` that is registered.
+// It returns false if `code` is not registered or has no gRPC code mapped.
+func GrpcCode(code Code) (uint32, bool) {
+	registration, ok := GetRegistration(code)
+	if !ok || registration.GrpcCode == 0 {
+		return 0, false
+	}
+	return registration.GrpcCode, true
+}
@@ -36,3 +36,36 @@ func Test_WithCode(t *testing.T) {
 		t.Assert(c.Detail(), "CodeInternalError")
 	})
 }
+
+func Test_Register(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		code := gcode.Register(gcode.New(10001, "User Not Found", nil), gcode.Registration{
+			HttpStatus: 404,
+			GrpcCode:   5,
+			Message:    "user does not exist",
+		})
+		t.Assert(code.Code(), 10001)
+
+		registration, ok := gcode.GetRegistration(gcode.New(10001, "", nil))
+		t.Assert(ok, true)
+		t.Assert(registration.Message, "user does not exist")
+
+		status, ok := gcode.HttpStatus(code)
+		t.Assert(ok, true)
+		t.Assert(status, 404)
+
+		grpcCode, ok := gcode.GrpcCode(code)
+		t.Assert(ok, true)
+		t.Assert(grpcCode, 5)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		code := gcode.Register(gcode.New(10002, "Only Http", nil), gcode.Registration{HttpStatus: 400})
+		_, ok := gcode.GrpcCode(code)
+		t.Assert(ok, false)
+
+		_, ok = gcode.HttpStatus(gcode.New(10003, "", nil))
+		t.Assert(ok, false)
+		_, ok = gcode.GetRegistration(nil)
+		t.Assert(ok, false)
+	})
+}
@@ -24,9 +24,10 @@ func New(text string) error {
 // Newf returns an error that formats as the given format and args.
 func Newf(format string, args ...interface{}) error {
 	return &Error{
-		stack: callers(),
-		text:  fmt.Sprintf(format, args...),
-		code:  gcode.CodeNil,
+		stack:  callers(),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   gcode.CodeNil,
 	}
 }
 
@@ -44,9 +45,10 @@ func NewSkip(skip int, text string) error {
 // The parameter `skip` specifies the stack callers skipped amount.
 func NewSkipf(skip int, format string, args ...interface{}) error {
 	return &Error{
-		stack: callers(skip),
-		text:  fmt.Sprintf(format, args...),
-		code:  gcode.CodeNil,
+		stack:  callers(skip),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   gcode.CodeNil,
 	}
 }
 
@@ -72,10 +74,11 @@ func Wrapf(err error, format string, args ...interface{}) error {
 		return nil
 	}
 	return &Error{
-		error: err,
-		stack: callers(),
-		text:  fmt.Sprintf(format, args...),
-		code:  Code(err),
+		error:  err,
+		stack:  callers(),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   Code(err),
 	}
 }
 
@@ -102,9 +105,10 @@ func WrapSkipf(skip int, err error, format string, args ...interface{}) error {
 		return nil
 	}
 	return &Error{
-		error: err,
-		stack: callers(skip),
-		text:  fmt.Sprintf(format, args...),
-		code:  Code(err),
+		error:  err,
+		stack:  callers(skip),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   Code(err),
 	}
 }
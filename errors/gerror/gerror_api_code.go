@@ -25,9 +25,10 @@ func NewCode(code gcode.Code, text ...string) error {
 // NewCodef returns an error that has error code and formats as the given format and args.
 func NewCodef(code gcode.Code, format string, args ...interface{}) error {
 	return &Error{
-		stack: callers(),
-		text:  fmt.Sprintf(format, args...),
-		code:  code,
+		stack:  callers(),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   code,
 	}
 }
 
@@ -45,9 +46,10 @@ func NewCodeSkip(code gcode.Code, skip int, text ...string) error {
 // The parameter `skip` specifies the stack callers skipped amount.
 func NewCodeSkipf(code gcode.Code, skip int, format string, args ...interface{}) error {
 	return &Error{
-		stack: callers(skip),
-		text:  fmt.Sprintf(format, args...),
-		code:  code,
+		stack:  callers(skip),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   code,
 	}
 }
 
@@ -72,10 +74,11 @@ func WrapCodef(code gcode.Code, err error, format string, args ...interface{}) e
 		return nil
 	}
 	return &Error{
-		error: err,
-		stack: callers(),
-		text:  fmt.Sprintf(format, args...),
-		code:  code,
+		error:  err,
+		stack:  callers(),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   code,
 	}
 }
 
@@ -102,10 +105,11 @@ func WrapCodeSkipf(code gcode.Code, skip int, err error, format string, args ...
 		return nil
 	}
 	return &Error{
-		error: err,
-		stack: callers(skip),
-		text:  fmt.Sprintf(format, args...),
-		code:  code,
+		error:  err,
+		stack:  callers(skip),
+		text:   fmt.Sprintf(format, args...),
+		format: format,
+		code:   code,
 	}
 }
 
@@ -135,5 +139,13 @@ func HasCode(err error, code gcode.Code) bool {
 	if e, ok := err.(IUnwrap); ok {
 		return HasCode(e.Unwrap(), code)
 	}
+	// Aggregated errors like MultiError.
+	if e, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, child := range e.Unwrap() {
+			if HasCode(child, code) {
+				return true
+			}
+		}
+	}
 	return false
 }
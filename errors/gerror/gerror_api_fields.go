@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// WithFields attaches structured metadata `fields` to `err`, like:
+//
+//	gerror.WithFields(err, g.Map{"order_id": id})
+//
+// The fields are preserved through wrapping and can be retrieved by function Fields,
+// which are rendered as structured data by components like glog and ghttp.
+// It returns nil if given `err` is nil.
+//
+// Note that the returned error has the same error string, code and stack as `err`.
+func WithFields(err error, fields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	var copied = make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return &Error{
+		error:  err,
+		code:   gcode.CodeNil,
+		fields: copied,
+	}
+}
+
+// Fields returns all the structured metadata fields attached to `err` and its wrapped errors.
+// The fields of outer errors overwrite the ones of wrapped errors having the same keys.
+// It returns nil if there's no field attached.
+func Fields(err error) map[string]interface{} {
+	var levels []map[string]interface{}
+	for err != nil {
+		if e, ok := err.(*Error); ok && len(e.fields) > 0 {
+			levels = append(levels, e.fields)
+		}
+		err = Unwrap(err)
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+	var fields = make(map[string]interface{})
+	for i := len(levels) - 1; i >= 0; i-- {
+		for k, v := range levels[i] {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// isFieldsOnly checks and returns whether current level error only carries fields,
+// which is created by WithFields.
+func (err *Error) isFieldsOnly() bool {
+	return err.text == "" && err.stack == nil && err.fields != nil && err.error != nil
+}
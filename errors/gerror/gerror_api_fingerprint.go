@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// fingerprintStackDepth is the count of top stack frames used in fingerprint.
+	fingerprintStackDepth = 3
+)
+
+// Fingerprint returns a stable fingerprint of `err`, which is derived from the error code,
+// the message template of root error and the top stack frames where root error is created.
+// The identical failures have the same fingerprint even if they have different message arguments,
+// so it can be used for grouping errors and deduplicating alerts in logs and monitoring.
+// It returns an empty string if `err` is nil.
+//
+// Note that the message template is available only if the root error is created by formatting
+// functions like Newf, or else the message of root error is used.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	hash := fnv.New64a()
+	writeFingerprint(hash, err)
+	return fmt.Sprintf(`%016x`, hash.Sum64())
+}
+
+// Fingerprint returns a stable fingerprint of current error.
+// See function Fingerprint.
+func (err *Error) Fingerprint() string {
+	if err == nil {
+		return ""
+	}
+	return Fingerprint(err)
+}
+
+// Fingerprint returns a stable fingerprint of current error,
+// which is derived from the fingerprints of all child errors.
+func (err *MultiError) Fingerprint() string {
+	if err == nil {
+		return ""
+	}
+	return Fingerprint(err)
+}
+
+// writeFingerprint writes the fingerprint content of `err` to `w`.
+func writeFingerprint(w io.Writer, err error) {
+	if e, ok := err.(*MultiError); ok {
+		for _, child := range e.errors {
+			_, _ = w.Write([]byte(Fingerprint(child)))
+		}
+		return
+	}
+	_, _ = w.Write([]byte(strconv.Itoa(Code(err).Code())))
+	// The root error, which is the deepest *Error, or the first error that is not *Error.
+	var (
+		root  = err
+		stack stack
+	)
+	for {
+		e, ok := root.(*Error)
+		if !ok {
+			break
+		}
+		if e.stack != nil {
+			stack = e.stack
+		}
+		if e.error == nil {
+			break
+		}
+		root = e.error
+	}
+	switch e := root.(type) {
+	case *Error:
+		if e.format != "" {
+			_, _ = w.Write([]byte(e.format))
+		} else {
+			_, _ = w.Write([]byte(fmt.Sprintf(`%-v`, e)))
+		}
+	case *MultiError:
+		writeFingerprint(w, e)
+	default:
+		_, _ = w.Write([]byte(e.Error()))
+	}
+	// Top stack frames, in which the line numbers are ignored for stability between source changes.
+	var count int
+	for _, p := range stack {
+		if count >= fingerprintStackDepth {
+			break
+		}
+		if fn := runtime.FuncForPC(p - 1); fn != nil {
+			_, _ = w.Write([]byte(fn.Name()))
+			count++
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// TranslateFunc translates the i18n message `key` with arguments `args`, which is usually implemented by gi18n.
+type TranslateFunc func(key string, args ...interface{}) string
+
+// NewI18n creates and returns an error with i18n message `key` and its arguments `args`,
+// the message of which is translated at rendering time, like gi18n.TranslateError.
+// The Error function of the returned error returns the `key` as error text.
+func NewI18n(key string, args ...interface{}) error {
+	return &Error{
+		stack:    callers(),
+		text:     key,
+		code:     gcode.CodeNil,
+		i18nArgs: args,
+		i18n:     true,
+	}
+}
+
+// NewCodeI18n creates and returns an error that has error code and i18n message `key` with its arguments `args`.
+// The message is translated at rendering time, like gi18n.TranslateError.
+func NewCodeI18n(code gcode.Code, key string, args ...interface{}) error {
+	return &Error{
+		stack:    callers(),
+		text:     key,
+		code:     code,
+		i18nArgs: args,
+		i18n:     true,
+	}
+}
+
+// WrapI18n wraps error with i18n message `key` and its arguments `args`.
+// It returns nil if given `err` is nil.
+// Note that it does not lose the error code of wrapped error, as it inherits the error code from it.
+func WrapI18n(err error, key string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		error:    err,
+		stack:    callers(),
+		text:     key,
+		code:     Code(err),
+		i18nArgs: args,
+		i18n:     true,
+	}
+}
+
+// Translate returns the error string of `err` like function Error, in which the i18n message keys of
+// all levels are translated by `translate`. The levels having no i18n message key are kept as they are.
+func Translate(err error, translate TranslateFunc) string {
+	if err == nil {
+		return ""
+	}
+	switch e := err.(type) {
+	case *Error:
+		if e == nil {
+			return ""
+		}
+		errStr := e.text
+		if e.i18n {
+			errStr = translate(e.text, e.i18nArgs...)
+		} else if errStr == "" && e.code != nil {
+			errStr = e.code.Message()
+		}
+		if e.error != nil {
+			if errStr != "" {
+				errStr += ": "
+			}
+			errStr += Translate(e.error, translate)
+		}
+		return errStr
+
+	case *MultiError:
+		if e == nil {
+			return ""
+		}
+		var texts = make([]string, len(e.errors))
+		for i, child := range e.errors {
+			texts[i] = Translate(child, translate)
+		}
+		return strings.Join(texts, multiErrorSeparator)
+
+	default:
+		return err.Error()
+	}
+}
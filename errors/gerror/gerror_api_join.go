@@ -0,0 +1,29 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+// Join returns an error that aggregates the given errors, which is usually used for collecting errors
+// of parallel operations or validation batches. Any nil error is discarded.
+// It returns nil if all errors are nil.
+//
+// The returned error preserves the stack and code of each child error, and it supports errors.Is
+// and errors.As across all child errors.
+func Join(errs ...error) error {
+	var children = make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			children = append(children, err)
+		}
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	return &MultiError{
+		errors: children,
+		stack:  callers(),
+	}
+}
@@ -14,15 +14,19 @@ type Option struct {
 	Stack bool       // Whether recording stack information into error.
 	Text  string     // Error text, which is created by New* functions.
 	Code  gcode.Code // Error code if necessary.
+
+	// StackOption is the custom stack option for the error, which uses the global stack option if it is nil.
+	StackOption *StackOption
 }
 
 // NewWithOption creates and returns a custom error with Option.
 // It is the senior usage for creating error, which is often used internally in framework.
 func NewWithOption(option Option) error {
 	err := &Error{
-		error: option.Error,
-		text:  option.Text,
-		code:  option.Code,
+		error:       option.Error,
+		text:        option.Text,
+		code:        option.Code,
+		stackOption: option.StackOption,
 	}
 	if option.Stack {
 		err.stack = callers()
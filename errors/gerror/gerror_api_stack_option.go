@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"strings"
+	"sync"
+)
+
+// StackOption is the option for filtering the frames in error stacks,
+// which makes the stacks concise in production logs.
+type StackOption struct {
+	// SkipPatterns specifies the frames to be skipped, a frame is skipped
+	// if its function name or file path contains any of the patterns,
+	// like "github.com/gogf/gf/v2/net/ghttp" for skipping framework internals of HTTP server.
+	SkipPatterns []string
+
+	// MaxDepth limits the frame count of each error in stack, it does not limit if it is not positive.
+	MaxDepth int
+
+	// TrimPrefixes are the prefixes trimmed from the file paths of frames,
+	// like GOPATH or the root path of the project.
+	TrimPrefixes []string
+}
+
+var (
+	// globalStackOption is the stack option for all errors without custom stack option.
+	globalStackOption   StackOption
+	globalStackOptionMu sync.RWMutex
+)
+
+// SetStackOption sets the global stack option for all errors that have no custom stack option.
+func SetStackOption(option StackOption) {
+	globalStackOptionMu.Lock()
+	defer globalStackOptionMu.Unlock()
+	globalStackOption = option
+}
+
+// GetStackOption returns the global stack option.
+func GetStackOption() StackOption {
+	globalStackOptionMu.RLock()
+	defer globalStackOptionMu.RUnlock()
+	return globalStackOption
+}
+
+// WithStackOption returns a copy of current error with custom stack option, which overwrites the global
+// stack option. The current error is not changed, as it might be shared, like the predefined errors.
+func (err *Error) WithStackOption(option StackOption) *Error {
+	if err == nil {
+		return nil
+	}
+	copied := *err
+	copied.stackOption = &option
+	return &copied
+}
+
+// getStackOption returns the stack option of `err`, which is the global stack option if it has no custom one.
+func (err *Error) getStackOption() StackOption {
+	if err != nil && err.stackOption != nil {
+		return *err.stackOption
+	}
+	return GetStackOption()
+}
+
+// isSkipped checks and returns whether the frame of `function` and `file` should be skipped.
+func (option StackOption) isSkipped(function, file string) bool {
+	for _, pattern := range option.SkipPatterns {
+		if pattern != "" && (strings.Contains(function, pattern) || strings.Contains(file, pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimFile trims the configured prefixes from file path `file`.
+func (option StackOption) trimFile(file string) string {
+	for _, prefix := range option.TrimPrefixes {
+		if prefix != "" && strings.HasPrefix(file, prefix) {
+			return strings.TrimLeft(file[len(prefix):], "/")
+		}
+	}
+	return file
+}
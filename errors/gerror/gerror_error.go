@@ -21,6 +21,12 @@ type Error struct {
 	stack stack      // Stack array, which records the stack information when this error is created or wrapped.
 	text  string     // Custom Error text when Error is created, might be empty when its code is not nil.
 	code  gcode.Code // Error code if necessary.
+
+	stackOption *StackOption           // Custom stack option, which uses the global stack option if it is nil.
+	i18nArgs    []interface{}          // Arguments of i18n message key, the key of which is stored as text.
+	i18n        bool                   // Whether the text is an i18n message key, which is translated in rendering.
+	fields      map[string]interface{} // Structured metadata fields attached by WithFields.
+	format      string                 // Format of text if it is created by formatting functions, like Newf.
 }
 
 const (
@@ -91,10 +97,15 @@ func (err *Error) Current() error {
 		return nil
 	}
 	return &Error{
-		error: nil,
-		stack: err.stack,
-		text:  err.text,
-		code:  err.code,
+		error:       nil,
+		stack:       err.stack,
+		text:        err.text,
+		code:        err.code,
+		stackOption: err.stackOption,
+		i18nArgs:    err.i18nArgs,
+		i18n:        err.i18n,
+		fields:      err.fields,
+		format:      err.format,
 	}
 }
 
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/internal/errors"
+)
+
+// MultiError is the error aggregating multiple errors, which is created by Join.
+type MultiError struct {
+	errors []error // Child errors in sequence, none of which is nil.
+	stack  stack   // Stack array, which records the stack information when this error is created.
+}
+
+const (
+	// multiErrorSeparator is the separator of child error strings.
+	multiErrorSeparator = "; "
+	// multiErrorStackIndent is the indent of child error stacks.
+	multiErrorStackIndent = "    "
+)
+
+// Error implements the interface of Error, it returns all the child errors as string joined by "; ".
+func (err *MultiError) Error() string {
+	if err == nil {
+		return ""
+	}
+	var texts = make([]string, len(err.errors))
+	for i, e := range err.errors {
+		texts[i] = e.Error()
+	}
+	return strings.Join(texts, multiErrorSeparator)
+}
+
+// Errors returns a copy of the child errors.
+func (err *MultiError) Errors() []error {
+	if err == nil {
+		return nil
+	}
+	var errs = make([]error, len(err.errors))
+	copy(errs, err.errors)
+	return errs
+}
+
+// Unwrap returns the child errors.
+// It is just for implements for stdlib errors.Is and errors.As from Go version 1.20.
+func (err *MultiError) Unwrap() []error {
+	if err == nil {
+		return nil
+	}
+	return err.errors
+}
+
+// Code returns the first error code of child errors.
+// It returns CodeNil if none of the child errors has error code.
+func (err *MultiError) Code() gcode.Code {
+	if err == nil {
+		return gcode.CodeNil
+	}
+	for _, e := range err.errors {
+		if code := Code(e); code != gcode.CodeNil {
+			return code
+		}
+	}
+	return gcode.CodeNil
+}
+
+// Stack returns the combined stack information of the MultiError and all its child errors as string,
+// in which the stack of each child error is indented.
+func (err *MultiError) Stack() string {
+	if err == nil {
+		return ""
+	}
+	var (
+		buffer = bytes.NewBuffer(nil)
+		info   = &stackInfo{
+			Index:   1,
+			Message: fmt.Sprintf("%d errors occurred", len(err.errors)),
+		}
+	)
+	loopLinesOfStackInfo(err.stack, info, errors.IsStackModeBrief(), GetStackOption())
+	buffer.WriteString(formatStackInfos([]*stackInfo{info}))
+	for i, e := range err.errors {
+		buffer.WriteString(fmt.Sprintf("%d. error %d of %d\n", i+2, i+1, len(err.errors)))
+		for _, line := range strings.Split(strings.TrimRight(Stack(e), "\n"), "\n") {
+			buffer.WriteString(multiErrorStackIndent + line + "\n")
+		}
+	}
+	return buffer.String()
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (err *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(err.Error())
+}
+
+// Format formats the MultiError according to the fmt.Formatter interface.
+//
+// %v, %s, %-v, %-s : Print all the child error strings;
+// %+s              : Print combined stack of all the child errors;
+// %+v              : Print the error string and combined stack of all the child errors.
+func (err *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		switch {
+		case s.Flag('+'):
+			if verb == 's' {
+				_, _ = io.WriteString(s, err.Stack())
+			} else {
+				_, _ = io.WriteString(s, err.Error()+"\n"+err.Stack())
+			}
+		default:
+			_, _ = io.WriteString(s, err.Error())
+		}
+	}
+}
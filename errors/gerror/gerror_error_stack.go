@@ -42,13 +42,20 @@ func (err *Error) Stack() string {
 		isStackModeBrief = errors.IsStackModeBrief()
 	)
 	for loop != nil {
+		// The level only carrying fields is not a real error level.
+		if loop.isFieldsOnly() {
+			if e, ok := loop.error.(*Error); ok {
+				loop = e
+				continue
+			}
+		}
 		info := &stackInfo{
 			Index:   index,
 			Message: fmt.Sprintf("%-v", loop),
 		}
 		index++
 		infos = append(infos, info)
-		loopLinesOfStackInfo(loop.stack, info, isStackModeBrief)
+		loopLinesOfStackInfo(loop.stack, info, isStackModeBrief, loop.getStackOption())
 		if loop.error != nil {
 			if e, ok := loop.error.(*Error); ok {
 				loop = e
@@ -133,11 +140,15 @@ func formatStackLines(buffer *bytes.Buffer, lines *list.List) string {
 }
 
 // loopLinesOfStackInfo iterates the stack info lines and produces the stack line info.
-func loopLinesOfStackInfo(st stack, info *stackInfo, isStackModeBrief bool) {
+// The frames are filtered and trimmed by `option`.
+func loopLinesOfStackInfo(st stack, info *stackInfo, isStackModeBrief bool, option StackOption) {
 	if st == nil {
 		return
 	}
 	for _, p := range st {
+		if option.MaxDepth > 0 && info.Lines != nil && info.Lines.Len() >= option.MaxDepth {
+			break
+		}
 		if fn := runtime.FuncForPC(p - 1); fn != nil {
 			file, line := fn.FileLine(p - 1)
 			if isStackModeBrief {
@@ -161,12 +172,15 @@ func loopLinesOfStackInfo(st stack, info *stackInfo, isStackModeBrief bool) {
 				file[0:len(goRootForFilter)] == goRootForFilter {
 				continue
 			}
+			if option.isSkipped(fn.Name(), file) {
+				continue
+			}
 			if info.Lines == nil {
 				info.Lines = list.New()
 			}
 			info.Lines.PushBack(&stackLine{
 				Function: fn.Name(),
-				FileLine: fmt.Sprintf(`%s:%d`, file, line),
+				FileLine: fmt.Sprintf(`%s:%d`, option.trimFile(file), line),
 			})
 		}
 	}
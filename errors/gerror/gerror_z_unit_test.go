@@ -11,10 +11,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/gogf/gf/v2/debug/gdebug"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
 )
 
 func nilError() error {
@@ -472,3 +475,160 @@ func Test_NewOption(t *testing.T) {
 		}), gerror.New("NewOptionError"))
 	})
 }
+
+func Test_Join(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gerror.Join())
+		t.AssertNil(gerror.Join(nil, nil))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			err1 = gerror.NewCode(gcode.CodeInvalidParameter, "invalid name")
+			err2 = gerror.Wrap(errors.New("connection refused"), "query failed")
+			err3 = errors.New("not gerror")
+			err  = gerror.Join(err1, nil, err2, err3)
+		)
+		t.Assert(err.Error(), "invalid name; query failed: connection refused; not gerror")
+		t.Assert(fmt.Sprintf("%v", err), err.Error())
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+		t.Assert(gerror.HasCode(err, gcode.CodeInvalidParameter), true)
+		t.Assert(gerror.HasCode(err, gcode.CodeInternalError), false)
+		t.Assert(gerror.Is(err, err1), true)
+		t.Assert(gerror.Is(err, err3), true)
+		t.Assert(gerror.Is(err, errors.New("not gerror")), false)
+
+		var gerr *gerror.Error
+		t.Assert(errors.As(err, &gerr), true)
+		t.Assert(gerr.Code(), gcode.CodeInvalidParameter)
+
+		multiErr, ok := err.(*gerror.MultiError)
+		t.Assert(ok, true)
+		t.Assert(len(multiErr.Errors()), 3)
+		t.Assert(multiErr.Errors()[1], err2)
+
+		jsonBytes, jsonErr := json.Marshal(err)
+		t.AssertNil(jsonErr)
+		t.Assert(string(jsonBytes), `"invalid name; query failed: connection refused; not gerror"`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			err   = gerror.Join(gerror.New("error 1"), gerror.Wrap(gerror.New("error 2"), "wrapped"))
+			stack = gerror.Stack(err)
+		)
+		t.Assert(gstr.HasPrefix(stack, "1. 2 errors occurred\n"), true)
+		t.Assert(gstr.Contains(stack, "2. error 1 of 2\n    1. error 1\n"), true)
+		t.Assert(gstr.Contains(stack, "3. error 2 of 2\n    1. wrapped\n"), true)
+		t.Assert(gstr.Contains(stack, "    2. error 2\n"), true)
+		t.Assert(gstr.Contains(stack, "Test_Join"), true)
+		t.Assert(fmt.Sprintf("%+v", err), err.Error()+"\n"+stack)
+	})
+}
+
+func Test_StackOption(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.NewWithOption(gerror.Option{
+			Text:  "custom",
+			Stack: true,
+			StackOption: &gerror.StackOption{
+				SkipPatterns: []string{"testing.tRunner"},
+				MaxDepth:     1,
+				TrimPrefixes: []string{gfile.Dir(gdebug.CallerFilePath())},
+			},
+		})
+		stack := gerror.Stack(err)
+		t.Assert(gstr.Count(stack, ").  "), 1)
+		t.Assert(gstr.Contains(stack, "\n        gerror_z_unit_test.go:"), true)
+		t.Assert(gstr.Contains(stack, "testing.tRunner"), false)
+
+		// Current level error keeps the custom stack option.
+		t.Assert(gstr.Count(gerror.Stack(gerror.Current(err)), ").  "), 1)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gerror.SetStackOption(gerror.StackOption{MaxDepth: 1})
+		defer gerror.SetStackOption(gerror.StackOption{})
+		t.Assert(gerror.GetStackOption().MaxDepth, 1)
+
+		inner := gerror.New("1")
+		err := gerror.Wrap(inner, "2")
+		t.Assert(gstr.Count(gerror.Stack(err), ").  "), 2)
+
+		// Custom stack option overwrites the global one, which does not change the original error.
+		copied := err.(*gerror.Error).WithStackOption(gerror.StackOption{
+			SkipPatterns: []string{"Test_StackOption", "gtest"},
+		})
+		t.Assert(gstr.HasPrefix(gerror.Stack(copied), "1. 2\n2. 1\n"), true)
+		t.Assert(copied.Error(), err.Error())
+		t.Assert(gstr.Count(gerror.Stack(err), ").  "), 2)
+	})
+}
+
+func Test_I18n(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			translate = func(key string, args ...interface{}) string {
+				return fmt.Sprintf("T("+key+")", args...)
+			}
+			err = gerror.WrapI18n(gerror.New("raw"), "order.failed:%d", 1)
+		)
+		t.Assert(err.Error(), "order.failed:%d: raw")
+		t.Assert(gerror.Translate(err, translate), "T(order.failed:1): raw")
+		t.Assert(gerror.Translate(gerror.Current(err), translate), "T(order.failed:1)")
+		t.Assert(gerror.Translate(gerror.NewCode(gcode.CodeNotFound), translate), "Not Found")
+		t.Assert(gerror.Translate(errors.New("std"), translate), "std")
+		t.AssertNil(gerror.WrapI18n(nil, "key"))
+	})
+}
+
+func Test_Fields(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gerror.WithFields(nil, map[string]interface{}{"a": 1}))
+		t.AssertNil(gerror.Fields(nil))
+		t.AssertNil(gerror.Fields(gerror.New("no fields")))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			inner  = gerror.NewCode(gcode.CodeNotFound, "order not found")
+			fields = map[string]interface{}{"order_id": 1, "user_id": 2}
+			err    = gerror.WithFields(inner, fields)
+		)
+		fields["order_id"] = 100
+		t.Assert(err.Error(), "order not found")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		t.Assert(gerror.Is(err, inner), true)
+		t.Assert(gerror.Stack(err), gerror.Stack(inner))
+		t.Assert(gerror.Fields(err), map[string]interface{}{"order_id": 1, "user_id": 2})
+
+		err = gerror.Wrap(gerror.WithFields(gerror.Wrap(err, "wrapped"), map[string]interface{}{"user_id": 3}), "outer")
+		t.Assert(err.Error(), "outer: wrapped: order not found")
+		t.Assert(gerror.Fields(err), map[string]interface{}{"order_id": 1, "user_id": 3})
+	})
+}
+
+func newFingerprintError(id int) error {
+	return gerror.NewCodef(gcode.CodeNotFound, "order %d not found", id)
+}
+
+func Test_Fingerprint(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gerror.Fingerprint(nil), "")
+
+		var (
+			err1 = gerror.Wrap(newFingerprintError(1), "query failed")
+			err2 = gerror.Wrap(newFingerprintError(2), "query failed")
+			err3 = gerror.NewCodef(gcode.CodeNotFound, "order %d not found", 1)
+		)
+		t.Assert(len(gerror.Fingerprint(err1)), 16)
+		t.Assert(gerror.Fingerprint(err1), gerror.Fingerprint(err2))
+		t.Assert(gerror.Fingerprint(err1), err1.(*gerror.Error).Fingerprint())
+		t.AssertNE(gerror.Fingerprint(err1), gerror.Fingerprint(err3))
+		t.AssertNE(
+			gerror.Fingerprint(errors.New("a")),
+			gerror.Fingerprint(errors.New("b")),
+		)
+
+		join1 := gerror.Join(err1, errors.New("a"))
+		join2 := gerror.Join(err2, errors.New("a"))
+		t.Assert(gerror.Fingerprint(join1), gerror.Fingerprint(join2))
+		t.Assert(gerror.Fingerprint(join1), join1.(*gerror.MultiError).Fingerprint())
+	})
+}
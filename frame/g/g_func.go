@@ -11,6 +11,7 @@ import (
 	"io"
 
 	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/frame/gins"
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gproc"
@@ -50,6 +51,20 @@ func Listen() {
 	gproc.Listen()
 }
 
+// ValidateBoot eagerly creates and pings every database, redis and server instance named in
+// configuration, and returns the consolidated report, which is commonly called in the boot
+// so that misconfiguration fails at startup rather than on first request.
+// See gins.ValidateBoot.
+func ValidateBoot(ctx context.Context) (*gins.BootReport, error) {
+	return gins.ValidateBoot(ctx)
+}
+
+// InitEager creates all the instances of eager initialization policy.
+// See gins.InitEager.
+func InitEager(ctx context.Context) error {
+	return gins.InitEager(ctx)
+}
+
 // Dump dumps a variable to stdout with more manually readable.
 func Dump(values ...interface{}) {
 	gutil.Dump(values...)
@@ -9,6 +9,7 @@ package g
 import (
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/frame/gdi"
 	"github.com/gogf/gf/v2/frame/gins"
 	"github.com/gogf/gf/v2/i18n/gi18n"
 	"github.com/gogf/gf/v2/net/gclient"
@@ -87,6 +88,13 @@ func DB(name ...string) gdb.DB {
 	return gins.Database(name...)
 }
 
+// OverrideDB replaces the database instance of group `name` with `db` for testing,
+// and returns the function restoring the previous instance.
+// See gins.OverrideDatabase.
+func OverrideDB(name string, db gdb.DB) (restore func()) {
+	return gins.OverrideDatabase(name, db)
+}
+
 // Model creates and returns a model based on configuration of default database group.
 func Model(tableNameOrStruct ...interface{}) *gdb.Model {
 	return DB().Model(tableNameOrStruct...)
@@ -102,7 +110,20 @@ func Redis(name ...string) *gredis.Redis {
 	return gins.Redis(name...)
 }
 
+// OverrideRedis replaces the redis instance of group `name` with `redis` for testing,
+// and returns the function restoring the previous instance.
+// See gins.OverrideRedis.
+func OverrideRedis(name string, redis *gredis.Redis) (restore func()) {
+	return gins.OverrideRedis(name, redis)
+}
+
 // Validator is a convenience function, which creates and returns a new validation manager object.
 func Validator() *gvalid.Validator {
 	return gvalid.New()
 }
+
+// Container returns the default dependency injection container,
+// which registers and resolves the services of application.
+func Container() *gdi.Container {
+	return gdi.Default()
+}
@@ -7,6 +7,7 @@
 package g
 
 import (
+	"github.com/gogf/gf/v2/frame/gins"
 	"github.com/gogf/gf/v2/internal/utils"
 )
 
@@ -16,3 +17,10 @@ import (
 func SetDebug(enabled bool) {
 	utils.SetDebugEnabled(enabled)
 }
+
+// SetInitPolicy sets the initialization policy of instance `key`, like "database.default",
+// which is created on its first use in default.
+// See gins.SetInitPolicy.
+func SetInitPolicy(key string, policy gins.InitPolicy) {
+	gins.SetInitPolicy(key, policy)
+}
@@ -0,0 +1,104 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gdi provides a lightweight dependency injection container.
+//
+// The services are registered by constructor functions, whose parameters are the dependencies
+// resolved from the container by type, and created lazily on resolution or eagerly by Start.
+// The singleton services implementing Starter or Stopper are started in creation order and stopped
+// in reverse order, so the dependencies are always started before and stopped after their dependents.
+package gdi
+
+import (
+	"context"
+	"reflect"
+)
+
+// Scope is the lifetime scope of service.
+type Scope int
+
+const (
+	// ScopeSingleton creates the service only once in the container, which is the default scope.
+	ScopeSingleton Scope = iota
+	// ScopeTransient creates a new service on each resolution.
+	ScopeTransient
+)
+
+// ProvideOption is the option for service registration.
+type ProvideOption struct {
+	// Name is the name of service, which distinguishes multiple services of the same type.
+	Name string
+
+	// Scope is the lifetime scope of service, which is ScopeSingleton in default.
+	Scope Scope
+}
+
+// Starter is the interface for singleton services that should be started when container starts.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is the interface for singleton services that should be stopped when container stops.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+var (
+	// defaultContainer is the default container of package.
+	defaultContainer = New()
+
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Default returns the default container.
+func Default() *Container {
+	return defaultContainer
+}
+
+// Provide registers service constructor to the default container.
+// See Container.Provide.
+func Provide(constructor interface{}, option ...ProvideOption) error {
+	return defaultContainer.Provide(constructor, option...)
+}
+
+// ProvideValue registers an existing service to the default container.
+// See Container.ProvideValue.
+func ProvideValue(value interface{}, name ...string) error {
+	return defaultContainer.ProvideValue(value, name...)
+}
+
+// Resolve resolves the service from the default container into `pointer`.
+// See Container.Resolve.
+func Resolve(ctx context.Context, pointer interface{}, name ...string) error {
+	return defaultContainer.Resolve(ctx, pointer, name...)
+}
+
+// MustResolve resolves the service from the default container into `pointer`.
+// It panics if any error occurs.
+func MustResolve(ctx context.Context, pointer interface{}, name ...string) {
+	if err := defaultContainer.Resolve(ctx, pointer, name...); err != nil {
+		panic(err)
+	}
+}
+
+// Invoke calls `function` with parameters resolved from the default container.
+// See Container.Invoke.
+func Invoke(ctx context.Context, function interface{}) error {
+	return defaultContainer.Invoke(ctx, function)
+}
+
+// Start starts the default container.
+// See Container.Start.
+func Start(ctx context.Context) error {
+	return defaultContainer.Start(ctx)
+}
+
+// Stop stops the default container.
+// See Container.Stop.
+func Stop(ctx context.Context) error {
+	return defaultContainer.Stop(ctx)
+}
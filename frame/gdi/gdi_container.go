@@ -0,0 +1,357 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Container is the dependency injection container managing the services and their lifecycle.
+type Container struct {
+	mu        sync.RWMutex
+	providers map[providerKey]*provider // Registered providers.
+	ordered   []*provider               // Registered providers in registration order.
+	created   []*provider               // Created singleton providers in creation order.
+	running   []*provider               // Started singleton providers in starting order.
+	started   bool                      // Whether the container is started.
+}
+
+// providerKey is the unique key of provider.
+type providerKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// provider creates and holds the service.
+type provider struct {
+	key         providerKey
+	scope       Scope
+	constructor reflect.Value // Constructor function, which is invalid for value provider.
+	mu          sync.Mutex    // Makes the singleton created only once.
+	instance    reflect.Value // Created singleton.
+	created     bool          // Whether the singleton is created.
+	started     bool          // Whether the singleton is started.
+}
+
+// New creates and returns a new Container.
+func New() *Container {
+	return &Container{
+		providers: make(map[providerKey]*provider),
+	}
+}
+
+// Provide registers service constructor to the container.
+//
+// The `constructor` should be a function returning the service, and optionally an error as the second
+// result, like: func(db gdb.DB, logger *glog.Logger) (*UserService, error).
+// The parameters of constructor are resolved from the container by their types, except that the
+// context.Context parameter receives the context of resolution.
+// The service is registered by the type of first result, which can be an interface type.
+func (c *Container) Provide(constructor interface{}, option ...ProvideOption) error {
+	var (
+		opt             ProvideOption
+		constructorType = reflect.TypeOf(constructor)
+	)
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if constructorType == nil || constructorType.Kind() != reflect.Func {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `constructor should be a function, but got %T`, constructor)
+	}
+	if constructorType.IsVariadic() {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `constructor "%s" should not be variadic`, constructorType)
+	}
+	switch {
+	case constructorType.NumOut() == 1:
+	case constructorType.NumOut() == 2 && constructorType.Out(1) == errorType:
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`constructor "%s" should return the service and an optional error`, constructorType,
+		)
+	}
+	return c.register(&provider{
+		key:         providerKey{typ: constructorType.Out(0), name: opt.Name},
+		scope:       opt.Scope,
+		constructor: reflect.ValueOf(constructor),
+	})
+}
+
+// ProvideValue registers an existing service `value` to the container, which is a singleton
+// registered by the type of `value`. The optional parameter `name` specifies the name of service.
+// Note that the lifecycle of value is not managed by container.
+func (c *Container) ProvideValue(value interface{}, name ...string) error {
+	if value == nil {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `value should not be nil`)
+	}
+	key := providerKey{typ: reflect.TypeOf(value)}
+	if len(name) > 0 {
+		key.name = name[0]
+	}
+	return c.register(&provider{
+		key:      key,
+		scope:    ScopeSingleton,
+		instance: reflect.ValueOf(value),
+		created:  true,
+	})
+}
+
+// Resolve resolves the service by the element type of `pointer` and sets it to `pointer`.
+// The optional parameter `name` specifies the name of service.
+//
+// Example:
+// var userService *UserService
+// err := container.Resolve(ctx, &userService).
+func (c *Container) Resolve(ctx context.Context, pointer interface{}, name ...string) error {
+	pointerValue := reflect.ValueOf(pointer)
+	if pointerValue.Kind() != reflect.Ptr || pointerValue.IsNil() {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `resolve target should be a non-nil pointer, but got %T`, pointer)
+	}
+	key := providerKey{typ: pointerValue.Type().Elem()}
+	if len(name) > 0 {
+		key.name = name[0]
+	}
+	instance, err := c.resolve(ctx, key, nil)
+	if err != nil {
+		return err
+	}
+	pointerValue.Elem().Set(instance)
+	return nil
+}
+
+// Invoke calls `function` with parameters resolved from the container, and returns the error
+// if `function` returns an error as its last result.
+func (c *Container) Invoke(ctx context.Context, function interface{}) error {
+	functionValue := reflect.ValueOf(function)
+	if functionValue.Kind() != reflect.Func || functionValue.Type().IsVariadic() {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invoke target should be a function, but got %T`, function)
+	}
+	results, err := c.call(ctx, functionValue, nil)
+	if err != nil {
+		return err
+	}
+	if n := len(results); n > 0 && functionValue.Type().Out(n-1) == errorType && !results[n-1].IsNil() {
+		return results[n-1].Interface().(error)
+	}
+	return nil
+}
+
+// Start creates all singleton services eagerly in registration order, and then starts the services
+// implementing Starter in creation order, in which the dependencies are started before their dependents.
+// The singleton services created after the container started are started on creation.
+//
+// If any service fails starting, the started services are stopped in reverse starting order,
+// and it returns the error of starting joined with the errors of stopping.
+func (c *Container) Start(ctx context.Context) (err error) {
+	defer func() {
+		if err == nil {
+			return
+		}
+		if stopErr := c.Stop(ctx); stopErr != nil {
+			err = gerror.Join(err, stopErr)
+		}
+	}()
+	c.mu.RLock()
+	ordered := make([]*provider, len(c.ordered))
+	copy(ordered, c.ordered)
+	c.mu.RUnlock()
+	for _, p := range ordered {
+		if p.scope != ScopeSingleton {
+			continue
+		}
+		if _, err = c.resolve(ctx, p.key, nil); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	c.started = true
+	created := make([]*provider, len(c.created))
+	copy(created, c.created)
+	c.mu.Unlock()
+	for _, p := range created {
+		if err = c.start(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops the started singleton services implementing Stopper in reverse starting order,
+// in which the dependents are stopped before their dependencies. The services that are created
+// but not started are not stopped.
+// It stops all the services even if some of them fail, and returns the joined errors.
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	c.started = false
+	running := c.running
+	c.running = nil
+	c.mu.Unlock()
+	var errs []error
+	for i := len(running) - 1; i >= 0; i-- {
+		if err := running[i].stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return gerror.Join(errs...)
+}
+
+// start starts the service of provider `p` and records it for stopping.
+func (c *Container) start(ctx context.Context, p *provider) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return c.startLocked(ctx, p)
+}
+
+// startLocked performs as start, but the caller should hold the lock of `p`.
+func (c *Container) startLocked(ctx context.Context, p *provider) error {
+	started, err := p.startLocked(ctx)
+	if err != nil || !started {
+		return err
+	}
+	c.mu.Lock()
+	c.running = append(c.running, p)
+	c.mu.Unlock()
+	return nil
+}
+
+// register registers provider `p` to the container.
+func (c *Container) register(p *provider) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.providers[p.key]; ok {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `service %s already registered`, p.key)
+	}
+	c.providers[p.key] = p
+	c.ordered = append(c.ordered, p)
+	return nil
+}
+
+// resolve resolves and returns the service of `key`, in which `path` is the resolving path for
+// circular dependency detection.
+func (c *Container) resolve(ctx context.Context, key providerKey, path []providerKey) (reflect.Value, error) {
+	for i, k := range path {
+		if k == key {
+			names := make([]string, 0, len(path)-i+1)
+			for _, k = range append(path[i:], key) {
+				names = append(names, k.String())
+			}
+			return reflect.Value{}, gerror.NewCodef(
+				gcode.CodeInvalidOperation, `circular dependency: %s`, strings.Join(names, " -> "),
+			)
+		}
+	}
+	c.mu.RLock()
+	p, ok := c.providers[key]
+	c.mu.RUnlock()
+	if !ok {
+		return reflect.Value{}, gerror.NewCodef(gcode.CodeNotFound, `service %s not found`, key)
+	}
+	path = append(path, key)
+	if p.scope == ScopeTransient {
+		return c.construct(ctx, p, path)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.created {
+		return p.instance, nil
+	}
+	instance, err := c.construct(ctx, p, path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	p.instance, p.created = instance, true
+	c.mu.Lock()
+	c.created = append(c.created, p)
+	started := c.started
+	c.mu.Unlock()
+	if started {
+		if err = c.startLocked(ctx, p); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return instance, nil
+}
+
+// construct creates the service of provider `p` by calling its constructor.
+func (c *Container) construct(ctx context.Context, p *provider, path []providerKey) (reflect.Value, error) {
+	results, err := c.call(ctx, p.constructor, path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if len(results) == 2 && !results[1].IsNil() {
+		return reflect.Value{}, gerror.Wrapf(results[1].Interface().(error), `create service %s failed`, p.key)
+	}
+	return results[0], nil
+}
+
+// call calls `function` with parameters resolved from the container.
+func (c *Container) call(ctx context.Context, function reflect.Value, path []providerKey) ([]reflect.Value, error) {
+	var (
+		functionType = function.Type()
+		inputs       = make([]reflect.Value, functionType.NumIn())
+	)
+	for i := range inputs {
+		inputType := functionType.In(i)
+		if inputType == contextType {
+			inputs[i] = reflect.ValueOf(&ctx).Elem()
+			continue
+		}
+		input, err := c.resolve(ctx, providerKey{typ: inputType}, path)
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = input
+	}
+	return function.Call(inputs), nil
+}
+
+// startLocked starts the service if it implements Starter and is not started, and returns whether it is
+// started by this call. The service is marked started only if it starts successfully.
+func (p *provider) startLocked(ctx context.Context) (started bool, err error) {
+	if p.started || !p.constructor.IsValid() {
+		return false, nil
+	}
+	if starter, ok := p.instance.Interface().(Starter); ok {
+		if err = starter.Start(ctx); err != nil {
+			return false, gerror.Wrapf(err, `start service %s failed`, p.key)
+		}
+	}
+	p.started = true
+	return true, nil
+}
+
+// stop stops the service if it implements Stopper and is started.
+func (p *provider) stop(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.started {
+		return nil
+	}
+	p.started = false
+	if stopper, ok := p.instance.Interface().(Stopper); ok {
+		if err := stopper.Stop(ctx); err != nil {
+			return gerror.Wrapf(err, `stop service %s failed`, p.key)
+		}
+	}
+	return nil
+}
+
+// String returns the readable string of providerKey, like "*main.UserService" or "gdb.DB(name)".
+func (k providerKey) String() string {
+	if k.name == "" {
+		return fmt.Sprintf(`"%s"`, k.typ)
+	}
+	return fmt.Sprintf(`"%s(%s)"`, k.typ, k.name)
+}
@@ -0,0 +1,272 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/gdi"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var ctx = context.Background()
+
+type recorder struct {
+	events []string
+}
+
+type Config struct {
+	Dsn string
+}
+
+type Database struct {
+	config   *Config
+	recorder *recorder
+}
+
+func (d *Database) Start(ctx context.Context) error {
+	d.recorder.events = append(d.recorder.events, "start:database")
+	return nil
+}
+
+func (d *Database) Stop(ctx context.Context) error {
+	d.recorder.events = append(d.recorder.events, "stop:database")
+	return nil
+}
+
+type UserRepository interface {
+	Name() string
+}
+
+type userRepository struct {
+	db *Database
+}
+
+func (r *userRepository) Name() string {
+	return "user:" + r.db.config.Dsn
+}
+
+type UserService struct {
+	repo     UserRepository
+	recorder *recorder
+}
+
+func (s *UserService) Start(ctx context.Context) error {
+	s.recorder.events = append(s.recorder.events, "start:service")
+	return nil
+}
+
+func (s *UserService) Stop(ctx context.Context) error {
+	s.recorder.events = append(s.recorder.events, "stop:service")
+	return errors.New("stop failed")
+}
+
+// FailingService fails starting.
+type FailingService struct {
+	db       *Database
+	recorder *recorder
+}
+
+func (s *FailingService) Start(ctx context.Context) error {
+	return errors.New("start failed")
+}
+
+func (s *FailingService) Stop(ctx context.Context) error {
+	s.recorder.events = append(s.recorder.events, "stop:failing")
+	return nil
+}
+
+func newContainer(t *gtest.T, r *recorder) *gdi.Container {
+	c := gdi.New()
+	t.AssertNil(c.ProvideValue(r))
+	t.AssertNil(c.ProvideValue(&Config{Dsn: "mysql"}))
+	// Registration order does not matter.
+	t.AssertNil(c.Provide(func(repo UserRepository, r *recorder) *UserService {
+		return &UserService{repo: repo, recorder: r}
+	}))
+	t.AssertNil(c.Provide(func(db *Database) UserRepository {
+		return &userRepository{db: db}
+	}))
+	t.AssertNil(c.Provide(func(ctx context.Context, config *Config, r *recorder) (*Database, error) {
+		t.AssertNE(ctx, nil)
+		return &Database{config: config, recorder: r}, nil
+	}))
+	return c
+}
+
+func Test_Container_Resolve(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := newContainer(t, &recorder{})
+		var service *UserService
+		t.AssertNil(c.Resolve(ctx, &service))
+		t.Assert(service.repo.Name(), "user:mysql")
+
+		// Singleton.
+		var service2 *UserService
+		t.AssertNil(c.Resolve(ctx, &service2))
+		t.Assert(service == service2, true)
+
+		var repo UserRepository
+		t.AssertNil(c.Resolve(ctx, &repo))
+		t.Assert(repo == service.repo, true)
+
+		err := c.Invoke(ctx, func(s *UserService, db *Database) error {
+			t.Assert(s == service, true)
+			t.Assert(db.config.Dsn, "mysql")
+			return errors.New("invoke error")
+		})
+		t.Assert(err.Error(), "invoke error")
+	})
+}
+
+func Test_Container_Scope_Name(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c     = gdi.New()
+			count = 0
+		)
+		t.AssertNil(c.Provide(func() *Config {
+			count++
+			return &Config{Dsn: fmt.Sprintf("transient-%d", count)}
+		}, gdi.ProvideOption{Scope: gdi.ScopeTransient}))
+		t.AssertNil(c.ProvideValue(&Config{Dsn: "backup"}, "backup"))
+
+		var config1, config2, backup *Config
+		t.AssertNil(c.Resolve(ctx, &config1))
+		t.AssertNil(c.Resolve(ctx, &config2))
+		t.AssertNil(c.Resolve(ctx, &backup, "backup"))
+		t.Assert(config1.Dsn, "transient-1")
+		t.Assert(config2.Dsn, "transient-2")
+		t.Assert(backup.Dsn, "backup")
+
+		err := c.ProvideValue(&Config{}, "backup")
+		t.Assert(gerror.Code(err), gcode.CodeInvalidOperation)
+	})
+}
+
+func Test_Container_Lifecycle(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r = &recorder{}
+			c = newContainer(t, r)
+		)
+		t.AssertNil(c.Start(ctx))
+		t.Assert(r.events, []string{"start:database", "start:service"})
+		// Started only once.
+		t.AssertNil(c.Start(ctx))
+		t.Assert(len(r.events), 2)
+
+		err := c.Stop(ctx)
+		t.AssertNE(err, nil)
+		t.Assert(strings.Contains(err.Error(), "stop failed"), true)
+		t.Assert(r.events, []string{"start:database", "start:service", "stop:service", "stop:database"})
+	})
+	// Services created after started are started on creation.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r = &recorder{}
+			c = gdi.New()
+		)
+		t.AssertNil(c.Start(ctx))
+		t.AssertNil(c.ProvideValue(r))
+		t.AssertNil(c.ProvideValue(&Config{}))
+		t.AssertNil(c.Provide(func(config *Config, r *recorder) *Database {
+			return &Database{config: config, recorder: r}
+		}))
+		var db *Database
+		t.AssertNil(c.Resolve(ctx, &db))
+		t.Assert(r.events, []string{"start:database"})
+	})
+	// Services that are not started are not stopped.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r       = &recorder{}
+			c       = newContainer(t, r)
+			service *UserService
+		)
+		t.AssertNil(c.Resolve(ctx, &service))
+		t.AssertNil(c.Stop(ctx))
+		t.Assert(len(r.events), 0)
+	})
+	// Started services are stopped in reverse order if any fails starting.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r = &recorder{}
+			c = newContainer(t, r)
+		)
+		t.AssertNil(c.Provide(func(db *Database, r *recorder) *FailingService {
+			return &FailingService{db: db, recorder: r}
+		}))
+		err := c.Start(ctx)
+		t.AssertNE(err, nil)
+		t.Assert(strings.Contains(err.Error(), "start failed"), true)
+		t.Assert(strings.Contains(err.Error(), "stop failed"), true)
+		t.Assert(r.events, []string{"start:database", "start:service", "stop:service", "stop:database"})
+		t.AssertNil(c.Stop(ctx))
+		t.Assert(len(r.events), 4)
+	})
+}
+
+func Test_Container_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gdi.New()
+		t.Assert(gerror.Code(c.Provide(1)), gcode.CodeInvalidParameter)
+		t.Assert(gerror.Code(c.Provide(func() {})), gcode.CodeInvalidParameter)
+		t.Assert(gerror.Code(c.Provide(func() (int, int) { return 0, 0 })), gcode.CodeInvalidParameter)
+		t.Assert(gerror.Code(c.Provide(func(...int) int { return 0 })), gcode.CodeInvalidParameter)
+		t.Assert(gerror.Code(c.ProvideValue(nil)), gcode.CodeInvalidParameter)
+
+		var config *Config
+		t.Assert(gerror.Code(c.Resolve(ctx, &config)), gcode.CodeNotFound)
+		t.Assert(gerror.Code(c.Resolve(ctx, config)), gcode.CodeInvalidParameter)
+		t.Assert(gerror.Code(c.Invoke(ctx, 1)), gcode.CodeInvalidParameter)
+
+		t.AssertNil(c.Provide(func() (*Config, error) { return nil, errors.New("constructor error") }))
+		err := c.Resolve(ctx, &config)
+		t.Assert(strings.Contains(err.Error(), "constructor error"), true)
+	})
+	// Circular dependency.
+	gtest.C(t, func(t *gtest.T) {
+		c := gdi.New()
+		t.AssertNil(c.Provide(func(db *Database) *Config { return &Config{} }))
+		t.AssertNil(c.Provide(func(config *Config) *Database { return &Database{} }))
+		var db *Database
+		err := c.Resolve(ctx, &db)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidOperation)
+		t.Assert(
+			err.Error(),
+			`circular dependency: "*gdi_test.Database" -> "*gdi_test.Config" -> "*gdi_test.Database"`,
+		)
+	})
+}
+
+func Test_Default(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		type defaultService struct{ Name string }
+		t.AssertNil(gdi.Provide(func() *defaultService { return &defaultService{Name: "default"} }))
+		t.AssertNil(gdi.ProvideValue(&Config{Dsn: "default"}, "default"))
+
+		var service *defaultService
+		gdi.MustResolve(ctx, &service)
+		t.Assert(service.Name, "default")
+		t.AssertNil(gdi.Invoke(ctx, func(s *defaultService) {
+			t.Assert(s == service, true)
+		}))
+		var config *Config
+		t.AssertNil(gdi.Resolve(ctx, &config, "default"))
+		t.Assert(config.Dsn, "default")
+		t.AssertNil(gdi.Start(ctx))
+		t.AssertNil(gdi.Stop(ctx))
+		t.Assert(gdi.Default() != nil, true)
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/consts"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// BootReport is the consolidated report of boot validation.
+type BootReport struct {
+	Valid     bool        `json:"valid"`     // Whether all configured instances are valid.
+	Instances []BootCheck `json:"instances"` // Validation result of each instance, sorted by key.
+}
+
+// BootCheck is the validation result of a configured instance.
+type BootCheck struct {
+	Key     string        `json:"key"`             // Key of instance, like "database.default".
+	Kind    string        `json:"kind"`            // Kind of instance, like "database".
+	Name    string        `json:"name"`            // Name of instance, like "default".
+	Valid   bool          `json:"valid"`           // Whether the instance is valid.
+	Error   string        `json:"error,omitempty"` // Error of validation.
+	Latency time.Duration `json:"latency"`         // Latency of validation, including creation and ping.
+}
+
+// bootTarget is a configured instance to be validated.
+type bootTarget struct {
+	kind        string
+	name        string
+	validate    func() error                    // Validates the required configuration keys, which can be nil.
+	newInstance func()                          // Creates the instance, which panics if any error occurs.
+	ping        func(ctx context.Context) error // Pings the created instance, which can be nil.
+}
+
+// serverConfigMapFields are the server configuration fields of map type,
+// which are not taken as server names.
+var serverConfigMapFields = map[string]struct{}{
+	"logger":    {},
+	"rewrites":  {},
+	"view":      {},
+	"tlsconfig": {},
+}
+
+// ValidateBoot eagerly creates every database, redis and server instance named in configuration,
+// validates their required configuration keys and pings the database and redis instances.
+// It validates all instances even if some of them fail, and returns the consolidated report
+// along with the joined errors, so that misconfiguration fails at startup rather than on first request.
+//
+// Note that the HTTP servers are created but not started, so they are not pinged.
+func ValidateBoot(ctx context.Context) (*BootReport, error) {
+	configData, err := Config().Data(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, `retrieve config data failed`)
+	}
+	var targets []*bootTarget
+	targets = append(targets, databaseBootTargets(configData)...)
+	targets = append(targets, redisBootTargets(configData)...)
+	targets = append(targets, serverBootTargets(configData)...)
+	sort.Slice(targets, func(i, j int) bool {
+		return InstanceKey(targets[i].kind, targets[i].name) < InstanceKey(targets[j].kind, targets[j].name)
+	})
+
+	var (
+		wg     sync.WaitGroup
+		report = &BootReport{
+			Valid:     true,
+			Instances: make([]BootCheck, len(targets)),
+		}
+	)
+	// The instances are created sequentially, as creating instances concurrently races on
+	// the global settings like graceful feature of servers, and only the pings run concurrently.
+	for i, target := range targets {
+		check := &report.Instances[i]
+		*check = BootCheck{
+			Key:  InstanceKey(target.kind, target.name),
+			Kind: target.kind,
+			Name: target.name,
+		}
+		startTime := time.Now()
+		err = target.create(ctx)
+		check.Latency = time.Since(startTime)
+		switch {
+		case err != nil:
+			check.Error = err.Error()
+			continue
+		case target.ping == nil:
+			check.Valid = true
+			continue
+		}
+		wg.Add(1)
+		go func(check *BootCheck, target *bootTarget) {
+			defer wg.Done()
+			startTime := time.Now()
+			if err := runWithTimeout(ctx, DefaultHealthCheckTimeout, target.ping); err != nil {
+				check.Error = err.Error()
+			} else {
+				check.Valid = true
+			}
+			check.Latency += time.Since(startTime)
+		}(check, target)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, check := range report.Instances {
+		if !check.Valid {
+			report.Valid = false
+			errs = append(errs, gerror.NewCodef(
+				gcode.CodeInvalidConfiguration, `validate instance "%s" failed: %s`, check.Key, check.Error,
+			))
+		}
+	}
+	if len(errs) > 0 {
+		return report, gerror.Wrap(gerror.Join(errs...), `boot validation failed`)
+	}
+	return report, nil
+}
+
+// create validates the configuration and creates the instance.
+func (t *bootTarget) create(ctx context.Context) error {
+	if t.validate != nil {
+		var err error
+		if tryErr := gutil.Try(ctx, func(ctx context.Context) { err = t.validate() }); tryErr != nil {
+			return tryErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return gutil.Try(ctx, func(ctx context.Context) { t.newInstance() })
+}
+
+// databaseBootTargets returns the database instances named in configuration.
+func databaseBootTargets(configData map[string]interface{}) []*bootTarget {
+	_, v := gutil.MapPossibleItemByKey(configData, consts.ConfigNodeNameDatabase)
+	configMap := gconv.Map(v)
+	if len(configMap) == 0 {
+		return nil
+	}
+	var (
+		targets   []*bootTarget
+		newTarget = func(group string, nodes []interface{}) *bootTarget {
+			return &bootTarget{
+				kind: InstanceKindDatabase,
+				name: group,
+				validate: func() error {
+					for _, node := range nodes {
+						if err := validateDBConfigNode(group, gconv.Map(node)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+				newInstance: func() { Database(group) },
+				ping: func(ctx context.Context) error {
+					return Database(group).Ctx(ctx).PingMaster()
+				},
+			}
+		}
+	)
+	// Single node configuration, which is the default group configuration.
+	if node := parseDBConfigNode(configMap); node != nil && (node.Link != "" || node.Host != "") {
+		return []*bootTarget{newTarget(gdb.DefaultGroupName, []interface{}{configMap})}
+	}
+	for group, groupConfig := range configMap {
+		switch value := groupConfig.(type) {
+		case []interface{}:
+			targets = append(targets, newTarget(group, value))
+		case map[string]interface{}:
+			targets = append(targets, newTarget(group, []interface{}{value}))
+		}
+	}
+	return targets
+}
+
+// redisBootTargets returns the redis instances named in configuration.
+func redisBootTargets(configData map[string]interface{}) []*bootTarget {
+	_, v := gutil.MapPossibleItemByKey(configData, consts.ConfigNodeNameRedis)
+	var targets []*bootTarget
+	for group, groupConfig := range gconv.Map(v) {
+		group := group
+		groupConfigMap, ok := groupConfig.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		targets = append(targets, &bootTarget{
+			kind: InstanceKindRedis,
+			name: group,
+			validate: func() error {
+				return validateRequiredKeys(InstanceKindRedis, group, groupConfigMap, "address")
+			},
+			newInstance: func() { Redis(group) },
+			ping: func(ctx context.Context) error {
+				_, err := Redis(group).Do(ctx, "PING")
+				return err
+			},
+		})
+	}
+	return targets
+}
+
+// serverBootTargets returns the server instances named in configuration.
+func serverBootTargets(configData map[string]interface{}) []*bootTarget {
+	_, v := gutil.MapPossibleItemByKey(configData, consts.ConfigNodeNameServer)
+	if v == nil {
+		_, v = gutil.MapPossibleItemByKey(configData, consts.ConfigNodeNameServerSecondary)
+	}
+	configMap := gconv.Map(v)
+	if len(configMap) == 0 {
+		return nil
+	}
+	var (
+		names         []string
+		hasDefaultKey bool
+	)
+	for key, value := range configMap {
+		if _, ok := value.(map[string]interface{}); !ok {
+			hasDefaultKey = true
+			continue
+		}
+		if _, ok := serverConfigMapFields[strings.ToLower(key)]; ok || key == ghttp.DefaultServerName {
+			hasDefaultKey = true
+			continue
+		}
+		names = append(names, key)
+	}
+	// The items that are not named server configuration belong to the default server configuration.
+	if hasDefaultKey || len(names) == 0 {
+		names = append(names, ghttp.DefaultServerName)
+	}
+	targets := make([]*bootTarget, 0, len(names))
+	for _, name := range names {
+		name := name
+		targets = append(targets, &bootTarget{
+			kind:        InstanceKindServer,
+			name:        name,
+			newInstance: func() { Server(name) },
+		})
+	}
+	return targets
+}
+
+// validateDBConfigNode validates the required keys of database node configuration.
+func validateDBConfigNode(group string, nodeMap map[string]interface{}) error {
+	if _, v := gutil.MapPossibleItemByKey(nodeMap, "link"); !gutil.IsEmpty(v) {
+		return nil
+	}
+	return validateRequiredKeys(InstanceKindDatabase, group, nodeMap, "host", "type")
+}
+
+// validateRequiredKeys checks that `configMap` contains non-empty values of `keys`.
+func validateRequiredKeys(kind, name string, configMap map[string]interface{}, keys ...string) error {
+	for _, key := range keys {
+		if _, v := gutil.MapPossibleItemByKey(configMap, key); gutil.IsEmpty(v) {
+			return gerror.NewCodef(
+				gcode.CodeMissingConfiguration,
+				`missing required configuration key "%s" for %s "%s"`, key, kind, name,
+			)
+		}
+	}
+	return nil
+}
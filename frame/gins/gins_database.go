@@ -23,6 +23,7 @@ import (
 )
 
 // Database returns an instance of database ORM object with specified configuration group name.
+// It returns the overriding instance if it is overridden by OverrideDatabase.
 // Note that it panics if any error occurs duration instance creating.
 func Database(name ...string) gdb.DB {
 	var (
@@ -33,6 +34,9 @@ func Database(name ...string) gdb.DB {
 	if len(name) > 0 && name[0] != "" {
 		group = name[0]
 	}
+	if v, ok := getOverrideInstance(InstanceKey(InstanceKindDatabase, group)); ok {
+		return v.(gdb.DB)
+	}
 	instanceKey := fmt.Sprintf("%s.%s", frameCoreComponentNameDatabase, group)
 	db := instance.GetOrSetFuncLock(instanceKey, func() interface{} {
 		// It ignores returned error to avoid file no found error while it's not necessary.
@@ -142,6 +146,9 @@ func Database(name ...string) gdb.DB {
 					}
 				}
 			}
+			manageInstance(InstanceKindDatabase, group, instanceKey, db.Close, func(ctx context.Context) error {
+				return db.Ctx(ctx).PingMaster()
+			})
 			return db
 		} else {
 			// If panics, often because it does not find its configuration for given group.
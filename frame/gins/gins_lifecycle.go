@@ -0,0 +1,277 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/instance"
+)
+
+const (
+	InstanceKindDatabase = "database" // Kind of database instance.
+	InstanceKindRedis    = "redis"    // Kind of redis instance.
+	InstanceKindServer   = "server"   // Kind of HTTP server instance.
+	InstanceKindLogger   = "logger"   // Kind of logger instance.
+)
+
+const (
+	// DefaultShutdownTimeout is the default timeout for shutting down each instance.
+	DefaultShutdownTimeout = 10 * time.Second
+
+	// DefaultHealthCheckTimeout is the default timeout for health checking each instance.
+	DefaultHealthCheckTimeout = 3 * time.Second
+)
+
+// HealthReport is the aggregated health snapshot of all managed instances.
+type HealthReport struct {
+	Healthy   bool             `json:"healthy"`   // Whether all instances are healthy, which also means ready.
+	Instances []InstanceHealth `json:"instances"` // Health of each instance, sorted by key.
+}
+
+// InstanceHealth is the health of a managed instance.
+type InstanceHealth struct {
+	Key     string        `json:"key"`             // Key of instance, like "database.default".
+	Kind    string        `json:"kind"`            // Kind of instance, like "database".
+	Name    string        `json:"name"`            // Name of instance, like "default".
+	Healthy bool          `json:"healthy"`         // Whether the instance is healthy.
+	Error   string        `json:"error,omitempty"` // Error of health checking.
+	Latency time.Duration `json:"latency"`         // Latency of health checking.
+}
+
+// managedInstance is the instance created by gins, which is managed for shutdown and health checking.
+type managedInstance struct {
+	key       string
+	kind      string
+	name      string
+	cacheKey  string                          // Key of instance in instance cache, which can be empty.
+	closeFunc func(ctx context.Context) error // Closes the instance, which can be nil.
+	checkFunc func(ctx context.Context) error // Checks the health of instance, which can be nil.
+}
+
+var (
+	// managedInstances are the managed instances, mapping instance key to instance.
+	managedInstances   = make(map[string]*managedInstance)
+	managedInstancesMu sync.RWMutex
+
+	// instanceDependencies are the declared dependencies, mapping instance key to dependency keys.
+	instanceDependencies = make(map[string][]string)
+
+	// shutdownTimeouts are the shutdown timeouts of instances, mapping instance key to timeout.
+	shutdownTimeouts = make(map[string]time.Duration)
+
+	// instanceKindRanks are the default shutdown order of instance kinds,
+	// in which the instance of higher rank is shut down earlier.
+	instanceKindRanks = map[string]int{
+		InstanceKindServer:   3,
+		InstanceKindDatabase: 2,
+		InstanceKindRedis:    2,
+		InstanceKindLogger:   1,
+	}
+)
+
+// InstanceKey returns the key of instance by its kind and name, like "database.default".
+func InstanceKey(kind, name string) string {
+	return kind + "." + name
+}
+
+// DependsOn declares that instance `key` depends on instances `dependencies`, so that the instance
+// is shut down before its dependencies. The keys are created by InstanceKey, like "server.default".
+//
+// The instances without declared dependencies are shut down in default order of their kinds:
+// servers first, then databases and redis clients, and loggers last.
+func DependsOn(key string, dependencies ...string) {
+	managedInstancesMu.Lock()
+	defer managedInstancesMu.Unlock()
+	instanceDependencies[key] = append(instanceDependencies[key], dependencies...)
+}
+
+// SetShutdownTimeout sets the timeout for shutting down instance `key`,
+// which is DefaultShutdownTimeout in default.
+func SetShutdownTimeout(key string, timeout time.Duration) {
+	managedInstancesMu.Lock()
+	defer managedInstancesMu.Unlock()
+	shutdownTimeouts[key] = timeout
+}
+
+// Shutdown shuts down all managed instances in reverse dependency order, in which each instance
+// is shut down with its own timeout. It shuts down all instances even if some of them fail or time out,
+// and returns the joined errors.
+//
+// Note that the shutdown instances are no longer managed, and they are removed from the instance cache,
+// so that the instances are created again if they are retrieved after shutdown.
+func Shutdown(ctx context.Context) error {
+	managedInstancesMu.Lock()
+	var (
+		ordered   = shutdownOrder()
+		timeouts  = make(map[string]time.Duration, len(ordered))
+		errs      []error
+		startTime = time.Now()
+	)
+	for _, item := range ordered {
+		timeouts[item.key] = DefaultShutdownTimeout
+		if timeout, ok := shutdownTimeouts[item.key]; ok && timeout > 0 {
+			timeouts[item.key] = timeout
+		}
+		delete(managedInstances, item.key)
+	}
+	managedInstancesMu.Unlock()
+
+	for _, item := range ordered {
+		if item.cacheKey != "" {
+			instance.Remove(item.cacheKey)
+		}
+		if item.closeFunc == nil {
+			continue
+		}
+		if err := runWithTimeout(ctx, timeouts[item.key], item.closeFunc); err != nil {
+			errs = append(errs, gerror.Wrapf(err, `shutdown instance "%s" failed`, item.key))
+		}
+	}
+	if len(errs) > 0 {
+		return gerror.Wrapf(gerror.Join(errs...), `shutdown instances failed after %s`, time.Since(startTime))
+	}
+	return nil
+}
+
+// Health checks the health of all managed instances concurrently, and returns the aggregated report.
+func Health(ctx context.Context) *HealthReport {
+	managedInstancesMu.RLock()
+	items := make([]*managedInstance, 0, len(managedInstances))
+	for _, item := range managedInstances {
+		items = append(items, item)
+	}
+	managedInstancesMu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key < items[j].key
+	})
+
+	var (
+		wg     sync.WaitGroup
+		report = &HealthReport{
+			Healthy:   true,
+			Instances: make([]InstanceHealth, len(items)),
+		}
+	)
+	for i, item := range items {
+		report.Instances[i] = InstanceHealth{
+			Key:     item.key,
+			Kind:    item.kind,
+			Name:    item.name,
+			Healthy: true,
+		}
+		if item.checkFunc == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(health *InstanceHealth, checkFunc func(ctx context.Context) error) {
+			defer wg.Done()
+			startTime := time.Now()
+			if err := runWithTimeout(ctx, DefaultHealthCheckTimeout, checkFunc); err != nil {
+				health.Healthy = false
+				health.Error = err.Error()
+			}
+			health.Latency = time.Since(startTime)
+		}(&report.Instances[i], item.checkFunc)
+	}
+	wg.Wait()
+	for _, health := range report.Instances {
+		if !health.Healthy {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+// manageInstance adds the instance to be managed for shutdown and health checking,
+// in which `cacheKey` is the key of instance in instance cache.
+func manageInstance(kind, name, cacheKey string, closeFunc, checkFunc func(ctx context.Context) error) {
+	key := InstanceKey(kind, name)
+	managedInstancesMu.Lock()
+	defer managedInstancesMu.Unlock()
+	managedInstances[key] = &managedInstance{
+		key:       key,
+		kind:      kind,
+		name:      name,
+		cacheKey:  cacheKey,
+		closeFunc: closeFunc,
+		checkFunc: checkFunc,
+	}
+}
+
+// shutdownOrder returns the managed instances in shutdown order, in which the dependents are
+// before their dependencies, and the instances are ordered by kind ranks if they have no dependency
+// relationship. It should be called with lock.
+func shutdownOrder() []*managedInstance {
+	// dependents counts the managed dependents of each instance.
+	dependents := make(map[string]int, len(managedInstances))
+	for key := range managedInstances {
+		for _, dependency := range instanceDependencies[key] {
+			if _, ok := managedInstances[dependency]; ok && dependency != key {
+				dependents[dependency]++
+			}
+		}
+	}
+	var (
+		ordered   = make([]*managedInstance, 0, len(managedInstances))
+		remaining = make(map[string]*managedInstance, len(managedInstances))
+	)
+	for key, item := range managedInstances {
+		remaining[key] = item
+	}
+	for len(remaining) > 0 {
+		// The ready instances have no remaining dependents. If there's circular dependency,
+		// all remaining instances are considered ready to make the shutdown go on.
+		var ready []*managedInstance
+		for key, item := range remaining {
+			if dependents[key] <= 0 {
+				ready = append(ready, item)
+			}
+		}
+		if len(ready) == 0 {
+			for _, item := range remaining {
+				ready = append(ready, item)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool {
+			if ri, rj := instanceKindRanks[ready[i].kind], instanceKindRanks[ready[j].kind]; ri != rj {
+				return ri > rj
+			}
+			return ready[i].key < ready[j].key
+		})
+		next := ready[0]
+		ordered = append(ordered, next)
+		delete(remaining, next.key)
+		for _, dependency := range instanceDependencies[next.key] {
+			if _, ok := remaining[dependency]; ok && dependency != next.key {
+				dependents[dependency]--
+			}
+		}
+	}
+	return ordered
+}
+
+// runWithTimeout runs `f` with `timeout`, and returns error if it does not return in time.
+func runWithTimeout(ctx context.Context, timeout time.Duration, f func(ctx context.Context) error) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- f(timeoutCtx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-timeoutCtx.Done():
+		return gerror.WrapCodef(gcode.CodeOperationFailed, timeoutCtx.Err(), `timeout after %s`, timeout)
+	}
+}
@@ -58,6 +58,7 @@ func Log(name ...string) *glog.Logger {
 				panic(err)
 			}
 		}
+		manageInstance(InstanceKindLogger, instanceName, instanceKey, nil, nil)
 		return logger
 	}).(*glog.Logger)
 }
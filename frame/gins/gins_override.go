@@ -0,0 +1,135 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// InitPolicy is the initialization policy of instance.
+type InitPolicy int
+
+const (
+	// InitPolicyLazy creates the instance on its first use, which is the default policy.
+	InitPolicyLazy InitPolicy = iota
+	// InitPolicyEager creates the instance in InitEager, which is commonly called in the boot.
+	InitPolicyEager
+)
+
+var (
+	// instanceOverrides are the overriding instances, mapping instance key to instance.
+	instanceOverrides   = make(map[string]interface{})
+	instanceOverridesMu sync.RWMutex
+
+	// initPolicies are the initialization policies of instances, mapping instance key to policy.
+	initPolicies   = make(map[string]InitPolicy)
+	initPoliciesMu sync.RWMutex
+)
+
+// OverrideDatabase replaces the database instance of group `name` with `db`, which is commonly
+// a test double, and returns the function restoring the previous instance.
+// The default group is used if `name` is empty.
+//
+// Example:
+// restore := gins.OverrideDatabase("default", fakeDB)
+// defer restore().
+func OverrideDatabase(name string, db gdb.DB) (restore func()) {
+	if name == "" {
+		name = gdb.DefaultGroupName
+	}
+	return overrideInstance(InstanceKey(InstanceKindDatabase, name), db)
+}
+
+// OverrideRedis replaces the redis instance of group `name` with `redis`, which is commonly
+// a test double, and returns the function restoring the previous instance.
+// The default group is used if `name` is empty.
+func OverrideRedis(name string, redis *gredis.Redis) (restore func()) {
+	if name == "" {
+		name = gredis.DefaultGroupName
+	}
+	return overrideInstance(InstanceKey(InstanceKindRedis, name), redis)
+}
+
+// SetInitPolicy sets the initialization policy of instance `key`, which is InitPolicyLazy in default.
+// The key is created by InstanceKey, like "database.default".
+func SetInitPolicy(key string, policy InitPolicy) {
+	initPoliciesMu.Lock()
+	defer initPoliciesMu.Unlock()
+	initPolicies[key] = policy
+}
+
+// InitEager creates all the instances of InitPolicyEager in order of their keys.
+// It creates all instances even if some of them fail, and returns the joined errors.
+func InitEager(ctx context.Context) error {
+	initPoliciesMu.RLock()
+	keys := make([]string, 0, len(initPolicies))
+	for key, policy := range initPolicies {
+		if policy == InitPolicyEager {
+			keys = append(keys, key)
+		}
+	}
+	initPoliciesMu.RUnlock()
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		kind, name, _ := strings.Cut(key, ".")
+		var create func()
+		switch kind {
+		case InstanceKindDatabase:
+			create = func() { Database(name) }
+		case InstanceKindRedis:
+			create = func() { Redis(name) }
+		case InstanceKindServer:
+			create = func() { Server(name) }
+		case InstanceKindLogger:
+			create = func() { Log(name) }
+		default:
+			errs = append(errs, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid instance key "%s"`, key))
+			continue
+		}
+		if err := gutil.Try(ctx, func(ctx context.Context) { create() }); err != nil {
+			errs = append(errs, gerror.Wrapf(err, `initialize instance "%s" failed`, key))
+		}
+	}
+	return gerror.Join(errs...)
+}
+
+// overrideInstance sets the overriding instance of `key`, and returns the function restoring
+// the previous overriding instance.
+func overrideInstance(key string, value interface{}) (restore func()) {
+	instanceOverridesMu.Lock()
+	defer instanceOverridesMu.Unlock()
+	previous, hasPrevious := instanceOverrides[key]
+	instanceOverrides[key] = value
+	return func() {
+		instanceOverridesMu.Lock()
+		defer instanceOverridesMu.Unlock()
+		if hasPrevious {
+			instanceOverrides[key] = previous
+		} else {
+			delete(instanceOverrides, key)
+		}
+	}
+}
+
+// getOverrideInstance returns the overriding instance of `key`.
+func getOverrideInstance(key string) (value interface{}, ok bool) {
+	instanceOverridesMu.RLock()
+	defer instanceOverridesMu.RUnlock()
+	value, ok = instanceOverrides[key]
+	return
+}
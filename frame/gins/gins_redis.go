@@ -21,6 +21,7 @@ import (
 )
 
 // Redis returns an instance of redis client with specified configuration group name.
+// It returns the overriding instance if it is overridden by OverrideRedis.
 // Note that it panics if any error occurs duration instance creating.
 func Redis(name ...string) *gredis.Redis {
 	var (
@@ -31,11 +32,16 @@ func Redis(name ...string) *gredis.Redis {
 	if len(name) > 0 && name[0] != "" {
 		group = name[0]
 	}
+	if v, ok := getOverrideInstance(InstanceKey(InstanceKindRedis, group)); ok {
+		return v.(*gredis.Redis)
+	}
 	instanceKey := fmt.Sprintf("%s.%s", frameCoreComponentNameRedis, group)
 	result := instance.GetOrSetFuncLock(instanceKey, func() interface{} {
 		// If already configured, it returns the redis instance.
 		if _, ok := gredis.GetConfig(group); ok {
-			return gredis.Instance(group)
+			redisClient := gredis.Instance(group)
+			manageRedisInstance(group, instanceKey, redisClient, true)
+			return redisClient
 		}
 		if Config().Available(ctx) {
 			var (
@@ -63,6 +69,7 @@ func Redis(name ...string) *gredis.Redis {
 			if redisClient, err = gredis.New(redisConfig); err != nil {
 				panic(err)
 			}
+			manageRedisInstance(group, instanceKey, redisClient, false)
 			return redisClient
 		}
 		panic(gerror.NewCode(
@@ -76,3 +83,24 @@ func Redis(name ...string) *gredis.Redis {
 	}
 	return nil
 }
+
+// manageRedisInstance adds the redis client to be managed for shutdown and health checking,
+// in which `fromGredis` specifies whether the client is the instance of gredis.Instance.
+func manageRedisInstance(group, instanceKey string, redisClient *gredis.Redis, fromGredis bool) {
+	if redisClient == nil {
+		return
+	}
+	closeFunc := redisClient.Close
+	if fromGredis {
+		// The closed client is also removed from the instances of gredis,
+		// or else it is still returned by gredis.Instance after shutdown.
+		closeFunc = func(ctx context.Context) error {
+			gredis.RemoveInstance(group)
+			return redisClient.Close(ctx)
+		}
+	}
+	manageInstance(InstanceKindRedis, group, instanceKey, closeFunc, func(ctx context.Context) error {
+		_, err := redisClient.Do(ctx, "PING")
+		return err
+	})
+}
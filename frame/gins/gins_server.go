@@ -10,6 +10,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/consts"
 	"github.com/gogf/gf/v2/internal/instance"
 	"github.com/gogf/gf/v2/internal/intlog"
@@ -95,6 +97,14 @@ func Server(name ...interface{}) *ghttp.Server {
 		// As it might use template feature,
 		// it initializes the view instance as well.
 		_ = getViewInstance()
+		manageInstance(InstanceKindServer, instanceName, instanceKey, func(ctx context.Context) error {
+			return server.Shutdown()
+		}, func(ctx context.Context) error {
+			if server.Status() != ghttp.ServerStatusRunning {
+				return gerror.NewCodef(gcode.CodeServerBusy, `server "%s" is not running`, instanceName)
+			}
+			return nil
+		})
 		return server
 	}).(*ghttp.Server)
 }
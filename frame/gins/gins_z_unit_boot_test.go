@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/gins"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ValidateBoot(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		adapter = gins.Config().GetAdapter().(*gcfg.AdapterFile)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		adapter.SetContent(`
+database:
+  user:
+    name: "test"
+    type: "mysql"
+  order:
+    host: "127.0.0.1"
+    type: "unregistered"
+redis:
+  cache:
+    db: 1
+server:
+  address: ":8199"
+  logger:
+    stdout: false
+  boot:
+    address: ":8299"
+`)
+		defer adapter.ClearContent()
+
+		report, err := gins.ValidateBoot(ctx)
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidConfiguration)
+		t.Assert(report.Valid, false)
+		t.Assert(len(report.Instances), 5)
+
+		var keys []string
+		for _, check := range report.Instances {
+			keys = append(keys, check.Key)
+		}
+		t.Assert(keys, []string{
+			"database.order",
+			"database.user",
+			"redis.cache",
+			"server.boot",
+			"server.default",
+		})
+		t.Assert(report.Instances[0].Valid, false)
+		t.AssertNE(report.Instances[0].Error, "")
+		t.Assert(report.Instances[1].Valid, false)
+		t.Assert(report.Instances[1].Error, `missing required configuration key "host" for database "user"`)
+		t.Assert(report.Instances[2].Valid, false)
+		t.Assert(report.Instances[2].Error, `missing required configuration key "address" for redis "cache"`)
+		t.Assert(report.Instances[3].Valid, true)
+		t.Assert(report.Instances[4].Valid, true)
+		t.Assert(strings.Contains(err.Error(), `validate instance "redis.cache" failed`), true)
+		t.Assert(gins.Server("boot").GetListenedAddress(), ":8299")
+	})
+	// Nothing configured.
+	gtest.C(t, func(t *gtest.T) {
+		adapter.SetContent(`test: "v=1"`)
+		defer adapter.ClearContent()
+
+		report, err := gins.ValidateBoot(ctx)
+		t.AssertNil(err)
+		t.Assert(report.Valid, true)
+		t.Assert(len(report.Instances), 0)
+	})
+}
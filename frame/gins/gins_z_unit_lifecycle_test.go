@@ -0,0 +1,206 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/internal/instance"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// isolateManagedInstances replaces the managed instances with empty ones for testing,
+// and returns the function restoring them.
+func isolateManagedInstances() func() {
+	managedInstancesMu.Lock()
+	defer managedInstancesMu.Unlock()
+	var (
+		instances    = managedInstances
+		dependencies = instanceDependencies
+		timeouts     = shutdownTimeouts
+	)
+	managedInstances = make(map[string]*managedInstance)
+	instanceDependencies = make(map[string][]string)
+	shutdownTimeouts = make(map[string]time.Duration)
+	return func() {
+		managedInstancesMu.Lock()
+		defer managedInstancesMu.Unlock()
+		managedInstances, instanceDependencies, shutdownTimeouts = instances, dependencies, timeouts
+	}
+}
+
+func Test_Lifecycle_Shutdown(t *testing.T) {
+	defer isolateManagedInstances()()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mu     sync.Mutex
+			closed []string
+		)
+		closer := func(key string) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				closed = append(closed, key)
+				return nil
+			}
+		}
+		manageInstance(InstanceKindLogger, "default", "", closer("logger.default"), nil)
+		manageInstance(InstanceKindDatabase, "default", "", closer("database.default"), nil)
+		manageInstance(InstanceKindDatabase, "user", "", closer("database.user"), nil)
+		manageInstance(InstanceKindRedis, "default", "", closer("redis.default"), nil)
+		manageInstance(InstanceKindServer, "default", "", closer("server.default"), nil)
+		manageInstance(InstanceKindServer, "admin", "", closer("server.admin"), nil)
+
+		// The user database is accessed through redis cache, so redis is shut down earlier.
+		DependsOn(InstanceKey(InstanceKindRedis, "default"), InstanceKey(InstanceKindDatabase, "user"))
+		// The admin server depends on the default server, like proxying to it.
+		DependsOn(InstanceKey(InstanceKindServer, "admin"), InstanceKey(InstanceKindServer, "default"))
+
+		t.AssertNil(Shutdown(context.Background()))
+		t.Assert(closed, []string{
+			"server.admin",
+			"server.default",
+			"database.default",
+			"redis.default",
+			"database.user",
+			"logger.default",
+		})
+		t.Assert(len(Health(context.Background()).Instances), 0)
+	})
+}
+
+func Test_Lifecycle_Shutdown_Timeout(t *testing.T) {
+	defer isolateManagedInstances()()
+	gtest.C(t, func(t *gtest.T) {
+		var closedDatabase bool
+		manageInstance(InstanceKindServer, "default", "", func(ctx context.Context) error {
+			<-ctx.Done()
+			time.Sleep(time.Second)
+			return nil
+		}, nil)
+		manageInstance(InstanceKindRedis, "default", "", func(ctx context.Context) error {
+			return errors.New("redis close error")
+		}, nil)
+		manageInstance(InstanceKindDatabase, "default", "", func(ctx context.Context) error {
+			closedDatabase = true
+			return nil
+		}, nil)
+		SetShutdownTimeout(InstanceKey(InstanceKindServer, "default"), 100*time.Millisecond)
+
+		startTime := time.Now()
+		err := Shutdown(context.Background())
+		t.AssertLT(time.Since(startTime), 500*time.Millisecond)
+		t.AssertNE(err, nil)
+		t.Assert(strings.Contains(err.Error(), `shutdown instance "server.default" failed`), true)
+		t.Assert(strings.Contains(err.Error(), `redis close error`), true)
+		t.Assert(closedDatabase, true)
+	})
+}
+
+func Test_Lifecycle_Health(t *testing.T) {
+	defer isolateManagedInstances()()
+	gtest.C(t, func(t *gtest.T) {
+		manageInstance(InstanceKindLogger, "default", "", nil, nil)
+		manageInstance(InstanceKindDatabase, "default", "", nil, func(ctx context.Context) error {
+			return nil
+		})
+		report := Health(context.Background())
+		t.Assert(report.Healthy, true)
+		t.Assert(len(report.Instances), 2)
+		t.Assert(report.Instances[0].Key, "database.default")
+		t.Assert(report.Instances[0].Kind, InstanceKindDatabase)
+		t.Assert(report.Instances[0].Name, "default")
+		t.Assert(report.Instances[1].Key, "logger.default")
+
+		manageInstance(InstanceKindRedis, "default", "", nil, func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		report = Health(context.Background())
+		t.Assert(report.Healthy, false)
+		t.Assert(report.Instances[2].Key, "redis.default")
+		t.Assert(report.Instances[2].Healthy, false)
+		t.Assert(report.Instances[2].Error, "connection refused")
+	})
+	// Managed by instance creation.
+	gtest.C(t, func(t *gtest.T) {
+		Log("lifecycle")
+		Server("lifecycle")
+		report := Health(context.Background())
+		t.Assert(report.Healthy, false)
+		t.Assert(len(report.Instances) >= 2, true)
+		for _, health := range report.Instances {
+			if health.Key == "server.lifecycle" {
+				t.Assert(health.Healthy, false)
+				t.Assert(health.Error, `server "lifecycle" is not running`)
+			}
+		}
+	})
+}
+
+func Test_Lifecycle_Shutdown_RemoveCache(t *testing.T) {
+	defer isolateManagedInstances()()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name     = guid.S()
+			cacheKey = fmt.Sprintf("%s.%s", frameCoreComponentNameServer, name)
+		)
+		instance.Set(cacheKey, name)
+		manageInstance(InstanceKindServer, name, cacheKey, nil, nil)
+
+		t.AssertNil(Shutdown(context.Background()))
+		t.AssertNil(instance.Get(cacheKey))
+	})
+}
+
+// closeRecordAdapter is the redis adapter recording whether it is closed, whose other operations are not implemented.
+type closeRecordAdapter struct {
+	gredis.AdapterOperation
+	closed bool
+}
+
+func (a *closeRecordAdapter) GroupGeneric() gredis.IGroupGeneric     { return nil }
+func (a *closeRecordAdapter) GroupHash() gredis.IGroupHash           { return nil }
+func (a *closeRecordAdapter) GroupList() gredis.IGroupList           { return nil }
+func (a *closeRecordAdapter) GroupPubSub() gredis.IGroupPubSub       { return nil }
+func (a *closeRecordAdapter) GroupScript() gredis.IGroupScript       { return nil }
+func (a *closeRecordAdapter) GroupSet() gredis.IGroupSet             { return nil }
+func (a *closeRecordAdapter) GroupSortedSet() gredis.IGroupSortedSet { return nil }
+func (a *closeRecordAdapter) GroupString() gredis.IGroupString       { return nil }
+func (a *closeRecordAdapter) Close(ctx context.Context) error        { a.closed = true; return nil }
+
+func Test_Lifecycle_Shutdown_RemoveRedisInstance(t *testing.T) {
+	defer isolateManagedInstances()()
+	gredis.RegisterAdapterFunc(func(config *gredis.Config) gredis.Adapter {
+		return &closeRecordAdapter{}
+	})
+	defer gredis.RegisterAdapterFunc(func(config *gredis.Config) gredis.Adapter {
+		return nil
+	})
+	gtest.C(t, func(t *gtest.T) {
+		name := guid.S()
+		gredis.SetConfig(&gredis.Config{Address: "127.0.0.1:6379"}, name)
+		defer gredis.RemoveConfig(name)
+
+		redis := Redis(name)
+		t.Assert(redis == gredis.Instance(name), true)
+		t.AssertNil(Shutdown(context.Background()))
+		t.Assert(redis.GetAdapter().(*closeRecordAdapter).closed, true)
+
+		// The closed client is removed from the instances of both gins and gredis.
+		t.Assert(Redis(name) == redis, false)
+		t.Assert(gredis.Instance(name) == redis, false)
+		t.Assert(Redis(name) == gredis.Instance(name), true)
+	})
+}
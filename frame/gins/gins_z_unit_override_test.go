@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gins_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/frame/gins"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type fakeDB struct {
+	gdb.DB
+	name string
+}
+
+func Test_Override(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			db1     = &fakeDB{name: "db1"}
+			db2     = &fakeDB{name: "db2"}
+			restore = gins.OverrideDatabase("", db1)
+		)
+		t.Assert(gins.Database().(*fakeDB).name, "db1")
+		t.Assert(gins.Database("default").(*fakeDB).name, "db1")
+
+		// Nested overriding.
+		restore2 := gins.OverrideDatabase("default", db2)
+		t.Assert(gins.Database().(*fakeDB).name, "db2")
+		restore2()
+		t.Assert(gins.Database().(*fakeDB).name, "db1")
+		restore()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		redis := &gredis.Redis{}
+		restore := gins.OverrideRedis("cache", redis)
+		defer restore()
+		t.Assert(gins.Redis("cache") == redis, true)
+	})
+}
+
+func Test_InitEager(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			db     = &fakeDB{name: "eager"}
+			logger = gins.Log("eager")
+		)
+		defer gins.OverrideDatabase("eager", db)()
+		gins.SetInitPolicy(gins.InstanceKey(gins.InstanceKindDatabase, "eager"), gins.InitPolicyEager)
+		gins.SetInitPolicy(gins.InstanceKey(gins.InstanceKindLogger, "eager"), gins.InitPolicyEager)
+		gins.SetInitPolicy(gins.InstanceKey(gins.InstanceKindLogger, "lazy"), gins.InitPolicyLazy)
+		t.AssertNil(gins.InitEager(ctx))
+		t.Assert(gins.Log("eager") == logger, true)
+
+		gins.SetInitPolicy("unknown.eager", gins.InitPolicyEager)
+		defer gins.SetInitPolicy("unknown.eager", gins.InitPolicyLazy)
+		err := gins.InitEager(ctx)
+		t.AssertNE(err, nil)
+		t.Assert(strings.Contains(err.Error(), `invalid instance key "unknown.eager"`), true)
+	})
+}
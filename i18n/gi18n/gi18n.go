@@ -50,3 +50,9 @@ func Translate(ctx context.Context, content string) string {
 func GetContent(ctx context.Context, key string) string {
 	return Instance().GetContent(ctx, key)
 }
+
+// TranslateError translates the i18n message keys of error `err` with configured language,
+// and returns the translated error string. See gerror.NewI18n.
+func TranslateError(ctx context.Context, err error) string {
+	return Instance().TranslateError(ctx, err)
+}
@@ -202,6 +202,14 @@ func (m *Manager) Translate(ctx context.Context, content string) string {
 	return result
 }
 
+// TranslateError translates the i18n message keys of error `err` with configured language,
+// and returns the translated error string. See gerror.NewI18n.
+func (m *Manager) TranslateError(ctx context.Context, err error) string {
+	return gerror.Translate(err, func(key string, args ...interface{}) string {
+		return m.TranslateFormat(ctx, key, args...)
+	})
+}
+
 // GetContent retrieves and returns the configured content for given key and specified language.
 // It returns an empty string if not found.
 func (m *Manager) GetContent(ctx context.Context, key string) string {
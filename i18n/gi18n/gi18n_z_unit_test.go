@@ -13,9 +13,12 @@ import (
 	"github.com/gogf/gf/v2/os/gctx"
 
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/i18n/gi18n"
 	"github.com/gogf/gf/v2/os/gfile"
@@ -261,3 +264,26 @@ func Test_PathInNormal(t *testing.T) {
 		t.Assert(i18n.T(context.Background(), "{#lang}"), "en-US")
 	})
 }
+
+func Test_TranslateError(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			i18n = gi18n.New(gi18n.Options{
+				Path:     gtest.DataPath("i18n"),
+				Language: "zh-CN",
+			})
+			err = gerror.WrapI18n(
+				gerror.Wrap(gerror.NewCodeI18n(gcode.CodeNotFound, "world"), "not translated"),
+				"hello",
+			)
+		)
+		t.Assert(err.Error(), "hello: not translated: world")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		t.Assert(i18n.TranslateError(ctx, err), "你好: not translated: 世界")
+		t.Assert(i18n.TranslateError(gi18n.WithLanguage(ctx, "en"), err), "Hello: not translated: World")
+		t.Assert(i18n.TranslateError(ctx, gerror.NewI18n("unknown %d", 1)), "unknown 1")
+		t.Assert(i18n.TranslateError(ctx, gerror.Join(gerror.NewI18n("hello"), errors.New("raw"))), "你好; raw")
+		t.Assert(i18n.TranslateError(ctx, nil), "")
+	})
+}
@@ -21,6 +21,12 @@ const (
 	fileUploadingKey = "@file:"
 )
 
+const (
+	// HeaderRequestBudget is the default header propagating the remaining time budget of request
+	// in milliseconds from client to server.
+	HeaderRequestBudget = "X-Request-Budget"
+)
+
 // BuildParams builds the request string for the http client. The `params` can be type of:
 // string/[]byte/map/struct/*struct.
 //
@@ -71,6 +71,11 @@ func SetIfNotExist(name string, instance interface{}) bool {
 	return getGroup(name).SetIfNotExist(name, instance)
 }
 
+// Remove deletes the instance by given name.
+func Remove(name string) {
+	getGroup(name).Remove(name)
+}
+
 // Clear deletes all instances stored.
 func Clear() {
 	for i := 0; i < groupNumber; i++ {
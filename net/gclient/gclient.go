@@ -15,6 +15,8 @@ import (
 	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/propagation"
+
 	"github.com/gogf/gf/v2"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/gsel"
@@ -24,18 +26,30 @@ import (
 
 // Client is the HTTP client for HTTP request management.
 type Client struct {
-	http.Client                         // Underlying HTTP Client.
-	header            map[string]string // Custom header map.
-	cookies           map[string]string // Custom cookie map.
-	prefix            string            // Prefix for request.
-	authUser          string            // HTTP basic authentication: user.
-	authPass          string            // HTTP basic authentication: pass.
-	retryCount        int               // Retry count when request fails.
-	noUrlEncode       bool              // No url encoding for request parameters.
-	retryInterval     time.Duration     // Retry interval when request fails.
-	middlewareHandler []HandlerFunc     // Interceptor handlers
-	discovery         gsvc.Discovery    // Discovery for service.
-	builder           gsel.Builder      // Builder for request balance.
+	http.Client                                     // Underlying HTTP Client.
+	header            map[string]string             // Custom header map.
+	cookies           map[string]string             // Custom cookie map.
+	prefix            string                        // Prefix for request.
+	authUser          string                        // HTTP basic authentication: user.
+	authPass          string                        // HTTP basic authentication: pass.
+	noUrlEncode       bool                          // No url encoding for request parameters.
+	retryPolicy       RetryPolicy                   // Retry policy when request fails.
+	hedgePolicy       HedgePolicy                   // Hedge policy for reducing tail latency.
+	circuitBreaker    *circuitBreakerGroup          // Circuit breakers for downstream, nil if disabled.
+	rateLimiter       *rateLimiterGroup             // Rate limiters for requests, nil if disabled.
+	balancer          *balancer                     // Balancer across endpoints, nil if disabled.
+	cache             *httpCache                    // Response cache, nil if disabled.
+	acceptEncodings   []string                      // Content encodings negotiated and decoded by client.
+	contentCodec      ContentCodec                  // Codec of request and response content, nil if not set.
+	requestProxy      string                        // Proxy overriding the client proxy for requests, empty if not set.
+	batchConcurrency  int                           // Maximum concurrent requests of Batch.
+	budget            time.Duration                 // Total time budget of request including retries and redirects.
+	budgetHeader      string                        // Header propagating the remaining budget.
+	tokenManager      *tokenManager                 // Token source of requests, nil if disabled.
+	middlewareHandler []HandlerFunc                 // Interceptor handlers
+	discovery         gsvc.Discovery                // Discovery for service.
+	builder           gsel.Builder                  // Builder for request balance.
+	propagator        propagation.TextMapPropagator // Propagator for tracing context, the global one if nil.
 }
 
 const (
@@ -67,6 +81,8 @@ func New() *Client {
 					InsecureSkipVerify: true,
 				},
 				DisableKeepAlives: true,
+				// The proxy of request can be overridden using SetRequestProxy.
+				Proxy: newProxyFunc(nil),
 			},
 		},
 		header:    make(map[string]string),
@@ -76,7 +92,14 @@ func New() *Client {
 	}
 	c.header[httpHeaderUserAgent] = defaultClientAgent
 	// It enables OpenTelemetry for client in default.
-	c.Use(internalMiddlewareObservability, internalMiddlewareDiscovery)
+	c.Use(
+		internalMiddlewareBalancer,
+		internalMiddlewareObservability,
+		internalMiddlewareDiscovery,
+		internalMiddlewareCache,
+		internalMiddlewareRateLimit,
+		internalMiddlewareCircuitBreaker,
+	)
 	return c
 }
 
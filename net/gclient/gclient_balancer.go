@@ -0,0 +1,277 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+)
+
+// BalanceStrategy is the strategy balancing the requests across endpoints.
+type BalanceStrategy string
+
+const (
+	BalanceRoundRobin      BalanceStrategy = "round-robin"      // Picks endpoints in turn, which is the default.
+	BalanceWeight          BalanceStrategy = "weight"           // Picks endpoints randomly by their weights.
+	BalanceLeastConnection BalanceStrategy = "least-connection" // Picks the endpoint with least in-flight requests.
+	BalanceEWMA            BalanceStrategy = "ewma"             // Picks the endpoint with least EWMA latency.
+)
+
+// BalancerEndpoint is an endpoint that requests are balanced to.
+type BalancerEndpoint struct {
+	Url    string // Base url of endpoint, like "http://127.0.0.1:8000/api".
+	Weight int    // Weight of endpoint for BalanceWeight, which is 1 if <= 0.
+}
+
+// BalancerConfig is the configuration for client-side load balancing.
+type BalancerConfig struct {
+	// Endpoints are the endpoints that requests are balanced to.
+	Endpoints []BalancerEndpoint
+
+	// Strategy is the balancing strategy, which is BalanceRoundRobin in default.
+	Strategy BalanceStrategy
+
+	// Builder builds the selector of endpoints, which replaces Strategy if given,
+	// so that any gsel.Builder can be used for balancing.
+	Builder gsel.Builder
+
+	// FailureThreshold is the count of consecutive failures that ejects an endpoint, which is 5 if <= 0.
+	// The request fails if it returns error or the response status code is >= 500.
+	FailureThreshold int
+
+	// EjectDuration is the duration that an ejected endpoint is excluded from balancing,
+	// which is 30 seconds if <= 0.
+	EjectDuration time.Duration
+}
+
+// balancer balances the requests across endpoints with passive health checking.
+type balancer struct {
+	mu       sync.Mutex
+	config   BalancerConfig
+	selector gsel.Selector
+	nodes    []*balancerNode
+	ejected  int // Count of ejected nodes.
+}
+
+// balancerNode is the endpoint node of balancer, which implements gsel.Node.
+type balancerNode struct {
+	service  gsvc.Service
+	url      *url.URL
+	failures int       // Consecutive failures.
+	ejectAt  time.Time // Time that the node is ejected, zero if not ejected.
+}
+
+const (
+	defaultBalancerFailureThreshold = 5
+	defaultBalancerEjectDuration    = 30 * time.Second
+	balancerServiceName             = "gclient-balancer"
+)
+
+// NewWithBalancer creates and returns a new HTTP client object, whose requests are balanced across
+// the endpoints in `config`. See SetBalancer.
+func NewWithBalancer(config BalancerConfig) (*Client, error) {
+	c := New()
+	if err := c.SetBalancer(config); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Balancer is a chaining function,
+// which balances the next requests across endpoints.
+func (c *Client) Balancer(config BalancerConfig) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetBalancer(config); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetBalancer sets the endpoints of the client, whose requests are balanced across the endpoints using the
+// strategy in `config`. The request url should be a path like "/user/1" without host, which is appended to
+// the base url of the picked endpoint.
+//
+// It ejects the endpoint failing consecutively for a while, which is known as passive health checking,
+// and all endpoints are used if all of them are ejected. It removes the balancer if no endpoint given.
+func (c *Client) SetBalancer(config BalancerConfig) error {
+	if len(config.Endpoints) == 0 {
+		c.balancer = nil
+		return nil
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultBalancerFailureThreshold
+	}
+	if config.EjectDuration <= 0 {
+		config.EjectDuration = defaultBalancerEjectDuration
+	}
+	builder := config.Builder
+	if builder == nil {
+		switch config.Strategy {
+		case BalanceRoundRobin, "":
+			builder = gsel.NewBuilderRoundRobin()
+		case BalanceWeight:
+			builder = gsel.NewBuilderWeight()
+		case BalanceLeastConnection:
+			builder = gsel.NewBuilderLeastConnection()
+		case BalanceEWMA:
+			builder = gsel.NewBuilderEWMA()
+		default:
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid balance strategy "%s"`, config.Strategy)
+		}
+	}
+	b := &balancer{
+		config:   config,
+		selector: builder.Build(),
+		nodes:    make([]*balancerNode, 0, len(config.Endpoints)),
+	}
+	for _, endpoint := range config.Endpoints {
+		u, err := url.Parse(endpoint.Url)
+		if err != nil || u.Host == "" {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid endpoint url "%s"`, endpoint.Url)
+		}
+		if u.Scheme == "" {
+			u.Scheme = httpProtocolName
+		}
+		weight := endpoint.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		b.nodes = append(b.nodes, &balancerNode{
+			service: &gsvc.LocalService{
+				Name:      balancerServiceName,
+				Endpoints: gsvc.NewEndpoints(u.Host),
+				Metadata:  gsvc.Metadata{gsvc.MDWeight: weight},
+			},
+			url: u,
+		})
+	}
+	if err := b.update(context.TODO()); err != nil {
+		return err
+	}
+	c.balancer = b
+	return nil
+}
+
+// internalMiddlewareBalancer is a client middleware that balances the requests across endpoints.
+func internalMiddlewareBalancer(c *Client, r *http.Request) (response *Response, err error) {
+	if c.balancer == nil {
+		return c.Next(r)
+	}
+	var ctx = r.Context()
+	node, done, err := c.balancer.pick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.URL.Scheme = node.url.Scheme
+	r.URL.Host = node.url.Host
+	r.URL.Path = strings.TrimSuffix(node.url.Path, "/") + "/" + strings.TrimPrefix(r.URL.Path, "/")
+	if r.URL.RawPath != "" {
+		r.URL.RawPath = strings.TrimSuffix(node.url.EscapedPath(), "/") + "/" + strings.TrimPrefix(r.URL.RawPath, "/")
+	}
+	r.Host = node.url.Host
+	response, err = c.Next(r)
+	failure := err
+	if failure == nil && response != nil && response.Response != nil &&
+		response.StatusCode >= http.StatusInternalServerError {
+		failure = gerror.NewCodef(gcode.CodeOperationFailed, `response status: %s`, response.Status)
+	}
+	if done != nil {
+		done(ctx, gsel.DoneInfo{Err: failure})
+	}
+	c.balancer.report(ctx, node, failure)
+	return response, err
+}
+
+// pick picks an endpoint node, in which the ejected nodes are readmitted if their eject duration passed.
+func (b *balancer) pick(ctx context.Context) (*balancerNode, gsel.DoneFunc, error) {
+	b.mu.Lock()
+	if b.ejected > 0 {
+		var (
+			now     = time.Now()
+			changed bool
+		)
+		for _, node := range b.nodes {
+			if !node.ejectAt.IsZero() && now.Sub(node.ejectAt) >= b.config.EjectDuration {
+				node.ejectAt = time.Time{}
+				node.failures = 0
+				b.ejected--
+				changed = true
+			}
+		}
+		if changed {
+			if err := b.update(ctx); err != nil {
+				b.mu.Unlock()
+				return nil, nil, err
+			}
+		}
+	}
+	b.mu.Unlock()
+	node, done, err := b.selector.Pick(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if node == nil {
+		return nil, nil, gerror.NewCode(gcode.CodeNotFound, `no endpoint available for balancing`)
+	}
+	return node.(*balancerNode), done, nil
+}
+
+// report reports the result of request sent to `node`, which ejects the node if it fails consecutively.
+func (b *balancer) report(ctx context.Context, node *balancerNode, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		node.failures = 0
+		return
+	}
+	node.failures++
+	if node.failures < b.config.FailureThreshold || !node.ejectAt.IsZero() {
+		return
+	}
+	intlog.Printf(ctx, `http client balancer ejects endpoint "%s": %+v`, node.Address(), err)
+	node.ejectAt = time.Now()
+	b.ejected++
+	if err = b.update(ctx); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// update updates the nodes not ejected to the selector, or all nodes if all of them are ejected.
+func (b *balancer) update(ctx context.Context) error {
+	nodes := make(gsel.Nodes, 0, len(b.nodes))
+	for _, node := range b.nodes {
+		if node.ejectAt.IsZero() {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		for _, node := range b.nodes {
+			nodes = append(nodes, node)
+		}
+	}
+	return b.selector.Update(ctx, nodes)
+}
+
+// Service returns the service of the node.
+func (n *balancerNode) Service() gsvc.Service {
+	return n.service
+}
+
+// Address returns the base url of the node.
+func (n *balancerNode) Address() string {
+	return n.url.String()
+}
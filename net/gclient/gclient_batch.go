@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+// BatchRequest is a single request of batch.
+type BatchRequest struct {
+	Method string      // HTTP method, which is GET if empty.
+	Url    string      // Request URL, which is prefixed with the prefix of client.
+	Data   interface{} // Request data, the same as the data of DoRequest.
+}
+
+// BatchResult is the result of a single request of batch.
+type BatchResult struct {
+	Response *Response // Response of request, nil if Error is not nil.
+	Error    error     // Error of request.
+}
+
+const (
+	defaultBatchConcurrency = 10
+	tracingSpanNameBatch    = "gclient.Batch"
+	tracingAttrBatchSize    = "gclient.batch.size"
+	tracingAttrBatchFailed  = "gclient.batch.failed"
+)
+
+// BatchConcurrency is a chaining function,
+// which sets the maximum concurrent requests of Batch for next request.
+func (c *Client) BatchConcurrency(concurrency int) *Client {
+	newClient := c.Clone()
+	newClient.SetBatchConcurrency(concurrency)
+	return newClient
+}
+
+// SetBatchConcurrency sets the maximum concurrent requests of Batch, which is 10 if <= 0.
+func (c *Client) SetBatchConcurrency(concurrency int) *Client {
+	c.batchConcurrency = concurrency
+	return c
+}
+
+// Batch sends `requests` concurrently with bounded parallelism set by SetBatchConcurrency,
+// and returns the results in order of `requests`. The returned error joins the errors of all failed
+// requests, which is nil if all requests succeed. The requests are traced as children of a span of batch.
+//
+// Note that the responses of results MUST be closed if they'll never be used.
+func (c *Client) Batch(ctx context.Context, requests ...BatchRequest) ([]BatchResult, error) {
+	var (
+		results     = make([]BatchResult, len(requests))
+		concurrency = c.batchConcurrency
+	)
+	if len(requests) == 0 {
+		return results, nil
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	var span trace.Span
+	if !gtrace.IsUsingDefaultProvider() {
+		tr := otel.GetTracerProvider().Tracer(
+			instrumentName,
+			trace.WithInstrumentationVersion(gf.VERSION),
+		)
+		ctx, span = tr.Start(ctx, tracingSpanNameBatch, trace.WithSpanKind(trace.SpanKindInternal))
+		defer span.End()
+		span.SetAttributes(attribute.Int(tracingAttrBatchSize, len(requests)))
+	}
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, concurrency)
+	)
+	for i, request := range requests {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, request BatchRequest) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			var data []interface{}
+			if request.Data != nil {
+				data = append(data, request.Data)
+			}
+			results[i].Response, results[i].Error = c.DoRequest(ctx, request.getMethod(), request.Url, data...)
+			if results[i].Error != nil && results[i].Response != nil {
+				_ = results[i].Response.Close()
+				results[i].Response = nil
+			}
+		}(i, request)
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, result := range results {
+		if result.Error != nil {
+			errs = append(errs, gerror.Wrapf(
+				result.Error, `batch request %d "%s %s" failed`, i, requests[i].getMethod(), requests[i].Url,
+			))
+		}
+	}
+	err := gerror.Join(errs...)
+	if span != nil && err != nil {
+		span.SetAttributes(attribute.Int(tracingAttrBatchFailed, len(errs)))
+		span.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
+	}
+	return results, err
+}
+
+// getMethod returns the HTTP method of request, which is GET if empty.
+func (r BatchRequest) getMethod() string {
+	if r.Method == "" {
+		return http.MethodGet
+	}
+	return r.Method
+}
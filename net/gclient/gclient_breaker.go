@@ -0,0 +1,297 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+// CircuitBreakerState is the state of circuit breaker.
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerStateClosed allows all requests, and opens if the failure rate exceeds the threshold.
+	CircuitBreakerStateClosed CircuitBreakerState = iota
+	// CircuitBreakerStateOpen rejects all requests with ErrCircuitBreakerOpen until the open timeout.
+	CircuitBreakerStateOpen
+	// CircuitBreakerStateHalfOpen allows limited probe requests, which closes if all of them succeed,
+	// or else opens again.
+	CircuitBreakerStateHalfOpen
+)
+
+const (
+	defaultCircuitBreakerFailureRate      = 0.5
+	defaultCircuitBreakerMinRequests      = 10
+	defaultCircuitBreakerWindow           = 10 * time.Second
+	defaultCircuitBreakerOpenTimeout      = 30 * time.Second
+	defaultCircuitBreakerHalfOpenRequests = 1
+)
+
+// CircuitBreakerConfig is the configuration for circuit breaker of client.
+// The circuit breakers are created for each key, which is the host of request in default.
+type CircuitBreakerConfig struct {
+	// FailureRateThreshold is the failure rate in (0, 1] that opens the breaker, which is 0.5 in default.
+	FailureRateThreshold float64
+
+	// MinRequests is the min request count in window before the failure rate is evaluated,
+	// which is 10 in default.
+	MinRequests int
+
+	// Window is the duration of statistics window for failure rate, which is 10 seconds in default.
+	// The statistics are reset when the window elapses.
+	Window time.Duration
+
+	// OpenTimeout is the duration of open state before half-open, which is 30 seconds in default.
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is the probe request count allowed in half-open state, which is 1 in default.
+	HalfOpenRequests int
+
+	// IsFailure checks whether the request fails, which takes the requests failing with error or
+	// responses with status code >= 500 as failures in default.
+	IsFailure func(resp *http.Response, err error) bool
+
+	// KeyFunc returns the key of breaker for request, which is the host of request in default.
+	KeyFunc func(r *http.Request) string
+
+	// OnStateChange is called when the state of breaker changes.
+	OnStateChange func(ctx context.Context, key string, from, to CircuitBreakerState)
+}
+
+// circuitBreakerGroup manages the circuit breakers of client by key.
+type circuitBreakerGroup struct {
+	config   CircuitBreakerConfig
+	breakers sync.Map // map[string]*circuitBreaker
+}
+
+// circuitBreaker is the circuit breaker of a single key.
+type circuitBreaker struct {
+	mu                sync.Mutex
+	state             CircuitBreakerState
+	windowStart       time.Time // Start time of statistics window in closed state.
+	requests          int       // Request count in window.
+	failures          int       // Failure count in window.
+	openedAt          time.Time // Time of entering open state.
+	halfOpenRequests  int       // Probe request count allowed in half-open state.
+	halfOpenSuccesses int       // Succeeded probe request count in half-open state.
+}
+
+// ErrCircuitBreakerOpen is returned if the request is rejected by open circuit breaker.
+var ErrCircuitBreakerOpen = gerror.NewWithOption(gerror.Option{
+	Text: "circuit breaker is open",
+	Code: gcode.CodeOperationFailed,
+})
+
+// String returns the readable name of the state.
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerStateOpen:
+		return "open"
+	case CircuitBreakerStateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker is a chaining function,
+// which enables circuit breaker with `config` for next request.
+func (c *Client) CircuitBreaker(config CircuitBreakerConfig) *Client {
+	newClient := c.Clone()
+	newClient.SetCircuitBreaker(config)
+	return newClient
+}
+
+// SetCircuitBreaker enables circuit breaker with `config` for the client, which rejects the requests
+// with ErrCircuitBreakerOpen if the failure rate of the downstream exceeds the threshold, so that the
+// downstream failures do not cascade. Note that the clients cloned from the client share the breakers.
+func (c *Client) SetCircuitBreaker(config CircuitBreakerConfig) *Client {
+	if config.FailureRateThreshold <= 0 || config.FailureRateThreshold > 1 {
+		config.FailureRateThreshold = defaultCircuitBreakerFailureRate
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = defaultCircuitBreakerMinRequests
+	}
+	if config.Window <= 0 {
+		config.Window = defaultCircuitBreakerWindow
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultCircuitBreakerOpenTimeout
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = defaultCircuitBreakerHalfOpenRequests
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || (resp != nil && resp.StatusCode >= http.StatusInternalServerError)
+		}
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(r *http.Request) string {
+			return r.URL.Host
+		}
+	}
+	c.circuitBreaker = &circuitBreakerGroup{
+		config: config,
+	}
+	return c
+}
+
+// GetCircuitBreakerState returns the state of circuit breaker for `key`, which is the host of request
+// in default. It returns CircuitBreakerStateClosed if circuit breaker is not enabled.
+func (c *Client) GetCircuitBreakerState(key string) CircuitBreakerState {
+	if c.circuitBreaker == nil {
+		return CircuitBreakerStateClosed
+	}
+	v, ok := c.circuitBreaker.breakers.Load(key)
+	if !ok {
+		return CircuitBreakerStateClosed
+	}
+	breaker := v.(*circuitBreaker)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	return breaker.state
+}
+
+// internalMiddlewareCircuitBreaker is a client middleware that enables circuit breaker feature for client.
+func internalMiddlewareCircuitBreaker(c *Client, r *http.Request) (response *Response, err error) {
+	if c.circuitBreaker == nil {
+		return c.Next(r)
+	}
+	var (
+		ctx     = r.Context()
+		group   = c.circuitBreaker
+		key     = group.config.KeyFunc(r)
+		breaker = group.getBreaker(key)
+	)
+	allowed, from, to := breaker.allow(time.Now(), group.config)
+	if from != to {
+		group.handleStateChange(ctx, key, from, to)
+	}
+	if !allowed {
+		return nil, gerror.Wrapf(ErrCircuitBreakerOpen, `request to "%s" rejected`, key)
+	}
+	response, err = c.Next(r)
+	var httpResponse *http.Response
+	if response != nil {
+		httpResponse = response.Response
+	}
+	from, to = breaker.record(time.Now(), group.config, group.config.IsFailure(httpResponse, err))
+	if from != to {
+		group.handleStateChange(ctx, key, from, to)
+	}
+	return
+}
+
+// getBreaker returns the circuit breaker for `key`, which creates one if not exists.
+func (g *circuitBreakerGroup) getBreaker(key string) *circuitBreaker {
+	if v, ok := g.breakers.Load(key); ok {
+		return v.(*circuitBreaker)
+	}
+	v, _ := g.breakers.LoadOrStore(key, &circuitBreaker{
+		windowStart: time.Now(),
+	})
+	return v.(*circuitBreaker)
+}
+
+// handleStateChange records the state transition of breaker in metrics and calls the callback.
+func (g *circuitBreakerGroup) handleStateChange(ctx context.Context, key string, from, to CircuitBreakerState) {
+	if gmetric.IsEnabled() {
+		metricManager.HttpClientCircuitBreakerStateChange.Inc(ctx, gmetric.Option{
+			Attributes: gmetric.Attributes{
+				gmetric.NewAttribute(metricAttrKeyCircuitBreakerKey, key),
+				gmetric.NewAttribute(metricAttrKeyCircuitBreakerFromState, from.String()),
+				gmetric.NewAttribute(metricAttrKeyCircuitBreakerToState, to.String()),
+			},
+		})
+	}
+	if g.config.OnStateChange != nil {
+		g.config.OnStateChange(ctx, key, from, to)
+	}
+}
+
+// allow checks whether the request is allowed, which returns the state transition if any.
+func (b *circuitBreaker) allow(now time.Time, config CircuitBreakerConfig) (allowed bool, from, to CircuitBreakerState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	from = b.state
+	switch b.state {
+	case CircuitBreakerStateOpen:
+		if now.Sub(b.openedAt) < config.OpenTimeout {
+			return false, from, b.state
+		}
+		b.setState(CircuitBreakerStateHalfOpen, now)
+		fallthrough
+
+	case CircuitBreakerStateHalfOpen:
+		if b.halfOpenRequests >= config.HalfOpenRequests {
+			return false, from, b.state
+		}
+		b.halfOpenRequests++
+
+	default:
+		if now.Sub(b.windowStart) >= config.Window {
+			b.resetWindow(now)
+		}
+	}
+	return true, from, b.state
+}
+
+// record records the result of request, which returns the state transition if any.
+func (b *circuitBreaker) record(now time.Time, config CircuitBreakerConfig, failed bool) (from, to CircuitBreakerState) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	from = b.state
+	switch b.state {
+	case CircuitBreakerStateHalfOpen:
+		if failed {
+			b.setState(CircuitBreakerStateOpen, now)
+			break
+		}
+		b.halfOpenSuccesses++
+		if b.halfOpenSuccesses >= config.HalfOpenRequests {
+			b.setState(CircuitBreakerStateClosed, now)
+		}
+
+	case CircuitBreakerStateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= config.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= config.FailureRateThreshold {
+			b.setState(CircuitBreakerStateOpen, now)
+		}
+
+	default:
+		// The results of requests sent before opening are ignored.
+	}
+	return from, b.state
+}
+
+// setState changes the state of breaker and resets its statistics.
+func (b *circuitBreaker) setState(state CircuitBreakerState, now time.Time) {
+	b.state = state
+	b.openedAt = now
+	b.halfOpenRequests = 0
+	b.halfOpenSuccesses = 0
+	b.resetWindow(now)
+}
+
+// resetWindow resets the statistics window of closed state.
+func (b *circuitBreaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/httputil"
+)
+
+const (
+	// defaultMaxRedirects is the max redirects of http.Client if CheckRedirect is nil.
+	defaultMaxRedirects = 10
+)
+
+// Budget is a chaining function,
+// which sets the total time budget of next request including its retries and redirects.
+func (c *Client) Budget(total time.Duration) *Client {
+	newClient := c.Clone()
+	newClient.SetBudget(total)
+	return newClient
+}
+
+// SetBudget sets the total time budget of each request, which is the deadline shared by all retries and
+// redirects of the request, and it stops retrying if the remaining budget is not enough for next retry.
+// The earlier deadline of request context takes precedence. It disables the budget if `total` <= 0.
+//
+// The remaining budget is propagated to server in milliseconds in header "X-Request-Budget" for each
+// attempt and redirect, which can be honored by ghttp.MiddlewareBudget of the downstream server.
+// The header name can be changed by SetBudgetHeader.
+func (c *Client) SetBudget(total time.Duration) *Client {
+	c.budget = total
+	return c
+}
+
+// SetBudgetHeader sets the header name propagating the remaining budget, which is "X-Request-Budget" in default.
+func (c *Client) SetBudgetHeader(name string) *Client {
+	c.budgetHeader = name
+	return c
+}
+
+// setBudgetHeader sets the remaining budget of request in header if the budget is enabled.
+func (c *Client) setBudgetHeader(req *http.Request) {
+	if c.budget <= 0 {
+		return
+	}
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		return
+	}
+	remaining := time.Until(deadline).Milliseconds()
+	if remaining < 0 {
+		remaining = 0
+	}
+	name := c.budgetHeader
+	if name == "" {
+		name = httputil.HeaderRequestBudget
+	}
+	req.Header.Set(name, strconv.FormatInt(remaining, 10))
+}
+
+// do sends the request using the underlying http client,
+// in which the budget header is updated for each redirect if the budget is enabled.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.budget <= 0 {
+		return c.Do(req)
+	}
+	var (
+		client        = c.Client
+		checkRedirect = client.CheckRedirect
+	)
+	client.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		if checkRedirect != nil {
+			if err := checkRedirect(r, via); err != nil {
+				return err
+			}
+		} else if len(via) >= defaultMaxRedirects {
+			return gerror.Newf(`stopped after %d redirects`, defaultMaxRedirects)
+		}
+		c.setBudgetHeader(r)
+		return nil
+	}
+	return client.Do(req)
+}
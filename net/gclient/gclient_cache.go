@@ -0,0 +1,526 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+// CacheOption is the option of response cache.
+type CacheOption struct {
+	// Shared specifies whether the cache is a shared cache, like the cache of proxy shared by users,
+	// which honors "s-maxage" and does not store the responses of "private" or of authorized requests.
+	// It is a private cache in default, whose responses are served only to the requests of the same
+	// credentials, that is the same "Authorization" and "Cookie" headers, as the client can be shared by users.
+	Shared bool
+
+	// MaxTTL is the max duration that a response is stored, which is 24 hours if <= 0.
+	// The responses with validators "ETag" or "Last-Modified" are stored for MaxTTL for revalidation.
+	MaxTTL time.Duration
+
+	// MaxBodySize is the max body size of the response stored, which is 10MB if <= 0.
+	MaxBodySize int64
+
+	// KeyPrefix is the prefix of cache keys, which is "gclient:cache:" in default.
+	KeyPrefix string
+}
+
+// httpCache is the response cache of client.
+type httpCache struct {
+	adapter gcache.Adapter
+	option  CacheOption
+}
+
+// cacheEntry is the stored response.
+type cacheEntry struct {
+	Status       string            `json:"status"`
+	StatusCode   int               `json:"statusCode"`
+	Header       http.Header       `json:"header"`
+	Body         []byte            `json:"body"`
+	Vary         map[string]string `json:"vary,omitempty"`       // Request header values selected by "Vary".
+	Credential   string            `json:"credential,omitempty"` // Hash of request credentials for private cache.
+	RequestTime  time.Time         `json:"requestTime"`
+	ResponseTime time.Time         `json:"responseTime"`
+}
+
+const (
+	httpHeaderCacheControl       = "Cache-Control"
+	httpHeaderPragma             = "Pragma"
+	httpHeaderExpires            = "Expires"
+	httpHeaderDate               = "Date"
+	httpHeaderAge                = "Age"
+	httpHeaderVary               = "Vary"
+	httpHeaderETag               = "ETag"
+	httpHeaderLastModified       = "Last-Modified"
+	httpHeaderIfNoneMatch        = "If-None-Match"
+	httpHeaderIfModifiedSince    = "If-Modified-Since"
+	defaultCacheMaxTTL           = 24 * time.Hour
+	defaultCacheMaxBodySize      = 10 << 20
+	defaultCacheKeyPrefix        = "gclient:cache:"
+	cacheHeuristicLifetimeFactor = 10 // The heuristic freshness lifetime is 10% of the age since last modified.
+)
+
+// cacheableStatusCodes are the status codes that can be cached, see RFC 7231 section 6.1.
+var cacheableStatusCodes = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMultipleChoices:      true,
+	http.StatusMovedPermanently:     true,
+	http.StatusPermanentRedirect:    true,
+	http.StatusNotFound:             true,
+	http.StatusMethodNotAllowed:     true,
+	http.StatusGone:                 true,
+	http.StatusRequestURITooLong:    true,
+	http.StatusNotImplemented:       true,
+}
+
+// Cache is a chaining function,
+// which enables response cache using `adapter` for next request.
+func (c *Client) Cache(adapter gcache.Adapter, option ...CacheOption) *Client {
+	newClient := c.Clone()
+	newClient.SetCache(adapter, option...)
+	return newClient
+}
+
+// SetCache enables response cache of the client using `adapter`, like gcache.NewAdapterMemory() or the
+// redis adapter shared by processes. It caches the responses of GET and HEAD requests following RFC 7234,
+// which honors the "Cache-Control" and "Expires" headers, and revalidates the stale responses with
+// "If-None-Match" and "If-Modified-Since" using their "ETag" and "Last-Modified".
+//
+// The successful responses of unsafe methods like POST invalidate the cached responses of the same url.
+// It disables response cache if `adapter` is nil.
+func (c *Client) SetCache(adapter gcache.Adapter, option ...CacheOption) *Client {
+	if adapter == nil {
+		c.cache = nil
+		return c
+	}
+	cache := &httpCache{
+		adapter: adapter,
+	}
+	if len(option) > 0 {
+		cache.option = option[0]
+	}
+	if cache.option.MaxTTL <= 0 {
+		cache.option.MaxTTL = defaultCacheMaxTTL
+	}
+	if cache.option.MaxBodySize <= 0 {
+		cache.option.MaxBodySize = defaultCacheMaxBodySize
+	}
+	if cache.option.KeyPrefix == "" {
+		cache.option.KeyPrefix = defaultCacheKeyPrefix
+	}
+	c.cache = cache
+	return c
+}
+
+// internalMiddlewareCache is a client middleware that enables response cache feature for client.
+func internalMiddlewareCache(c *Client, r *http.Request) (response *Response, err error) {
+	if c.cache == nil {
+		return c.Next(r)
+	}
+	return c.cache.handle(c, r)
+}
+
+// handle serves the request `r` from cache if the cached response is fresh, or else sends the request
+// and stores the response.
+func (h *httpCache) handle(c *Client, r *http.Request) (*Response, error) {
+	ctx := r.Context()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		resp, err := c.Next(r)
+		if err == nil && resp != nil && resp.Response != nil && resp.StatusCode < http.StatusBadRequest &&
+			!isSafeMethod(r.Method) {
+			h.invalidate(ctx, r)
+		}
+		return resp, err
+	}
+	requestDirectives := parseCacheControl(r.Header.Values(httpHeaderCacheControl))
+	if len(requestDirectives) == 0 && strings.Contains(r.Header.Get(httpHeaderPragma), "no-cache") {
+		requestDirectives["no-cache"] = ""
+	}
+	if _, ok := requestDirectives["no-store"]; ok {
+		return c.Next(r)
+	}
+	// The range requests are neither served from nor stored in cache.
+	if r.Header.Get(httpHeaderRange) != "" {
+		return c.Next(r)
+	}
+	var (
+		key         = h.key(r.Method, r.URL.String())
+		entry       = h.load(ctx, key)
+		conditional bool
+	)
+	if entry != nil && (!entry.matchVary(r) || entry.Credential != h.credential(r)) {
+		entry = nil
+	}
+	if entry != nil {
+		age := entry.currentAge(time.Now())
+		if h.isFresh(entry, requestDirectives, age) {
+			return entry.toResponse(r, age), nil
+		}
+		// The stale response is revalidated with its validators,
+		// unless the request is a conditional request of the caller.
+		if r.Header.Get(httpHeaderIfNoneMatch) == "" && r.Header.Get(httpHeaderIfModifiedSince) == "" {
+			if etag := entry.Header.Get(httpHeaderETag); etag != "" {
+				r.Header.Set(httpHeaderIfNoneMatch, etag)
+				conditional = true
+			}
+			if lastModified := entry.Header.Get(httpHeaderLastModified); lastModified != "" {
+				r.Header.Set(httpHeaderIfModifiedSince, lastModified)
+				conditional = true
+			}
+		}
+	}
+	requestTime := time.Now()
+	resp, err := c.Next(r)
+	if err != nil || resp == nil || resp.Response == nil {
+		return resp, err
+	}
+	responseTime := time.Now()
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		entry.refresh(resp.Header, requestTime, responseTime)
+		h.save(ctx, key, entry)
+		return entry.toResponse(r, entry.currentAge(time.Now())), nil
+	}
+	h.store(ctx, key, r, resp, requestTime, responseTime)
+	return resp, nil
+}
+
+// isFresh checks whether the cached `entry` of `age` can be served without revalidation.
+func (h *httpCache) isFresh(entry *cacheEntry, requestDirectives map[string]string, age time.Duration) bool {
+	responseDirectives := parseCacheControl(entry.Header.Values(httpHeaderCacheControl))
+	if _, ok := responseDirectives["no-cache"]; ok {
+		return false
+	}
+	if _, ok := requestDirectives["no-cache"]; ok {
+		return false
+	}
+	lifetime := entry.freshnessLifetime(responseDirectives, h.option.Shared)
+	if maxAge, ok := parseDirectiveSeconds(requestDirectives, "max-age"); ok && age > maxAge {
+		return false
+	}
+	if minFresh, ok := parseDirectiveSeconds(requestDirectives, "min-fresh"); ok && lifetime-age < minFresh {
+		return false
+	}
+	if age < lifetime {
+		return true
+	}
+	// The stale response is acceptable for request with "max-stale".
+	if _, ok := responseDirectives["must-revalidate"]; ok {
+		return false
+	}
+	if _, ok := requestDirectives["max-stale"]; !ok {
+		return false
+	}
+	if maxStale, ok := parseDirectiveSeconds(requestDirectives, "max-stale"); ok {
+		return age-lifetime <= maxStale
+	}
+	return true
+}
+
+// store stores the response `resp` of request `r` if it is cacheable.
+func (h *httpCache) store(
+	ctx context.Context, key string, r *http.Request, resp *Response, requestTime, responseTime time.Time,
+) {
+	if !cacheableStatusCodes[resp.StatusCode] || resp.Header.Get(httpHeaderVary) == "*" {
+		return
+	}
+	responseDirectives := parseCacheControl(resp.Header.Values(httpHeaderCacheControl))
+	if _, ok := responseDirectives["no-store"]; ok {
+		return
+	}
+	if h.option.Shared {
+		if _, ok := responseDirectives["private"]; ok {
+			return
+		}
+		// The responses of authorized requests are not shared unless explicitly allowed.
+		if r.Header.Get(httpHeaderAuthorization) != "" {
+			_, public := responseDirectives["public"]
+			_, sMaxAge := responseDirectives["s-maxage"]
+			_, mustRevalidate := responseDirectives["must-revalidate"]
+			if !public && !sMaxAge && !mustRevalidate {
+				return
+			}
+		}
+	}
+	if resp.ContentLength > h.option.MaxBodySize {
+		return
+	}
+	entry := &cacheEntry{
+		Status:       resp.Status,
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Credential:   h.credential(r),
+		RequestTime:  requestTime,
+		ResponseTime: responseTime,
+	}
+	var (
+		lifetime     = entry.freshnessLifetime(responseDirectives, h.option.Shared)
+		hasValidator = entry.Header.Get(httpHeaderETag) != "" || entry.Header.Get(httpHeaderLastModified) != ""
+	)
+	if lifetime <= 0 && !hasValidator {
+		return
+	}
+	// The body exceeding MaxBodySize is not stored, which is returned to the caller as it is.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, h.option.MaxBodySize+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		resp.Body = utils.NewReadCloser(body, false)
+		intlog.Errorf(ctx, `%+v`, err)
+		return
+	}
+	if int64(len(body)) > h.option.MaxBodySize {
+		resp.Body = &cacheBody{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return
+	}
+	_ = resp.Body.Close()
+	resp.Body = utils.NewReadCloser(body, false)
+	entry.Body = body
+	if vary := resp.Header.Values(httpHeaderVary); len(vary) > 0 {
+		entry.Vary = make(map[string]string)
+		for _, name := range parseHeaderList(vary) {
+			entry.Vary[name] = r.Header.Get(name)
+		}
+	}
+	h.save(ctx, key, entry)
+}
+
+// save saves `entry` to cache, which is kept for its freshness lifetime,
+// or MaxTTL if it has validators for revalidation.
+func (h *httpCache) save(ctx context.Context, key string, entry *cacheEntry) {
+	var (
+		responseDirectives = parseCacheControl(entry.Header.Values(httpHeaderCacheControl))
+		ttl                = entry.freshnessLifetime(responseDirectives, h.option.Shared) - entry.currentAge(time.Now())
+	)
+	if ttl > h.option.MaxTTL || entry.Header.Get(httpHeaderETag) != "" || entry.Header.Get(httpHeaderLastModified) != "" {
+		ttl = h.option.MaxTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return
+	}
+	if err = h.adapter.Set(ctx, key, data, ttl); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// load loads and returns the cached entry of `key`, which returns nil if not found.
+func (h *httpCache) load(ctx context.Context, key string) *cacheEntry {
+	v, err := h.adapter.Get(ctx, key)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	if v.IsNil() {
+		return nil
+	}
+	var entry *cacheEntry
+	if err = json.Unmarshal(v.Bytes(), &entry); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return nil
+	}
+	return entry
+}
+
+// invalidate removes the cached responses of the url of request `r`.
+func (h *httpCache) invalidate(ctx context.Context, r *http.Request) {
+	url := r.URL.String()
+	if _, err := h.adapter.Remove(ctx, h.key(http.MethodGet, url), h.key(http.MethodHead, url)); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// key returns the cache key of request with `method` and `url`.
+func (h *httpCache) key(method, url string) string {
+	return h.option.KeyPrefix + method + ":" + url
+}
+
+// credential returns the hash of the credentials of request `r` for private cache, which is empty
+// for shared cache or the request without credentials. The shared cache stores the responses
+// of authorized requests only if they are explicitly allowed to be shared.
+func (h *httpCache) credential(r *http.Request) string {
+	if h.option.Shared {
+		return ""
+	}
+	var (
+		authorization = r.Header.Values(httpHeaderAuthorization)
+		cookie        = r.Header.Values(httpHeaderCookie)
+	)
+	if len(authorization) == 0 && len(cookie) == 0 {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(strings.Join(authorization, "\n") + "\x00" + strings.Join(cookie, "\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// freshnessLifetime returns the freshness lifetime of the entry, see RFC 7234 section 4.2.1.
+func (e *cacheEntry) freshnessLifetime(responseDirectives map[string]string, shared bool) time.Duration {
+	if shared {
+		if sMaxAge, ok := parseDirectiveSeconds(responseDirectives, "s-maxage"); ok {
+			return sMaxAge
+		}
+	}
+	if maxAge, ok := parseDirectiveSeconds(responseDirectives, "max-age"); ok {
+		return maxAge
+	}
+	if expires := e.Header.Get(httpHeaderExpires); expires != "" {
+		// The invalid Expires means already expired.
+		expiresTime, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		return expiresTime.Sub(e.date())
+	}
+	if lastModified := e.Header.Get(httpHeaderLastModified); lastModified != "" {
+		lastModifiedTime, err := http.ParseTime(lastModified)
+		if err == nil && e.date().After(lastModifiedTime) {
+			return e.date().Sub(lastModifiedTime) / cacheHeuristicLifetimeFactor
+		}
+	}
+	return 0
+}
+
+// currentAge returns the current age of entry, see RFC 7234 section 4.2.3.
+func (e *cacheEntry) currentAge(now time.Time) time.Duration {
+	var (
+		apparentAge   = e.ResponseTime.Sub(e.date())
+		ageValue, _   = strconv.ParseInt(e.Header.Get(httpHeaderAge), 10, 64)
+		correctedAge  = time.Duration(ageValue)*time.Second + e.ResponseTime.Sub(e.RequestTime)
+		residentTime  = now.Sub(e.ResponseTime)
+		correctedInit = correctedAge
+	)
+	if apparentAge > correctedInit {
+		correctedInit = apparentAge
+	}
+	return correctedInit + residentTime
+}
+
+// date returns the time of header "Date", or the response time if absent.
+func (e *cacheEntry) date() time.Time {
+	if date, err := http.ParseTime(e.Header.Get(httpHeaderDate)); err == nil {
+		return date
+	}
+	return e.ResponseTime
+}
+
+// matchVary checks whether the request `r` matches the request headers selected by "Vary".
+func (e *cacheEntry) matchVary(r *http.Request) bool {
+	for name, value := range e.Vary {
+		if r.Header.Get(name) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// refresh updates the entry with the headers of response 304, see RFC 7234 section 4.3.4.
+func (e *cacheEntry) refresh(header http.Header, requestTime, responseTime time.Time) {
+	for name, values := range header {
+		if name == httpHeaderContentLength {
+			continue
+		}
+		e.Header[name] = values
+	}
+	e.RequestTime = requestTime
+	e.ResponseTime = responseTime
+}
+
+// toResponse creates and returns the response of the entry for request `r`.
+func (e *cacheEntry) toResponse(r *http.Request, age time.Duration) *Response {
+	header := e.Header.Clone()
+	header.Set(httpHeaderAge, strconv.FormatInt(int64(age/time.Second), 10))
+	return &Response{
+		Response: &http.Response{
+			Status:        e.Status,
+			StatusCode:    e.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          utils.NewReadCloser(e.Body, false),
+			ContentLength: int64(len(e.Body)),
+			Request:       r,
+		},
+		request: r,
+	}
+}
+
+// cacheBody is the response body that is partially read for caching.
+type cacheBody struct {
+	io.Reader
+	io.Closer
+}
+
+// parseCacheControl parses the directives of header "Cache-Control" to map,
+// in which the names are in lower case and the values are unquoted.
+func parseCacheControl(values []string) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			name, v, _ := strings.Cut(part, "=")
+			directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+	return directives
+}
+
+// parseDirectiveSeconds parses the directive `name` in seconds, which returns false if absent or invalid.
+func parseDirectiveSeconds(directives map[string]string, name string) (time.Duration, bool) {
+	v, ok := directives[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// parseHeaderList parses the comma separated header values to canonical header names.
+func parseHeaderList(values []string) []string {
+	var names []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// isSafeMethod checks whether `method` is safe, which does not change the state of server.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
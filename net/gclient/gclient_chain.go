@@ -9,6 +9,8 @@ package gclient
 import (
 	"time"
 
+	"go.opentelemetry.io/otel/propagation"
+
 	"github.com/gogf/gf/v2/net/gsvc"
 )
 
@@ -47,6 +49,13 @@ func (c *Client) Discovery(discovery gsvc.Discovery) *Client {
 	return newClient
 }
 
+// Propagator is a chaining function, which sets the propagator for tracing context for next request.
+func (c *Client) Propagator(propagator propagation.TextMapPropagator) *Client {
+	newClient := c.Clone()
+	newClient.SetPropagator(propagator)
+	return newClient
+}
+
 // Cookie is a chaining function,
 // which sets cookie items with map for next request.
 func (c *Client) Cookie(m map[string]string) *Client {
@@ -0,0 +1,114 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"mime"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ContentCodec marshals the request data and unmarshals the response content of certain content type,
+// like msgpack, protobuf and cbor.
+type ContentCodec interface {
+	// ContentType returns the media type of the codec, like "application/msgpack".
+	ContentType() string
+
+	// Marshal marshals `v` to bytes.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal unmarshals `data` to `pointer`.
+	Unmarshal(data []byte, pointer interface{}) error
+}
+
+const (
+	httpHeaderAccept = "Accept"
+)
+
+var (
+	// contentCodecs is the registered codecs by media type.
+	contentCodecs   = make(map[string]ContentCodec)
+	contentCodecsMu sync.RWMutex
+)
+
+// RegisterContentCodec registers `codec` by its content type, which replaces the registered one.
+// The registered codecs are used by all clients, in which the request data is marshaled by the codec of
+// request Content-Type, and the response content is unmarshaled by the codec of response Content-Type
+// in Response.Scan.
+//
+// The JSON and XML content types are handled by client natively, which need no codec.
+func RegisterContentCodec(codec ContentCodec) {
+	contentCodecsMu.Lock()
+	defer contentCodecsMu.Unlock()
+	contentCodecs[strings.ToLower(codec.ContentType())] = codec
+}
+
+// ContentCodec is a chaining function,
+// which marshals the request data and unmarshals the response content using `codec` for next request.
+func (c *Client) ContentCodec(codec ContentCodec) *Client {
+	newClient := c.Clone()
+	newClient.SetContentCodec(codec)
+	return newClient
+}
+
+// SetContentCodec sets the content codec of client, which sets the content type of `codec` as the header
+// "Content-Type" and "Accept" of requests. The request data which is not string or []byte is marshaled
+// by `codec`, and the response content of the same content type is unmarshaled by `codec` in Response.Scan,
+// even if `codec` is not registered by RegisterContentCodec. It removes the codec if `codec` is nil.
+func (c *Client) SetContentCodec(codec ContentCodec) *Client {
+	if codec == nil {
+		c.contentCodec = nil
+		return c
+	}
+	c.contentCodec = codec
+	c.header[httpHeaderContentType] = codec.ContentType()
+	c.header[httpHeaderAccept] = codec.ContentType()
+	return c
+}
+
+// getContentCodec returns the codec of `contentType`, or nil if no codec found.
+// It searches the codec of client in priority, and then the registered codecs.
+func getContentCodec(clientCodec ContentCodec, contentType string) ContentCodec {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	if clientCodec != nil && strings.EqualFold(clientCodec.ContentType(), mediaType) {
+		return clientCodec
+	}
+	contentCodecsMu.RLock()
+	defer contentCodecsMu.RUnlock()
+	return contentCodecs[mediaType]
+}
+
+// Scan reads the response content and unmarshals it to `pointer`. The content is unmarshaled by the
+// codec of response Content-Type if any, or else it is converted like gvar.Var.Scan,
+// which supports JSON content.
+//
+// Note that the response content is consumed after calling this method.
+func (r *Response) Scan(pointer interface{}) error {
+	if r == nil || r.Response == nil {
+		return nil
+	}
+	content := r.ReadAll()
+	if len(content) == 0 {
+		return nil
+	}
+	if codec := getContentCodec(r.codec, r.Header.Get(httpHeaderContentType)); codec != nil {
+		if err := codec.Unmarshal(content, pointer); err != nil {
+			return gerror.Wrapf(err, `unmarshal response content of type "%s" failed`, codec.ContentType())
+		}
+		return nil
+	}
+	return gvar.New(content).Scan(pointer)
+}
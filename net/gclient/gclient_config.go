@@ -16,6 +16,9 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/net/http2"
 	"golang.org/x/net/proxy"
 
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -107,11 +110,14 @@ func (c *Client) SetBasicAuth(user, pass string) *Client {
 	return c
 }
 
-// SetRetry sets retry count and interval.
+// SetRetry sets retry count and interval, which retries the failed requests with fixed interval.
+// See SetRetryPolicy for more retry features like exponential backoff.
 // TODO removed.
 func (c *Client) SetRetry(retryCount int, retryInterval time.Duration) *Client {
-	c.retryCount = retryCount
-	c.retryInterval = retryInterval
+	c.retryPolicy = RetryPolicy{
+		MaxRetries:      retryCount,
+		InitialInterval: retryInterval,
+	}
 	return c
 }
 
@@ -147,7 +153,7 @@ func (c *Client) SetProxy(proxyURL string) {
 	}
 	if _proxy.Scheme == httpProtocolName {
 		if v, ok := c.Transport.(*http.Transport); ok {
-			v.Proxy = http.ProxyURL(_proxy)
+			v.Proxy = newProxyFunc(http.ProxyURL(_proxy))
 		}
 	} else {
 		auth := &proxy.Auth{}
@@ -190,17 +196,17 @@ func (c *Client) SetTLSKeyCrt(crtFile, keyFile string) error {
 	if err != nil {
 		return gerror.Wrap(err, "LoadKeyCrt failed")
 	}
-	if v, ok := c.Transport.(*http.Transport); ok {
-		tlsConfig.InsecureSkipVerify = true
-		v.TLSClientConfig = tlsConfig
-		return nil
-	}
-	return gerror.New(`cannot set TLSClientConfig for custom Transport of the client`)
+	tlsConfig.InsecureSkipVerify = true
+	return c.SetTLSConfig(tlsConfig)
 }
 
 // SetTLSConfig sets the TLS configuration of client.
 func (c *Client) SetTLSConfig(tlsConfig *tls.Config) error {
-	if v, ok := c.Transport.(*http.Transport); ok {
+	switch v := c.Transport.(type) {
+	case *http.Transport:
+		v.TLSClientConfig = tlsConfig
+		return nil
+	case *http2.Transport:
 		v.TLSClientConfig = tlsConfig
 		return nil
 	}
@@ -216,3 +222,18 @@ func (c *Client) SetBuilder(builder gsel.Builder) {
 func (c *Client) SetDiscovery(discovery gsvc.Discovery) {
 	c.discovery = discovery
 }
+
+// SetPropagator sets the propagator for injecting tracing context into request headers,
+// which is usually created by gtrace.NewTextMapPropagator for peers using different propagation
+// formats like B3. It uses the global propagator if `propagator` is nil.
+func (c *Client) SetPropagator(propagator propagation.TextMapPropagator) {
+	c.propagator = propagator
+}
+
+// getPropagator returns the propagator of the client, or the global propagator if it is not set.
+func (c *Client) getPropagator() propagation.TextMapPropagator {
+	if c.propagator != nil {
+		return c.propagator
+	}
+	return otel.GetTextMapPropagator()
+}
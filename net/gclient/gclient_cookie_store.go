@@ -0,0 +1,236 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// CookieStore is the persistent storage of cookie jar, which stores the serialized cookies.
+type CookieStore interface {
+	// Load loads and returns the stored cookies data, which returns nil if nothing stored.
+	Load(ctx context.Context) ([]byte, error)
+
+	// Save stores the cookies data, which replaces the stored one.
+	Save(ctx context.Context, data []byte) error
+}
+
+// FileCookieStore is the CookieStore storing cookies in local file.
+type FileCookieStore struct {
+	path string
+}
+
+// persistentCookieJar is the cookie jar that stores its cookies in CookieStore.
+type persistentCookieJar struct {
+	*cookiejar.Jar
+	mu      sync.Mutex
+	store   CookieStore
+	key     []byte                   // Encryption key of stored cookies, no encryption if empty.
+	entries map[string]*storedCookie // Stored cookies by storedCookieKey.
+}
+
+// storedCookie is the stored cookie with the url that it is received from.
+type storedCookie struct {
+	Url    string       `json:"url"`
+	Cookie *http.Cookie `json:"cookie"`
+}
+
+const (
+	// cookieStoreFilePerm is the perm of cookie file, which is only accessible by current user.
+	cookieStoreFilePerm = os.FileMode(0600)
+)
+
+// NewFileCookieStore creates and returns a CookieStore storing cookies in file of `path`.
+func NewFileCookieStore(path string) *FileCookieStore {
+	return &FileCookieStore{
+		path: path,
+	}
+}
+
+// Load loads and returns the cookies data from file, which returns nil if the file does not exist.
+func (s *FileCookieStore) Load(ctx context.Context) ([]byte, error) {
+	if !gfile.Exists(s.path) {
+		return nil, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read cookie file "%s" failed`, s.path)
+	}
+	return data, nil
+}
+
+// Save writes the cookies data to file, which writes a temporary file and renames it,
+// so that the file is not corrupted if the process exits while writing.
+func (s *FileCookieStore) Save(ctx context.Context, data []byte) error {
+	if err := gfile.Mkdir(gfile.Dir(s.path)); err != nil {
+		return err
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, data, cookieStoreFilePerm); err != nil {
+		return gerror.Wrapf(err, `write cookie file "%s" failed`, tempPath)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		return gerror.Wrapf(err, `rename cookie file "%s" to "%s" failed`, tempPath, s.path)
+	}
+	return nil
+}
+
+// CookieStore is a chaining function,
+// which enables browser mode with cookies persisted in `store` for next request.
+func (c *Client) CookieStore(store CookieStore, encryptionKey ...[]byte) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetCookieStore(store, encryptionKey...); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetCookieStore enables browser mode of the client with cookie jar persisted in `store`, which loads
+// the stored cookies immediately and saves the cookies when they are changed by server, so that the
+// sessions are kept across restarts of process.
+//
+// The optional parameter `encryptionKey` is the AES key in length of 16/24/32 that encrypts the stored
+// cookies, which is recommended as the cookies commonly contain the credentials of sessions.
+func (c *Client) SetCookieStore(store CookieStore, encryptionKey ...[]byte) error {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return gerror.Wrap(err, `create cookie jar failed`)
+	}
+	persistentJar := &persistentCookieJar{
+		Jar:     jar,
+		store:   store,
+		entries: make(map[string]*storedCookie),
+	}
+	if len(encryptionKey) > 0 {
+		persistentJar.key = encryptionKey[0]
+	}
+	if err = persistentJar.load(context.TODO()); err != nil {
+		return err
+	}
+	c.Jar = persistentJar
+	return nil
+}
+
+// SetCookies handles the receipt of the cookies in a reply for the given URL,
+// which also saves the cookies to store.
+func (j *persistentCookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.Jar.SetCookies(u, cookies)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var (
+		now     = time.Now()
+		rawUrl  = (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+		changed bool
+	)
+	for _, cookie := range cookies {
+		var (
+			stored = *cookie
+			key    = storedCookieKey(u, cookie)
+		)
+		// The relative expiration is converted to absolute one, as the cookie is restored later.
+		if stored.MaxAge > 0 {
+			stored.Expires = now.Add(time.Duration(stored.MaxAge) * time.Second)
+			stored.MaxAge = 0
+		}
+		if stored.MaxAge < 0 || (!stored.Expires.IsZero() && !stored.Expires.After(now)) {
+			if _, ok := j.entries[key]; ok {
+				delete(j.entries, key)
+				changed = true
+			}
+			continue
+		}
+		j.entries[key] = &storedCookie{
+			Url:    rawUrl,
+			Cookie: &stored,
+		}
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if err := j.save(context.TODO(), now); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+}
+
+// load loads the stored cookies into the jar, in which the expired cookies are ignored.
+func (j *persistentCookieJar) load(ctx context.Context) error {
+	data, err := j.store.Load(ctx)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	if len(j.key) > 0 {
+		if data, err = gaes.DecryptGCM(data, j.key); err != nil {
+			return gerror.Wrap(err, `decrypt stored cookies failed`)
+		}
+	}
+	var entries []*storedCookie
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return gerror.Wrap(err, `decode stored cookies failed`)
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.Cookie == nil || (!entry.Cookie.Expires.IsZero() && !entry.Cookie.Expires.After(now)) {
+			continue
+		}
+		u, err := url.Parse(entry.Url)
+		if err != nil {
+			intlog.Errorf(ctx, `%+v`, gerror.Wrapf(err, `invalid url "%s" of stored cookie`, entry.Url))
+			continue
+		}
+		j.Jar.SetCookies(u, []*http.Cookie{entry.Cookie})
+		j.entries[storedCookieKey(u, entry.Cookie)] = entry
+	}
+	return nil
+}
+
+// save saves the cookies to store, in which the expired cookies are removed.
+func (j *persistentCookieJar) save(ctx context.Context, now time.Time) error {
+	var (
+		keys    = make([]string, 0, len(j.entries))
+		entries = make([]*storedCookie, 0, len(j.entries))
+	)
+	for key, entry := range j.entries {
+		if !entry.Cookie.Expires.IsZero() && !entry.Cookie.Expires.After(now) {
+			delete(j.entries, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entries = append(entries, j.entries[key])
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return gerror.Wrap(err, `encode cookies failed`)
+	}
+	if len(j.key) > 0 {
+		if data, err = gaes.EncryptGCM(data, j.key); err != nil {
+			return gerror.Wrap(err, `encrypt cookies failed`)
+		}
+	}
+	return j.store.Save(ctx, data)
+}
+
+// storedCookieKey returns the unique key of `cookie` received from `u`.
+func storedCookieKey(u *url.URL, cookie *http.Cookie) string {
+	return u.Host + ";" + cookie.Domain + ";" + cookie.Path + ";" + cookie.Name
+}
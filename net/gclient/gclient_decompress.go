@@ -0,0 +1,208 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// ContentDecoder creates and returns the reader decoding `reader` of certain content encoding.
+type ContentDecoder func(reader io.Reader) (io.ReadCloser, error)
+
+// decodingBody is the response body decoded lazily in streaming,
+// in which the decoder is created on the first reading.
+type decodingBody struct {
+	body      io.ReadCloser    // Raw response body.
+	encodings []string         // Content encodings in order of applied.
+	decoders  []ContentDecoder // Decoders in order of encodings.
+	once      sync.Once        // Creates the decoders once.
+	reader    io.Reader        // Decoding reader, nil if not created.
+	closers   []io.Closer      // Closers of decoders.
+	err       error            // Error creating decoders.
+}
+
+const (
+	httpHeaderAcceptEncoding  = "Accept-Encoding"
+	httpHeaderContentEncoding = "Content-Encoding"
+	httpHeaderContentLength   = "Content-Length"
+	contentEncodingIdentity   = "identity"
+)
+
+var (
+	// contentDecoders is the registered decoders by content encoding.
+	contentDecoders = map[string]ContentDecoder{
+		"gzip":    decodeGzip,
+		"x-gzip":  decodeGzip,
+		"deflate": decodeDeflate,
+	}
+	contentDecodersMu sync.RWMutex
+)
+
+// RegisterContentDecoder registers the decoder of content `encoding` for response decompression,
+// which replaces the registered one. The decoders of "gzip" and "deflate" are registered in default,
+// and the decoders of "br" and "zstd" can be registered using third-party packages, like:
+//
+//	gclient.RegisterContentDecoder("br", func(reader io.Reader) (io.ReadCloser, error) {
+//		return io.NopCloser(brotli.NewReader(reader)), nil
+//	})
+//	gclient.RegisterContentDecoder("zstd", func(reader io.Reader) (io.ReadCloser, error) {
+//		decoder, err := zstd.NewReader(reader)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return decoder.IOReadCloser(), nil
+//	})
+func RegisterContentDecoder(encoding string, decoder ContentDecoder) {
+	contentDecodersMu.Lock()
+	defer contentDecodersMu.Unlock()
+	contentDecoders[strings.ToLower(encoding)] = decoder
+}
+
+// getContentDecoder returns the registered decoder of content `encoding`, or nil if not registered.
+func getContentDecoder(encoding string) ContentDecoder {
+	contentDecodersMu.RLock()
+	defer contentDecodersMu.RUnlock()
+	return contentDecoders[strings.ToLower(encoding)]
+}
+
+// AcceptEncoding is a chaining function,
+// which negotiates the content encodings for next request.
+func (c *Client) AcceptEncoding(encodings ...string) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetAcceptEncoding(encodings...); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetAcceptEncoding sets the content encodings like "br", "zstd" and "gzip" accepted by the client, which are
+// sent in header "Accept-Encoding" in order of preference, and the response bodies of these encodings are
+// decoded transparently in streaming. All the encodings should be registered by RegisterContentDecoder.
+//
+// The underlying Transport decompresses only gzip in default, which is replaced by this feature.
+// It restores the default behavior if no encoding given.
+func (c *Client) SetAcceptEncoding(encodings ...string) error {
+	for _, encoding := range encodings {
+		if getContentDecoder(encoding) == nil {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `no decoder registered for content encoding "%s"`, encoding)
+		}
+	}
+	if len(encodings) == 0 {
+		c.acceptEncodings = nil
+		return nil
+	}
+	c.acceptEncodings = append([]string(nil), encodings...)
+	return nil
+}
+
+// setAcceptEncoding sets the header "Accept-Encoding" of request if the content encodings are negotiated,
+// unless it is set by caller.
+func (c *Client) setAcceptEncoding(req *http.Request) {
+	if len(c.acceptEncodings) > 0 && req.Header.Get(httpHeaderAcceptEncoding) == "" {
+		req.Header.Set(httpHeaderAcceptEncoding, strings.Join(c.acceptEncodings, ", "))
+	}
+}
+
+// decodeResponse replaces the body of `resp` with the decoding body if the content encodings are negotiated,
+// in which the body of unknown encoding is kept as it is.
+func (c *Client) decodeResponse(resp *http.Response) {
+	if len(c.acceptEncodings) == 0 || resp == nil || resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+	var (
+		encodings []string
+		decoders  []ContentDecoder
+	)
+	for _, value := range resp.Header.Values(httpHeaderContentEncoding) {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding == "" || encoding == contentEncodingIdentity {
+				continue
+			}
+			decoder := getContentDecoder(encoding)
+			if decoder == nil {
+				return
+			}
+			encodings = append(encodings, encoding)
+			decoders = append(decoders, decoder)
+		}
+	}
+	if len(decoders) == 0 {
+		return
+	}
+	resp.Body = &decodingBody{
+		body:      resp.Body,
+		encodings: encodings,
+		decoders:  decoders,
+	}
+	resp.Header.Del(httpHeaderContentEncoding)
+	resp.Header.Del(httpHeaderContentLength)
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// Read reads the decoded content, which creates the decoders on the first reading.
+func (b *decodingBody) Read(p []byte) (n int, err error) {
+	b.once.Do(func() {
+		var reader io.Reader = b.body
+		// The encodings are decoded in reverse order of applied.
+		for i := len(b.decoders) - 1; i >= 0; i-- {
+			decoder, err := b.decoders[i](reader)
+			if err != nil {
+				b.err = gerror.Wrapf(err, `create decoder of content encoding "%s" failed`, b.encodings[i])
+				return
+			}
+			b.closers = append(b.closers, decoder)
+			reader = decoder
+		}
+		b.reader = reader
+	})
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// Close closes the decoders and the raw body.
+func (b *decodingBody) Close() error {
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		_ = b.closers[i].Close()
+	}
+	return b.body.Close()
+}
+
+// decodeGzip creates and returns the gzip decoder.
+func decodeGzip(reader io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(reader)
+}
+
+// decodeDeflate creates and returns the deflate decoder, which decodes the zlib format of RFC 1950,
+// or the raw deflate format of RFC 1951 sent by some servers.
+func decodeDeflate(reader io.Reader) (io.ReadCloser, error) {
+	bufReader := bufio.NewReader(reader)
+	header, err := bufReader.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	// The zlib header has the compression method 8 and its checksum is a multiple of 31.
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(bufReader)
+	}
+	return flate.NewReader(bufReader), nil
+}
@@ -90,7 +90,11 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 		return nil, err
 	}
 	if done != nil {
-		defer done(ctx, gsel.DoneInfo{})
+		defer func() {
+			done(ctx, gsel.DoneInfo{
+				Err: err,
+			})
+		}()
 	}
 	r.Host = node.Address()
 	r.URL.Host = node.Address()
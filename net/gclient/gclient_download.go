@@ -0,0 +1,495 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// DownloadOption is the option for Download.
+type DownloadOption struct {
+	// Concurrency is the max concurrent range requests, which is 4 in default.
+	Concurrency int
+
+	// PartSize is the size of each range request, which is 4MB in default.
+	PartSize int64
+
+	// Checksum is the expected checksum of file like "sha256:<hex>" or "md5:<hex>". The checksum is also
+	// verified if the server responds header "Repr-Digest", "Digest" or "Content-MD5".
+	Checksum string
+
+	// DisableResume disables resuming the partial downloading of previous failure.
+	DisableResume bool
+
+	// OnProgress is called when the file content is received, optional.
+	OnProgress DownloadProgressHandler
+}
+
+// DownloadProgress is the downloading progress of a file.
+type DownloadProgress struct {
+	Url        string // URL of the file.
+	Path       string // Local path of the file.
+	Downloaded int64  // Downloaded bytes, including the resumed ones.
+	Total      int64  // Total bytes of the file, -1 if unknown.
+}
+
+// DownloadProgressHandler is called when the file content is received.
+type DownloadProgressHandler func(ctx context.Context, progress DownloadProgress)
+
+// downloadState is the state of partial downloading, which is saved for resuming.
+type downloadState struct {
+	Url          string          `json:"url"`
+	Total        int64           `json:"total"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"lastModified,omitempty"`
+	Parts        []*downloadPart `json:"parts"`
+}
+
+// downloadPart is the part of file downloaded in a range request.
+type downloadPart struct {
+	Start      int64 `json:"start"`      // Start offset of the part.
+	End        int64 `json:"end"`        // End offset of the part, exclusive.
+	Downloaded int64 `json:"downloaded"` // Downloaded bytes of the part.
+}
+
+// downloader downloads a file.
+type downloader struct {
+	client     *Client
+	url        string
+	path       string
+	option     DownloadOption
+	downloaded int64      // Downloaded bytes, which is updated atomically.
+	total      int64      // Total bytes, -1 if unknown.
+	mu         sync.Mutex // Serializes the progress callbacks and state saving.
+}
+
+// downloadPartWriter writes the content of part to file at its offset and reports the progress.
+type downloadPartWriter struct {
+	ctx        context.Context
+	downloader *downloader
+	file       *os.File
+	part       *downloadPart
+}
+
+const (
+	defaultDownloadConcurrency = 4
+	defaultDownloadPartSize    = 4 * 1024 * 1024
+	downloadTempFileSuffix     = ".download"
+	downloadStateFileSuffix    = ".download.json"
+	httpHeaderIfRange          = "If-Range"
+	httpHeaderDigest           = "Digest"
+	httpHeaderReprDigest       = "Repr-Digest"
+	httpHeaderContentMD5       = "Content-MD5"
+	checksumAlgorithmMD5       = "md5"
+	checksumAlgorithmSHA256    = "sha256"
+)
+
+// Download downloads the file of `url` to local path `dst`. The file is downloaded in parallel range requests
+// if the server supports, or else in a single request. The content is written to temporary file "dst.download"
+// first, which is renamed to `dst` after the checksum is verified.
+//
+// The partial downloading is resumed from "dst.download" and its state file "dst.download.json" if the
+// previous downloading fails, unless the file is changed in server, which is detected by its size, "ETag"
+// and "Last-Modified".
+func (c *Client) Download(ctx context.Context, url, dst string, option ...DownloadOption) error {
+	d := &downloader{
+		client: c,
+		url:    url,
+		path:   dst,
+		total:  -1,
+	}
+	if len(option) > 0 {
+		d.option = option[0]
+	}
+	if d.option.Concurrency <= 0 {
+		d.option.Concurrency = defaultDownloadConcurrency
+	}
+	if d.option.PartSize <= 0 {
+		d.option.PartSize = defaultDownloadPartSize
+	}
+	if err := gfile.Mkdir(gfile.Dir(dst)); err != nil {
+		return err
+	}
+	return d.download(ctx)
+}
+
+// download probes the file with a range request, and downloads it in parts or in a single request.
+func (d *downloader) download(ctx context.Context) error {
+	resp, err := d.request(ctx, "bytes=0-0", "")
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	total := parseContentRangeTotal(resp.Header.Get(httpHeaderContentRange))
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		// The server does not support range requests, which responds the whole file.
+		return d.downloadSingle(ctx, resp)
+
+	case resp.StatusCode != http.StatusPartialContent || total < 0:
+		// The range is not satisfiable like the empty file.
+		_ = resp.Close()
+		if resp, err = d.request(ctx, "", ""); err != nil {
+			return err
+		}
+		defer resp.Close()
+		return d.downloadSingle(ctx, resp)
+	}
+	checksum := d.option.Checksum
+	if checksum == "" {
+		// The header "Content-MD5" of partial response is the checksum of part, which is ignored.
+		checksum = parseDigestChecksum(resp.Header, false)
+	}
+	state := &downloadState{
+		Url:          d.url,
+		Total:        total,
+		ETag:         resp.Header.Get(httpHeaderETag),
+		LastModified: resp.Header.Get(httpHeaderLastModified),
+	}
+	_ = resp.Close()
+	return d.downloadParts(ctx, state, checksum)
+}
+
+// downloadParts downloads the file in parallel range requests.
+func (d *downloader) downloadParts(ctx context.Context, state *downloadState, checksum string) error {
+	var (
+		tempPath  = d.path + downloadTempFileSuffix
+		statePath = d.path + downloadStateFileSuffix
+	)
+	if saved := d.loadState(statePath); saved != nil && gfile.Exists(tempPath) &&
+		saved.Url == state.Url && saved.Total == state.Total &&
+		saved.ETag == state.ETag && saved.LastModified == state.LastModified {
+		state.Parts = saved.Parts
+	} else {
+		for start := int64(0); start < state.Total; start += d.option.PartSize {
+			end := start + d.option.PartSize
+			if end > state.Total {
+				end = state.Total
+			}
+			state.Parts = append(state.Parts, &downloadPart{Start: start, End: end})
+		}
+	}
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return gerror.Wrapf(err, `open file "%s" failed`, tempPath)
+	}
+	d.total = state.Total
+	for _, part := range state.Parts {
+		d.downloaded += part.Downloaded
+	}
+	d.reportProgress(ctx)
+
+	var (
+		wg        sync.WaitGroup
+		errOnce   sync.Once
+		partErr   error
+		partCtx   context.Context
+		cancel    context.CancelFunc
+		semaphore = make(chan struct{}, d.option.Concurrency)
+		validator = state.ETag
+	)
+	if validator == "" {
+		validator = state.LastModified
+	}
+	partCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	for _, part := range state.Parts {
+		if part.Start+part.Downloaded >= part.End {
+			continue
+		}
+		select {
+		case semaphore <- struct{}{}:
+		case <-partCtx.Done():
+		}
+		if partCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(part *downloadPart) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := d.downloadPart(partCtx, file, part, validator); err != nil {
+				errOnce.Do(func() {
+					partErr = err
+					cancel()
+				})
+			}
+		}(part)
+	}
+	wg.Wait()
+	if err = file.Close(); err != nil && partErr == nil {
+		partErr = gerror.Wrapf(err, `close file "%s" failed`, tempPath)
+	}
+	if partErr == nil && ctx.Err() != nil {
+		partErr = ctx.Err()
+	}
+	if partErr != nil {
+		// The state is saved for resuming.
+		if !d.option.DisableResume {
+			d.saveState(statePath, state)
+		}
+		return partErr
+	}
+	_ = os.Remove(statePath)
+	return d.complete(tempPath, checksum)
+}
+
+// downloadPart downloads the remaining content of `part` in a range request.
+func (d *downloader) downloadPart(ctx context.Context, file *os.File, part *downloadPart, validator string) error {
+	start := part.Start + part.Downloaded
+	resp, err := d.request(ctx, fmt.Sprintf("bytes=%d-%d", start, part.End-1), validator)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed,
+			`download range %d-%d of "%s" failed with status code %d, the file might be changed`,
+			start, part.End-1, d.url, resp.StatusCode,
+		)
+	}
+	writer := &downloadPartWriter{
+		ctx:        ctx,
+		downloader: d,
+		file:       file,
+		part:       part,
+	}
+	if _, err = io.Copy(writer, io.LimitReader(resp.Body, part.End-start)); err != nil {
+		return gerror.Wrapf(err, `download range %d-%d of "%s" failed`, start, part.End-1, d.url)
+	}
+	if part.Start+part.Downloaded < part.End {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `download range %d-%d of "%s" failed: unexpected EOF`,
+			start, part.End-1, d.url,
+		)
+	}
+	return nil
+}
+
+// downloadSingle downloads the file from the whole content of `resp`, which cannot be resumed.
+func (d *downloader) downloadSingle(ctx context.Context, resp *Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `download "%s" failed with status code %d`, d.url, resp.StatusCode,
+		)
+	}
+	checksum := d.option.Checksum
+	if checksum == "" {
+		checksum = parseDigestChecksum(resp.Header, true)
+	}
+	tempPath := d.path + downloadTempFileSuffix
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return gerror.Wrapf(err, `open file "%s" failed`, tempPath)
+	}
+	d.total = resp.ContentLength
+	writer := &downloadPartWriter{
+		ctx:        ctx,
+		downloader: d,
+		file:       file,
+		part:       &downloadPart{End: resp.ContentLength},
+	}
+	_, err = io.Copy(writer, resp.Body)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return gerror.Wrapf(err, `download "%s" failed`, d.url)
+	}
+	_ = os.Remove(d.path + downloadStateFileSuffix)
+	return d.complete(tempPath, checksum)
+}
+
+// request sends GET request of `rangeValue` for downloading.
+func (d *downloader) request(ctx context.Context, rangeValue, validator string) (*Response, error) {
+	client := d.client.Clone()
+	// The content is downloaded as it is, as the ranges of encoded content cannot be decoded separately.
+	client.SetHeader(httpHeaderAcceptEncoding, contentEncodingIdentity)
+	if rangeValue != "" {
+		client.SetHeader(httpHeaderRange, rangeValue)
+	}
+	if validator != "" {
+		client.SetHeader(httpHeaderIfRange, validator)
+	}
+	resp, err := client.Get(ctx, d.url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		_ = resp.Close()
+		return nil, gerror.NewCodef(
+			gcode.CodeOperationFailed, `download "%s" failed with status code %d`, d.url, resp.StatusCode,
+		)
+	}
+	return resp, nil
+}
+
+// complete verifies the checksum of downloaded temporary file, and renames it to the destination path.
+func (d *downloader) complete(tempPath, checksum string) error {
+	if checksum != "" {
+		if err := verifyFileChecksum(tempPath, checksum); err != nil {
+			_ = os.Remove(tempPath)
+			return err
+		}
+	}
+	if err := os.Rename(tempPath, d.path); err != nil {
+		return gerror.Wrapf(err, `rename file "%s" to "%s" failed`, tempPath, d.path)
+	}
+	return nil
+}
+
+// reportProgress calls the progress callback with current progress.
+func (d *downloader) reportProgress(ctx context.Context) {
+	if d.option.OnProgress == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.option.OnProgress(ctx, DownloadProgress{
+		Url:        d.url,
+		Path:       d.path,
+		Downloaded: atomic.LoadInt64(&d.downloaded),
+		Total:      d.total,
+	})
+}
+
+// loadState loads the state of partial downloading, which returns nil if not found or invalid.
+func (d *downloader) loadState(statePath string) *downloadState {
+	if d.option.DisableResume || !gfile.Exists(statePath) {
+		return nil
+	}
+	var state *downloadState
+	if err := json.Unmarshal(gfile.GetBytes(statePath), &state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// saveState saves the state of partial downloading.
+func (d *downloader) saveState(statePath string, state *downloadState) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	content, err := json.Marshal(state)
+	if err == nil {
+		err = gfile.PutBytes(statePath, content)
+	}
+	if err != nil {
+		_ = os.Remove(statePath)
+	}
+}
+
+// Write writes `p` to the file at the offset of part and reports the progress.
+func (w *downloadPartWriter) Write(p []byte) (n int, err error) {
+	n, err = w.file.WriteAt(p, w.part.Start+w.part.Downloaded)
+	if n <= 0 {
+		return
+	}
+	// The downloaded bytes of part are updated after written, so that the saved state is always valid.
+	atomic.AddInt64(&w.part.Downloaded, int64(n))
+	atomic.AddInt64(&w.downloader.downloaded, int64(n))
+	w.downloader.reportProgress(w.ctx)
+	return
+}
+
+// parseContentRangeTotal parses the total size from header "Content-Range" like "bytes 0-0/1024",
+// which returns -1 if it is unknown.
+func parseContentRangeTotal(value string) int64 {
+	_, totalValue, found := strings.Cut(value, "/")
+	if !found {
+		return -1
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(totalValue), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// parseDigestChecksum parses the checksum like "sha256:<hex>" from headers "Repr-Digest" like
+// "sha-256=:<base64>:", "Digest" like "SHA-256=<base64>", and "Content-MD5" like "<base64>" if `withContentMD5`.
+func parseDigestChecksum(header http.Header, withContentMD5 bool) string {
+	var digests = make(map[string]string)
+	for _, name := range []string{httpHeaderReprDigest, httpHeaderDigest} {
+		for _, item := range strings.Split(header.Get(name), ",") {
+			algorithm, value, found := strings.Cut(strings.TrimSpace(item), "=")
+			if !found {
+				continue
+			}
+			algorithm = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(algorithm)), "-", "")
+			if _, ok := digests[algorithm]; !ok {
+				digests[algorithm] = strings.Trim(strings.TrimSpace(value), ":")
+			}
+		}
+	}
+	if value := header.Get(httpHeaderContentMD5); withContentMD5 && value != "" {
+		if _, ok := digests[checksumAlgorithmMD5]; !ok {
+			digests[checksumAlgorithmMD5] = value
+		}
+	}
+	for _, algorithm := range []string{checksumAlgorithmSHA256, checksumAlgorithmMD5} {
+		if value, ok := digests[algorithm]; ok {
+			if b, err := base64.StdEncoding.DecodeString(value); err == nil {
+				return algorithm + ":" + hex.EncodeToString(b)
+			}
+		}
+	}
+	return ""
+}
+
+// verifyFileChecksum verifies the checksum of file `path`, which is like "sha256:<hex>" or "md5:<hex>".
+func verifyFileChecksum(path, checksum string) error {
+	algorithm, expected, found := strings.Cut(checksum, ":")
+	if !found {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid checksum "%s"`, checksum)
+	}
+	var h hash.Hash
+	switch strings.ToLower(algorithm) {
+	case checksumAlgorithmMD5:
+		h = md5.New()
+	case checksumAlgorithmSHA256:
+		h = sha256.New()
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported checksum algorithm "%s"`, algorithm)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return gerror.Wrapf(err, `open file "%s" failed`, path)
+	}
+	defer file.Close()
+	if _, err = io.Copy(h, file); err != nil {
+		return gerror.Wrapf(err, `read file "%s" failed`, path)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return gerror.NewCodef(
+			gcode.CodeValidationFailed, `checksum mismatch of "%s", expected "%s" but got "%s:%s"`,
+			path, checksum, algorithm, actual,
+		)
+	}
+	return nil
+}
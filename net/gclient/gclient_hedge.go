@@ -0,0 +1,218 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/utils"
+)
+
+// HedgePolicy is the policy for hedging requests, which sends duplicated requests if the original request
+// does not complete in time, and takes the first successful response to reduce the tail latency.
+type HedgePolicy struct {
+	// Delay is the delay before sending each hedged request, hedging is disabled if <= 0.
+	Delay time.Duration
+
+	// MaxHedges is the max count of hedged requests besides the original one, which is 1 if <= 0.
+	MaxHedges int
+
+	// Hosts are the alternate hosts like "127.0.0.1:8000" that the hedged requests are sent to in turn,
+	// or else the hedged requests are sent to the same host as the original request. The hedged requests
+	// sent to the alternate hosts are signed again if the request is signed by SignerMiddleware.
+	Hosts []string
+
+	// NonIdempotent enables hedging for the non-idempotent methods like POST and PATCH, which are not hedged
+	// in default as the duplicated requests might cause side effects on server.
+	NonIdempotent bool
+}
+
+// hedgeResult is the result of a hedged attempt.
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+	cancel  context.CancelFunc
+}
+
+// cancelOnCloseBody is the response body which cancels the context of its request when closed,
+// like the response body of the winning attempt of hedged requests.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// HedgePolicy is a chaining function,
+// which sets the hedge policy for next request.
+func (c *Client) HedgePolicy(policy HedgePolicy) *Client {
+	newClient := c.Clone()
+	newClient.SetHedgePolicy(policy)
+	return newClient
+}
+
+// SetHedgePolicy sets the hedge policy of the client. The hedged requests are sent with the same body,
+// so the requests of streaming body, like the multipart uploading by PostMultipart, are not hedged.
+// The losing attempts are canceled once a successful response is received, in which the successful response
+// is the one without error and status code < 500.
+func (c *Client) SetHedgePolicy(policy HedgePolicy) *Client {
+	c.hedgePolicy = policy
+	return c
+}
+
+// isEnabled checks whether the request `req` should be hedged.
+func (p HedgePolicy) isEnabled(req *http.Request) bool {
+	if p.Delay <= 0 || isStreamingBody(req.Body) {
+		return false
+	}
+	if p.NonIdempotent {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// doHedged sends the request `req` with body `body`, and sends hedged requests after delay until a successful
+// response is received or the hedged requests are used up. It returns the last failed result if all fail.
+func (c *Client) doHedged(req *http.Request, body []byte) (*http.Response, error) {
+	var (
+		policy    = c.hedgePolicy
+		maxHedges = policy.MaxHedges
+		attempts  int
+		pending   int
+		last      hedgeResult
+		cancels   []context.CancelFunc
+	)
+	if maxHedges <= 0 {
+		maxHedges = 1
+	}
+	// It is buffered so that the losing attempts never block.
+	results := make(chan hedgeResult, maxHedges+1)
+	send := func() {
+		attempt := attempts
+		attemptReq, cancel, err := c.newHedgeRequest(req, body, attempt)
+		cancels = append(cancels, cancel)
+		attempts++
+		pending++
+		go func() {
+			var resp *http.Response
+			if err == nil {
+				resp, err = c.do(attemptReq)
+			}
+			results <- hedgeResult{attempt: attempt, resp: resp, err: err, cancel: cancel}
+		}()
+	}
+	send()
+	timer := time.NewTimer(policy.Delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			if attempts <= maxHedges {
+				send()
+				timer.Reset(policy.Delay)
+			}
+
+		case result := <-results:
+			pending--
+			if result.err == nil && result.resp.StatusCode < http.StatusInternalServerError {
+				last.close()
+				// The losing attempts are canceled immediately and their responses are discarded.
+				for i, cancel := range cancels {
+					if i != result.attempt {
+						cancel()
+					}
+				}
+				go func(pending int) {
+					for i := 0; i < pending; i++ {
+						(<-results).close()
+					}
+				}(pending)
+				result.resp.Body = &cancelOnCloseBody{
+					ReadCloser: result.resp.Body,
+					cancel:     result.cancel,
+				}
+				return result.resp, nil
+			}
+			last.close()
+			last = result
+			if pending > 0 {
+				continue
+			}
+			// All in-flight attempts failed, it sends the next hedged request immediately.
+			if attempts <= maxHedges && req.Context().Err() == nil {
+				send()
+				timer.Reset(policy.Delay)
+				continue
+			}
+			// The context of the last failed attempt is kept for reading its response.
+			if last.resp != nil {
+				last.resp.Body = &cancelOnCloseBody{
+					ReadCloser: last.resp.Body,
+					cancel:     last.cancel,
+				}
+			} else {
+				last.cancel()
+			}
+			return last.resp, last.err
+		}
+	}
+}
+
+// newHedgeRequest creates and returns the request of the `attempt`th attempt, which starts from 0 for the
+// original request, in which the hedged requests are sent to the alternate hosts if configured.
+// The hedged requests sent to the alternate hosts are signed again by the signers of SignerMiddleware.
+func (c *Client) newHedgeRequest(
+	req *http.Request, body []byte, attempt int,
+) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	attemptReq := req.Clone(ctx)
+	if req.Body != nil && req.Body != http.NoBody {
+		attemptReq.Body = utils.NewReadCloser(body, false)
+	}
+	if hosts := c.hedgePolicy.Hosts; attempt > 0 && len(hosts) > 0 {
+		host := hosts[(attempt-1)%len(hosts)]
+		attemptReq.URL.Host = host
+		attemptReq.Host = host
+		var (
+			signers, _ = req.Context().Value(signerCtxKey).([]RequestSigner)
+			signBody   = body
+		)
+		if signBody == nil {
+			// It is the same as SignerMiddleware, which signs the empty body for non-streaming request.
+			signBody = []byte{}
+		}
+		for _, signer := range signers {
+			if err := signer.Sign(attemptReq, signBody, time.Now()); err != nil {
+				return attemptReq, cancel, err
+			}
+		}
+	}
+	return attemptReq, cancel, nil
+}
+
+// close closes the response and cancels the context of the attempt.
+func (r hedgeResult) close() {
+	if r.resp != nil {
+		_ = r.resp.Body.Close()
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// Close closes the response body and cancels the context of its request.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
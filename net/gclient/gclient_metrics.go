@@ -16,22 +16,33 @@ import (
 )
 
 type localMetricManager struct {
-	HttpClientRequestActive        gmetric.UpDownCounter
-	HttpClientRequestTotal         gmetric.Counter
-	HttpClientRequestDuration      gmetric.Histogram
-	HttpClientRequestDurationTotal gmetric.Counter
-	HttpClientConnectionDuration   gmetric.Histogram
-	HttpClientRequestBodySize      gmetric.Counter
-	HttpClientResponseBodySize     gmetric.Counter
+	HttpClientRequestActive             gmetric.UpDownCounter
+	HttpClientRequestTotal              gmetric.Counter
+	HttpClientRequestDuration           gmetric.Histogram
+	HttpClientRequestDurationTotal      gmetric.Counter
+	HttpClientConnectionDuration        gmetric.Histogram
+	HttpClientDnsDuration               gmetric.Histogram
+	HttpClientRequestBodySize           gmetric.Counter
+	HttpClientResponseBodySize          gmetric.Counter
+	HttpClientCircuitBreakerStateChange gmetric.Counter
+	HttpClientUploadSize                gmetric.Counter
+	HttpClientConnectionWaitDuration    gmetric.Histogram
+	HttpClientConnectionPending         gmetric.UpDownCounter
+	HttpClientConnectionAcquired        gmetric.Counter
+	HttpClientRetryWaitDuration         gmetric.Histogram
 }
 
 const (
-	metricAttrKeyServerAddress          = "server.address"
-	metricAttrKeyServerPort             = "server.port"
-	metricAttrKeyUrlSchema              = "url.schema"
-	metricAttrKeyHttpRequestMethod      = "http.request.method"
-	metricAttrKeyHttpResponseStatusCode = "http.response.status_code"
-	metricAttrKeyNetworkProtocolVersion = "network.protocol.version"
+	metricAttrKeyServerAddress           = "server.address"
+	metricAttrKeyServerPort              = "server.port"
+	metricAttrKeyUrlSchema               = "url.schema"
+	metricAttrKeyHttpRequestMethod       = "http.request.method"
+	metricAttrKeyHttpResponseStatusCode  = "http.response.status_code"
+	metricAttrKeyNetworkProtocolVersion  = "network.protocol.version"
+	metricAttrKeyCircuitBreakerKey       = "circuit_breaker.key"
+	metricAttrKeyCircuitBreakerFromState = "circuit_breaker.state.from"
+	metricAttrKeyCircuitBreakerToState   = "circuit_breaker.state.to"
+	metricAttrKeyConnectionReused        = "connection.reused"
 )
 
 var (
@@ -122,6 +133,65 @@ func newMetricManager() *localMetricManager {
 				Buckets:    durationBuckets,
 			},
 		),
+		HttpClientDnsDuration: meter.MustHistogram(
+			"http.client.dns.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the DNS lookup duration of client requests.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets:    durationBuckets,
+			},
+		),
+		HttpClientCircuitBreakerStateChange: meter.MustCounter(
+			"http.client.circuit_breaker.state_change",
+			gmetric.MetricOption{
+				Help:       "Total state transitions of client circuit breakers.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpClientUploadSize: meter.MustCounter(
+			"http.client.upload.size",
+			gmetric.MetricOption{
+				Help:       "Uploaded file bytes total of multipart requests.",
+				Unit:       "bytes",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpClientConnectionWaitDuration: meter.MustHistogram(
+			"http.client.connection.wait_duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration waiting for a connection from the connection pool.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets:    durationBuckets,
+			},
+		),
+		HttpClientConnectionPending: meter.MustUpDownCounter(
+			"http.client.connection.pending",
+			gmetric.MetricOption{
+				Help:       "Number of requests waiting for a connection, which indicates the saturation of connection pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpClientConnectionAcquired: meter.MustCounter(
+			"http.client.connection.acquired",
+			gmetric.MetricOption{
+				Help:       "Total connections acquired from the connection pool, either reused or newly created.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpClientRetryWaitDuration: meter.MustHistogram(
+			"http.client.retry.wait_duration",
+			gmetric.MetricOption{
+				Help:       "Measures the waiting duration before retrying client requests.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets:    durationBuckets,
+			},
+		),
 	}
 	return mm
 }
@@ -192,9 +262,7 @@ func (m *localMetricManager) GetMetricAttributeMap(r *http.Request) gmetric.Attr
 			serverPort = "443"
 		}
 	}
-	if array := gstr.Split(r.Proto, "/"); len(array) > 1 {
-		protocolVersion = array[1]
-	}
+	protocolVersion = getProtocolVersion(r.Proto)
 	attrMap.Sets(gmetric.AttributeMap{
 		metricAttrKeyServerAddress:          serverAddress,
 		metricAttrKeyServerPort:             serverPort,
@@ -210,6 +278,14 @@ func (m *localMetricManager) GetMetricAttributeMap(r *http.Request) gmetric.Attr
 	return attrMap
 }
 
+// getProtocolVersion returns the version of protocol like "HTTP/1.1".
+func getProtocolVersion(proto string) string {
+	if array := gstr.Split(proto, "/"); len(array) > 1 {
+		return array[1]
+	}
+	return ""
+}
+
 func (c *Client) handleMetricsBeforeRequest(r *http.Request) {
 	if !gmetric.IsEnabled() {
 		return
@@ -240,13 +316,24 @@ func (c *Client) handleMetricsAfterRequestDone(r *http.Request, requestStartTime
 	}
 
 	var (
-		ctx             = r.Context()
-		attrMap         = metricManager.GetMetricAttributeMap(r)
-		duration        = float64(gtime.Now().Sub(requestStartTime).Milliseconds())
-		requestOption   = metricManager.GetMetricOptionForRequestByMap(attrMap)
+		ctx           = r.Context()
+		attrMap       = metricManager.GetMetricAttributeMap(r)
+		duration      = float64(gtime.Now().Sub(requestStartTime).Milliseconds())
+		requestOption = metricManager.GetMetricOptionForRequestByMap(attrMap)
+	)
+	// The active requests use the requested protocol version as they are increased with,
+	// and the others use the protocol version negotiated with the server.
+	if r.Response != nil {
+		attrMap.Sets(gmetric.AttributeMap{
+			metricAttrKeyNetworkProtocolVersion: getProtocolVersion(r.Response.Proto),
+		})
+	}
+	var (
 		responseOption  = metricManager.GetMetricOptionForResponseByMap(attrMap)
 		histogramOption = metricManager.GetMetricOptionForHistogramByMap(attrMap)
 	)
+	// The exemplar links the duration bucket to the sampled trace of the request.
+	histogramOption.Exemplar = gmetric.ExemplarFromContext(ctx)
 	metricManager.HttpClientRequestActive.Dec(
 		ctx,
 		requestOption,
@@ -0,0 +1,305 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// MockTransport is the http.RoundTripper that responds the requests using the expectations without network
+// access, which makes the tests of client hermetic. It is installed on any Client by setting as its Transport:
+//
+//	mock := gclient.NewMockTransport()
+//	mock.On("GET", "/user").WithQuery("id", "1").Reply(200, g.Map{"name": "john"}).Times(1)
+//	client := g.Client()
+//	client.Transport = mock
+//	// Requests of client...
+//	err := mock.AssertExpectations()
+type MockTransport struct {
+	mu           sync.Mutex
+	expectations []*MockExpectation
+	unmatched    []string // Requests that match no expectation, like "GET /path".
+}
+
+// MockExpectation is an expectation of MockTransport,
+// which matches the requests and responds with configured response.
+type MockExpectation struct {
+	transport *MockTransport             // Transport that the expectation belongs to.
+	method    string                     // Request method, which matches any method if empty.
+	path      string                     // Request path, which matches any path if empty.
+	query     map[string]string          // Request query parameters.
+	header    map[string]string          // Request headers.
+	body      *string                    // Request body, nil if not matched.
+	matchers  []func(*http.Request) bool // Custom matchers.
+
+	status        int           // Response status code.
+	replyHeader   http.Header   // Response header.
+	replyBody     []byte        // Response body.
+	replyErr      error         // Error returned instead of response.
+	delay         time.Duration // Delay before response.
+	expectedCalls int           // Expected call count, 0 means at least once.
+	calls         int           // Actual call count.
+}
+
+// ErrMockNotMatched is returned by MockTransport if no expectation matches the request.
+var ErrMockNotMatched = gerror.NewWithOption(gerror.Option{
+	Text: "no mock expectation matches the request",
+	Code: gcode.CodeNotFound,
+})
+
+// NewMockTransport creates and returns a MockTransport.
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// On adds and returns an expectation matching requests of `method` and `path`, in which the empty value
+// matches any method or path. The expectation responds 200 with empty body in default.
+// The expectations are matched in order of adding.
+func (m *MockTransport) On(method, path string) *MockExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expectation := &MockExpectation{
+		transport:   m,
+		method:      strings.ToUpper(method),
+		path:        path,
+		query:       make(map[string]string),
+		header:      make(map[string]string),
+		status:      http.StatusOK,
+		replyHeader: make(http.Header),
+	}
+	m.expectations = append(m.expectations, expectation)
+	return expectation
+}
+
+// RoundTrip implements the http.RoundTripper interface,
+// which responds the request using the first matched expectation.
+func (m *MockTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return nil, gerror.Wrap(err, `read request body failed`)
+		}
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	m.mu.Lock()
+	var matched *MockExpectation
+	for _, expectation := range m.expectations {
+		if expectation.match(r, body) {
+			matched = expectation
+			matched.calls++
+			break
+		}
+	}
+	if matched == nil {
+		m.unmatched = append(m.unmatched, r.Method+" "+r.URL.Path)
+		m.mu.Unlock()
+		return nil, gerror.Wrapf(ErrMockNotMatched, `%s %s`, r.Method, r.URL.String())
+	}
+	m.mu.Unlock()
+
+	if matched.delay > 0 {
+		timer := time.NewTimer(matched.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+	}
+	if matched.replyErr != nil {
+		return nil, matched.replyErr
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", matched.status, http.StatusText(matched.status)),
+		StatusCode:    matched.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        matched.replyHeader.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(matched.replyBody)),
+		ContentLength: int64(len(matched.replyBody)),
+		Request:       r,
+	}, nil
+}
+
+// AssertExpectations checks the call counts of all expectations, which returns error if any expectation is
+// not called as expected, or any request matches no expectation.
+func (m *MockTransport) AssertExpectations() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var errs []error
+	for _, expectation := range m.expectations {
+		switch {
+		case expectation.expectedCalls == 0 && expectation.calls == 0:
+			errs = append(errs, gerror.NewCodef(
+				gcode.CodeValidationFailed, `expectation "%s" is never called`, expectation,
+			))
+		case expectation.expectedCalls > 0 && expectation.calls != expectation.expectedCalls:
+			errs = append(errs, gerror.NewCodef(
+				gcode.CodeValidationFailed, `expectation "%s" is called %d times, but expected %d times`,
+				expectation, expectation.calls, expectation.expectedCalls,
+			))
+		}
+	}
+	for _, request := range m.unmatched {
+		errs = append(errs, gerror.NewCodef(
+			gcode.CodeValidationFailed, `request "%s" matches no expectation`, request,
+		))
+	}
+	return gerror.Join(errs...)
+}
+
+// Reset removes all expectations and records of the transport.
+func (m *MockTransport) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expectations = nil
+	m.unmatched = nil
+}
+
+// WithQuery matches the requests having query parameter `key` of `value`.
+func (e *MockExpectation) WithQuery(key, value string) *MockExpectation {
+	e.query[key] = value
+	return e
+}
+
+// WithHeader matches the requests having header `key` of `value`.
+func (e *MockExpectation) WithHeader(key, value string) *MockExpectation {
+	e.header[key] = value
+	return e
+}
+
+// WithBody matches the requests whose body is `body`.
+func (e *MockExpectation) WithBody(body string) *MockExpectation {
+	e.body = &body
+	return e
+}
+
+// WithMatcher matches the requests using custom `matcher`, in which the request body can be read repeatedly.
+func (e *MockExpectation) WithMatcher(matcher func(r *http.Request) bool) *MockExpectation {
+	e.matchers = append(e.matchers, matcher)
+	return e
+}
+
+// Reply responds the matched requests with `status` and `body`, in which the body of string or []byte is
+// responded as it is, and others are encoded as JSON with header "Content-Type: application/json".
+func (e *MockExpectation) Reply(status int, body ...interface{}) *MockExpectation {
+	e.status = status
+	e.replyBody = nil
+	if len(body) == 0 || body[0] == nil {
+		return e
+	}
+	switch v := body[0].(type) {
+	case string:
+		e.replyBody = []byte(v)
+	case []byte:
+		e.replyBody = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			e.replyErr = gerror.Wrap(err, `marshal mock response body failed`)
+			return e
+		}
+		e.replyBody = b
+		e.replyHeader.Set(httpHeaderContentType, httpHeaderContentTypeJson)
+	}
+	return e
+}
+
+// ReplyHeader sets the header `key` of `value` of response.
+func (e *MockExpectation) ReplyHeader(key, value string) *MockExpectation {
+	e.replyHeader.Set(key, value)
+	return e
+}
+
+// ReplyError returns `err` for the matched requests instead of response, like the network errors.
+func (e *MockExpectation) ReplyError(err error) *MockExpectation {
+	e.replyErr = err
+	return e
+}
+
+// Delay delays the response for `delay`, which is interrupted if the request context is done.
+func (e *MockExpectation) Delay(delay time.Duration) *MockExpectation {
+	e.delay = delay
+	return e
+}
+
+// Times sets the expected call count of expectation, after which it matches no more requests.
+// The expectation is expected to be called at least once and matches unlimited requests in default.
+func (e *MockExpectation) Times(n int) *MockExpectation {
+	e.expectedCalls = n
+	return e
+}
+
+// Calls returns the count that the expectation is called.
+func (e *MockExpectation) Calls() int {
+	e.transport.mu.Lock()
+	defer e.transport.mu.Unlock()
+	return e.calls
+}
+
+// String returns the description of expectation.
+func (e *MockExpectation) String() string {
+	var (
+		method = e.method
+		path   = e.path
+	)
+	if method == "" {
+		method = "*"
+	}
+	if path == "" {
+		path = "*"
+	}
+	return method + " " + path
+}
+
+// match checks whether the request matches the expectation.
+func (e *MockExpectation) match(r *http.Request, body []byte) bool {
+	if e.expectedCalls > 0 && e.calls >= e.expectedCalls {
+		return false
+	}
+	if e.method != "" && e.method != r.Method {
+		return false
+	}
+	if e.path != "" && e.path != r.URL.Path {
+		return false
+	}
+	query := r.URL.Query()
+	for k, v := range e.query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+	for k, v := range e.header {
+		if r.Header.Get(k) != v {
+			return false
+		}
+	}
+	if e.body != nil && *e.body != string(body) {
+		return false
+	}
+	for _, matcher := range e.matchers {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if !matcher(r) {
+			return false
+		}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return true
+}
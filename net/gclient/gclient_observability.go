@@ -62,7 +62,7 @@ func internalMiddlewareObservability(c *Client, r *http.Request) (response *Resp
 	span.SetAttributes(gtrace.CommonLabels()...)
 
 	// Inject tracing content into http header.
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	c.getPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
 
 	// Inject ClientTrace into context for http request.
 	var (
@@ -76,7 +76,9 @@ func internalMiddlewareObservability(c *Client, r *http.Request) (response *Resp
 	}
 	// Metrics.
 	if gmetric.IsEnabled() {
-		baseClientTracer = newClientTracerMetrics(r, baseClientTracer)
+		var metricsDone func()
+		baseClientTracer, metricsDone = newClientTracerMetrics(r, baseClientTracer)
+		defer metricsDone()
 	}
 	httpClientTracer = newClientTracer(baseClientTracer)
 	r = r.WithContext(
@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// MaxIdleConnsPerHost is a chaining function,
+// which sets the maximum idle connections kept per host for next request.
+func (c *Client) MaxIdleConnsPerHost(n int) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetMaxIdleConnsPerHost(n); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// MaxConnsPerHost is a chaining function,
+// which sets the maximum connections per host for next request.
+func (c *Client) MaxConnsPerHost(n int) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetMaxConnsPerHost(n); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// IdleConnTimeout is a chaining function,
+// which sets the idle connection timeout for next request.
+func (c *Client) IdleConnTimeout(timeout time.Duration) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetIdleConnTimeout(timeout); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetMaxIdleConnsPerHost sets the maximum idle connections kept per host in the connection pool,
+// and it also raises the maximum idle connections of all hosts if it is limited less than `n`.
+//
+// The keep-alive connections are disabled for the client in default, which are enabled by this function
+// as the connection pool takes no effect without keep-alive.
+func (c *Client) SetMaxIdleConnsPerHost(n int) error {
+	if n < 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid max idle connections per host: %d`, n)
+	}
+	transport, err := c.getPoolTransport()
+	if err != nil {
+		return err
+	}
+	transport.DisableKeepAlives = false
+	transport.MaxIdleConnsPerHost = n
+	if transport.MaxIdleConns > 0 && transport.MaxIdleConns < n {
+		transport.MaxIdleConns = n
+	}
+	return nil
+}
+
+// SetMaxConnsPerHost sets the maximum connections per host, including the dialing, active and idle ones.
+// The requests wait for an available connection if the limit is reached, and the waiting duration is
+// published in metric "http.client.connection.wait_duration". The value 0 means no limit.
+//
+// The keep-alive connections are enabled by this function, see SetMaxIdleConnsPerHost.
+func (c *Client) SetMaxConnsPerHost(n int) error {
+	if n < 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid max connections per host: %d`, n)
+	}
+	transport, err := c.getPoolTransport()
+	if err != nil {
+		return err
+	}
+	transport.DisableKeepAlives = false
+	transport.MaxConnsPerHost = n
+	return nil
+}
+
+// SetIdleConnTimeout sets the maximum duration an idle connection remains in the connection pool
+// before it is closed. The value 0 means no limit.
+//
+// The keep-alive connections are enabled by this function, see SetMaxIdleConnsPerHost.
+func (c *Client) SetIdleConnTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid idle connection timeout: %s`, timeout)
+	}
+	// The HTTP/2 transport multiplexes a connection per host, which supports the idle timeout only.
+	if v, ok := c.Transport.(*http2.Transport); ok {
+		v.IdleConnTimeout = timeout
+		return nil
+	}
+	transport, err := c.getPoolTransport()
+	if err != nil {
+		return err
+	}
+	transport.DisableKeepAlives = false
+	transport.IdleConnTimeout = timeout
+	return nil
+}
+
+// getPoolTransport returns the Transport of the client which manages the connection pool.
+func (c *Client) getPoolTransport() (*http.Transport, error) {
+	if v, ok := c.Transport.(*http.Transport); ok {
+		return v, nil
+	}
+	return nil, gerror.NewCode(
+		gcode.CodeInvalidOperation,
+		`cannot set connection pool for custom Transport of the client`,
+	)
+}
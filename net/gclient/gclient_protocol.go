@@ -0,0 +1,143 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+
+	"golang.org/x/net/http2"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// HTTP3TransportProvider creates and returns the HTTP/3 round tripper with given TLS configuration.
+type HTTP3TransportProvider func(tlsConfig *tls.Config) http.RoundTripper
+
+const (
+	// ProtocolAuto uses HTTP/2 if it is negotiated by TLS ALPN, or else HTTP/1.1.
+	ProtocolAuto = "auto"
+
+	// ProtocolHTTP2 uses HTTP/2 over TLS only, which fails if the server does not support HTTP/2.
+	ProtocolHTTP2 = "h2"
+
+	// ProtocolH2C uses HTTP/2 over cleartext TCP with prior knowledge, which is for "http" urls only.
+	ProtocolH2C = "h2c"
+
+	// ProtocolHTTP3 uses HTTP/3 over QUIC, which requires the HTTP/3 transport registered
+	// by RegisterHTTP3Transport, like importing package "github.com/gogf/gf/contrib/net/gquic/v2".
+	ProtocolHTTP3 = "h3"
+)
+
+var (
+	// http3TransportProvider is the registered provider of HTTP/3 transport.
+	http3TransportProvider HTTP3TransportProvider
+
+	// http3TransportMu is the mutex for http3TransportProvider.
+	http3TransportMu sync.RWMutex
+)
+
+// RegisterHTTP3Transport registers the provider of HTTP/3 transport, which is used by ProtocolHTTP3.
+// It is commonly called by the package implementing QUIC in its initialization.
+func RegisterHTTP3Transport(provider HTTP3TransportProvider) {
+	http3TransportMu.Lock()
+	defer http3TransportMu.Unlock()
+	http3TransportProvider = provider
+}
+
+// Protocol is a chaining function,
+// which sets the transport protocol for next request.
+// The `protocol` can be ProtocolAuto, ProtocolHTTP2, ProtocolH2C or ProtocolHTTP3.
+func (c *Client) Protocol(protocol string) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetProtocol(protocol); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetProtocol sets the transport protocol of the client, which replaces the underlying Transport with
+// a new one of given `protocol`, inheriting the TLS configuration of current Transport.
+// The `protocol` can be ProtocolAuto, ProtocolHTTP2, ProtocolH2C or ProtocolHTTP3.
+//
+// Note that the negotiated protocol of each request is recorded in its metrics,
+// and can also be retrieved from Response.Proto.
+func (c *Client) SetProtocol(protocol string) error {
+	tlsConfig := c.getTLSConfig()
+	switch protocol {
+	case ProtocolAuto:
+		transport, ok := c.Transport.(*http.Transport)
+		if !ok {
+			transport = &http.Transport{}
+		} else {
+			// It clones the Transport as it might be shared with other clients.
+			transport = transport.Clone()
+		}
+		transport.TLSClientConfig = tlsConfig
+		transport.TLSNextProto = nil
+		transport.ForceAttemptHTTP2 = true
+		c.Transport = transport
+
+	case ProtocolHTTP2:
+		c.Transport = &http2.Transport{
+			TLSClientConfig: tlsConfig,
+		}
+
+	case ProtocolH2C:
+		c.Transport = &http2.Transport{
+			TLSClientConfig: tlsConfig,
+			AllowHTTP:       true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+
+	case ProtocolHTTP3:
+		http3TransportMu.RLock()
+		provider := http3TransportProvider
+		http3TransportMu.RUnlock()
+		if provider == nil {
+			return gerror.NewCode(
+				gcode.CodeNotSupported,
+				`HTTP/3 transport is not registered, please import package "github.com/gogf/gf/contrib/net/gquic/v2"`,
+			)
+		}
+		c.Transport = provider(tlsConfig)
+
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid protocol "%s"`, protocol)
+	}
+	return nil
+}
+
+// getTLSConfig returns a copy of the TLS configuration of current Transport,
+// or the default one if it cannot be retrieved.
+func (c *Client) getTLSConfig() *tls.Config {
+	var tlsConfig *tls.Config
+	switch v := c.Transport.(type) {
+	case *http.Transport:
+		tlsConfig = v.TLSClientConfig
+	case *http2.Transport:
+		tlsConfig = v.TLSClientConfig
+	}
+	if tlsConfig == nil {
+		// No validation for https certification of the server in default.
+		return &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+	tlsConfig = tlsConfig.Clone()
+	// The ALPN protocols are set by the Transport of each protocol.
+	tlsConfig.NextProtos = nil
+	return tlsConfig
+}
@@ -0,0 +1,278 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// PACEvaluator evaluates the PAC(Proxy Auto-Configuration) `script` and returns the result of its function
+// FindProxyForURL(url, host), like "PROXY proxy.example.com:8080; SOCKS5 socks.example.com:1080; DIRECT".
+//
+// There's no JavaScript engine built in client, the evaluator can be implemented using third-party
+// packages, like:
+//
+//	func(ctx context.Context, script, url, host string) (string, error) {
+//		vm := goja.New()
+//		// Register the PAC helper functions like isPlainHostName, dnsResolve and shExpMatch here.
+//		if _, err := vm.RunString(script); err != nil {
+//			return "", err
+//		}
+//		findProxyForURL, _ := goja.AssertFunction(vm.Get("FindProxyForURL"))
+//		result, err := findProxyForURL(goja.Undefined(), vm.ToValue(url), vm.ToValue(host))
+//		if err != nil {
+//			return "", err
+//		}
+//		return result.String(), nil
+//	}
+type PACEvaluator func(ctx context.Context, script, url, host string) (string, error)
+
+// PACConfig is the configuration of proxy auto-configuration.
+type PACConfig struct {
+	Url             string        // URL of PAC file like "http://wpad/wpad.dat", or a local file path.
+	Script          string        // Content of PAC script, which is used if Url is empty.
+	Evaluator       PACEvaluator  // Evaluator of PAC script, which is required.
+	RefreshInterval time.Duration // Interval reloading PAC file from Url, 0 means never.
+}
+
+// proxyPAC selects proxies of requests by evaluating PAC script.
+type proxyPAC struct {
+	config   PACConfig
+	mu       sync.RWMutex
+	script   string    // Loaded PAC script.
+	loadedAt time.Time // Last time that the script is loaded.
+}
+
+const (
+	// ProxyDirect is the proxy for SetRequestProxy that connects to server directly without any proxy.
+	ProxyDirect = "DIRECT"
+
+	// proxyCtxKey is the context key for the proxy overriding of request, which is only set on the
+	// context of http request sent by the client, so that it never affects other requests.
+	proxyCtxKey gctx.StrKey = `Proxy`
+
+	pacLoadTimeout = 10 * time.Second
+)
+
+// RequestProxy is a chaining function,
+// which overrides the proxy of client for next request, see SetRequestProxy.
+func (c *Client) RequestProxy(proxyURL string) *Client {
+	newClient := c.Clone()
+	newClient.SetRequestProxy(proxyURL)
+	return newClient
+}
+
+// SetRequestProxy sets the proxy of the requests sent by the client, which overrides the proxy set by SetProxy
+// and the proxy auto-configuration, or connects directly without any proxy if `proxyURL` is ProxyDirect.
+// The proxy URL is like `http://USER:PASSWORD@IP:PORT`, `socks5://IP:PORT` or `socks5h://IP:PORT`,
+// in which the host names are resolved remotely by the socks5 proxy server. The overriding is removed
+// if `proxyURL` is empty.
+//
+// It is commonly used by the chaining function RequestProxy for a single request, like:
+//
+//	client.RequestProxy(gclient.ProxyDirect).Get(ctx, url)
+//
+// Note that the proxy overriding takes no effect for custom Transport of the client,
+// and it is not supported with the socks5 proxy set by SetProxy, which replaces the dialer of the client.
+func (c *Client) SetRequestProxy(proxyURL string) *Client {
+	c.requestProxy = proxyURL
+	return c
+}
+
+// ProxyPAC is a chaining function,
+// which selects the proxies using proxy auto-configuration for next request.
+func (c *Client) ProxyPAC(config PACConfig) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetProxyPAC(config); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetProxyPAC sets the proxy auto-configuration of client, which selects the proxy of each request by
+// evaluating the PAC script. The first proxy of the PAC result is used, in which "PROXY" and "HTTP" are
+// HTTP proxies, "HTTPS" is HTTPS proxy, and "SOCKS" and "SOCKS5" are socks5 proxies with remote DNS
+// resolution. The PAC file is loaded immediately, and reloaded in RefreshInterval if configured, in which
+// the previous script is still used if the reloading fails.
+func (c *Client) SetProxyPAC(config PACConfig) error {
+	if config.Evaluator == nil {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `evaluator of PAC script is required`)
+	}
+	if config.Url == "" && config.Script == "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `either url or script of PAC is required`)
+	}
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		return gerror.New(`cannot set proxy auto-configuration for custom Transport of the client`)
+	}
+	pac := &proxyPAC{
+		config: config,
+		script: config.Script,
+	}
+	if config.Url != "" {
+		if err := pac.load(context.Background()); err != nil {
+			return err
+		}
+	}
+	// It clones the Transport as it might be shared with other clients.
+	transport = transport.Clone()
+	transport.Proxy = newProxyFunc(pac.proxy)
+	c.Transport = transport
+	return nil
+}
+
+// withRequestProxy returns the request carrying the proxy overriding of client in its context if any.
+func (c *Client) withRequestProxy(req *http.Request) *http.Request {
+	if c.requestProxy == "" {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), proxyCtxKey, c.requestProxy))
+}
+
+// newProxyFunc creates and returns the proxy function for Transport, which uses the proxy overriding of
+// request context in priority, or else the proxy returned by `next` if it is not nil.
+func newProxyFunc(next func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(r *http.Request) (*url.URL, error) {
+		if v := r.Context().Value(proxyCtxKey); v != nil {
+			proxyURL, _ := v.(string)
+			if strings.EqualFold(proxyURL, ProxyDirect) {
+				return nil, nil
+			}
+			return parseProxyURL(proxyURL)
+		}
+		if next == nil {
+			return nil, nil
+		}
+		return next(r)
+	}
+}
+
+// parseProxyURL parses and returns the proxy URL for Transport.
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid proxy "%s"`, proxyURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	case "socks5h":
+		// The Transport resolves host names remotely for socks5 proxy, which is the same as socks5h.
+		u.Scheme = "socks5"
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported proxy scheme "%s"`, u.Scheme)
+	}
+	return u, nil
+}
+
+// proxy evaluates the PAC script and returns the proxy of request, or nil for direct connection.
+func (p *proxyPAC) proxy(r *http.Request) (*url.URL, error) {
+	ctx := r.Context()
+	p.mu.RLock()
+	var (
+		script    = p.script
+		needsLoad = p.config.Url != "" && p.config.RefreshInterval > 0 &&
+			time.Since(p.loadedAt) >= p.config.RefreshInterval
+	)
+	p.mu.RUnlock()
+	if needsLoad {
+		if err := p.load(ctx); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+		p.mu.RLock()
+		script = p.script
+		p.mu.RUnlock()
+	}
+	result, err := p.config.Evaluator(ctx, script, r.URL.String(), r.URL.Hostname())
+	if err != nil {
+		return nil, gerror.Wrap(err, `evaluate PAC script failed`)
+	}
+	return parsePACResult(result)
+}
+
+// load loads the PAC script from the url of configuration.
+func (p *proxyPAC) load(ctx context.Context) error {
+	var (
+		content []byte
+		err     error
+		pacURL  = p.config.Url
+	)
+	if strings.HasPrefix(pacURL, "http://") || strings.HasPrefix(pacURL, "https://") {
+		content, err = loadPACFromURL(ctx, pacURL)
+	} else {
+		content, err = os.ReadFile(strings.TrimPrefix(pacURL, "file://"))
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// It retries loading in next interval whether it succeeds or not.
+	p.loadedAt = time.Now()
+	if err != nil {
+		return gerror.Wrapf(err, `load PAC file "%s" failed`, pacURL)
+	}
+	p.script = string(content)
+	return nil
+}
+
+// loadPACFromURL downloads the PAC file from `pacURL` directly without any proxy.
+func loadPACFromURL(ctx context.Context, pacURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, pacLoadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pacURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Transport: &http.Transport{}}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, gerror.NewCodef(gcode.CodeOperationFailed, `unexpected status code %d`, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parsePACResult parses the result of FindProxyForURL, which returns the first supported proxy,
+// or nil for direct connection.
+func parsePACResult(result string) (*url.URL, error) {
+	for _, item := range strings.Split(result, ";") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+		var scheme string
+		switch strings.ToUpper(fields[0]) {
+		case "DIRECT":
+			return nil, nil
+		case "PROXY", "HTTP":
+			scheme = "http"
+		case "HTTPS":
+			scheme = "https"
+		case "SOCKS", "SOCKS5":
+			scheme = "socks5"
+		default:
+			continue
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		return &url.URL{Scheme: scheme, Host: fields[1]}, nil
+	}
+	// It connects directly if no proxy returned.
+	return nil, nil
+}
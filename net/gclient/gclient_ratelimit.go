@@ -0,0 +1,219 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// RateLimitMode is the mode of rate limiter when the rate limit is exceeded.
+type RateLimitMode int
+
+const (
+	// RateLimitModeWait blocks the request until it is allowed or its context is done.
+	RateLimitModeWait RateLimitMode = iota
+	// RateLimitModeFailFast rejects the request with ErrRateLimited immediately.
+	RateLimitModeFailFast
+)
+
+const (
+	// RateLimitKeyGlobal is the rate limit key which limits all requests of the client.
+	RateLimitKeyGlobal = "*"
+
+	// rateLimitGroupCtxKey is the context key for the rate limit group of request.
+	rateLimitGroupCtxKey gctx.StrKey = `RateLimitGroup`
+)
+
+// ErrRateLimited is returned if the request is rejected by rate limiter in RateLimitModeFailFast.
+var ErrRateLimited = gerror.NewWithOption(gerror.Option{
+	Text: "rate limit exceeded",
+	Code: gcode.CodeOperationFailed,
+})
+
+// rateLimiterGroup manages the rate limiters of client by key, which is copied on write.
+type rateLimiterGroup struct {
+	mode     RateLimitMode
+	limiters map[string]*rateLimiter
+}
+
+// rateLimiter is the token bucket rate limiter of a single key.
+type rateLimiter struct {
+	mu     sync.Mutex
+	qps    float64   // Tokens refilled per second.
+	burst  int       // Capacity of bucket.
+	tokens float64   // Available tokens, which is negative if tokens are reserved by waiting requests.
+	last   time.Time // Last time that tokens are refilled.
+}
+
+// WithRateLimitGroup returns a new context with rate limit `group`, so that the requests with the
+// context are also limited by the rate limiter of key `group`, like the group of expensive APIs.
+func WithRateLimitGroup(ctx context.Context, group string) context.Context {
+	return context.WithValue(ctx, rateLimitGroupCtxKey, group)
+}
+
+// RateLimit is a chaining function,
+// which limits the requests of `key` to `qps` requests per second with `burst` for next request.
+func (c *Client) RateLimit(key string, qps float64, burst int) *Client {
+	newClient := c.Clone()
+	newClient.SetRateLimit(key, qps, burst)
+	return newClient
+}
+
+// RateLimitMode is a chaining function,
+// which sets the mode of rate limiters for next request.
+func (c *Client) RateLimitMode(mode RateLimitMode) *Client {
+	newClient := c.Clone()
+	newClient.SetRateLimitMode(mode)
+	return newClient
+}
+
+// SetRateLimit limits the requests of `key` to `qps` requests per second using token bucket, in which
+// `burst` is the max requests allowed at once, which is 1 if <= 0. It removes the limit of `key` if `qps` <= 0.
+//
+// The `key` can be RateLimitKeyGlobal for all requests, the host of request like "127.0.0.1:8000",
+// or the group set by WithRateLimitGroup, and the request is limited by all limiters it matches.
+// Note that the clients cloned from the client share the rate limiters.
+func (c *Client) SetRateLimit(key string, qps float64, burst int) *Client {
+	group := &rateLimiterGroup{
+		limiters: make(map[string]*rateLimiter),
+	}
+	if c.rateLimiter != nil {
+		group.mode = c.rateLimiter.mode
+		for k, v := range c.rateLimiter.limiters {
+			group.limiters[k] = v
+		}
+	}
+	if qps <= 0 {
+		delete(group.limiters, key)
+	} else {
+		if burst <= 0 {
+			burst = 1
+		}
+		group.limiters[key] = &rateLimiter{
+			qps:    qps,
+			burst:  burst,
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+	c.rateLimiter = group
+	return c
+}
+
+// SetRateLimitMode sets the mode of rate limiters when the rate limit is exceeded,
+// which is RateLimitModeWait in default.
+func (c *Client) SetRateLimitMode(mode RateLimitMode) *Client {
+	group := &rateLimiterGroup{
+		mode: mode,
+	}
+	if c.rateLimiter != nil {
+		group.limiters = c.rateLimiter.limiters
+	}
+	c.rateLimiter = group
+	return c
+}
+
+// internalMiddlewareRateLimit is a client middleware that enables rate limit feature for client.
+func internalMiddlewareRateLimit(c *Client, r *http.Request) (response *Response, err error) {
+	if c.rateLimiter == nil || len(c.rateLimiter.limiters) == 0 {
+		return c.Next(r)
+	}
+	if err = c.rateLimiter.wait(r); err != nil {
+		return nil, err
+	}
+	return c.Next(r)
+}
+
+// wait takes a token from each limiter that `r` matches, which blocks until all tokens are available
+// in RateLimitModeWait, or fails if any token is unavailable in RateLimitModeFailFast.
+func (g *rateLimiterGroup) wait(r *http.Request) error {
+	var (
+		ctx      = r.Context()
+		now      = time.Now()
+		maxWait  time.Duration
+		reserved []*rateLimiter
+	)
+	for _, key := range g.getKeys(r) {
+		limiter, ok := g.limiters[key]
+		if !ok {
+			continue
+		}
+		wait, ok := limiter.reserve(now, g.mode == RateLimitModeWait)
+		if !ok {
+			for _, v := range reserved {
+				v.cancel()
+			}
+			return gerror.Wrapf(ErrRateLimited, `request rejected by rate limiter of "%s"`, key)
+		}
+		reserved = append(reserved, limiter)
+		if wait > maxWait {
+			maxWait = wait
+		}
+	}
+	if maxWait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		for _, v := range reserved {
+			v.cancel()
+		}
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getKeys returns the keys of limiters that `r` matches.
+func (g *rateLimiterGroup) getKeys(r *http.Request) []string {
+	keys := []string{RateLimitKeyGlobal, r.URL.Host}
+	if group, ok := r.Context().Value(rateLimitGroupCtxKey).(string); ok && group != "" {
+		keys = append(keys, group)
+	}
+	return keys
+}
+
+// reserve takes a token and returns the duration to wait before the token is available.
+// If `allowWait` is false, it takes the token only if it is available now.
+func (l *rateLimiter) reserve(now time.Time, allowWait bool) (wait time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.qps
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+		l.last = now
+	}
+	if l.tokens < 1 && !allowWait {
+		return 0, false
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-l.tokens / l.qps * float64(time.Second)), true
+}
+
+// cancel returns the token taken by reserve, which is used if the request is not sent.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/encoding/gyaml"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// RecorderMode is the mode of Recorder.
+type RecorderMode int
+
+const (
+	// RecorderModeReplayOrRecord replays the matched interaction from cassette,
+	// or sends the request and records it if no interaction matches.
+	RecorderModeReplayOrRecord RecorderMode = iota
+	// RecorderModeRecord sends all requests and records them, which replaces the interactions in cassette.
+	RecorderModeRecord
+	// RecorderModeReplay replays the matched interaction from cassette without network access,
+	// which fails with ErrRecorderNoInteraction if no interaction matches.
+	RecorderModeReplay
+)
+
+// RecorderConfig is the configuration of Recorder.
+type RecorderConfig struct {
+	// Path is the path of cassette file, which is in YAML format if its extension is ".yaml" or ".yml",
+	// or else in JSON format.
+	Path string
+
+	// Mode is the mode of recorder, which is RecorderModeReplayOrRecord in default.
+	Mode RecorderMode
+
+	// MatchHeaders are the request headers that should be matched, besides the method and url.
+	MatchHeaders []string
+
+	// MatchBody specifies whether the request body should be matched.
+	MatchBody bool
+
+	// Matcher replaces the default matching rules if given, which checks whether the request
+	// matches the recorded interaction.
+	Matcher func(r *http.Request, body []byte, interaction *RecorderInteraction) bool
+}
+
+// RecorderInteraction is a recorded pair of request and response.
+type RecorderInteraction struct {
+	Request  RecorderRequest  `json:"request"  yaml:"request"`
+	Response RecorderResponse `json:"response" yaml:"response"`
+}
+
+// RecorderRequest is the recorded request.
+type RecorderRequest struct {
+	Method string      `json:"method"           yaml:"method"`
+	Url    string      `json:"url"              yaml:"url"`
+	Header http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body   string      `json:"body,omitempty"   yaml:"body,omitempty"`
+}
+
+// RecorderResponse is the recorded response.
+type RecorderResponse struct {
+	Status     string      `json:"status"           yaml:"status"`
+	StatusCode int         `json:"statusCode"       yaml:"statusCode"`
+	Header     http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       string      `json:"body,omitempty"   yaml:"body,omitempty"`
+}
+
+// Recorder records the outbound requests and responses of client to cassette file, and replays them
+// in tests without network access. It is enabled by using its Middleware for client.
+type Recorder struct {
+	mu           sync.Mutex
+	config       RecorderConfig
+	interactions []*RecorderInteraction
+	replayed     map[*RecorderInteraction]bool
+}
+
+// ErrRecorderNoInteraction is returned if no recorded interaction matches the request in RecorderModeReplay.
+var ErrRecorderNoInteraction = gerror.NewWithOption(gerror.Option{
+	Text: "no recorded interaction matches the request",
+	Code: gcode.CodeNotFound,
+})
+
+// NewRecorder creates and returns a Recorder, which loads the interactions from cassette file if exists.
+func NewRecorder(config RecorderConfig) (*Recorder, error) {
+	recorder := &Recorder{
+		config:   config,
+		replayed: make(map[*RecorderInteraction]bool),
+	}
+	if config.Mode == RecorderModeRecord || !gfile.Exists(config.Path) {
+		if config.Mode == RecorderModeReplay {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `cassette file "%s" does not exist`, config.Path)
+		}
+		return recorder, nil
+	}
+	content, err := os.ReadFile(config.Path)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read cassette file "%s" failed`, config.Path)
+	}
+	if recorder.isYaml() {
+		err = gyaml.DecodeTo(content, &recorder.interactions)
+	} else {
+		err = json.Unmarshal(content, &recorder.interactions)
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `decode cassette file "%s" failed`, config.Path)
+	}
+	return recorder, nil
+}
+
+// Middleware is the client middleware that records or replays the requests, which is used like:
+// client.Use(recorder.Middleware).
+func (r *Recorder) Middleware(c *Client, req *http.Request) (*Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, gerror.Wrap(err, `read request body failed`)
+		}
+		_ = req.Body.Close()
+		req.Body = utils.NewReadCloser(body, false)
+	}
+	if r.config.Mode != RecorderModeRecord {
+		if interaction := r.match(req, body); interaction != nil {
+			return r.replay(req, body, interaction), nil
+		}
+		if r.config.Mode == RecorderModeReplay {
+			return nil, gerror.Wrapf(ErrRecorderNoInteraction, `%s %s`, req.Method, req.URL.String())
+		}
+	}
+	resp, err := c.Next(req)
+	if err != nil || resp == nil || resp.Response == nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return resp, gerror.Wrap(err, `read response body failed`)
+	}
+	resp.Body = utils.NewReadCloser(respBody, false)
+	err = r.record(&RecorderInteraction{
+		Request: RecorderRequest{
+			Method: req.Method,
+			Url:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(body),
+		},
+		Response: RecorderResponse{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+	return resp, err
+}
+
+// Interactions returns the recorded interactions.
+func (r *Recorder) Interactions() []*RecorderInteraction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interactions := make([]*RecorderInteraction, len(r.interactions))
+	copy(interactions, r.interactions)
+	return interactions
+}
+
+// match returns the interaction matching the request, in which the interactions matched are replayed in
+// order, and the last matched one is replayed repeatedly. It returns nil if no interaction matches.
+func (r *Recorder) match(req *http.Request, body []byte) *RecorderInteraction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var lastMatched *RecorderInteraction
+	for _, interaction := range r.interactions {
+		if !r.isMatched(req, body, interaction) {
+			continue
+		}
+		if !r.replayed[interaction] {
+			r.replayed[interaction] = true
+			return interaction
+		}
+		lastMatched = interaction
+	}
+	return lastMatched
+}
+
+// isMatched checks whether the request matches the interaction.
+func (r *Recorder) isMatched(req *http.Request, body []byte, interaction *RecorderInteraction) bool {
+	if r.config.Matcher != nil {
+		return r.config.Matcher(req, body, interaction)
+	}
+	if req.Method != interaction.Request.Method || req.URL.String() != interaction.Request.Url {
+		return false
+	}
+	for _, key := range r.config.MatchHeaders {
+		if req.Header.Get(key) != interaction.Request.Header.Get(key) {
+			return false
+		}
+	}
+	if r.config.MatchBody && !bytes.Equal(body, []byte(interaction.Request.Body)) {
+		return false
+	}
+	return true
+}
+
+// replay creates and returns the response of the interaction.
+func (r *Recorder) replay(req *http.Request, body []byte, interaction *RecorderInteraction) *Response {
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &Response{
+		Response: &http.Response{
+			Status:        interaction.Response.Status,
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          utils.NewReadCloser([]byte(interaction.Response.Body), false),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		},
+		request:     req,
+		requestBody: body,
+	}
+}
+
+// record appends the interaction and saves all interactions to cassette file.
+func (r *Recorder) record(interaction *RecorderInteraction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction)
+	r.replayed[interaction] = true
+	var (
+		content []byte
+		err     error
+	)
+	if r.isYaml() {
+		content, err = gyaml.Encode(r.interactions)
+	} else {
+		content, err = json.MarshalIndent(r.interactions, "", "  ")
+	}
+	if err != nil {
+		return gerror.Wrap(err, `encode interactions failed`)
+	}
+	if err = gfile.PutBytes(r.config.Path, content); err != nil {
+		return gerror.Wrapf(err, `write cassette file "%s" failed`, r.config.Path)
+	}
+	return nil
+}
+
+// isYaml checks whether the cassette file is in YAML format.
+func (r *Recorder) isYaml() bool {
+	switch strings.ToLower(gfile.ExtName(r.config.Path)) {
+	case "yaml", "yml":
+		return true
+	}
+	return false
+}
@@ -16,12 +16,15 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/httputil"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/text/gregex"
@@ -130,7 +133,51 @@ func (c *Client) DoRequest(
 	if err != nil {
 		return nil, err
 	}
+	return c.doRequest(req, requestStartTime)
+}
+
+// DoHttpRequest sends the http request `req` built by the caller through the middlewares of the client,
+// and returns the response object. Note that the response object MUST be closed if it'll never be used.
+//
+// Different from DoRequest, the method, URL, header and body of `req` are sent as they are, and the
+// prefix, header, cookie and basic authentication configured on the client are not applied to it.
+func (c *Client) DoHttpRequest(req *http.Request) (resp *Response, err error) {
+	return c.doRequest(req, gtime.Now())
+}
 
+// doRequest sends the prepared request through the middlewares and returns the response object.
+func (c *Client) doRequest(req *http.Request, requestStartTime *gtime.Time) (resp *Response, err error) {
+	var cancel context.CancelFunc
+	if c.budget > 0 {
+		ctx, cancelFunc := context.WithTimeout(req.Context(), c.budget)
+		req, cancel = req.WithContext(ctx), cancelFunc
+	}
+	resp, err = c.doRequestWithHandler(req, requestStartTime, func(cli *Client, r *http.Request) (*Response, error) {
+		return cli.callRequest(r)
+	})
+	if cancel != nil {
+		// The budget context is kept for reading response body.
+		if resp != nil && resp.Response != nil && resp.Response.Body != nil {
+			resp.Response.Body = &cancelOnCloseBody{
+				ReadCloser: resp.Response.Body,
+				cancel:     cancel,
+			}
+		} else {
+			cancel()
+		}
+	}
+	if resp != nil {
+		resp.codec = c.contentCodec
+	}
+	return resp, err
+}
+
+// doRequestWithHandler sends the prepared request through the middlewares, in which the request is finally
+// handled by `handler` rather than sent by the underlying http client, like the WebSocket handshake.
+func (c *Client) doRequestWithHandler(
+	req *http.Request, requestStartTime *gtime.Time, handler HandlerFunc,
+) (resp *Response, err error) {
+	req = c.withRequestProxy(req)
 	// Metrics.
 	c.handleMetricsBeforeRequest(req)
 	defer c.handleMetricsAfterRequestDone(req, requestStartTime)
@@ -139,10 +186,8 @@ func (c *Client) DoRequest(
 	if len(c.middlewareHandler) > 0 {
 		mdlHandlers := make([]HandlerFunc, 0, len(c.middlewareHandler)+1)
 		mdlHandlers = append(mdlHandlers, c.middlewareHandler...)
-		mdlHandlers = append(mdlHandlers, func(cli *Client, r *http.Request) (*Response, error) {
-			return cli.callRequest(r)
-		})
-		ctx = context.WithValue(req.Context(), clientMiddlewareKey, &clientMiddleware{
+		mdlHandlers = append(mdlHandlers, handler)
+		ctx := context.WithValue(req.Context(), clientMiddlewareKey, &clientMiddleware{
 			client:       c,
 			handlers:     mdlHandlers,
 			handlerIndex: -1,
@@ -150,7 +195,7 @@ func (c *Client) DoRequest(
 		req = req.WithContext(ctx)
 		resp, err = c.Next(req)
 	} else {
-		resp, err = c.callRequest(req)
+		resp, err = handler(c, req)
 	}
 	if resp != nil && resp.Response != nil {
 		req.Response = resp.Response
@@ -170,6 +215,7 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 	var (
 		params             string
 		allowFileUploading = true
+		contentCodec       = getContentCodec(c.contentCodec, c.header[httpHeaderContentType])
 	)
 	if len(data) > 0 {
 		switch c.header[httpHeaderContentType] {
@@ -200,7 +246,24 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 			allowFileUploading = false
 
 		default:
-			params = httputil.BuildParams(data[0], c.noUrlEncode)
+			if contentCodec == nil {
+				params = httputil.BuildParams(data[0], c.noUrlEncode)
+				break
+			}
+			// Custom content codec.
+			switch data[0].(type) {
+			case string, []byte:
+				params = gconv.String(data[0])
+			default:
+				if b, err := contentCodec.Marshal(data[0]); err != nil {
+					return nil, gerror.Wrapf(
+						err, `marshal request data of type "%s" failed`, contentCodec.ContentType(),
+					)
+				} else {
+					params = string(b)
+				}
+			}
+			allowFileUploading = false
 		}
 	}
 	if method == http.MethodGet {
@@ -212,6 +275,10 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 				httpHeaderContentTypeXml:
 				bodyBuffer = bytes.NewBuffer([]byte(params))
 			default:
+				if contentCodec != nil {
+					bodyBuffer = bytes.NewBuffer([]byte(params))
+					break
+				}
 				// It appends the parameters to the url
 				// if http method is GET and Content-Type is not specified.
 				if gstr.Contains(url, "?") {
@@ -363,26 +430,100 @@ func (c *Client) callRequest(req *http.Request) (resp *Response, err error) {
 	// Dump feature.
 	// The request body can be reused for dumping
 	// raw HTTP request-response procedure.
-	reqBodyContent, _ := io.ReadAll(req.Body)
-	resp.requestBody = reqBodyContent
+	var (
+		reqBodyContent []byte
+		streaming      = isStreamingBody(req.Body)
+	)
+	if !streaming {
+		reqBodyContent, _ = io.ReadAll(req.Body)
+		resp.requestBody = reqBodyContent
+	}
+	var (
+		retried   int
+		attempted bool
+		refreshed bool
+		token     *Token
+		policy    = c.retryPolicy
+		startTime = time.Now()
+	)
+	defer func() {
+		if retried > 0 {
+			trace.SpanFromContext(req.Context()).SetAttributes(gtrace.RetryCountAttribute(retried))
+		}
+	}()
 	for {
-		req.Body = utils.NewReadCloser(reqBodyContent, false)
-		if resp.Response, err = c.Do(req); err != nil {
+		if !streaming {
+			req.Body = utils.NewReadCloser(reqBodyContent, false)
+		} else if attempted {
+			// The streaming body is not buffered, which is recreated for each retry.
+			if req.Body, err = req.GetBody(); err != nil {
+				return resp, gerror.Wrap(err, `recreate request body failed`)
+			}
+		}
+		if c.tokenManager != nil {
+			if token, err = c.tokenManager.setAuthorization(req); err != nil {
+				return resp, err
+			}
+		}
+		attempted = true
+		c.setAcceptEncoding(req)
+		c.setBudgetHeader(req)
+		if c.hedgePolicy.isEnabled(req) {
+			resp.Response, err = c.doHedged(req, reqBodyContent)
+		} else {
+			resp.Response, err = c.do(req)
+		}
+		if err == nil {
+			c.decodeResponse(resp.Response)
+		}
+		if err != nil {
 			err = gerror.Wrapf(err, `request failed`)
 			// The response might not be nil when err != nil.
 			if resp.Response != nil {
 				_ = resp.Response.Body.Close()
 			}
-			if c.retryCount > 0 {
-				c.retryCount--
-				time.Sleep(c.retryInterval)
-			} else {
-				// return resp, err
+		}
+		// The token rejected by server is refreshed, and the request is sent once again,
+		// which is not counted as retry.
+		if c.tokenManager != nil && !refreshed && err == nil && resp.Response.StatusCode == http.StatusUnauthorized {
+			refreshed = true
+			if _, err = c.tokenManager.refresh(req.Context(), token); err != nil {
+				_ = resp.Response.Body.Close()
+				resp.Response = nil
+				return resp, err
+			}
+			_ = resp.Response.Body.Close()
+			continue
+		}
+		if retried >= policy.MaxRetries || !policy.shouldRetry(req.Context(), resp.Response, err) {
+			break
+		}
+		interval := policy.Backoff(retried + 1)
+		// The waiting time required by server takes precedence over the shorter backoff.
+		if retryAfter, ok := policy.retryAfter(resp.Response); ok {
+			if policy.MaxRetryAfter > 0 && retryAfter > policy.MaxRetryAfter {
 				break
 			}
-		} else {
+			if retryAfter > interval {
+				interval = retryAfter
+			}
+		}
+		if policy.MaxElapsedTime > 0 && time.Since(startTime)+interval > policy.MaxElapsedTime {
+			break
+		}
+		// It stops retrying if the remaining time before deadline is not enough for next attempt.
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) <= interval {
 			break
 		}
+		policy.recordRetryWait(req, retried+1, interval, resp.Response, err)
+		if !waitForRetry(req.Context(), interval) {
+			break
+		}
+		// The response of failed attempt is discarded.
+		if err == nil && resp.Response != nil {
+			_ = resp.Response.Body.Close()
+		}
+		retried++
 	}
 	return resp, err
 }
@@ -72,6 +72,9 @@ func (c *Client) DoRequestObj(ctx context.Context, req, res interface{}) error {
 		http.MethodConnect,
 		http.MethodOptions,
 		http.MethodTrace:
+		if c.contentCodec != nil {
+			return c.doRequestObjWithCodec(ctx, method, path, req, res)
+		}
 		if result := c.RequestVar(ctx, method, path, req); res != nil && !result.IsEmpty() {
 			return result.Scan(res)
 		}
@@ -82,6 +85,20 @@ func (c *Client) DoRequestObj(ctx context.Context, req, res interface{}) error {
 	}
 }
 
+// doRequestObjWithCodec does HTTP request in which the request object `req` is marshaled and the response
+// content is unmarshaled to `res` using the content codec of client.
+func (c *Client) doRequestObjWithCodec(ctx context.Context, method, path string, req, res interface{}) error {
+	response, err := c.DoRequest(ctx, method, path, req)
+	if err != nil {
+		return err
+	}
+	defer response.Close()
+	if res == nil {
+		return nil
+	}
+	return response.Scan(res)
+}
+
 // handlePathForObjRequest replaces parameters in `path` with parameters from request object.
 // Eg:
 // /order/{id}  -> /order/1
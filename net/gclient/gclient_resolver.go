@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// Resolver resolves the host to its IP addresses for client, which is implemented by net.Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// TTLResolver is the Resolver that also returns the TTL of lookup result,
+// which is honored by CachingResolver.
+type TTLResolver interface {
+	Resolver
+	LookupHostTTL(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+}
+
+// CachingResolver is the Resolver that caches the lookup results of another Resolver.
+type CachingResolver struct {
+	resolver Resolver
+	ttl      time.Duration
+	mu       sync.RWMutex
+	cache    map[string]*resolverCacheItem
+}
+
+// StaticResolver is the Resolver that resolves the hosts using static host overrides,
+// like the "/etc/hosts" file, which falls back to another Resolver for other hosts.
+type StaticResolver struct {
+	hosts    map[string][]string
+	fallback Resolver
+}
+
+// resolverCacheItem is the cached lookup result.
+type resolverCacheItem struct {
+	addrs    []string
+	expireAt time.Time
+}
+
+const (
+	defaultResolverCacheTTL = time.Minute
+)
+
+// NewCachingResolver creates and returns a CachingResolver that caches the lookup results of `resolver`,
+// which is net.DefaultResolver if nil. The results are cached for the TTL returned by `resolver`
+// if it implements TTLResolver, or else for `ttl`, which is 1 minute if <= 0.
+// The failed lookups are not cached.
+func NewCachingResolver(resolver Resolver, ttl time.Duration) *CachingResolver {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if ttl <= 0 {
+		ttl = defaultResolverCacheTTL
+	}
+	return &CachingResolver{
+		resolver: resolver,
+		ttl:      ttl,
+		cache:    make(map[string]*resolverCacheItem),
+	}
+}
+
+// LookupHost looks up the given host, which returns the cached addresses if not expired.
+func (r *CachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	now := time.Now()
+	r.mu.RLock()
+	item, ok := r.cache[host]
+	r.mu.RUnlock()
+	if ok && now.Before(item.expireAt) {
+		return item.addrs, nil
+	}
+	var (
+		addrs []string
+		ttl   = r.ttl
+		err   error
+	)
+	if ttlResolver, ok := r.resolver.(TTLResolver); ok {
+		addrs, ttl, err = ttlResolver.LookupHostTTL(ctx, host)
+	} else {
+		addrs, err = r.resolver.LookupHost(ctx, host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		r.mu.Lock()
+		r.cache[host] = &resolverCacheItem{
+			addrs:    addrs,
+			expireAt: now.Add(ttl),
+		}
+		r.mu.Unlock()
+	}
+	return addrs, nil
+}
+
+// Clear removes all the cached lookup results.
+func (r *CachingResolver) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = make(map[string]*resolverCacheItem)
+}
+
+// NewStaticResolver creates and returns a StaticResolver with `hosts` mapping the host names to addresses,
+// which falls back to `fallback` for other hosts, or net.DefaultResolver if `fallback` is nil.
+func NewStaticResolver(hosts map[string][]string, fallback Resolver) *StaticResolver {
+	if fallback == nil {
+		fallback = net.DefaultResolver
+	}
+	r := &StaticResolver{
+		hosts:    make(map[string][]string, len(hosts)),
+		fallback: fallback,
+	}
+	for host, addrs := range hosts {
+		r.hosts[strings.ToLower(host)] = addrs
+	}
+	return r
+}
+
+// LookupHost looks up the given host, which uses the static addresses if the host is overridden.
+func (r *StaticResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[strings.ToLower(host)]; ok {
+		return addrs, nil
+	}
+	return r.fallback.LookupHost(ctx, host)
+}
+
+// Resolver is a chaining function,
+// which sets the resolver resolving hosts for next request.
+func (c *Client) Resolver(resolver Resolver) *Client {
+	newClient := c.Clone()
+	if err := newClient.SetResolver(resolver); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	return newClient
+}
+
+// SetResolver sets the resolver resolving hosts of the client, which replaces the DialContext of the
+// underlying Transport, so it cannot be used with socks5 proxy. The addresses returned by resolver are
+// dialed in order until one succeeds.
+//
+// The lookup duration is recorded in the DNS hooks of httptrace, like the metrics and tracing of client.
+func (c *Client) SetResolver(resolver Resolver) error {
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		return gerror.New(`cannot set resolver for custom Transport of the client`)
+	}
+	// It clones the Transport as it might be shared with other clients.
+	transport = transport.Clone()
+	dialer := &net.Dialer{}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		return dialWithResolver(ctx, dialer, resolver, network, address)
+	}
+	c.Transport = transport
+	return nil
+}
+
+// dialWithResolver dials `address` whose host is resolved by `resolver`.
+func dialWithResolver(
+	ctx context.Context, dialer *net.Dialer, resolver Resolver, network, address string,
+) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `invalid address "%s"`, address)
+	}
+	if net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, network, address)
+	}
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.DNSStart != nil {
+		trace.DNSStart(httptrace.DNSStartInfo{Host: host})
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err == nil && len(addrs) == 0 {
+		err = gerror.NewCodef(gcode.CodeOperationFailed, `no address found for host "%s"`, host)
+	}
+	if trace != nil && trace.DNSDone != nil {
+		info := httptrace.DNSDoneInfo{Err: err}
+		for _, addr := range addrs {
+			if ip := net.ParseIP(addr); ip != nil {
+				info.Addrs = append(info.Addrs, net.IPAddr{IP: ip})
+			}
+		}
+		trace.DNSDone(info)
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `lookup host "%s" failed`, host)
+	}
+	var conn net.Conn
+	for _, addr := range addrs {
+		if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(addr, port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
@@ -20,6 +20,7 @@ type Response struct {
 	request        *http.Request     // Request is the underlying http.Request object of certain request.
 	requestBody    []byte            // The body bytes of certain request, only available in Dump feature.
 	cookies        map[string]string // Response cookies, which are only parsed once.
+	codec          ContentCodec      // Content codec of client, which unmarshals the response content.
 }
 
 // initCookie initializes the cookie map attribute of Response.
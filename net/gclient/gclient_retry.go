@@ -0,0 +1,225 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// maxRetryInterval is the max interval computed by RetryPolicy.Backoff.
+const maxRetryInterval = float64(1 << 61)
+
+const (
+	httpHeaderRetryAfter         = "Retry-After"
+	httpHeaderRateLimit          = "RateLimit"
+	httpHeaderRateLimitReset     = "RateLimit-Reset"
+	httpHeaderRateLimitRemaining = "RateLimit-Remaining"
+)
+
+// RetryPredicate checks whether the request should be retried with its response and error.
+// Note that the `resp` might be nil if `err` is not nil.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// RetryPolicy is the policy for retrying the failed requests.
+type RetryPolicy struct {
+	// MaxRetries is the max retry count, no retry if <= 0.
+	MaxRetries int
+
+	// InitialInterval is the interval before the first retry.
+	InitialInterval time.Duration
+
+	// MaxInterval is the max interval between retries, no limit if <= 0.
+	MaxInterval time.Duration
+
+	// Multiplier multiplies the interval for each retry, like 2 for exponential backoff
+	// doubling the interval. The interval is fixed if it is <= 1.
+	Multiplier float64
+
+	// Jitter randomizes the interval in [interval*(1-Jitter), interval*(1+Jitter)], which is in range [0, 1].
+	// It avoids the retries of many clients happening at the same time.
+	Jitter float64
+
+	// MaxElapsedTime is the max elapsed time of all attempts, no limit if <= 0.
+	// It stops retrying if the elapsed time would exceed it after the next interval.
+	MaxElapsedTime time.Duration
+
+	// RetryStatusCodes are the response status codes which are retried, like 502, 503 and 504.
+	RetryStatusCodes []int
+
+	// RetryIf checks whether the request should be retried, which replaces the default predicate
+	// if given. The default predicate retries the requests failing with error and the responses
+	// with status code in RetryStatusCodes.
+	RetryIf RetryPredicate
+
+	// IgnoreRetryAfter disables the waiting for headers "Retry-After" and "RateLimit-Reset" of response.
+	// In default, the interval before retry is prolonged to the waiting time required by these headers,
+	// like the responses of status 429 and 503.
+	IgnoreRetryAfter bool
+
+	// MaxRetryAfter is the max waiting time required by response headers, no limit if <= 0.
+	// It stops retrying if the server requires waiting longer than it.
+	MaxRetryAfter time.Duration
+
+	// OnRetryWait is called with the interval before each retry, which starts from 1.
+	// Note that the `resp` might be nil if `err` is not nil.
+	OnRetryWait func(ctx context.Context, retry int, interval time.Duration, resp *http.Response, err error)
+}
+
+// RetryPolicy is a chaining function,
+// which sets the retry policy for next request.
+func (c *Client) RetryPolicy(policy RetryPolicy) *Client {
+	newClient := c.Clone()
+	newClient.SetRetryPolicy(policy)
+	return newClient
+}
+
+// SetRetryPolicy sets the retry policy of the client, which replaces the retry count and interval
+// set by SetRetry.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
+
+// Backoff returns the interval before the `retry`th retry, which starts from 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.InitialInterval <= 0 {
+		return 0
+	}
+	interval := float64(p.InitialInterval)
+	if p.Multiplier > 1 {
+		interval *= math.Pow(p.Multiplier, float64(retry-1))
+	}
+	if p.MaxInterval > 0 && interval > float64(p.MaxInterval) {
+		interval = float64(p.MaxInterval)
+	}
+	// It avoids overflow of time.Duration for large retry count.
+	if interval > maxRetryInterval {
+		interval = maxRetryInterval
+	}
+	if p.Jitter > 0 {
+		delta := interval * math.Min(p.Jitter, 1)
+		return grand.D(time.Duration(interval-delta), time.Duration(interval+delta))
+	}
+	return time.Duration(interval)
+}
+
+// retryAfter returns the waiting time before retry required by the headers of `resp`, which supports
+// "Retry-After" in seconds or HTTP-date, and "RateLimit-Reset" or "RateLimit" of the RateLimit header fields
+// for HTTP. It returns false if no waiting is required.
+func (p RetryPolicy) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if p.IgnoreRetryAfter || resp == nil {
+		return 0, false
+	}
+	if value := strings.TrimSpace(resp.Header.Get(httpHeaderRetryAfter)); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parseRetryAfterSeconds(seconds)
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			if wait := time.Until(date); wait > 0 {
+				return wait, true
+			}
+			return 0, false
+		}
+	}
+	// The rate limit reset is used only if the quota is exhausted.
+	exhausted := resp.StatusCode == http.StatusTooManyRequests ||
+		strings.TrimSpace(resp.Header.Get(httpHeaderRateLimitRemaining)) == "0" ||
+		parseRateLimitParam(resp.Header.Get(httpHeaderRateLimit), "remaining") == "0"
+	if !exhausted {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get(httpHeaderRateLimitReset))
+	if value == "" {
+		value = parseRateLimitParam(resp.Header.Get(httpHeaderRateLimit), "reset")
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return parseRetryAfterSeconds(seconds)
+	}
+	return 0, false
+}
+
+// parseRetryAfterSeconds converts the waiting `seconds` to time.Duration.
+func parseRetryAfterSeconds(seconds int64) (time.Duration, bool) {
+	if seconds < 0 {
+		return 0, false
+	}
+	// It avoids overflow of time.Duration.
+	if float64(seconds)*float64(time.Second) > maxRetryInterval {
+		return time.Duration(maxRetryInterval), true
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// parseRateLimitParam returns the parameter `name` of header "RateLimit" like "limit=100, remaining=0, reset=50".
+func parseRateLimitParam(value, name string) string {
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		k, v, found := strings.Cut(strings.TrimSpace(item), "=")
+		if found && strings.EqualFold(strings.TrimSpace(k), name) {
+			return strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+	return ""
+}
+
+// recordRetryWait records the interval before retry in metrics and calls the callback.
+func (p RetryPolicy) recordRetryWait(
+	req *http.Request, retry int, interval time.Duration, resp *http.Response, err error,
+) {
+	if gmetric.IsEnabled() {
+		option := metricManager.GetMetricOptionForHistogram(req)
+		option.Exemplar = gmetric.ExemplarFromContext(req.Context())
+		metricManager.HttpClientRetryWaitDuration.Record(float64(interval.Milliseconds()), option)
+	}
+	if p.OnRetryWait != nil {
+		p.OnRetryWait(req.Context(), retry, interval, resp, err)
+	}
+}
+
+// shouldRetry checks whether the request should be retried with its response and error.
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	// It never retries if the request is canceled or timeout.
+	if ctx.Err() != nil {
+		return false
+	}
+	if p.RetryIf != nil {
+		return p.RetryIf(resp, err)
+	}
+	if err != nil {
+		return true
+	}
+	if resp != nil {
+		for _, statusCode := range p.RetryStatusCodes {
+			if resp.StatusCode == statusCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// waitForRetry waits `interval` before retrying, which returns false if `ctx` is done before that.
+func waitForRetry(ctx context.Context, interval time.Duration) bool {
+	if interval <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
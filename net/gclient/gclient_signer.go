@@ -0,0 +1,404 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// RequestSigner signs the request, which usually sets the signature in header "Authorization".
+// The `body` is nil if the request body is streaming, like the multipart uploading.
+type RequestSigner interface {
+	Sign(r *http.Request, body []byte, signTime time.Time) error
+}
+
+// AWSV4Signer signs the request using AWS Signature Version 4.
+type AWSV4Signer struct {
+	AccessKeyId     string // Access key id of credentials.
+	SecretAccessKey string // Secret access key of credentials.
+	SessionToken    string // Session token of temporary credentials, optional.
+	Region          string // Region of service, like "us-east-1".
+	Service         string // Service name, like "s3".
+}
+
+// HMACSigner signs the canonical request using HMAC-SHA256, which is similar to AWS Signature Version 4
+// with a simpler key derivation, and is commonly used for signing requests between internal services.
+//
+// It sets header "X-Timestamp" as the unix timestamp of signing, and header "Authorization" like:
+// HMAC-SHA256 KeyId=key, SignedHeaders=host;x-timestamp, Signature=hex.
+// The string to sign is: "HMAC-SHA256\n" + timestamp + "\n" + hex(sha256(canonical request)),
+// in which the canonical request is in the same format as AWS Signature Version 4.
+type HMACSigner struct {
+	KeyId         string   // Id of the key, which is sent for the server to find the secret.
+	Secret        []byte   // Secret key of HMAC.
+	SignedHeaders []string // Headers signed besides "Host" and "X-Timestamp", like "Content-Type".
+}
+
+// OAuth1Signer signs the request using OAuth 1.0 HMAC-SHA1 signature method, see RFC 5849.
+type OAuth1Signer struct {
+	ConsumerKey    string        // Consumer key (client identifier).
+	ConsumerSecret string        // Consumer secret (client shared-secret).
+	Token          string        // Token of resource owner, optional.
+	TokenSecret    string        // Token secret of resource owner, optional.
+	Nonce          func() string // Nonce generator, which generates random string in default.
+}
+
+const (
+	httpHeaderAuthorization      = "Authorization"
+	awsV4Algorithm               = "AWS4-HMAC-SHA256"
+	awsV4HeaderDate              = "X-Amz-Date"
+	awsV4HeaderSecurityToken     = "X-Amz-Security-Token"
+	awsV4HeaderContentSha256     = "X-Amz-Content-Sha256"
+	awsV4TimeFormat              = "20060102T150405Z"
+	awsV4DateFormat              = "20060102"
+	hmacSignerAlgorithm          = "HMAC-SHA256"
+	hmacSignerHeaderTimestamp    = "X-Timestamp"
+	oauth1SignatureMethod        = "HMAC-SHA1"
+	signerUnsignedPayload        = "UNSIGNED-PAYLOAD"
+	signerUnreservedCharacters   = "-_.~"
+	oauth1DefaultNonceByteLength = 32
+
+	// signerCtxKey is the context key for the signers of request, which are used for re-signing the hedged
+	// requests sent to the alternate hosts.
+	signerCtxKey gctx.StrKey = `RequestSigners`
+)
+
+// SignerMiddleware returns a client middleware that signs each request using `signer`.
+// The signature is computed once before sending, which is reused by the retries. The hedged requests
+// sent to the alternate hosts of HedgePolicy are signed again, as the signature covers the host.
+func SignerMiddleware(signer RequestSigner) HandlerFunc {
+	return func(c *Client, r *http.Request) (*Response, error) {
+		var body []byte
+		if r.Body != nil && !isStreamingBody(r.Body) {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				return nil, gerror.Wrap(err, `read request body failed`)
+			}
+			_ = r.Body.Close()
+			r.Body = utils.NewReadCloser(body, false)
+		}
+		if body == nil && !isStreamingBody(r.Body) {
+			body = []byte{}
+		}
+		if err := signer.Sign(r, body, time.Now()); err != nil {
+			return nil, err
+		}
+		signers, _ := r.Context().Value(signerCtxKey).([]RequestSigner)
+		signers = append(signers[:len(signers):len(signers)], signer)
+		return c.Next(r.WithContext(context.WithValue(r.Context(), signerCtxKey, signers)))
+	}
+}
+
+// SignerAWSV4 returns a client middleware that signs each request using AWS Signature Version 4,
+// which is used like: client.Use(gclient.SignerAWSV4(gclient.AWSV4Signer{...})).
+func SignerAWSV4(signer AWSV4Signer) HandlerFunc {
+	return SignerMiddleware(&signer)
+}
+
+// SignerHMAC returns a client middleware that signs each request using HMACSigner.
+func SignerHMAC(signer HMACSigner) HandlerFunc {
+	return SignerMiddleware(&signer)
+}
+
+// SignerOAuth1 returns a client middleware that signs each request using OAuth 1.0.
+func SignerOAuth1(signer OAuth1Signer) HandlerFunc {
+	return SignerMiddleware(&signer)
+}
+
+// Sign signs the request using AWS Signature Version 4, which sets header "X-Amz-Date" and "Authorization".
+// The signed headers are "Host", "Content-Type" and the headers with prefix "X-Amz-".
+func (s *AWSV4Signer) Sign(r *http.Request, body []byte, signTime time.Time) error {
+	var (
+		utcTime     = signTime.UTC()
+		date        = utcTime.Format(awsV4DateFormat)
+		scope       = strings.Join([]string{date, s.Region, s.Service, "aws4_request"}, "/")
+		payloadHash = signerPayloadHash(body)
+	)
+	r.Header.Set(awsV4HeaderDate, utcTime.Format(awsV4TimeFormat))
+	if s.SessionToken != "" {
+		r.Header.Set(awsV4HeaderSecurityToken, s.SessionToken)
+	}
+	if s.Service == "s3" {
+		r.Header.Set(awsV4HeaderContentSha256, payloadHash)
+	}
+	var headerNames []string
+	for name := range r.Header {
+		lowerName := strings.ToLower(name)
+		if lowerName == "content-type" || strings.HasPrefix(lowerName, "x-amz-") {
+			headerNames = append(headerNames, lowerName)
+		}
+	}
+	var (
+		// The path of S3 is neither normalized nor encoded twice.
+		canonicalRequest, signedHeaders = signerCanonicalRequest(r, headerNames, payloadHash, s.Service != "s3")
+		stringToSign                    = strings.Join([]string{
+			awsV4Algorithm, utcTime.Format(awsV4TimeFormat), scope, signerSha256Hex([]byte(canonicalRequest)),
+		}, "\n")
+		signingKey = signerHmacSha256([]byte("AWS4"+s.SecretAccessKey), []byte(date))
+	)
+	for _, v := range []string{s.Region, s.Service, "aws4_request"} {
+		signingKey = signerHmacSha256(signingKey, []byte(v))
+	}
+	r.Header.Set(httpHeaderAuthorization, fmt.Sprintf(
+		`%s Credential=%s/%s, SignedHeaders=%s, Signature=%s`,
+		awsV4Algorithm, s.AccessKeyId, scope, signedHeaders,
+		hex.EncodeToString(signerHmacSha256(signingKey, []byte(stringToSign))),
+	))
+	return nil
+}
+
+// Sign signs the request using HMAC-SHA256, which sets header "X-Timestamp" and "Authorization".
+func (s *HMACSigner) Sign(r *http.Request, body []byte, signTime time.Time) error {
+	if len(s.Secret) == 0 {
+		return gerror.New(`secret of HMAC signer is empty`)
+	}
+	timestamp := strconv.FormatInt(signTime.Unix(), 10)
+	r.Header.Set(hmacSignerHeaderTimestamp, timestamp)
+	headerNames := []string{strings.ToLower(hmacSignerHeaderTimestamp)}
+	for _, name := range s.SignedHeaders {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	var (
+		canonicalRequest, signedHeaders = signerCanonicalRequest(r, headerNames, signerPayloadHash(body), false)
+		stringToSign                    = strings.Join([]string{
+			hmacSignerAlgorithm, timestamp, signerSha256Hex([]byte(canonicalRequest)),
+		}, "\n")
+	)
+	r.Header.Set(httpHeaderAuthorization, fmt.Sprintf(
+		`%s KeyId=%s, SignedHeaders=%s, Signature=%s`,
+		hmacSignerAlgorithm, s.KeyId, signedHeaders,
+		hex.EncodeToString(signerHmacSha256(s.Secret, []byte(stringToSign))),
+	))
+	return nil
+}
+
+// Sign signs the request using OAuth 1.0 HMAC-SHA1 signature method, which sets header "Authorization".
+// The parameters of query and the form body are also signed.
+func (s *OAuth1Signer) Sign(r *http.Request, body []byte, signTime time.Time) error {
+	var nonce string
+	if s.Nonce != nil {
+		nonce = s.Nonce()
+	} else {
+		nonce = hex.EncodeToString(grand.B(oauth1DefaultNonceByteLength))
+	}
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     s.ConsumerKey,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": oauth1SignatureMethod,
+		"oauth_timestamp":        strconv.FormatInt(signTime.Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if s.Token != "" {
+		oauthParams["oauth_token"] = s.Token
+	}
+	// The parameters are encoded before sorting.
+	var params [][2]string
+	for k, v := range oauthParams {
+		params = append(params, [2]string{signerURIEncode(k, true), signerURIEncode(v, true)})
+	}
+	for k, values := range r.URL.Query() {
+		for _, v := range values {
+			params = append(params, [2]string{signerURIEncode(k, true), signerURIEncode(v, true)})
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get(httpHeaderContentType))
+	if mediaType == httpHeaderContentTypeForm && len(body) > 0 {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return gerror.Wrap(err, `parse form body failed`)
+		}
+		for k, values := range form {
+			for _, v := range values {
+				params = append(params, [2]string{signerURIEncode(k, true), signerURIEncode(v, true)})
+			}
+		}
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i][0] != params[j][0] {
+			return params[i][0] < params[j][0]
+		}
+		return params[i][1] < params[j][1]
+	})
+	var pairs = make([]string, 0, len(params))
+	for _, param := range params {
+		pairs = append(pairs, param[0]+"="+param[1])
+	}
+	var (
+		baseUrl = fmt.Sprintf(
+			`%s://%s%s`, strings.ToLower(r.URL.Scheme), oauth1Host(r), r.URL.EscapedPath(),
+		)
+		baseString = strings.Join([]string{
+			strings.ToUpper(r.Method),
+			signerURIEncode(baseUrl, true),
+			signerURIEncode(strings.Join(pairs, "&"), true),
+		}, "&")
+		key = signerURIEncode(s.ConsumerSecret, true) + "&" + signerURIEncode(s.TokenSecret, true)
+		mac = hmac.New(sha1.New, []byte(key))
+	)
+	mac.Write([]byte(baseString))
+	oauthParams["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	var names = make([]string, 0, len(oauthParams))
+	for k := range oauthParams {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var authParams = make([]string, 0, len(names))
+	for _, name := range names {
+		authParams = append(authParams, fmt.Sprintf(`%s="%s"`, name, signerURIEncode(oauthParams[name], true)))
+	}
+	r.Header.Set(httpHeaderAuthorization, "OAuth "+strings.Join(authParams, ", "))
+	return nil
+}
+
+// signerCanonicalRequest builds and returns the canonical request in format of AWS Signature Version 4,
+// and the signed headers joined with ";". The header "Host" is always signed. The canonical URI is
+// normalized by removing the "." and ".." segments and encoded twice if `normalizePath` is true.
+func signerCanonicalRequest(
+	r *http.Request, headerNames []string, payloadHash string, normalizePath bool,
+) (canonicalRequest, signedHeaders string) {
+	var (
+		host          = r.Host
+		headerMap     = make(map[string]string)
+		signedNames   []string
+		headerBuilder strings.Builder
+	)
+	if host == "" {
+		host = r.URL.Host
+	}
+	headerMap["host"] = host
+	for _, name := range headerNames {
+		values := r.Header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		// The values are canonicalized in a copy, as Header.Values returns the values of request.
+		canonicalValues := make([]string, len(values))
+		for i, v := range values {
+			canonicalValues[i] = strings.Join(strings.Fields(v), " ")
+		}
+		headerMap[name] = strings.Join(canonicalValues, ",")
+	}
+	for name := range headerMap {
+		signedNames = append(signedNames, name)
+	}
+	sort.Strings(signedNames)
+	for _, name := range signedNames {
+		headerBuilder.WriteString(name + ":" + headerMap[name] + "\n")
+	}
+	signedHeaders = strings.Join(signedNames, ";")
+
+	// Canonical URI.
+	uri := r.URL.Path
+	if uri == "" {
+		uri = "/"
+	}
+	if normalizePath {
+		uri = signerNormalizePath(uri)
+	}
+	uri = signerURIEncode(uri, false)
+	if normalizePath {
+		uri = signerURIEncode(uri, false)
+	}
+	// Canonical query string.
+	var queries []string
+	for k, values := range r.URL.Query() {
+		for _, v := range values {
+			queries = append(queries, signerURIEncode(k, true)+"="+signerURIEncode(v, true))
+		}
+	}
+	sort.Strings(queries)
+
+	canonicalRequest = strings.Join([]string{
+		r.Method,
+		uri,
+		strings.Join(queries, "&"),
+		headerBuilder.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	return
+}
+
+// signerNormalizePath removes the "." and ".." segments and the duplicated slashes of `uriPath`,
+// in which the trailing slash is kept.
+func signerNormalizePath(uriPath string) string {
+	normalized := path.Clean("/" + uriPath)
+	if strings.HasSuffix(uriPath, "/") && normalized != "/" {
+		normalized += "/"
+	}
+	return normalized
+}
+
+// signerURIEncode encodes `s` in which only the unreserved characters of RFC 3986 are not encoded.
+// The character '/' is not encoded if `encodeSlash` is false.
+func signerURIEncode(s string, encodeSlash bool) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			strings.IndexByte(signerUnreservedCharacters, c) >= 0 || (c == '/' && !encodeSlash) {
+			builder.WriteByte(c)
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%%%02X", c))
+	}
+	return builder.String()
+}
+
+// signerPayloadHash returns the hex encoded SHA256 hash of `body`, or "UNSIGNED-PAYLOAD" if `body` is nil.
+func signerPayloadHash(body []byte) string {
+	if body == nil {
+		return signerUnsignedPayload
+	}
+	return signerSha256Hex(body)
+}
+
+// signerSha256Hex returns the hex encoded SHA256 hash of `data`.
+func signerSha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// signerHmacSha256 returns the HMAC-SHA256 of `data` using `key`.
+func signerHmacSha256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// oauth1Host returns the lowercase host of request without the default port.
+func oauth1Host(r *http.Request) string {
+	host := strings.ToLower(r.URL.Host)
+	switch {
+	case r.URL.Scheme == "http" && strings.HasSuffix(host, ":80"):
+		return strings.TrimSuffix(host, ":80")
+	case r.URL.Scheme == "https" && strings.HasSuffix(host, ":443"):
+		return strings.TrimSuffix(host, ":443")
+	}
+	return host
+}
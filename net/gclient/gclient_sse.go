@@ -0,0 +1,179 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// SSEHandler handles the event of Server-Sent Events subscription,
+// which stops the subscription if it returns error.
+type SSEHandler func(ctx context.Context, event *StreamEvent) error
+
+// SSEOption is the option for Server-Sent Events subscription.
+type SSEOption struct {
+	// Method is the HTTP method of subscription request, which is GET in default.
+	Method string
+
+	// Data is the parameters of subscription request, like the prompt for LLM streaming APIs.
+	Data interface{}
+
+	// LastEventId is the initial last event id sent in header "Last-Event-ID" for resuming.
+	LastEventId string
+
+	// MaxReconnects is the max count of consecutive reconnections, in which the count is reset
+	// once an event is received. It is unlimited if it is 0, and no reconnection if it is < 0.
+	MaxReconnects int
+
+	// Backoff is the backoff policy of reconnections, in which only the intervals are used.
+	// It is 1 second with exponential backoff up to 30 seconds in default,
+	// and the initial interval is replaced by the reconnection time specified by server.
+	Backoff RetryPolicy
+}
+
+const (
+	sseHeaderAccept       = "Accept"
+	sseHeaderCacheControl = "Cache-Control"
+	sseHeaderLastEventId  = "Last-Event-ID"
+	sseContentType        = "text/event-stream"
+)
+
+var (
+	defaultSSEBackoff = RetryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+)
+
+// SSE subscribes the Server-Sent Events of `url` and calls `handler` for each event, which blocks until
+// the `ctx` is done, the `handler` returns error, or the subscription fails without reconnection.
+// It reconnects automatically with backoff when the connection is broken, resuming from the last event id.
+//
+// It returns the error of `ctx` if it is done, the error returned by `handler`, or the error of failed
+// subscription. It returns nil if the server responds with status 204 No Content, which means stop.
+// Note that the timeout of client is not applied to the subscription, as the stream is long-lived.
+func (c *Client) SSE(ctx context.Context, url string, handler SSEHandler, option ...SSEOption) error {
+	var opt SSEOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Method == "" {
+		opt.Method = http.MethodGet
+	}
+	if opt.Backoff.InitialInterval <= 0 {
+		opt.Backoff = defaultSSEBackoff
+	}
+	var (
+		client      = c.Clone().Timeout(0)
+		lastEventId = opt.LastEventId
+		reconnects  int
+	)
+	client.SetHeader(sseHeaderAccept, sseContentType)
+	client.SetHeader(sseHeaderCacheControl, "no-cache")
+	for {
+		var (
+			received bool
+			err      = client.subscribeSSE(ctx, url, opt, &lastEventId, func(ctx context.Context, event *StreamEvent) error {
+				received = true
+				if event.Retry > 0 {
+					opt.Backoff.InitialInterval = event.Retry
+				}
+				if event.Data == "" {
+					// The event only updates the reconnection time or last event id.
+					return nil
+				}
+				return handler(ctx, event)
+			})
+		)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		reconnectErr, ok := err.(sseReconnectError)
+		if !ok {
+			return err
+		}
+		err = reconnectErr.error
+		if received {
+			reconnects = 0
+		}
+		if opt.MaxReconnects < 0 || (opt.MaxReconnects > 0 && reconnects >= opt.MaxReconnects) {
+			return err
+		}
+		reconnects++
+		intlog.Printf(ctx, `SSE subscription of "%s" broken, reconnecting %d: %+v`, url, reconnects, err)
+		if !waitForRetry(ctx, opt.Backoff.Backoff(reconnects)) {
+			return ctx.Err()
+		}
+	}
+}
+
+// sseReconnectError is the error of broken subscription which can be reconnected.
+type sseReconnectError struct {
+	error
+}
+
+// subscribeSSE sends the subscription request and handles its events until the stream ends.
+// It returns nil only if the server responds with status 204 No Content.
+func (c *Client) subscribeSSE(
+	ctx context.Context, url string, opt SSEOption, lastEventId *string, handler SSEHandler,
+) error {
+	client := c
+	if *lastEventId != "" {
+		client = c.Header(map[string]string{
+			sseHeaderLastEventId: *lastEventId,
+		})
+	}
+	var data []interface{}
+	if opt.Data != nil {
+		data = append(data, opt.Data)
+	}
+	stream, err := client.DoStream(ctx, opt.Method, url, data...)
+	if err != nil {
+		return sseReconnectError{err}
+	}
+	defer stream.Close()
+
+	switch {
+	case stream.StatusCode == http.StatusNoContent:
+		return nil
+
+	case stream.StatusCode >= http.StatusInternalServerError:
+		return sseReconnectError{gerror.NewCodef(
+			gcode.CodeOperationFailed, `SSE subscription failed with status: %s`, stream.Status,
+		)}
+
+	case stream.StatusCode != http.StatusOK:
+		return gerror.NewCodef(gcode.CodeOperationFailed, `SSE subscription failed with status: %s`, stream.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(stream.Header.Get(httpHeaderContentType)); mediaType != sseContentType {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `invalid SSE Content-Type "%s"`, stream.Header.Get(httpHeaderContentType),
+		)
+	}
+	for {
+		event, err := stream.ReadEvent()
+		if err != nil {
+			// The stream ending normally is also reconnected, as the events are endless.
+			return sseReconnectError{gerror.Wrap(err, `read SSE event failed`)}
+		}
+		if event.Id != "" {
+			*lastEventId = event.Id
+		}
+		if err = handler(ctx, event); err != nil {
+			return err
+		}
+	}
+}
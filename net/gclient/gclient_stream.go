@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// StreamResponse is the response whose body is consumed incrementally, which implements io.Reader.
+// The body is read from the connection only when it is consumed, so that the large or endless
+// responses are not buffered fully in memory, and the slow consumer applies backpressure to the server.
+//
+// Note that the timeout of client also limits the duration of reading body, which should be disabled
+// for long-lived streams like Server-Sent Events, and the context of request can be used for canceling.
+type StreamResponse struct {
+	*Response
+	reader *bufio.Reader
+}
+
+// StreamEvent is the event of Server-Sent Events.
+type StreamEvent struct {
+	Id    string        // Id of event, empty if not specified.
+	Event string        // Type of event, which is "message" if not specified.
+	Data  string        // Data of event, in which multiple data lines are joined with "\n".
+	Retry time.Duration // Reconnection time specified by server, 0 if not specified.
+}
+
+const (
+	streamDefaultEventType = "message"
+)
+
+// GetStream sends GET request and returns the streaming response.
+// The returned response MUST be closed after use.
+func (c *Client) GetStream(ctx context.Context, url string, data ...interface{}) (*StreamResponse, error) {
+	return c.DoStream(ctx, http.MethodGet, url, data...)
+}
+
+// PostStream sends POST request and returns the streaming response.
+// The returned response MUST be closed after use.
+func (c *Client) PostStream(ctx context.Context, url string, data ...interface{}) (*StreamResponse, error) {
+	return c.DoStream(ctx, http.MethodPost, url, data...)
+}
+
+// DoStream sends request with given HTTP method and data and returns the streaming response.
+// The returned response MUST be closed after use.
+func (c *Client) DoStream(ctx context.Context, method, url string, data ...interface{}) (*StreamResponse, error) {
+	resp, err := c.DoRequest(ctx, method, url, data...)
+	if err != nil {
+		_ = resp.Close()
+		return nil, err
+	}
+	return &StreamResponse{
+		Response: resp,
+		reader:   bufio.NewReader(resp.Body),
+	}, nil
+}
+
+// Read reads the response body into `p`, which implements io.Reader.
+func (r *StreamResponse) Read(p []byte) (n int, err error) {
+	return r.reader.Read(p)
+}
+
+// ReadLine reads and returns the next line of response body without the line ending "\n" or "\r\n".
+// It returns io.EOF if there's no more content.
+func (r *StreamResponse) ReadLine() ([]byte, error) {
+	line, err := r.reader.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return nil, err
+	}
+	line = bytes.TrimSuffix(line, []byte{'\n'})
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	return line, nil
+}
+
+// ReadJSON reads the next line of line-delimited JSON response body and decodes it into `pointer`,
+// in which the empty lines are skipped. It returns io.EOF if there's no more content.
+func (r *StreamResponse) ReadJSON(pointer interface{}) error {
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err = json.Unmarshal(line, pointer); err != nil {
+			return gerror.Wrapf(err, `decode JSON line failed: %s`, line)
+		}
+		return nil
+	}
+}
+
+// ReadEvent reads and returns the next event of Server-Sent Events response body, in which the comments
+// and the unknown fields are ignored. It returns io.EOF if there's no more event.
+func (r *StreamResponse) ReadEvent() (*StreamEvent, error) {
+	var (
+		event     = &StreamEvent{}
+		hasData   bool
+		dataLines []string
+	)
+	for {
+		line, err := r.ReadLine()
+		if err == io.EOF && hasData {
+			// The last event without ending empty line.
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 {
+			if !hasData {
+				continue
+			}
+			break
+		}
+		if line[0] == ':' {
+			// Comment line, which is commonly used for keeping alive.
+			continue
+		}
+		field, value, _ := strings.Cut(string(line), ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.Id = value
+		case "event":
+			event.Event = value
+		case "data":
+			dataLines = append(dataLines, value)
+		case "retry":
+			if retry, parseErr := strconv.Atoi(value); parseErr == nil {
+				event.Retry = time.Duration(retry) * time.Millisecond
+			}
+		default:
+			continue
+		}
+		hasData = true
+	}
+	if event.Event == "" {
+		event.Event = streamDefaultEventType
+	}
+	event.Data = strings.Join(dataLines, "\n")
+	return event, nil
+}
@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Token is the OAuth2-style access token injected into requests.
+type Token struct {
+	AccessToken string    // Access token sent in header "Authorization".
+	TokenType   string    // Type of token, which is "Bearer" in default.
+	Expiry      time.Time // Expiration time of token, which never expires if zero.
+}
+
+// TokenSource supplies the tokens for client, like the token endpoint of OAuth2 server.
+// It should fetch a new token for each call rather than returning a cached one, as the tokens are
+// cached by client and refreshed only when they expire or are rejected by server.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc is the function adapter of TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// tokenManager caches the token of TokenSource and refreshes it in single flight.
+type tokenManager struct {
+	mu         sync.Mutex
+	source     TokenSource
+	token      *Token            // Current cached token.
+	refreshing *tokenRefreshCall // In-flight refreshing call, nil if not refreshing.
+}
+
+// tokenRefreshCall is the refreshing call shared by concurrent requests.
+type tokenRefreshCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+const (
+	defaultTokenType = "Bearer"
+	// tokenExpiryDelta is the duration before expiry that the token is considered expired,
+	// so that the token does not expire during requesting.
+	tokenExpiryDelta = 10 * time.Second
+)
+
+// Token implements TokenSource, which calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// TokenSource is a chaining function,
+// which sets the token source for next request.
+func (c *Client) TokenSource(source TokenSource) *Client {
+	newClient := c.Clone()
+	newClient.SetTokenSource(source)
+	return newClient
+}
+
+// SetTokenSource sets the token source of the client, which injects the token into header "Authorization"
+// like "Bearer token" for each request. The token is cached until expiry and is refreshed in single flight,
+// so that concurrent requests do not stampede the token source. If the server responds with status 401,
+// the token is refreshed and the request is sent once again with the new token.
+//
+// It removes the token source if `source` is nil.
+// Note that the clients cloned from the client share the cached token.
+func (c *Client) SetTokenSource(source TokenSource) *Client {
+	if source == nil {
+		c.tokenManager = nil
+		return c
+	}
+	c.tokenManager = &tokenManager{
+		source: source,
+	}
+	return c
+}
+
+// setAuthorization sets the header "Authorization" of `req` using the valid token,
+// and returns the token used.
+func (m *tokenManager) setAuthorization(req *http.Request) (*Token, error) {
+	m.mu.Lock()
+	token := m.token
+	m.mu.Unlock()
+	if !token.isValid() {
+		var err error
+		if token, err = m.refresh(req.Context(), token); err != nil {
+			return nil, err
+		}
+	}
+	tokenType := token.TokenType
+	if tokenType == "" {
+		tokenType = defaultTokenType
+	}
+	req.Header.Set(httpHeaderAuthorization, tokenType+" "+token.AccessToken)
+	return token, nil
+}
+
+// refresh fetches a new token to replace the `stale` one, which returns the current token directly if it has
+// already been refreshed by other requests, or waits for the in-flight refreshing if any.
+func (m *tokenManager) refresh(ctx context.Context, stale *Token) (*Token, error) {
+	m.mu.Lock()
+	if m.token != stale && m.token.isValid() {
+		token := m.token
+		m.mu.Unlock()
+		return token, nil
+	}
+	if call := m.refreshing; call != nil {
+		m.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.token, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &tokenRefreshCall{
+		done: make(chan struct{}),
+	}
+	m.refreshing = call
+	m.mu.Unlock()
+
+	call.token, call.err = m.source.Token(ctx)
+	if call.err != nil {
+		call.token, call.err = nil, gerror.Wrap(call.err, `fetch token from token source failed`)
+	} else if call.token == nil {
+		call.err = gerror.New(`token source returns nil token`)
+	}
+	m.mu.Lock()
+	if call.err == nil {
+		m.token = call.token
+	}
+	m.refreshing = nil
+	m.mu.Unlock()
+	close(call.done)
+	return call.token, call.err
+}
+
+// isValid checks whether the token is not nil and not expired.
+func (t *Token) isValid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"net/http/httptrace"
 	"net/textproto"
+	"sync"
 
+	"github.com/gogf/gf/v2/os/gmetric"
 	"github.com/gogf/gf/v2/os/gtime"
 )
 
@@ -19,10 +21,16 @@ type clientTracerMetrics struct {
 	*httptrace.ClientTrace
 	Request          *http.Request
 	ConnectStartTime *gtime.Time
+	DNSStartTime     *gtime.Time
+	GetConnTimes     []*gtime.Time // Start times of pending connection acquisitions, for redirects using the same tracer.
+	mu               sync.Mutex    // Protects GetConnTimes.
 }
 
-// newClientTracerMetrics creates and returns object of httptrace.ClientTrace.
-func newClientTracerMetrics(request *http.Request, baseClientTracer *httptrace.ClientTrace) *httptrace.ClientTrace {
+// newClientTracerMetrics creates and returns object of httptrace.ClientTrace,
+// and the function that should be called after the request done.
+func newClientTracerMetrics(
+	request *http.Request, baseClientTracer *httptrace.ClientTrace,
+) (clientTracer *httptrace.ClientTrace, done func()) {
 	c := &clientTracerMetrics{
 		Request:     request,
 		ClientTrace: baseClientTracer,
@@ -44,6 +52,21 @@ func newClientTracerMetrics(request *http.Request, baseClientTracer *httptrace.C
 		WroteHeaders:         c.WroteHeaders,
 		Wait100Continue:      c.Wait100Continue,
 		WroteRequest:         c.WroteRequest,
+	}, c.Done
+}
+
+// Done releases the pending connection acquisitions that fail without calling GotConn,
+// as there is no hook for failure to obtain a connection.
+func (ct *clientTracerMetrics) Done() {
+	ct.mu.Lock()
+	pending := len(ct.GetConnTimes)
+	ct.GetConnTimes = nil
+	ct.mu.Unlock()
+	for i := 0; i < pending; i++ {
+		metricManager.HttpClientConnectionPending.Dec(
+			ct.Request.Context(),
+			metricManager.GetMetricOptionForHistogram(ct.Request),
+		)
 	}
 }
 
@@ -52,6 +75,13 @@ func newClientTracerMetrics(request *http.Request, baseClientTracer *httptrace.C
 // "host:port" of the target or proxy. GetConn is called even
 // if there's already an idle cached connection available.
 func (ct *clientTracerMetrics) GetConn(hostPort string) {
+	ct.mu.Lock()
+	ct.GetConnTimes = append(ct.GetConnTimes, gtime.Now())
+	ct.mu.Unlock()
+	metricManager.HttpClientConnectionPending.Inc(
+		ct.Request.Context(),
+		metricManager.GetMetricOptionForHistogram(ct.Request),
+	)
 	ct.ClientTrace.GetConn(hostPort)
 }
 
@@ -60,6 +90,38 @@ func (ct *clientTracerMetrics) GetConn(hostPort string) {
 // connection; instead, use the error from
 // Transport.RoundTrip.
 func (ct *clientTracerMetrics) GotConn(info httptrace.GotConnInfo) {
+	var getConnTime *gtime.Time
+	ct.mu.Lock()
+	if len(ct.GetConnTimes) > 0 {
+		getConnTime = ct.GetConnTimes[0]
+		ct.GetConnTimes = ct.GetConnTimes[1:]
+	}
+	ct.mu.Unlock()
+	var (
+		ctx           = ct.Request.Context()
+		attrMap       = metricManager.GetMetricAttributeMap(ct.Request)
+		acquireOption = metricManager.GetMetricOptionForHistogramByMap(attrMap)
+	)
+	if getConnTime != nil {
+		var (
+			duration       = float64(gtime.Now().Sub(getConnTime).Milliseconds())
+			durationOption = metricManager.GetMetricOptionForHistogramByMap(attrMap)
+		)
+		durationOption.Exemplar = gmetric.ExemplarFromContext(ctx)
+		metricManager.HttpClientConnectionWaitDuration.Record(
+			duration,
+			durationOption,
+		)
+		metricManager.HttpClientConnectionPending.Dec(
+			ctx,
+			metricManager.GetMetricOptionForHistogramByMap(attrMap),
+		)
+	}
+	acquireOption.Attributes = append(
+		acquireOption.Attributes,
+		gmetric.NewAttribute(metricAttrKeyConnectionReused, info.Reused),
+	)
+	metricManager.HttpClientConnectionAcquired.Inc(ctx, acquireOption)
 	ct.ClientTrace.GotConn(info)
 }
 
@@ -97,11 +159,23 @@ func (ct *clientTracerMetrics) Got1xxResponse(code int, header textproto.MIMEHea
 
 // DNSStart is called when a DNS lookup begins.
 func (ct *clientTracerMetrics) DNSStart(info httptrace.DNSStartInfo) {
+	ct.DNSStartTime = gtime.Now()
 	ct.ClientTrace.DNSStart(info)
 }
 
 // DNSDone is called when a DNS lookup ends.
 func (ct *clientTracerMetrics) DNSDone(info httptrace.DNSDoneInfo) {
+	if ct.DNSStartTime != nil {
+		var (
+			duration       = float64(gtime.Now().Sub(ct.DNSStartTime).Milliseconds())
+			durationOption = metricManager.GetMetricOptionForHistogram(ct.Request)
+		)
+		durationOption.Exemplar = gmetric.ExemplarFromContext(ct.Request.Context())
+		metricManager.HttpClientDnsDuration.Record(
+			duration,
+			durationOption,
+		)
+	}
 	ct.ClientTrace.DNSDone(info)
 }
 
@@ -126,6 +200,7 @@ func (ct *clientTracerMetrics) ConnectDone(network, addr string, err error) {
 		duration       = float64(gtime.Now().Sub(ct.ConnectStartTime).Milliseconds())
 		durationOption = metricManager.GetMetricOptionForHistogram(ct.Request)
 	)
+	durationOption.Exemplar = gmetric.ExemplarFromContext(ct.Request.Context())
 	metricManager.HttpClientConnectionDuration.Record(
 		duration,
 		durationOption,
@@ -0,0 +1,307 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// UploadFile is the file uploaded by PostMultipart.
+type UploadFile struct {
+	FieldName  string                // Form field name of the file.
+	Path       string                // Local path of the file.
+	FileName   string                // File name sent to server, which is the base name of Path in default.
+	Offset     int64                 // Offset to resume the chunked uploading from, which is ignored if not chunked.
+	OnProgress UploadProgressHandler // Progress callback of the file, optional.
+}
+
+// UploadProgress is the uploading progress of a file.
+type UploadProgress struct {
+	FieldName string // Form field name of the file.
+	FileName  string // File name sent to server.
+	Uploaded  int64  // Uploaded bytes of the file, including the resumed offset.
+	Total     int64  // Total bytes of the file.
+}
+
+// UploadProgressHandler is called when the file content is sent.
+type UploadProgressHandler func(ctx context.Context, progress UploadProgress)
+
+// MultipartOption is the option for PostMultipart.
+type MultipartOption struct {
+	// Fields are the form fields sent with the files, which are sent in each chunk if chunked.
+	Fields map[string]string
+
+	// Files are the files uploaded.
+	Files []UploadFile
+
+	// ChunkSize enables chunked uploading if it is > 0, in which each file is split into chunks of
+	// ChunkSize bytes, and each chunk is uploaded in a separate request with header "Content-Range"
+	// like "bytes 0-1023/4096", so that the failed uploading can be resumed from the last chunk.
+	ChunkSize int64
+}
+
+// multipartBody is the request body of multipart form streamed from files,
+// which is not buffered in memory.
+type multipartBody struct {
+	*io.PipeReader
+}
+
+// uploadPart is the part of a file sent in a request.
+type uploadPart struct {
+	file  UploadFile
+	start int64 // Start offset of the part in file.
+	end   int64 // End offset of the part in file, exclusive.
+	total int64 // Total size of file.
+}
+
+// uploadProgressWriter counts the bytes written and reports the progress.
+type uploadProgressWriter struct {
+	ctx          context.Context
+	writer       io.Writer
+	part         uploadPart
+	uploaded     int64
+	metricOption gmetric.Option
+}
+
+const (
+	httpHeaderContentRange = "Content-Range"
+	httpHeaderRange        = "Range"
+)
+
+// PostMultipart sends POST request of multipart form with fields and files in `option`,
+// in which the files are streamed from disk with progress callbacks.
+// If chunked uploading is enabled by MultipartOption.ChunkSize, the files are uploaded chunk by chunk,
+// and it returns the response of the last chunk.
+//
+// For chunked uploading, the server can respond with header "Range" like "bytes=0-2047" to indicate
+// the bytes it has received, so that the next chunk is sent from offset 2048. The uploading stops with
+// error if any chunk is responded with status code >= 400, and it can be resumed by UploadFile.Offset.
+// Note that the response object MUST be closed if it'll never be used.
+func (c *Client) PostMultipart(ctx context.Context, url string, option MultipartOption) (*Response, error) {
+	parts := make([]uploadPart, 0, len(option.Files))
+	for _, file := range option.Files {
+		if file.FileName == "" {
+			file.FileName = gfile.Basename(file.Path)
+		}
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `stat upload file "%s" failed`, file.Path)
+		}
+		parts = append(parts, uploadPart{file: file, end: info.Size(), total: info.Size()})
+	}
+	if option.ChunkSize <= 0 || len(parts) == 0 {
+		return c.doMultipartRequest(ctx, url, option.Fields, parts)
+	}
+	// Each file is uploaded in chunks separately.
+	for i, part := range parts {
+		part.start = part.file.Offset
+		resp, err := c.uploadChunks(ctx, url, option, part)
+		if err != nil || i == len(parts)-1 {
+			return resp, err
+		}
+		_ = resp.Close()
+	}
+	return nil, nil
+}
+
+// uploadChunks uploads the file of `part` from its start offset chunk by chunk.
+func (c *Client) uploadChunks(
+	ctx context.Context, url string, option MultipartOption, part uploadPart,
+) (resp *Response, err error) {
+	if part.total == 0 {
+		return c.doMultipartRequest(ctx, url, option.Fields, []uploadPart{part})
+	}
+	for {
+		chunk := part
+		chunk.end = chunk.start + option.ChunkSize
+		if chunk.end > chunk.total {
+			chunk.end = chunk.total
+		}
+		client := c.Header(map[string]string{
+			httpHeaderContentRange: fmt.Sprintf(`bytes %d-%d/%d`, chunk.start, chunk.end-1, chunk.total),
+		})
+		if resp, err = client.doMultipartRequest(ctx, url, option.Fields, []uploadPart{chunk}); err != nil {
+			return resp, gerror.Wrapf(err, `upload chunk of "%s" from offset %d failed`, part.file.Path, chunk.start)
+		}
+		if resp.StatusCode >= http.StatusBadRequest {
+			_ = resp.Close()
+			return nil, gerror.NewCodef(
+				gcode.CodeOperationFailed,
+				`upload chunk of "%s" from offset %d failed with status: %s`,
+				part.file.Path, chunk.start, resp.Status,
+			)
+		}
+		part.start = chunk.end
+		if received := parseReceivedRange(resp.Header.Get(httpHeaderRange)); received > chunk.start {
+			part.start = received
+		}
+		if part.start >= part.total {
+			return resp, nil
+		}
+		_ = resp.Close()
+	}
+}
+
+// doMultipartRequest sends the multipart request of `fields` and file `parts`,
+// in which the body is recreated by GetBody for each attempt of retrying.
+func (c *Client) doMultipartRequest(
+	ctx context.Context, url string, fields map[string]string, parts []uploadPart,
+) (*Response, error) {
+	var (
+		requestStartTime = gtime.Now()
+		boundary         = multipart.NewWriter(nil).Boundary()
+		client           = c.ContentType(`multipart/form-data; boundary=` + boundary)
+	)
+	req, err := client.prepareRequest(ctx, http.MethodPost, url)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		mu           sync.Mutex
+		bodies       []*multipartBody
+		metricOption = metricManager.GetMetricOptionForRequest(req)
+	)
+	req.GetBody = func() (io.ReadCloser, error) {
+		reader, writer := io.Pipe()
+		go writeMultipartBody(ctx, writer, boundary, fields, parts, metricOption)
+		body := &multipartBody{PipeReader: reader}
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		return body, nil
+	}
+	// The bodies are closed after request, so that the writing goroutines exit
+	// even if the bodies are not consumed, like the request rejected by middleware.
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, body := range bodies {
+			_ = body.Close()
+		}
+	}()
+	// The length of streaming body is unknown.
+	req.ContentLength = -1
+	req.Body, _ = req.GetBody()
+	return client.doRequest(req, requestStartTime)
+}
+
+// isStreamingBody checks whether `body` is the streaming body which should not be buffered.
+func isStreamingBody(body io.ReadCloser) bool {
+	_, ok := body.(*multipartBody)
+	return ok
+}
+
+// writeMultipartBody writes the multipart form of `fields` and file `parts` to `writer`.
+func writeMultipartBody(
+	ctx context.Context, writer *io.PipeWriter, boundary string,
+	fields map[string]string, parts []uploadPart, metricOption gmetric.Option,
+) {
+	var (
+		err             error
+		multipartWriter = multipart.NewWriter(writer)
+		fieldNames      = make([]string, 0, len(fields))
+	)
+	defer func() {
+		_ = writer.CloseWithError(err)
+	}()
+	if err = multipartWriter.SetBoundary(boundary); err != nil {
+		return
+	}
+	for name := range fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+	for _, name := range fieldNames {
+		if err = multipartWriter.WriteField(name, fields[name]); err != nil {
+			return
+		}
+	}
+	for _, part := range parts {
+		if err = writeUploadPart(ctx, multipartWriter, part, metricOption); err != nil {
+			return
+		}
+	}
+	err = multipartWriter.Close()
+}
+
+// writeUploadPart writes the file content of `part` as a form file.
+func writeUploadPart(ctx context.Context, writer *multipart.Writer, part uploadPart, metricOption gmetric.Option) error {
+	formWriter, err := writer.CreateFormFile(part.file.FieldName, part.file.FileName)
+	if err != nil {
+		return gerror.Wrapf(err, `CreateFormFile failed with "%s", "%s"`, part.file.FieldName, part.file.FileName)
+	}
+	f, err := gfile.Open(part.file.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.Seek(part.start, io.SeekStart); err != nil {
+		return gerror.Wrapf(err, `seek file "%s" to offset %d failed`, part.file.Path, part.start)
+	}
+	progressWriter := &uploadProgressWriter{
+		ctx:          ctx,
+		writer:       formWriter,
+		part:         part,
+		uploaded:     part.start,
+		metricOption: metricOption,
+	}
+	if _, err = io.CopyN(progressWriter, f, part.end-part.start); err != nil {
+		return gerror.Wrapf(err, `io.Copy failed from "%s" to form "%s"`, part.file.Path, part.file.FieldName)
+	}
+	return nil
+}
+
+// Write writes `p` to the underlying writer and reports the progress.
+func (w *uploadProgressWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	if n <= 0 {
+		return
+	}
+	w.uploaded += int64(n)
+	if gmetric.IsEnabled() {
+		metricManager.HttpClientUploadSize.Add(w.ctx, float64(n), w.metricOption)
+	}
+	if w.part.file.OnProgress != nil {
+		w.part.file.OnProgress(w.ctx, UploadProgress{
+			FieldName: w.part.file.FieldName,
+			FileName:  w.part.file.FileName,
+			Uploaded:  w.uploaded,
+			Total:     w.part.total,
+		})
+	}
+	return
+}
+
+// parseReceivedRange parses the header "Range" like "bytes=0-2047" responded by server,
+// and returns the next offset to upload, which is 0 if it is invalid.
+func parseReceivedRange(value string) int64 {
+	value = strings.TrimPrefix(value, "bytes=")
+	_, end, found := strings.Cut(value, "-")
+	if !found {
+		return 0
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(end), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return offset + 1
+}
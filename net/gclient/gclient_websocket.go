@@ -7,10 +7,19 @@
 package gclient
 
 import (
+	"context"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // WebSocketClient wraps the underlying websocket client connection
@@ -19,6 +28,83 @@ type WebSocketClient struct {
 	*websocket.Dialer
 }
 
+// WebSocketOption is the option for WebSocket connection of Client.
+type WebSocketOption struct {
+	// Subprotocols are the requested subprotocols of connection.
+	Subprotocols []string
+
+	// PingInterval is the interval of heartbeat pings, which is 30 seconds in default,
+	// and the heartbeat is disabled if it is < 0.
+	PingInterval time.Duration
+
+	// PongTimeout is the timeout waiting for the pong or any message from server,
+	// which is twice of PingInterval in default. The connection is considered broken if timeout.
+	PongTimeout time.Duration
+
+	// MaxReconnects is the max count of consecutive reconnections, in which the count is reset once
+	// connected. It is unlimited if it is 0, and no reconnection if it is < 0.
+	MaxReconnects int
+
+	// Backoff is the backoff policy of reconnections, in which only the intervals are used.
+	// It is 1 second with exponential backoff up to 30 seconds in default.
+	Backoff RetryPolicy
+
+	// OnConnect is called after each connection established, including the reconnections,
+	// which is commonly used for resubscribing after reconnection.
+	OnConnect func(ctx context.Context, conn *WebSocketConn)
+}
+
+// WebSocketConn is the WebSocket connection of Client, which reconnects automatically when broken,
+// and keeps alive with heartbeat. It is safe for one concurrent reader and multiple concurrent writers.
+//
+// Note that the connection should be read continuously, as the pongs and the broken connection
+// are detected in reading.
+type WebSocketConn struct {
+	ctx        context.Context
+	client     *Client
+	url        string
+	option     WebSocketOption
+	mu         sync.Mutex // Guards session.
+	writeMu    sync.Mutex // Serializes writing.
+	session    *webSocketSession
+	reconnects int
+	closed     bool
+	closeChan  chan struct{} // Closed when the connection is closed.
+}
+
+// webSocketSession is a connection of WebSocketConn, which is replaced when reconnected.
+type webSocketSession struct {
+	conn *websocket.Conn
+	done chan struct{} // Closed when the session ends, which stops the heartbeat.
+	once sync.Once
+}
+
+// ErrWebSocketClosed is returned when the WebSocketConn is used after being closed.
+var ErrWebSocketClosed = gerror.NewWithOption(gerror.Option{
+	Text: "websocket connection is closed",
+	Code: gcode.CodeInvalidOperation,
+})
+
+const (
+	defaultWebSocketPingInterval     = 30 * time.Second
+	defaultWebSocketHandshakeTimeout = 45 * time.Second
+	webSocketWriteTimeout            = 10 * time.Second
+)
+
+var (
+	defaultWebSocketBackoff = RetryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.2,
+	}
+	// webSocketHandshakeHeaders are the headers set by the WebSocket handshake itself.
+	webSocketHandshakeHeaders = []string{
+		"Upgrade", "Connection", "Sec-Websocket-Key", "Sec-Websocket-Version",
+		"Sec-Websocket-Extensions", "Sec-Websocket-Protocol", httpHeaderContentType, httpHeaderContentLength,
+	}
+)
+
 // NewWebSocket creates and returns a new WebSocketClient object.
 func NewWebSocket() *WebSocketClient {
 	return &WebSocketClient{
@@ -28,3 +114,346 @@ func NewWebSocket() *WebSocketClient {
 		},
 	}
 }
+
+// WebSocket connects to the WebSocket `url` like "ws://127.0.0.1:8000/ws" and returns the connection.
+// The handshake request is sent through the middlewares of client with its headers, cookies and tracing,
+// and the TLS configuration, proxy and resolver of its Transport are also used for dialing.
+//
+// The connection is kept alive with ping/pong heartbeat, and reconnects with backoff when it is broken
+// in reading. The `ctx` controls the lifetime of the connection, which closes the connection when done.
+func (c *Client) WebSocket(ctx context.Context, url string, option ...WebSocketOption) (*WebSocketConn, error) {
+	var opt WebSocketOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.PingInterval == 0 {
+		opt.PingInterval = defaultWebSocketPingInterval
+	}
+	if opt.PongTimeout <= 0 && opt.PingInterval > 0 {
+		opt.PongTimeout = 2 * opt.PingInterval
+	}
+	if opt.Backoff.InitialInterval <= 0 {
+		opt.Backoff = defaultWebSocketBackoff
+	}
+	conn := &WebSocketConn{
+		ctx:       ctx,
+		client:    c,
+		url:       url,
+		option:    opt,
+		closeChan: make(chan struct{}),
+	}
+	if err := conn.connect(); err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-conn.closeChan:
+		}
+	}()
+	return conn, nil
+}
+
+// Conn returns the underlying websocket connection, which changes after reconnection.
+// It returns nil if the connection is closed.
+func (w *WebSocketConn) Conn() *websocket.Conn {
+	if session := w.currentSession(); session != nil {
+		return session.conn
+	}
+	return nil
+}
+
+// ReadMessage reads and returns the next message, which reconnects and reads from the new connection
+// if the connection is broken and reconnection is enabled.
+func (w *WebSocketConn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		session := w.currentSession()
+		if session == nil {
+			return 0, nil, ErrWebSocketClosed
+		}
+		if messageType, data, err = session.conn.ReadMessage(); err == nil {
+			w.extendReadDeadline(session.conn)
+			return
+		}
+		session.end()
+		if err = w.reconnect(session, err); err != nil {
+			return 0, nil, err
+		}
+	}
+}
+
+// ReadText reads and returns the next message as string.
+func (w *WebSocketConn) ReadText() (string, error) {
+	_, data, err := w.ReadMessage()
+	return string(data), err
+}
+
+// ReadJSON reads the next message and decodes it as JSON to `pointer`.
+func (w *WebSocketConn) ReadJSON(pointer interface{}) error {
+	_, data, err := w.ReadMessage()
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, pointer); err != nil {
+		return gerror.Wrap(err, `decode websocket message as JSON failed`)
+	}
+	return nil
+}
+
+// WriteMessage writes the message of `messageType` like websocket.TextMessage.
+// It fails if the connection is broken, and the message can be written again after reconnection.
+func (w *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	session := w.currentSession()
+	if session == nil {
+		return ErrWebSocketClosed
+	}
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	_ = session.conn.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout))
+	return session.conn.WriteMessage(messageType, data)
+}
+
+// WriteText writes `text` as text message.
+func (w *WebSocketConn) WriteText(text string) error {
+	return w.WriteMessage(websocket.TextMessage, []byte(text))
+}
+
+// WriteJSON encodes `value` as JSON and writes it as text message.
+func (w *WebSocketConn) WriteJSON(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return gerror.Wrap(err, `encode websocket message as JSON failed`)
+	}
+	return w.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close sends the close message and closes the connection, which stops the reconnection.
+func (w *WebSocketConn) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.closeChan)
+	session := w.session
+	w.session = nil
+	w.mu.Unlock()
+	if session == nil {
+		return nil
+	}
+	session.end()
+	w.writeMu.Lock()
+	_ = session.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(webSocketWriteTimeout),
+	)
+	w.writeMu.Unlock()
+	return session.conn.Close()
+}
+
+// currentSession returns the current session, which is nil if closed.
+func (w *WebSocketConn) currentSession() *webSocketSession {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.session
+}
+
+// reconnect replaces the broken `session` with a new connection, which returns `cause` if no more
+// reconnection is allowed, or nil if the session has been replaced by other goroutine.
+func (w *WebSocketConn) reconnect(session *webSocketSession, cause error) error {
+	for {
+		w.mu.Lock()
+		if w.closed {
+			w.mu.Unlock()
+			return ErrWebSocketClosed
+		}
+		if w.session != session {
+			w.mu.Unlock()
+			return nil
+		}
+		if w.option.MaxReconnects < 0 || (w.option.MaxReconnects > 0 && w.reconnects >= w.option.MaxReconnects) {
+			w.mu.Unlock()
+			return cause
+		}
+		w.reconnects++
+		reconnects := w.reconnects
+		w.mu.Unlock()
+
+		intlog.Printf(w.ctx, `websocket connection of "%s" broken, reconnecting %d: %+v`, w.url, reconnects, cause)
+		if !waitForRetry(w.ctx, w.option.Backoff.Backoff(reconnects)) {
+			return w.ctx.Err()
+		}
+		if cause = w.connect(); cause == nil {
+			return nil
+		}
+		session = w.currentSession()
+		if session == nil {
+			return ErrWebSocketClosed
+		}
+	}
+}
+
+// connect dials a new connection and replaces the current session, which starts the heartbeat.
+func (w *WebSocketConn) connect() error {
+	conn, err := w.client.dialWebSocket(w.ctx, w.url, w.option)
+	if err != nil {
+		return err
+	}
+	session := &webSocketSession{
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		_ = conn.Close()
+		return ErrWebSocketClosed
+	}
+	oldSession := w.session
+	w.session = session
+	w.reconnects = 0
+	w.mu.Unlock()
+	if oldSession != nil {
+		oldSession.end()
+		_ = oldSession.conn.Close()
+	}
+	if w.option.PingInterval > 0 {
+		w.extendReadDeadline(conn)
+		conn.SetPongHandler(func(string) error {
+			w.extendReadDeadline(conn)
+			return nil
+		})
+		go w.heartbeat(session)
+	}
+	if w.option.OnConnect != nil {
+		w.option.OnConnect(w.ctx, w)
+	}
+	return nil
+}
+
+// heartbeat sends pings to server periodically until the session ends.
+func (w *WebSocketConn) heartbeat(session *webSocketSession) {
+	ticker := time.NewTicker(w.option.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-session.done:
+			return
+		case <-ticker.C:
+			w.writeMu.Lock()
+			err := session.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(webSocketWriteTimeout))
+			w.writeMu.Unlock()
+			if err != nil {
+				intlog.Printf(w.ctx, `websocket ping of "%s" failed: %+v`, w.url, err)
+				return
+			}
+		}
+	}
+}
+
+// extendReadDeadline extends the read deadline of `conn` by the pong timeout.
+func (w *WebSocketConn) extendReadDeadline(conn *websocket.Conn) {
+	if w.option.PongTimeout > 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(w.option.PongTimeout))
+	}
+}
+
+// end ends the session, which stops its heartbeat.
+func (s *webSocketSession) end() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// dialWebSocket sends the WebSocket handshake request through the middlewares and returns the connection.
+func (c *Client) dialWebSocket(ctx context.Context, url string, option WebSocketOption) (*websocket.Conn, error) {
+	// The request is prepared in http scheme, which is converted back in dialing.
+	switch {
+	case strings.HasPrefix(url, "ws://"):
+		url = "http://" + url[len("ws://"):]
+	case strings.HasPrefix(url, "wss://"):
+		url = "https://" + url[len("wss://"):]
+	}
+	var requestStartTime = gtime.Now()
+	req, err := c.prepareRequest(ctx, http.MethodGet, url)
+	if err != nil {
+		return nil, err
+	}
+	var conn *websocket.Conn
+	resp, err := c.doRequestWithHandler(req, requestStartTime, func(cli *Client, r *http.Request) (*Response, error) {
+		var (
+			wsConn     *websocket.Conn
+			wsResponse *http.Response
+			dialErr    error
+		)
+		wsConn, wsResponse, dialErr = cli.newWebSocketDialer(option).DialContext(
+			r.Context(), webSocketUrl(r), webSocketHeader(r),
+		)
+		conn = wsConn
+		if dialErr != nil {
+			dialErr = gerror.Wrapf(dialErr, `websocket handshake of "%s" failed`, r.URL.String())
+		}
+		return &Response{Response: wsResponse, request: r}, dialErr
+	})
+	if err != nil {
+		if conn != nil {
+			_ = conn.Close()
+		}
+		return nil, err
+	}
+	if conn == nil {
+		// The handshake is intercepted by middleware.
+		if resp != nil {
+			_ = resp.Close()
+		}
+		return nil, gerror.NewCodef(gcode.CodeOperationFailed, `websocket handshake of "%s" intercepted`, url)
+	}
+	return conn, nil
+}
+
+// newWebSocketDialer creates and returns the dialer using the configurations of client.
+func (c *Client) newWebSocketDialer(option WebSocketOption) *websocket.Dialer {
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: defaultWebSocketHandshakeTimeout,
+		Subprotocols:     option.Subprotocols,
+		Jar:              c.Jar,
+	}
+	if c.Client.Timeout > 0 {
+		dialer.HandshakeTimeout = c.Client.Timeout
+	}
+	if transport, ok := c.Transport.(*http.Transport); ok {
+		dialer.Proxy = transport.Proxy
+		dialer.TLSClientConfig = transport.TLSClientConfig
+		dialer.NetDialContext = transport.DialContext
+	}
+	return dialer
+}
+
+// webSocketUrl returns the WebSocket url of request `r`.
+func webSocketUrl(r *http.Request) string {
+	u := *r.URL
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	return u.String()
+}
+
+// webSocketHeader returns the headers of request `r` for handshake,
+// in which the headers set by the handshake itself are removed.
+func webSocketHeader(r *http.Request) http.Header {
+	header := r.Header.Clone()
+	for _, name := range webSocketHandshakeHeaders {
+		header.Del(name)
+	}
+	if r.Host != "" && r.Host != r.URL.Host {
+		header.Set(httpHeaderHost, r.Host)
+	}
+	return header
+}
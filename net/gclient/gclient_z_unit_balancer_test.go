@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Balancer(t *testing.T) {
+	var (
+		failing = gtype.NewBool()
+		servers = make([]*ghttp.Server, 0, 2)
+	)
+	for i := 0; i < 2; i++ {
+		name := fmt.Sprintf("server%d", i)
+		s := g.Server(guid.S())
+		s.BindHandler("/api/name", func(r *ghttp.Request) {
+			if name == "server1" && failing.Val() {
+				r.Response.WriteStatus(http.StatusServiceUnavailable)
+				return
+			}
+			r.Response.Write(name)
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		servers = append(servers, s)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx       = context.Background()
+		endpoints = []gclient.BalancerEndpoint{
+			{Url: fmt.Sprintf("http://127.0.0.1:%d/api", servers[0].GetListenedPort())},
+			{Url: fmt.Sprintf("http://127.0.0.1:%d/api/", servers[1].GetListenedPort())},
+		}
+	)
+	// Round-robin.
+	gtest.C(t, func(t *gtest.T) {
+		client, err := gclient.NewWithBalancer(gclient.BalancerConfig{Endpoints: endpoints})
+		t.AssertNil(err)
+		names := make(map[string]int)
+		for i := 0; i < 4; i++ {
+			names[client.GetContent(ctx, "/name")]++
+		}
+		t.Assert(names, g.MapStrInt{"server0": 2, "server1": 2})
+	})
+	// Other strategies.
+	gtest.C(t, func(t *gtest.T) {
+		for _, strategy := range []gclient.BalanceStrategy{
+			gclient.BalanceWeight, gclient.BalanceLeastConnection, gclient.BalanceEWMA,
+		} {
+			client := g.Client().Balancer(gclient.BalancerConfig{Endpoints: endpoints, Strategy: strategy})
+			t.AssertIN(client.GetContent(ctx, "/name"), g.Slice{"server0", "server1"})
+		}
+		_, err := gclient.NewWithBalancer(gclient.BalancerConfig{Endpoints: endpoints, Strategy: "unknown"})
+		t.AssertNE(err, nil)
+		_, err = gclient.NewWithBalancer(gclient.BalancerConfig{
+			Endpoints: []gclient.BalancerEndpoint{{Url: "/api"}},
+		})
+		t.AssertNE(err, nil)
+	})
+	// Weight.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Balancer(gclient.BalancerConfig{
+			Endpoints: []gclient.BalancerEndpoint{
+				{Url: endpoints[0].Url, Weight: 100},
+				{Url: endpoints[1].Url, Weight: 1},
+			},
+			Strategy: gclient.BalanceWeight,
+		})
+		names := make(map[string]int)
+		for i := 0; i < 20; i++ {
+			names[client.GetContent(ctx, "/name")]++
+		}
+		t.AssertGT(names["server0"], names["server1"])
+	})
+	// Passive health checking.
+	gtest.C(t, func(t *gtest.T) {
+		failing.Set(true)
+		defer failing.Set(false)
+		client, err := gclient.NewWithBalancer(gclient.BalancerConfig{
+			Endpoints:        endpoints,
+			FailureThreshold: 2,
+			EjectDuration:    500 * time.Millisecond,
+		})
+		t.AssertNil(err)
+		var failures int
+		for i := 0; i < 10; i++ {
+			if client.GetContent(ctx, "/name") != "server0" {
+				failures++
+			}
+		}
+		t.Assert(failures, 2)
+
+		// The endpoint is readmitted after eject duration.
+		failing.Set(false)
+		time.Sleep(600 * time.Millisecond)
+		names := make(map[string]int)
+		for i := 0; i < 4; i++ {
+			names[client.GetContent(ctx, "/name")]++
+		}
+		t.Assert(names, g.MapStrInt{"server0": 2, "server1": 2})
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Batch(t *testing.T) {
+	var (
+		active    = gtype.NewInt()
+		maxActive = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/batch/{id}", func(r *ghttp.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			if m := maxActive.Val(); n <= m || maxActive.Cas(m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		r.Response.Write(r.Method, ":", r.Get("id"), ":", r.Get("name"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client   = g.Client().Prefix(prefix).BatchConcurrency(2)
+			requests []gclient.BatchRequest
+		)
+		for i := 0; i < 6; i++ {
+			requests = append(requests, gclient.BatchRequest{
+				Method: http.MethodPost,
+				Url:    fmt.Sprintf("/batch/%d", i),
+				Data:   g.Map{"name": "john"},
+			})
+		}
+		requests = append(requests, gclient.BatchRequest{Url: "/batch/6"})
+		results, err := client.Batch(ctx, requests...)
+		t.AssertNil(err)
+		t.Assert(len(results), 7)
+		for i, result := range results[:6] {
+			t.AssertNil(result.Error)
+			t.Assert(result.Response.ReadAllString(), fmt.Sprintf("POST:%d:john", i))
+			result.Response.Close()
+		}
+		t.Assert(results[6].Response.ReadAllString(), "GET:6:")
+		results[6].Response.Close()
+		t.AssertLE(maxActive.Val(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		results, err := g.Client().Batch(ctx,
+			gclient.BatchRequest{Url: prefix + "/batch/1"},
+			gclient.BatchRequest{Url: "http://127.0.0.1:1/batch/2"},
+		)
+		t.AssertNE(err, nil)
+		t.AssertNil(results[0].Error)
+		t.Assert(results[0].Response.ReadAllString(), "GET:1:")
+		results[0].Response.Close()
+		t.AssertNE(results[1].Error, nil)
+		t.AssertNil(results[1].Response)
+	})
+}
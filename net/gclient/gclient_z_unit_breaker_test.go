@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_CircuitBreaker(t *testing.T) {
+	var (
+		healthy = gtype.NewBool()
+		counter = gtype.NewInt()
+		s       = g.Server(guid.S())
+	)
+	s.BindHandler("/downstream", func(r *ghttp.Request) {
+		counter.Add(1)
+		if !healthy.Val() {
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			host        = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+			transitions = garray.NewStrArray(true)
+			client      = g.Client().Prefix("http://" + host).CircuitBreaker(gclient.CircuitBreakerConfig{
+				FailureRateThreshold: 0.5,
+				MinRequests:          4,
+				OpenTimeout:          200 * time.Millisecond,
+				HalfOpenRequests:     2,
+				OnStateChange: func(ctx context.Context, key string, from, to gclient.CircuitBreakerState) {
+					transitions.Append(fmt.Sprintf("%s:%s->%s", key, from, to))
+				},
+			})
+		)
+		// Failures open the breaker.
+		for i := 0; i < 4; i++ {
+			resp, err := client.Get(ctx, "/downstream")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, http.StatusInternalServerError)
+			resp.Close()
+		}
+		t.Assert(client.GetCircuitBreakerState(host), gclient.CircuitBreakerStateOpen)
+		_, err := client.Get(ctx, "/downstream")
+		t.Assert(gerror.Is(err, gclient.ErrCircuitBreakerOpen), true)
+		t.Assert(counter.Val(), 4)
+
+		// Failed probe in half-open state opens the breaker again.
+		time.Sleep(250 * time.Millisecond)
+		resp, err := client.Get(ctx, "/downstream")
+		t.AssertNil(err)
+		resp.Close()
+		t.Assert(client.GetCircuitBreakerState(host), gclient.CircuitBreakerStateOpen)
+		t.Assert(counter.Val(), 5)
+
+		// Succeeded probes close the breaker.
+		healthy.Set(true)
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, "/downstream"), "ok")
+		t.Assert(client.GetCircuitBreakerState(host), gclient.CircuitBreakerStateHalfOpen)
+		t.Assert(client.GetContent(ctx, "/downstream"), "ok")
+		t.Assert(client.GetCircuitBreakerState(host), gclient.CircuitBreakerStateClosed)
+
+		t.Assert(transitions.Slice(), []string{
+			host + ":closed->open",
+			host + ":open->half-open",
+			host + ":half-open->open",
+			host + ":open->half-open",
+			host + ":half-open->closed",
+		})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		t.Assert(client.GetCircuitBreakerState("127.0.0.1"), gclient.CircuitBreakerStateClosed)
+		t.Assert(gclient.CircuitBreakerStateHalfOpen.String(), "half-open")
+	})
+}
+
+func Test_Client_CircuitBreaker_PerHost(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().CircuitBreaker(gclient.CircuitBreakerConfig{
+			MinRequests: 2,
+			OpenTimeout: time.Minute,
+		})
+		for i := 0; i < 2; i++ {
+			_, err := client.Get(ctx, "http://127.0.0.1:1/not-exist")
+			t.AssertNE(err, nil)
+		}
+		t.Assert(client.GetCircuitBreakerState("127.0.0.1:1"), gclient.CircuitBreakerStateOpen)
+		t.Assert(client.GetCircuitBreakerState("127.0.0.1:2"), gclient.CircuitBreakerStateClosed)
+
+		_, err := client.Get(ctx, "http://127.0.0.1:1/not-exist")
+		t.Assert(gerror.Is(err, gclient.ErrCircuitBreakerOpen), true)
+		_, err = client.Get(ctx, "http://127.0.0.1:2/not-exist")
+		t.Assert(gerror.Is(err, gclient.ErrCircuitBreakerOpen), false)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Budget(t *testing.T) {
+	var counter = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareBudget)
+		group.GET("/budget", func(r *ghttp.Request) {
+			var remaining time.Duration
+			if deadline, ok := r.Context().Deadline(); ok {
+				remaining = time.Until(deadline)
+			}
+			r.Response.Write(r.Header.Get("X-Request-Budget"), ",", remaining.Milliseconds())
+		})
+		group.GET("/custom", func(r *ghttp.Request) {
+			r.Response.Write(r.Header.Get("X-Budget"))
+		})
+		group.GET("/redirect", func(r *ghttp.Request) {
+			time.Sleep(200 * time.Millisecond)
+			r.Response.RedirectTo("/budget")
+		})
+		group.GET("/unavailable", func(r *ghttp.Request) {
+			counter.Add(1)
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		client = g.Client().Prefix(prefix)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		// No budget.
+		t.Assert(client.GetContent(ctx, "/budget"), ",0")
+
+		array := gconv.Ints(gstr.Split(client.Budget(2*time.Second).GetContent(ctx, "/budget"), ","))
+		t.Assert(len(array), 2)
+		t.AssertGT(array[0], 1500)
+		t.AssertLE(array[0], 2000)
+		t.AssertGT(array[1], 1500)
+		t.AssertLE(array[1], array[0])
+
+		// The budget is updated for redirects.
+		array = gconv.Ints(gstr.Split(client.Budget(2*time.Second).GetContent(ctx, "/redirect"), ","))
+		t.Assert(len(array), 2)
+		t.AssertLE(array[0], 1800)
+
+		// The earlier deadline of context takes precedence.
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		array = gconv.Ints(gstr.Split(client.Budget(2*time.Second).GetContent(timeoutCtx, "/budget"), ","))
+		t.AssertLE(array[0], 1000)
+
+		// Custom header name.
+		t.AssertGT(gconv.Int(client.Budget(time.Second).SetBudgetHeader("X-Budget").GetContent(ctx, "/custom")), 500)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// It stops retrying if the budget is not enough.
+		start := time.Now()
+		resp, err := client.Budget(300*time.Millisecond).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       3,
+			InitialInterval:  500 * time.Millisecond,
+			RetryStatusCodes: []int{http.StatusServiceUnavailable},
+		}).Get(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.Assert(counter.Val(), 1)
+		t.AssertLT(time.Since(start), 300*time.Millisecond)
+	})
+}
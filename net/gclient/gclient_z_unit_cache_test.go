@@ -0,0 +1,171 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Cache(t *testing.T) {
+	var (
+		counter      = gtype.NewInt()
+		lastModified = time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/max-age", func(r *ghttp.Request) {
+		r.Response.Header().Set("Cache-Control", "max-age=1")
+		r.Response.Write(counter.Add(1))
+	})
+	s.BindHandler("/no-store", func(r *ghttp.Request) {
+		r.Response.Header().Set("Cache-Control", "no-store, max-age=60")
+		r.Response.Write(counter.Add(1))
+	})
+	s.BindHandler("/etag", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Header().Set("Cache-Control", "no-cache")
+		r.Response.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			r.Response.WriteHeader(http.StatusNotModified)
+			return
+		}
+		r.Response.Write("etag")
+	})
+	s.BindHandler("/last-modified", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Header().Set("Cache-Control", "max-age=0")
+		r.Response.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			r.Response.WriteHeader(http.StatusNotModified)
+			return
+		}
+		r.Response.Write("last-modified")
+	})
+	s.BindHandler("/vary", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Header().Set("Cache-Control", "max-age=60")
+		r.Response.Header().Set("Vary", "Accept-Language")
+		r.Response.Write(r.Header.Get("Accept-Language"))
+	})
+	s.BindHandler("/authorized", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Header().Set("Cache-Control", "max-age=60")
+		r.Response.Write(r.Header.Get("Authorization"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	// Fresh response.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Cache(gcache.NewAdapterMemory())
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "1")
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "1")
+		resp, err := client.Get(ctx, prefix+"/max-age")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Age"), "0")
+		resp.Close()
+		t.Assert(counter.Val(), 1)
+
+		// Request directives.
+		t.Assert(client.Header(g.MapStrStr{"Cache-Control": "no-cache"}).GetContent(ctx, prefix+"/max-age"), "2")
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "2")
+
+		// Expired.
+		time.Sleep(1100 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "3")
+	})
+	// No store.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Cache(gcache.NewAdapterMemory())
+		t.Assert(client.GetContent(ctx, prefix+"/no-store"), "1")
+		t.Assert(client.GetContent(ctx, prefix+"/no-store"), "2")
+	})
+	// Revalidation.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Cache(gcache.NewAdapterMemory())
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(ctx, prefix+"/etag")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, http.StatusOK)
+			t.Assert(resp.ReadAllString(), "etag")
+			resp.Close()
+		}
+		t.Assert(counter.Val(), 3)
+
+		for i := 0; i < 3; i++ {
+			t.Assert(client.GetContent(ctx, prefix+"/last-modified"), "last-modified")
+		}
+		t.Assert(counter.Val(), 6)
+	})
+	// Vary and invalidation.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		var (
+			client = g.Client().Cache(gcache.NewAdapterMemory())
+			en     = client.Header(g.MapStrStr{"Accept-Language": "en"})
+			zh     = client.Header(g.MapStrStr{"Accept-Language": "zh"})
+		)
+		t.Assert(en.GetContent(ctx, prefix+"/vary"), "en")
+		t.Assert(en.GetContent(ctx, prefix+"/vary"), "en")
+		t.Assert(counter.Val(), 1)
+		t.Assert(zh.GetContent(ctx, prefix+"/vary"), "zh")
+		t.Assert(counter.Val(), 2)
+
+		t.Assert(zh.GetContent(ctx, prefix+"/vary"), "zh")
+		t.Assert(counter.Val(), 2)
+		zh.PostContent(ctx, prefix+"/vary")
+		t.Assert(counter.Val(), 3)
+		t.Assert(zh.GetContent(ctx, prefix+"/vary"), "zh")
+		t.Assert(counter.Val(), 4)
+	})
+	// Private cache shared by users of different credentials.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		var (
+			client = g.Client().Cache(gcache.NewAdapterMemory())
+			userA  = client.Header(g.MapStrStr{"Authorization": "Bearer a"})
+			userB  = client.Header(g.MapStrStr{"Authorization": "Bearer b"})
+		)
+		t.Assert(userA.GetContent(ctx, prefix+"/authorized"), "Bearer a")
+		t.Assert(userA.GetContent(ctx, prefix+"/authorized"), "Bearer a")
+		t.Assert(counter.Val(), 1)
+		t.Assert(userB.GetContent(ctx, prefix+"/authorized"), "Bearer b")
+		t.Assert(counter.Val(), 2)
+		t.Assert(client.GetContent(ctx, prefix+"/authorized"), "")
+		t.Assert(counter.Val(), 3)
+	})
+	// Shared cache and disabling.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Cache(gcache.NewAdapterMemory(), gclient.CacheOption{Shared: true})
+		client.SetHeader("Authorization", "Bearer token")
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "1")
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "2")
+		client.SetCache(nil)
+		t.Assert(client.GetContent(ctx, prefix+"/max-age"), "3")
+	})
+}
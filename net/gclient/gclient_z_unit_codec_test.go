@@ -0,0 +1,115 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type gobCodec struct{}
+
+func (gobCodec) ContentType() string {
+	return "application/x-gob"
+}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(v); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, pointer interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(pointer)
+}
+
+type codecUser struct {
+	Id   int
+	Name string
+}
+
+type codecUserReq struct {
+	g.Meta `path:"/user/{Id}" method:"post"`
+	Id     int
+	Name   string
+}
+
+func Test_Client_ContentCodec(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/user/{id}", func(r *ghttp.Request) {
+		var (
+			codec = gobCodec{}
+			user  codecUser
+		)
+		if err := codec.Unmarshal(r.GetBody(), &user); err != nil {
+			r.Response.WriteStatus(400, err.Error())
+			return
+		}
+		user.Name = fmt.Sprintf("%s:%s:%s", user.Name, r.Get("id"), r.Header.Get("Accept"))
+		content, _ := codec.Marshal(user)
+		r.Response.Header().Set("Content-Type", codec.ContentType())
+		r.Response.Write(content)
+	})
+	s.BindHandler("/json", func(r *ghttp.Request) {
+		r.Response.WriteJson(codecUser{Id: 1, Name: "json"})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).ContentCodec(gobCodec{})
+		resp, err := client.Post(ctx, "/user/1", codecUser{Id: 1, Name: "john"})
+		t.AssertNil(err)
+		var user codecUser
+		t.AssertNil(resp.Scan(&user))
+		resp.Close()
+		t.Assert(user.Id, 1)
+		t.Assert(user.Name, "john:1:application/x-gob")
+
+		// Request object.
+		var res *codecUser
+		t.AssertNil(client.DoRequestObj(ctx, codecUserReq{Id: 2, Name: "smith"}, &res))
+		t.Assert(res.Id, 2)
+		t.Assert(res.Name, "smith:2:application/x-gob")
+
+		// Response of other content type.
+		resp, err = client.Get(ctx, "/json")
+		t.AssertNil(err)
+		t.AssertNil(resp.Scan(&user))
+		resp.Close()
+		t.Assert(user.Name, "json")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gclient.RegisterContentCodec(gobCodec{})
+		client := g.Client().Prefix(prefix).ContentType("application/x-gob")
+		resp, err := client.Post(ctx, "/user/3", codecUser{Id: 3, Name: "registered"})
+		t.AssertNil(err)
+		var user codecUser
+		t.AssertNil(resp.Scan(&user))
+		resp.Close()
+		t.Assert(user.Id, 3)
+		t.Assert(user.Name, "registered:3:")
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_CookieStore(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/login", func(r *ghttp.Request) {
+		http.SetCookie(r.Response.Writer, &http.Cookie{Name: "session", Value: "secret-token", MaxAge: 3600})
+		http.SetCookie(r.Response.Writer, &http.Cookie{Name: "theme", Value: "dark"})
+	})
+	s.BindHandler("/logout", func(r *ghttp.Request) {
+		http.SetCookie(r.Response.Writer, &http.Cookie{Name: "session", MaxAge: -1})
+	})
+	s.BindHandler("/whoami", func(r *ghttp.Request) {
+		r.Response.Write(r.Cookie.Get("session").String(), ",", r.Cookie.Get("theme").String())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path  = gfile.Temp(guid.S(), "cookies.json")
+			store = gclient.NewFileCookieStore(path)
+		)
+		defer gfile.Remove(gfile.Dir(path))
+
+		client := g.Client().Prefix(url)
+		t.AssertNil(client.SetCookieStore(store))
+		client.GetContent(ctx, "/login")
+		t.Assert(client.GetContent(ctx, "/whoami"), "secret-token,dark")
+		t.Assert(gstr.Contains(gfile.GetContents(path), "secret-token"), true)
+
+		// Cookies are restored by a new client.
+		client = g.Client().Prefix(url).CookieStore(store)
+		t.Assert(client.GetContent(ctx, "/whoami"), "secret-token,dark")
+
+		// Deleted cookie is removed from store.
+		client.GetContent(ctx, "/logout")
+		client = g.Client().Prefix(url).CookieStore(store)
+		t.Assert(client.GetContent(ctx, "/whoami"), ",dark")
+	})
+	// Encryption.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path  = gfile.Temp(guid.S(), "cookies")
+			store = gclient.NewFileCookieStore(path)
+			key   = []byte("1234567890123456")
+		)
+		defer gfile.Remove(gfile.Dir(path))
+
+		client := g.Client().Prefix(url)
+		t.AssertNil(client.SetCookieStore(store, key))
+		client.GetContent(ctx, "/login")
+		t.Assert(gfile.Exists(path), true)
+		t.Assert(gstr.Contains(gfile.GetContents(path), "secret-token"), false)
+
+		client = g.Client().Prefix(url)
+		t.AssertNil(client.SetCookieStore(store, key))
+		t.Assert(client.GetContent(ctx, "/whoami"), "secret-token,dark")
+
+		t.AssertNE(g.Client().SetCookieStore(store, []byte("6543210987654321")), nil)
+		t.AssertNE(g.Client().SetCookieStore(store), nil)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_AcceptEncoding(t *testing.T) {
+	gclient.RegisterContentDecoder("x-base64", func(reader io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(base64.NewDecoder(base64.StdEncoding, reader)), nil
+	})
+	var (
+		content = "hello, decompression"
+		encode  = func(encoding string, data []byte) []byte {
+			var buffer bytes.Buffer
+			switch encoding {
+			case "gzip":
+				w := gzip.NewWriter(&buffer)
+				_, _ = w.Write(data)
+				_ = w.Close()
+			case "deflate":
+				w := zlib.NewWriter(&buffer)
+				_, _ = w.Write(data)
+				_ = w.Close()
+			case "raw-deflate":
+				w, _ := flate.NewWriter(&buffer, flate.DefaultCompression)
+				_, _ = w.Write(data)
+				_ = w.Close()
+			case "x-base64":
+				buffer.WriteString(base64.StdEncoding.EncodeToString(data))
+			}
+			return buffer.Bytes()
+		}
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/encoding", func(r *ghttp.Request) {
+		var (
+			encoding = r.Get("encoding").String()
+			data     = []byte(content)
+		)
+		switch encoding {
+		case "deflate, gzip":
+			data = encode("gzip", encode("deflate", data))
+		case "raw-deflate":
+			data = encode(encoding, data)
+			encoding = "deflate"
+		default:
+			data = encode(encoding, data)
+		}
+		r.Response.Header().Set("Content-Encoding", encoding)
+		r.Response.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		r.Response.Write(data)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().AcceptEncoding("x-base64", "gzip", "deflate")
+		for _, encoding := range []string{"gzip", "deflate", "raw-deflate", "x-base64", "deflate, gzip"} {
+			resp, err := client.Get(ctx, prefix+"/encoding", g.Map{"encoding": encoding})
+			t.AssertNil(err)
+			t.Assert(resp.ReadAllString(), content)
+			t.Assert(resp.Header.Get("Content-Encoding"), "")
+			t.Assert(resp.Header.Get("X-Accept-Encoding"), "x-base64, gzip, deflate")
+			resp.Close()
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		t.AssertNE(client.SetAcceptEncoding("unknown"), nil)
+
+		// The body is not decoded without negotiation.
+		resp, err := client.Get(ctx, prefix+"/encoding", g.Map{"encoding": "x-base64"})
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), base64.StdEncoding.EncodeToString([]byte(content)))
+		t.Assert(resp.Header.Get("Content-Encoding"), "x-base64")
+		resp.Close()
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/grand"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Download(t *testing.T) {
+	var (
+		content  = grand.S(10 * 1024)
+		srcPath  = gfile.Temp(guid.S())
+		dstDir   = gfile.Temp(guid.S())
+		sha256Of = sha256.Sum256([]byte(content))
+		md5Of    = md5.Sum([]byte(content))
+		failing  = gtype.NewBool()
+	)
+	gtest.AssertNil(gfile.PutContents(srcPath, content))
+	defer gfile.Remove(srcPath)
+	defer gfile.Remove(dstDir)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/range", func(r *ghttp.Request) {
+		// The range from offset 5120 fails once.
+		if strings.HasPrefix(r.Header.Get("Range"), "bytes=5120-") && failing.Cas(true, false) {
+			r.Response.WriteStatus(500)
+			return
+		}
+		r.Response.ServeFile(srcPath)
+	})
+	s.BindHandler("/whole", func(r *ghttp.Request) {
+		r.Response.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Of[:]))
+		r.Response.Write(content)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	// Parallel range requests.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dst      = gfile.Join(dstDir, "range.txt")
+			progress gclient.DownloadProgress
+		)
+		err := g.Client().Download(ctx, prefix+"/range", dst, gclient.DownloadOption{
+			Concurrency: 3,
+			PartSize:    1024,
+			Checksum:    "sha256:" + hex.EncodeToString(sha256Of[:]),
+			OnProgress: func(ctx context.Context, p gclient.DownloadProgress) {
+				progress = p
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(gfile.GetContents(dst), content)
+		t.Assert(progress.Downloaded, len(content))
+		t.Assert(progress.Total, len(content))
+		t.Assert(gfile.Exists(dst+".download"), false)
+		t.Assert(gfile.Exists(dst+".download.json"), false)
+	})
+	// Resuming.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dst      = gfile.Join(dstDir, "resume.txt")
+			progress []int64
+			option   = gclient.DownloadOption{
+				Concurrency: 1,
+				PartSize:    1024,
+				OnProgress: func(ctx context.Context, p gclient.DownloadProgress) {
+					progress = append(progress, p.Downloaded)
+				},
+			}
+		)
+		failing.Set(true)
+		t.AssertNE(g.Client().Download(ctx, prefix+"/range", dst, option), nil)
+		t.Assert(gfile.Exists(dst), false)
+		t.Assert(gfile.Exists(dst+".download.json"), true)
+
+		progress = nil
+		t.AssertNil(g.Client().Download(ctx, prefix+"/range", dst, option))
+		t.Assert(gfile.GetContents(dst), content)
+		// It resumes from the failed part.
+		t.Assert(progress[0], 5120)
+	})
+	// Single request with checksum of header.
+	gtest.C(t, func(t *gtest.T) {
+		dst := gfile.Join(dstDir, "whole.txt")
+		t.AssertNil(g.Client().Download(ctx, prefix+"/whole", dst))
+		t.Assert(gfile.GetContents(dst), content)
+
+		err := g.Client().Download(ctx, prefix+"/whole", dst+".mismatch", gclient.DownloadOption{
+			Checksum: "sha256:" + strings.Repeat("0", 64),
+		})
+		t.AssertNE(err, nil)
+		t.Assert(gfile.Exists(dst+".mismatch"), false)
+	})
+}
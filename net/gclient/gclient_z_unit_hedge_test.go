@@ -0,0 +1,159 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_HedgePolicy(t *testing.T) {
+	var counter = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/slow-first", func(r *ghttp.Request) {
+		if counter.Add(1) == 1 {
+			time.Sleep(2 * time.Second)
+			r.Response.Write("slow")
+			return
+		}
+		r.Response.Write("fast:", r.GetBodyString())
+	})
+	s.BindHandler("/fail-first", func(r *ghttp.Request) {
+		if counter.Add(1) == 1 {
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.BindHandler("/fail", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.WriteStatus(http.StatusBadGateway)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	s2 := g.Server(guid.S())
+	s2.BindHandler("/slow-first", func(r *ghttp.Request) {
+		r.Response.Write("alternate")
+	})
+	s2.SetDumpRouterMap(false)
+	s2.Start()
+	defer s2.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{Delay: 100 * time.Millisecond})
+		start := time.Now()
+		t.Assert(client.PutContent(ctx, prefix+"/slow-first", "a=1"), "fast:a=1")
+		t.AssertLT(time.Since(start), time.Second)
+		t.Assert(counter.Val(), 2)
+	})
+	// Alternate hosts.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{
+			Delay: 100 * time.Millisecond,
+			Hosts: []string{fmt.Sprintf("127.0.0.1:%d", s2.GetListenedPort())},
+		})
+		t.Assert(client.GetContent(ctx, prefix+"/slow-first"), "alternate")
+	})
+	// Non-idempotent method is not hedged in default.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{Delay: 100 * time.Millisecond})
+		client.SetTimeout(300 * time.Millisecond)
+		_, err := client.Post(ctx, prefix+"/slow-first")
+		t.AssertNE(err, nil)
+		t.Assert(counter.Val(), 1)
+
+		counter.Set(0)
+		client = g.Client().HedgePolicy(gclient.HedgePolicy{Delay: 100 * time.Millisecond, NonIdempotent: true})
+		t.Assert(client.PostContent(ctx, prefix+"/slow-first", "b=2"), "fast:b=2")
+	})
+	// The failed attempt is hedged immediately.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{Delay: time.Second, MaxHedges: 2})
+		start := time.Now()
+		t.Assert(client.GetContent(ctx, prefix+"/fail-first"), "ok")
+		t.AssertLT(time.Since(start), 500*time.Millisecond)
+	})
+	// All attempts failed.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{Delay: time.Second, MaxHedges: 2})
+		resp, err := client.Get(ctx, prefix+"/fail")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadGateway)
+		t.Assert(counter.Val(), 3)
+		resp.Close()
+	})
+}
+
+func Test_Client_HedgePolicy_Signer(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		signer = gclient.HMACSigner{KeyId: "key1", Secret: []byte("secret")}
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(2 * time.Second)
+		r.Response.Write("slow")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	// The alternate host verifies the signature, which covers the host of request.
+	s2 := g.Server(guid.S())
+	s2.BindHandler("/slow", func(r *ghttp.Request) {
+		req := r.Request.Clone(ctx)
+		timestamp := gconv.Int64(r.Header.Get("X-Timestamp"))
+		if err := signer.Sign(req, r.GetBody(), time.Unix(timestamp, 0)); err != nil {
+			r.Response.Write(err.Error())
+			return
+		}
+		if req.Header.Get("Authorization") != r.Header.Get("Authorization") {
+			r.Response.Write("invalid signature")
+			return
+		}
+		r.Response.Write("alternate")
+	})
+	s2.SetDumpRouterMap(false)
+	s2.Start()
+	defer s2.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().HedgePolicy(gclient.HedgePolicy{
+			Delay: 100 * time.Millisecond,
+			Hosts: []string{fmt.Sprintf("127.0.0.1:%d", s2.GetListenedPort())},
+		})
+		client.Use(gclient.SignerHMAC(signer))
+		t.Assert(
+			client.GetContent(ctx, fmt.Sprintf("http://127.0.0.1:%d/slow", s.GetListenedPort())),
+			"alternate",
+		)
+	})
+}
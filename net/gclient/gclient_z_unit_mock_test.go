@@ -0,0 +1,92 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Client_MockTransport(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mock   = gclient.NewMockTransport()
+			client = g.Client().Prefix("http://mock.test")
+			user   = mock.On("GET", "/user").WithQuery("id", "1").Reply(200, g.Map{"name": "john"}).Times(2)
+			create = mock.On("POST", "/user").WithBody(`{"name":"smith"}`).
+				Reply(http.StatusCreated, "created").ReplyHeader("X-Id", "2")
+		)
+		mock.On("", "/auth").WithHeader("Authorization", "Bearer token").Reply(204)
+		client.Transport = mock
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "/user", g.Map{"id": 1})
+			t.AssertNil(err)
+			t.Assert(resp.Header.Get("Content-Type"), "application/json")
+			t.Assert(resp.ReadAllString(), `{"name":"john"}`)
+			resp.Close()
+		}
+		t.Assert(user.Calls(), 2)
+
+		resp, err := client.ContentJson().Post(ctx, "/user", g.Map{"name": "smith"})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.Header.Get("X-Id"), "2")
+		t.Assert(resp.ReadAllString(), "created")
+		resp.Close()
+		t.Assert(create.Calls(), 1)
+
+		// The authorization expectation is never called.
+		t.AssertNE(mock.AssertExpectations(), nil)
+		resp, err = client.Header(g.MapStrStr{"Authorization": "Bearer token"}).Delete(ctx, "/auth")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		resp.Close()
+		t.AssertNil(mock.AssertExpectations())
+
+		// Exceeds the expected times.
+		_, err = client.Get(ctx, "/user", g.Map{"id": 1})
+		t.Assert(gerror.Is(err, gclient.ErrMockNotMatched), true)
+		t.AssertNE(mock.AssertExpectations(), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mock    = gclient.NewMockTransport()
+			client  = g.Client()
+			errMock = errors.New("connection refused")
+		)
+		mock.On("GET", "/slow").Delay(time.Second)
+		mock.On("GET", "/error").ReplyError(errMock)
+		mock.On("GET", "/match").WithMatcher(func(r *http.Request) bool {
+			return r.URL.Query().Get("name") == "john"
+		}).Reply(200, "matched")
+		client.Transport = mock
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err := client.Get(timeoutCtx, "http://mock.test/slow")
+		t.AssertNE(err, nil)
+
+		_, err = client.Get(ctx, "http://mock.test/error")
+		t.Assert(errors.Is(err, errMock), true)
+
+		t.Assert(client.GetContent(ctx, "http://mock.test/match?name=john"), "matched")
+		t.Assert(client.GetContent(ctx, "http://mock.test/match?name=smith"), "")
+
+		mock.Reset()
+		t.AssertNil(mock.AssertExpectations())
+	})
+}
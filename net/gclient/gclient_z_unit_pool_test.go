@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_ConnectionPool(t *testing.T) {
+	var (
+		active    = gtype.NewInt()
+		maxActive = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/pool", func(r *ghttp.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			if m := maxActive.Val(); n <= m || maxActive.Cas(m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx    = context.Background()
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New().
+			MaxIdleConnsPerHost(4).
+			MaxConnsPerHost(2).
+			IdleConnTimeout(time.Minute)
+		transport := client.Transport.(*http.Transport)
+		t.Assert(transport.DisableKeepAlives, false)
+		t.Assert(transport.MaxIdleConnsPerHost, 4)
+		t.Assert(transport.MaxConnsPerHost, 2)
+		t.Assert(transport.IdleConnTimeout, time.Minute)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				t.Assert(client.GetContent(ctx, prefix+"/pool"), "ok")
+			}()
+		}
+		wg.Wait()
+		t.AssertLE(maxActive.Val(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New()
+		t.AssertNE(client.SetMaxConnsPerHost(-1), nil)
+		t.AssertNE(client.SetIdleConnTimeout(-time.Second), nil)
+
+		client.Transport = roundTripperFunc(client.Transport.RoundTrip)
+		t.AssertNE(client.SetMaxIdleConnsPerHost(1), nil)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
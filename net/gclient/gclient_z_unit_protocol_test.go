@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Protocol_HTTPS(t *testing.T) {
+	var (
+		crtFile = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server.crt"
+		keyFile = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server.key"
+		s       = g.Server(guid.S())
+	)
+	s.BindHandler("/proto", func(r *ghttp.Request) {
+		r.Response.Write(r.Proto)
+	})
+	s.EnableHTTPS(crtFile, keyFile, &tls.Config{
+		NextProtos: []string{"h2", "http/1.1"},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("https://127.0.0.1:%d/proto", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(g.Client().GetContent(ctx, url), "HTTP/1.1")
+		t.Assert(g.Client().Protocol(gclient.ProtocolAuto).GetContent(ctx, url), "HTTP/2.0")
+		t.Assert(g.Client().Protocol(gclient.ProtocolHTTP2).GetContent(ctx, url), "HTTP/2.0")
+
+		resp, err := g.Client().Protocol(gclient.ProtocolHTTP2).Get(ctx, url)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Proto, "HTTP/2.0")
+	})
+	// The TLS configuration is inherited.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		t.AssertNil(client.SetTLSConfig(&tls.Config{InsecureSkipVerify: false}))
+		t.AssertNil(client.SetProtocol(gclient.ProtocolHTTP2))
+		_, err := client.Get(ctx, url)
+		t.AssertNE(err, nil)
+
+		t.AssertNil(client.SetTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+		t.Assert(client.GetContent(ctx, url), "HTTP/2.0")
+	})
+}
+
+func Test_Client_Protocol_H2C(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), &http2.Server{}))
+	defer server.Close()
+
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(g.Client().GetContent(ctx, server.URL), "HTTP/1.1")
+		t.Assert(g.Client().Protocol(gclient.ProtocolH2C).GetContent(ctx, server.URL), "HTTP/2.0")
+	})
+}
+
+func Test_Client_Protocol_HTTP3(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := g.Client().SetProtocol(gclient.ProtocolHTTP3)
+		t.Assert(gerror.Code(err), gcode.CodeNotSupported)
+
+		err = g.Client().SetProtocol("h4")
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var transportTLSConfig *tls.Config
+		gclient.RegisterHTTP3Transport(func(tlsConfig *tls.Config) http.RoundTripper {
+			transportTLSConfig = tlsConfig
+			return http.DefaultTransport
+		})
+		defer gclient.RegisterHTTP3Transport(nil)
+
+		client := g.Client()
+		t.AssertNil(client.SetProtocol(gclient.ProtocolHTTP3))
+		t.Assert(client.Transport, http.DefaultTransport)
+		t.Assert(transportTLSConfig.InsecureSkipVerify, true)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_ProxyPAC(t *testing.T) {
+	// The server acts as both the HTTP proxy and the target server.
+	s := g.Server(guid.S())
+	s.BindHandler("/proxy", func(r *ghttp.Request) {
+		r.Response.Write(r.Host)
+	})
+	s.BindHandler("/proxy.pac", func(r *ghttp.Request) {
+		r.Response.Write("remote")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx       = context.Background()
+		proxyAddr = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+		evaluator = func(ctx context.Context, script, url, host string) (string, error) {
+			if script == "remote" && strings.HasSuffix(host, ".proxy.test") {
+				return "SOCKS4 127.0.0.1:1; PROXY " + proxyAddr + "; DIRECT", nil
+			}
+			return "DIRECT", nil
+		}
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().ProxyPAC(gclient.PACConfig{
+			Url:       fmt.Sprintf("http://%s/proxy.pac", proxyAddr),
+			Evaluator: evaluator,
+		})
+		t.Assert(client.GetContent(ctx, "http://gf.proxy.test/proxy"), "gf.proxy.test")
+		t.Assert(client.GetContent(ctx, fmt.Sprintf("http://%s/proxy", proxyAddr)), proxyAddr)
+
+		// Per-request proxy overriding.
+		t.Assert(client.RequestProxy(gclient.ProxyDirect).GetContent(ctx, "http://gf.proxy.test/proxy"), "")
+		// The overriding never affects other requests.
+		t.Assert(client.GetContent(ctx, "http://gf.proxy.test/proxy"), "gf.proxy.test")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client  = g.Client()
+			pacFile = gfile.Temp(guid.S())
+		)
+		t.AssertNil(gfile.PutContents(pacFile, "remote"))
+		defer gfile.Remove(pacFile)
+
+		t.Assert(client.GetContent(ctx, "http://gf.proxy.test/proxy"), "")
+		t.Assert(client.RequestProxy("http://"+proxyAddr).GetContent(ctx, "http://gf.proxy.test/proxy"), "gf.proxy.test")
+		t.Assert(client.GetContent(ctx, "http://gf.proxy.test/proxy"), "")
+
+		t.AssertNE(client.SetProxyPAC(gclient.PACConfig{Url: pacFile}), nil)
+		t.AssertNE(client.SetProxyPAC(gclient.PACConfig{Url: pacFile + ".none", Evaluator: evaluator}), nil)
+		t.AssertNil(client.SetProxyPAC(gclient.PACConfig{Url: pacFile, Evaluator: evaluator}))
+		t.Assert(client.GetContent(ctx, "http://gf.proxy.test/proxy"), "gf.proxy.test")
+	})
+}
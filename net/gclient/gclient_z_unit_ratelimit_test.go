@@ -0,0 +1,98 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_RateLimit(t *testing.T) {
+	counter := gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/limited", func(r *ghttp.Request) {
+		counter.Add(1)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		host = fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort())
+		url  = "http://" + host + "/limited"
+	)
+	// Blocking mode.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().RateLimit(host, 10, 2)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			t.Assert(client.GetContent(ctx, url), "ok")
+		}
+		// The first 2 requests are allowed by burst, and the others wait 100ms each.
+		t.AssertGE(time.Since(start), 180*time.Millisecond)
+		t.AssertLT(time.Since(start), time.Second)
+	})
+	// Fail-fast mode.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().RateLimit(gclient.RateLimitKeyGlobal, 1, 2).RateLimitMode(gclient.RateLimitModeFailFast)
+		for i := 0; i < 2; i++ {
+			t.Assert(client.GetContent(ctx, url), "ok")
+		}
+		_, err := client.Get(ctx, url)
+		t.Assert(gerror.Is(err, gclient.ErrRateLimited), true)
+		t.Assert(counter.Val(), 2)
+	})
+	// Request group.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client   = g.Client().RateLimit("search", 1, 1).RateLimitMode(gclient.RateLimitModeFailFast)
+			groupCtx = gclient.WithRateLimitGroup(ctx, "search")
+		)
+		t.Assert(client.GetContent(groupCtx, url), "ok")
+		_, err := client.Get(groupCtx, url)
+		t.Assert(gerror.Is(err, gclient.ErrRateLimited), true)
+		t.Assert(client.GetContent(ctx, url), "ok")
+
+		// Limit is removed.
+		client.SetRateLimit("search", 0, 0)
+		t.Assert(client.GetContent(groupCtx, url), "ok")
+	})
+	// Canceled waiting.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().RateLimit(host, 1, 1)
+		t.Assert(client.GetContent(ctx, url), "ok")
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Get(timeoutCtx, url)
+		t.Assert(gerror.Is(err, context.DeadlineExceeded), true)
+	})
+	// Chaining does not affect the original client.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client        = g.Client().RateLimitMode(gclient.RateLimitModeFailFast)
+			limitedClient = client.RateLimit(host, 1, 1)
+		)
+		t.Assert(limitedClient.GetContent(ctx, url), "ok")
+		t.Assert(limitedClient.GetContent(ctx, url), "")
+		t.Assert(client.GetContent(ctx, url), "ok")
+		t.Assert(client.GetContent(ctx, url), "ok")
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Recorder(t *testing.T) {
+	var (
+		counter = gtype.NewInt()
+		s       = g.Server(guid.S())
+	)
+	s.BindHandler("/user", func(r *ghttp.Request) {
+		r.Response.Header().Set("X-Count", fmt.Sprint(counter.Add(1)))
+		r.Response.Writef("%s:%s", r.Method, r.Get("name"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		dir = gfile.Temp(guid.S())
+		url = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	)
+	defer gfile.Remove(dir)
+
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		path := gfile.Join(dir, name)
+		counter.Set(0)
+		// Recording.
+		gtest.C(t, func(t *gtest.T) {
+			recorder, err := gclient.NewRecorder(gclient.RecorderConfig{
+				Path:      path,
+				MatchBody: true,
+			})
+			t.AssertNil(err)
+			client := g.Client().Prefix(url).Use(recorder.Middleware)
+			t.Assert(client.PostContent(ctx, "/user", "name=john"), "POST:john")
+			t.Assert(client.PostContent(ctx, "/user", "name=smith"), "POST:smith")
+			t.Assert(client.GetContent(ctx, "/user?name=john"), "GET:john")
+			t.Assert(len(recorder.Interactions()), 3)
+			t.Assert(counter.Val(), 3)
+			t.Assert(gfile.Exists(path), true)
+		})
+	}
+	s.Shutdown()
+
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		path := gfile.Join(dir, name)
+		// Replaying without network access.
+		gtest.C(t, func(t *gtest.T) {
+			recorder, err := gclient.NewRecorder(gclient.RecorderConfig{
+				Path:      path,
+				Mode:      gclient.RecorderModeReplay,
+				MatchBody: true,
+			})
+			t.AssertNil(err)
+			client := g.Client().Prefix(url).Use(recorder.Middleware)
+
+			resp, err := client.Post(ctx, "/user", "name=smith")
+			t.AssertNil(err)
+			t.Assert(resp.ReadAllString(), "POST:smith")
+			t.Assert(resp.Header.Get("X-Count"), "2")
+			resp.Close()
+
+			t.Assert(client.PostContent(ctx, "/user", "name=john"), "POST:john")
+			t.Assert(client.GetContent(ctx, "/user?name=john"), "GET:john")
+			t.Assert(client.GetContent(ctx, "/user?name=john"), "GET:john")
+
+			_, err = client.Post(ctx, "/user", "name=alice")
+			t.Assert(gerror.Is(err, gclient.ErrRecorderNoInteraction), true)
+		})
+	}
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gclient.NewRecorder(gclient.RecorderConfig{
+			Path: gfile.Join(dir, "not-exist.json"),
+			Mode: gclient.RecorderModeReplay,
+		})
+		t.AssertNE(err, nil)
+	})
+}
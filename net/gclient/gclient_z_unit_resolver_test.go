@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testResolver struct {
+	lookups *gtype.Int
+	ttl     time.Duration
+}
+
+func (r *testResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups.Add(1)
+	if host == "api.example.test" {
+		return []string{"127.0.0.1"}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+type testTTLResolver struct {
+	*testResolver
+}
+
+func (r *testTTLResolver) LookupHostTTL(ctx context.Context, host string) ([]string, time.Duration, error) {
+	addrs, err := r.LookupHost(ctx, host)
+	return addrs, r.ttl, err
+}
+
+func Test_Client_Resolver(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/host", func(r *ghttp.Request) {
+		r.Response.Write(r.Host)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		port = s.GetListenedPort()
+		url  = fmt.Sprintf("http://api.example.test:%d/host", port)
+	)
+	// Static host overrides.
+	gtest.C(t, func(t *gtest.T) {
+		resolver := gclient.NewStaticResolver(map[string][]string{
+			"API.example.test": {"127.0.0.1"},
+		}, nil)
+		client := g.Client().Resolver(resolver)
+		t.Assert(client.GetContent(ctx, url), fmt.Sprintf("api.example.test:%d", port))
+
+		_, err := g.Client().Get(ctx, url)
+		t.AssertNE(err, nil)
+	})
+	// Caching resolver.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			upstream = &testResolver{lookups: gtype.NewInt()}
+			client   = g.Client().Resolver(gclient.NewCachingResolver(upstream, 0))
+			hosts    = garray.NewStrArray(true)
+			traceCtx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+				DNSStart: func(info httptrace.DNSStartInfo) {
+					hosts.Append(info.Host)
+				},
+			})
+		)
+		for i := 0; i < 3; i++ {
+			t.Assert(client.GetContent(traceCtx, url), fmt.Sprintf("api.example.test:%d", port))
+		}
+		t.Assert(upstream.lookups.Val(), 1)
+		t.Assert(hosts.Slice(), []string{"api.example.test", "api.example.test", "api.example.test"})
+
+		// Failed lookups are not cached.
+		failedUrl := fmt.Sprintf("http://not-exist.example.test:%d/host", port)
+		for i := 0; i < 2; i++ {
+			_, err := client.Get(ctx, failedUrl)
+			t.AssertNE(err, nil)
+		}
+		t.Assert(upstream.lookups.Val(), 3)
+	})
+	// TTL of resolver is honored.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			upstream = &testTTLResolver{&testResolver{lookups: gtype.NewInt(), ttl: 100 * time.Millisecond}}
+			resolver = gclient.NewCachingResolver(upstream, time.Hour)
+		)
+		for i := 0; i < 2; i++ {
+			addrs, err := resolver.LookupHost(ctx, "api.example.test")
+			t.AssertNil(err)
+			t.Assert(addrs, []string{"127.0.0.1"})
+		}
+		t.Assert(upstream.lookups.Val(), 1)
+
+		time.Sleep(150 * time.Millisecond)
+		_, _ = resolver.LookupHost(ctx, "api.example.test")
+		t.Assert(upstream.lookups.Val(), 2)
+
+		resolver.Clear()
+		_, _ = resolver.LookupHost(ctx, "api.example.test")
+		t.Assert(upstream.lookups.Val(), 3)
+	})
+}
@@ -0,0 +1,236 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_RetryPolicy_Backoff(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		policy := gclient.RetryPolicy{
+			InitialInterval: 100 * time.Millisecond,
+			MaxInterval:     time.Second,
+			Multiplier:      2,
+		}
+		t.Assert(policy.Backoff(0), time.Duration(0))
+		t.Assert(policy.Backoff(1), 100*time.Millisecond)
+		t.Assert(policy.Backoff(2), 200*time.Millisecond)
+		t.Assert(policy.Backoff(3), 400*time.Millisecond)
+		t.Assert(policy.Backoff(5), time.Second)
+		t.Assert(policy.Backoff(1000), time.Second)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		policy := gclient.RetryPolicy{
+			InitialInterval: 100 * time.Millisecond,
+			Multiplier:      2,
+			Jitter:          0.5,
+		}
+		for i := 0; i < 100; i++ {
+			interval := policy.Backoff(2)
+			t.AssertGE(interval, 100*time.Millisecond)
+			t.AssertLE(interval, 300*time.Millisecond)
+		}
+		t.AssertGT(gclient.RetryPolicy{InitialInterval: time.Second, Multiplier: 10}.Backoff(1000), time.Duration(0))
+	})
+}
+
+func Test_Client_RetryPolicy(t *testing.T) {
+	var (
+		counter = gtype.NewInt()
+		s       = g.Server(guid.S())
+	)
+	s.BindHandler("/unavailable", func(r *ghttp.Request) {
+		if counter.Add(1)%3 != 0 {
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	// Retry by status code.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(url).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       3,
+			InitialInterval:  10 * time.Millisecond,
+			Multiplier:       2,
+			Jitter:           0.1,
+			RetryStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+		t.Assert(client.GetContent(ctx, "/unavailable"), "ok")
+		t.Assert(counter.Val(), 3)
+	})
+	// Max retries exceeded.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(url).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       1,
+			RetryStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+		resp, err := client.Get(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.Assert(counter.Val(), 2)
+	})
+	// Custom predicate.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(url).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries: 5,
+			RetryIf: func(resp *http.Response, err error) bool {
+				return err == nil && resp.StatusCode >= 500
+			},
+		})
+		t.Assert(client.GetContent(ctx, "/unavailable"), "ok")
+		t.Assert(counter.Val(), 3)
+	})
+	// Max elapsed time.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(url).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       5,
+			InitialInterval:  200 * time.Millisecond,
+			MaxElapsedTime:   100 * time.Millisecond,
+			RetryStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+		resp, err := client.Get(ctx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.Assert(counter.Val(), 1)
+	})
+	// Canceled context stops retrying.
+	gtest.C(t, func(t *gtest.T) {
+		counter.Set(0)
+		client := g.Client().Prefix(url).RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       5,
+			InitialInterval:  time.Second,
+			RetryStatusCodes: []int{http.StatusServiceUnavailable},
+		})
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		resp, err := client.Get(timeoutCtx, "/unavailable")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.AssertLT(time.Since(start), time.Second)
+		t.Assert(counter.Val(), 1)
+	})
+}
+
+func Test_Client_Retry_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			counter = gtype.NewInt()
+			client  = g.Client().RetryPolicy(gclient.RetryPolicy{
+				MaxRetries:      2,
+				InitialInterval: 10 * time.Millisecond,
+				RetryIf: func(resp *http.Response, err error) bool {
+					counter.Add(1)
+					return err != nil
+				},
+			})
+		)
+		_, err := client.Get(ctx, "http://127.0.0.1:1/not-exist")
+		t.AssertNE(err, nil)
+		t.Assert(counter.Val(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Retry(2, 10*time.Millisecond)
+		start := time.Now()
+		_, err := client.Get(ctx, "http://127.0.0.1:1/not-exist")
+		t.AssertNE(err, nil)
+		t.AssertGE(time.Since(start), 20*time.Millisecond)
+	})
+}
+
+func Test_Client_RetryPolicy_RetryAfter(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mock      = gclient.NewMockTransport()
+			client    = g.Client()
+			intervals []time.Duration
+		)
+		mock.On("GET", "/seconds").Reply(http.StatusTooManyRequests).ReplyHeader("Retry-After", "1").Times(1)
+		mock.On("GET", "/seconds").Reply(http.StatusOK, "ok")
+		mock.On("GET", "/date").Reply(http.StatusServiceUnavailable).
+			ReplyHeader("Retry-After", time.Now().Add(2*time.Second).UTC().Format(http.TimeFormat)).Times(1)
+		mock.On("GET", "/date").Reply(http.StatusOK, "ok")
+		mock.On("GET", "/ratelimit").Reply(http.StatusTooManyRequests).ReplyHeader("RateLimit-Reset", "1").Times(1)
+		mock.On("GET", "/ratelimit").Reply(http.StatusOK, "ok")
+		mock.On("GET", "/structured").Reply(http.StatusTooManyRequests).
+			ReplyHeader("RateLimit", "limit=10, remaining=0, reset=1").Times(1)
+		mock.On("GET", "/structured").Reply(http.StatusOK, "ok")
+		client.Transport = mock
+		client.SetRetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       1,
+			InitialInterval:  10 * time.Millisecond,
+			RetryStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+			OnRetryWait: func(ctx context.Context, retry int, interval time.Duration, resp *http.Response, err error) {
+				intervals = append(intervals, interval)
+			},
+		})
+		for _, path := range []string{"/date", "/seconds", "/ratelimit", "/structured"} {
+			start := time.Now()
+			t.Assert(client.GetContent(ctx, "http://mock.test"+path), "ok")
+			t.AssertGE(time.Since(start), 900*time.Millisecond)
+		}
+		t.Assert(len(intervals), 4)
+		t.AssertGT(intervals[0], 900*time.Millisecond)
+		t.Assert(intervals[1], time.Second)
+		t.Assert(intervals[2], time.Second)
+		t.Assert(intervals[3], time.Second)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mock   = gclient.NewMockTransport()
+			client = g.Client()
+		)
+		mock.On("GET", "/long").Reply(http.StatusTooManyRequests).ReplyHeader("Retry-After", "60").Times(1)
+		mock.On("GET", "/long").Reply(http.StatusOK, "ok")
+		client.Transport = mock
+
+		// The server requires waiting too long.
+		resp, err := client.RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       1,
+			RetryStatusCodes: []int{http.StatusTooManyRequests},
+			MaxRetryAfter:    time.Second,
+		}).Get(ctx, "http://mock.test/long")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusTooManyRequests)
+		resp.Close()
+
+		// The header is ignored.
+		start := time.Now()
+		t.Assert(client.RetryPolicy(gclient.RetryPolicy{
+			MaxRetries:       1,
+			RetryStatusCodes: []int{http.StatusTooManyRequests},
+			IgnoreRetryAfter: true,
+		}).GetContent(ctx, "http://mock.test/long"), "ok")
+		t.AssertLT(time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Signer_AWSV4(t *testing.T) {
+	// Example of AWS Signature Version 4 documentation.
+	gtest.C(t, func(t *gtest.T) {
+		req, err := http.NewRequest(
+			http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil,
+		)
+		t.AssertNil(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		signer := &gclient.AWSV4Signer{
+			AccessKeyId:     "AKIDEXAMPLE",
+			SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			Region:          "us-east-1",
+			Service:         "iam",
+		}
+		err = signer.Sign(req, []byte{}, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+		t.AssertNil(err)
+		t.Assert(req.Header.Get("X-Amz-Date"), "20150830T123600Z")
+		t.Assert(
+			req.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+				"SignedHeaders=content-type;host;x-amz-date, "+
+				"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		)
+	})
+	// The headers of request are not changed by canonicalization.
+	gtest.C(t, func(t *gtest.T) {
+		req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/", nil)
+		t.AssertNil(err)
+		req.Header.Set("X-Amz-Meta", "  a   b  ")
+		signer := &gclient.AWSV4Signer{
+			AccessKeyId:     "AKIDEXAMPLE",
+			SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			Region:          "us-east-1",
+			Service:         "iam",
+		}
+		t.AssertNil(signer.Sign(req, nil, time.Now()))
+		t.Assert(req.Header.Get("X-Amz-Meta"), "  a   b  ")
+	})
+	// The "." and ".." segments are removed from canonical URI except for S3.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			signTime      = time.Now()
+			authorization = func(service, url string) string {
+				req, err := http.NewRequest(http.MethodGet, url, nil)
+				t.AssertNil(err)
+				signer := &gclient.AWSV4Signer{
+					AccessKeyId:     "AKIDEXAMPLE",
+					SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+					Region:          "us-east-1",
+					Service:         service,
+				}
+				t.AssertNil(signer.Sign(req, []byte{}, signTime))
+				return req.Header.Get("Authorization")
+			}
+		)
+		t.Assert(
+			authorization("iam", "https://iam.amazonaws.com/a/./b/../c/"),
+			authorization("iam", "https://iam.amazonaws.com/a/c/"),
+		)
+		t.Assert(
+			authorization("iam", "https://iam.amazonaws.com/a/../.."),
+			authorization("iam", "https://iam.amazonaws.com/"),
+		)
+		t.AssertNE(
+			authorization("s3", "https://s3.amazonaws.com/a/./b/../c"),
+			authorization("s3", "https://s3.amazonaws.com/a/c"),
+		)
+	})
+}
+
+func Test_Client_Signer_OAuth1(t *testing.T) {
+	// Example of Twitter documentation "Creating a signature".
+	gtest.C(t, func(t *gtest.T) {
+		req, err := http.NewRequest(
+			http.MethodPost, "https://api.twitter.com/1.1/statuses/update.json?include_entities=true", nil,
+		)
+		t.AssertNil(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		signer := &gclient.OAuth1Signer{
+			ConsumerKey:    "xvz1evFS4wEEPTGEFPHBog",
+			ConsumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+			Token:          "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+			TokenSecret:    "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2YPi5kE",
+			Nonce: func() string {
+				return "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg"
+			},
+		}
+		body := []byte("status=Hello%20Ladies%20%2b%20Gentlemen%2c%20a%20signed%20OAuth%20request%21")
+		err = signer.Sign(req, body, time.Unix(1318622958, 0))
+		t.AssertNil(err)
+		authorization := req.Header.Get("Authorization")
+		t.Assert(strings.HasPrefix(authorization, "OAuth "), true)
+		t.AssertIN(`oauth_signature="hCtSmYh%2BiHYCEqBWrE7C7hYmtUk%3D"`, strings.Split(authorization[6:], ", "))
+		t.AssertIN(`oauth_timestamp="1318622958"`, strings.Split(authorization[6:], ", "))
+	})
+}
+
+func Test_Client_Signer_Middleware(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/signed", func(r *ghttp.Request) {
+		r.Response.Write(fmt.Sprintf(
+			"%s|%s|%s",
+			r.Header.Get("Authorization"), r.Header.Get("X-Timestamp"), r.GetBodyString(),
+		))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx = context.Background()
+		url = fmt.Sprintf("http://127.0.0.1:%d/signed", s.GetListenedPort())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.Use(gclient.SignerHMAC(gclient.HMACSigner{
+			KeyId:         "key1",
+			Secret:        []byte("secret"),
+			SignedHeaders: []string{"Content-Type"},
+		}))
+		array := strings.Split(client.PostContent(ctx, url, "a=1&b=2"), "|")
+		t.Assert(len(array), 3)
+		t.Assert(strings.HasPrefix(array[0], "HMAC-SHA256 KeyId=key1, SignedHeaders=content-type;host;x-timestamp, Signature="), true)
+		t.AssertNE(array[1], "")
+		t.Assert(array[2], "a=1&b=2")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.Use(gclient.SignerHMAC(gclient.HMACSigner{KeyId: "key1"}))
+		_, err := client.Post(ctx, url, "a=1")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.Use(gclient.SignerAWSV4(gclient.AWSV4Signer{
+			AccessKeyId:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			SessionToken:    "token",
+			Region:          "us-east-1",
+			Service:         "s3",
+		}))
+		client.Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			t.AssertNE(r.Header.Get("X-Amz-Content-Sha256"), "")
+			t.Assert(r.Header.Get("X-Amz-Security-Token"), "token")
+			return c.Next(r)
+		})
+		array := strings.Split(client.PostContent(ctx, url, "a=1"), "|")
+		t.Assert(len(array), 3)
+		t.Assert(strings.HasPrefix(array[0], "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), true)
+		t.Assert(strings.Contains(array[0], "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token,"), true)
+		t.Assert(array[2], "a=1")
+	})
+}
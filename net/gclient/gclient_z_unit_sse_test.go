@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_SSE(t *testing.T) {
+	var (
+		connections  = gtype.NewInt()
+		lastEventIds = garray.NewStrArray(true)
+		s            = g.Server(guid.S())
+	)
+	s.BindHandler("/events", func(r *ghttp.Request) {
+		lastEventIds.Append(r.Header.Get("Last-Event-ID"))
+		r.Response.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		switch connections.Add(1) {
+		case 1:
+			r.Response.Write("retry: 10\n\n")
+			r.Response.Writef("id: 1\ndata: %s-1\n\n", r.Get("prompt").String())
+			r.Response.Writef("id: 2\ndata: %s-2\n\n", r.Get("prompt").String())
+		case 2:
+			// Failed connection is reconnected.
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+		default:
+			r.Response.Write("id: 3\nevent: done\ndata: end\n\n")
+		}
+	})
+	s.BindHandler("/no-content", func(r *ghttp.Request) {
+		r.Response.WriteHeader(http.StatusNoContent)
+	})
+	s.BindHandler("/not-sse", func(r *ghttp.Request) {
+		r.Response.Write("plain")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			errDone = errors.New("done")
+			events  = garray.NewStrArray()
+			client  = g.Client().Prefix(url)
+		)
+		err := client.SSE(ctx, "/events", func(ctx context.Context, event *gclient.StreamEvent) error {
+			events.Append(fmt.Sprintf("%s:%s:%s", event.Id, event.Event, event.Data))
+			if event.Event == "done" {
+				return errDone
+			}
+			return nil
+		}, gclient.SSEOption{
+			Method: http.MethodPost,
+			Data:   g.Map{"prompt": "hi"},
+		})
+		t.Assert(err, errDone)
+		t.Assert(events.Slice(), []string{"1:message:hi-1", "2:message:hi-2", "3:done:end"})
+		t.Assert(lastEventIds.Slice(), []string{"", "2", "2"})
+		t.Assert(connections.Val(), 3)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(url)
+		err := client.SSE(ctx, "/no-content", func(ctx context.Context, event *gclient.StreamEvent) error {
+			return nil
+		})
+		t.AssertNil(err)
+
+		err = client.SSE(ctx, "/not-sse", func(ctx context.Context, event *gclient.StreamEvent) error {
+			return nil
+		})
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := g.Client().SSE(ctx, "http://127.0.0.1:1/events", func(ctx context.Context, event *gclient.StreamEvent) error {
+			return nil
+		}, gclient.SSEOption{
+			MaxReconnects: 2,
+			Backoff:       gclient.RetryPolicy{InitialInterval: 10 * time.Millisecond},
+		})
+		t.AssertNE(err, nil)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err = g.Client().SSE(timeoutCtx, "http://127.0.0.1:1/events", func(ctx context.Context, event *gclient.StreamEvent) error {
+			return nil
+		})
+		t.Assert(err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_Stream(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/lines", func(r *ghttp.Request) {
+		for i := 1; i <= 3; i++ {
+			r.Response.Writef("{\"id\":%d,\"name\":\"item%d\"}\n", i, i)
+			r.Response.Flush()
+		}
+		r.Response.Write("\r\n")
+	})
+	s.BindHandler("/events", func(r *ghttp.Request) {
+		r.Response.Header().Set("Content-Type", "text/event-stream")
+		r.Response.Write(": keep-alive\n\n")
+		r.Response.Write("id: 1\nevent: greeting\ndata: hello\ndata: world\nretry: 3000\n\n")
+		r.Response.Flush()
+		r.Response.Write("data: " + r.Get("name").String() + "\n\n")
+		r.Response.Write("data: last")
+	})
+	s.BindHandler("/large", func(r *ghttp.Request) {
+		for i := 0; i < 100; i++ {
+			r.Response.Write(fmt.Sprintf("%04d\n", i))
+			r.Response.Flush()
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	// Line-delimited JSON.
+	gtest.C(t, func(t *gtest.T) {
+		stream, err := g.Client().Prefix(url).GetStream(ctx, "/lines")
+		t.AssertNil(err)
+		defer stream.Close()
+
+		type Item struct {
+			Id   int
+			Name string
+		}
+		var items []Item
+		for {
+			var item Item
+			if err = stream.ReadJSON(&item); err != nil {
+				break
+			}
+			items = append(items, item)
+		}
+		t.Assert(err, io.EOF)
+		t.Assert(items, []Item{{1, "item1"}, {2, "item2"}, {3, "item3"}})
+	})
+	// Server-Sent Events.
+	gtest.C(t, func(t *gtest.T) {
+		stream, err := g.Client().Prefix(url).PostStream(ctx, "/events", g.Map{"name": "john"})
+		t.AssertNil(err)
+		defer stream.Close()
+
+		event, err := stream.ReadEvent()
+		t.AssertNil(err)
+		t.Assert(event.Id, "1")
+		t.Assert(event.Event, "greeting")
+		t.Assert(event.Data, "hello\nworld")
+		t.Assert(event.Retry, 3*time.Second)
+
+		event, err = stream.ReadEvent()
+		t.AssertNil(err)
+		t.Assert(event.Event, "message")
+		t.Assert(event.Data, "john")
+
+		event, err = stream.ReadEvent()
+		t.AssertNil(err)
+		t.Assert(event.Data, "last")
+
+		_, err = stream.ReadEvent()
+		t.Assert(err, io.EOF)
+	})
+	// Incremental reading.
+	gtest.C(t, func(t *gtest.T) {
+		stream, err := g.Client().Prefix(url).DoStream(ctx, "GET", "/large")
+		t.AssertNil(err)
+		defer stream.Close()
+
+		line, err := stream.ReadLine()
+		t.AssertNil(err)
+		t.Assert(line, "0000")
+
+		buffer := make([]byte, 5)
+		_, err = io.ReadFull(stream, buffer)
+		t.AssertNil(err)
+		t.Assert(buffer, "0001\n")
+
+		rest, err := io.ReadAll(stream)
+		t.AssertNil(err)
+		t.Assert(len(rest), 98*5)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := g.Client().GetStream(ctx, "http://127.0.0.1:1/not-exist")
+		t.AssertNE(err, nil)
+	})
+}
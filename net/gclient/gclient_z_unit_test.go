@@ -414,6 +414,35 @@ func Test_Client_Middleware(t *testing.T) {
 	})
 }
 
+func Test_Client_DoHttpRequest(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write(r.Header.Get("X-Middleware"), ":", r.GetBodyString())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client().Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			r.Header.Set("X-Middleware", "m")
+			return c.Next(r)
+		})
+		req, err := http.NewRequest(
+			http.MethodPost,
+			fmt.Sprintf("http://127.0.0.1:%d/", s.GetListenedPort()),
+			bytes.NewReader([]byte("file=@file:/etc/hosts")),
+		)
+		t.AssertNil(err)
+		resp, err := c.DoHttpRequest(req)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "m:file=@file:/etc/hosts")
+	})
+}
+
 func Test_Client_Agent(t *testing.T) {
 	s := g.Server(guid.S())
 	s.BindHandler("/", func(r *ghttp.Request) {
@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_TokenSource(t *testing.T) {
+	var validToken = gtype.NewString("token1")
+	s := g.Server(guid.S())
+	s.BindHandler("/token", func(r *ghttp.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validToken.Val() {
+			r.Response.WriteStatus(http.StatusUnauthorized)
+			return
+		}
+		r.Response.Write(r.Header.Get("Authorization"), "|", r.GetBodyString())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx = context.Background()
+		url = fmt.Sprintf("http://127.0.0.1:%d/token", s.GetListenedPort())
+	)
+	// Token cached and refreshed on 401.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			fetched = gtype.NewInt()
+			client  = g.Client().TokenSource(gclient.TokenSourceFunc(func(ctx context.Context) (*gclient.Token, error) {
+				return &gclient.Token{AccessToken: fmt.Sprintf("token%d", fetched.Add(1))}, nil
+			}))
+		)
+		t.Assert(client.PostContent(ctx, url, "a=1"), "Bearer token1|a=1")
+		t.Assert(client.PostContent(ctx, url, "a=2"), "Bearer token1|a=2")
+		t.Assert(fetched.Val(), 1)
+
+		validToken.Set("token2")
+		t.Assert(client.PostContent(ctx, url, "a=3"), "Bearer token2|a=3")
+		t.Assert(fetched.Val(), 2)
+
+		// It refreshes only once for each request.
+		validToken.Set("unknown")
+		resp, err := client.Post(ctx, url)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+		t.Assert(fetched.Val(), 3)
+	})
+	// Expired token.
+	gtest.C(t, func(t *gtest.T) {
+		validToken.Set("token")
+		var (
+			fetched = gtype.NewInt()
+			client  = g.Client().TokenSource(gclient.TokenSourceFunc(func(ctx context.Context) (*gclient.Token, error) {
+				fetched.Add(1)
+				return &gclient.Token{AccessToken: "token", Expiry: time.Now().Add(time.Second)}, nil
+			}))
+		)
+		t.Assert(client.GetContent(ctx, url), "Bearer token|")
+		t.Assert(client.GetContent(ctx, url), "Bearer token|")
+		t.Assert(fetched.Val(), 2)
+	})
+	// Single flight refreshing.
+	gtest.C(t, func(t *gtest.T) {
+		validToken.Set("token")
+		var (
+			wg      sync.WaitGroup
+			fetched = gtype.NewInt()
+			client  = g.Client().TokenSource(gclient.TokenSourceFunc(func(ctx context.Context) (*gclient.Token, error) {
+				fetched.Add(1)
+				time.Sleep(100 * time.Millisecond)
+				return &gclient.Token{AccessToken: "token", TokenType: "Bearer"}, nil
+			}))
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				t.Assert(client.GetContent(ctx, url), "Bearer token|")
+			}()
+		}
+		wg.Wait()
+		t.Assert(fetched.Val(), 1)
+	})
+	// Failed token source.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().TokenSource(gclient.TokenSourceFunc(func(ctx context.Context) (*gclient.Token, error) {
+			return nil, errors.New("unavailable")
+		}))
+		_, err := client.Get(ctx, url)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_PostMultipart(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []byte
+		ranges   = garray.NewStrArray(true)
+		s        = g.Server(guid.S())
+	)
+	readUploadFile := func(r *ghttp.Request) []byte {
+		f, err := r.GetUploadFile("file").Open()
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		content, _ := io.ReadAll(f)
+		return content
+	}
+	s.BindHandler("/upload", func(r *ghttp.Request) {
+		content := readUploadFile(r)
+		r.Response.Writef("%s:%s:%s", r.Get("name"), r.GetUploadFile("file").Filename, content)
+	})
+	s.BindHandler("/chunk", func(r *ghttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		contentRange := r.Header.Get("Content-Range")
+		ranges.Append(contentRange)
+		var start, end, total int
+		_, _ = fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total)
+		if start != len(received) {
+			r.Response.WriteStatus(http.StatusConflict)
+			return
+		}
+		received = append(received, readUploadFile(r)...)
+		if len(received) < total {
+			r.Response.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(received)-1))
+			return
+		}
+		r.Response.Write(r.Get("name"), ":", string(received))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		url     = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		dir     = gfile.Temp(guid.S())
+		path    = gfile.Join(dir, "data.txt")
+		content = strings.Repeat("0123456789", 10)
+	)
+	if err := gfile.PutContents(path, content); err != nil {
+		t.Fatal(err)
+	}
+	defer gfile.Remove(dir)
+
+	gtest.C(t, func(t *gtest.T) {
+		var progresses []gclient.UploadProgress
+		resp, err := g.Client().Prefix(url).PostMultipart(ctx, "/upload", gclient.MultipartOption{
+			Fields: map[string]string{"name": "john"},
+			Files: []gclient.UploadFile{{
+				FieldName: "file",
+				Path:      path,
+				OnProgress: func(ctx context.Context, progress gclient.UploadProgress) {
+					progresses = append(progresses, progress)
+				},
+			}},
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "john:data.txt:"+content)
+		t.AssertGT(len(progresses), 0)
+		t.Assert(progresses[len(progresses)-1], gclient.UploadProgress{
+			FieldName: "file",
+			FileName:  "data.txt",
+			Uploaded:  100,
+			Total:     100,
+		})
+	})
+	// Chunked uploading.
+	gtest.C(t, func(t *gtest.T) {
+		var uploaded = garray.NewIntArray()
+		resp, err := g.Client().Prefix(url).PostMultipart(ctx, "/chunk", gclient.MultipartOption{
+			Fields: map[string]string{"name": "john"},
+			Files: []gclient.UploadFile{{
+				FieldName: "file",
+				FileName:  "chunked.txt",
+				Path:      path,
+				OnProgress: func(ctx context.Context, progress gclient.UploadProgress) {
+					uploaded.Append(int(progress.Uploaded))
+				},
+			}},
+			ChunkSize: 40,
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "john:"+content)
+		t.Assert(ranges.Slice(), []string{"bytes 0-39/100", "bytes 40-79/100", "bytes 80-99/100"})
+		t.Assert(uploaded.Contains(40), true)
+		t.Assert(uploaded.Contains(80), true)
+		t.Assert(uploaded.Contains(100), true)
+	})
+	// Resumed chunked uploading.
+	gtest.C(t, func(t *gtest.T) {
+		mu.Lock()
+		received = []byte(content[:50])
+		mu.Unlock()
+		ranges.Clear()
+
+		client := g.Client().Prefix(url)
+		_, err := client.PostMultipart(ctx, "/chunk", gclient.MultipartOption{
+			Files:     []gclient.UploadFile{{FieldName: "file", Path: path}},
+			ChunkSize: 40,
+		})
+		t.AssertNE(err, nil)
+
+		resp, err := client.PostMultipart(ctx, "/chunk", gclient.MultipartOption{
+			Files:     []gclient.UploadFile{{FieldName: "file", Path: path, Offset: 50}},
+			ChunkSize: 40,
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), ":"+content)
+		t.Assert(ranges.Slice(), []string{"bytes 0-39/100", "bytes 50-89/100", "bytes 90-99/100"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := g.Client().Prefix(url).PostMultipart(ctx, "/upload", gclient.MultipartOption{
+			Files: []gclient.UploadFile{{FieldName: "file", Path: gfile.Join(dir, "not-exist")}},
+		})
+		t.AssertNE(err, nil)
+	})
+}
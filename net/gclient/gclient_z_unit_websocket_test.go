@@ -0,0 +1,161 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Client_WebSocket(t *testing.T) {
+	var connections = gtype.NewInt()
+	s := g.Server(guid.S())
+	s.BindHandler("/ws", func(r *ghttp.Request) {
+		ws, err := r.WebSocket()
+		if err != nil {
+			r.Exit()
+		}
+		defer ws.Close()
+		connection := connections.Add(1)
+		for {
+			msgType, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			// The first connection is broken after the first message.
+			if string(msg) == "break" && connection == 1 {
+				return
+			}
+			if string(msg) == "header" {
+				msg = []byte(r.Header.Get("X-Test"))
+			}
+			if err = ws.WriteMessage(msgType, msg); err != nil {
+				return
+			}
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	// No closing in case of DATA RACE due to keep alive connection of WebSocket.
+	// defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		ctx = context.Background()
+		url = fmt.Sprintf("ws://127.0.0.1:%d/ws", s.GetListenedPort())
+	)
+	// Messages and middleware.
+	gtest.C(t, func(t *gtest.T) {
+		connections.Set(0)
+		var handshakes = gtype.NewInt()
+		client := g.Client().Header(g.MapStrStr{"X-Test": "test"})
+		client.Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+			handshakes.Add(1)
+			return c.Next(r)
+		})
+		conn, err := client.WebSocket(ctx, url)
+		t.AssertNil(err)
+		defer conn.Close()
+		t.Assert(handshakes.Val(), 1)
+
+		t.AssertNil(conn.WriteText("hello"))
+		text, err := conn.ReadText()
+		t.AssertNil(err)
+		t.Assert(text, "hello")
+
+		t.AssertNil(conn.WriteText("header"))
+		text, err = conn.ReadText()
+		t.AssertNil(err)
+		t.Assert(text, "test")
+
+		t.AssertNil(conn.WriteJSON(g.Map{"id": 1}))
+		var data g.Map
+		t.AssertNil(conn.ReadJSON(&data))
+		t.Assert(data, g.Map{"id": 1})
+
+		t.AssertNil(conn.Close())
+		_, err = conn.ReadText()
+		t.Assert(gerror.Is(err, gclient.ErrWebSocketClosed), true)
+		t.Assert(gerror.Is(conn.WriteText("closed"), gclient.ErrWebSocketClosed), true)
+	})
+	// Reconnection.
+	gtest.C(t, func(t *gtest.T) {
+		connections.Set(0)
+		var connects = gtype.NewInt()
+		conn, err := g.Client().WebSocket(ctx, url, gclient.WebSocketOption{
+			Backoff: gclient.RetryPolicy{InitialInterval: 10 * time.Millisecond},
+			OnConnect: func(ctx context.Context, conn *gclient.WebSocketConn) {
+				connects.Add(1)
+			},
+		})
+		t.AssertNil(err)
+		defer conn.Close()
+		t.AssertNil(conn.WriteText("break"))
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = conn.WriteText("after")
+		}()
+		text, err := conn.ReadText()
+		t.AssertNil(err)
+		t.Assert(text, "after")
+		t.Assert(connects.Val(), 2)
+	})
+	// No reconnection.
+	gtest.C(t, func(t *gtest.T) {
+		connections.Set(0)
+		conn, err := g.Client().WebSocket(ctx, url, gclient.WebSocketOption{MaxReconnects: -1})
+		t.AssertNil(err)
+		defer conn.Close()
+		t.AssertNil(conn.WriteText("break"))
+		_, err = conn.ReadText()
+		t.AssertNE(err, nil)
+	})
+	// Heartbeat keeps the idle connection alive.
+	gtest.C(t, func(t *gtest.T) {
+		connections.Set(0)
+		conn, err := g.Client().WebSocket(ctx, url, gclient.WebSocketOption{
+			PingInterval:  50 * time.Millisecond,
+			PongTimeout:   150 * time.Millisecond,
+			MaxReconnects: -1,
+		})
+		t.AssertNil(err)
+		defer conn.Close()
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_ = conn.WriteText("alive")
+		}()
+		text, err := conn.ReadText()
+		t.AssertNil(err)
+		t.Assert(text, "alive")
+		t.Assert(connections.Val(), 1)
+	})
+	// Context done.
+	gtest.C(t, func(t *gtest.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		conn, err := g.Client().WebSocket(ctx, url)
+		t.AssertNil(err)
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(conn.Conn())
+	})
+	// Handshake failure.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := g.Client().WebSocket(ctx, fmt.Sprintf("ws://127.0.0.1:%d/none", s.GetListenedPort()))
+		t.AssertNE(err, nil)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gtype"
@@ -31,21 +32,33 @@ import (
 type (
 	// Server wraps the http.Server and provides more rich features.
 	Server struct {
-		instance         string                    // Instance name of current HTTP server.
-		config           ServerConfig              // Server configuration.
-		plugins          []Plugin                  // Plugin array to extend server functionality.
-		servers          []*gracefulServer         // Underlying http.Server array.
-		serverCount      *gtype.Int                // Underlying http.Server number for internal usage.
-		closeChan        chan struct{}             // Used for underlying server closing event notification.
-		serveTree        map[string]interface{}    // The route maps tree.
-		serveCache       *gcache.Cache             // Server caches for internal usage.
-		routesMap        map[string][]*HandlerItem // Route map mainly for route dumps and repeated route checks.
-		statusHandlerMap map[string][]HandlerFunc  // Custom status handler map.
-		sessionManager   *gsession.Manager         // Session manager.
-		openapi          *goai.OpenApiV3           // The OpenApi specification management object.
-		serviceMu        sync.Mutex                // Concurrent safety for operations of attribute service.
-		service          gsvc.Service              // The service for Registry.
-		registrar        gsvc.Registrar            // Registrar for service register.
+		instance         string                        // Instance name of current HTTP server.
+		config           ServerConfig                  // Server configuration.
+		plugins          []Plugin                      // Plugin array to extend server functionality.
+		servers          []*gracefulServer             // Underlying http.Server array.
+		http3Servers     []*http3Server                // Underlying HTTP/3 server array.
+		http3AltSvc      *gtype.String                 // Header "Alt-Svc" advertising HTTP/3.
+		serverCount      *gtype.Int                    // Underlying http.Server number for internal usage.
+		closeChan        chan struct{}                 // Used for underlying server closing event notification.
+		serveTree        map[string]interface{}        // The route maps tree.
+		serveCache       *gcache.Cache                 // Server caches for internal usage.
+		routesMap        map[string][]*HandlerItem     // Route map mainly for route dumps and repeated route checks.
+		statusHandlerMap map[string][]HandlerFunc      // Custom status handler map.
+		sessionManager   *gsession.Manager             // Session manager.
+		openapi          *goai.OpenApiV3               // The OpenApi specification management object.
+		serviceMu        sync.Mutex                    // Concurrent safety for operations of attribute service.
+		service          gsvc.Service                  // The service for Registry.
+		registrar        gsvc.Registrar                // Registrar for service register.
+		propagator       propagation.TextMapPropagator // Propagator for tracing context, the global one if nil.
+		admission        *admissionController          // Admission control of concurrent requests, nil if not limited.
+		drain            *drainTracker                 // Tracker of in-flight requests and connections for draining.
+		versions         *versionRegistry              // Registry of API versions for negotiation and deprecation.
+		certificates     *certificateReloader          // Reloader of HTTPS certificate from files.
+		live             *liveConfig                   // Configuration values applied live without restart.
+		accessLogMu      sync.RWMutex                  // Concurrent safety for attribute accessLog.
+		accessLog        *accessLogPipeline            // Pipeline of access log, which is rebuilt if configuration changes.
+		jwtMu            sync.RWMutex                  // Concurrent safety for attribute jwtVerifier.
+		jwtVerifier      *jwtVerifier                  // Verifier of MiddlewareJWT using configuration JWT of server.
 	}
 
 	// Router object.
@@ -82,6 +95,7 @@ type (
 		Value           reflect.Value    // Reflect value information for current handler, which is used for extensions of the handler feature.
 		IsStrictRoute   bool             // Whether strict route matching is enabled.
 		ReqStructFields []gstructs.Field // Request struct fields.
+		InjectTypes     []reflect.Type   // Types of injected input parameters following the request struct, see Provide.
 	}
 
 	// HandlerItem is the registered handler for route handling,
@@ -0,0 +1,36 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/httputil"
+)
+
+// MiddlewareBudget is a middleware handler honoring the remaining time budget of request,
+// which is propagated in milliseconds in header "X-Request-Budget" by gclient.Client.SetBudget.
+// It sets the deadline of request context to the remaining budget, so that the downstream calls
+// using the context are canceled if the budget is exhausted.
+func MiddlewareBudget(r *Request) {
+	value := r.Header.Get(httputil.HeaderRequestBudget)
+	if value == "" {
+		r.Middleware.Next()
+		return
+	}
+	remaining, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || remaining < 0 {
+		r.Middleware.Next()
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(remaining)*time.Millisecond)
+	defer cancel()
+	r.SetCtx(ctx)
+	r.Middleware.Next()
+}
@@ -0,0 +1,283 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// CompressEncoder is the encoder compressing response content, which is pooled and reused by Reset.
+type CompressEncoder interface {
+	io.WriteCloser
+
+	// Reset discards the state of encoder and makes it write compressed content to `w`.
+	Reset(w io.Writer)
+}
+
+// CompressEncoderProvider creates and returns a new encoder writing compressed content to `w`.
+type CompressEncoderProvider func(w io.Writer) (CompressEncoder, error)
+
+// CompressConfig is the configuration of compression middleware.
+type CompressConfig struct {
+	// Encodings specifies the content encodings by server preference, which is used if the client accepts
+	// multiple encodings with the same quality. Only the registered ones are used.
+	// It's "zstd", "br", "gzip" and "deflate" in default.
+	Encodings []string
+
+	// MinSize specifies the minimum size in bytes of content to compress, it's 1024 in default.
+	MinSize int
+
+	// ContentTypes specifies the allowed content types to compress, which can be prefix ending with "*"
+	// like "text/*". It's the common text types in default.
+	ContentTypes []string
+}
+
+// compressEncoderItem is the registered encoder with pool.
+type compressEncoderItem struct {
+	provider CompressEncoderProvider
+	pool     *sync.Pool
+}
+
+const (
+	CompressEncodingGzip    = "gzip"
+	CompressEncodingDeflate = "deflate"
+	CompressEncodingBrotli  = "br"
+	CompressEncodingZstd    = "zstd"
+
+	compressDefaultMinSize = 1024
+)
+
+var (
+	// compressDefaultEncodings is the default encodings by server preference.
+	compressDefaultEncodings = []string{
+		CompressEncodingZstd, CompressEncodingBrotli, CompressEncodingGzip, CompressEncodingDeflate,
+	}
+
+	// compressDefaultContentTypes is the default content types to compress.
+	compressDefaultContentTypes = []string{
+		"text/*",
+		"application/json",
+		"application/javascript",
+		"application/xml",
+		"application/xhtml+xml",
+		"application/rss+xml",
+		"application/atom+xml",
+		"application/problem+json",
+		"application/x-ndjson",
+		"image/svg+xml",
+	}
+
+	// compressEncoders is the registered encoders by encoding name.
+	compressEncoders = make(map[string]*compressEncoderItem)
+
+	// compressEncodersMu is the mutex for compressEncoders.
+	compressEncodersMu sync.RWMutex
+)
+
+func init() {
+	RegisterCompressEncoder(CompressEncodingGzip, func(w io.Writer) (CompressEncoder, error) {
+		return gzip.NewWriterLevel(w, gzip.DefaultCompression)
+	})
+	RegisterCompressEncoder(CompressEncodingDeflate, func(w io.Writer) (CompressEncoder, error) {
+		return flate.NewWriter(w, flate.DefaultCompression)
+	})
+}
+
+// RegisterCompressEncoder registers the encoder provider for content `encoding` like "br" and "zstd",
+// which overwrites the existing one. The "gzip" and "deflate" are registered in default, and the others
+// are commonly registered by importing package "github.com/gogf/gf/contrib/net/gcompress/v2".
+func RegisterCompressEncoder(encoding string, provider CompressEncoderProvider) {
+	compressEncodersMu.Lock()
+	defer compressEncodersMu.Unlock()
+	compressEncoders[encoding] = &compressEncoderItem{
+		provider: provider,
+		pool:     &sync.Pool{},
+	}
+}
+
+// getCompressEncoder returns the registered encoder of `encoding`, or nil if it is not registered.
+func getCompressEncoder(encoding string) *compressEncoderItem {
+	compressEncodersMu.RLock()
+	defer compressEncodersMu.RUnlock()
+	return compressEncoders[encoding]
+}
+
+// compress compresses `content` using the pooled encoder.
+func (item *compressEncoderItem) compress(content []byte) ([]byte, error) {
+	var (
+		err     error
+		buffer  = bytes.NewBuffer(make([]byte, 0, len(content)/2))
+		encoder CompressEncoder
+	)
+	if v := item.pool.Get(); v != nil {
+		encoder = v.(CompressEncoder)
+		encoder.Reset(buffer)
+	} else if encoder, err = item.provider(buffer); err != nil {
+		return nil, err
+	}
+	if _, err = encoder.Write(content); err != nil {
+		return nil, err
+	}
+	if err = encoder.Close(); err != nil {
+		return nil, err
+	}
+	item.pool.Put(encoder)
+	return buffer.Bytes(), nil
+}
+
+// MiddlewareCompress returns a middleware handler compressing the buffered response content, which
+// negotiates the content encoding by request header "Accept-Encoding". The content is not compressed
+// if its size is less than MinSize or its type is not allowed, or it is already encoded.
+//
+// It compresses the responses of the routes it is bound to, so the routes can use different configurations
+// by different middlewares. It should be used before the middlewares building the response content,
+// like MiddlewareHandlerResponse, and after MiddlewareETag if they are used together.
+func MiddlewareCompress(config ...CompressConfig) HandlerFunc {
+	var cfg CompressConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if len(cfg.Encodings) == 0 {
+		cfg.Encodings = compressDefaultEncodings
+	}
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = compressDefaultMinSize
+	}
+	if len(cfg.ContentTypes) == 0 {
+		cfg.ContentTypes = compressDefaultContentTypes
+	}
+	return func(r *Request) {
+		r.Middleware.Next()
+		var (
+			response = r.Response
+			header   = response.Header()
+		)
+		if response.IsHeaderWrote() || response.IsHijacked() {
+			return
+		}
+		if response.BufferLength() < cfg.MinSize || header.Get("Content-Encoding") != "" {
+			return
+		}
+		contentType := header.Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(response.Buffer())
+		}
+		if !isContentTypeAllowed(contentType, cfg.ContentTypes) {
+			return
+		}
+		// The response varies by the encoding even if it is not compressed for the current client.
+		header.Add("Vary", "Accept-Encoding")
+		encoding, item := negotiateCompressEncoding(r.Header.Get("Accept-Encoding"), cfg.Encodings)
+		if item == nil {
+			return
+		}
+		content, err := item.compress(response.Buffer())
+		if err != nil {
+			r.Server.Logger().Errorf(r.Context(), `compress response using "%s" failed: %+v`, encoding, err)
+			return
+		}
+		if len(content) >= response.BufferLength() {
+			return
+		}
+		// The strong ETag of uncompressed content is not valid for the compressed one.
+		if etag := header.Get(responseHeaderETag); etag != "" && !gstr.HasPrefix(etag, etagWeakPrefix) {
+			header.Set(responseHeaderETag, etagWeakPrefix+etag)
+		}
+		header.Set("Content-Encoding", encoding)
+		header.Del("Content-Length")
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", contentType)
+		}
+		response.SetBuffer(content)
+	}
+}
+
+// isContentTypeAllowed checks and returns whether `contentType` matches any of `allowedTypes`.
+func isContentTypeAllowed(contentType string, allowedTypes []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowedType := range allowedTypes {
+		if prefix, ok := strings.CutSuffix(allowedType, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if strings.EqualFold(mediaType, allowedType) {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateCompressEncoding returns the registered encoding with the highest quality in `acceptEncoding`,
+// in which the encodings with the same quality are chosen by the order of `encodings`.
+func negotiateCompressEncoding(acceptEncoding string, encodings []string) (string, *compressEncoderItem) {
+	encoding := negotiateEncoding(acceptEncoding, encodings, func(encoding string) bool {
+		return getCompressEncoder(encoding) != nil
+	})
+	if encoding == "" {
+		return "", nil
+	}
+	return encoding, getCompressEncoder(encoding)
+}
+
+// negotiateEncoding returns the available encoding with the highest quality in `acceptEncoding`, in which the
+// encodings with the same quality are chosen by the order of `encodings`. It returns empty if none is acceptable.
+func negotiateEncoding(acceptEncoding string, encodings []string, available func(encoding string) bool) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	var (
+		qualities       = make(map[string]float64)
+		wildcardQuality = -1.0
+	)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		var (
+			name, params, _ = strings.Cut(strings.TrimSpace(part), ";")
+			quality         = 1.0
+		)
+		name = strings.ToLower(strings.TrimSpace(name))
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = v
+			}
+		}
+		if name == "*" {
+			wildcardQuality = quality
+		} else if name != "" {
+			qualities[name] = quality
+		}
+	}
+	var (
+		bestEncoding string
+		bestQuality  float64
+	)
+	for _, encoding := range encodings {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcardQuality
+		}
+		if quality <= bestQuality {
+			continue
+		}
+		if available(encoding) {
+			bestEncoding, bestQuality = encoding, quality
+		}
+	}
+	return bestEncoding
+}
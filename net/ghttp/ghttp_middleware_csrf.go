@@ -0,0 +1,336 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// CsrfMode is the mode storing and verifying CSRF tokens.
+type CsrfMode string
+
+const (
+	// CsrfModeDoubleSubmit stores the token in cookie, and verifies the token submitted
+	// in header or form against the cookie one. It needs no server side storage.
+	// The token is signed with CsrfConfig.Secret and bound to the session id of client,
+	// so that the token cookie cannot be forged or injected by other sites.
+	CsrfModeDoubleSubmit CsrfMode = "double-submit"
+
+	// CsrfModeSynchronizer stores the token in session, and verifies the token submitted
+	// in header or form against the session one.
+	CsrfModeSynchronizer CsrfMode = "synchronizer"
+)
+
+// CsrfConfig is the configuration of CSRF protection middleware.
+type CsrfConfig struct {
+	// Mode is the mode storing and verifying tokens, which is CsrfModeDoubleSubmit in default.
+	Mode CsrfMode
+
+	// Secret is the key signing the tokens in mode CsrfModeDoubleSubmit, which is a random key
+	// created for the middleware in default. It should be configured if the tokens are shared
+	// among multiple servers or should be kept valid after restarting.
+	Secret []byte
+
+	// HeaderName is the request header carrying the token, which is "X-CSRF-Token" in default.
+	HeaderName string
+
+	// FormName is the form field carrying the token, which is "_csrf" in default.
+	FormName string
+
+	// CookieName is the cookie name storing the token in mode CsrfModeDoubleSubmit, and also the
+	// session key storing the token in mode CsrfModeSynchronizer. It's "_csrf" in default.
+	CookieName string
+
+	// CookieDomain is the domain of token cookie, which is the server cookie domain in default.
+	CookieDomain string
+
+	// CookiePath is the path of token cookie, which is "/" in default.
+	CookiePath string
+
+	// CookieMaxAge is the max age of token cookie, which is 24 hours in default.
+	CookieMaxAge time.Duration
+
+	// CookieSameSite is the SameSite property of token cookie, which is http.SameSiteLaxMode in default.
+	CookieSameSite http.SameSite
+
+	// CookieSecure marks the token cookie sent over HTTPS only.
+	CookieSecure bool
+
+	// SafeMethods specifies the request methods that are not verified,
+	// which is "GET", "HEAD", "OPTIONS" and "TRACE" in default.
+	SafeMethods []string
+
+	// ExemptPaths specifies the request paths that are not verified, which supports the patterns
+	// of path.Match like "/webhook/*".
+	ExemptPaths []string
+
+	// ErrorHandler writes the response if the verification fails,
+	// which writes status 403 Forbidden in default.
+	ErrorHandler HandlerFunc
+}
+
+const (
+	csrfDefaultHeaderName               = "X-CSRF-Token"
+	csrfDefaultFormName                 = "_csrf"
+	csrfDefaultCookieName               = "_csrf"
+	csrfDefaultCookiePath               = "/"
+	csrfDefaultCookieMaxAge             = 24 * time.Hour
+	csrfTokenLength                     = 32
+	csrfCtxKeyForConfig     gctx.StrKey = "gHttpCsrfConfig"
+	csrfCtxKeyForToken      gctx.StrKey = "gHttpCsrfToken"
+
+	// CsrfTokenViewKey is the template variable name of CSRF token assigned by MiddlewareCsrf.
+	CsrfTokenViewKey = "CsrfToken"
+
+	// CsrfFieldViewKey is the template variable name of CSRF hidden form field assigned by MiddlewareCsrf.
+	CsrfFieldViewKey = "CsrfField"
+)
+
+// MiddlewareCsrf returns a middleware handler protecting the requests from cross-site request forgery.
+//
+// The token is created for each client and exposed by Request.GetCsrfToken, and it is also assigned to
+// the templates as variables "CsrfToken" and "CsrfField", in which the latter is the hidden form field.
+// The requests of unsafe methods should submit the token in header or form, or else they are rejected.
+func MiddlewareCsrf(config ...CsrfConfig) HandlerFunc {
+	var cfg CsrfConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = CsrfModeDoubleSubmit
+	}
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = make([]byte, csrfTokenLength)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			panic(gerror.WrapCode(gcode.CodeInternalError, err, `create CSRF secret failed`))
+		}
+	}
+	if cfg.HeaderName == "" {
+		cfg.HeaderName = csrfDefaultHeaderName
+	}
+	if cfg.FormName == "" {
+		cfg.FormName = csrfDefaultFormName
+	}
+	if cfg.CookieName == "" {
+		cfg.CookieName = csrfDefaultCookieName
+	}
+	if cfg.CookiePath == "" {
+		cfg.CookiePath = csrfDefaultCookiePath
+	}
+	if cfg.CookieMaxAge == 0 {
+		cfg.CookieMaxAge = csrfDefaultCookieMaxAge
+	}
+	if cfg.CookieSameSite == 0 {
+		cfg.CookieSameSite = http.SameSiteLaxMode
+	}
+	if len(cfg.SafeMethods) == 0 {
+		cfg.SafeMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace}
+	}
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = func(r *Request) {
+			r.Response.WriteStatus(http.StatusForbidden, "invalid CSRF token")
+		}
+	}
+	return func(r *Request) {
+		var (
+			ctx       = r.Context()
+			sessionId = r.GetSessionId()
+			token     = cfg.storedToken(r)
+			generated = false
+		)
+		if token == "" {
+			var err error
+			if token, err = cfg.newToken(r); err != nil {
+				r.Server.Logger().Errorf(ctx, `create CSRF token failed: %+v`, err)
+				r.Response.WriteStatus(http.StatusInternalServerError)
+				return
+			}
+			generated = true
+		}
+		if !gstr.InArray(cfg.SafeMethods, r.Method) && !cfg.isExempt(r.URL.Path) {
+			submitted := r.Header.Get(cfg.HeaderName)
+			if submitted == "" {
+				submitted = r.GetForm(cfg.FormName).String()
+			}
+			if generated || submitted == "" || !cfg.matchToken(submitted, token) {
+				cfg.ErrorHandler(r)
+				return
+			}
+		}
+		if generated {
+			if err := cfg.storeToken(r, token); err != nil {
+				r.Server.Logger().Errorf(ctx, `store CSRF token failed: %+v`, err)
+			}
+		}
+		r.SetCtxVar(csrfCtxKeyForConfig, &cfg)
+		r.SetCtxVar(csrfCtxKeyForToken, token)
+		r.Assigns(map[string]interface{}{
+			CsrfTokenViewKey: token,
+			CsrfFieldViewKey: csrfField(cfg.FormName, token),
+		})
+		r.Middleware.Next()
+
+		// The session might be created or changed by the handler, like signing in on the first visit, and the
+		// token cookie is signed again for the new session. It keeps the random part of the token, so that the
+		// token responded by this request is still valid for the next request carrying the new session id.
+		if cfg.Mode == CsrfModeDoubleSubmit {
+			if newSessionId := csrfSessionId(r); newSessionId != sessionId {
+				random, _, _ := strings.Cut(token, ".")
+				if err := cfg.storeToken(r, random+"."+cfg.signToken(newSessionId, random)); err != nil {
+					r.Server.Logger().Errorf(ctx, `store CSRF token failed: %+v`, err)
+				}
+			}
+		}
+	}
+}
+
+// GetCsrfToken returns the CSRF token of current request, which should be submitted with the requests
+// of unsafe methods. It returns empty string if the request is not handled by MiddlewareCsrf.
+func (r *Request) GetCsrfToken() string {
+	return r.GetCtxVar(csrfCtxKeyForToken).String()
+}
+
+// GetCsrfField returns the hidden form field carrying the CSRF token of current request, which can be
+// rendered in the form directly. It returns empty if the request is not handled by MiddlewareCsrf.
+func (r *Request) GetCsrfField() template.HTML {
+	cfg, ok := r.GetCtxVar(csrfCtxKeyForConfig).Val().(*CsrfConfig)
+	if !ok {
+		return ""
+	}
+	return csrfField(cfg.FormName, r.GetCsrfToken())
+}
+
+// storedToken retrieves and returns the token stored for the client of `r`.
+func (cfg *CsrfConfig) storedToken(r *Request) string {
+	if cfg.Mode == CsrfModeSynchronizer {
+		v, err := r.Session.Get(cfg.CookieName)
+		if err != nil {
+			r.Server.Logger().Errorf(r.Context(), `retrieve CSRF token from session failed: %+v`, err)
+			return ""
+		}
+		return v.String()
+	}
+	// The token cookie that is not signed for the session of client is taken as absent.
+	token := r.Cookie.Get(cfg.CookieName).String()
+	if !cfg.verifyToken(r, token) {
+		return ""
+	}
+	return token
+}
+
+// newToken creates and returns a new token for the client of `r`, which is signed in mode CsrfModeDoubleSubmit
+// in format of "random.signature".
+func (cfg *CsrfConfig) newToken(r *Request) (string, error) {
+	token, err := newCsrfToken()
+	if err != nil || cfg.Mode == CsrfModeSynchronizer {
+		return token, err
+	}
+	return token + "." + cfg.signToken(r.GetSessionId(), token), nil
+}
+
+// verifyToken checks whether `token` is signed for the client of `r`.
+func (cfg *CsrfConfig) verifyToken(r *Request, token string) bool {
+	random, signature, ok := strings.Cut(token, ".")
+	if !ok || random == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(cfg.signToken(r.GetSessionId(), random)))
+}
+
+// matchToken checks whether the `submitted` token matches the stored `token`. In mode CsrfModeDoubleSubmit,
+// only the random parts are compared, as the stored token is already verified for the session of client,
+// and the submitted one might be signed for the session before it is created.
+func (cfg *CsrfConfig) matchToken(submitted, token string) bool {
+	if cfg.Mode == CsrfModeDoubleSubmit {
+		submitted, _, _ = strings.Cut(submitted, ".")
+		token, _, _ = strings.Cut(token, ".")
+	}
+	return subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) == 1
+}
+
+// signToken returns the signature of `random` bound to `sessionId`.
+func (cfg *CsrfConfig) signToken(sessionId, random string) string {
+	mac := hmac.New(sha256.New, cfg.Secret)
+	mac.Write([]byte(sessionId))
+	mac.Write([]byte{0})
+	mac.Write([]byte(random))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// storeToken stores `token` for the client of `r`.
+func (cfg *CsrfConfig) storeToken(r *Request, token string) error {
+	if cfg.Mode == CsrfModeSynchronizer {
+		return r.Session.Set(cfg.CookieName, token)
+	}
+	var domain = cfg.CookieDomain
+	if domain == "" {
+		domain = r.Server.GetCookieDomain()
+	}
+	r.Cookie.SetCookie(cfg.CookieName, token, domain, cfg.CookiePath, cfg.CookieMaxAge, CookieOptions{
+		SameSite: cfg.CookieSameSite,
+		Secure:   cfg.CookieSecure,
+		// The token cookie should be readable by scripts for submitting in header.
+		HttpOnly: false,
+	})
+	return nil
+}
+
+// isExempt checks whether the request `path` is exempted from verification.
+func (cfg *CsrfConfig) isExempt(requestPath string) bool {
+	for _, pattern := range cfg.ExemptPaths {
+		if pattern == requestPath {
+			return true
+		}
+		if matched, _ := path.Match(pattern, requestPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// csrfSessionId returns the session id that the client of `r` carries in the next request,
+// which is the id of the session created or changed in current request if any.
+func csrfSessionId(r *Request) string {
+	if r.Session.IsDirty() {
+		if id, err := r.Session.Id(); err == nil && id != "" {
+			return id
+		}
+	}
+	return r.GetSessionId()
+}
+
+// newCsrfToken creates and returns a random token.
+func newCsrfToken() (string, error) {
+	b := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// csrfField returns the hidden form field named `name` with `token`.
+func csrfField(name, token string) template.HTML {
+	return template.HTML(fmt.Sprintf(
+		`<input type="hidden" name="%s" value="%s">`,
+		template.HTMLEscapeString(name), template.HTMLEscapeString(token),
+	))
+}
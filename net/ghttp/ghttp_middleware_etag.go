@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+)
+
+// ETagConfig is the configuration of ETag middleware.
+type ETagConfig struct {
+	// Weak specifies producing weak ETags like `W/"xxx"`, which is strong in default.
+	// Use weak ETags if the content can be changed by proxies, like compression.
+	Weak bool
+}
+
+// MiddlewareETag returns a middleware handler that computes the ETag from the buffered response content
+// of GET and HEAD requests, and responds status 304 Not Modified if the client has the same content
+// according to the request headers "If-None-Match" and "If-Modified-Since".
+//
+// The ETag set by handlers using Response.SetETag is used instead of computing. It handles the responses
+// with status 200 only, and ignores the responses written directly to the raw writer, like streaming.
+// It should be used before the middlewares building the response content, like MiddlewareHandlerResponse.
+func MiddlewareETag(config ...ETagConfig) HandlerFunc {
+	var cfg ETagConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	return func(r *Request) {
+		r.Middleware.Next()
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			return
+		}
+		if r.Response.Status != 0 && r.Response.Status != http.StatusOK {
+			return
+		}
+		if r.Response.IsHeaderWrote() || r.Response.IsHijacked() {
+			return
+		}
+		if r.Response.Header().Get(responseHeaderETag) == "" {
+			if r.Response.BufferLength() == 0 {
+				return
+			}
+			r.Response.SetETag(computeETag(r.Response.Buffer()), cfg.Weak)
+		}
+		if r.Response.IsNotModified() {
+			r.Response.WriteNotModified()
+		}
+	}
+}
+
+// computeETag computes and returns the entity tag of `content`, which is composed of
+// the length and the hash of content.
+func computeETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return strconv.Itoa(len(content)) + "-" + base64.RawURLEncoding.EncodeToString(sum[:12])
+}
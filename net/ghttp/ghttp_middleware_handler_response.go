@@ -12,6 +12,7 @@ import (
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/i18n/gi18n"
 )
 
 // DefaultHandlerResponse is the default implementation of HandlerResponse.
@@ -59,7 +60,18 @@ func MiddlewareHandlerResponse(r *Request) {
 		if code == gcode.CodeNil {
 			code = gcode.CodeInternalError
 		}
-		msg = err.Error()
+		// The i18n message keys of error are translated with the language of request context.
+		msg = gi18n.TranslateError(r.Context(), err)
+		// The registered code is mapped to its HTTP status and default message.
+		if registration, ok := gcode.GetRegistration(code); ok {
+			if msg == "" {
+				msg = registration.Message
+			}
+			if registration.HttpStatus > 0 &&
+				(r.Response.Status == 0 || r.Response.Status == http.StatusOK) {
+				r.Response.WriteHeader(registration.HttpStatus)
+			}
+		}
 	} else {
 		if r.Response.Status > 0 && r.Response.Status != http.StatusOK {
 			msg = http.StatusText(r.Response.Status)
@@ -79,6 +91,11 @@ func MiddlewareHandlerResponse(r *Request) {
 		}
 	}
 
+	if err != nil && r.Server.config.ErrorResponseFormat == ErrorResponseFormatProblem {
+		r.Response.WriteProblem(NewProblemDetails(r, err))
+		return
+	}
+
 	r.Response.WriteJson(DefaultHandlerResponse{
 		Code:    code.Code(),
 		Message: msg,
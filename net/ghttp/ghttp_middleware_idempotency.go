@@ -0,0 +1,194 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// IdempotencyConfig is the configuration of idempotency middleware.
+type IdempotencyConfig struct {
+	// Header is the request header carrying the idempotency key, which is "Idempotency-Key" in default.
+	Header string
+
+	// TTL is the duration that the first response of a key is stored and replayed, which is 24 hours in default.
+	TTL time.Duration
+
+	// LockTTL is the max duration that a key is locked for processing, after which the key is unlocked
+	// in case that the server crashes during processing. It's 1 minute in default.
+	LockTTL time.Duration
+
+	// Methods specifies the request methods checking idempotency key, which is "POST" and "PATCH" in default.
+	Methods []string
+
+	// Prefix is the prefix of idempotency keys, which distinguishes the middlewares sharing the same Storage.
+	Prefix string
+
+	// KeyFunc returns the scope of caller for request `r`, which scopes the idempotency keys so that
+	// a caller cannot replay the responses of other callers with the same key.
+	// It is the hash of header "Authorization" or session id of the request in default,
+	// and all the anonymous callers share the same scope.
+	KeyFunc func(r *Request) string
+
+	// Storage stores the responses of idempotency keys, which is a new memory storage in default.
+	// Use the redis storage to share the idempotency keys among multiple servers.
+	Storage IdempotencyStorage
+}
+
+// IdempotencyRecord is the stored response of an idempotency key.
+type IdempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"` // Fingerprint of the request, which is the hash of method, path and body.
+	Status      int         `json:"status"`      // Response status.
+	Header      http.Header `json:"header"`      // Response header, excluding "Set-Cookie".
+	Body        []byte      `json:"body"`        // Response body.
+}
+
+// IdempotencyStorage stores the responses of idempotency keys.
+type IdempotencyStorage interface {
+	// Lock locks `key` for processing in `ttl` if it is not locked and has no record.
+	// It returns the stored record if the key was done, or else false if the key is locked by another request.
+	Lock(ctx context.Context, key string, ttl time.Duration) (record *IdempotencyRecord, locked bool, err error)
+
+	// Save stores `record` of the locked `key` for `ttl` and unlocks the key.
+	Save(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+
+	// Unlock unlocks `key` without record, so that the requests of the key can be retried.
+	Unlock(ctx context.Context, key string) error
+}
+
+const (
+	idempotencyDefaultHeader  = "Idempotency-Key"
+	idempotencyDefaultTTL     = 24 * time.Hour
+	idempotencyDefaultLockTTL = time.Minute
+	idempotencyHeaderReplayed = "Idempotent-Replayed"
+)
+
+// MiddlewareIdempotency returns a middleware handler making the requests with idempotency key safe to retry.
+//
+// The first response of a key is stored and replayed for the retried requests of the same key with header
+// "Idempotent-Replayed: true". It responds status 409 Conflict if the request of the same key is still in
+// processing, and status 422 Unprocessable Entity if the key is reused for a different request.
+// The responses with server errors are not stored, so that the requests can be retried.
+// The keys are scoped by caller using option KeyFunc, and the cookies of response are never stored or replayed.
+// The requests are processed without idempotency if the storage fails.
+func MiddlewareIdempotency(config ...IdempotencyConfig) HandlerFunc {
+	var cfg IdempotencyConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Header == "" {
+		cfg.Header = idempotencyDefaultHeader
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = idempotencyDefaultTTL
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = idempotencyDefaultLockTTL
+	}
+	if len(cfg.Methods) == 0 {
+		cfg.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+	if cfg.Storage == nil {
+		cfg.Storage = NewIdempotencyStorageMemory()
+	}
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = idempotencyCallerScope
+	}
+	return func(r *Request) {
+		var idempotencyKey = r.Header.Get(cfg.Header)
+		if idempotencyKey == "" || !gstr.InArray(cfg.Methods, r.Method) {
+			r.Middleware.Next()
+			return
+		}
+		var (
+			ctx         = r.Context()
+			key         = cfg.Prefix + cfg.KeyFunc(r) + ":" + idempotencyKey
+			fingerprint = idempotencyFingerprint(r)
+		)
+		record, locked, err := cfg.Storage.Lock(ctx, key, cfg.LockTTL)
+		if err != nil {
+			r.Server.Logger().Errorf(ctx, `idempotency lock of "%s" failed: %+v`, idempotencyKey, err)
+			r.Middleware.Next()
+			return
+		}
+		if record != nil {
+			if record.Fingerprint != fingerprint {
+				r.Response.WriteStatus(http.StatusUnprocessableEntity)
+				return
+			}
+			header := r.Response.Header()
+			for k, v := range record.Header {
+				if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+					continue
+				}
+				header[k] = v
+			}
+			header.Set(idempotencyHeaderReplayed, "true")
+			r.Response.WriteHeader(record.Status)
+			r.Response.Write(record.Body)
+			return
+		}
+		if !locked {
+			r.Response.WriteStatus(http.StatusConflict)
+			return
+		}
+
+		r.Middleware.Next()
+
+		var status = r.Response.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if r.GetError() != nil || status >= http.StatusInternalServerError ||
+			r.Response.IsHeaderWrote() || r.Response.IsHijacked() {
+			if err = cfg.Storage.Unlock(ctx, key); err != nil {
+				r.Server.Logger().Errorf(ctx, `idempotency unlock of "%s" failed: %+v`, idempotencyKey, err)
+			}
+			return
+		}
+		record = &IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			Header:      r.Response.Header().Clone(),
+			Body:        r.Response.Buffer(),
+		}
+		// The cookies like session id belong to the caller, which should never be replayed.
+		record.Header.Del("Set-Cookie")
+		if err = cfg.Storage.Save(ctx, key, record, cfg.TTL); err != nil {
+			r.Server.Logger().Errorf(ctx, `idempotency save of "%s" failed: %+v`, idempotencyKey, err)
+		}
+	}
+}
+
+// idempotencyFingerprint returns the fingerprint of request `r`, which is the hash of method, path and body.
+func idempotencyFingerprint(r *Request) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	h.Write(r.GetBody())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyCallerScope returns the scope of caller for request `r`, which is the hash of header
+// "Authorization" or session id, or empty for anonymous caller.
+func idempotencyCallerScope(r *Request) string {
+	var scope string
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		scope = "authorization:" + authorization
+	} else if sessionId := r.GetSessionId(); sessionId != "" {
+		scope = "session:" + sessionId
+	} else {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(scope))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyStorageMemory implements the IdempotencyStorage interface with memory,
+// which stores the idempotency keys of current server only.
+type IdempotencyStorageMemory struct {
+	mu        sync.Mutex
+	states    map[string]*idempotencyState // States of idempotency keys.
+	lastSweep time.Time                    // Last time that the expired states are removed.
+}
+
+// idempotencyState is the state of an idempotency key.
+type idempotencyState struct {
+	record   *IdempotencyRecord // Stored record, nil if the key is locked.
+	expireAt time.Time          // Time after which the record or lock expires.
+}
+
+const (
+	// idempotencySweepInterval is the interval removing the expired states of memory storage.
+	idempotencySweepInterval = time.Minute
+)
+
+// NewIdempotencyStorageMemory creates and returns a memory storage for idempotency keys.
+func NewIdempotencyStorageMemory() *IdempotencyStorageMemory {
+	return &IdempotencyStorageMemory{
+		states:    make(map[string]*idempotencyState),
+		lastSweep: time.Now(),
+	}
+}
+
+// Lock locks `key` for processing in `ttl` if it is not locked and has no record.
+// It returns the stored record if the key was done, or else false if the key is locked by another request.
+func (s *IdempotencyStorageMemory) Lock(
+	ctx context.Context, key string, ttl time.Duration,
+) (record *IdempotencyRecord, locked bool, err error) {
+	var now = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= idempotencySweepInterval {
+		for k, state := range s.states {
+			if now.After(state.expireAt) {
+				delete(s.states, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	if state, ok := s.states[key]; ok && now.Before(state.expireAt) {
+		return state.record, false, nil
+	}
+	s.states[key] = &idempotencyState{
+		expireAt: now.Add(ttl),
+	}
+	return nil, true, nil
+}
+
+// Save stores `record` of the locked `key` for `ttl` and unlocks the key.
+func (s *IdempotencyStorageMemory) Save(
+	ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[key] = &idempotencyState{
+		record:   record,
+		expireAt: time.Now().Add(ttl),
+	}
+	return nil
+}
+
+// Unlock unlocks `key` without record, so that the requests of the key can be retried.
+func (s *IdempotencyStorageMemory) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state, ok := s.states[key]; ok && state.record == nil {
+		delete(s.states, key)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// IdempotencyStorageRedis implements the IdempotencyStorage interface with redis,
+// which shares the idempotency keys among multiple servers.
+type IdempotencyStorageRedis struct {
+	redis  *gredis.Redis // Redis client for idempotency keys.
+	prefix string        // Redis key prefix for idempotency keys.
+}
+
+const (
+	// DefaultIdempotencyStorageRedisPrefix is the default redis key prefix of IdempotencyStorageRedis.
+	DefaultIdempotencyStorageRedisPrefix = "Idempotency:"
+
+	// idempotencyRedisLockSuffix is the redis key suffix of the lock of idempotency key.
+	idempotencyRedisLockSuffix = ":lock"
+)
+
+const (
+	// idempotencyLockScript returns the stored record if exists, or else locks the key,
+	// in which the first returned value is 1 for record, 2 for locked and 0 for conflict.
+	idempotencyLockScript = `
+local record = redis.call('GET', KEYS[1])
+if record then
+	return {1, record}
+end
+if redis.call('SET', KEYS[2], '1', 'NX', 'PX', ARGV[1]) then
+	return {2, ''}
+end
+return {0, ''}
+`
+
+	// idempotencySaveScript stores the record and removes the lock.
+	idempotencySaveScript = `
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('DEL', KEYS[2])
+return 1
+`
+)
+
+// NewIdempotencyStorageRedis creates and returns a redis storage for idempotency keys.
+// The optional parameter `prefix` specifies the redis key prefix, which is "Idempotency:" in default.
+func NewIdempotencyStorageRedis(redis *gredis.Redis, prefix ...string) *IdempotencyStorageRedis {
+	if redis == nil {
+		panic("redis instance for storage cannot be empty")
+	}
+	s := &IdempotencyStorageRedis{
+		redis:  redis,
+		prefix: DefaultIdempotencyStorageRedisPrefix,
+	}
+	if len(prefix) > 0 && prefix[0] != "" {
+		s.prefix = prefix[0]
+	}
+	return s
+}
+
+// Lock locks `key` for processing in `ttl` if it is not locked and has no record.
+// It returns the stored record if the key was done, or else false if the key is locked by another request.
+func (s *IdempotencyStorageRedis) Lock(
+	ctx context.Context, key string, ttl time.Duration,
+) (record *IdempotencyRecord, locked bool, err error) {
+	v, err := s.redis.Eval(
+		ctx, idempotencyLockScript, 2, s.redisKeys(key),
+		[]interface{}{ttl.Milliseconds()},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	values := v.Strings()
+	if len(values) != 2 {
+		return nil, false, gerror.NewCodef(gcode.CodeInternalError, `unexpected result of idempotency script: %s`, v)
+	}
+	switch values[0] {
+	case "1":
+		record = &IdempotencyRecord{}
+		if err = json.Unmarshal([]byte(values[1]), record); err != nil {
+			return nil, false, err
+		}
+		return record, false, nil
+	case "2":
+		return nil, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// Save stores `record` of the locked `key` for `ttl` and unlocks the key.
+func (s *IdempotencyStorageRedis) Save(
+	ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration,
+) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.redis.Eval(
+		ctx, idempotencySaveScript, 2, s.redisKeys(key),
+		[]interface{}{b, ttl.Milliseconds()},
+	)
+	return err
+}
+
+// Unlock unlocks `key` without record, so that the requests of the key can be retried.
+func (s *IdempotencyStorageRedis) Unlock(ctx context.Context, key string) error {
+	_, err := s.redis.Del(ctx, s.prefix+key+idempotencyRedisLockSuffix)
+	return err
+}
+
+// redisKeys returns the redis keys of record and lock of idempotency `key`.
+func (s *IdempotencyStorageRedis) redisKeys(key string) []string {
+	return []string{s.prefix + key, s.prefix + key + idempotencyRedisLockSuffix}
+}
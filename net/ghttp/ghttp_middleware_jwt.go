@@ -0,0 +1,238 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// JWTConfig is the configuration of JWT verification middleware.
+// The verification key is one of Secret, PublicKey and JWKSUrl.
+type JWTConfig struct {
+	// Secret is the shared secret for HS algorithms.
+	Secret string `json:"secret"`
+
+	// PublicKey is the public key in PEM format for RS, ES and EdDSA algorithms.
+	PublicKey string `json:"publicKey"`
+
+	// JWKSUrl is the url of remote JSON Web Key Set like the "jwks_uri" of OpenID provider,
+	// whose keys are cached and fetched again for key rotation.
+	JWKSUrl string `json:"jwksUrl"`
+
+	// JWKSTTL is the cache duration of JWKS keys, which is 10 minutes in default.
+	JWKSTTL time.Duration `json:"jwksTTL"`
+
+	// Algorithms are the accepted algorithms, which accepts all algorithms of the key type in default.
+	Algorithms []string `json:"algorithms"`
+
+	// Issuer is the expected issuer of token, which is not checked if it is empty.
+	Issuer string `json:"issuer"`
+
+	// Audience is the expected audience of token, which is not checked if it is empty.
+	Audience string `json:"audience"`
+
+	// Leeway is the tolerance of clock skew between token issuer and server.
+	Leeway time.Duration `json:"leeway"`
+
+	// RequireExpiration rejects the token without expiration time.
+	RequireExpiration bool `json:"requireExpiration"`
+
+	// Query is the query parameter name carrying the token if it is not in header "Authorization",
+	// which is commonly used for WebSocket. The query is not used if it is empty.
+	Query string `json:"query"`
+
+	// Cookie is the cookie name carrying the token if it is not in header "Authorization".
+	// The cookie is not used if it is empty.
+	Cookie string `json:"cookie"`
+
+	// Optional allows the requests without token, whose claims are nil.
+	// The requests with invalid token are still rejected.
+	Optional bool `json:"optional"`
+
+	// ErrorHandler writes the response if the verification fails,
+	// which writes status 401 Unauthorized with header "WWW-Authenticate" in default.
+	ErrorHandler func(r *Request, err error) `json:"-"`
+}
+
+const (
+	jwtAuthorizationScheme                = "Bearer "
+	jwtCtxKeyForClaims        gctx.StrKey = "gHttpJWTClaims"
+	jwtDefaultWWWAuthenticate             = `Bearer`
+)
+
+// MiddlewareJWT returns a middleware handler verifying the JWT of requests, which is stateless and
+// independent of session. The token is retrieved from header "Authorization" in Bearer scheme, and then
+// from the query parameter and cookie if configured.
+//
+// The claims of verified token are injected into the request context, which can be retrieved by
+// Request.GetJWTClaims or JWTClaimsFromCtx. It uses the configuration JWT of server if no configuration
+// given, so that the verification can be configured in configuration file, and the changes of the
+// configuration take effect for the following requests.
+func MiddlewareJWT(config ...JWTConfig) HandlerFunc {
+	var verifier *jwtVerifier
+	if len(config) > 0 {
+		verifier = newJWTVerifier(config[0])
+	}
+	return func(r *Request) {
+		var v = verifier
+		if v == nil {
+			v = r.Server.getJWTVerifier()
+		}
+		claims, err := v.verify(r)
+		if err != nil {
+			v.config.ErrorHandler(r, err)
+			return
+		}
+		if claims != nil {
+			r.SetCtxVar(jwtCtxKeyForClaims, claims)
+		}
+		r.Middleware.Next()
+	}
+}
+
+// GetJWTClaims returns the claims of verified token of current request,
+// or nil if the request is not verified by MiddlewareJWT.
+func (r *Request) GetJWTClaims() *gjwt.Claims {
+	return JWTClaimsFromCtx(r.Context())
+}
+
+// JWTClaimsFromCtx returns the claims of verified token from request context `ctx`,
+// or nil if the request is not verified by MiddlewareJWT.
+func JWTClaimsFromCtx(ctx context.Context) *gjwt.Claims {
+	if ctx == nil {
+		return nil
+	}
+	claims, _ := ctx.Value(jwtCtxKeyForClaims).(*gjwt.Claims)
+	return claims
+}
+
+// getJWTVerifier returns the verifier using the configuration JWT of server, which is created if it is
+// not created or the configuration changes.
+func (s *Server) getJWTVerifier() *jwtVerifier {
+	s.jwtMu.RLock()
+	verifier := s.jwtVerifier
+	s.jwtMu.RUnlock()
+	if verifier != nil {
+		return verifier
+	}
+	s.jwtMu.Lock()
+	defer s.jwtMu.Unlock()
+	if s.jwtVerifier == nil {
+		s.jwtVerifier = newJWTVerifier(s.config.JWT)
+	}
+	return s.jwtVerifier
+}
+
+// resetJWTVerifier resets the verifier of server, which is created again with the changed configuration.
+func (s *Server) resetJWTVerifier() {
+	s.jwtMu.Lock()
+	defer s.jwtMu.Unlock()
+	s.jwtVerifier = nil
+}
+
+// jwtVerifier verifies the tokens of requests using JWTConfig.
+type jwtVerifier struct {
+	config JWTConfig
+	option gjwt.ParseOption
+	err    error // Error of invalid configuration, which fails all verifications.
+}
+
+// newJWTVerifier creates and returns a jwtVerifier using `config`.
+func newJWTVerifier(config JWTConfig) *jwtVerifier {
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = defaultJWTErrorHandler
+	}
+	v := &jwtVerifier{
+		config: config,
+		option: gjwt.ParseOption{
+			Algorithms:        config.Algorithms,
+			Issuer:            config.Issuer,
+			Audience:          config.Audience,
+			Leeway:            config.Leeway,
+			RequireExpiration: config.RequireExpiration,
+		},
+	}
+	switch {
+	case config.Secret != "":
+		v.option.Key = []byte(config.Secret)
+	case config.PublicKey != "":
+		block, _ := pem.Decode([]byte(config.PublicKey))
+		if block == nil {
+			v.err = gerror.NewCode(gcode.CodeInvalidConfiguration, `invalid PEM data of JWT public key`)
+			break
+		}
+		if v.option.Key, v.err = x509.ParsePKIXPublicKey(block.Bytes); v.err != nil {
+			v.err = gerror.WrapCode(gcode.CodeInvalidConfiguration, v.err, `parse JWT public key failed`)
+		}
+	case config.JWKSUrl != "":
+		v.option.KeyFunc = gjwt.NewJWKS(config.JWKSUrl, config.JWKSTTL).KeyFunc
+	default:
+		v.err = gerror.NewCode(
+			gcode.CodeMissingConfiguration,
+			`JWT verification key is required, one of secret, public key and JWKS url should be configured`,
+		)
+	}
+	return v
+}
+
+// verify retrieves and verifies the token of `r`, and returns its claims.
+// It returns nil claims without error if no token is found and the token is optional.
+func (v *jwtVerifier) verify(r *Request) (*gjwt.Claims, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	token := v.token(r)
+	if token == "" {
+		if v.config.Optional {
+			return nil, nil
+		}
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, `JWT is required`)
+	}
+	return gjwt.Parse(r.Context(), token, v.option)
+}
+
+// token retrieves the token of `r` from header, query and cookie in order.
+func (v *jwtVerifier) token(r *Request) string {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		if len(authorization) > len(jwtAuthorizationScheme) &&
+			strings.EqualFold(authorization[:len(jwtAuthorizationScheme)], jwtAuthorizationScheme) {
+			return strings.TrimSpace(authorization[len(jwtAuthorizationScheme):])
+		}
+	}
+	if v.config.Query != "" {
+		if token := r.GetQuery(v.config.Query).String(); token != "" {
+			return token
+		}
+	}
+	if v.config.Cookie != "" {
+		return r.Cookie.Get(v.config.Cookie).String()
+	}
+	return ""
+}
+
+// defaultJWTErrorHandler writes status 401 Unauthorized, or 500 Internal Server Error if the
+// verification is not well configured.
+func defaultJWTErrorHandler(r *Request, err error) {
+	switch gerror.Code(err) {
+	case gcode.CodeInvalidConfiguration, gcode.CodeMissingConfiguration:
+		r.Server.Logger().Errorf(r.Context(), `%+v`, err)
+		r.Response.WriteStatus(http.StatusInternalServerError)
+	default:
+		r.Response.Header().Set("WWW-Authenticate", jwtDefaultWWWAuthenticate)
+		r.Response.WriteStatus(http.StatusUnauthorized)
+	}
+}
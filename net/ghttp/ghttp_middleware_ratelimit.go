@@ -0,0 +1,195 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitAlgorithm is the algorithm of rate limiting.
+type RateLimitAlgorithm int
+
+const (
+	// RateLimitTokenBucket limits the requests using token bucket, which refills the tokens at the rate of
+	// Limit per Window, and allows bursting up to Burst requests.
+	RateLimitTokenBucket RateLimitAlgorithm = iota
+
+	// RateLimitSlidingWindow limits the requests using sliding window log,
+	// which allows at most Limit requests in any period of Window.
+	RateLimitSlidingWindow
+)
+
+// RateLimitKeyFunc extracts and returns the rate limit key of request,
+// and the request is not limited if it returns empty string.
+type RateLimitKeyFunc func(r *Request) string
+
+// RateLimitConfig is the configuration of rate limit middleware.
+type RateLimitConfig struct {
+	// Algorithm is the algorithm of rate limiting, which is RateLimitTokenBucket in default.
+	Algorithm RateLimitAlgorithm
+
+	// Limit is the max requests of each key in Window, which is required.
+	Limit int
+
+	// Window is the period of Limit, which is 1 second in default.
+	Window time.Duration
+
+	// Burst is the capacity of token bucket, which is Limit in default. It is for RateLimitTokenBucket only.
+	Burst int
+
+	// KeyFunc extracts the rate limit key of request, which is RateLimitKeyByIP in default.
+	KeyFunc RateLimitKeyFunc
+
+	// Prefix is the prefix of rate limit keys, which distinguishes the middlewares sharing the same Storage.
+	Prefix string
+
+	// Storage stores the states of rate limiting, which is a new memory storage in default.
+	// Use the redis storage to share the rate limits among multiple servers.
+	Storage RateLimitStorage
+
+	// Handler handles the rejected requests, which responds status 429 Too Many Requests in default.
+	Handler HandlerFunc
+}
+
+// RateLimitRule is the rule of rate limiting for RateLimitStorage.
+type RateLimitRule struct {
+	Algorithm RateLimitAlgorithm // Algorithm of rate limiting.
+	Limit     int                // Max requests in Window.
+	Window    time.Duration      // Period of Limit.
+	Burst     int                // Capacity of token bucket.
+}
+
+// RateLimitResult is the result of rate limiting for a request.
+type RateLimitResult struct {
+	Allowed    bool          // Whether the request is allowed.
+	Limit      int           // Max requests in window.
+	Remaining  int           // Remaining requests in current window.
+	Reset      time.Duration // Duration until the quota is fully restored.
+	RetryAfter time.Duration // Duration to wait before retrying if the request is not allowed.
+}
+
+// RateLimitStorage stores the states of rate limiting.
+type RateLimitStorage interface {
+	// Allow checks whether the request of `key` is allowed by `rule`, and consumes the quota if allowed.
+	Allow(ctx context.Context, key string, rule RateLimitRule) (*RateLimitResult, error)
+}
+
+const (
+	rateLimitHeaderLimit     = "RateLimit-Limit"
+	rateLimitHeaderRemaining = "RateLimit-Remaining"
+	rateLimitHeaderReset     = "RateLimit-Reset"
+	rateLimitHeaderRetry     = "Retry-After"
+	rateLimitDefaultWindow   = time.Second
+)
+
+// RateLimitKeyByIP is the RateLimitKeyFunc that limits the requests by the remote IP of connection.
+// It does not use the headers like "X-Forwarded-For", as they are controlled by client and can be rotated
+// to bypass the limit. Use RateLimitKeyByClientIP if the server is behind trusted proxies.
+func RateLimitKeyByIP(r *Request) string {
+	return r.GetRemoteIp()
+}
+
+// RateLimitKeyByClientIP is the RateLimitKeyFunc that limits the requests by client IP retrieved from
+// the headers "X-Forwarded-For" and "X-Real-IP", see Request.GetClientIp.
+//
+// Note that it should be used only if the server is accessed through trusted proxies that overwrite these
+// headers, or else any client can bypass the limit by rotating the headers.
+func RateLimitKeyByClientIP(r *Request) string {
+	return r.GetClientIp()
+}
+
+// RateLimitKeyByRoute is the RateLimitKeyFunc that limits the requests by route,
+// like "GET /user/{id}", in which all clients share the limit of the route.
+func RateLimitKeyByRoute(r *Request) string {
+	if r.Router == nil {
+		return r.Method + " " + r.URL.Path
+	}
+	return r.Method + " " + r.Router.Uri
+}
+
+// RateLimitKeyByRouteAndIP is the RateLimitKeyFunc that limits the requests by route and
+// the remote IP of connection, see RateLimitKeyByIP.
+func RateLimitKeyByRouteAndIP(r *Request) string {
+	return RateLimitKeyByRoute(r) + " " + r.GetRemoteIp()
+}
+
+// RateLimitKeyByHeader returns a RateLimitKeyFunc that limits the requests by header `name`,
+// like the API key of clients. The requests without the header are not limited.
+func RateLimitKeyByHeader(name string) RateLimitKeyFunc {
+	return func(r *Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// MiddlewareRateLimit returns a middleware handler limiting the requests by `config`, which sets the
+// headers "RateLimit-Limit", "RateLimit-Remaining" and "RateLimit-Reset" for the limited requests,
+// and "Retry-After" for the rejected requests. The requests are allowed if the storage fails.
+//
+// It limits the requests of a route if it is bound to the route, or else the requests of all routes
+// of the group, in which the RateLimitKeyFunc distinguishes the clients and routes.
+func MiddlewareRateLimit(config RateLimitConfig) HandlerFunc {
+	if config.Window <= 0 {
+		config.Window = rateLimitDefaultWindow
+	}
+	if config.Burst <= 0 {
+		config.Burst = config.Limit
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = RateLimitKeyByIP
+	}
+	if config.Storage == nil {
+		config.Storage = NewRateLimitStorageMemory()
+	}
+	var rule = RateLimitRule{
+		Algorithm: config.Algorithm,
+		Limit:     config.Limit,
+		Window:    config.Window,
+		Burst:     config.Burst,
+	}
+	return func(r *Request) {
+		var key string
+		if config.Limit > 0 {
+			key = config.KeyFunc(r)
+		}
+		if key == "" {
+			r.Middleware.Next()
+			return
+		}
+		result, err := config.Storage.Allow(r.Context(), config.Prefix+key, rule)
+		if err != nil {
+			r.Server.Logger().Errorf(r.Context(), `rate limit of "%s" failed: %+v`, key, err)
+			r.Middleware.Next()
+			return
+		}
+		header := r.Response.Header()
+		header.Set(rateLimitHeaderLimit, strconv.Itoa(result.Limit))
+		header.Set(rateLimitHeaderRemaining, strconv.Itoa(result.Remaining))
+		header.Set(rateLimitHeaderReset, formatRateLimitSeconds(result.Reset))
+		if result.Allowed {
+			r.Middleware.Next()
+			return
+		}
+		header.Set(rateLimitHeaderRetry, formatRateLimitSeconds(result.RetryAfter))
+		if config.Handler != nil {
+			config.Handler(r)
+			return
+		}
+		r.Response.WriteStatus(http.StatusTooManyRequests)
+	}
+}
+
+// formatRateLimitSeconds formats `d` as seconds rounded up for headers.
+func formatRateLimitSeconds(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,123 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitStorageMemory implements the RateLimitStorage interface with memory,
+// which limits the requests of current server only.
+type RateLimitStorageMemory struct {
+	mu        sync.Mutex
+	states    map[string]*rateLimitState // States of rate limiting by key.
+	lastSweep time.Time                  // Last time that the expired states are removed.
+}
+
+// rateLimitState is the state of rate limiting of a key.
+type rateLimitState struct {
+	tokens   float64     // Available tokens of token bucket.
+	last     time.Time   // Last time that tokens are refilled.
+	times    []time.Time // Times of requests in sliding window.
+	expireAt time.Time   // Time after which the state is the same as a new one.
+}
+
+const (
+	// rateLimitSweepInterval is the interval removing the expired states of memory storage.
+	rateLimitSweepInterval = time.Minute
+)
+
+// NewRateLimitStorageMemory creates and returns a memory storage for rate limiting.
+func NewRateLimitStorageMemory() *RateLimitStorageMemory {
+	return &RateLimitStorageMemory{
+		states:    make(map[string]*rateLimitState),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow checks whether the request of `key` is allowed by `rule`, and consumes the quota if allowed.
+func (s *RateLimitStorageMemory) Allow(ctx context.Context, key string, rule RateLimitRule) (*RateLimitResult, error) {
+	var now = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= rateLimitSweepInterval {
+		for k, state := range s.states {
+			if now.After(state.expireAt) {
+				delete(s.states, k)
+			}
+		}
+		s.lastSweep = now
+	}
+	state, ok := s.states[key]
+	if !ok {
+		state = &rateLimitState{
+			tokens: float64(rule.Burst),
+			last:   now,
+		}
+		s.states[key] = state
+	}
+	if rule.Algorithm == RateLimitSlidingWindow {
+		return state.allowSlidingWindow(now, rule), nil
+	}
+	return state.allowTokenBucket(now, rule), nil
+}
+
+// allowTokenBucket checks and takes a token from the bucket.
+func (s *rateLimitState) allowTokenBucket(now time.Time, rule RateLimitRule) *RateLimitResult {
+	var (
+		rate   = float64(rule.Limit) / rule.Window.Seconds()
+		result = &RateLimitResult{Limit: rule.Burst}
+	)
+	if elapsed := now.Sub(s.last); elapsed > 0 {
+		s.tokens = math.Min(float64(rule.Burst), s.tokens+elapsed.Seconds()*rate)
+		s.last = now
+	}
+	if s.tokens >= 1 {
+		s.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - s.tokens) / rate)
+	}
+	result.Remaining = int(s.tokens)
+	result.Reset = secondsToDuration((float64(rule.Burst) - s.tokens) / rate)
+	s.expireAt = now.Add(result.Reset)
+	return result
+}
+
+// allowSlidingWindow checks and records the request in the sliding window.
+func (s *rateLimitState) allowSlidingWindow(now time.Time, rule RateLimitRule) *RateLimitResult {
+	var (
+		result     = &RateLimitResult{Limit: rule.Limit}
+		windowFrom = now.Add(-rule.Window)
+		expired    int
+	)
+	for expired < len(s.times) && !s.times[expired].After(windowFrom) {
+		expired++
+	}
+	s.times = s.times[expired:]
+	if len(s.times) < rule.Limit {
+		s.times = append(s.times, now)
+		result.Allowed = true
+	}
+	result.Remaining = rule.Limit - len(s.times)
+	if len(s.times) > 0 {
+		result.Reset = s.times[0].Add(rule.Window).Sub(now)
+		s.expireAt = s.times[len(s.times)-1].Add(rule.Window)
+	}
+	if !result.Allowed {
+		result.RetryAfter = result.Reset
+	}
+	return result
+}
+
+// secondsToDuration converts the float seconds to time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
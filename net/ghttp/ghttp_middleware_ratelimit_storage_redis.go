@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// RateLimitStorageRedis implements the RateLimitStorage interface with redis,
+// which shares the rate limits among multiple servers.
+//
+// Note that the time of servers is used for rate limiting, which should be synchronized.
+type RateLimitStorageRedis struct {
+	redis  *gredis.Redis // Redis client for rate limiting.
+	prefix string        // Redis key prefix for rate limit keys.
+}
+
+const (
+	// DefaultRateLimitStorageRedisPrefix is the default redis key prefix of RateLimitStorageRedis.
+	DefaultRateLimitStorageRedisPrefix = "RateLimit:"
+)
+
+const (
+	// rateLimitTokenBucketScript takes a token from the bucket stored in hash,
+	// and returns whether it is allowed and the remaining tokens.
+	rateLimitTokenBucketScript = `
+local rate   = tonumber(ARGV[1])
+local burst  = tonumber(ARGV[2])
+local now    = tonumber(ARGV[3])
+local state  = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1])
+local last   = tonumber(state[2])
+if tokens == nil then
+	tokens = burst
+	last   = now
+end
+if now > last then
+	tokens = math.min(burst, tokens + (now - last) * rate)
+	last   = now
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens  = tokens - 1
+	allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'last', last)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+	// rateLimitSlidingWindowScript records the request in the sliding window stored in sorted set,
+	// and returns whether it is allowed, the count of requests and the time until the oldest expires.
+	rateLimitSlidingWindowScript = `
+local limit  = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now    = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count   = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count   = count + 1
+	allowed = 1
+end
+local reset  = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, count, reset}
+`
+)
+
+// NewRateLimitStorageRedis creates and returns a redis storage for rate limiting.
+// The optional parameter `prefix` specifies the redis key prefix, which is "RateLimit:" in default.
+func NewRateLimitStorageRedis(redis *gredis.Redis, prefix ...string) *RateLimitStorageRedis {
+	if redis == nil {
+		panic("redis instance for storage cannot be empty")
+	}
+	s := &RateLimitStorageRedis{
+		redis:  redis,
+		prefix: DefaultRateLimitStorageRedisPrefix,
+	}
+	if len(prefix) > 0 && prefix[0] != "" {
+		s.prefix = prefix[0]
+	}
+	return s
+}
+
+// Allow checks whether the request of `key` is allowed by `rule`, and consumes the quota if allowed.
+func (s *RateLimitStorageRedis) Allow(ctx context.Context, key string, rule RateLimitRule) (*RateLimitResult, error) {
+	var (
+		now      = time.Now().UnixMilli()
+		redisKey = s.prefix + key
+	)
+	if rule.Algorithm == RateLimitSlidingWindow {
+		v, err := s.redis.Eval(
+			ctx, rateLimitSlidingWindowScript, 1, []string{redisKey},
+			[]interface{}{rule.Limit, rule.Window.Milliseconds(), now, guid.S()},
+		)
+		if err != nil {
+			return nil, err
+		}
+		values := v.Int64s()
+		if len(values) != 3 {
+			return nil, gerror.NewCodef(gcode.CodeInternalError, `unexpected result of rate limit script: %s`, v)
+		}
+		result := &RateLimitResult{
+			Allowed:   values[0] == 1,
+			Limit:     rule.Limit,
+			Remaining: rule.Limit - int(values[1]),
+			Reset:     time.Duration(values[2]) * time.Millisecond,
+		}
+		if !result.Allowed {
+			result.RetryAfter = result.Reset
+		}
+		return result, nil
+	}
+	// Tokens refilled per millisecond.
+	rate := float64(rule.Limit) / float64(rule.Window.Milliseconds())
+	v, err := s.redis.Eval(
+		ctx, rateLimitTokenBucketScript, 1, []string{redisKey},
+		[]interface{}{rate, rule.Burst, now},
+	)
+	if err != nil {
+		return nil, err
+	}
+	values := v.Strings()
+	if len(values) != 2 {
+		return nil, gerror.NewCodef(gcode.CodeInternalError, `unexpected result of rate limit script: %s`, v)
+	}
+	var (
+		tokens = gconv.Float64(values[1])
+		result = &RateLimitResult{
+			Allowed:   values[0] == "1",
+			Limit:     rule.Burst,
+			Remaining: int(tokens),
+			Reset:     time.Duration((float64(rule.Burst) - tokens) / rate * float64(time.Millisecond)),
+		}
+	)
+	if !result.Allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Millisecond))
+	}
+	return result, nil
+}
@@ -0,0 +1,173 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// SecurityHeadersConfig is the configuration of security headers middleware.
+// The headers of empty or zero fields are not sent.
+type SecurityHeadersConfig struct {
+	// ContentSecurityPolicy is the value of header "Content-Security-Policy", in which the placeholder
+	// "{nonce}" is replaced with a random nonce for each request, like "script-src 'self' 'nonce-{nonce}'".
+	ContentSecurityPolicy string
+
+	// ContentSecurityPolicyReportOnly sends the policy in header "Content-Security-Policy-Report-Only" instead,
+	// which reports the violations without enforcing the policy.
+	ContentSecurityPolicyReportOnly bool
+
+	// HSTSMaxAge is the max age of header "Strict-Transport-Security",
+	// which is only sent for the requests over HTTPS.
+	HSTSMaxAge time.Duration
+
+	// HSTSIncludeSubDomains adds directive "includeSubDomains" to header "Strict-Transport-Security".
+	HSTSIncludeSubDomains bool
+
+	// HSTSPreload adds directive "preload" to header "Strict-Transport-Security".
+	HSTSPreload bool
+
+	// FrameOptions is the value of header "X-Frame-Options", like "DENY" or "SAMEORIGIN".
+	FrameOptions string
+
+	// ContentTypeOptions is the value of header "X-Content-Type-Options", which should be "nosniff".
+	ContentTypeOptions string
+
+	// ReferrerPolicy is the value of header "Referrer-Policy".
+	ReferrerPolicy string
+
+	// CrossOriginOpenerPolicy is the value of header "Cross-Origin-Opener-Policy", like "same-origin".
+	CrossOriginOpenerPolicy string
+
+	// CrossOriginEmbedderPolicy is the value of header "Cross-Origin-Embedder-Policy", like "require-corp".
+	// The page is cross-origin isolated along with CrossOriginOpenerPolicy "same-origin".
+	CrossOriginEmbedderPolicy string
+
+	// CrossOriginResourcePolicy is the value of header "Cross-Origin-Resource-Policy", like "same-origin".
+	CrossOriginResourcePolicy string
+}
+
+const (
+	// CspNoncePlaceholder is the placeholder in ContentSecurityPolicy replaced with the nonce of request.
+	CspNoncePlaceholder = "{nonce}"
+
+	// CspNonceViewKey is the template variable name of CSP nonce assigned by MiddlewareSecurityHeaders.
+	CspNonceViewKey = "CspNonce"
+
+	cspNonceLength                     = 16
+	cspCtxKeyForNonce      gctx.StrKey = "gHttpCspNonce"
+	securityHeadersHSTSAge             = 365 * 24 * time.Hour
+)
+
+// DefaultSecurityHeadersConfig returns the default configuration of security headers middleware,
+// which is commonly used as the base of custom configuration.
+func DefaultSecurityHeadersConfig() SecurityHeadersConfig {
+	return SecurityHeadersConfig{
+		ContentSecurityPolicy:   "default-src 'self'; object-src 'none'; base-uri 'self'; frame-ancestors 'none'",
+		HSTSMaxAge:              securityHeadersHSTSAge,
+		HSTSIncludeSubDomains:   true,
+		FrameOptions:            "DENY",
+		ContentTypeOptions:      "nosniff",
+		ReferrerPolicy:          "strict-origin-when-cross-origin",
+		CrossOriginOpenerPolicy: "same-origin",
+	}
+}
+
+// MiddlewareSecurityHeaders returns a middleware handler sending the security headers, which uses
+// DefaultSecurityHeadersConfig if no configuration given.
+//
+// If the ContentSecurityPolicy contains placeholder "{nonce}", a random nonce is created for each request,
+// which is exposed by Request.GetCspNonce and assigned to the templates as variable "CspNonce", so that
+// the inline scripts and styles can be allowed like: <script nonce="{{.CspNonce}}">.
+func MiddlewareSecurityHeaders(config ...SecurityHeadersConfig) HandlerFunc {
+	var cfg = DefaultSecurityHeadersConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	var (
+		cspHeader  = "Content-Security-Policy"
+		cspNonce   = strings.Contains(cfg.ContentSecurityPolicy, CspNoncePlaceholder)
+		hstsHeader = cfg.hstsHeader()
+		headers    = make(map[string]string)
+	)
+	if cfg.ContentSecurityPolicyReportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+	for key, value := range map[string]string{
+		"X-Frame-Options":              cfg.FrameOptions,
+		"X-Content-Type-Options":       cfg.ContentTypeOptions,
+		"Referrer-Policy":              cfg.ReferrerPolicy,
+		"Cross-Origin-Opener-Policy":   cfg.CrossOriginOpenerPolicy,
+		"Cross-Origin-Embedder-Policy": cfg.CrossOriginEmbedderPolicy,
+		"Cross-Origin-Resource-Policy": cfg.CrossOriginResourcePolicy,
+	} {
+		if value != "" {
+			headers[key] = value
+		}
+	}
+	if cfg.ContentSecurityPolicy != "" && !cspNonce {
+		headers[cspHeader] = cfg.ContentSecurityPolicy
+	}
+	return func(r *Request) {
+		header := r.Response.Header()
+		for key, value := range headers {
+			header.Set(key, value)
+		}
+		if hstsHeader != "" && r.GetSchema() == "https" {
+			header.Set("Strict-Transport-Security", hstsHeader)
+		}
+		if cspNonce {
+			nonce, err := newCspNonce()
+			if err != nil {
+				r.Server.Logger().Errorf(r.Context(), `create CSP nonce failed: %+v`, err)
+				r.Response.WriteStatus(http.StatusInternalServerError)
+				return
+			}
+			header.Set(cspHeader, strings.ReplaceAll(cfg.ContentSecurityPolicy, CspNoncePlaceholder, nonce))
+			r.SetCtxVar(cspCtxKeyForNonce, nonce)
+			r.Assign(CspNonceViewKey, nonce)
+		}
+		r.Middleware.Next()
+	}
+}
+
+// GetCspNonce returns the Content-Security-Policy nonce of current request. It returns empty string
+// if the request is not handled by MiddlewareSecurityHeaders or the policy contains no nonce.
+func (r *Request) GetCspNonce() string {
+	return r.GetCtxVar(cspCtxKeyForNonce).String()
+}
+
+// hstsHeader returns the value of header "Strict-Transport-Security", or empty if HSTS is disabled.
+func (cfg *SecurityHeadersConfig) hstsHeader() string {
+	if cfg.HSTSMaxAge <= 0 {
+		return ""
+	}
+	value := fmt.Sprintf("max-age=%d", int64(cfg.HSTSMaxAge/time.Second))
+	if cfg.HSTSIncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if cfg.HSTSPreload {
+		value += "; preload"
+	}
+	return value
+}
+
+// newCspNonce creates and returns a random nonce for Content-Security-Policy.
+func newCspNonce() (string, error) {
+	b := make([]byte, cspNonceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
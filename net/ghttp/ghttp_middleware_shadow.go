@@ -0,0 +1,185 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// ShadowConfig is the configuration of shadow traffic middleware.
+type ShadowConfig struct {
+	// Upstream is the base URL of shadow upstream like "http://127.0.0.1:8001",
+	// to which the request path and query are appended.
+	Upstream string
+
+	// Percentage is the percentage of requests mirrored in range (0, 100], which is 100 in default.
+	Percentage float64
+
+	// Header is the request header tagging the mirrored requests, which is "X-Shadow-Request" in default.
+	// Its value is "true", so that the shadow upstream can identify the mirrored traffic.
+	Header string
+
+	// Timeout is the timeout of mirrored requests, which is 5 seconds in default.
+	Timeout time.Duration
+
+	// MaxBodySize is the max size of request body mirrored, which is 1MB in default.
+	// The requests with larger body or unknown body size are not mirrored.
+	MaxBodySize int64
+
+	// MaxConcurrency is the max number of mirrored requests in flight, which is 100 in default.
+	// The requests are not mirrored if it is exceeded, so that the shadow never slows down the server.
+	MaxConcurrency int
+
+	// Filter specifies the requests mirrored, which mirrors all requests in default.
+	Filter func(r *Request) bool
+
+	// ExcludedHeaders are the request headers not sent to the shadow upstream, which are the credential
+	// headers "Authorization" and "Cookie" in default, so that the credentials of users are never leaked
+	// to the shadow upstream. Set it to an empty slice to send all the headers.
+	ExcludedHeaders []string
+
+	// Client is the client sending mirrored requests, which is a new client in default.
+	// The mirrored requests are sent through the middlewares of the client.
+	Client *gclient.Client
+}
+
+const (
+	shadowDefaultHeader         = "X-Shadow-Request"
+	shadowDefaultPercentage     = 100
+	shadowDefaultTimeout        = 5 * time.Second
+	shadowDefaultMaxBodySize    = 1 << 20
+	shadowDefaultMaxConcurrency = 100
+)
+
+// shadowDefaultExcludedHeaders are the credential headers not sent to the shadow upstream in default.
+var shadowDefaultExcludedHeaders = []string{
+	"Authorization",
+	"Cookie",
+}
+
+// shadowHopHeaders are the hop-by-hop headers not sent to the shadow upstream.
+var shadowHopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// MiddlewareShadow returns a middleware handler mirroring the requests to the shadow upstream, which is
+// commonly used for verifying a new version with the production traffic safely.
+//
+// The requests, with their headers and bodies, are copied and sent asynchronously, and the responses of
+// shadow upstream are discarded, so they never affect the responses of current server. The upgrade requests
+// like WebSocket are never mirrored.
+func MiddlewareShadow(config ShadowConfig) HandlerFunc {
+	var cfg = config
+	cfg.Upstream = strings.TrimRight(cfg.Upstream, "/")
+	if cfg.Percentage <= 0 {
+		cfg.Percentage = shadowDefaultPercentage
+	}
+	if cfg.Header == "" {
+		cfg.Header = shadowDefaultHeader
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = shadowDefaultTimeout
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = shadowDefaultMaxBodySize
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = shadowDefaultMaxConcurrency
+	}
+	if cfg.ExcludedHeaders == nil {
+		cfg.ExcludedHeaders = shadowDefaultExcludedHeaders
+	}
+	if cfg.Client == nil {
+		cfg.Client = gclient.New()
+	}
+	var limiter = make(chan struct{}, cfg.MaxConcurrency)
+	return func(r *Request) {
+		if cfg.shouldMirror(r) {
+			select {
+			case limiter <- struct{}{}:
+				var (
+					ctx    = r.GetNeverDoneCtx()
+					url    = cfg.Upstream + r.URL.RequestURI()
+					header = cfg.shadowHeader(r.Header)
+					body   = r.GetBody()
+					logger = r.Server.Logger()
+				)
+				go func() {
+					defer func() { <-limiter }()
+					if err := cfg.mirror(ctx, r.Method, url, header, body); err != nil {
+						logger.Warningf(ctx, `mirror request to shadow "%s" failed: %+v`, url, err)
+					}
+				}()
+			default:
+			}
+		}
+		r.Middleware.Next()
+	}
+}
+
+// shouldMirror checks whether request `r` should be mirrored.
+func (cfg *ShadowConfig) shouldMirror(r *Request) bool {
+	if cfg.Upstream == "" || r.Header.Get(cfg.Header) != "" || r.Header.Get("Upgrade") != "" {
+		return false
+	}
+	if r.ContentLength < 0 || r.ContentLength > cfg.MaxBodySize {
+		return false
+	}
+	if cfg.Percentage < 100 && float64(grand.Intn(1000000)) >= cfg.Percentage*10000 {
+		return false
+	}
+	return cfg.Filter == nil || cfg.Filter(r)
+}
+
+// mirror sends the mirrored request to the shadow upstream and discards the response.
+// The request is sent by gclient.Client.DoHttpRequest, so that the body is sent as it is.
+func (cfg *ShadowConfig) mirror(ctx context.Context, method, url string, header http.Header, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	resp, err := cfg.Client.DoHttpRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// shadowHeader returns the header of mirrored request, which is cloned from `header` without the
+// hop-by-hop headers and the excluded headers, and tagged with the configured header.
+func (cfg *ShadowConfig) shadowHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range shadowHopHeaders {
+		header.Del(key)
+	}
+	for _, key := range cfg.ExcludedHeaders {
+		header.Del(key)
+	}
+	header.Set(cfg.Header, "true")
+	return header
+}
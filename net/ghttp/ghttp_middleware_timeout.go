@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// MiddlewareTimeout returns a middleware handler limiting the following handlers in `timeout`.
+//
+// It sets the deadline of request context to `timeout`, so that the downstream calls using the context,
+// like gdb and gclient, are canceled if the deadline exceeds. Note that the handlers are not interrupted,
+// they should return as soon as the context is done.
+//
+// If the deadline exceeds, the buffered output and headers written by the handlers are discarded, and the
+// response status is set to 504 Gateway Timeout and its body is written by optional parameter `handler`,
+// which writes the status text in default.
+// The response is kept as it is if it has been flushed to client or hijacked, to avoid writing twice.
+func MiddlewareTimeout(timeout time.Duration, handler ...HandlerFunc) HandlerFunc {
+	var timeoutHandler = func(r *Request) {
+		r.Response.Write(http.StatusText(http.StatusGatewayTimeout))
+	}
+	if len(handler) > 0 && handler[0] != nil {
+		timeoutHandler = handler[0]
+	}
+	return func(r *Request) {
+		var (
+			header                 = r.Response.Header().Clone()
+			timeoutCtx, cancelFunc = context.WithTimeout(r.Context(), timeout)
+		)
+		defer cancelFunc()
+		r.SetCtx(timeoutCtx)
+		r.Middleware.Next()
+
+		var timedOut = errors.Is(timeoutCtx.Err(), context.DeadlineExceeded)
+		// The context is canceled after current middleware, it makes the context never done
+		// for the rest procedures of the request, like hooks and logging.
+		r.SetCtx(r.GetNeverDoneCtx())
+		if !timedOut || r.Response.IsHeaderWrote() || r.Response.IsHijacked() {
+			return
+		}
+		responseHeader := r.Response.Header()
+		for k := range responseHeader {
+			delete(responseHeader, k)
+		}
+		for k, v := range header {
+			responseHeader[k] = v
+		}
+		r.Response.ClearBuffer()
+		r.Response.WriteHeader(http.StatusGatewayTimeout)
+		timeoutHandler(r)
+	}
+}
@@ -55,7 +55,7 @@ func internalMiddlewareServerTracing(r *Request) {
 		)
 	)
 	ctx, span = tr.Start(
-		otel.GetTextMapPropagator().Extract(
+		r.Server.getPropagator().Extract(
 			ctx,
 			propagation.HeaderCarrier(r.Header),
 		),
@@ -69,6 +69,11 @@ func internalMiddlewareServerTracing(r *Request) {
 	// Inject tracing context.
 	r.SetCtx(ctx)
 
+	// Inject trace id into response header, so the client can report it along with the errors.
+	if traceId := span.SpanContext().TraceID(); traceId.IsValid() && gtrace.IsIdInjectionEnabled() {
+		r.Response.Header().Set(gtrace.GetIdInjectionHeader(), traceId.String())
+	}
+
 	// If it is now using a default trace provider, it then does no complex tracing jobs.
 	if gtrace.IsUsingDefaultProvider() {
 		r.Middleware.Next()
@@ -9,6 +9,7 @@ package ghttp
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
@@ -39,35 +40,38 @@ type Request struct {
 	// Private attributes for internal usage purpose.
 	// =================================================================================================================
 
-	handlers        []*HandlerItemParsed   // All matched handlers containing handler, hook and middleware for this request.
-	serveHandler    *HandlerItemParsed     // Real handler serving for this request, not hook or middleware.
-	handlerResponse interface{}            // Handler response object for Request/Response handler.
-	hasHookHandler  bool                   // A bool marking whether there's hook handler in the handlers for performance purpose.
-	hasServeHandler bool                   // A bool marking whether there's serving handler in the handlers for performance purpose.
-	parsedQuery     bool                   // A bool marking whether the GET parameters parsed.
-	parsedBody      bool                   // A bool marking whether the request body parsed.
-	parsedForm      bool                   // A bool marking whether request Form parsed for HTTP method PUT, POST, PATCH.
-	paramsMap       map[string]interface{} // Custom parameters map.
-	routerMap       map[string]string      // Router parameters map, which might be nil if there are no router parameters.
-	queryMap        map[string]interface{} // Query parameters map, which is nil if there's no query string.
-	formMap         map[string]interface{} // Form parameters map, which is nil if there's no form of data from the client.
-	bodyMap         map[string]interface{} // Body parameters map, which might be nil if their nobody content.
-	error           error                  // Current executing error of the request.
-	exitAll         bool                   // A bool marking whether current request is exited.
-	parsedHost      string                 // The parsed host name for current host used by GetHost function.
-	clientIp        string                 // The parsed client ip for current host used by GetClientIp function.
-	bodyContent     []byte                 // Request body content.
-	isFileRequest   bool                   // A bool marking whether current request is file serving.
-	viewObject      *gview.View            // Custom template view engine object for this response.
-	viewParams      gview.Params           // Custom template view variables for this response.
-	originUrlPath   string                 // Original URL path that passed from client.
+	handlers        []*HandlerItemParsed         // All matched handlers containing handler, hook and middleware for this request.
+	serveHandler    *HandlerItemParsed           // Real handler serving for this request, not hook or middleware.
+	handlerResponse interface{}                  // Handler response object for Request/Response handler.
+	hasHookHandler  bool                         // A bool marking whether there's hook handler in the handlers for performance purpose.
+	hasServeHandler bool                         // A bool marking whether there's serving handler in the handlers for performance purpose.
+	parsedQuery     bool                         // A bool marking whether the GET parameters parsed.
+	parsedBody      bool                         // A bool marking whether the request body parsed.
+	parsedForm      bool                         // A bool marking whether request Form parsed for HTTP method PUT, POST, PATCH.
+	paramsMap       map[string]interface{}       // Custom parameters map.
+	routerMap       map[string]string            // Router parameters map, which might be nil if there are no router parameters.
+	queryMap        map[string]interface{}       // Query parameters map, which is nil if there's no query string.
+	formMap         map[string]interface{}       // Form parameters map, which is nil if there's no form of data from the client.
+	bodyMap         map[string]interface{}       // Body parameters map, which might be nil if their nobody content.
+	error           error                        // Current executing error of the request.
+	exitAll         bool                         // A bool marking whether current request is exited.
+	parsedHost      string                       // The parsed host name for current host used by GetHost function.
+	clientIp        string                       // The parsed client ip for current host used by GetClientIp function.
+	bodyContent     []byte                       // Request body content.
+	isFileRequest   bool                         // A bool marking whether current request is file serving.
+	viewObject      *gview.View                  // Custom template view engine object for this response.
+	viewParams      gview.Params                 // Custom template view variables for this response.
+	originUrlPath   string                       // Original URL path that passed from client.
+	injections      map[reflect.Type]interface{} // Typed values provided for injection, see Provide.
 }
 
 // staticFile is the file struct for static file service.
 type staticFile struct {
-	File  *gres.File // Resource file object.
-	Path  string     // File path.
-	IsDir bool       // Is directory.
+	File   *gres.File        // Resource file object.
+	Path   string            // File path.
+	IsDir  bool              // Is directory.
+	Uri    string            // URI relative to the static path for matching cache rules.
+	Config *StaticPathConfig // Serving configuration, nil for defaults.
 }
 
 // newRequest creates and returns a new request object.
@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Provide registers `value` of type T for current request, which can be retrieved by Resolve
+// or injected into the handler declaring a parameter of type T, like:
+// func(ctx context.Context, req *XxxReq, user *User) (*XxxRes, error).
+// It is commonly called in middleware, and returns error if a value of type T is already provided.
+//
+// Note that the value is matched by its exact type T, which means a value provided as type
+// *User cannot be resolved as an interface type it implements.
+func Provide[T any](r *Request, value T) error {
+	typ := injectionType[T]()
+	if _, ok := r.injections[typ]; ok {
+		return gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`value of type "%s" is already provided for current request`,
+			typ.String(),
+		)
+	}
+	if r.injections == nil {
+		r.injections = make(map[reflect.Type]interface{})
+	}
+	r.injections[typ] = value
+	return nil
+}
+
+// MustProvide performs as Provide, but it panics if any error occurs.
+func MustProvide[T any](r *Request, value T) {
+	if err := Provide(r, value); err != nil {
+		panic(err)
+	}
+}
+
+// Resolve retrieves and returns the value of type T provided for current request.
+// The returned `ok` is false if no value of type T is provided.
+func Resolve[T any](r *Request) (value T, ok bool) {
+	v, ok := r.injections[injectionType[T]()]
+	if !ok || v == nil {
+		return
+	}
+	return v.(T), true
+}
+
+// MustResolve performs as Resolve, but it panics if no value of type T is provided.
+func MustResolve[T any](r *Request) T {
+	value, ok := Resolve[T](r)
+	if !ok {
+		panic(gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`no value of type "%s" is provided for current request`,
+			injectionType[T]().String(),
+		))
+	}
+	return value
+}
+
+// injectionType returns the reflect type of T, which also works for interface type.
+func injectionType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// resolveInjections resolves the values of `types` provided for current request,
+// which are used as the injected parameters of handler.
+func (r *Request) resolveInjections(types []reflect.Type) ([]reflect.Value, error) {
+	values := make([]reflect.Value, 0, len(types))
+	for _, typ := range types {
+		v, ok := r.injections[typ]
+		if !ok {
+			return nil, gerror.NewCodef(
+				gcode.CodeInternalError,
+				`no value of type "%s" is provided for injected parameter of handler`,
+				typ.String(),
+			)
+		}
+		if v == nil {
+			values = append(values, reflect.Zero(typ))
+		} else {
+			values = append(values, reflect.ValueOf(v))
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,130 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"sync"
+	"time"
+)
+
+// LongPollOption is the option for parking request of long-polling.
+type LongPollOption struct {
+	// Timeout specifies the max duration parking the request, which is 30 seconds in default.
+	Timeout time.Duration
+
+	// Ready checks whether the data is already available, like the events after the cursor passed
+	// by client. The request is responded immediately without parking if it returns true.
+	Ready func() (data interface{}, ok bool)
+}
+
+// LongPollHub dispatches the events of topics to the requests parked by long-polling,
+// which wakes all the requests waiting on the topic for each published event.
+type LongPollHub struct {
+	mu      sync.Mutex
+	waiters map[string]map[chan interface{}]struct{} // Waiting channels of parked requests by topic.
+}
+
+const (
+	// defaultLongPollTimeout is the default max duration parking the request.
+	defaultLongPollTimeout = 30 * time.Second
+)
+
+// LongPoll parks current request until the data is received from `events`, which returns the data and true.
+// It returns false without parking if the deadline of option Timeout exceeds, the client disconnects,
+// the server starts draining for shutdown, or `events` is closed, and it is commonly responded with
+// status 204 in this situation. It returns immediately if the data is ready checked by option Ready.
+//
+// It blocks in the goroutine serving the request, so there's no goroutine created for each request.
+func (r *Request) LongPoll(events <-chan interface{}, option ...LongPollOption) (data interface{}, ok bool) {
+	var opt LongPollOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = defaultLongPollTimeout
+	}
+	if opt.Ready != nil {
+		if data, ok = opt.Ready(); ok {
+			return
+		}
+	}
+	timer := time.NewTimer(opt.Timeout)
+	defer timer.Stop()
+	select {
+	case data, ok = <-events:
+		return
+
+	case <-timer.C:
+	case <-r.Context().Done():
+	case <-r.Server.drain.drainingChan():
+	}
+	return nil, false
+}
+
+// NewLongPollHub creates and returns a hub for long-polling.
+func NewLongPollHub() *LongPollHub {
+	return &LongPollHub{
+		waiters: make(map[string]map[chan interface{}]struct{}),
+	}
+}
+
+// Poll parks request `r` until an event of `topic` is published, see Request.LongPoll.
+// Note that the events published when the request is not parked are missed, which should be
+// checked by option Ready with the cursor passed by client.
+func (h *LongPollHub) Poll(r *Request, topic string, option ...LongPollOption) (data interface{}, ok bool) {
+	// It subscribes the topic before checking option Ready,
+	// to not miss the event published between them.
+	ch := h.subscribe(topic)
+	defer h.unsubscribe(topic, ch)
+	return r.LongPoll(ch, option...)
+}
+
+// Publish publishes event `data` of `topic`, which wakes all the requests waiting on `topic`.
+// It returns the number of the woken requests.
+func (h *LongPollHub) Publish(topic string, data interface{}) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var woken int
+	for ch := range h.waiters[topic] {
+		select {
+		case ch <- data:
+			woken++
+		default:
+			// The request is already woken by the previous event.
+		}
+	}
+	return woken
+}
+
+// Waiting returns the number of requests waiting on `topic`.
+func (h *LongPollHub) Waiting(topic string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.waiters[topic])
+}
+
+// subscribe subscribes `topic` and returns the channel receiving its event.
+func (h *LongPollHub) subscribe(topic string) chan interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan interface{}, 1)
+	if h.waiters[topic] == nil {
+		h.waiters[topic] = make(map[chan interface{}]struct{})
+	}
+	h.waiters[topic][ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes channel `ch` subscribing `topic`.
+func (h *LongPollHub) unsubscribe(topic string, ch chan interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.waiters[topic], ch)
+	if len(h.waiters[topic]) == 0 {
+		delete(h.waiters, topic)
+	}
+}
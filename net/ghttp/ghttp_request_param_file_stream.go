@@ -0,0 +1,167 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// MultipartPart is a part of the multipart request streamed by StreamMultipart,
+// which reads the content of the part as it arrives.
+type MultipartPart struct {
+	FormName    string               // Form field name of the part.
+	FileName    string               // File name of the part, which is empty if it is not a file.
+	Header      textproto.MIMEHeader // Header of the part.
+	ContentType string               // Content type declared in the part header.
+	SniffedType string               // Content type sniffed from the leading content of the part.
+	reader      io.Reader            // Reader of the part content with size limit.
+}
+
+// MultipartPartHandler handles a part of the multipart request, which stops the streaming if it returns error.
+type MultipartPartHandler func(part *MultipartPart) error
+
+// StreamMultipartOption is the option for StreamMultipart.
+type StreamMultipartOption struct {
+	// MaxPartSize specifies the max size in bytes of each part, it's not limited if it is not greater than 0.
+	MaxPartSize int64
+
+	// MaxParts specifies the max count of parts, it's not limited if it is not greater than 0.
+	MaxParts int
+
+	// AllowedTypes specifies the allowed sniffed content types of file parts, which can be prefix ending with "*"
+	// like "image/*". All types are allowed if it is empty.
+	AllowedTypes []string
+}
+
+// multipartPartLimitReader is the reader returning error if the content exceeds the limit.
+type multipartPartLimitReader struct {
+	reader    io.Reader
+	remaining int64
+	formName  string
+}
+
+const (
+	// multipartSniffLength is the length of leading content for sniffing content type.
+	multipartSniffLength = 512
+)
+
+// Read implements the io.Reader interface, which reads the content of the part.
+func (p *MultipartPart) Read(b []byte) (int, error) {
+	return p.reader.Read(b)
+}
+
+// IsFile checks and returns whether the part is a file.
+func (p *MultipartPart) IsFile() bool {
+	return p.FileName != ""
+}
+
+// StreamMultipart streams the multipart request and calls `handler` for each part as it arrives, in which
+// the content of part is read from the client directly without buffering into memory or temporary files.
+//
+// It returns error if the request is not multipart, or any part exceeds the limits of `option`,
+// or `handler` returns error. Note that it should be called before any form parameters are retrieved,
+// and the form parameters are not available after streaming.
+func (r *Request) StreamMultipart(handler MultipartPartHandler, option ...StreamMultipartOption) error {
+	var opt StreamMultipartOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if r.parsedForm && r.MultipartForm != nil {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `multipart form is already parsed`)
+	}
+	reader, err := r.Request.MultipartReader()
+	if err != nil {
+		return gerror.WrapCode(gcode.CodeInvalidRequest, err, `r.Request.MultipartReader failed`)
+	}
+	// The body is consumed by streaming, which cannot be parsed as form any more.
+	r.parsedForm = true
+	for count := 1; ; count++ {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeInvalidRequest, err, `read multipart part failed`)
+		}
+		if opt.MaxParts > 0 && count > opt.MaxParts {
+			_ = part.Close()
+			return gerror.NewCodef(gcode.CodeInvalidRequest, `multipart parts exceed the limit %d`, opt.MaxParts)
+		}
+		if err = r.handleMultipartPart(part, handler, opt); err != nil {
+			_ = part.Close()
+			return err
+		}
+		if err = part.Close(); err != nil {
+			return gerror.WrapCode(gcode.CodeInvalidRequest, err, `close multipart part failed`)
+		}
+	}
+}
+
+// handleMultipartPart sniffs and checks the content type of `part`, then calls `handler` with the part.
+func (r *Request) handleMultipartPart(part *multipart.Part, handler MultipartPartHandler, opt StreamMultipartOption) error {
+	var contentReader io.Reader = part
+	if opt.MaxPartSize > 0 {
+		contentReader = &multipartPartLimitReader{
+			reader:    part,
+			remaining: opt.MaxPartSize,
+			formName:  part.FormName(),
+		}
+	}
+	var (
+		bufferedReader = bufio.NewReaderSize(contentReader, multipartSniffLength)
+		multipartPart  = &MultipartPart{
+			FormName:    part.FormName(),
+			FileName:    part.FileName(),
+			Header:      part.Header,
+			ContentType: part.Header.Get("Content-Type"),
+			reader:      bufferedReader,
+		}
+	)
+	// It ignores the error here, which is returned when the handler reads the content.
+	leading, _ := bufferedReader.Peek(multipartSniffLength)
+	if len(leading) > 0 {
+		multipartPart.SniffedType = http.DetectContentType(leading)
+	}
+	if multipartPart.IsFile() && len(opt.AllowedTypes) > 0 && multipartPart.SniffedType != "" &&
+		!isContentTypeAllowed(multipartPart.SniffedType, opt.AllowedTypes) {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`content type "%s" of file "%s" is not allowed`,
+			multipartPart.SniffedType, multipartPart.FileName,
+		)
+	}
+	return handler(multipartPart)
+}
+
+// Read implements the io.Reader interface.
+func (l *multipartPartLimitReader) Read(b []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, l.exceededError()
+	}
+	// It reads one more byte than the limit to detect exceeding.
+	if int64(len(b)) > l.remaining+1 {
+		b = b[:l.remaining+1]
+	}
+	n, err := l.reader.Read(b)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), l.exceededError()
+	}
+	return n, err
+}
+
+func (l *multipartPartLimitReader) exceededError() error {
+	return gerror.NewCodef(gcode.CodeInvalidRequest, `size of multipart part "%s" exceeds the limit`, l.formName)
+}
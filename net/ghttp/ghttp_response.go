@@ -23,9 +23,12 @@ import (
 // Response is the http response manager.
 // Note that it implements the http.ResponseWriter interface with buffering feature.
 type Response struct {
-	*response.BufferWriter          // Underlying ResponseWriter.
-	Server                 *Server  // Parent server.
-	Request                *Request // According request.
+	*response.BufferWriter            // Underlying ResponseWriter.
+	Server                 *Server    // Parent server.
+	Request                *Request   // According request.
+	sseWriter              *SSEWriter // Writer of Server-Sent Events, nil if not used.
+	capturedBody           []byte     // Captured response body for access log.
+	capturedTruncated      bool       // Whether the captured response body is truncated.
 }
 
 // newResponse creates and returns a new Response object.
@@ -131,5 +134,6 @@ func (r *Response) Flush() {
 	if r.Server.config.ServerAgent != "" {
 		r.Header().Set("Server", r.Server.config.ServerAgent)
 	}
+	r.captureResponseBody()
 	r.BufferWriter.Flush()
 }
@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	responseHeaderETag         = "ETag"
+	responseHeaderLastModified = "Last-Modified"
+	requestHeaderIfNoneMatch   = "If-None-Match"
+	requestHeaderIfModified    = "If-Modified-Since"
+	etagWeakPrefix             = "W/"
+)
+
+// SetETag sets the header "ETag" of response with `etag`, which is quoted automatically if it is not.
+// The optional parameter `weak` specifies whether it is a weak validator like `W/"xxx"`.
+func (r *Response) SetETag(etag string, weak ...bool) {
+	if !gstr.HasPrefix(etag, `"`) && !gstr.HasPrefix(etag, etagWeakPrefix) {
+		etag = `"` + etag + `"`
+	}
+	if len(weak) > 0 && weak[0] && !gstr.HasPrefix(etag, etagWeakPrefix) {
+		etag = etagWeakPrefix + etag
+	}
+	r.Header().Set(responseHeaderETag, etag)
+}
+
+// SetLastModified sets the header "Last-Modified" of response with time `t`.
+func (r *Response) SetLastModified(t time.Time) {
+	r.Header().Set(responseHeaderLastModified, t.UTC().Format(http.TimeFormat))
+}
+
+// IsNotModified checks and returns whether the content of response is not modified for the client,
+// according to the request headers "If-None-Match" and "If-Modified-Since" and the response headers
+// "ETag" and "Last-Modified". It is always false for the requests other than GET and HEAD.
+//
+// The "If-Modified-Since" is ignored if the request has "If-None-Match", see RFC 7232.
+func (r *Response) IsNotModified() bool {
+	if r.Request.Method != http.MethodGet && r.Request.Method != http.MethodHead {
+		return false
+	}
+	if ifNoneMatch := r.Request.Header.Get(requestHeaderIfNoneMatch); ifNoneMatch != "" {
+		etag := r.Header().Get(responseHeaderETag)
+		if etag == "" {
+			return false
+		}
+		for _, v := range strings.Split(ifNoneMatch, ",") {
+			v = strings.TrimSpace(v)
+			if v == "*" || etagWeakMatch(v, etag) {
+				return true
+			}
+		}
+		return false
+	}
+	ifModifiedSince := r.Request.Header.Get(requestHeaderIfModified)
+	if ifModifiedSince == "" {
+		return false
+	}
+	lastModified, err := http.ParseTime(r.Header().Get(responseHeaderLastModified))
+	if err != nil {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// WriteNotModified clears the buffer and writes status 304 Not Modified to response,
+// which keeps the validator headers for the client.
+func (r *Response) WriteNotModified() {
+	header := r.Header()
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	r.ClearBuffer()
+	r.WriteHeader(http.StatusNotModified)
+}
+
+// etagWeakMatch reports whether the two entity tags match by weak comparison,
+// which ignores the weak prefix of them.
+func etagWeakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, etagWeakPrefix) == strings.TrimPrefix(b, etagWeakPrefix)
+}
@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package ghttp
+
+import (
+	"net/http"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	responseHeaderLink = "Link"
+)
+
+// WriteEarlyHints sends 103 Early Hints with header "Link" of `links` immediately, so that the client can
+// preload the assets while the final response is in processing. The link is like:
+// </style.css>; rel=preload; as=style
+//
+// It can be called multiple times before the final response, and the links are also kept in the final response.
+// It returns error if the header is already sent to the client.
+func (r *Response) WriteEarlyHints(links ...string) error {
+	if len(links) == 0 {
+		return nil
+	}
+	var (
+		header = r.Header()
+		saved  = header.Clone()
+	)
+	// The informational response carries header "Link" only.
+	for k := range header {
+		delete(header, k)
+	}
+	header[responseHeaderLink] = links
+	ok := r.BufferWriter.Writer.WriteInformational(http.StatusEarlyHints)
+	for k := range header {
+		delete(header, k)
+	}
+	for k, v := range saved {
+		header[k] = v
+	}
+	if !ok {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `early hints cannot be sent after the header is written`)
+	}
+	for _, link := range links {
+		header.Add(responseHeaderLink, link)
+	}
+	return nil
+}
+
+// SetTrailer sets HTTP trailer `key` with `value`, which is sent after the response body.
+// It can be called at any time before the handler returns, even after the body is flushed.
+//
+// Note that the trailers are only sent for the responses in chunked encoding, which is not the case if the
+// header "Content-Length" is set.
+func (r *Response) SetTrailer(key, value string) {
+	r.Header().Set(http.TrailerPrefix+key, value)
+}
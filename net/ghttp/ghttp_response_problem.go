@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/i18n/gi18n"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gvalid"
+)
+
+// ProblemDetails is the problem details for HTTP APIs defined by RFC 7807,
+// which is responded with content type "application/problem+json".
+type ProblemDetails struct {
+	Type       string             `json:"type,omitempty"       dc:"URI reference identifying the problem type"`
+	Title      string             `json:"title"                dc:"Short summary of the problem type"`
+	Status     int                `json:"status"               dc:"HTTP status code"`
+	Detail     string             `json:"detail,omitempty"     dc:"Explanation specific to this occurrence of the problem"`
+	Instance   string             `json:"instance,omitempty"   dc:"URI reference identifying this occurrence of the problem"`
+	Code       int                `json:"code"                 dc:"Error code"`
+	Violations []ProblemViolation `json:"violations,omitempty" dc:"Violations of request fields if validation failed"`
+}
+
+// ProblemViolation is the violation of a request field for ProblemDetails.
+type ProblemViolation struct {
+	Field   string `json:"field"   dc:"Field name"`
+	Rule    string `json:"rule"    dc:"Validation rule name"`
+	Message string `json:"message" dc:"Violation message"`
+}
+
+const (
+	// ErrorResponseFormatDefault responds errors using DefaultHandlerResponse.
+	ErrorResponseFormatDefault = "default"
+
+	// ErrorResponseFormatProblem responds errors using ProblemDetails of RFC 7807.
+	ErrorResponseFormatProblem = "problem"
+
+	contentTypeProblemJson = "application/problem+json"
+)
+
+var (
+	// problemStatusMap is the HTTP statuses of builtin error codes for ProblemDetails,
+	// which are used if the codes are not registered with HTTP statuses.
+	problemStatusMap = map[int]int{
+		gcode.CodeValidationFailed.Code():         http.StatusBadRequest,
+		gcode.CodeInvalidParameter.Code():         http.StatusBadRequest,
+		gcode.CodeMissingParameter.Code():         http.StatusBadRequest,
+		gcode.CodeInvalidRequest.Code():           http.StatusBadRequest,
+		gcode.CodeNotAuthorized.Code():            http.StatusUnauthorized,
+		gcode.CodeSecurityReason.Code():           http.StatusForbidden,
+		gcode.CodeNotFound.Code():                 http.StatusNotFound,
+		gcode.CodeBusinessValidationFailed.Code(): http.StatusUnprocessableEntity,
+		gcode.CodeServerBusy.Code():               http.StatusServiceUnavailable,
+		gcode.CodeNotImplemented.Code():           http.StatusNotImplemented,
+	}
+)
+
+// NewProblemDetails creates and returns the ProblemDetails of request `r` for `err`.
+//
+// The status is the current response status if it is already set with error status, or else the
+// HTTP status of error code. The violations are created from the validation error of package gvalid.
+func NewProblemDetails(r *Request, err error) *ProblemDetails {
+	var (
+		code    = gerror.Code(err)
+		problem = &ProblemDetails{
+			Status:   r.Response.Status,
+			Instance: r.URL.Path,
+		}
+	)
+	if code == gcode.CodeNil {
+		code = gcode.CodeInternalError
+	}
+	problem.Code = code.Code()
+	if problem.Status < http.StatusBadRequest {
+		if status, ok := gcode.HttpStatus(code); ok {
+			problem.Status = status
+		} else if status, ok = problemStatusMap[code.Code()]; ok {
+			problem.Status = status
+		} else {
+			problem.Status = http.StatusInternalServerError
+		}
+	}
+	problem.Title = http.StatusText(problem.Status)
+	if err != nil {
+		problem.Detail = gi18n.TranslateError(r.Context(), err)
+	}
+	if problem.Detail == "" {
+		if registration, ok := gcode.GetRegistration(code); ok {
+			problem.Detail = registration.Message
+		}
+	}
+	var validationErr gvalid.Error
+	if errors.As(err, &validationErr) {
+		for _, item := range validationErr.Items() {
+			for field, ruleErrors := range item {
+				rules := make([]string, 0, len(ruleErrors))
+				for rule := range ruleErrors {
+					rules = append(rules, rule)
+				}
+				sort.Strings(rules)
+				for _, rule := range rules {
+					problem.Violations = append(problem.Violations, ProblemViolation{
+						Field:   field,
+						Rule:    rule,
+						Message: ruleErrors[rule].Error(),
+					})
+				}
+			}
+		}
+	}
+	return problem
+}
+
+// WriteProblem writes `problem` to the response with its status and content type "application/problem+json".
+func (r *Response) WriteProblem(problem *ProblemDetails) {
+	b, err := json.Marshal(problem)
+	if err != nil {
+		panic(gerror.Wrap(err, `WriteProblem failed`))
+	}
+	r.Header().Set("Content-Type", contentTypeProblemJson)
+	r.WriteHeader(problem.Status)
+	r.Write(b)
+}
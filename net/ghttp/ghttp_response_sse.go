@@ -0,0 +1,205 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package ghttp
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// SSEEvent is an event of Server-Sent Events.
+type SSEEvent struct {
+	Id    string        // Id of event, which is sent as the "Last-Event-ID" by client when reconnecting.
+	Event string        // Type of event, which is "message" for client if empty.
+	Data  interface{}   // Data of event, in which string and []byte are sent as they are, and others are encoded as JSON.
+	Retry time.Duration // Reconnection time of client, which is not sent if 0.
+}
+
+// SSEWriter writes Server-Sent Events to the client, which flushes each event immediately.
+// It is safe for concurrent use.
+type SSEWriter struct {
+	response *Response
+	mu       sync.Mutex
+	closed   bool
+	done     chan struct{} // Closed if the client disconnects or the writer is closed.
+}
+
+const (
+	// defaultSSEHeartbeatInterval is the default interval of heartbeat comments.
+	defaultSSEHeartbeatInterval = 15 * time.Second
+)
+
+var (
+	// ErrSSEClosed is returned by SSEWriter if the client disconnects or the request is done.
+	ErrSSEClosed = gerror.NewWithOption(gerror.Option{
+		Text: "server-sent events writer is closed",
+		Code: gcode.CodeInvalidOperation,
+	})
+)
+
+// SSE sets the headers for Server-Sent Events and returns the event writer of the response,
+// which is closed if the client disconnects or the request is done. It sends a heartbeat comment in
+// `heartbeat` interval keeping the connection alive through proxies, which is 15 seconds in default,
+// and no heartbeat if it is <= 0. It returns the same writer if it is called repeatedly.
+//
+// The content in buffer is output to the client before any event.
+func (r *Response) SSE(heartbeat ...time.Duration) *SSEWriter {
+	if r.sseWriter != nil {
+		return r.sseWriter
+	}
+	var interval = defaultSSEHeartbeatInterval
+	if len(heartbeat) > 0 {
+		interval = heartbeat[0]
+	}
+	header := r.Header()
+	header.Set("Content-Type", contentTypeEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// It disables the response buffering of nginx.
+	header.Set("X-Accel-Buffering", "no")
+	if r.Status == 0 {
+		r.Status = http.StatusOK
+	}
+	r.Flush()
+	if !r.Writer.IsHijacked() {
+		r.Writer.WriteHeader(r.Status)
+		r.Writer.Flush()
+	}
+	w := &SSEWriter{
+		response: r,
+		done:     make(chan struct{}),
+	}
+	r.sseWriter = w
+	go w.watch(interval)
+	return w
+}
+
+// Send sends `event` to the client.
+func (w *SSEWriter) Send(event SSEEvent) error {
+	if strings.ContainsAny(event.Id, "\r\n") || strings.ContainsAny(event.Event, "\r\n") {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `id and event type of event cannot contain line breaks`)
+	}
+	var buffer bytes.Buffer
+	if event.Id != "" {
+		buffer.WriteString("id: " + event.Id + "\n")
+	}
+	if event.Event != "" {
+		buffer.WriteString("event: " + event.Event + "\n")
+	}
+	if event.Retry > 0 {
+		buffer.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	var data string
+	switch v := event.Data.(type) {
+	case nil:
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return gerror.Wrap(err, `marshal data of event failed`)
+		}
+		data = string(b)
+	}
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		buffer.WriteString("data: " + line + "\n")
+	}
+	buffer.WriteString("\n")
+	return w.write(buffer.Bytes())
+}
+
+// SendData sends an event of type "message" with `data` to the client.
+func (w *SSEWriter) SendData(data interface{}) error {
+	return w.Send(SSEEvent{Data: data})
+}
+
+// Comment sends `comment` to the client, which is ignored by the client.
+func (w *SSEWriter) Comment(comment string) error {
+	var buffer bytes.Buffer
+	for _, line := range strings.Split(strings.ReplaceAll(comment, "\r\n", "\n"), "\n") {
+		buffer.WriteString(": " + line + "\n")
+	}
+	buffer.WriteString("\n")
+	return w.write(buffer.Bytes())
+}
+
+// Done returns a channel that is closed if the client disconnects or the writer is closed,
+// which is used for stopping producing events.
+func (w *SSEWriter) Done() <-chan struct{} {
+	return w.done
+}
+
+// Close closes the writer and stops the heartbeat, after which no more events can be sent.
+// It is called automatically after the request is done.
+func (w *SSEWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.close()
+}
+
+// close closes the writer without lock.
+func (w *SSEWriter) close() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.done)
+}
+
+// write writes `data` to the client and flushes it immediately.
+func (w *SSEWriter) write(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed || w.response.Writer.IsHijacked() {
+		return ErrSSEClosed
+	}
+	if _, err := w.response.Writer.Write(data); err != nil {
+		// The client disconnects.
+		w.close()
+		return gerror.Wrap(ErrSSEClosed, err.Error())
+	}
+	w.response.Writer.Flush()
+	return nil
+}
+
+// watch closes the writer if the client disconnects, and sends the heartbeat comments in `interval`.
+func (w *SSEWriter) watch(interval time.Duration) {
+	var (
+		ctx       = w.response.Request.Context()
+		heartbeat <-chan time.Time
+	)
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			w.Close()
+			return
+		case <-w.done:
+			return
+		case <-heartbeat:
+			if err := w.Comment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
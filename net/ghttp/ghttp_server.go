@@ -100,12 +100,17 @@ func GetServer(name ...interface{}) *Server {
 			servers:          make([]*gracefulServer, 0),
 			closeChan:        make(chan struct{}, 10000),
 			serverCount:      gtype.NewInt(),
+			http3AltSvc:      gtype.NewString(),
 			statusHandlerMap: make(map[string][]HandlerFunc),
 			serveTree:        make(map[string]interface{}),
 			serveCache:       gcache.New(),
 			routesMap:        make(map[string][]*HandlerItem),
 			openapi:          goai.New(),
 			registrar:        gsvc.GetRegistry(),
+			drain:            newDrainTracker(),
+			versions:         newVersionRegistry(),
+			certificates:     newCertificateReloader(),
+			live:             newLiveConfig(),
 		}
 		// Initialize the server using default configurations.
 		if err := s.SetConfig(NewConfig()); err != nil {
@@ -176,6 +181,12 @@ func (s *Server) Start() error {
 		s.EnablePProf(s.config.PProfPattern)
 	}
 
+	// Admission control of concurrent requests.
+	s.admission = newAdmissionController(s.config)
+
+	// The draining of previous shutdown should not affect the requests after restarting.
+	s.drain.reset()
+
 	// Default HTTP handler.
 	if s.config.Handler == nil {
 		s.config.Handler = s.ServeHTTP
@@ -499,7 +510,8 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 		httpsEnabled bool
 	)
 	// HTTPS
-	if s.config.TLSConfig != nil || (s.config.HTTPSCertPath != "" && s.config.HTTPSKeyPath != "") {
+	if s.config.TLSConfig != nil || s.config.CertificateProvider != nil ||
+		(s.config.HTTPSCertPath != "" && s.config.HTTPSKeyPath != "") {
 		if len(s.config.HTTPSAddr) == 0 {
 			if len(s.config.Address) > 0 {
 				s.config.HTTPSAddr = s.config.Address
@@ -581,6 +593,10 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 		go s.startGracefulServer(ctx, wg, gs)
 	}
 	wg.Wait()
+	// HTTP/3 over QUIC, which listens on the addresses of HTTPS in default.
+	if err := s.startHTTP3Servers(ctx); err != nil {
+		s.Logger().Fatalf(ctx, `%+v`, err)
+	}
 }
 
 func (s *Server) startGracefulServer(ctx context.Context, wg *sync.WaitGroup, server *gracefulServer) {
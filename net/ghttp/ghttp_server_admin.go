@@ -8,6 +8,7 @@ package ghttp
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -43,6 +44,14 @@ please make sure it is running using standalone binary not from IDE or "go run"
                 <p>
 <a href="{{$.uri}}/shutdown">Shutdown</a>
 graceful shutdown the server
+</p>
+                <p>
+<a href="{{$.uri}}/config">Config</a>
+show the configuration that can be applied live, which is applied by POST request with the values
+</p>
+                <p>
+<a href="{{$.uri}}/reload-certificate">Reload Certificate</a>
+reload the HTTPS certificate from files without dropping connections
 </p>
             </body>
             </html>
@@ -78,6 +87,25 @@ func (p *utilAdmin) Shutdown(r *Request) {
 	r.Response.WriteExit("server shutdown")
 }
 
+// Config shows the configuration that can be applied live, or applies the request parameters
+// to the server without restarting it if it is not a GET request.
+func (p *utilAdmin) Config(r *Request) {
+	if r.Method != http.MethodGet {
+		if err := r.Server.SetLiveConfig(r.GetRequestMap()); err != nil {
+			r.Response.WriteStatusExit(http.StatusBadRequest, err.Error())
+		}
+	}
+	r.Response.WriteJsonExit(r.Server.GetLiveConfig())
+}
+
+// ReloadCertificate reloads the HTTPS certificate from files for the server.
+func (p *utilAdmin) ReloadCertificate(r *Request) {
+	if err := r.Server.ReloadCertificate(); err != nil {
+		r.Response.WriteStatusExit(http.StatusInternalServerError, err.Error())
+	}
+	r.Response.WriteExit("certificate reloaded")
+}
+
 // EnableAdmin enables the administration feature for the process.
 // The optional parameter `pattern` specifies the URI for the administration page.
 func (s *Server) EnableAdmin(pattern ...string) {
@@ -88,14 +116,8 @@ func (s *Server) EnableAdmin(pattern ...string) {
 	s.BindObject(p, &utilAdmin{})
 }
 
-// Shutdown shuts down current server.
+// Shutdown shuts down current server gracefully, see Drain.
 func (s *Server) Shutdown() error {
-	var ctx = context.TODO()
-	s.doServiceDeregister()
-	// Only shut down current servers.
-	// It may have multiple underlying http servers.
-	for _, v := range s.servers {
-		v.shutdown(ctx)
-	}
-	return nil
+	_, err := s.Drain(context.TODO())
+	return err
 }
@@ -265,10 +265,8 @@ func shutdownWebServersGracefully(ctx context.Context, signal os.Signal) {
 	}
 	serverMapping.RLockFunc(func(m map[string]interface{}) {
 		for _, v := range m {
-			server := v.(*Server)
-			server.doServiceDeregister()
-			for _, s := range server.servers {
-				s.shutdown(ctx)
+			if _, err := v.(*Server).Drain(ctx); err != nil {
+				glog.Errorf(ctx, `%+v`, err)
 			}
 		}
 	})
@@ -281,6 +279,9 @@ func forceCloseWebServers(ctx context.Context) {
 			for _, s := range v.(*Server).servers {
 				s.close(ctx)
 			}
+			for _, s := range v.(*Server).http3Servers {
+				s.close(ctx)
+			}
 		}
 	})
 }
@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// admissionController limits the concurrent requests of the server, and queues the requests
+// exceeding the limit by route, which are rejected if the queue of the route is full or timeout.
+type admissionController struct {
+	slots     chan struct{}  // Slots of concurrent requests.
+	mu        sync.Mutex     // Mutex for queued.
+	queued    map[string]int // Number of queued requests by route.
+	maxQueued int            // Max number of queued requests of each route.
+	timeout   time.Duration  // Max duration that a request waits in queue.
+}
+
+const (
+	admissionRejectQueueFull    = "queue_full"
+	admissionRejectQueueTimeout = "queue_timeout"
+	admissionRejectCanceled     = "canceled"
+)
+
+// newAdmissionController creates and returns an admission controller by configuration,
+// which returns nil if the concurrent requests are not limited.
+func newAdmissionController(config ServerConfig) *admissionController {
+	if config.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+	return &admissionController{
+		slots:     make(chan struct{}, config.MaxConcurrentRequests),
+		queued:    make(map[string]int),
+		maxQueued: config.MaxQueuedRequests,
+		timeout:   config.RequestQueueTimeout,
+	}
+}
+
+// admit waits for a slot of request `r` on `route`, which returns the reason if it is rejected.
+func (c *admissionController) admit(r *Request, route string) (reason string) {
+	select {
+	case c.slots <- struct{}{}:
+		return ""
+	default:
+	}
+	c.mu.Lock()
+	if c.queued[route] >= c.maxQueued {
+		c.mu.Unlock()
+		return admissionRejectQueueFull
+	}
+	c.queued[route]++
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		if c.queued[route]--; c.queued[route] <= 0 {
+			delete(c.queued, route)
+		}
+		c.mu.Unlock()
+	}()
+
+	s := r.Server
+	s.handleMetricsRequestQueued(r, 1)
+	defer s.handleMetricsRequestQueued(r, -1)
+
+	var timeoutChan <-chan time.Time
+	if c.timeout > 0 {
+		timer := time.NewTimer(c.timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return ""
+	case <-timeoutChan:
+		return admissionRejectQueueTimeout
+	case <-r.Context().Done():
+		return admissionRejectCanceled
+	}
+}
+
+// release releases the slot of an admitted request.
+func (c *admissionController) release() {
+	<-c.slots
+}
+
+// retryAfter returns the seconds for header "Retry-After" of the rejected requests.
+func (c *admissionController) retryAfter() string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(c.timeout.Seconds()))))
+}
+
+// handleAdmission admits request `r` by the admission controller of the server,
+// which returns a function releasing the admission, or nil if it is rejected.
+//
+// The rejected request responds status 503 with header "Retry-After" and exits.
+func (s *Server) handleAdmission(r *Request) (release func()) {
+	if s.admission == nil {
+		return func() {}
+	}
+	var route string
+	if r.serveHandler != nil && r.serveHandler.Handler.Router != nil {
+		route = r.Method + " " + r.serveHandler.Handler.Router.Uri
+	} else {
+		route = r.Method + " " + r.URL.Path
+	}
+	if reason := s.admission.admit(r, route); reason != "" {
+		s.handleMetricsRequestRejected(r, reason)
+		r.Response.Header().Set("Retry-After", s.admission.retryAfter())
+		r.Response.WriteStatus(http.StatusServiceUnavailable)
+		r.exitAll = true
+		return nil
+	}
+	return s.admission.release
+}
@@ -9,7 +9,6 @@ package ghttp
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
 	"net"
 	"net/http"
 	"strconv"
@@ -54,6 +53,9 @@ type ServerConfig struct {
 
 	// Address specifies the server listening address like "port" or ":port",
 	// multiple addresses joined using ','.
+	// It also supports the unix domain socket like "unix:/run/app.sock", the socket passed by
+	// systemd socket activation like "systemd:" or "systemd:name", and the pre-opened
+	// file descriptor passed by launchd or other supervisors like "fd:3".
 	Address string `json:"address"`
 
 	// HTTPSAddr specifies the HTTPS addresses, multiple addresses joined using char ','.
@@ -62,6 +64,12 @@ type ServerConfig struct {
 	// Listeners specifies the custom listeners.
 	Listeners []net.Listener `json:"listeners"`
 
+	// UnixSocketMode specifies the file mode of unix domain socket in octal like "0660".
+	UnixSocketMode string `json:"unixSocketMode"`
+
+	// UnixSocketOwner specifies the owner of unix domain socket like "user:group", "user" or "1000:1000".
+	UnixSocketOwner string `json:"unixSocketOwner"`
+
 	// Endpoints are custom endpoints for service register, it uses Address if empty.
 	Endpoints []string `json:"endpoints"`
 
@@ -71,6 +79,14 @@ type ServerConfig struct {
 	// HTTPSKeyPath specifies the key file path for HTTPS service.
 	HTTPSKeyPath string `json:"httpsKeyPath"`
 
+	// HTTPSCertWatch enables watching the certification and key files of HTTPS, which reloads
+	// the certificate without restarting the server if the files are changed.
+	HTTPSCertWatch bool `json:"httpsCertWatch"`
+
+	// CertificateProvider provides the certificate for each TLS handshake of HTTPS,
+	// which takes priority over the certification and key files if it is set.
+	CertificateProvider CertificateProvider `json:"-"`
+
 	// TLSConfig optionally provides a TLS configuration for use
 	// by ServeTLS and ListenAndServeTLS. Note that this value is
 	// cloned by ServeTLS and ListenAndServeTLS, so it's not
@@ -80,9 +96,22 @@ type ServerConfig struct {
 	// instead.
 	TLSConfig *tls.Config `json:"tlsConfig"`
 
+	// HTTP3Enabled enables serving HTTP/3 over QUIC alongside HTTPS, which requires HTTPS enabled
+	// and the HTTP/3 server registered by RegisterHTTP3Server,
+	// like importing package "github.com/gogf/gf/contrib/net/gquic/v2".
+	HTTP3Enabled bool `json:"http3Enabled"`
+
+	// HTTP3Addr specifies the UDP addresses for HTTP/3, multiple addresses joined using char ','.
+	// It uses the same addresses as the listened HTTPS ones if empty.
+	HTTP3Addr string `json:"http3Addr"`
+
 	// Handler the handler for HTTP request.
 	Handler func(w http.ResponseWriter, r *http.Request) `json:"-"`
 
+	// GrpcHandler the handler for gRPC request like *grpc.Server, which serves gRPC requests
+	// on the same listeners with HTTP requests.
+	GrpcHandler http.Handler `json:"-"`
+
 	// ReadTimeout is the maximum duration for reading the entire
 	// request, including the body.
 	//
@@ -146,6 +175,9 @@ type ServerConfig struct {
 	// StaticPaths specifies URI to directory mapping array.
 	StaticPaths []staticPathItem `json:"staticPaths"`
 
+	// StaticConfig specifies the serving configuration for static files of ServerRoot and SearchPaths.
+	StaticConfig StaticPathConfig `json:"staticConfig"`
+
 	// FileServerEnabled is the global switch for static service.
 	// It is automatically set enabled if any static path is set.
 	FileServerEnabled bool `json:"fileServerEnabled"`
@@ -215,6 +247,9 @@ type ServerConfig struct {
 	AccessLogEnabled bool         `json:"accessLogEnabled"` // AccessLogEnabled enables access logging content to files.
 	AccessLogPattern string       `json:"accessLogPattern"` // AccessLogPattern specifies the error log file pattern like: access-{Ymd}.log
 
+	// AccessLog specifies the pipeline for access logging, like the encoder, sampling and sinks.
+	AccessLog AccessLogConfig `json:"accessLog"`
+
 	// ======================================================================================================
 	// PProf.
 	// ======================================================================================================
@@ -230,6 +265,10 @@ type ServerConfig struct {
 	SwaggerPath       string `json:"swaggerPath"`       // SwaggerPath specifies the swagger UI path for route registering.
 	SwaggerUITemplate string `json:"swaggerUITemplate"` // SwaggerUITemplate specifies the swagger UI custom template
 
+	// OpenApiVersion specifies the version of OpenApi specification, which is "3.0.0" in default.
+	// It produces the schemas as JSON Schema 2020-12 and the webhooks if it is "3.1.0".
+	OpenApiVersion string `json:"openapiVersion"`
+
 	// ======================================================================================================
 	// Graceful reload & shutdown.
 	// ======================================================================================================
@@ -243,6 +282,32 @@ type ServerConfig struct {
 	// GracefulShutdownTimeout set the maximum survival time (seconds) before stopping the server.
 	GracefulShutdownTimeout int `json:"gracefulShutdownTimeout"`
 
+	// ======================================================================================================
+	// Tracing.
+	// ======================================================================================================
+
+	// TracingPropagators specifies the propagator names for extracting tracing context from request headers,
+	// the available names are: tracecontext, baggage, b3, b3multi and none.
+	// It uses the global propagator if it is empty.
+	TracingPropagators []string `json:"tracingPropagators"`
+
+	// ======================================================================================================
+	// Admission control.
+	// ======================================================================================================
+
+	// MaxConcurrentRequests specifies the max number of requests served concurrently by the server,
+	// the requests exceeding it are queued or rejected with status 503 and header "Retry-After".
+	// It's 0 in default, which means no limit.
+	MaxConcurrentRequests int `json:"maxConcurrentRequests"`
+
+	// MaxQueuedRequests specifies the max number of requests of each route waiting in queue
+	// if the server is saturated. It's 0 in default, which means rejecting immediately.
+	MaxQueuedRequests int `json:"maxQueuedRequests"`
+
+	// RequestQueueTimeout specifies the max duration that a request waits in queue,
+	// after which it is rejected. It's 10 seconds in default.
+	RequestQueueTimeout time.Duration `json:"requestQueueTimeout"`
+
 	// ======================================================================================================
 	// Other.
 	// ======================================================================================================
@@ -267,6 +332,17 @@ type ServerConfig struct {
 
 	// DumpRouterMap specifies whether automatically dumps router map when server starts.
 	DumpRouterMap bool `json:"dumpRouterMap"`
+
+	// ErrorResponseFormat specifies the format of error responses of MiddlewareHandlerResponse,
+	// which is "default" for DefaultHandlerResponse, or "problem" for RFC 7807 problem details.
+	ErrorResponseFormat string `json:"errorResponseFormat"`
+
+	// JWT specifies the token verification configuration of MiddlewareJWT if no configuration given.
+	JWT JWTConfig `json:"jwt"`
+
+	// ApiVersionHeader specifies the request header negotiating the API version of routes registered
+	// by RouterGroup.Version, which is "Api-Version" in default.
+	ApiVersionHeader string `json:"apiVersionHeader"`
 }
 
 // NewConfig creates and returns a ServerConfig object with default configurations.
@@ -313,6 +389,7 @@ func NewConfig() ServerConfig {
 		Graceful:                false,
 		GracefulTimeout:         2, // seconds
 		GracefulShutdownTimeout: 5, // seconds
+		RequestQueueTimeout:     10 * time.Second,
 	}
 }
 
@@ -382,6 +459,18 @@ func (s *Server) SetConfig(c ServerConfig) error {
 	if err := s.config.Logger.SetLevelStr(s.config.LogLevel); err != nil {
 		intlog.Errorf(context.TODO(), `%+v`, err)
 	}
+	s.resetAccessLogPipeline()
+	s.resetJWTVerifier()
+	// OpenApi.
+	if c.OpenApiVersion != "" {
+		s.openapi.OpenAPI = c.OpenApiVersion
+	}
+	// Tracing.
+	if len(c.TracingPropagators) > 0 {
+		if err := s.SetTracingPropagators(c.TracingPropagators...); err != nil {
+			return err
+		}
+	}
 	gracefulEnabled = c.Graceful
 	intlog.Printf(context.TODO(), "SetConfig: %+v", s.config)
 	return nil
@@ -393,11 +482,24 @@ func (s *Server) SetConfig(c ServerConfig) error {
 // SetAddr("0.0.0.0:80")
 // SetAddr("127.0.0.1:80")
 // SetAddr("180.18.99.10:80")
+// SetAddr("unix:/run/app.sock")
 // etc.
 func (s *Server) SetAddr(address string) {
 	s.config.Address = address
 }
 
+// SetUnixSocketMode sets the file mode of unix domain socket in octal like "0660",
+// which is used for the address like "unix:/run/app.sock".
+func (s *Server) SetUnixSocketMode(mode string) {
+	s.config.UnixSocketMode = mode
+}
+
+// SetUnixSocketOwner sets the owner of unix domain socket like "user:group", "user" or "1000:1000",
+// which is used for the address like "unix:/run/app.sock".
+func (s *Server) SetUnixSocketOwner(owner string) {
+	s.config.UnixSocketOwner = owner
+}
+
 // SetPort sets the listening ports for the server.
 // The listening ports can be multiple like: SetPort(80, 8080).
 func (s *Server) SetPort(port ...int) {
@@ -442,7 +544,7 @@ func (s *Server) SetListener(listeners ...net.Listener) error {
 			if v == nil {
 				return gerror.NewCodef(gcode.CodeInvalidParameter, "SetListener failed: listener can not be nil")
 			}
-			ports[k] = fmt.Sprintf(":%d", (v.Addr().(*net.TCPAddr)).Port)
+			ports[k] = listenerAddress(v)
 		}
 		s.config.Address = strings.Join(ports, ",")
 		s.config.Listeners = listeners
@@ -494,6 +596,29 @@ func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
 	s.config.TLSConfig = tlsConfig
 }
 
+// SetHTTPSCertWatch enables or disables watching the certification and key files of HTTPS,
+// which reloads the certificate without restarting the server if the files are changed.
+func (s *Server) SetHTTPSCertWatch(enabled bool) {
+	s.config.HTTPSCertWatch = enabled
+}
+
+// SetCertificateProvider sets the provider of certificate for each TLS handshake and enables HTTPS
+// feature for the server, which takes priority over the certification and key files.
+func (s *Server) SetCertificateProvider(provider CertificateProvider) {
+	s.config.CertificateProvider = provider
+}
+
+// EnableHTTP3 enables or disables serving HTTP/3 over QUIC alongside HTTPS for the server.
+// The HTTPS responses advertise the HTTP/3 service in header "Alt-Svc" if it is enabled.
+func (s *Server) EnableHTTP3(enabled bool) {
+	s.config.HTTP3Enabled = enabled
+}
+
+// SetHTTP3Addr sets the UDP listening addresses for HTTP/3 of the server.
+func (s *Server) SetHTTP3Addr(address string) {
+	s.config.HTTP3Addr = address
+}
+
 // SetReadTimeout sets the ReadTimeout for the server.
 func (s *Server) SetReadTimeout(t time.Duration) {
 	s.config.ReadTimeout = t
@@ -557,6 +682,16 @@ func (s *Server) GetHandler() func(w http.ResponseWriter, r *http.Request) {
 	return s.config.Handler
 }
 
+// SetGrpcHandler sets the gRPC handler for server, see ServerConfig.GrpcHandler.
+func (s *Server) SetGrpcHandler(handler http.Handler) {
+	s.config.GrpcHandler = handler
+}
+
+// GetGrpcHandler returns the gRPC handler of the server, which is nil if not set.
+func (s *Server) GetGrpcHandler() http.Handler {
+	return s.config.GrpcHandler
+}
+
 // SetRegistrar sets the Registrar for server.
 func (s *Server) SetRegistrar(registrar gsvc.Registrar) {
 	s.registrar = registrar
@@ -21,3 +21,10 @@ func (s *Server) SetSwaggerUITemplate(swaggerUITemplate string) {
 func (s *Server) SetOpenApiPath(path string) {
 	s.config.OpenApiPath = path
 }
+
+// SetOpenApiVersion sets the OpenApiVersion for server, like: goai.OpenApiVersion31.
+// Note that it should be called before adding any object to the OpenApi specification of server.
+func (s *Server) SetOpenApiVersion(version string) {
+	s.config.OpenApiVersion = version
+	s.openapi.OpenAPI = version
+}
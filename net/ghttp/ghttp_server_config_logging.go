@@ -22,6 +22,7 @@ func (s *Server) SetLogPath(path string) error {
 			return err
 		}
 	}
+	s.resetAccessLogPipeline()
 	return nil
 }
 
@@ -29,6 +30,7 @@ func (s *Server) SetLogPath(path string) error {
 // Note that it cannot be set in runtime as there may be concurrent safety issue.
 func (s *Server) SetLogger(logger *glog.Logger) {
 	s.config.Logger = logger
+	s.resetAccessLogPipeline()
 }
 
 // Logger is alias of GetLogger.
@@ -44,6 +46,7 @@ func (s *Server) SetLogLevel(level string) {
 // SetLogStdout sets whether output the logging content to stdout.
 func (s *Server) SetLogStdout(enabled bool) {
 	s.config.LogStdout = enabled
+	s.resetAccessLogPipeline()
 }
 
 // SetAccessLogEnabled enables/disables the access log.
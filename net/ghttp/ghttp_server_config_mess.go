@@ -6,6 +6,8 @@
 
 package ghttp
 
+import "time"
+
 // SetNameToUriType sets the NameToUriType for server.
 func (s *Server) SetNameToUriType(t int) {
 	s.config.NameToUriType = t
@@ -17,6 +19,21 @@ func (s *Server) SetDumpRouterMap(enabled bool) {
 	s.config.DumpRouterMap = enabled
 }
 
+// SetErrorResponseFormat sets the ErrorResponseFormat for server,
+// like ErrorResponseFormatProblem for RFC 7807 problem details.
+func (s *Server) SetErrorResponseFormat(format string) {
+	s.config.ErrorResponseFormat = format
+}
+
+// SetJWTConfig sets the token verification configuration of MiddlewareJWT if no configuration given.
+// It can be called in runtime, and the changed configuration takes effect for the following requests.
+func (s *Server) SetJWTConfig(config JWTConfig) {
+	s.jwtMu.Lock()
+	defer s.jwtMu.Unlock()
+	s.config.JWT = config
+	s.jwtVerifier = nil
+}
+
 // SetClientMaxBodySize sets the ClientMaxBodySize for server.
 func (s *Server) SetClientMaxBodySize(maxSize int64) {
 	s.config.ClientMaxBodySize = maxSize
@@ -27,6 +44,21 @@ func (s *Server) SetFormParsingMemory(maxMemory int64) {
 	s.config.FormParsingMemory = maxMemory
 }
 
+// SetMaxConcurrentRequests sets the MaxConcurrentRequests for server.
+func (s *Server) SetMaxConcurrentRequests(maxRequests int) {
+	s.config.MaxConcurrentRequests = maxRequests
+}
+
+// SetMaxQueuedRequests sets the MaxQueuedRequests for server.
+func (s *Server) SetMaxQueuedRequests(maxRequests int) {
+	s.config.MaxQueuedRequests = maxRequests
+}
+
+// SetRequestQueueTimeout sets the RequestQueueTimeout for server.
+func (s *Server) SetRequestQueueTimeout(timeout time.Duration) {
+	s.config.RequestQueueTimeout = timeout
+}
+
 // SetGraceful sets the Graceful for server.
 func (s *Server) SetGraceful(graceful bool) {
 	s.config.Graceful = graceful
@@ -20,8 +20,35 @@ import (
 
 // staticPathItem is the item struct for static path configuration.
 type staticPathItem struct {
-	Prefix string // The router URI.
-	Path   string // The static path.
+	Prefix string            // The router URI.
+	Path   string            // The static path.
+	Config *StaticPathConfig // The serving configuration of the static path, nil for defaults.
+}
+
+// StaticPathConfig is the serving configuration for static files of a static path.
+type StaticPathConfig struct {
+	// Precompressed enables serving the pre-compressed sibling files like "app.js.br" and "app.js.gz"
+	// for "app.js" if the client accepts the encoding, in which "br" has higher priority than "gzip".
+	Precompressed bool `json:"precompressed"`
+
+	// CacheRules specifies the "Cache-Control" header of files by pattern, in which the first matched rule is used.
+	CacheRules []StaticCacheRule `json:"cacheRules"`
+
+	// IndexTemplate specifies the template file for directory listing, which is parsed by the view of server
+	// with variables "Path", "Parent" and "Files". The item of "Files" has attributes "Name", "Url", "Size",
+	// "ModTime" and "IsDir". The builtin HTML is used if it is empty.
+	IndexTemplate string `json:"indexTemplate"`
+}
+
+// StaticCacheRule is the rule specifying the "Cache-Control" header of static files by pattern.
+type StaticCacheRule struct {
+	// Pattern is the shell pattern of file names like "*.js", or of file paths relative to the static path
+	// like "/assets/*" if it contains char '/'.
+	Pattern string `json:"pattern"`
+
+	// CacheControl is the "Cache-Control" header of the matched files, like "public, max-age=31536000, immutable"
+	// for the fingerprinted assets, or "no-cache" for the html entries.
+	CacheControl string `json:"cacheControl"`
 }
 
 // SetIndexFiles sets the index files for server.
@@ -82,8 +109,14 @@ func (s *Server) AddSearchPath(path string) {
 	s.config.FileServerEnabled = true
 }
 
+// SetStaticConfig sets the serving configuration for static files of ServerRoot and SearchPaths.
+func (s *Server) SetStaticConfig(config StaticPathConfig) {
+	s.config.StaticConfig = config
+}
+
 // AddStaticPath sets the uri to static directory path mapping for static file service.
-func (s *Server) AddStaticPath(prefix string, path string) {
+// The optional parameter `config` specifies the serving configuration for static files of the path.
+func (s *Server) AddStaticPath(prefix string, path string, config ...StaticPathConfig) {
 	var (
 		ctx      = context.TODO()
 		realPath = path
@@ -99,6 +132,9 @@ func (s *Server) AddStaticPath(prefix string, path string) {
 		Prefix: prefix,
 		Path:   realPath,
 	}
+	if len(config) > 0 {
+		addItem.Config = &config[0]
+	}
 	if len(s.config.StaticPaths) > 0 {
 		s.config.StaticPaths = append(s.config.StaticPaths, addItem)
 		// Sort the array by length of prefix from short to long.
@@ -0,0 +1,46 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+// SetPropagator sets the propagator for extracting tracing context from request headers.
+// It uses the global propagator if `propagator` is nil.
+func (s *Server) SetPropagator(propagator propagation.TextMapPropagator) {
+	s.propagator = propagator
+}
+
+// SetTracingPropagators sets the propagators by names for extracting tracing context from request headers,
+// the available names are: tracecontext, baggage, b3, b3multi and none.
+// It uses the global propagator if `names` is empty.
+func (s *Server) SetTracingPropagators(names ...string) error {
+	if len(names) == 0 {
+		s.config.TracingPropagators = nil
+		s.propagator = nil
+		return nil
+	}
+	propagator, err := gtrace.NewTextMapPropagator(names...)
+	if err != nil {
+		return err
+	}
+	s.config.TracingPropagators = names
+	s.propagator = propagator
+	return nil
+}
+
+// getPropagator returns the propagator of the server, or the global propagator if it is not set.
+func (s *Server) getPropagator() propagation.TextMapPropagator {
+	if s.propagator != nil {
+		return s.propagator
+	}
+	return otel.GetTextMapPropagator()
+}
@@ -0,0 +1,190 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gproc"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// DrainHook is the hook function called when the server starts draining for shutdown,
+// which is commonly used for deregistering the service from service discovery.
+type DrainHook func(ctx context.Context)
+
+// DrainResult is the result of draining the server for shutdown.
+type DrainResult struct {
+	Drained     int64 // Number of requests completed during draining.
+	Aborted     int64 // Number of in-flight requests aborted as the deadline exceeded.
+	Connections int64 // Number of open connections closed forcibly as the deadline exceeded.
+}
+
+// drainTracker tracks the in-flight requests and open connections of server for draining.
+type drainTracker struct {
+	mu          sync.RWMutex  // Concurrent safety for hooks and draining.
+	hooks       []DrainHook   // Registered drain hooks.
+	inFlight    *gtype.Int64  // Number of in-flight requests.
+	completed   *gtype.Int64  // Number of completed requests.
+	connections *gtype.Int64  // Number of open connections.
+	draining    chan struct{} // Closed when the server starts draining, which wakes the parked requests.
+	drained     bool          // Whether draining is closed, which is recreated when the server starts again.
+}
+
+// newDrainTracker creates and returns a new drainTracker.
+func newDrainTracker() *drainTracker {
+	return &drainTracker{
+		inFlight:    gtype.NewInt64(),
+		completed:   gtype.NewInt64(),
+		connections: gtype.NewInt64(),
+		draining:    make(chan struct{}),
+	}
+}
+
+// startDraining marks the server starts draining, which wakes the parked requests like long-polling.
+func (t *drainTracker) startDraining() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.drained {
+		close(t.draining)
+		t.drained = true
+	}
+}
+
+// reset recreates the draining channel if it is closed, which is called when the server starts,
+// so that the parked requests are not woken immediately after the server restarts.
+func (t *drainTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.drained {
+		t.draining = make(chan struct{})
+		t.drained = false
+	}
+}
+
+// drainingChan returns the channel closed when the server starts draining.
+func (t *drainTracker) drainingChan() <-chan struct{} {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.draining
+}
+
+// requestStarted marks a request starts serving, and returns the function marking it done.
+func (t *drainTracker) requestStarted() func() {
+	t.inFlight.Add(1)
+	return func() {
+		t.inFlight.Add(-1)
+		t.completed.Add(1)
+	}
+}
+
+// connStateChanged tracks the open connections, which is used as http.Server.ConnState.
+func (t *drainTracker) connStateChanged(_ net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		t.connections.Add(1)
+	case http.StateHijacked, http.StateClosed:
+		t.connections.Add(-1)
+	}
+}
+
+// AddDrainHook adds `hook` called when the server starts draining for shutdown, before it stops accepting
+// new connections. The hooks are called in the order they are added.
+func (s *Server) AddDrainHook(hook DrainHook) {
+	s.drain.mu.Lock()
+	defer s.drain.mu.Unlock()
+	s.drain.hooks = append(s.drain.hooks, hook)
+}
+
+// Drain shuts down current server gracefully and returns the draining result.
+//
+// It deregisters the service and calls the drain hooks, then stops accepting new connections
+// and waits for the in-flight requests to complete. The connections are closed forcibly
+// if the requests are not completed in GracefulShutdownTimeout or before `ctx` is done,
+// and an error is returned in this situation along with the result.
+func (s *Server) Drain(ctx context.Context) (*DrainResult, error) {
+	s.doServiceDeregister()
+	s.callDrainHooks(ctx)
+	s.drain.startDraining()
+
+	var (
+		wg                     sync.WaitGroup
+		result                 = &DrainResult{}
+		completed              = s.drain.completed.Val()
+		timeoutCtx, cancelFunc = context.WithTimeout(
+			ctx,
+			time.Duration(s.config.GracefulShutdownTimeout)*time.Second,
+		)
+	)
+	defer cancelFunc()
+	// Only shut down current servers.
+	// It may have multiple underlying http servers.
+	for _, v := range s.servers {
+		wg.Add(1)
+		go func(server *gracefulServer) {
+			defer wg.Done()
+			server.shutdown(timeoutCtx)
+		}(v)
+	}
+	for _, v := range s.http3Servers {
+		wg.Add(1)
+		go func(server *http3Server) {
+			defer wg.Done()
+			server.shutdown(timeoutCtx)
+		}(v)
+	}
+	wg.Wait()
+	s.certificates.stopWatching()
+
+	result.Drained = s.drain.completed.Val() - completed
+	if timeoutCtx.Err() != nil {
+		// The deadline exceeded, it closes the remaining connections forcibly.
+		result.Aborted = s.drain.inFlight.Val()
+		result.Connections = s.drain.connections.Val()
+		for _, v := range s.servers {
+			_ = v.httpServer.Close()
+		}
+		for _, v := range s.http3Servers {
+			_ = v.httpServer.Close()
+		}
+	}
+	s.Logger().Infof(
+		ctx,
+		`pid[%d]: server drained, %d requests drained, %d requests aborted, %d connections closed forcibly`,
+		gproc.Pid(), result.Drained, result.Aborted, result.Connections,
+	)
+	if result.Aborted > 0 || result.Connections > 0 {
+		return result, gerror.WrapCodef(
+			gcode.CodeOperationFailed, timeoutCtx.Err(),
+			`server draining aborted %d in-flight requests`, result.Aborted,
+		)
+	}
+	return result, nil
+}
+
+// callDrainHooks calls the registered drain hooks, in which the panics are caught and logged.
+func (s *Server) callDrainHooks(ctx context.Context) {
+	s.drain.mu.RLock()
+	hooks := make([]DrainHook, len(s.drain.hooks))
+	copy(hooks, s.drain.hooks)
+	s.drain.mu.RUnlock()
+
+	for _, hook := range hooks {
+		gutil.TryCatch(ctx, func(ctx context.Context) {
+			hook(ctx)
+		}, func(ctx context.Context, exception error) {
+			s.Logger().Errorf(ctx, `call drain hook failed: %+v`, exception)
+		})
+	}
+}
@@ -58,12 +58,15 @@ func (s *Server) newGracefulServer(address string, fd ...int) *gracefulServer {
 	if s.config.Listeners != nil {
 		addrArray := gstr.SplitAndTrim(address, ":")
 		addrPort, err := strconv.Atoi(addrArray[len(addrArray)-1])
-		if err == nil {
-			for _, v := range s.config.Listeners {
-				if listenerPort := (v.Addr().(*net.TCPAddr)).Port; listenerPort == addrPort {
+		for _, v := range s.config.Listeners {
+			if tcpAddr, ok := v.Addr().(*net.TCPAddr); ok {
+				if err == nil && tcpAddr.Port == addrPort {
 					gs.rawListener = v
 					break
 				}
+			} else if listenerAddress(v) == address {
+				gs.rawListener = v
+				break
 			}
 		}
 	}
@@ -74,12 +77,13 @@ func (s *Server) newGracefulServer(address string, fd ...int) *gracefulServer {
 func (s *Server) newHttpServer(address string) *http.Server {
 	server := &http.Server{
 		Addr:           address,
-		Handler:        http.HandlerFunc(s.config.Handler),
+		Handler:        s.newGrpcMultiplexHandler(http.HandlerFunc(s.config.Handler)),
 		ReadTimeout:    s.config.ReadTimeout,
 		WriteTimeout:   s.config.WriteTimeout,
 		IdleTimeout:    s.config.IdleTimeout,
 		MaxHeaderBytes: s.config.MaxHeaderBytes,
 		ErrorLog:       log.New(&errorLogger{logger: s.config.Logger}, "", 0),
+		ConnState:      s.drain.connStateChanged,
 	}
 	server.SetKeepAlivesEnabled(s.config.KeepAlive)
 	return server
@@ -88,8 +92,8 @@ func (s *Server) newHttpServer(address string) *http.Server {
 // Fd retrieves and returns the file descriptor of the current server.
 // It is available ony in *nix like operating systems like linux, unix, darwin.
 func (s *gracefulServer) Fd() uintptr {
-	if ln := s.getRawListener(); ln != nil {
-		file, err := ln.(*net.TCPListener).File()
+	if ln, ok := s.getRawListener().(interface{ File() (*os.File, error) }); ok {
+		file, err := ln.File()
 		if err == nil {
 			return file.Fd()
 		}
@@ -122,21 +126,19 @@ func (s *gracefulServer) CreateListenerTLS(certFile, keyFile string, tlsConfig .
 	}
 	if config.NextProtos == nil {
 		config.NextProtos = []string{"http/1.1"}
-	}
-	var err error
-	if len(config.Certificates) == 0 {
-		config.Certificates = make([]tls.Certificate, 1)
-		if gres.Contains(certFile) {
-			config.Certificates[0], err = tls.X509KeyPair(
-				gres.GetContent(certFile),
-				gres.GetContent(keyFile),
-			)
-		} else {
-			config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
+		if s.server.config.GrpcHandler != nil {
+			// gRPC requires HTTP/2.
+			config.NextProtos = []string{"h2", "http/1.1"}
 		}
 	}
-	if err != nil {
-		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	if len(config.Certificates) == 0 && config.GetCertificate == nil {
+		getCertificate, err := s.server.getCertificateFunc(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		// It clones the configuration as it might be shared by multiple listeners.
+		config = config.Clone()
+		config.GetCertificate = getCertificate
 	}
 	ln, err := s.getNetListener()
 	if err != nil {
@@ -148,6 +150,22 @@ func (s *gracefulServer) CreateListenerTLS(certFile, keyFile string, tlsConfig .
 	return nil
 }
 
+// loadCertificate loads the certificate from `certFile` and `keyFile`, which can be files or resources.
+func loadCertificate(certFile, keyFile string) (certificate tls.Certificate, err error) {
+	if gres.Contains(certFile) {
+		certificate, err = tls.X509KeyPair(
+			gres.GetContent(certFile),
+			gres.GetContent(keyFile),
+		)
+	} else {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	if err != nil {
+		err = gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	}
+	return
+}
+
 // Serve starts the serving with blocking way.
 func (s *gracefulServer) Serve(ctx context.Context) error {
 	if s.rawListener == nil {
@@ -186,7 +204,9 @@ func (s *gracefulServer) GetListenedAddress() string {
 // Note that this method is only available if the server is listening on one port.
 func (s *gracefulServer) GetListenedPort() int {
 	if ln := s.getRawListener(); ln != nil {
-		return ln.Addr().(*net.TCPAddr).Port
+		if addr, ok := ln.Addr().(*net.TCPAddr); ok {
+			return addr.Port
+		}
 	}
 	return -1
 }
@@ -217,10 +237,7 @@ func (s *gracefulServer) getNetListener() (net.Listener, error) {
 			return nil, err
 		}
 	} else {
-		ln, err = net.Listen("tcp", s.httpServer.Addr)
-		if err != nil {
-			err = gerror.Wrapf(err, `net.Listen address "%s" failed`, s.httpServer.Addr)
-		}
+		ln, err = s.server.listen(s.httpServer.Addr)
 	}
 	return ln, err
 }
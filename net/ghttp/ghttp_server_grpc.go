@@ -0,0 +1,44 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	// contentTypeGrpc is the content type prefix of gRPC requests.
+	contentTypeGrpc = "application/grpc"
+)
+
+// newGrpcMultiplexHandler returns the handler serving gRPC requests using GrpcHandler and the others using `handler`,
+// which also enables HTTP/2 without TLS (h2c) for gRPC requests. It returns `handler` directly if GrpcHandler not set.
+func (s *Server) newGrpcMultiplexHandler(handler http.Handler) http.Handler {
+	grpcHandler := s.config.GrpcHandler
+	if grpcHandler == nil {
+		return handler
+	}
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGrpcRequest(r) {
+			grpcHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}), &http2.Server{
+		IdleTimeout: s.config.IdleTimeout,
+	})
+}
+
+// isGrpcRequest checks and returns whether `r` is a gRPC request,
+// which is HTTP/2 request with content type "application/grpc" or like "application/grpc+proto".
+func isGrpcRequest(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), contentTypeGrpc)
+}
@@ -29,16 +29,24 @@ import (
 //
 // This function also makes serve implementing the interface of http.Handler.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// In-flight requests tracking for draining.
+	defer s.drain.requestStarted()()
 	// Max body size limit.
 	if s.config.ClientMaxBodySize > 0 {
 		r.Body = http.MaxBytesReader(w, r.Body, s.config.ClientMaxBodySize)
 	}
+	// Live timeouts applied without restart.
+	s.live.applyDeadlines(w)
+	// HTTP/3 advertisement.
+	s.setAltSvcHeader(w, r)
 	// Rewrite feature checks.
 	if len(s.config.Rewrites) > 0 {
 		if rewrite, ok := s.config.Rewrites[r.URL.Path]; ok {
 			r.URL.Path = rewrite
 		}
 	}
+	// API version negotiation by header or media type.
+	unversionedPath := s.negotiateApiVersion(r)
 
 	var (
 		request   = newRequest(s, r, w)    // Create a new request object.
@@ -66,6 +74,15 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		request.hasHookHandler,
 		request.hasServeHandler = s.getHandlersWithCache(request)
 
+	// The unversioned route serves the request if the negotiated version has no such route.
+	if unversionedPath != "" && !request.hasServeHandler {
+		r.URL.Path = unversionedPath
+		request.handlers,
+			request.serveHandler,
+			request.hasHookHandler,
+			request.hasServeHandler = s.getHandlersWithCache(request)
+	}
+
 	// Check the service type static or dynamic for current request.
 	if request.StaticFile != nil && request.StaticFile.IsDir && request.hasServeHandler {
 		request.isFileRequest = false
@@ -74,8 +91,15 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Metrics.
 	s.handleMetricsBeforeRequest(request)
 
+	// Admission control, which rejects the request if the server is saturated.
+	if release := s.handleAdmission(request); release != nil {
+		defer release()
+	}
+
 	// HOOK - BeforeServe
-	s.callHookHandler(HookBeforeServe, request)
+	if !request.IsExited() {
+		s.callHookHandler(HookBeforeServe, request)
+	}
 
 	// Core serving handling.
 	if !request.IsExited() {
@@ -129,7 +153,11 @@ func (s *Server) handleResponse(request *Request, sessionId string) {
 			if request.Response.BufferLength() == 0 {
 				request.Response.Write(err.Error())
 			}
-			request.Response.WriteHeader(http.StatusInternalServerError)
+			if status, ok := gcode.HttpStatus(gerror.Code(err)); ok {
+				request.Response.WriteHeader(status)
+			} else {
+				request.Response.WriteHeader(http.StatusInternalServerError)
+			}
 		} else {
 			request.Response.WriteHeader(http.StatusNotFound)
 		}
@@ -171,6 +199,10 @@ func (s *Server) handleResponse(request *Request, sessionId string) {
 
 func (s *Server) handleAfterRequestDone(request *Request) {
 	request.LeaveTime = gtime.Now()
+	// The writer of Server-Sent Events cannot be used after the request is done.
+	if request.Response.sseWriter != nil {
+		request.Response.sseWriter.Close()
+	}
 	// error log handling.
 	if request.error != nil {
 		s.handleErrorLog(request.error, request)
@@ -235,18 +267,23 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 				if len(uri) > len(item.Prefix) && uri[len(item.Prefix)] != '/' {
 					continue
 				}
-				file = gres.GetWithIndex(item.Path+uri[len(item.Prefix):], s.config.IndexFiles)
+				relativeUri := uri[len(item.Prefix):]
+				file = gres.GetWithIndex(item.Path+relativeUri, s.config.IndexFiles)
 				if file != nil {
 					return &staticFile{
-						File:  file,
-						IsDir: file.FileInfo().IsDir(),
+						File:   file,
+						IsDir:  file.FileInfo().IsDir(),
+						Uri:    relativeUri,
+						Config: item.Config,
 					}
 				}
-				path, dir = gspath.Search(item.Path, uri[len(item.Prefix):], s.config.IndexFiles...)
+				path, dir = gspath.Search(item.Path, relativeUri, s.config.IndexFiles...)
 				if path != "" {
 					return &staticFile{
-						Path:  path,
-						IsDir: dir,
+						Path:   path,
+						IsDir:  dir,
+						Uri:    relativeUri,
+						Config: item.Config,
 					}
 				}
 			}
@@ -258,14 +295,18 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 			file = gres.GetWithIndex(p+uri, s.config.IndexFiles)
 			if file != nil {
 				return &staticFile{
-					File:  file,
-					IsDir: file.FileInfo().IsDir(),
+					File:   file,
+					IsDir:  file.FileInfo().IsDir(),
+					Uri:    uri,
+					Config: &s.config.StaticConfig,
 				}
 			}
 			if path, dir = gspath.Search(p, uri, s.config.IndexFiles...); path != "" {
 				return &staticFile{
-					Path:  path,
-					IsDir: dir,
+					Path:   path,
+					IsDir:  dir,
+					Uri:    uri,
+					Config: &s.config.StaticConfig,
 				}
 			}
 		}
@@ -274,8 +315,10 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 	if len(s.config.StaticPaths) == 0 && len(s.config.SearchPaths) == 0 {
 		if file = gres.GetWithIndex(uri, s.config.IndexFiles); file != nil {
 			return &staticFile{
-				File:  file,
-				IsDir: file.FileInfo().IsDir(),
+				File:   file,
+				IsDir:  file.FileInfo().IsDir(),
+				Uri:    uri,
+				Config: &s.config.StaticConfig,
 			}
 		}
 	}
@@ -285,17 +328,21 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 // serveFile serves the static file for the client.
 // The optional parameter `allowIndex` specifies if allowing directory listing if `f` is a directory.
 func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
+	var config = f.Config
+	if config == nil {
+		config = &StaticPathConfig{}
+	}
 	// Use resource file from memory.
 	if f.File != nil {
 		if f.IsDir {
 			if s.config.IndexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
-				s.listDir(r, f.File)
+				s.listDir(r, f.File, config)
 			} else {
 				r.Response.WriteStatus(http.StatusForbidden)
 			}
 		} else {
 			info := f.File.FileInfo()
-			r.Response.ServeContent(info.Name(), info.ModTime(), f.File)
+			s.serveStaticContent(r, f, config, info.Name(), info.ModTime(), f.File)
 		}
 		return
 	}
@@ -316,17 +363,17 @@ func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
 	info, _ := file.Stat()
 	if info.IsDir() {
 		if s.config.IndexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
-			s.listDir(r, file)
+			s.listDir(r, file, config)
 		} else {
 			r.Response.WriteStatus(http.StatusForbidden)
 		}
 	} else {
-		r.Response.ServeContent(info.Name(), info.ModTime(), file)
+		s.serveStaticContent(r, f, config, info.Name(), info.ModTime(), file)
 	}
 }
 
 // listDir lists the sub files of specified directory as HTML content to the client.
-func (s *Server) listDir(r *Request, f http.File) {
+func (s *Server) listDir(r *Request, f http.File, config *StaticPathConfig) {
 	files, err := f.Readdir(-1)
 	if err != nil {
 		r.Response.WriteStatus(http.StatusInternalServerError, "Error reading directory")
@@ -342,6 +389,10 @@ func (s *Server) listDir(r *Request, f http.File) {
 		}
 		return files[i].Name() < files[j].Name()
 	})
+	if config.IndexTemplate != "" {
+		s.listDirWithTemplate(r, files, config.IndexTemplate)
+		return
+	}
 	if r.Response.Header().Get("Content-Type") == "" {
 		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
 	}
@@ -0,0 +1,225 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gproc"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// HTTP3Server is the HTTP/3 server over QUIC, like the http3.Server of quic-go.
+type HTTP3Server interface {
+	// Serve serves HTTP/3 requests on `conn` in blocking way until the server is closed.
+	Serve(conn net.PacketConn) error
+
+	// Shutdown shuts down the server gracefully, which closes the connections forcibly if `ctx` is done.
+	Shutdown(ctx context.Context) error
+
+	// Close closes the server immediately.
+	Close() error
+}
+
+// HTTP3ServerProvider creates and returns an HTTP/3 server serving `handler` with `tlsConfig`.
+type HTTP3ServerProvider func(handler http.Handler, tlsConfig *tls.Config) HTTP3Server
+
+// http3Server is an HTTP/3 server listening on an UDP address.
+type http3Server struct {
+	server     *Server        // Belonged server.
+	address    string         // Listening address like ":443".
+	conn       net.PacketConn // Underlying UDP connection.
+	httpServer HTTP3Server    // Underlying HTTP/3 server.
+	status     *gtype.Int     // Status of current server.
+}
+
+const (
+	// http3AltSvcMaxAge is the max age in seconds of the HTTP/3 service advertised in header "Alt-Svc".
+	http3AltSvcMaxAge = 86400
+)
+
+var (
+	// http3ServerProvider is the registered provider of HTTP/3 server.
+	http3ServerProvider HTTP3ServerProvider
+
+	// http3ServerMu is the mutex for http3ServerProvider.
+	http3ServerMu sync.RWMutex
+)
+
+// RegisterHTTP3Server registers the provider of HTTP/3 server, which is used if HTTP/3 is enabled.
+// It is commonly called by the package implementing QUIC in its initialization.
+func RegisterHTTP3Server(provider HTTP3ServerProvider) {
+	http3ServerMu.Lock()
+	defer http3ServerMu.Unlock()
+	http3ServerProvider = provider
+}
+
+// getHTTP3ServerProvider returns the registered provider of HTTP/3 server.
+func getHTTP3ServerProvider() HTTP3ServerProvider {
+	http3ServerMu.RLock()
+	defer http3ServerMu.RUnlock()
+	return http3ServerProvider
+}
+
+// startHTTP3Servers starts the HTTP/3 servers if it is enabled, which should be called after
+// the HTTPS servers are listening, as it uses the same addresses of them in default.
+//
+// Note that the graceful reload is not supported for HTTP/3 servers,
+// the clients fall back to HTTPS during the reloading.
+func (s *Server) startHTTP3Servers(ctx context.Context) error {
+	if !s.config.HTTP3Enabled {
+		return nil
+	}
+	provider := getHTTP3ServerProvider()
+	if provider == nil {
+		return gerror.NewCode(
+			gcode.CodeMissingConfiguration,
+			`HTTP/3 server is not registered, did you forget import package "github.com/gogf/gf/contrib/net/gquic/v2"?`,
+		)
+	}
+	tlsConfig, err := s.getHTTP3TLSConfig()
+	if err != nil {
+		return err
+	}
+	var addresses []string
+	if s.config.HTTP3Addr != "" {
+		addresses = gstr.SplitAndTrim(s.config.HTTP3Addr, ",")
+	} else {
+		for _, gs := range s.servers {
+			if gs.isHttps {
+				addresses = append(addresses, gs.GetListenedAddress())
+			}
+		}
+	}
+	for _, address := range addresses {
+		if gstr.IsNumeric(address) {
+			address = ":" + address
+		}
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return gerror.Wrapf(err, `net.ListenPacket address "%s" failed`, address)
+		}
+		hs := &http3Server{
+			server:     s,
+			address:    address,
+			conn:       conn,
+			httpServer: provider(http.HandlerFunc(s.config.Handler), tlsConfig),
+			status:     gtype.NewInt(),
+		}
+		s.http3Servers = append(s.http3Servers, hs)
+		go hs.Serve(ctx)
+	}
+	if len(s.http3Servers) > 0 {
+		s.http3AltSvc.Set(fmt.Sprintf(
+			`h3=":%d"; ma=%d`, s.http3Servers[0].GetListenedPort(), http3AltSvcMaxAge,
+		))
+	}
+	return nil
+}
+
+// getHTTP3TLSConfig returns the TLS configuration for HTTP/3, which is the same as HTTPS.
+func (s *Server) getHTTP3TLSConfig() (*tls.Config, error) {
+	var tlsConfig *tls.Config
+	if s.config.TLSConfig != nil {
+		tlsConfig = s.config.TLSConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+	if len(tlsConfig.Certificates) == 0 && tlsConfig.GetCertificate == nil {
+		if s.config.CertificateProvider == nil &&
+			(s.config.HTTPSCertPath == "" || s.config.HTTPSKeyPath == "") {
+			return nil, gerror.NewCode(gcode.CodeMissingConfiguration, `HTTP/3 requires HTTPS enabled`)
+		}
+		getCertificate, err := s.getCertificateFunc(s.config.HTTPSCertPath, s.config.HTTPSKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetCertificate = getCertificate
+	}
+	// The ALPN protocol of HTTP/3 is set by the HTTP/3 server.
+	tlsConfig.NextProtos = nil
+	return tlsConfig, nil
+}
+
+// setAltSvcHeader advertises the HTTP/3 service in header "Alt-Svc" for HTTPS requests.
+func (s *Server) setAltSvcHeader(w http.ResponseWriter, r *http.Request) {
+	if r.TLS == nil || r.ProtoMajor >= 3 {
+		return
+	}
+	if altSvc := s.http3AltSvc.Val(); altSvc != "" {
+		w.Header().Set("Alt-Svc", altSvc)
+	}
+}
+
+// Serve starts the serving with blocking way.
+func (s *http3Server) Serve(ctx context.Context) {
+	s.server.Logger().Infof(
+		ctx,
+		`pid[%d]: http3 server started listening on [%s]`,
+		gproc.Pid(), s.GetListenedAddress(),
+	)
+	s.status.Set(ServerStatusRunning)
+	err := s.httpServer.Serve(s.conn)
+	s.status.Set(ServerStatusStopped)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.server.Logger().Errorf(ctx, `%+v`, gerror.Wrap(err, `http3 server serving failed`))
+	}
+	_ = s.conn.Close()
+}
+
+// GetListenedAddress retrieves and returns the address string which is listened by current server.
+func (s *http3Server) GetListenedAddress() string {
+	if !gstr.Contains(s.address, FreePortAddress) {
+		return s.address
+	}
+	return gstr.Replace(s.address, FreePortAddress, fmt.Sprintf(`:%d`, s.GetListenedPort()))
+}
+
+// GetListenedPort retrieves and returns the port which is listened by current server.
+func (s *http3Server) GetListenedPort() int {
+	if addr, ok := s.conn.LocalAddr().(*net.UDPAddr); ok {
+		return addr.Port
+	}
+	return -1
+}
+
+// shutdown shuts down the server gracefully.
+func (s *http3Server) shutdown(ctx context.Context) {
+	timeoutCtx, cancelFunc := context.WithTimeout(
+		ctx,
+		time.Duration(s.server.config.GracefulShutdownTimeout)*time.Second,
+	)
+	defer cancelFunc()
+	if err := s.httpServer.Shutdown(timeoutCtx); err != nil {
+		s.server.Logger().Errorf(
+			ctx,
+			"%d: http3 server [%s] shutdown error: %v",
+			gproc.Pid(), s.address, err,
+		)
+	}
+}
+
+// close shuts down the server forcibly.
+func (s *http3Server) close(ctx context.Context) {
+	if err := s.httpServer.Close(); err != nil {
+		s.server.Logger().Errorf(
+			ctx,
+			"%d: http3 server [%s] closed error: %v",
+			gproc.Pid(), s.address, err,
+		)
+	}
+}
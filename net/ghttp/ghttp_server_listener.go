@@ -0,0 +1,238 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// listenerSchemeUnix is the address scheme of unix domain socket, like "unix:/run/app.sock".
+	listenerSchemeUnix = "unix:"
+
+	// listenerSchemeSystemd is the address scheme of the socket passed by systemd socket activation,
+	// like "systemd:" for the first socket, "systemd:1" for the socket by index,
+	// or "systemd:http" for the socket by FileDescriptorName of the socket unit.
+	listenerSchemeSystemd = "systemd:"
+
+	// listenerSchemeFd is the address scheme of the pre-opened file descriptor like "fd:3",
+	// which is commonly passed by launchd or other process supervisors.
+	listenerSchemeFd = "fd:"
+
+	// systemdListenFdsStart is the first file descriptor passed by systemd socket activation.
+	systemdListenFdsStart = 3
+
+	// unixSocketDialTimeout is the timeout dialing the existing unix domain socket to check whether it is alive.
+	unixSocketDialTimeout = time.Second
+)
+
+// isTCPAddress checks whether `address` is TCP address like ":80",
+// but not the address with scheme of unix domain socket, systemd socket activation or file descriptor.
+func isTCPAddress(address string) bool {
+	for _, scheme := range []string{listenerSchemeUnix, listenerSchemeSystemd, listenerSchemeFd} {
+		if strings.HasPrefix(address, scheme) {
+			return false
+		}
+	}
+	return true
+}
+
+// listen creates and returns the listener for `address`, which can be the TCP address like ":80",
+// or the address with scheme of unix domain socket, systemd socket activation or pre-opened file descriptor.
+func (s *Server) listen(address string) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(address, listenerSchemeUnix):
+		return s.listenUnix(address[len(listenerSchemeUnix):])
+
+	case strings.HasPrefix(address, listenerSchemeSystemd):
+		return listenSystemd(address[len(listenerSchemeSystemd):])
+
+	case strings.HasPrefix(address, listenerSchemeFd):
+		fd, err := strconv.Atoi(address[len(listenerSchemeFd):])
+		if err != nil || fd < 0 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid file descriptor address "%s"`, address)
+		}
+		return listenFd(fd, address)
+
+	default:
+		ln, err := net.Listen("tcp", address)
+		if err != nil {
+			err = gerror.Wrapf(err, `net.Listen address "%s" failed`, address)
+		}
+		return ln, err
+	}
+}
+
+// listenUnix creates and returns the listener of unix domain socket on `path`, which removes the stale
+// socket file and changes the mode and owner of the socket file as configured.
+func (s *Server) listenUnix(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `empty path for unix domain socket`)
+	}
+	// The socket file is left if the server exits abnormally, which fails the listening.
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err = removeStaleUnixSocket(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `net.Listen unix domain socket "%s" failed`, path)
+	}
+	// The socket file should not be removed when the listener is closed,
+	// as the listener might be passed to child process for graceful restart.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	if s.config.UnixSocketMode != "" {
+		mode, err := strconv.ParseUint(s.config.UnixSocketMode, 8, 32)
+		if err != nil {
+			_ = ln.Close()
+			return nil, gerror.WrapCodef(
+				gcode.CodeInvalidConfiguration, err, `invalid UnixSocketMode "%s"`, s.config.UnixSocketMode,
+			)
+		}
+		if err = os.Chmod(path, os.FileMode(mode)); err != nil {
+			_ = ln.Close()
+			return nil, gerror.Wrapf(err, `chmod unix domain socket "%s" failed`, path)
+		}
+	}
+	if s.config.UnixSocketOwner != "" {
+		uid, gid, err := lookupUnixSocketOwner(s.config.UnixSocketOwner)
+		if err == nil {
+			err = os.Chown(path, uid, gid)
+		}
+		if err != nil {
+			_ = ln.Close()
+			return nil, gerror.Wrapf(err, `chown unix domain socket "%s" to "%s" failed`, path, s.config.UnixSocketOwner)
+		}
+	}
+	return ln, nil
+}
+
+// removeStaleUnixSocket removes the socket file on `path` if it is stale, which is checked by dialing it.
+// It does not remove the socket that is still served by other process, and returns error in this situation.
+func removeStaleUnixSocket(path string) error {
+	conn, err := net.DialTimeout("unix", path, unixSocketDialTimeout)
+	if err == nil {
+		_ = conn.Close()
+		return gerror.NewCodef(
+			gcode.CodeInvalidOperation, `unix domain socket "%s" is already in use by other process`, path,
+		)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		// It is not sure whether the socket is stale, and it leaves the listening to report the error.
+		return nil
+	}
+	if err = os.Remove(path); err != nil {
+		return gerror.Wrapf(err, `remove stale unix domain socket "%s" failed`, path)
+	}
+	return nil
+}
+
+// lookupUnixSocketOwner parses `owner` like "user:group", "user" or "1000:1000", and returns its uid and gid.
+// The gid is -1 if the group is absent, which keeps the group unchanged.
+func lookupUnixSocketOwner(owner string) (uid, gid int, err error) {
+	var (
+		array     = strings.SplitN(owner, ":", 2)
+		userName  = array[0]
+		groupName string
+	)
+	if len(array) > 1 {
+		groupName = array[1]
+	}
+	uid, gid = -1, -1
+	if userName != "" {
+		if uid, err = strconv.Atoi(userName); err != nil {
+			u, err := user.Lookup(userName)
+			if err != nil {
+				return 0, 0, err
+			}
+			uid, _ = strconv.Atoi(u.Uid)
+		}
+	}
+	if groupName != "" {
+		if gid, err = strconv.Atoi(groupName); err != nil {
+			group, err := user.LookupGroup(groupName)
+			if err != nil {
+				return 0, 0, err
+			}
+			gid, _ = strconv.Atoi(group.Gid)
+		}
+	}
+	return uid, gid, nil
+}
+
+// listenSystemd creates and returns the listener of the socket passed by systemd socket activation,
+// which is specified by `name` as index or FileDescriptorName, or the first one if `name` is empty.
+func listenSystemd(name string) (net.Listener, error) {
+	if pid := os.Getenv("LISTEN_PID"); pid != strconv.Itoa(os.Getpid()) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`no socket passed by systemd socket activation for current process, LISTEN_PID is "%s"`,
+			pid,
+		)
+	}
+	count, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	index := -1
+	if name == "" {
+		index = 0
+	} else if v, err := strconv.Atoi(name); err == nil {
+		index = v
+	} else {
+		for i, v := range strings.Split(os.Getenv("LISTEN_FDNAMES"), ":") {
+			if v == name {
+				index = i
+				break
+			}
+		}
+	}
+	if index < 0 || index >= count {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`socket "%s" not found in %d sockets passed by systemd socket activation`,
+			name, count,
+		)
+	}
+	return listenFd(systemdListenFdsStart+index, listenerSchemeSystemd+name)
+}
+
+// listenFd creates and returns the listener of pre-opened file descriptor `fd`.
+func listenFd(fd int, name string) (net.Listener, error) {
+	file := os.NewFile(uintptr(fd), name)
+	if file == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid file descriptor %d`, fd)
+	}
+	// The listener holds a duplicated file descriptor, so the original one can be closed.
+	defer file.Close()
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `net.FileListener of file descriptor %d failed`, fd)
+	}
+	return ln, nil
+}
+
+// listenerAddress returns the address of listener `ln` in format of server address,
+// like ":80" for TCP and "unix:/run/app.sock" for unix domain socket.
+func listenerAddress(ln net.Listener) string {
+	switch addr := ln.Addr().(type) {
+	case *net.TCPAddr:
+		return ":" + strconv.Itoa(addr.Port)
+	case *net.UnixAddr:
+		return listenerSchemeUnix + addr.Name
+	default:
+		return addr.String()
+	}
+}
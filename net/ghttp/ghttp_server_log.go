@@ -15,29 +15,30 @@ import (
 	"github.com/gogf/gf/v2/text/gstr"
 )
 
-// handleAccessLog handles the access logging for server.
+// handleAccessLog handles the access logging for server, which passes the access log entry
+// through the pipeline configured by AccessLogConfig.
 func (s *Server) handleAccessLog(r *Request) {
 	if !s.IsAccessLogEnabled() {
 		return
 	}
 	var (
-		scheme            = r.GetSchema()
-		loggerInstanceKey = fmt.Sprintf(`Acccess Logger Of Server:%s`, s.instance)
-	)
-	content := fmt.Sprintf(
-		`%d "%s %s %s %s %s" %.3f, %s, "%s", "%s"`,
-		r.Response.Status, r.Method, scheme, r.Host, r.URL.String(), r.Proto,
-		float64(r.LeaveTime.Sub(r.EnterTime).Milliseconds())/1000,
-		r.GetClientIp(), r.Referer(), r.UserAgent(),
+		ctx      = r.Context()
+		pipeline = s.getAccessLogPipeline()
 	)
-	logger := instance.GetOrSetFuncLock(loggerInstanceKey, func() interface{} {
-		l := s.Logger().Clone()
-		l.SetFile(s.config.AccessLogPattern)
-		l.SetStdoutPrint(s.config.LogStdout)
-		l.SetLevelPrint(false)
-		return l
-	}).(*glog.Logger)
-	logger.Print(r.Context(), content)
+	if !pipeline.sampled(r.Response.Status) {
+		return
+	}
+	entry := pipeline.newEntry(r)
+	content, err := pipeline.encoder.Encode(entry)
+	if err != nil {
+		s.Logger().Errorf(ctx, `encode access log failed: %+v`, err)
+		return
+	}
+	for _, sink := range pipeline.sinks {
+		if err = sink.Write(ctx, entry, content); err != nil {
+			s.Logger().Errorf(ctx, `write access log failed: %+v`, err)
+		}
+	}
 }
 
 // handleErrorLog handles the error logging for server.
@@ -80,5 +81,10 @@ func (s *Server) handleErrorLog(err error, r *Request) {
 		l.SetLevelPrint(false)
 		return l
 	}).(*glog.Logger)
+	// The fields of error are logged as structured data.
+	if fields := gerror.Fields(err); len(fields) > 0 {
+		logger.Error(r.Context(), content, glog.Fields(fields))
+		return
+	}
 	logger.Error(r.Context(), content)
 }
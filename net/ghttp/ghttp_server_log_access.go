@@ -0,0 +1,530 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// AccessLogConfig is the configuration for the access log pipeline, which encodes each access
+// log entry with the encoder and writes it to all the sinks.
+type AccessLogConfig struct {
+	// Format specifies the built-in encoder, which is AccessLogFormatText in default
+	// or AccessLogFormatJson for structured JSON.
+	Format string `json:"format"`
+
+	// Fields specifies the fields and their order of the JSON encoder, like: ["time", "status", "uri"].
+	// All the fields are encoded if it is empty, see AccessLogFields.
+	Fields []string `json:"fields"`
+
+	// CaptureRequestBody enables capturing the request body into the access log entry,
+	// which does not capture the multipart form of uploading.
+	CaptureRequestBody bool `json:"captureRequestBody"`
+
+	// CaptureResponseBody enables capturing the response body into the access log entry.
+	CaptureResponseBody bool `json:"captureResponseBody"`
+
+	// MaxBodySize specifies the max bytes of the captured body, the exceeding part is truncated.
+	MaxBodySize int `json:"maxBodySize"`
+
+	// RedactKeys specifies the keys of JSON or form body whose values are redacted in captured body,
+	// which are case-insensitive. If it is not empty, the body that is truncated in capturing or cannot be
+	// parsed as JSON or form is replaced by a placeholder, as it cannot be redacted reliably.
+	RedactKeys []string `json:"redactKeys"`
+
+	// Sampling specifies the sampling rate from 0 to 1 for status class like "2xx" or "5xx",
+	// the access log of status class absent in it is always written.
+	Sampling map[string]float64 `json:"sampling"`
+
+	// Encoder specifies the custom encoder, which overrides Format.
+	Encoder AccessLogEncoder `json:"-"`
+
+	// Sinks specifies where the encoded access logs are written to. It writes to the server logger
+	// using AccessLogPattern as file pattern in default.
+	Sinks []AccessLogSink `json:"-"`
+}
+
+// AccessLogEntry is the access log of a request passing through the pipeline.
+type AccessLogEntry struct {
+	Time         time.Time // Time entering the request.
+	Status       int       // Response status.
+	Method       string    // Request method.
+	Scheme       string    // Request scheme like "http" or "https".
+	Host         string    // Request host.
+	Uri          string    // Request URI including the query string.
+	Proto        string    // Request protocol like "HTTP/1.1".
+	Duration     float64   // Duration serving the request in seconds.
+	ClientIp     string    // Client IP address.
+	Referer      string    // Request referer.
+	UserAgent    string    // Request user agent.
+	TraceId      string    // Trace id of the request.
+	Error        string    // Error of the request if any.
+	RequestBody  string    // Captured request body if CaptureRequestBody is enabled.
+	ResponseBody string    // Captured response body if CaptureResponseBody is enabled.
+}
+
+// AccessLogEncoder encodes the access log entry into content written to sinks.
+type AccessLogEncoder interface {
+	Encode(entry *AccessLogEntry) ([]byte, error)
+}
+
+// AccessLogSink writes the encoded access log `content` of `entry`.
+type AccessLogSink interface {
+	Write(ctx context.Context, entry *AccessLogEntry, content []byte) error
+}
+
+// AccessLogKafkaProducer is the adapter producing message to Kafka for access log,
+// which can be implemented with any Kafka client.
+type AccessLogKafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+const (
+	// AccessLogFormatText is the format of access log in plain text line.
+	AccessLogFormatText = "text"
+
+	// AccessLogFormatJson is the format of access log in structured JSON.
+	AccessLogFormatJson = "json"
+
+	// defaultAccessLogMaxBodySize is the default max bytes of the captured body.
+	defaultAccessLogMaxBodySize = 4096
+
+	// accessLogRedactedValue is the value replacing the redacted ones in captured body.
+	accessLogRedactedValue = "******"
+
+	// accessLogOmittedBody is the placeholder of captured body that cannot be redacted reliably.
+	accessLogOmittedBody = "[body omitted]"
+)
+
+var (
+	// AccessLogFields is all the fields of access log entry for the JSON encoder in default order.
+	AccessLogFields = []string{
+		"time", "status", "method", "scheme", "host", "uri", "proto", "duration",
+		"clientIp", "referer", "userAgent", "traceId", "error", "requestBody", "responseBody",
+	}
+
+	// defaultAccessLogRedactKeys is the keys redacted in captured body in default.
+	defaultAccessLogRedactKeys = []string{"password", "passwd", "secret", "token", "authorization"}
+)
+
+// accessLogPipeline is the built pipeline from AccessLogConfig for a server.
+type accessLogPipeline struct {
+	config  AccessLogConfig  // Configuration of the pipeline.
+	encoder AccessLogEncoder // Encoder of the entries.
+	sinks   []AccessLogSink  // Sinks writing the encoded entries.
+}
+
+// accessLogTextEncoder encodes the entry into plain text line.
+type accessLogTextEncoder struct{}
+
+// accessLogJsonEncoder encodes the entry into JSON object with selected fields.
+type accessLogJsonEncoder struct {
+	fields []string // Selected fields in order, all fields if empty.
+}
+
+// accessLogGlogSink writes the access logs using glog.Logger.
+type accessLogGlogSink struct {
+	logger *glog.Logger
+}
+
+// accessLogFileSink writes the access logs to files without header.
+type accessLogFileSink struct {
+	logger *glog.Logger
+}
+
+// accessLogKafkaSink writes the access logs to Kafka topic.
+type accessLogKafkaSink struct {
+	producer AccessLogKafkaProducer
+	topic    string
+}
+
+// NewAccessLogTextEncoder creates and returns an encoder encoding access log as plain text line, like:
+// 200 "GET http 127.0.0.1:8000 /hello HTTP/1.1" 0.001, 127.0.0.1, "", "curl/8.0"
+func NewAccessLogTextEncoder() AccessLogEncoder {
+	return &accessLogTextEncoder{}
+}
+
+// NewAccessLogJsonEncoder creates and returns an encoder encoding access log as JSON object with
+// selected `fields` in order, which encodes all the fields if `fields` is empty, see AccessLogFields.
+func NewAccessLogJsonEncoder(fields ...string) AccessLogEncoder {
+	return &accessLogJsonEncoder{
+		fields: fields,
+	}
+}
+
+// NewAccessLogGlogSink creates and returns a sink printing access logs using `logger`.
+func NewAccessLogGlogSink(logger *glog.Logger) AccessLogSink {
+	return &accessLogGlogSink{
+		logger: logger,
+	}
+}
+
+// NewAccessLogFileSink creates and returns a sink writing access logs to files in directory `path`
+// with file `pattern` like "access-{Ymd}.log", which writes the encoded content only without header.
+func NewAccessLogFileSink(path, pattern string) (AccessLogSink, error) {
+	logger := glog.New()
+	if err := logger.SetPath(path); err != nil {
+		return nil, err
+	}
+	logger.SetFile(pattern)
+	logger.SetHeaderPrint(false)
+	logger.SetLevelPrint(false)
+	logger.SetStdoutPrint(false)
+	return &accessLogFileSink{
+		logger: logger,
+	}, nil
+}
+
+// NewAccessLogKafkaSink creates and returns a sink producing access logs to Kafka `topic` using `producer`,
+// which uses the trace id of request as message key.
+func NewAccessLogKafkaSink(producer AccessLogKafkaProducer, topic string) AccessLogSink {
+	return &accessLogKafkaSink{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+// SetAccessLogConfig sets the configuration for the access log pipeline.
+func (s *Server) SetAccessLogConfig(config AccessLogConfig) {
+	s.config.AccessLog = config
+	s.resetAccessLogPipeline()
+}
+
+// getAccessLogPipeline returns the access log pipeline of server, which is built if it is not built
+// or the configuration changes.
+func (s *Server) getAccessLogPipeline() *accessLogPipeline {
+	s.accessLogMu.RLock()
+	pipeline := s.accessLog
+	s.accessLogMu.RUnlock()
+	if pipeline != nil {
+		return pipeline
+	}
+	s.accessLogMu.Lock()
+	defer s.accessLogMu.Unlock()
+	if s.accessLog == nil {
+		s.accessLog = s.newAccessLogPipeline()
+	}
+	return s.accessLog
+}
+
+// resetAccessLogPipeline resets the access log pipeline, which is rebuilt by the next access log
+// with the changed configuration.
+func (s *Server) resetAccessLogPipeline() {
+	s.accessLogMu.Lock()
+	defer s.accessLogMu.Unlock()
+	s.accessLog = nil
+}
+
+// newAccessLogPipeline creates the access log pipeline for server.
+func (s *Server) newAccessLogPipeline() *accessLogPipeline {
+	var (
+		config   = s.config.AccessLog
+		pipeline = &accessLogPipeline{
+			config:  config,
+			encoder: config.Encoder,
+			sinks:   config.Sinks,
+		}
+	)
+	if pipeline.config.MaxBodySize <= 0 {
+		pipeline.config.MaxBodySize = defaultAccessLogMaxBodySize
+	}
+	if pipeline.config.RedactKeys == nil {
+		pipeline.config.RedactKeys = defaultAccessLogRedactKeys
+	}
+	if pipeline.encoder == nil {
+		if strings.EqualFold(config.Format, AccessLogFormatJson) {
+			pipeline.encoder = NewAccessLogJsonEncoder(config.Fields...)
+		} else {
+			pipeline.encoder = NewAccessLogTextEncoder()
+		}
+	}
+	if len(pipeline.sinks) == 0 {
+		logger := s.Logger().Clone()
+		logger.SetFile(s.config.AccessLogPattern)
+		logger.SetStdoutPrint(s.config.LogStdout)
+		logger.SetLevelPrint(false)
+		pipeline.sinks = []AccessLogSink{NewAccessLogGlogSink(logger)}
+	}
+	return pipeline
+}
+
+// sampled checks whether the access log of `status` is sampled to write.
+func (p *accessLogPipeline) sampled(status int) bool {
+	rate, ok := p.config.Sampling[metricStatusClass(status)]
+	if !ok || rate >= 1 {
+		return true
+	}
+	return rate > 0 && grand.Intn(1000000) < int(rate*1000000)
+}
+
+// newEntry creates the access log entry of request `r`.
+func (p *accessLogPipeline) newEntry(r *Request) *AccessLogEntry {
+	entry := &AccessLogEntry{
+		Time:      r.EnterTime.Time,
+		Status:    r.Response.Status,
+		Method:    r.Method,
+		Scheme:    r.GetSchema(),
+		Host:      r.Host,
+		Uri:       r.URL.String(),
+		Proto:     r.Proto,
+		Duration:  float64(r.LeaveTime.Sub(r.EnterTime).Milliseconds()) / 1000,
+		ClientIp:  r.GetClientIp(),
+		Referer:   r.Referer(),
+		UserAgent: r.UserAgent(),
+		TraceId:   gtrace.GetTraceID(r.Context()),
+	}
+	if err := r.GetError(); err != nil {
+		entry.Error = err.Error()
+	}
+	if p.config.CaptureRequestBody {
+		body, truncated := p.readRequestBody(r)
+		entry.RequestBody = p.captureBody(body, r.GetHeader("Content-Type"), truncated)
+	}
+	if p.config.CaptureResponseBody {
+		entry.ResponseBody = p.captureBody(
+			r.Response.capturedBody, r.Response.Header().Get("Content-Type"), r.Response.capturedTruncated,
+		)
+	}
+	return entry
+}
+
+// readRequestBody reads the request body for capturing, which reads at most twice of
+// MaxBodySize if the body is not read by handler, as the redaction needs complete content.
+// It also returns whether the body is truncated in reading.
+func (p *accessLogPipeline) readRequestBody(r *Request) (body []byte, truncated bool) {
+	if strings.Contains(r.GetHeader("Content-Type"), "multipart/") {
+		return nil, false
+	}
+	if r.bodyContent != nil {
+		return r.bodyContent, false
+	}
+	maxSize := p.config.MaxBodySize * 2
+	body, _ = io.ReadAll(io.LimitReader(r.Body, int64(maxSize)+1))
+	if len(body) > maxSize {
+		return body[:maxSize], true
+	}
+	return body, false
+}
+
+// captureBody redacts and truncates the `body` of `contentType` for access log.
+// The parameter `truncated` specifies whether the `body` is truncated in capturing.
+func (p *accessLogPipeline) captureBody(body []byte, contentType string, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(p.config.RedactKeys) > 0 {
+		// The truncated body might contain values of RedactKeys that cannot be recognized.
+		if truncated {
+			return accessLogOmittedBody
+		}
+		var ok bool
+		if body, ok = p.redactBody(body, contentType); !ok {
+			return accessLogOmittedBody
+		}
+	}
+	if len(body) > p.config.MaxBodySize {
+		return string(body[:p.config.MaxBodySize]) + "..."
+	}
+	return string(body)
+}
+
+// redactBody redacts the values of RedactKeys in JSON or form `body`.
+// It returns `body` unchanged if it is neither JSON nor form, and returns false if it cannot be parsed.
+func (p *accessLogPipeline) redactBody(body []byte, contentType string) ([]byte, bool) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		var data interface{}
+		if json.Unmarshal(trimmed, &data) != nil {
+			return nil, false
+		}
+		redacted, err := json.Marshal(p.redactValue(data))
+		if err != nil {
+			return nil, false
+		}
+		return redacted, true
+	}
+	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, false
+		}
+		for key := range values {
+			if p.isRedactKey(key) {
+				values[key] = []string{accessLogRedactedValue}
+			}
+		}
+		return []byte(values.Encode()), true
+	}
+	return body, true
+}
+
+// redactValue redacts the values of RedactKeys in decoded JSON `value` recursively.
+func (p *accessLogPipeline) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if p.isRedactKey(key) {
+				v[key] = accessLogRedactedValue
+			} else {
+				v[key] = p.redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = p.redactValue(item)
+		}
+	}
+	return value
+}
+
+// isRedactKey checks whether `key` is one of the RedactKeys.
+func (p *accessLogPipeline) isRedactKey(key string) bool {
+	for _, v := range p.config.RedactKeys {
+		if strings.EqualFold(v, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureResponseBody captures the response body to be flushed for access log.
+func (r *Response) captureResponseBody() {
+	var config = r.Server.config.AccessLog
+	if !config.CaptureResponseBody || !r.Server.IsAccessLogEnabled() {
+		return
+	}
+	var maxSize = config.MaxBodySize
+	if maxSize <= 0 {
+		maxSize = defaultAccessLogMaxBodySize
+	}
+	// It captures twice of MaxBodySize, as the redaction needs complete content.
+	var (
+		buffer    = r.Buffer()
+		remaining = maxSize*2 - len(r.capturedBody)
+	)
+	if len(buffer) > remaining {
+		r.capturedTruncated = true
+		if remaining <= 0 {
+			return
+		}
+		buffer = buffer[:remaining]
+	}
+	r.capturedBody = append(r.capturedBody, buffer...)
+}
+
+// Encode implements the interface AccessLogEncoder.
+func (e *accessLogTextEncoder) Encode(entry *AccessLogEntry) ([]byte, error) {
+	content := fmt.Sprintf(
+		`%d "%s %s %s %s %s" %.3f, %s, "%s", "%s"`,
+		entry.Status, entry.Method, entry.Scheme, entry.Host, entry.Uri, entry.Proto,
+		entry.Duration, entry.ClientIp, entry.Referer, entry.UserAgent,
+	)
+	if entry.RequestBody != "" || entry.ResponseBody != "" {
+		content += fmt.Sprintf(`, %q, %q`, entry.RequestBody, entry.ResponseBody)
+	}
+	return []byte(content), nil
+}
+
+// Encode implements the interface AccessLogEncoder.
+func (e *accessLogJsonEncoder) Encode(entry *AccessLogEntry) ([]byte, error) {
+	var (
+		fields = e.fields
+		buffer = bytes.NewBuffer(nil)
+	)
+	if len(fields) == 0 {
+		fields = AccessLogFields
+	}
+	buffer.WriteByte('{')
+	for _, field := range fields {
+		value, ok := entry.fieldValue(field)
+		if !ok {
+			continue
+		}
+		// The optional fields are omitted if they are empty and not selected explicitly.
+		if len(e.fields) == 0 && value == "" {
+			continue
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+		keyBytes, _ := json.Marshal(field)
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// fieldValue returns the value of `field` of entry, the returned `ok` is false if `field` is unknown.
+func (entry *AccessLogEntry) fieldValue(field string) (value interface{}, ok bool) {
+	switch field {
+	case "time":
+		return entry.Time.Format(time.RFC3339Nano), true
+	case "status":
+		return entry.Status, true
+	case "method":
+		return entry.Method, true
+	case "scheme":
+		return entry.Scheme, true
+	case "host":
+		return entry.Host, true
+	case "uri":
+		return entry.Uri, true
+	case "proto":
+		return entry.Proto, true
+	case "duration":
+		return entry.Duration, true
+	case "clientIp":
+		return entry.ClientIp, true
+	case "referer":
+		return entry.Referer, true
+	case "userAgent":
+		return entry.UserAgent, true
+	case "traceId":
+		return entry.TraceId, true
+	case "error":
+		return entry.Error, true
+	case "requestBody":
+		return entry.RequestBody, true
+	case "responseBody":
+		return entry.ResponseBody, true
+	}
+	return nil, false
+}
+
+// Write implements the interface AccessLogSink.
+func (s *accessLogGlogSink) Write(ctx context.Context, entry *AccessLogEntry, content []byte) error {
+	s.logger.Print(ctx, string(content))
+	return nil
+}
+
+// Write implements the interface AccessLogSink.
+// It does not pass `ctx` to the logger, as the logger prints the trace id of `ctx` even without header.
+func (s *accessLogFileSink) Write(ctx context.Context, entry *AccessLogEntry, content []byte) error {
+	s.logger.Print(context.Background(), string(content))
+	return nil
+}
+
+// Write implements the interface AccessLogSink.
+func (s *accessLogKafkaSink) Write(ctx context.Context, entry *AccessLogEntry, content []byte) error {
+	return s.producer.Produce(ctx, s.topic, []byte(entry.TraceId), content)
+}
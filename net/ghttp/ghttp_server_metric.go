@@ -7,6 +7,7 @@
 package ghttp
 
 import (
+	"fmt"
 	"net"
 	"net/http"
 
@@ -23,17 +24,24 @@ type localMetricManager struct {
 	HttpServerRequestDurationTotal gmetric.Counter
 	HttpServerRequestBodySize      gmetric.Counter
 	HttpServerResponseBodySize     gmetric.Counter
+	HttpServerRequestQueued        gmetric.UpDownCounter
+	HttpServerRequestRejected      gmetric.Counter
+	HttpServerRequestVersion       gmetric.Counter
 }
 
 const (
-	metricAttrKeyServerAddress          = "server.address"
-	metricAttrKeyServerPort             = "server.port"
-	metricAttrKeyHttpRoute              = "http.route"
-	metricAttrKeyUrlSchema              = "url.schema"
-	metricAttrKeyHttpRequestMethod      = "http.request.method"
-	metricAttrKeyErrorCode              = "error.code"
-	metricAttrKeyHttpResponseStatusCode = "http.response.status_code"
-	metricAttrKeyNetworkProtocolVersion = "network.protocol.version"
+	metricAttrKeyServerAddress           = "server.address"
+	metricAttrKeyServerPort              = "server.port"
+	metricAttrKeyHttpRoute               = "http.route"
+	metricAttrKeyUrlSchema               = "url.schema"
+	metricAttrKeyHttpRequestMethod       = "http.request.method"
+	metricAttrKeyErrorCode               = "error.code"
+	metricAttrKeyHttpResponseStatusCode  = "http.response.status_code"
+	metricAttrKeyHttpResponseStatusClass = "http.response.status_class"
+	metricAttrKeyNetworkProtocolVersion  = "network.protocol.version"
+	metricAttrKeyRejectionReason         = "rejection.reason"
+	metricAttrKeyApiVersion              = "api.version"
+	metricAttrKeyApiDeprecated           = "api.deprecated"
 )
 
 var (
@@ -50,7 +58,7 @@ func newMetricManager() *localMetricManager {
 		HttpServerRequestDuration: meter.MustHistogram(
 			"http.server.request.duration",
 			gmetric.MetricOption{
-				Help:       "Measures the duration of inbound request.",
+				Help:       "Measures the duration of inbound request by route, method and status class.",
 				Unit:       "ms",
 				Attributes: gmetric.Attributes{},
 				Buckets: []float64{
@@ -114,15 +122,45 @@ func newMetricManager() *localMetricManager {
 				Attributes: gmetric.Attributes{},
 			},
 		),
+		HttpServerRequestQueued: meter.MustUpDownCounter(
+			"http.server.request.queued",
+			gmetric.MetricOption{
+				Help:       "Number of server requests waiting in admission queue.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpServerRequestRejected: meter.MustCounter(
+			"http.server.request.rejected",
+			gmetric.MetricOption{
+				Help:       "Total request number rejected by admission control.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		HttpServerRequestVersion: meter.MustCounter(
+			"http.server.request.version",
+			gmetric.MetricOption{
+				Help:       "Total request number by API version.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
 	}
 	return mm
 }
 
+// GetMetricOptionForRequestDurationByMap returns the option of duration histogram, which is recorded per route
+// with the route pattern, method and status class, so that the latency buckets are comparable among routes
+// without the high cardinality of status codes.
 func (m *localMetricManager) GetMetricOptionForRequestDurationByMap(attrMap gmetric.AttributeMap) gmetric.Option {
 	return gmetric.Option{
 		Attributes: attrMap.Pick(
 			metricAttrKeyServerAddress,
 			metricAttrKeyServerPort,
+			metricAttrKeyHttpRoute,
+			metricAttrKeyHttpRequestMethod,
+			metricAttrKeyHttpResponseStatusClass,
 		),
 	}
 }
@@ -196,13 +234,23 @@ func (m *localMetricManager) GetMetricAttributeMap(r *Request) gmetric.Attribute
 			errCode = gerror.Code(err).Code()
 		}
 		attrMap.Sets(gmetric.AttributeMap{
-			metricAttrKeyErrorCode:              errCode,
-			metricAttrKeyHttpResponseStatusCode: r.Response.Status,
+			metricAttrKeyErrorCode:               errCode,
+			metricAttrKeyHttpResponseStatusCode:  r.Response.Status,
+			metricAttrKeyHttpResponseStatusClass: metricStatusClass(r.Response.Status),
 		})
 	}
 	return attrMap
 }
 
+// metricStatusClass returns the class of response `status` like "2xx", in which the zero status is
+// taken as 200 as it is written by default.
+func metricStatusClass(status int) string {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return fmt.Sprintf("%dxx", status/100)
+}
+
 func (s *Server) handleMetricsBeforeRequest(r *Request) {
 	if !gmetric.IsEnabled() {
 		return
@@ -234,6 +282,8 @@ func (s *Server) handleMetricsAfterRequestDone(r *Request) {
 		responseOption  = metricManager.GetMetricOptionForResponseByMap(attrMap)
 		histogramOption = metricManager.GetMetricOptionForRequestDurationByMap(attrMap)
 	)
+	// The exemplar links the duration bucket to the sampled trace of the request.
+	histogramOption.Exemplar = gmetric.ExemplarFromContext(ctx)
 	metricManager.HttpServerRequestTotal.Inc(ctx, responseOption)
 	metricManager.HttpServerRequestActive.Dec(
 		ctx,
@@ -254,3 +304,62 @@ func (s *Server) handleMetricsAfterRequestDone(r *Request) {
 		histogramOption,
 	)
 }
+
+func (s *Server) handleMetricsRequestQueued(r *Request, delta float64) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	metricManager.HttpServerRequestQueued.Add(
+		r.Context(),
+		delta,
+		metricManager.GetMetricOptionForRequest(r),
+	)
+}
+
+func (s *Server) handleMetricsRequestRejected(r *Request, reason string) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	attrMap := metricManager.GetMetricAttributeMap(r)
+	attrMap.Sets(gmetric.AttributeMap{
+		metricAttrKeyRejectionReason: reason,
+	})
+	metricManager.HttpServerRequestRejected.Inc(
+		r.Context(),
+		gmetric.Option{
+			Attributes: attrMap.Pick(
+				metricAttrKeyServerAddress,
+				metricAttrKeyServerPort,
+				metricAttrKeyHttpRoute,
+				metricAttrKeyUrlSchema,
+				metricAttrKeyHttpRequestMethod,
+				metricAttrKeyNetworkProtocolVersion,
+				metricAttrKeyRejectionReason,
+			),
+		},
+	)
+}
+
+func (s *Server) handleMetricsVersionRequest(r *Request, version string, deprecated bool) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	attrMap := metricManager.GetMetricAttributeMap(r)
+	attrMap.Sets(gmetric.AttributeMap{
+		metricAttrKeyApiVersion:    version,
+		metricAttrKeyApiDeprecated: deprecated,
+	})
+	metricManager.HttpServerRequestVersion.Inc(
+		r.Context(),
+		gmetric.Option{
+			Attributes: attrMap.Pick(
+				metricAttrKeyServerAddress,
+				metricAttrKeyServerPort,
+				metricAttrKeyHttpRoute,
+				metricAttrKeyHttpRequestMethod,
+				metricAttrKeyApiVersion,
+				metricAttrKeyApiDeprecated,
+			),
+		},
+	)
+}
@@ -84,6 +84,11 @@ func (s *Server) calculateListenedEndpoints(ctx context.Context) gsvc.Endpoints
 		addresses = gstr.SplitAndTrim(configAddr, ",")
 	}
 	for _, address := range addresses {
+		// The listeners of unix domain socket, systemd socket activation and pre-opened file descriptor
+		// have no IP and port in address, which cannot be registered as endpoints.
+		if !isTCPAddress(address) {
+			continue
+		}
 		var (
 			addrArray     = gstr.Split(address, ":")
 			listenedIps   []string
@@ -131,6 +136,10 @@ func (s *Server) calculateListenedEndpoints(ctx context.Context) gsvc.Endpoints
 		}
 		for _, ip := range listenedIps {
 			for _, port := range listenedPorts {
+				// The port is -1 for the listener that is not TCP.
+				if port <= 0 {
+					continue
+				}
 				endpoints = append(endpoints, gsvc.NewEndpoint(fmt.Sprintf(`%s:%d`, ip, port)))
 			}
 		}
@@ -0,0 +1,319 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfsnotify"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// CertificateProvider provides the certificate for the TLS handshake described by `hello`,
+// like the GetCertificate of tls.Config.
+type CertificateProvider func(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+
+// certificateReloader serves the HTTPS certificate loaded from files, which can be reloaded
+// without restarting the server. The established connections are not affected by reloading,
+// as the certificate is only used for new TLS handshakes.
+type certificateReloader struct {
+	mu          sync.Mutex
+	certFile    string                // Certification file path.
+	keyFile     string                // Key file path.
+	certificate *gtype.Interface      // Current serving certificate of type *tls.Certificate.
+	callbacks   []*gfsnotify.Callback // Callbacks watching the certification and key files.
+}
+
+// liveConfig is the configuration values that can be applied without restarting the server.
+type liveConfig struct {
+	readTimeout  *gtype.Int64 // Live ReadTimeout in nanoseconds, which is negative if not applied.
+	writeTimeout *gtype.Int64 // Live WriteTimeout in nanoseconds, which is negative if not applied.
+}
+
+const (
+	// LiveConfigReadTimeout is the key of live configuration for ReadTimeout.
+	LiveConfigReadTimeout = "readTimeout"
+
+	// LiveConfigWriteTimeout is the key of live configuration for WriteTimeout.
+	LiveConfigWriteTimeout = "writeTimeout"
+
+	// LiveConfigLogLevel is the key of live configuration for the level of server logger.
+	LiveConfigLogLevel = "logLevel"
+)
+
+// newCertificateReloader creates and returns a certificate reloader.
+func newCertificateReloader() *certificateReloader {
+	return &certificateReloader{
+		certificate: gtype.NewInterface(),
+	}
+}
+
+// newLiveConfig creates and returns a live configuration with nothing applied.
+func newLiveConfig() *liveConfig {
+	return &liveConfig{
+		readTimeout:  gtype.NewInt64(-1),
+		writeTimeout: gtype.NewInt64(-1),
+	}
+}
+
+// getCertificateFunc returns the function providing certificate for TLS handshakes, which is the
+// CertificateProvider if configured, or else the certificate loaded from `certFile` and `keyFile`.
+func (s *Server) getCertificateFunc(certFile, keyFile string) (CertificateProvider, error) {
+	if s.config.CertificateProvider != nil {
+		return s.config.CertificateProvider, nil
+	}
+	if err := s.certificates.load(certFile, keyFile); err != nil {
+		return nil, err
+	}
+	if s.config.HTTPSCertWatch {
+		if err := s.certificates.watch(s); err != nil {
+			return nil, err
+		}
+	}
+	return s.certificates.getCertificate, nil
+}
+
+// ReloadCertificate reloads the HTTPS certificate from the certification and key files,
+// which is used for the new TLS handshakes without dropping the established connections.
+// It keeps serving the previous certificate if the reloading fails.
+func (s *Server) ReloadCertificate() error {
+	if s.config.CertificateProvider != nil {
+		return gerror.NewCode(
+			gcode.CodeInvalidOperation,
+			`certificate is provided by CertificateProvider, which cannot be reloaded`,
+		)
+	}
+	return s.certificates.reload()
+}
+
+// load loads the certificate from `certFile` and `keyFile` if they are not loaded yet.
+func (c *certificateReloader) load(certFile, keyFile string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.certFile == certFile && c.keyFile == keyFile && c.certificate.Val() != nil {
+		return nil
+	}
+	certificate, err := loadCertificate(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	c.certFile = certFile
+	c.keyFile = keyFile
+	c.certificate.Set(&certificate)
+	return nil
+}
+
+// reload reloads the certificate from the loaded files, which keeps the current one if it fails.
+func (c *certificateReloader) reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.certificate.Val() == nil {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `HTTPS certificate is not loaded from files`)
+	}
+	certificate, err := loadCertificate(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.certificate.Set(&certificate)
+	return nil
+}
+
+// getCertificate returns the current serving certificate for TLS handshakes.
+func (c *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if v := c.certificate.Val(); v != nil {
+		return v.(*tls.Certificate), nil
+	}
+	return nil, gerror.NewCode(gcode.CodeInvalidOperation, `HTTPS certificate is not loaded`)
+}
+
+// watch watches the certification and key files, which reloads the certificate if they are changed.
+// The certificate from resource manager is not watched as it cannot be changed.
+func (c *certificateReloader) watch(s *Server) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.callbacks) > 0 || gres.Contains(c.certFile) {
+		return nil
+	}
+	var ctx = context.TODO()
+	for _, path := range []string{c.certFile, c.keyFile} {
+		callback, err := gfsnotify.Add(path, func(event *gfsnotify.Event) {
+			if event.IsRemove() {
+				return
+			}
+			// The certification and key files might not be updated at the same time,
+			// it keeps the current certificate until they are matched with each other.
+			if err := c.reload(); err != nil {
+				s.Logger().Warningf(ctx, `reload HTTPS certificate failed: %+v`, err)
+				return
+			}
+			s.Logger().Infof(ctx, `HTTPS certificate reloaded from "%s"`, c.certFile)
+		}, gfsnotify.WatchOption{NoRecursive: true})
+		if err != nil {
+			return err
+		}
+		c.callbacks = append(c.callbacks, callback)
+	}
+	return nil
+}
+
+// stopWatching stops watching the certification and key files.
+func (c *certificateReloader) stopWatching() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, callback := range c.callbacks {
+		_ = gfsnotify.RemoveCallback(callback.Id)
+	}
+	c.callbacks = nil
+}
+
+// SetLiveConfig applies the configuration values in `data` to the running server without restarting it.
+// The supported keys are LiveConfigReadTimeout, LiveConfigWriteTimeout and LiveConfigLogLevel,
+// and none of the values is applied if any of them is invalid.
+//
+// The timeouts are applied to the requests served after this call, like "10s" or "1m". Note that they are
+// applied as the deadlines of the connection when the request handling starts, which differs from the
+// ReadTimeout and WriteTimeout configured for the underlying http server:
+//   - The live ReadTimeout limits reading the rest of the request body from the handling start, and the
+//     request header is still read under the configured ReadTimeout and ReadHeaderTimeout.
+//   - The live WriteTimeout limits writing the response from the handling start, rather than from the end
+//     of reading the request header.
+//   - The keep-alive connections waiting for the next requests are still limited by the configured
+//     IdleTimeout, or ReadTimeout if IdleTimeout is not configured.
+//
+// The configured values of the http server are never changed, as they are shared by the connections
+// being served.
+func (s *Server) SetLiveConfig(data map[string]interface{}) error {
+	var (
+		readTimeout  = time.Duration(-1)
+		writeTimeout = time.Duration(-1)
+		logLevel     string
+		err          error
+	)
+	for key, value := range data {
+		switch {
+		case strings.EqualFold(key, LiveConfigReadTimeout):
+			if readTimeout, err = parseLiveDuration(key, value); err != nil {
+				return err
+			}
+
+		case strings.EqualFold(key, LiveConfigWriteTimeout):
+			if writeTimeout, err = parseLiveDuration(key, value); err != nil {
+				return err
+			}
+
+		case strings.EqualFold(key, LiveConfigLogLevel):
+			logLevel = gconv.String(value)
+
+		default:
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported live configuration "%s"`, key)
+		}
+	}
+	if logLevel != "" {
+		if err := s.Logger().SetLevelStr(logLevel); err != nil {
+			return gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid %s "%s"`, LiveConfigLogLevel, logLevel)
+		}
+	}
+	if readTimeout >= 0 {
+		s.live.readTimeout.Set(int64(readTimeout))
+	}
+	if writeTimeout >= 0 {
+		s.live.writeTimeout.Set(int64(writeTimeout))
+	}
+	return nil
+}
+
+// parseLiveDuration parses the live configuration `value` of `key` as a non-negative duration.
+func parseLiveDuration(key string, value interface{}) (time.Duration, error) {
+	var (
+		duration time.Duration
+		err      error
+	)
+	if v, ok := value.(string); ok {
+		duration, err = gtime.ParseDuration(v)
+	} else {
+		duration = gconv.Duration(value)
+	}
+	if err != nil || duration < 0 {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid %s "%v"`, key, value)
+	}
+	return duration, nil
+}
+
+// GetLiveConfig returns the current values of the configuration that can be applied live.
+func (s *Server) GetLiveConfig() map[string]interface{} {
+	var (
+		readTimeout  = s.config.ReadTimeout
+		writeTimeout = s.config.WriteTimeout
+	)
+	if v := s.live.readTimeout.Val(); v >= 0 {
+		readTimeout = time.Duration(v)
+	}
+	if v := s.live.writeTimeout.Val(); v >= 0 {
+		writeTimeout = time.Duration(v)
+	}
+	return map[string]interface{}{
+		LiveConfigReadTimeout:  readTimeout.String(),
+		LiveConfigWriteTimeout: writeTimeout.String(),
+		LiveConfigLogLevel:     s.getLogLevelStr(),
+	}
+}
+
+// getLogLevelStr returns the name of the lowest level enabled for the server logger.
+func (s *Server) getLogLevelStr() string {
+	var (
+		logger = s.Logger()
+		level  = logger.GetLevel()
+	)
+	for _, v := range []int{
+		glog.LEVEL_DEBU, glog.LEVEL_INFO, glog.LEVEL_NOTI,
+		glog.LEVEL_WARN, glog.LEVEL_ERRO, glog.LEVEL_CRIT,
+	} {
+		if level&v > 0 {
+			return logger.GetLevelPrefix(v)
+		}
+	}
+	return ""
+}
+
+// applyDeadlines applies the live timeouts to the connection of current request as deadlines from now,
+// which override the deadlines set by the underlying http server for the configured timeouts.
+// See SetLiveConfig for the difference between them.
+func (c *liveConfig) applyDeadlines(w http.ResponseWriter) {
+	var (
+		readTimeout  = c.readTimeout.Val()
+		writeTimeout = c.writeTimeout.Val()
+	)
+	if readTimeout < 0 && writeTimeout < 0 {
+		return
+	}
+	controller := http.NewResponseController(w)
+	if readTimeout >= 0 {
+		_ = controller.SetReadDeadline(liveDeadline(readTimeout))
+	}
+	if writeTimeout >= 0 {
+		_ = controller.SetWriteDeadline(liveDeadline(writeTimeout))
+	}
+}
+
+// liveDeadline returns the deadline from now for `timeout` in nanoseconds, which is zero for no timeout.
+func liveDeadline(timeout int64) time.Time {
+	if timeout == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(timeout))
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/gogf/gf/v2/debug/gdebug"
 	"github.com/gogf/gf/v2/internal/consts"
@@ -265,6 +266,12 @@ func (g *RouterGroup) Middleware(handlers ...HandlerFunc) *RouterGroup {
 	return g
 }
 
+// Timeout binds the timeout middleware to the router group, which limits the handlers of the group in `timeout`.
+// The optional parameter `handler` writes the response if timeout, see MiddlewareTimeout.
+func (g *RouterGroup) Timeout(timeout time.Duration, handler ...HandlerFunc) *RouterGroup {
+	return g.Middleware(MiddlewareTimeout(timeout, handler...))
+}
+
 // preBindToLocalArray adds the route registering parameters to an internal variable array for lazily registering feature.
 func (g *RouterGroup) preBindToLocalArray(bindType string, pattern string, object interface{}, params ...interface{}) *RouterGroup {
 	_, file, line := gdebug.CallerWithFilter([]string{consts.StackFilterKeyForGoFrame})
@@ -162,7 +162,7 @@ func (s *Server) checkAndCreateFuncInfo(
 		inputObject    reflect.Value
 		inputObjectPtr interface{}
 	)
-	if reflectType.NumIn() != 2 || reflectType.NumOut() != 2 {
+	if reflectType.NumIn() < 2 || reflectType.NumOut() != 2 {
 		if pkgPath != "" {
 			err = gerror.NewCodef(
 				gcode.CodeInvalidParameter,
@@ -257,6 +257,10 @@ func (s *Server) checkAndCreateFuncInfo(
 		return funcInfo, err
 	}
 	funcInfo.ReqStructFields = fields
+	// The input parameters following the request struct are injected with values provided for the request.
+	for i := 2; i < reflectType.NumIn(); i++ {
+		funcInfo.InjectTypes = append(funcInfo.InjectTypes, reflectType.In(i))
+	}
 	funcInfo.Func = createRouterFunc(funcInfo)
 	return
 }
@@ -270,7 +274,7 @@ func createRouterFunc(funcInfo handlerFuncInfo) func(r *Request) {
 				reflect.ValueOf(r.Context()),
 			}
 		)
-		if funcInfo.Type.NumIn() == 2 {
+		if funcInfo.Type.NumIn() >= 2 {
 			var inputObject reflect.Value
 			if funcInfo.Type.In(1).Kind() == reflect.Ptr {
 				inputObject = reflect.New(funcInfo.Type.In(1).Elem())
@@ -284,6 +288,13 @@ func createRouterFunc(funcInfo handlerFuncInfo) func(r *Request) {
 			}
 			inputValues = append(inputValues, inputObject)
 		}
+		if len(funcInfo.InjectTypes) > 0 {
+			var injectValues []reflect.Value
+			if injectValues, r.error = r.resolveInjections(funcInfo.InjectTypes); r.error != nil {
+				return
+			}
+			inputValues = append(inputValues, injectValues...)
+		}
 		// Call handler with dynamic created parameter values.
 		results := funcInfo.Value.Call(inputValues)
 		switch len(results) {
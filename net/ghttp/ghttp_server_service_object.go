@@ -154,7 +154,7 @@ func (s *Server) doBindObject(ctx context.Context, in doBindObjectInput) {
 		var (
 			isIndexMethod = strings.EqualFold(methodName, specialMethodNameIndex)
 			hasBuildInVar = gregex.IsMatchString(`\{\.\w+\}`, in.Pattern)
-			hashTwoParams = funcInfo.Type.NumIn() == 2
+			hashTwoParams = funcInfo.Type.NumIn() >= 2
 		)
 		if isIndexMethod && !hasBuildInVar && !hashTwoParams {
 			p := gstr.PosRI(key, "/index")
@@ -0,0 +1,165 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gview"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+var (
+	// staticPrecompressedEncodings is the encodings of pre-compressed static files by priority.
+	staticPrecompressedEncodings = []string{CompressEncodingBrotli, CompressEncodingGzip}
+
+	// staticPrecompressedSuffixes is the file name suffixes of pre-compressed static files by encoding.
+	staticPrecompressedSuffixes = map[string]string{
+		CompressEncodingBrotli: ".br",
+		CompressEncodingGzip:   ".gz",
+	}
+)
+
+// serveStaticContent serves the content of static file `f` with `config`, which serves the pre-compressed
+// sibling file instead if available and sets the "Cache-Control" header by the cache rules.
+// It supports the Range and conditional requests.
+func (s *Server) serveStaticContent(
+	r *Request, f *staticFile, config *StaticPathConfig, name string, modTime time.Time, content io.ReadSeeker,
+) {
+	header := r.Response.Header()
+	if cacheControl := matchStaticCacheControl(config.CacheRules, f.Uri, name); cacheControl != "" {
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", cacheControl)
+		}
+	}
+	if config.Precompressed {
+		header.Add("Vary", "Accept-Encoding")
+		var (
+			compressedContent io.ReadSeeker
+			compressedModTime time.Time
+			closeFunc         func()
+			encoding          = negotiateEncoding(
+				r.Header.Get("Accept-Encoding"), staticPrecompressedEncodings,
+				func(encoding string) bool {
+					return hasPrecompressedFile(f, staticPrecompressedSuffixes[encoding])
+				},
+			)
+		)
+		if encoding != "" {
+			compressedContent, compressedModTime, closeFunc = openPrecompressedFile(
+				f, staticPrecompressedSuffixes[encoding],
+			)
+		}
+		if compressedContent != nil {
+			defer closeFunc()
+			// The content type is detected by the original file name instead of the compressed content.
+			if header.Get("Content-Type") == "" {
+				contentType := mime.TypeByExtension(path.Ext(name))
+				if contentType == "" {
+					contentType = "application/octet-stream"
+				}
+				header.Set("Content-Type", contentType)
+			}
+			header.Set("Content-Encoding", encoding)
+			content, modTime = compressedContent, compressedModTime
+		}
+	}
+	r.Response.ServeContent(name, modTime, content)
+}
+
+// hasPrecompressedFile checks and returns whether the pre-compressed sibling file of `f` with name `suffix` exists.
+func hasPrecompressedFile(f *staticFile, suffix string) bool {
+	if f.File != nil {
+		file := gres.Get(f.File.Name() + suffix)
+		return file != nil && !file.FileInfo().IsDir()
+	}
+	return gfile.IsFile(f.Path + suffix)
+}
+
+// openPrecompressedFile opens and returns the pre-compressed sibling file of `f` with name `suffix`,
+// and the function closing it. It returns nil content if the sibling file does not exist.
+func openPrecompressedFile(f *staticFile, suffix string) (content io.ReadSeeker, modTime time.Time, closeFunc func()) {
+	if f.File != nil {
+		if file := gres.Get(f.File.Name() + suffix); file != nil && !file.FileInfo().IsDir() {
+			return file, file.FileInfo().ModTime(), func() {}
+		}
+		return nil, time.Time{}, nil
+	}
+	file, err := os.Open(f.Path + suffix)
+	if err != nil {
+		return nil, time.Time{}, nil
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		_ = file.Close()
+		return nil, time.Time{}, nil
+	}
+	return file, info.ModTime(), func() {
+		_ = file.Close()
+	}
+}
+
+// matchStaticCacheControl returns the "Cache-Control" header of the first rule in `rules` matching the file
+// of relative `uri` and `name`, or empty if no rule matches.
+func matchStaticCacheControl(rules []StaticCacheRule, uri, name string) string {
+	for _, rule := range rules {
+		var matched bool
+		if strings.Contains(rule.Pattern, "/") {
+			matched, _ = path.Match(rule.Pattern, "/"+strings.TrimLeft(uri, "/"))
+		} else {
+			matched, _ = path.Match(rule.Pattern, name)
+		}
+		if matched {
+			return rule.CacheControl
+		}
+	}
+	return ""
+}
+
+// listDirWithTemplate lists `files` of the requested directory using template file `tpl`.
+func (s *Server) listDirWithTemplate(r *Request, files []os.FileInfo, tpl string) {
+	var (
+		prefix = gstr.TrimRight(r.URL.Path, "/")
+		items  = make([]map[string]interface{}, 0, len(files))
+		parent string
+	)
+	if r.URL.Path != "/" {
+		parent = gfile.Dir(r.URL.Path)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() {
+			name += "/"
+		}
+		items = append(items, map[string]interface{}{
+			"Name":    name,
+			"Url":     prefix + "/" + name,
+			"Size":    file.Size(),
+			"ModTime": gtime.New(file.ModTime()),
+			"IsDir":   file.IsDir(),
+		})
+	}
+	err := r.Response.WriteTpl(tpl, gview.Params{
+		"Path":   r.URL.Path,
+		"Parent": parent,
+		"Files":  items,
+	})
+	if err != nil {
+		s.Logger().Errorf(r.Context(), `list directory using template "%s" failed: %+v`, tpl, err)
+		r.Response.ClearBuffer()
+		r.Response.WriteStatus(http.StatusInternalServerError, "Error listing directory")
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+// VersionDeprecation is the deprecation of an API version, which is announced to clients by the headers
+// "Deprecation" (RFC 9745), "Sunset" (RFC 8594) and "Link".
+type VersionDeprecation struct {
+	// At is the time that the version is deprecated, which is the time of deprecating in default.
+	At time.Time
+
+	// Sunset is the time that the version is retired, which is not announced if it is zero.
+	Sunset time.Time
+
+	// Link is the url of migration document, which is sent in header "Link" with relation "deprecation".
+	Link string
+
+	// RejectAfterSunset responds status 410 Gone for the requests of the version after Sunset.
+	RejectAfterSunset bool
+}
+
+// versionRegistry is the registry of API versions of server.
+type versionRegistry struct {
+	mu           sync.RWMutex
+	mounts       map[string]map[string]struct{} // Group prefix to the versions registered under it.
+	deprecations map[string]*VersionDeprecation // Version to its deprecation.
+}
+
+const (
+	defaultApiVersionHeader             = "Api-Version"
+	ctxKeyForApiVersion     gctx.StrKey = "gHttpApiVersion"
+)
+
+// newVersionRegistry creates and returns a new versionRegistry.
+func newVersionRegistry() *versionRegistry {
+	return &versionRegistry{
+		mounts:       make(map[string]map[string]struct{}),
+		deprecations: make(map[string]*VersionDeprecation),
+	}
+}
+
+// Version creates and returns a subgroup of the current router group, whose routes are registered under
+// API `version` like "v2". The routes are requested by the version in path like "/v2/user", or by the
+// version negotiated by header "Api-Version: v2", or media type like "application/vnd.example.v2+json"
+// and "application/json; version=v2" in header "Accept".
+//
+// The deprecation of version is announced by the response headers, see Server.DeprecateVersion.
+func (g *RouterGroup) Version(version string, groups ...func(group *RouterGroup)) *RouterGroup {
+	version = strings.Trim(version, "/")
+	g.server.versions.mount(g.getPrefix(), version)
+	group := g.Group("/" + version)
+	group.middleware = append(group.middleware, g.server.newVersionMiddleware(version))
+	for _, v := range groups {
+		v(group)
+	}
+	return group
+}
+
+// DeprecateVersion marks API `version` deprecated, so that the responses of its routes contain headers
+// "Deprecation", "Sunset" and "Link" as configured by optional parameter `deprecation`.
+func (s *Server) DeprecateVersion(version string, deprecation ...VersionDeprecation) {
+	var d VersionDeprecation
+	if len(deprecation) > 0 {
+		d = deprecation[0]
+	}
+	if d.At.IsZero() {
+		d.At = time.Now()
+	}
+	s.versions.mu.Lock()
+	defer s.versions.mu.Unlock()
+	s.versions.deprecations[version] = &d
+}
+
+// GetApiVersion returns the API version of the route serving current request,
+// or empty if the route is not registered by RouterGroup.Version.
+func (r *Request) GetApiVersion() string {
+	return r.GetCtxVar(ctxKeyForApiVersion).String()
+}
+
+// mount registers `version` under group `prefix`.
+func (v *versionRegistry) mount(prefix, version string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.mounts[prefix] == nil {
+		v.mounts[prefix] = make(map[string]struct{})
+	}
+	v.mounts[prefix][version] = struct{}{}
+}
+
+// deprecation returns the deprecation of `version`, or nil if it is not deprecated.
+func (v *versionRegistry) deprecation(version string) *VersionDeprecation {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.deprecations[version]
+}
+
+// resolve returns the path of `path` with the negotiated `version`, which inserts the version after the
+// longest group prefix registering the version. It returns empty if the path does not need rewriting.
+func (v *versionRegistry) resolve(path, version string) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	var (
+		matchedPrefix string
+		matched       bool
+	)
+	for prefix, versions := range v.mounts {
+		if _, ok := versions[version]; !ok {
+			continue
+		}
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		// The version in path has priority over the negotiated one.
+		segment := strings.SplitN(strings.TrimPrefix(path[len(prefix):], "/"), "/", 2)[0]
+		if _, ok := versions[segment]; ok {
+			return ""
+		}
+		if !matched || len(prefix) > len(matchedPrefix) {
+			matchedPrefix, matched = prefix, true
+		}
+	}
+	if !matched {
+		return ""
+	}
+	return matchedPrefix + "/" + version + path[len(matchedPrefix):]
+}
+
+// negotiateApiVersion rewrites the path of request `r` with the API version negotiated by header
+// or media type, so that the request is served by the routes of the version.
+// It returns the original path if it is rewritten, or else empty.
+func (s *Server) negotiateApiVersion(r *http.Request) (unversionedPath string) {
+	s.versions.mu.RLock()
+	noVersion := len(s.versions.mounts) == 0
+	s.versions.mu.RUnlock()
+	if noVersion {
+		return ""
+	}
+	version := s.requestedApiVersion(r)
+	if version == "" {
+		return ""
+	}
+	if path := s.versions.resolve(r.URL.Path, version); path != "" {
+		unversionedPath = r.URL.Path
+		r.URL.Path = path
+		r.URL.RawPath = ""
+	}
+	return unversionedPath
+}
+
+// requestedApiVersion returns the API version requested by header or media type of `r`.
+func (s *Server) requestedApiVersion(r *http.Request) string {
+	var headerName = s.config.ApiVersionHeader
+	if headerName == "" {
+		headerName = defaultApiVersionHeader
+	}
+	if version := r.Header.Get(headerName); version != "" {
+		return version
+	}
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return ""
+	}
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		if version := params["version"]; version != "" {
+			return version
+		}
+		// Vendor media type like: application/vnd.example.v2+json
+		if match, _ := gregex.MatchString(`^[\w\-]+/vnd\.(?:[\w\-]+\.)+(v\d+)(?:\+[\w\-]+)?$`, mediaType); len(match) > 1 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// newVersionMiddleware creates and returns the middleware of routes of API `version`, which marks the
+// version of request, announces the deprecation and records the metrics.
+func (s *Server) newVersionMiddleware(version string) HandlerFunc {
+	return func(r *Request) {
+		r.SetCtxVar(ctxKeyForApiVersion, version)
+		deprecation := s.versions.deprecation(version)
+		s.handleMetricsVersionRequest(r, version, deprecation != nil)
+		if deprecation != nil {
+			header := r.Response.Header()
+			header.Set("Deprecation", fmt.Sprintf("@%d", deprecation.At.Unix()))
+			if !deprecation.Sunset.IsZero() {
+				header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				header.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, deprecation.Link))
+			}
+			if deprecation.RejectAfterSunset && !deprecation.Sunset.IsZero() && time.Now().After(deprecation.Sunset) {
+				r.Response.WriteStatus(http.StatusGone)
+				return
+			}
+		}
+		r.Middleware.Next()
+	}
+}
@@ -0,0 +1,463 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// WebSocketHub manages the websocket connections of endpoints, which provides room membership,
+// broadcasting, and per-connection send queues with backpressure. It is safe for concurrent use.
+//
+//	hub := ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+//		OnMessage: func(conn *ghttp.WebSocketConn, msgType int, data []byte) {
+//			conn.Hub().BroadcastToRoom("chat", msgType, data)
+//		},
+//	})
+//	s.BindHandler("/ws", hub.Handle)
+type WebSocketHub struct {
+	config WebSocketHubConfig
+	mu     sync.RWMutex
+	conns  map[*WebSocketConn]struct{}            // All connections.
+	rooms  map[string]map[*WebSocketConn]struct{} // Connections by room.
+	closed bool
+}
+
+// WebSocketHubConfig is the configuration of WebSocketHub.
+type WebSocketHubConfig struct {
+	// SendQueueSize is the size of send queue of each connection, which is 256 in default.
+	SendQueueSize int
+
+	// SendTimeout is the time waiting for the send queue if it is full, after which the connection
+	// is considered as a slow consumer and closed. It does not wait in default.
+	SendTimeout time.Duration
+
+	// WriteTimeout is the timeout writing a message to connection, which is 10 seconds in default.
+	WriteTimeout time.Duration
+
+	// PingInterval is the interval of heartbeat pings, which is 30 seconds in default,
+	// and the heartbeat is disabled if it is < 0.
+	PingInterval time.Duration
+
+	// PongTimeout is the timeout waiting for the pong or any message from client,
+	// which is twice of PingInterval in default. The connection is closed if timeout.
+	PongTimeout time.Duration
+
+	// MaxMessageSize is the max size of message read from client, which is unlimited if it is 0.
+	MaxMessageSize int64
+
+	// OnConnect is called after the connection is established and before it starts sending,
+	// in which the connection can join rooms.
+	OnConnect func(conn *WebSocketConn)
+
+	// OnMessage is called for each message received from connection in its reading goroutine.
+	OnMessage func(conn *WebSocketConn, msgType int, data []byte)
+
+	// OnDisconnect is called after the connection is closed and removed from hub, in which `err`
+	// is the reason of closing, which is nil if the connection is closed normally.
+	OnDisconnect func(conn *WebSocketConn, err error)
+}
+
+// WebSocketConn is a websocket connection managed by WebSocketHub.
+type WebSocketConn struct {
+	Id        string          // Unique id of connection.
+	Request   *Request        // Request upgraded to the connection.
+	conn      *websocket.Conn // Underlying websocket connection.
+	hub       *WebSocketHub
+	rooms     map[string]struct{} // Rooms joined, guarded by mutex of hub.
+	sendQueue chan webSocketMessage
+	done      chan struct{} // Closed when the connection is closed.
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// webSocketMessage is a message in the send queue.
+type webSocketMessage struct {
+	msgType int
+	data    []byte
+}
+
+const (
+	defaultWebSocketSendQueueSize = 256
+	defaultWebSocketWriteTimeout  = 10 * time.Second
+	defaultWebSocketPingInterval  = 30 * time.Second
+)
+
+var (
+	// ErrWebSocketClosed is returned when sending to a closed connection.
+	ErrWebSocketClosed = gerror.NewWithOption(gerror.Option{
+		Text: "websocket connection is closed",
+		Code: gcode.CodeInvalidOperation,
+	})
+
+	// ErrWebSocketSlowConsumer is the reason of closing the connection whose send queue is full.
+	ErrWebSocketSlowConsumer = gerror.NewWithOption(gerror.Option{
+		Text: "send queue of websocket connection is full",
+		Code: gcode.CodeOperationFailed,
+	})
+)
+
+// NewWebSocketHub creates and returns a new WebSocketHub.
+func NewWebSocketHub(config ...WebSocketHubConfig) *WebSocketHub {
+	var c WebSocketHubConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.SendQueueSize <= 0 {
+		c.SendQueueSize = defaultWebSocketSendQueueSize
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = defaultWebSocketWriteTimeout
+	}
+	if c.PingInterval == 0 {
+		c.PingInterval = defaultWebSocketPingInterval
+	}
+	if c.PongTimeout <= 0 && c.PingInterval > 0 {
+		c.PongTimeout = 2 * c.PingInterval
+	}
+	return &WebSocketHub{
+		config: c,
+		conns:  make(map[*WebSocketConn]struct{}),
+		rooms:  make(map[string]map[*WebSocketConn]struct{}),
+	}
+}
+
+// Handle upgrades the request as a websocket connection and manages it in hub,
+// which blocks until the connection is closed. It can be bound as the handler of endpoint directly.
+func (h *WebSocketHub) Handle(r *Request) {
+	if h.isClosed() {
+		r.Response.WriteStatus(http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := wsUpGrader.Upgrade(r.Response.Writer, r.Request, nil)
+	if err != nil {
+		// The upgrader has responded the error.
+		return
+	}
+	c := &WebSocketConn{
+		Id:        guid.S(),
+		Request:   r,
+		conn:      conn,
+		hub:       h,
+		rooms:     make(map[string]struct{}),
+		sendQueue: make(chan webSocketMessage, h.config.SendQueueSize),
+		done:      make(chan struct{}),
+	}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		_ = conn.Close()
+		return
+	}
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	if h.config.OnConnect != nil {
+		h.config.OnConnect(c)
+	}
+	go c.writeLoop()
+	c.readLoop()
+
+	h.remove(c)
+	if h.config.OnDisconnect != nil {
+		h.config.OnDisconnect(c, c.closeErr)
+	}
+}
+
+// Broadcast sends the message to all connections, and returns the count of connections sent to.
+func (h *WebSocketHub) Broadcast(msgType int, data []byte) int {
+	h.mu.RLock()
+	conns := make([]*WebSocketConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.RUnlock()
+	return sendToConns(conns, msgType, data)
+}
+
+// BroadcastToRoom sends the message to the connections of `room`,
+// and returns the count of connections sent to.
+func (h *WebSocketHub) BroadcastToRoom(room string, msgType int, data []byte) int {
+	h.mu.RLock()
+	conns := make([]*WebSocketConn, 0, len(h.rooms[room]))
+	for c := range h.rooms[room] {
+		conns = append(conns, c)
+	}
+	h.mu.RUnlock()
+	return sendToConns(conns, msgType, data)
+}
+
+// Count returns the count of connections.
+func (h *WebSocketHub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// RoomCount returns the count of connections of `room`.
+func (h *WebSocketHub) RoomCount(room string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.rooms[room])
+}
+
+// Rooms returns the sorted names of rooms having connections.
+func (h *WebSocketHub) Rooms() []string {
+	h.mu.RLock()
+	rooms := make([]string, 0, len(h.rooms))
+	for room := range h.rooms {
+		rooms = append(rooms, room)
+	}
+	h.mu.RUnlock()
+	sort.Strings(rooms)
+	return rooms
+}
+
+// Close closes all connections, after which no more connection is accepted.
+func (h *WebSocketHub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	conns := make([]*WebSocketConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+// isClosed checks whether the hub is closed.
+func (h *WebSocketHub) isClosed() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.closed
+}
+
+// remove removes the connection from hub and all its rooms.
+func (h *WebSocketHub) remove(c *WebSocketConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.conns, c)
+	for room := range c.rooms {
+		h.leave(c, room)
+	}
+}
+
+// leave removes the connection from `room` without lock.
+func (h *WebSocketHub) leave(c *WebSocketConn, room string) {
+	delete(c.rooms, room)
+	if members, ok := h.rooms[room]; ok {
+		delete(members, c)
+		if len(members) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+}
+
+// sendToConns sends the message to `conns`, and returns the count of connections sent to.
+func sendToConns(conns []*WebSocketConn, msgType int, data []byte) int {
+	var count int
+	for _, c := range conns {
+		if c.Send(msgType, data) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Hub returns the hub managing the connection.
+func (c *WebSocketConn) Hub() *WebSocketHub {
+	return c.hub
+}
+
+// Conn returns the underlying websocket connection, which should not be written directly.
+func (c *WebSocketConn) Conn() *websocket.Conn {
+	return c.conn
+}
+
+// Join joins the connection to `room`.
+func (c *WebSocketConn) Join(room string) {
+	h := c.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// The closed connection is removed from hub.
+	if _, ok := h.conns[c]; !ok {
+		return
+	}
+	c.rooms[room] = struct{}{}
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*WebSocketConn]struct{})
+	}
+	h.rooms[room][c] = struct{}{}
+}
+
+// Leave removes the connection from `room`.
+func (c *WebSocketConn) Leave(room string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	c.hub.leave(c, room)
+}
+
+// Rooms returns the sorted names of rooms that the connection joins.
+func (c *WebSocketConn) Rooms() []string {
+	c.hub.mu.RLock()
+	rooms := make([]string, 0, len(c.rooms))
+	for room := range c.rooms {
+		rooms = append(rooms, room)
+	}
+	c.hub.mu.RUnlock()
+	sort.Strings(rooms)
+	return rooms
+}
+
+// Send puts the message in the send queue of connection. If the queue is full, it waits for
+// SendTimeout of hub, after which the connection is closed with ErrWebSocketSlowConsumer.
+func (c *WebSocketConn) Send(msgType int, data []byte) error {
+	message := webSocketMessage{msgType: msgType, data: data}
+	select {
+	case <-c.done:
+		return ErrWebSocketClosed
+	default:
+	}
+	select {
+	case c.sendQueue <- message:
+		return nil
+	default:
+	}
+	if timeout := c.hub.config.SendTimeout; timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case c.sendQueue <- message:
+			return nil
+		case <-c.done:
+			return ErrWebSocketClosed
+		case <-timer.C:
+		}
+	}
+	c.closeWithError(ErrWebSocketSlowConsumer)
+	return ErrWebSocketSlowConsumer
+}
+
+// SendText puts the text message in the send queue of connection.
+func (c *WebSocketConn) SendText(text string) error {
+	return c.Send(websocket.TextMessage, []byte(text))
+}
+
+// SendJSON encodes `v` as JSON and puts it as text message in the send queue of connection.
+func (c *WebSocketConn) SendJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return gerror.Wrap(err, `encode websocket message as JSON failed`)
+	}
+	return c.Send(websocket.TextMessage, data)
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (c *WebSocketConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the connection normally with a close message.
+func (c *WebSocketConn) Close() {
+	c.closeWithError(nil)
+}
+
+// closeWithError closes the connection with reason `err`.
+func (c *WebSocketConn) closeWithError(err error) {
+	c.closeOnce.Do(func() {
+		c.closeErr = err
+		close(c.done)
+		closeCode, closeText := websocket.CloseNormalClosure, ""
+		if err != nil {
+			closeCode, closeText = websocket.ClosePolicyViolation, err.Error()
+		}
+		_ = c.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(closeCode, closeText),
+			time.Now().Add(c.hub.config.WriteTimeout),
+		)
+		_ = c.conn.Close()
+	})
+}
+
+// readLoop reads the messages from client until the connection is closed.
+func (c *WebSocketConn) readLoop() {
+	config := c.hub.config
+	if config.MaxMessageSize > 0 {
+		c.conn.SetReadLimit(config.MaxMessageSize)
+	}
+	extendReadDeadline := func() {
+		if config.PongTimeout > 0 {
+			_ = c.conn.SetReadDeadline(time.Now().Add(config.PongTimeout))
+		}
+	}
+	extendReadDeadline()
+	c.conn.SetPongHandler(func(string) error {
+		extendReadDeadline()
+		return nil
+	})
+	for {
+		msgType, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				err = nil
+			}
+			select {
+			case <-c.done:
+				// It is closed by server with its reason.
+			default:
+				c.closeWithError(err)
+			}
+			return
+		}
+		extendReadDeadline()
+		if config.OnMessage != nil {
+			config.OnMessage(c, msgType, data)
+		}
+	}
+}
+
+// writeLoop writes the messages in send queue and the heartbeat pings until the connection is closed.
+func (c *WebSocketConn) writeLoop() {
+	config := c.hub.config
+	var heartbeat <-chan time.Time
+	if config.PingInterval > 0 {
+		ticker := time.NewTicker(config.PingInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	for {
+		select {
+		case <-c.done:
+			return
+		case message := <-c.sendQueue:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(config.WriteTimeout))
+			if err := c.conn.WriteMessage(message.msgType, message.data); err != nil {
+				c.closeWithError(gerror.Wrap(err, `write websocket message failed`))
+				return
+			}
+		case <-heartbeat:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(config.WriteTimeout))
+			if err != nil {
+				c.closeWithError(gerror.Wrap(err, `write websocket ping failed`))
+				return
+			}
+		}
+	}
+}
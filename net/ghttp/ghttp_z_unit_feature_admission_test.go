@@ -0,0 +1,146 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_Admission_Reject(t *testing.T) {
+	var (
+		entered = make(chan struct{}, 1)
+		unblock = make(chan struct{})
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		entered <- struct{}{}
+		<-unblock
+		r.Response.Write("slow")
+	})
+	s.BindHandler("/fast", func(r *ghttp.Request) {
+		r.Response.Write("fast")
+	})
+	s.SetMaxConcurrentRequests(1)
+	s.SetRequestQueueTimeout(2 * time.Second)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.Assert(client.GetContent(ctx, "/slow"), "slow")
+		}()
+		<-entered
+
+		resp, err := client.Get(ctx, "/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.Header.Get("Retry-After"), "2")
+		resp.Close()
+
+		close(unblock)
+		wg.Wait()
+		t.Assert(client.GetContent(ctx, "/fast"), "fast")
+	})
+}
+
+func Test_Server_Admission_Queue(t *testing.T) {
+	var (
+		entered  = make(chan struct{}, 1)
+		unblock  = make(chan struct{})
+		unblock2 = make(chan struct{})
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		entered <- struct{}{}
+		<-unblock
+		r.Response.Write("slow")
+	})
+	s.BindHandler("/slow2", func(r *ghttp.Request) {
+		entered <- struct{}{}
+		<-unblock2
+	})
+	s.BindHandler("/fast", func(r *ghttp.Request) {
+		r.Response.Write("fast")
+	})
+	s.SetMaxConcurrentRequests(1)
+	s.SetMaxQueuedRequests(1)
+	s.SetRequestQueueTimeout(time.Second)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			t.Assert(client.GetContent(ctx, "/slow"), "slow")
+		}()
+		<-entered
+
+		// Queued and served after the slow request is done.
+		go func() {
+			defer wg.Done()
+			t.Assert(client.GetContent(ctx, "/fast"), "fast")
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		// The queue of route is full.
+		resp, err := client.Get(ctx, "/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+
+		close(unblock)
+		wg.Wait()
+	})
+	// Queue timeout.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetContent(ctx, "/slow2")
+		}()
+		<-entered
+
+		start := time.Now()
+		resp, err := client.Get(ctx, "/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.Header.Get("Retry-After"), "1")
+		t.Assert(time.Since(start) >= time.Second, true)
+		resp.Close()
+
+		close(unblock2)
+		wg.Wait()
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_Drain(t *testing.T) {
+	var hooks = garray.NewStrArray(true)
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(500 * time.Millisecond)
+		r.Response.Write("done")
+	})
+	s.AddDrainHook(func(ctx context.Context) {
+		hooks.Append("deregister")
+	})
+	s.AddDrainHook(func(ctx context.Context) {
+		panic("hook panic")
+	})
+	s.AddDrainHook(func(ctx context.Context) {
+		hooks.Append("notify")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		var result = make(chan string, 1)
+		go func() {
+			result <- g.Client().Prefix(prefix).GetContent(ctx, "/slow")
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		drained, err := s.Drain(ctx)
+		t.AssertNil(err)
+		t.Assert(drained.Drained, 1)
+		t.Assert(drained.Aborted, 0)
+		t.Assert(drained.Connections, 0)
+		t.Assert(hooks.Slice(), g.SliceStr{"deregister", "notify"})
+		t.Assert(<-result, "done")
+
+		// It does not accept new connections.
+		t.Assert(g.Client().Prefix(prefix).GetContent(ctx, "/slow"), "")
+	})
+}
+
+func Test_Server_Drain_Deadline(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(2 * time.Second)
+		r.Response.Write("done")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		var result = make(chan string, 1)
+		go func() {
+			result <- g.Client().Prefix(prefix).GetContent(ctx, "/slow")
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		drained, err := s.Drain(timeoutCtx)
+		t.AssertNE(err, nil)
+		t.Assert(drained.Drained, 0)
+		t.Assert(drained.Aborted, 1)
+		t.Assert(drained.Connections, 1)
+		t.Assert(<-result, "")
+	})
+}
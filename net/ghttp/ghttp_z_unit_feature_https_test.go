@@ -9,10 +9,17 @@ package ghttp_test
 import (
 	_ "github.com/gogf/gf/v2/net/ghttp/testdata/https/packed"
 
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/net/gtcp"
@@ -127,3 +134,96 @@ func Test_HTTPS_HTTP_Basic(t *testing.T) {
 		t.Assert(c.GetContent(ctx, "/test"), "test")
 	})
 }
+
+// testHTTP3Server is the HTTP/3 server for testing, which serves nothing.
+type testHTTP3Server struct {
+	handler   http.Handler
+	tlsConfig *tls.Config
+	served    chan net.PacketConn
+	shutdown  *gtype.Bool
+	done      chan struct{}
+	once      sync.Once
+}
+
+func (s *testHTTP3Server) Serve(conn net.PacketConn) error {
+	s.served <- conn
+	<-s.done
+	return http.ErrServerClosed
+}
+
+func (s *testHTTP3Server) Shutdown(ctx context.Context) error {
+	s.shutdown.Set(true)
+	return s.Close()
+}
+
+func (s *testHTTP3Server) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+func Test_HTTPS_HTTP3(t *testing.T) {
+	var servers = make(chan *testHTTP3Server, 1)
+	ghttp.RegisterHTTP3Server(func(handler http.Handler, tlsConfig *tls.Config) ghttp.HTTP3Server {
+		server := &testHTTP3Server{
+			handler:   handler,
+			tlsConfig: tlsConfig,
+			served:    make(chan net.PacketConn, 1),
+			shutdown:  gtype.NewBool(),
+			done:      make(chan struct{}),
+		}
+		servers <- server
+		return server
+	})
+	defer ghttp.RegisterHTTP3Server(nil)
+
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.GET("/test", func(r *ghttp.Request) {
+			r.Response.Write("test")
+		})
+	})
+	s.EnableHTTPS(
+		gtest.DataPath("https", "files", "server.crt"),
+		gtest.DataPath("https", "files", "server.key"),
+	)
+	s.EnableHTTP3(true)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	var server *testHTTP3Server
+	gtest.C(t, func(t *gtest.T) {
+		select {
+		case server = <-servers:
+		case <-time.After(time.Second):
+			t.Fatal("HTTP/3 server is not created")
+		}
+		t.Assert(server.tlsConfig.GetCertificate != nil, true)
+		conn := <-server.served
+		t.Assert(conn.LocalAddr().(*net.UDPAddr).Port, s.GetListenedPort())
+
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := c.Get(ctx, "/test")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "test")
+		t.Assert(resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%d"; ma=86400`, s.GetListenedPort()))
+
+		// The handler of HTTP/3 server is the same as HTTPS.
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/test", nil)
+		)
+		request.Proto, request.ProtoMajor, request.ProtoMinor = "HTTP/3.0", 3, 0
+		server.handler.ServeHTTP(recorder, request)
+		t.Assert(recorder.Body.String(), "test")
+		t.Assert(recorder.Header().Get("Alt-Svc"), "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(s.Shutdown())
+		t.Assert(server.shutdown.Val(), true)
+	})
+}
@@ -9,7 +9,10 @@
 package ghttp_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -62,3 +65,202 @@ func Test_Log(t *testing.T) {
 		t.Assert(gstr.Contains(gfile.GetContents(logPath3), "custom error"), true)
 	})
 }
+
+type testAccessLogSink struct {
+	mu       sync.Mutex
+	contents []string
+}
+
+func (s *testAccessLogSink) Write(ctx context.Context, entry *ghttp.AccessLogEntry, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contents = append(s.contents, string(content))
+	return nil
+}
+
+func (s *testAccessLogSink) Contents() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.contents...)
+}
+
+type testAccessLogProducer struct {
+	messages chan string
+}
+
+func (p *testAccessLogProducer) Produce(ctx context.Context, topic string, key, value []byte) error {
+	p.messages <- fmt.Sprintf("%s:%t:%s", topic, len(key) > 0, value)
+	return nil
+}
+
+func Test_Log_AccessLogPipeline(t *testing.T) {
+	var (
+		sink     = &testAccessLogSink{}
+		producer = &testAccessLogProducer{messages: make(chan string, 10)}
+		s        = g.Server(guid.S())
+	)
+	s.BindHandler("/login", func(r *ghttp.Request) {
+		r.Response.WriteJson(g.Map{
+			"user":  r.Get("user"),
+			"token": "abcdefg",
+		})
+	})
+	s.BindHandler("/hello", func(r *ghttp.Request) {
+		r.Response.Write("hello")
+	})
+	s.SetAccessLogEnabled(true)
+	s.SetAccessLogConfig(ghttp.AccessLogConfig{
+		Format:              ghttp.AccessLogFormatJson,
+		Fields:              []string{"status", "method", "uri", "requestBody", "responseBody"},
+		CaptureRequestBody:  true,
+		CaptureResponseBody: true,
+		MaxBodySize:         40,
+		Sampling:            map[string]float64{"2xx": 1, "4xx": 0},
+		Sinks: []ghttp.AccessLogSink{
+			sink,
+			ghttp.NewAccessLogKafkaSink(producer, "access"),
+		},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(
+			client.ContentJson().PostContent(ctx, "/login", g.Map{"user": "john", "password": "123456"}),
+			`{"token":"abcdefg","user":"john"}`,
+		)
+		// The status class "4xx" is sampled out.
+		t.Assert(client.GetContent(ctx, "/none"), "Not Found")
+		t.Assert(client.GetContent(ctx, "/hello?name=john"), "hello")
+
+		time.Sleep(100 * time.Millisecond)
+		contents := sink.Contents()
+		t.Assert(len(contents), 2)
+		t.Assert(
+			contents[0],
+			`{"status":200,"method":"POST","uri":"/login","requestBody":"{\"password\":\"******\",\"user\":\"john\"}","responseBody":"{\"token\":\"******\",\"user\":\"john\"}"}`,
+		)
+		t.Assert(
+			contents[1],
+			`{"status":200,"method":"GET","uri":"/hello?name=john","requestBody":"","responseBody":"hello"}`,
+		)
+		t.Assert(<-producer.messages, "access:true:"+contents[0])
+	})
+}
+
+func Test_Log_AccessLogOmitBody(t *testing.T) {
+	var (
+		sink = &testAccessLogSink{}
+		s    = g.Server(guid.S())
+	)
+	s.BindHandler("/echo", func(r *ghttp.Request) {
+		r.Response.Write(r.GetBody())
+	})
+	s.BindHandler("/ignore", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	s.SetAccessLogEnabled(true)
+	s.SetAccessLogConfig(ghttp.AccessLogConfig{
+		Format:              ghttp.AccessLogFormatJson,
+		Fields:              []string{"uri", "requestBody", "responseBody"},
+		CaptureRequestBody:  true,
+		CaptureResponseBody: true,
+		MaxBodySize:         20,
+		Sinks:               []ghttp.AccessLogSink{sink},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Unparseable JSON.
+		t.Assert(client.PostContent(ctx, "/echo", `{"password":"123456"`), `{"password":"123456"`)
+		// The request body read by handler is complete, which is redacted before truncating,
+		// and the one not read by handler is truncated in capturing.
+		long := fmt.Sprintf(`{"user":"%s","password":"123456"}`, strings.Repeat("a", 40))
+		t.Assert(client.ContentJson().PostContent(ctx, "/echo", long), long)
+		t.Assert(client.ContentJson().PostContent(ctx, "/ignore", long), "ok")
+
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(sink.Contents(), []string{
+			`{"uri":"/echo","requestBody":"[body omitted]","responseBody":"[body omitted]"}`,
+			`{"uri":"/echo","requestBody":"{\"password\":\"******\"...","responseBody":"[body omitted]"}`,
+			`{"uri":"/ignore","requestBody":"[body omitted]","responseBody":"ok"}`,
+		})
+	})
+}
+
+func Test_Log_AccessLogConfigChange(t *testing.T) {
+	var (
+		sink1 = &testAccessLogSink{}
+		sink2 = &testAccessLogSink{}
+		s     = g.Server(guid.S())
+	)
+	s.BindHandler("/hello", func(r *ghttp.Request) {
+		r.Response.Write("hello")
+	})
+	s.SetAccessLogEnabled(true)
+	s.SetAccessLogConfig(ghttp.AccessLogConfig{
+		Encoder: ghttp.NewAccessLogJsonEncoder("uri"),
+		Sinks:   []ghttp.AccessLogSink{sink1},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/hello?n=1"), "hello")
+
+		// The pipeline is rebuilt with the changed configuration.
+		s.SetAccessLogConfig(ghttp.AccessLogConfig{
+			Encoder: ghttp.NewAccessLogJsonEncoder("status", "uri"),
+			Sinks:   []ghttp.AccessLogSink{sink2},
+		})
+		t.Assert(client.GetContent(ctx, "/hello?n=2"), "hello")
+
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(sink1.Contents(), []string{`{"uri":"/hello?n=1"}`})
+		t.Assert(sink2.Contents(), []string{`{"status":200,"uri":"/hello?n=2"}`})
+	})
+}
+
+func Test_Log_AccessLogFileSink(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		logDir := gfile.Temp(guid.S())
+		defer gfile.Remove(logDir)
+		fileSink, err := ghttp.NewAccessLogFileSink(logDir, "access.log")
+		t.AssertNil(err)
+
+		s := g.Server(guid.S())
+		s.BindHandler("/hello", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.SetAccessLogEnabled(true)
+		s.SetAccessLogConfig(ghttp.AccessLogConfig{
+			Encoder: ghttp.NewAccessLogJsonEncoder("status", "uri"),
+			Sinks:   []ghttp.AccessLogSink{fileSink},
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/hello"), "hello")
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(gstr.Trim(gfile.GetContents(gfile.Join(logDir, "access.log"))), `{"status":200,"uri":"/hello"}`)
+	})
+}
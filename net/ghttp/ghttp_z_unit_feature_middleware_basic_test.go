@@ -19,6 +19,8 @@ import (
 	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -826,3 +828,42 @@ var _ trace.TracerProvider = &testTracerProvider{}
 func (*testTracerProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
 	return noop.NewTracerProvider().Tracer("")
 }
+
+func Test_MiddlewareHandlerResponse_CodeRegistry(t *testing.T) {
+	var (
+		codeUserNotFound = gcode.Register(gcode.New(20001, "", nil), gcode.Registration{
+			HttpStatus: http.StatusNotFound,
+			Message:    "user not found",
+		})
+		codeNotRegistered = gcode.New(20002, "Not Registered", nil)
+	)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/registered", func(r *ghttp.Request) {
+			r.SetError(gerror.NewCode(codeUserNotFound))
+		})
+		group.GET("/not-registered", func(r *ghttp.Request) {
+			r.SetError(gerror.NewCode(codeNotRegistered))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		rsp, err := client.Get(ctx, "/registered")
+		t.AssertNil(err)
+		t.Assert(rsp.StatusCode, http.StatusNotFound)
+		t.Assert(rsp.ReadAllString(), `{"code":20001,"message":"user not found","data":null}`)
+
+		rsp, err = client.Get(ctx, "/not-registered")
+		t.AssertNil(err)
+		t.Assert(rsp.StatusCode, http.StatusOK)
+		t.Assert(rsp.ReadAllString(), `{"code":20002,"message":"Not Registered","data":null}`)
+	})
+}
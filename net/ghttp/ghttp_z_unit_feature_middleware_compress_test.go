@@ -0,0 +1,135 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Compress(t *testing.T) {
+	var content = gstr.Repeat("hello world ", 200)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareETag(), ghttp.MiddlewareCompress())
+		group.GET("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+		group.GET("/json", func(r *ghttp.Request) {
+			r.Response.SetETag("v1")
+			r.Response.WriteJson(g.Map{"content": content})
+		})
+		group.GET("/small", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		group.GET("/binary", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "application/octet-stream")
+			r.Response.Write(content)
+		})
+	})
+	s.Group("/custom", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompress(ghttp.CompressConfig{
+			Encodings:    []string{"deflate", "gzip"},
+			MinSize:      1,
+			ContentTypes: []string{"application/*"},
+		}))
+		group.GET("/small", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "application/octet-stream")
+			r.Response.Write(gstr.Repeat("a", 100))
+		})
+		group.GET("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"Accept-Encoding": "deflate;q=0.5, gzip"})
+
+		resp, err := client.Get(ctx, "/text")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.AssertNE(resp.Header.Get("ETag"), "")
+		reader, err := gzip.NewReader(resp.Body)
+		t.AssertNil(err)
+		b, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(string(b), content)
+		resp.Close()
+
+		// The strong ETag of handler becomes weak.
+		resp, err = client.Get(ctx, "/json")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("ETag"), `W/"v1"`)
+		resp.Close()
+
+		// Less than the min size.
+		resp, err = client.Get(ctx, "/small")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), "hello")
+		resp.Close()
+
+		// Content type not allowed.
+		resp, err = client.Get(ctx, "/binary")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Not accepted.
+		resp, err := g.Client().Prefix(prefix).Header(g.MapStrStr{
+			"Accept-Encoding": "identity",
+		}).Get(ctx, "/text")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+
+		resp, err = g.Client().Prefix(prefix).Header(g.MapStrStr{
+			"Accept-Encoding": "gzip;q=0, *",
+		}).Get(ctx, "/text")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"Accept-Encoding": "gzip, deflate"})
+
+		// Server preference.
+		resp, err := client.Get(ctx, "/custom/small")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		b, err := io.ReadAll(flate.NewReader(resp.Body))
+		t.AssertNil(err)
+		t.Assert(string(b), gstr.Repeat("a", 100))
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/custom/text")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		resp.Close()
+	})
+}
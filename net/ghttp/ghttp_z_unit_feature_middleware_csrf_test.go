@@ -0,0 +1,171 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Csrf_DoubleSubmit(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCsrf(ghttp.CsrfConfig{
+			ExemptPaths: []string{"/webhook/*"},
+		}))
+		group.GET("/token", func(r *ghttp.Request) {
+			r.Response.Write(r.GetCsrfToken())
+		})
+		group.GET("/form", func(r *ghttp.Request) {
+			r.Response.WriteTplContent(`<form>{{.CsrfField}}</form>`)
+		})
+		group.POST("/submit", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+		group.GET("/signin", func(r *ghttp.Request) {
+			if err := r.Session.Set("user", "john"); err != nil {
+				r.Response.WriteStatus(http.StatusInternalServerError)
+				return
+			}
+			r.Response.WriteTplContent(`<form>{{.CsrfField}}</form>`)
+		})
+		group.POST("/webhook/github", func(r *ghttp.Request) {
+			r.Response.Write("hook")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+
+		resp, err := client.Get(ctx, "/token")
+		t.AssertNil(err)
+		token := resp.ReadAllString()
+		t.AssertNE(token, "")
+		var cookie *http.Cookie
+		for _, c := range resp.Cookies() {
+			if c.Name == "_csrf" {
+				cookie = c
+			}
+		}
+		resp.Close()
+		t.AssertNE(cookie, nil)
+		t.Assert(cookie.Value, token)
+		t.Assert(cookie.HttpOnly, false)
+		t.Assert(cookie.SameSite, http.SameSiteLaxMode)
+
+		// The token is kept for the client.
+		client = client.Cookie(g.MapStrStr{"_csrf": token})
+		t.Assert(client.GetContent(ctx, "/token"), token)
+		t.Assert(
+			client.GetContent(ctx, "/form"),
+			fmt.Sprintf(`<form><input type="hidden" name="_csrf" value="%s"></form>`, token),
+		)
+
+		resp, err = client.Post(ctx, "/submit")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		resp, err = client.Post(ctx, "/submit", g.Map{"_csrf": "invalid"})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		t.Assert(client.PostContent(ctx, "/submit", g.Map{"_csrf": token}), "ok")
+		t.Assert(client.Header(g.MapStrStr{"X-CSRF-Token": token}).PostContent(ctx, "/submit"), "ok")
+
+		// The token without cookie is rejected.
+		resp, err = g.Client().Prefix(prefix).Header(g.MapStrStr{"X-CSRF-Token": token}).Post(ctx, "/submit")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		t.Assert(g.Client().Prefix(prefix).PostContent(ctx, "/webhook/github"), "hook")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The forged token cookie is rejected.
+		forged := g.Client().Prefix(prefix).Cookie(g.MapStrStr{"_csrf": "forged"})
+		resp, err := forged.Post(ctx, "/submit", g.Map{"_csrf": "forged"})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+		t.AssertNE(forged.GetContent(ctx, "/token"), "forged")
+
+		// The token is bound to the session of client.
+		var (
+			client1 = g.Client().Prefix(prefix).Cookie(g.MapStrStr{"gfsessionid": "session1"})
+			token   = client1.GetContent(ctx, "/token")
+		)
+		client1 = client1.Cookie(g.MapStrStr{"_csrf": token})
+		t.Assert(client1.PostContent(ctx, "/submit", g.Map{"_csrf": token}), "ok")
+		client2 := g.Client().Prefix(prefix).Cookie(g.MapStrStr{"gfsessionid": "session2", "_csrf": token})
+		resp, err = client2.Post(ctx, "/submit", g.Map{"_csrf": token})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The first visit creates the session, and the token rendered in the form is submitted with it.
+		client := g.Client().Prefix(prefix).SetBrowserMode(true)
+		form := client.GetContent(ctx, "/signin")
+		t.Assert(gstr.HasPrefix(form, `<form><input type="hidden" name="_csrf" value="`), true)
+		token := gstr.TrimRightStr(gstr.TrimLeftStr(form, `<form><input type="hidden" name="_csrf" value="`), `"></form>`)
+		t.AssertNE(token, "")
+		t.Assert(client.PostContent(ctx, "/submit", g.Map{"_csrf": token}), "ok")
+	})
+}
+
+func Test_Middleware_Csrf_Synchronizer(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCsrf(ghttp.CsrfConfig{
+			Mode: ghttp.CsrfModeSynchronizer,
+			ErrorHandler: func(r *ghttp.Request) {
+				r.Response.WriteStatus(http.StatusForbidden, "csrf")
+			},
+		}))
+		group.GET("/token", func(r *ghttp.Request) {
+			r.Response.Write(r.GetCsrfToken())
+		})
+		group.POST("/submit", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).SetBrowserMode(true)
+
+		token := client.GetContent(ctx, "/token")
+		t.AssertNE(token, "")
+		t.Assert(client.GetContent(ctx, "/token"), token)
+		t.Assert(client.PostContent(ctx, "/submit"), "csrf")
+		t.Assert(client.PostContent(ctx, "/submit", g.Map{"_csrf": token}), "ok")
+
+		// The token of another session is rejected.
+		other := g.Client().Prefix(prefix).SetBrowserMode(true)
+		t.AssertNE(other.GetContent(ctx, "/token"), token)
+		t.Assert(other.PostContent(ctx, "/submit", g.Map{"_csrf": token}), "csrf")
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_ETag(t *testing.T) {
+	var lastModified = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareETag())
+		group.ALL("/content", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		group.ALL("/custom", func(r *ghttp.Request) {
+			r.Response.SetETag("v1", true)
+			r.Response.SetLastModified(lastModified)
+			r.Response.Write("custom")
+		})
+		group.ALL("/error", func(r *ghttp.Request) {
+			r.Response.WriteStatus(http.StatusBadRequest, "bad")
+		})
+	})
+	s.Group("/weak", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareETag(ghttp.ETagConfig{Weak: true}))
+		group.ALL("/content", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+	})
+	s.BindHandler("/none", func(r *ghttp.Request) {
+		r.Response.Write("hello")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/content")
+		t.AssertNil(err)
+		etag := resp.Header.Get("ETag")
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(gstr.HasPrefix(etag, `"5-`), true)
+		t.Assert(resp.ReadAllString(), "hello")
+		resp.Close()
+
+		// Not modified.
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Get(ctx, "/content")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		t.Assert(resp.Header.Get("ETag"), etag)
+		t.Assert(resp.ReadAllString(), "")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": `"x", W/` + etag}).Get(ctx, "/content")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		resp.Close()
+
+		// Modified.
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": `"x"`}).Get(ctx, "/content")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "hello")
+		resp.Close()
+
+		// Only for GET and HEAD.
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Post(ctx, "/content")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("ETag"), "")
+		resp.Close()
+
+		// Only for status 200.
+		resp, err = client.Get(ctx, "/error")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadRequest)
+		t.Assert(resp.Header.Get("ETag"), "")
+		resp.Close()
+
+		// Not enabled.
+		resp, err = client.Get(ctx, "/none")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("ETag"), "")
+		resp.Close()
+
+		// Weak.
+		resp, err = client.Get(ctx, "/weak/content")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("ETag"), "W/"+etag)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("ETag"), `W/"v1"`)
+		t.Assert(resp.Header.Get("Last-Modified"), lastModified.Format(http.TimeFormat))
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"If-None-Match": `"v1"`}).Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{
+			"If-Modified-Since": lastModified.Format(http.TimeFormat),
+		}).Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotModified)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{
+			"If-Modified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat),
+		}).Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "custom")
+		resp.Close()
+
+		// If-None-Match takes precedence over If-Modified-Since.
+		resp, err = client.Header(g.MapStrStr{
+			"If-None-Match":     `"v2"`,
+			"If-Modified-Since": lastModified.Format(http.TimeFormat),
+		}).Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		resp.Close()
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Idempotency(t *testing.T) {
+	var (
+		orders = gtype.NewInt()
+		fails  = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareIdempotency())
+		group.POST("/order", func(r *ghttp.Request) {
+			r.Response.Header().Set("X-Order", fmt.Sprint(orders.Add(1)))
+			r.Response.WriteStatus(http.StatusCreated, fmt.Sprintf("order %d", orders.Val()))
+		})
+		group.POST("/login", func(r *ghttp.Request) {
+			r.Response.Header().Add("Set-Cookie", "token="+guid.S())
+			r.Response.Write("login")
+		})
+		group.POST("/slow", func(r *ghttp.Request) {
+			time.Sleep(500 * time.Millisecond)
+			r.Response.Write("done")
+		})
+		group.POST("/fail", func(r *ghttp.Request) {
+			if fails.Add(1) == 1 {
+				r.Response.WriteStatus(http.StatusServiceUnavailable)
+				return
+			}
+			r.Response.Write("ok")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"Idempotency-Key": guid.S()})
+
+		resp, err := client.Post(ctx, "/order", "a=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.Header.Get("X-Order"), "1")
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "")
+		t.Assert(resp.ReadAllString(), "order 1")
+		resp.Close()
+
+		// Replayed.
+		resp, err = client.Post(ctx, "/order", "a=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.Header.Get("X-Order"), "1")
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "true")
+		t.Assert(resp.ReadAllString(), "order 1")
+		resp.Close()
+
+		// Reused for a different request.
+		resp, err = client.Post(ctx, "/order", "a=2")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnprocessableEntity)
+		resp.Close()
+
+		// Without key.
+		t.Assert(g.Client().Prefix(prefix).PostContent(ctx, "/order", "a=1"), "order 2")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = g.Client().Prefix(prefix).Header(g.MapStrStr{"Idempotency-Key": guid.S()})
+			result = make(chan string, 1)
+		)
+		go func() {
+			result <- client.PostContent(ctx, "/slow")
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		// Concurrent duplicate.
+		resp, err := client.Post(ctx, "/slow")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusConflict)
+		resp.Close()
+		t.Assert(<-result, "done")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"Idempotency-Key": guid.S()})
+
+		// Server errors are not stored.
+		resp, err := client.Post(ctx, "/fail")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+		t.Assert(client.PostContent(ctx, "/fail"), "ok")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key    = guid.S()
+			client = g.Client().Prefix(prefix).Header(g.MapStrStr{"Idempotency-Key": key})
+		)
+		// The keys are scoped by caller.
+		resp, err := client.Header(g.MapStrStr{"Authorization": "Bearer user1"}).Post(ctx, "/order", "a=1")
+		t.AssertNil(err)
+		order := resp.Header.Get("X-Order")
+		resp.Close()
+		resp, err = client.Header(g.MapStrStr{"Authorization": "Bearer user2"}).Post(ctx, "/order", "a=1")
+		t.AssertNil(err)
+		t.AssertNE(resp.Header.Get("X-Order"), order)
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "")
+		resp.Close()
+		resp, err = client.Header(g.MapStrStr{"Authorization": "Bearer user1"}).Post(ctx, "/order", "a=1")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("X-Order"), order)
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "true")
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"Idempotency-Key": guid.S()})
+
+		// The cookies are not replayed.
+		resp, err := client.Post(ctx, "/login")
+		t.AssertNil(err)
+		t.AssertNE(resp.Header.Get("Set-Cookie"), "")
+		resp.Close()
+		resp, err = client.Post(ctx, "/login")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "true")
+		t.Assert(resp.Header.Get("Set-Cookie"), "")
+		t.Assert(resp.ReadAllString(), "login")
+		resp.Close()
+	})
+}
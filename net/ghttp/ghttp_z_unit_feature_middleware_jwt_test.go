@@ -0,0 +1,175 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/crypto/gsign"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_JWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicKey, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		secret   = "secret"
+		esKey, _ = gsign.GenerateKey(gsign.AlgorithmES256)
+		esJwk, _ = gsign.EncodeJWK(esKey.Public(), "es-1")
+		newToken = func(algorithm string, key interface{}, issuer string, keyId ...string) string {
+			token, err := gjwt.Sign(&gjwt.Claims{
+				Issuer:    issuer,
+				Subject:   "user-1",
+				Audience:  []string{"api"},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, algorithm, key, keyId...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+		handler = func(r *ghttp.Request) {
+			if claims := r.GetJWTClaims(); claims != nil {
+				r.Response.Write(claims.Subject)
+				return
+			}
+			r.Response.Write("anonymous")
+		}
+	)
+
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"keys":[%s]}`, esJwk)))
+	}))
+	defer jwksServer.Close()
+
+	s := g.Server(guid.S())
+	err = s.SetConfigWithMap(g.Map{
+		"jwt": g.Map{
+			"jwksUrl": jwksServer.URL,
+			"jwksTTL": "5m",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Group("/hs", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareJWT(ghttp.JWTConfig{
+			Secret:   secret,
+			Issuer:   "issuer",
+			Audience: "api",
+			Query:    "token",
+		}))
+		group.GET("/", handler)
+	})
+	s.Group("/rs", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareJWT(ghttp.JWTConfig{
+			PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPublicKey})),
+			Algorithms: []string{gjwt.AlgorithmRS256},
+			Optional:   true,
+		}))
+		group.GET("/", handler)
+	})
+	s.Group("/es", func(group *ghttp.RouterGroup) {
+		// Configured by server configuration.
+		group.Middleware(ghttp.MiddlewareJWT())
+		group.GET("/", handler)
+	})
+	s.Group("/none", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareJWT(ghttp.JWTConfig{}))
+		group.GET("/", handler)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	bearer := func(token string) map[string]string {
+		return g.MapStrStr{"Authorization": "Bearer " + token}
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+		token := newToken(gjwt.AlgorithmHS256, []byte(secret), "issuer")
+		t.Assert(client.Header(bearer(token)).GetContent(ctx, "/hs/"), "user-1")
+		t.Assert(client.GetContent(ctx, "/hs/?token="+token), "user-1")
+
+		resp, err := client.Get(ctx, "/hs/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		t.Assert(resp.Header.Get("WWW-Authenticate"), "Bearer")
+		resp.Close()
+
+		// Invalid signature.
+		resp, err = client.Header(bearer(newToken(gjwt.AlgorithmHS256, []byte("other"), "issuer"))).Get(ctx, "/hs/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+
+		// Unexpected issuer.
+		resp, err = client.Header(bearer(newToken(gjwt.AlgorithmHS256, []byte(secret), "other"))).Get(ctx, "/hs/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+		t.Assert(client.Header(bearer(newToken(gjwt.AlgorithmRS256, rsaKey, ""))).GetContent(ctx, "/rs/"), "user-1")
+		t.Assert(client.GetContent(ctx, "/rs/"), "anonymous")
+
+		// Not accepted algorithm.
+		resp, err := client.Header(bearer(newToken(gjwt.AlgorithmHS256, []byte(secret), ""))).Get(ctx, "/rs/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+		t.Assert(client.Header(bearer(newToken(gjwt.AlgorithmES256, esKey, "", "es-1"))).GetContent(ctx, "/es/"), "user-1")
+
+		resp, err := client.Header(bearer(newToken(gjwt.AlgorithmES256, esKey, "", "unknown"))).Get(ctx, "/es/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+		token := newToken(gjwt.AlgorithmHS256, []byte(secret), "")
+		resp, err := client.Header(bearer(token)).Get(ctx, "/es/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnauthorized)
+		resp.Close()
+
+		// The changed configuration of server takes effect.
+		s.SetJWTConfig(ghttp.JWTConfig{Secret: secret})
+		t.Assert(client.Header(bearer(token)).GetContent(ctx, "/es/"), "user-1")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Get(ctx, "/none/")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusInternalServerError)
+		resp.Close()
+	})
+}
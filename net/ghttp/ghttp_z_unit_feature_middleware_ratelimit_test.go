@@ -0,0 +1,150 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_RateLimit(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Group("/bucket", func(group *ghttp.RouterGroup) {
+			group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+				Limit:  10,
+				Window: time.Second,
+				Burst:  2,
+			}))
+			group.GET("/", func(r *ghttp.Request) {
+				r.Response.Write("ok")
+			})
+		})
+		group.Group("/window", func(group *ghttp.RouterGroup) {
+			group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+				Algorithm: ghttp.RateLimitSlidingWindow,
+				Limit:     2,
+				Window:    time.Second,
+				KeyFunc:   ghttp.RateLimitKeyByHeader("X-Api-Key"),
+				Handler: func(r *ghttp.Request) {
+					r.Response.WriteStatus(http.StatusTooManyRequests, "slow down")
+				},
+			}))
+			group.GET("/", func(r *ghttp.Request) {
+				r.Response.Write("ok")
+			})
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	client := g.Client()
+	client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+	gtest.C(t, func(t *gtest.T) {
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(ctx, "/bucket")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, http.StatusOK)
+			t.Assert(resp.Header.Get("RateLimit-Limit"), "2")
+			t.Assert(resp.Header.Get("RateLimit-Remaining"), 1-i)
+			resp.Close()
+		}
+		resp, err := client.Get(ctx, "/bucket")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusTooManyRequests)
+		t.Assert(resp.Header.Get("RateLimit-Remaining"), "0")
+		t.Assert(resp.Header.Get("Retry-After"), "1")
+		resp.Close()
+
+		// The limit is not bypassed by rotating the forwarded headers.
+		resp, err = client.Header(g.MapStrStr{"X-Forwarded-For": "10.0.0.1", "X-Real-IP": "10.0.0.2"}).Get(ctx, "/bucket")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusTooManyRequests)
+		resp.Close()
+
+		// A token is refilled in 100ms.
+		time.Sleep(120 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, "/bucket"), "ok")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Requests without key are not limited.
+		for i := 0; i < 3; i++ {
+			t.Assert(client.GetContent(ctx, "/window"), "ok")
+		}
+		var (
+			client1 = client.Header(g.MapStrStr{"X-Api-Key": "1"})
+			client2 = client.Header(g.MapStrStr{"X-Api-Key": "2"})
+		)
+		t.Assert(client1.GetContent(ctx, "/window"), "ok")
+		t.Assert(client1.GetContent(ctx, "/window"), "ok")
+		resp, err := client1.Get(ctx, "/window")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusTooManyRequests)
+		t.Assert(resp.ReadAllString(), "slow down")
+		t.Assert(resp.Header.Get("RateLimit-Limit"), "2")
+		t.Assert(resp.Header.Get("Retry-After"), "1")
+		resp.Close()
+		t.Assert(client2.GetContent(ctx, "/window"), "ok")
+	})
+}
+
+func Test_RateLimitStorageMemory(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		storage = ghttp.NewRateLimitStorageMemory()
+	)
+	gtest.C(t, func(t *gtest.T) {
+		rule := ghttp.RateLimitRule{
+			Algorithm: ghttp.RateLimitSlidingWindow,
+			Limit:     2,
+			Window:    200 * time.Millisecond,
+		}
+		result, err := storage.Allow(ctx, "window", rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 1)
+		time.Sleep(100 * time.Millisecond)
+		result, _ = storage.Allow(ctx, "window", rule)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+		result, _ = storage.Allow(ctx, "window", rule)
+		t.Assert(result.Allowed, false)
+		t.AssertLE(result.RetryAfter, 100*time.Millisecond)
+		// The first request slides out of the window.
+		time.Sleep(result.RetryAfter + 10*time.Millisecond)
+		result, _ = storage.Allow(ctx, "window", rule)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		rule := ghttp.RateLimitRule{
+			Algorithm: ghttp.RateLimitTokenBucket,
+			Limit:     10,
+			Window:    time.Second,
+			Burst:     1,
+		}
+		result, err := storage.Allow(ctx, "bucket", rule)
+		t.AssertNil(err)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Limit, 1)
+		t.AssertLE(result.Reset, 100*time.Millisecond)
+		result, _ = storage.Allow(ctx, "bucket", rule)
+		t.Assert(result.Allowed, false)
+		t.AssertGT(result.RetryAfter, 0)
+		t.AssertLE(result.RetryAfter, 100*time.Millisecond)
+	})
+}
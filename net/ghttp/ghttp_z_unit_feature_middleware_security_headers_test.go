@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_SecurityHeaders(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareSecurityHeaders())
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.Group("/nonce", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareSecurityHeaders(ghttp.SecurityHeadersConfig{
+			ContentSecurityPolicy:     "script-src 'self' 'nonce-{nonce}'",
+			HSTSMaxAge:                time.Hour,
+			HSTSPreload:               true,
+			CrossOriginOpenerPolicy:   "same-origin",
+			CrossOriginEmbedderPolicy: "require-corp",
+		}))
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.WriteTplContent(`{{.CspNonce}}`)
+		})
+		group.GET("/ctx", func(r *ghttp.Request) {
+			r.Response.Write(r.GetCspNonce())
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "ok")
+		t.Assert(resp.Header.Get("Content-Security-Policy"), ghttp.DefaultSecurityHeadersConfig().ContentSecurityPolicy)
+		t.Assert(resp.Header.Get("X-Frame-Options"), "DENY")
+		t.Assert(resp.Header.Get("X-Content-Type-Options"), "nosniff")
+		t.Assert(resp.Header.Get("Referrer-Policy"), "strict-origin-when-cross-origin")
+		t.Assert(resp.Header.Get("Cross-Origin-Opener-Policy"), "same-origin")
+		t.Assert(resp.Header.Get("Cross-Origin-Embedder-Policy"), "")
+		// HSTS is only sent over HTTPS.
+		t.Assert(resp.Header.Get("Strict-Transport-Security"), "")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"X-Forwarded-Proto": "https"}).Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Strict-Transport-Security"), "max-age=31536000; includeSubDomains")
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{"X-Forwarded-Proto": "https"})
+
+		resp, err := client.Get(ctx, "/nonce/")
+		t.AssertNil(err)
+		nonce := resp.ReadAllString()
+		t.AssertNE(nonce, "")
+		t.Assert(resp.Header.Get("Content-Security-Policy"), fmt.Sprintf("script-src 'self' 'nonce-%s'", nonce))
+		t.Assert(resp.Header.Get("Strict-Transport-Security"), "max-age=3600; preload")
+		t.Assert(resp.Header.Get("Cross-Origin-Embedder-Policy"), "require-corp")
+		t.Assert(resp.Header.Get("X-Frame-Options"), "")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/nonce/ctx")
+		t.AssertNil(err)
+		nonce2 := resp.ReadAllString()
+		t.AssertNE(nonce2, "")
+		t.AssertNE(nonce2, nonce)
+		t.Assert(resp.Header.Get("Content-Security-Policy"), fmt.Sprintf("script-src 'self' 'nonce-%s'", nonce2))
+		resp.Close()
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Shadow(t *testing.T) {
+	var mirrored = garray.NewStrArray(true)
+	shadow := g.Server(guid.S())
+	shadow.BindHandler("/*", func(r *ghttp.Request) {
+		mirrored.Append(fmt.Sprintf(
+			"%s %s %s %s [%s] [%s] %s %s",
+			r.Method, r.URL.RequestURI(), r.Header.Get("X-Shadow-Request"), r.Header.Get("X-Custom"),
+			r.Header.Get("Authorization"), r.Header.Get("Cookie"), r.Header.Get("X-Client-Middleware"),
+			r.GetBodyString(),
+		))
+		r.Response.WriteStatus(500, "shadow")
+	})
+	shadow.SetDumpRouterMap(false)
+	shadow.Start()
+	defer shadow.Shutdown()
+
+	shadowClient := g.Client().Use(func(c *gclient.Client, r *http.Request) (*gclient.Response, error) {
+		r.Header.Set("X-Client-Middleware", "m")
+		return c.Next(r)
+	})
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareShadow(ghttp.ShadowConfig{
+			Upstream: fmt.Sprintf("http://127.0.0.1:%d/", shadow.GetListenedPort()),
+			Filter: func(r *ghttp.Request) bool {
+				return r.URL.Path != "/private" && !strings.HasPrefix(r.URL.Path, "/all")
+			},
+			Client: shadowClient,
+		}))
+		group.ALL("/*", func(r *ghttp.Request) {
+			r.Response.Write("main:" + r.GetBodyString())
+		})
+	})
+	s.Group("/all", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareShadow(ghttp.ShadowConfig{
+			Upstream:        fmt.Sprintf("http://127.0.0.1:%d", shadow.GetListenedPort()),
+			ExcludedHeaders: []string{},
+			Client:          shadowClient,
+		}))
+		group.ALL("/*", func(r *ghttp.Request) {
+			r.Response.Write("all")
+		})
+	})
+	s.Group("/none", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareShadow(ghttp.ShadowConfig{
+			Upstream:   fmt.Sprintf("http://127.0.0.1:%d", shadow.GetListenedPort()),
+			Percentage: 0.0001,
+		}))
+		group.ALL("/*", func(r *ghttp.Request) {
+			r.Response.Write("none")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix).Header(g.MapStrStr{
+			"X-Custom":      "v",
+			"Authorization": "Bearer secret",
+			"Cookie":        "sid=secret",
+		})
+		t.Assert(client.PostContent(ctx, "/order?id=1", `{"name":"john"}`), `main:{"name":"john"}`)
+		t.Assert(client.GetContent(ctx, "/private"), "main:")
+		t.Assert(client.GetContent(ctx, "/none/1"), "none")
+		time.Sleep(300 * time.Millisecond)
+		// The credential headers are not mirrored in default.
+		t.Assert(mirrored.Slice(), g.SliceStr{`POST /order?id=1 true v [] [] m {"name":"john"}`})
+
+		// The body is sent as it is, which is not taken as file uploading of gclient.
+		mirrored.Clear()
+		req, err := http.NewRequest(http.MethodPost, prefix+"/all/1", strings.NewReader("file=@file:/etc/hosts"))
+		t.AssertNil(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Custom", "v")
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Cookie", "sid=secret")
+		resp, err := http.DefaultClient.Do(req)
+		t.AssertNil(err)
+		t.AssertNil(resp.Body.Close())
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(mirrored.Slice(), g.SliceStr{`POST /all/1 true v [Bearer secret] [sid=secret] m file=@file:/etc/hosts`})
+
+		// The mirrored requests are never mirrored again.
+		t.Assert(client.Header(g.MapStrStr{"X-Shadow-Request": "true"}).GetContent(ctx, "/again"), "main:")
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(mirrored.Len(), 1)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Timeout(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Timeout(200 * time.Millisecond)
+		group.GET("/fast", func(r *ghttp.Request) {
+			r.Response.Write("fast")
+		})
+		group.GET("/slow", func(r *ghttp.Request) {
+			r.Response.Header().Set("X-Partial", "1")
+			r.Response.Write("partial")
+			select {
+			case <-r.Context().Done():
+				r.Response.Write(r.Context().Err())
+			case <-time.After(2 * time.Second):
+				r.Response.Write("slow")
+			}
+		})
+		group.GET("/stream", func(r *ghttp.Request) {
+			r.Response.Write("stream")
+			r.Response.Flush()
+			<-r.Context().Done()
+			r.Response.Write(" end")
+		})
+	})
+	s.Group("/custom", func(group *ghttp.RouterGroup) {
+		group.Timeout(200*time.Millisecond, func(r *ghttp.Request) {
+			r.Response.WriteJson(g.Map{"code": 504, "message": "timeout"})
+		})
+		group.GET("/slow", func(r *ghttp.Request) {
+			<-r.Context().Done()
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+
+		resp, err := client.Get(ctx, "/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "fast")
+		resp.Close()
+
+		start := time.Now()
+		resp, err = client.Get(ctx, "/slow")
+		t.AssertNil(err)
+		t.AssertLT(time.Since(start), time.Second)
+		t.Assert(resp.StatusCode, http.StatusGatewayTimeout)
+		t.Assert(resp.Header.Get("X-Partial"), "")
+		t.Assert(resp.ReadAllString(), "Gateway Timeout")
+		resp.Close()
+
+		// The flushed response is not overwritten.
+		resp, err = client.Get(ctx, "/stream")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "stream end")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/custom/slow")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusGatewayTimeout)
+		t.Assert(resp.ReadAllString(), `{"code":504,"message":"timeout"}`)
+		resp.Close()
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/goai"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gmeta"
@@ -185,3 +186,30 @@ func Test_OpenApi_Method_All_Swagger(t *testing.T) {
 		t.Assert(gstr.Contains(c.GetContent(ctx, "/api.json"), `/test/error`), true)
 	})
 }
+
+func Test_OpenApi_Version(t *testing.T) {
+	type TestReq struct {
+		gmeta.Meta `method:"get" summary:"Test summary" tags:"Test"`
+		Name       string
+	}
+	type TestRes struct {
+		Name string
+	}
+	s := g.Server(guid.S())
+	s.SetOpenApiPath("/api.json")
+	s.SetOpenApiVersion(goai.OpenApiVersion31)
+	s.BindHandler("/test", func(ctx context.Context, req *TestReq) (res *TestRes, err error) {
+		return &TestRes{Name: req.Name}, nil
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(gstr.Contains(c.GetContent(ctx, "/api.json"), `"openapi":"3.1.0"`), true)
+	})
+}
@@ -9,6 +9,7 @@ package ghttp_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -415,3 +416,57 @@ func Test_Params_Strict_Route_File_Batch_Up_Multiple(t *testing.T) {
 		t.Assert(gfile.GetContents(dstPath2), gfile.GetContents(srcPath2))
 	})
 }
+
+func Test_Params_File_StreamMultipart(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/upload/stream", func(r *ghttp.Request) {
+		var items []string
+		err := r.StreamMultipart(func(part *ghttp.MultipartPart) error {
+			content, err := io.ReadAll(part)
+			if err != nil {
+				return err
+			}
+			items = append(items, fmt.Sprintf(
+				"%s:%s:%s:%d", part.FormName, part.FileName, part.SniffedType, len(content),
+			))
+			return nil
+		}, ghttp.StreamMultipartOption{
+			MaxPartSize:  16,
+			AllowedTypes: []string{"text/*"},
+		})
+		if err != nil {
+			r.Response.WriteExit(err.Error())
+		}
+		r.Response.Write(strings.Join(items, ","))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client  = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+			dirPath = gfile.Temp(guid.S())
+		)
+		defer gfile.Remove(dirPath)
+		gfile.PutContents(gfile.Join(dirPath, "file.txt"), "hello")
+		gfile.PutContents(gfile.Join(dirPath, "large.txt"), strings.Repeat("a", 17))
+		gfile.PutBytes(gfile.Join(dirPath, "image.png"), []byte("\x89PNG\r\n\x1a\n0000"))
+
+		t.Assert(client.PostContent(ctx, "/upload/stream", g.Map{
+			"file": "@file:" + gfile.Join(dirPath, "file.txt"),
+		}), "file:file.txt:text/plain; charset=utf-8:5")
+
+		t.Assert(client.PostContent(ctx, "/upload/stream", g.Map{
+			"file": "@file:" + gfile.Join(dirPath, "large.txt"),
+		}), `size of multipart part "file" exceeds the limit`)
+
+		t.Assert(client.PostContent(ctx, "/upload/stream", g.Map{
+			"file": "@file:" + gfile.Join(dirPath, "image.png"),
+		}), `content type "image/png" of file "image.png" is not allowed`)
+
+		// Not multipart.
+		t.Assert(gstr.Contains(client.PostContent(ctx, "/upload/stream", "a=1"), "MultipartReader failed"), true)
+	})
+}
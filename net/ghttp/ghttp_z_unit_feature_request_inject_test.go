@@ -0,0 +1,95 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testInjectUser struct {
+	Name string
+}
+
+type testInjectTenant interface {
+	Id() int
+}
+
+type testInjectTenantImpl int
+
+func (t testInjectTenantImpl) Id() int {
+	return int(t)
+}
+
+type testInjectReq struct {
+	g.Meta `path:"/inject" method:"get"`
+	Id     int
+}
+
+type testInjectRes struct {
+	Content string
+}
+
+func Test_Request_Inject(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Middleware(func(r *ghttp.Request) {
+			if name := r.GetHeader("User"); name != "" {
+				ghttp.MustProvide(r, &testInjectUser{Name: name})
+			}
+			ghttp.MustProvide[testInjectTenant](r, testInjectTenantImpl(100))
+			r.Middleware.Next()
+		})
+		group.GET("/resolve", func(r *ghttp.Request) {
+			user, ok := ghttp.Resolve[*testInjectUser](r)
+			if !ok {
+				r.Response.Write("anonymous")
+				return
+			}
+			// Provided value of the same type cannot be provided again.
+			r.Response.Write(user.Name, ghttp.Provide(r, &testInjectUser{}) != nil)
+		})
+		group.Bind(func(
+			ctx context.Context, req *testInjectReq, user *testInjectUser, tenant testInjectTenant,
+		) (res *testInjectRes, err error) {
+			return &testInjectRes{
+				Content: fmt.Sprintf("%d-%s-%d", req.Id, user.Name, tenant.Id()),
+			}, nil
+		})
+	})
+	s.SetOpenApiPath("/api.json")
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/resolve"), "anonymous")
+		t.Assert(client.Header(g.MapStrStr{"User": "john"}).GetContent(ctx, "/resolve"), "johntrue")
+		t.Assert(
+			client.Header(g.MapStrStr{"User": "john"}).GetContent(ctx, "/inject?id=1"),
+			`{"code":0,"message":"","data":{"Content":"1-john-100"}}`,
+		)
+		t.Assert(gstr.Contains(client.GetContent(ctx, "/api.json"), `"/inject"`), true)
+		t.Assert(
+			client.GetContent(ctx, "/inject?id=1"),
+			`{"code":50,"message":"no value of type \"*ghttp_test.testInjectUser\" is provided for injected parameter of handler","data":null}`,
+		)
+	})
+}
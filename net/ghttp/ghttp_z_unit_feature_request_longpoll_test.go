@@ -0,0 +1,153 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Request_LongPoll(t *testing.T) {
+	var (
+		hub    = ghttp.NewLongPollHub()
+		cursor = gtype.NewInt()
+		s      = g.Server(guid.S())
+	)
+	s.BindHandler("/poll", func(r *ghttp.Request) {
+		data, ok := hub.Poll(r, "news", ghttp.LongPollOption{
+			Timeout: r.Get("timeout", time.Second).Duration(),
+			Ready: func() (interface{}, bool) {
+				if current := cursor.Val(); current > r.Get("cursor").Int() {
+					return current, true
+				}
+				return nil, false
+			},
+		})
+		if !ok {
+			r.Response.WriteStatus(http.StatusNoContent)
+			return
+		}
+		r.Response.Write(data)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Woken by the published event.
+		result := make(chan string, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				result <- client.GetContent(ctx, "/poll?cursor=0")
+			}()
+		}
+		for i := 0; i < 20 && hub.Waiting("news") < 2; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Assert(hub.Waiting("news"), 2)
+		t.Assert(hub.Publish("news", "event-1"), 2)
+		t.Assert(<-result, "event-1")
+		t.Assert(<-result, "event-1")
+		t.Assert(hub.Waiting("news"), 0)
+		t.Assert(hub.Publish("news", "event-2"), 0)
+
+		// Responded immediately as data is ready.
+		cursor.Set(1)
+		t.Assert(client.GetContent(ctx, "/poll?cursor=0"), "1")
+
+		// Timeout.
+		resp, err := client.Get(ctx, "/poll?cursor=1&timeout=100ms")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		resp.Close()
+
+		// Client disconnects.
+		_, err = g.Client().Timeout(100*time.Millisecond).Get(
+			ctx, fmt.Sprintf("http://127.0.0.1:%d/poll?cursor=1", s.GetListenedPort()),
+		)
+		t.AssertNE(err, nil)
+		for i := 0; i < 20 && hub.Waiting("news") > 0; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Assert(hub.Waiting("news"), 0)
+	})
+}
+
+func Test_Request_LongPoll_Drain(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/poll", func(r *ghttp.Request) {
+		if _, ok := r.LongPoll(make(chan interface{}), ghttp.LongPollOption{Timeout: time.Minute}); !ok {
+			r.Response.Write("closed")
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		result := make(chan string, 1)
+		go func() {
+			result <- g.Client().GetContent(ctx, fmt.Sprintf("http://127.0.0.1:%d/poll", s.GetListenedPort()))
+		}()
+		time.Sleep(100 * time.Millisecond)
+		start := time.Now()
+		t.AssertNil(s.Shutdown())
+		t.Assert(<-result, "closed")
+		t.Assert(time.Since(start) < 5*time.Second, true)
+	})
+}
+
+func Test_Request_LongPoll_Restart(t *testing.T) {
+	var (
+		hub = ghttp.NewLongPollHub()
+		s   = g.Server(guid.S())
+	)
+	s.BindHandler("/poll", func(r *ghttp.Request) {
+		data, ok := hub.Poll(r, "news", ghttp.LongPollOption{Timeout: time.Second})
+		if !ok {
+			r.Response.Write("closed")
+			return
+		}
+		r.Response.Write(data)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(s.Shutdown())
+		// The requests are parked again after restarting.
+		t.AssertNil(s.Start())
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		var (
+			ports  = s.GetListenedPorts()
+			result = make(chan string, 1)
+		)
+		go func() {
+			result <- g.Client().GetContent(ctx, fmt.Sprintf("http://127.0.0.1:%d/poll", ports[len(ports)-1]))
+		}()
+		for i := 0; i < 20 && hub.Waiting("news") < 1; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Assert(hub.Publish("news", "event"), 1)
+		t.Assert(<-result, "event")
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testProblemReq struct {
+	g.Meta `path:"/user" method:"post"`
+	Name   string `v:"required#name is required"`
+	Age    int    `v:"required|min:18"`
+}
+
+type testProblemRes struct{}
+
+type testProblemController struct{}
+
+func (c *testProblemController) User(ctx context.Context, req *testProblemReq) (res *testProblemRes, err error) {
+	return nil, gerror.NewCode(gcode.CodeNotFound, "user not found")
+}
+
+func Test_Response_Problem(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetErrorResponseFormat(ghttp.ErrorResponseFormatProblem)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(new(testProblemController))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Post(ctx, "/user", g.Map{"age": 10})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 400)
+		t.Assert(resp.Header.Get("Content-Type"), "application/problem+json")
+		j, err := gjson.LoadJson(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(j.Get("status"), 400)
+		t.Assert(j.Get("title"), "Bad Request")
+		t.Assert(j.Get("code"), gcode.CodeValidationFailed.Code())
+		t.Assert(j.Get("instance"), "/user")
+		t.Assert(len(j.Get("violations").Array()), 1)
+		t.Assert(j.Get("violations.0.field"), "Name")
+		t.Assert(j.Get("violations.0.rule"), "required")
+		t.Assert(j.Get("violations.0.message"), "name is required")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Post(ctx, "/user", g.Map{"name": "john", "age": 20})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 404)
+		j, err := gjson.LoadJson(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(j.Get("status"), 404)
+		t.Assert(j.Get("code"), gcode.CodeNotFound.Code())
+		t.Assert(j.Get("detail"), "user not found")
+		t.Assert(j.Get("violations"), nil)
+	})
+}
+
+func Test_Response_Problem_DefaultFormat(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(new(testProblemController))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Post(ctx, "/user", g.Map{"age": 10})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 200)
+		t.AssertNE(resp.Header.Get("Content-Type"), "application/problem+json")
+		j, err := gjson.LoadJson(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(j.Get("code"), gcode.CodeValidationFailed.Code())
+		t.Assert(j.Get("message"), "name is required")
+	})
+}
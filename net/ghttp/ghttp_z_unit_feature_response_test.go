@@ -7,12 +7,16 @@
 package ghttp_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/encoding/gxml"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/os/gview"
@@ -310,3 +314,158 @@ func Test_Response_Write(t *testing.T) {
 		t.Assert(client.GetContent(ctx, "/WriteXmlWithStruct"), "<name>john</name>")
 	})
 }
+
+func Test_Response_SSE(t *testing.T) {
+	var disconnected = gtype.NewBool()
+	s := g.Server(guid.S())
+	s.BindHandler("/events", func(r *ghttp.Request) {
+		w := r.Response.SSE()
+		_ = w.Send(ghttp.SSEEvent{Id: "1", Event: "greeting", Data: "hello\nworld", Retry: time.Second})
+		_ = w.SendData(g.Map{"name": "john"})
+		_ = w.Send(ghttp.SSEEvent{Id: "1\n", Data: "invalid"})
+	})
+	s.BindHandler("/heartbeat", func(r *ghttp.Request) {
+		w := r.Response.SSE(50 * time.Millisecond)
+		time.Sleep(120 * time.Millisecond)
+		_ = w.SendData("done")
+	})
+	s.BindHandler("/disconnect", func(r *ghttp.Request) {
+		w := r.Response.SSE()
+		for {
+			select {
+			case <-w.Done():
+				disconnected.Set(true)
+				return
+			case <-time.After(10 * time.Millisecond):
+				_ = w.SendData("tick")
+			}
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	client := g.Client()
+	client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := client.Get(ctx, "/events")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Type"), "text/event-stream")
+		t.Assert(resp.Header.Get("Cache-Control"), "no-cache")
+		t.Assert(
+			resp.ReadAllString(),
+			"id: 1\nevent: greeting\nretry: 1000\ndata: hello\ndata: world\n\ndata: {\"name\":\"john\"}\n\n",
+		)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		content := client.GetContent(ctx, "/heartbeat")
+		t.Assert(content, ": heartbeat\n\n: heartbeat\n\ndata: done\n\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		stream, err := client.GetStream(timeoutCtx, "/disconnect")
+		t.AssertNil(err)
+		event, err := stream.ReadEvent()
+		t.AssertNil(err)
+		t.Assert(event.Data, "tick")
+		stream.Close()
+		cancel()
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(disconnected.Val(), true)
+	})
+}
+
+func Test_Response_EarlyHints(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/hints", func(r *ghttp.Request) {
+		r.Response.Header().Set("X-Custom", "1")
+		if err := r.Response.WriteEarlyHints(
+			"</style.css>; rel=preload; as=style",
+			"</app.js>; rel=preload; as=script",
+		); err != nil {
+			r.Response.WriteStatus(http.StatusInternalServerError, err.Error())
+			return
+		}
+		r.Response.Write("page")
+	})
+	s.BindHandler("/flushed", func(r *ghttp.Request) {
+		r.Response.Write("page")
+		r.Response.Flush()
+		r.Response.Write(r.Response.WriteEarlyHints("</style.css>; rel=preload; as=style") != nil)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			hints    []http.Header
+			traceCtx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+				Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+					if code == http.StatusEarlyHints {
+						hints = append(hints, http.Header(header))
+					}
+					return nil
+				},
+			})
+		)
+		resp, err := g.Client().Prefix(prefix).Get(traceCtx, "/hints")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "page")
+		t.Assert(resp.Header.Get("X-Custom"), "1")
+		t.Assert(resp.Header.Values("Link"), g.SliceStr{
+			"</style.css>; rel=preload; as=style",
+			"</app.js>; rel=preload; as=script",
+		})
+		t.Assert(len(hints), 1)
+		t.Assert(hints[0].Values("Link"), g.SliceStr{
+			"</style.css>; rel=preload; as=style",
+			"</app.js>; rel=preload; as=script",
+		})
+		t.Assert(hints[0].Get("X-Custom"), "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(g.Client().Prefix(prefix).GetContent(ctx, "/flushed"), "pagetrue")
+	})
+}
+
+func Test_Response_Trailer(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/trailer", func(r *ghttp.Request) {
+		r.Response.Write("body")
+		r.Response.SetTrailer("X-Checksum", "abc")
+	})
+	s.BindHandler("/stream", func(r *ghttp.Request) {
+		r.Response.Write("chunk1")
+		r.Response.Flush()
+		r.Response.Write("chunk2")
+		r.Response.SetTrailer("X-Status", "done")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Get(ctx, "/trailer")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "body")
+		t.Assert(resp.Trailer.Get("X-Checksum"), "abc")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := g.Client().Prefix(prefix).Get(ctx, "/stream")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), "chunk1chunk2")
+		t.Assert(resp.Trailer.Get("X-Status"), "done")
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Router_Version(t *testing.T) {
+	var (
+		deprecatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		sunset       = time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+		handler      = func(r *ghttp.Request) {
+			r.Response.Write(r.GetApiVersion() + ":" + r.Get("id").String())
+		}
+	)
+	s := g.Server(guid.S())
+	s.Group("/api", func(group *ghttp.RouterGroup) {
+		group.Version("v0", func(group *ghttp.RouterGroup) {
+			group.GET("/user/{id}", handler)
+		})
+		group.Version("v1", func(group *ghttp.RouterGroup) {
+			group.GET("/user/{id}", handler)
+		})
+		group.Version("v2", func(group *ghttp.RouterGroup) {
+			group.GET("/user/{id}", handler)
+		})
+		group.GET("/ping", func(r *ghttp.Request) {
+			r.Response.Write("pong" + r.GetApiVersion())
+		})
+	})
+	s.DeprecateVersion("v0", ghttp.VersionDeprecation{
+		Sunset:            time.Now().Add(-time.Hour),
+		RejectAfterSunset: true,
+	})
+	s.DeprecateVersion("v1", ghttp.VersionDeprecation{
+		At:     deprecatedAt,
+		Sunset: sunset,
+		Link:   "https://example.com/migration",
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+
+		// Version in path.
+		resp, err := client.Get(ctx, "/api/v1/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "v1:1")
+		t.Assert(resp.Header.Get("Deprecation"), fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		t.Assert(resp.Header.Get("Sunset"), "Thu, 01 Jan 2099 00:00:00 GMT")
+		t.Assert(resp.Header.Get("Link"), `<https://example.com/migration>; rel="deprecation"`)
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/api/v2/user/2")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "v2:2")
+		t.Assert(resp.Header.Get("Deprecation"), "")
+		t.Assert(resp.Header.Get("Sunset"), "")
+		resp.Close()
+
+		// Retired version.
+		resp, err = client.Get(ctx, "/api/v0/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusGone)
+		t.AssertNE(resp.Header.Get("Deprecation"), "")
+		resp.Close()
+
+		// No version.
+		resp, err = client.Get(ctx, "/api/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+		resp.Close()
+		t.Assert(client.GetContent(ctx, "/api/ping"), "pong")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().Prefix(prefix)
+
+		// Version in header.
+		t.Assert(client.Header(g.MapStrStr{"Api-Version": "v2"}).GetContent(ctx, "/api/user/1"), "v2:1")
+		t.Assert(client.Header(g.MapStrStr{"Api-Version": "v1"}).GetContent(ctx, "/api/user/1"), "v1:1")
+		// Version in path has priority.
+		t.Assert(client.Header(g.MapStrStr{"Api-Version": "v1"}).GetContent(ctx, "/api/v2/user/1"), "v2:1")
+		// Unknown version.
+		t.Assert(client.Header(g.MapStrStr{"Api-Version": "v9"}).GetContent(ctx, "/api/user/1"), "Not Found")
+		// Unversioned route is not affected.
+		t.Assert(client.Header(g.MapStrStr{"Api-Version": "v2"}).GetContent(ctx, "/api/ping"), "pong")
+
+		// Version in media type.
+		t.Assert(
+			client.Header(g.MapStrStr{"Accept": "application/vnd.example.v2+json"}).GetContent(ctx, "/api/user/3"),
+			"v2:3",
+		)
+		t.Assert(
+			client.Header(g.MapStrStr{"Accept": "text/html, application/json; version=v1"}).GetContent(ctx, "/api/user/3"),
+			"v1:3",
+		)
+		t.Assert(client.Header(g.MapStrStr{"Accept": "application/json"}).GetContent(ctx, "/api/user/3"), "Not Found")
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_UnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file mode of unix domain socket is not supported on windows")
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir  = gfile.Temp(guid.S())
+			path = gfile.Join(dir, "app.sock")
+		)
+		t.AssertNil(gfile.Mkdir(dir))
+		defer gfile.Remove(dir)
+
+		// Stale socket file left by the previous process.
+		ln, err := net.Listen("unix", path)
+		t.AssertNil(err)
+		ln.(*net.UnixListener).SetUnlinkOnClose(false)
+		t.AssertNil(ln.Close())
+		t.Assert(gfile.Exists(path), true)
+
+		s := g.Server(guid.S())
+		s.BindHandler("/hello", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.SetAddr("unix:" + path)
+		s.SetUnixSocketMode("0660")
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		info, err := os.Stat(path)
+		t.AssertNil(err)
+		t.Assert(info.Mode().Perm(), os.FileMode(0660))
+		t.Assert(s.GetListenedAddress(), "unix:"+path)
+
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", path)
+				},
+			},
+		}
+		resp, err := client.Get("http://unix/hello")
+		t.AssertNil(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		t.AssertNil(err)
+		t.Assert(string(body), "hello")
+	})
+}
+
+// recordRegistrar records the registered service.
+type recordRegistrar struct {
+	service gsvc.Service
+}
+
+func (r *recordRegistrar) Register(ctx context.Context, service gsvc.Service) (gsvc.Service, error) {
+	r.service = service
+	return service, nil
+}
+
+func (r *recordRegistrar) Deregister(ctx context.Context, service gsvc.Service) error {
+	return nil
+}
+
+func Test_Server_UnixSocket_Registry(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file mode of unix domain socket is not supported on windows")
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir       = gfile.Temp(guid.S())
+			path      = gfile.Join(dir, "app.sock")
+			registrar = &recordRegistrar{}
+		)
+		t.AssertNil(gfile.Mkdir(dir))
+		defer gfile.Remove(dir)
+
+		s := g.Server(guid.S())
+		s.BindHandler("/hello", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.SetAddr(fmt.Sprintf("unix:%s,:0", path))
+		s.SetRegistrar(registrar)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		// Only the TCP listener is registered.
+		t.AssertNE(registrar.service, nil)
+		endpoints := registrar.service.GetEndpoints()
+		t.AssertGT(len(endpoints), 0)
+		for _, endpoint := range endpoints {
+			t.Assert(endpoint.Port(), s.GetListenedPorts()[1])
+		}
+	})
+}
+
+func Test_Server_FileDescriptorListener(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("passing file descriptor of listener is not supported on windows")
+	}
+	gtest.C(t, func(t *gtest.T) {
+		// The listener opened by process supervisor like launchd.
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		t.AssertNil(err)
+		defer ln.Close()
+		file, err := ln.(*net.TCPListener).File()
+		t.AssertNil(err)
+		defer file.Close()
+
+		s := g.Server(guid.S())
+		s.BindHandler("/hello", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.SetAddr(fmt.Sprintf("fd:%d", file.Fd()))
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		port := ln.Addr().(*net.TCPAddr).Port
+		t.Assert(s.GetListenedPort(), port)
+		// The original listener is closed, and the requests are accepted by the server.
+		t.AssertNil(ln.Close())
+		t.Assert(g.Client().GetContent(ctx, fmt.Sprintf("http://127.0.0.1:%d/hello", port)), "hello")
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// newTestCertificate creates a self-signed certificate with `commonName` in PEM.
+func newTestCertificate(t *gtest.T, commonName string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.AssertNil(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	t.AssertNil(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	t.AssertNil(err)
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return
+}
+
+// peerCommonName returns the common name of the certificate served by the TLS server on `port`.
+func peerCommonName(port int) string {
+	conn, err := tls.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return err.Error()
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func Test_Server_ReloadCertificate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir      = gfile.Temp(guid.S())
+			certFile = gfile.Join(dir, "server.crt")
+			keyFile  = gfile.Join(dir, "server.key")
+		)
+		defer gfile.Remove(dir)
+		certPEM, keyPEM := newTestCertificate(t, "first")
+		t.AssertNil(gfile.PutBytes(certFile, certPEM))
+		t.AssertNil(gfile.PutBytes(keyFile, keyPEM))
+
+		s := g.Server(guid.S())
+		s.BindHandler("/", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+		s.EnableHTTPS(certFile, keyFile)
+		s.SetHTTPSCertWatch(true)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		t.Assert(peerCommonName(s.GetListenedPort()), "first")
+
+		// Reloaded by watching the files.
+		certPEM, keyPEM = newTestCertificate(t, "second")
+		t.AssertNil(gfile.PutBytes(certFile, certPEM))
+		t.AssertNil(gfile.PutBytes(keyFile, keyPEM))
+		for i := 0; i < 20 && peerCommonName(s.GetListenedPort()) != "second"; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Assert(peerCommonName(s.GetListenedPort()), "second")
+
+		// It keeps the current certificate if the files are invalid.
+		t.AssertNil(gfile.PutContents(keyFile, "invalid"))
+		t.AssertNE(s.ReloadCertificate(), nil)
+		t.Assert(peerCommonName(s.GetListenedPort()), "second")
+
+		// Reloaded manually.
+		certPEM, keyPEM = newTestCertificate(t, "third")
+		t.AssertNil(gfile.PutBytes(certFile, certPEM))
+		t.AssertNil(gfile.PutBytes(keyFile, keyPEM))
+		t.AssertNil(s.ReloadCertificate())
+		t.Assert(peerCommonName(s.GetListenedPort()), "third")
+	})
+}
+
+func Test_Server_CertificateProvider(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		certPEM, keyPEM := newTestCertificate(t, "provided")
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		t.AssertNil(err)
+
+		s := g.Server(guid.S())
+		s.BindHandler("/", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+		s.SetCertificateProvider(func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &certificate, nil
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		t.Assert(peerCommonName(s.GetListenedPort()), "provided")
+		t.AssertNE(s.ReloadCertificate(), nil)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+	})
+}
+
+func Test_Server_LiveConfig(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	s.EnableAdmin()
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		config := s.GetLiveConfig()
+		t.Assert(config[ghttp.LiveConfigReadTimeout], "1m0s")
+		t.Assert(config[ghttp.LiveConfigWriteTimeout], "0s")
+
+		content := client.PostContent(ctx, "/debug/admin/config", g.Map{
+			"readTimeout":  "10s",
+			"writeTimeout": "5s",
+			"logLevel":     "error",
+		})
+		t.Assert(content, `{"logLevel":"ERRO","readTimeout":"10s","writeTimeout":"5s"}`)
+		t.Assert(client.GetContent(ctx, "/debug/admin/config"), content)
+		t.Assert(s.Logger().GetLevel()&glog.LEVEL_INFO, 0)
+		t.Assert(client.GetContent(ctx, "/"), "ok")
+
+		// Nothing is applied if any value is invalid.
+		resp, err := client.Post(ctx, "/debug/admin/config", g.Map{
+			"readTimeout":  "1s",
+			"writeTimeout": "invalid",
+		})
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 400)
+		t.Assert(s.GetLiveConfig()[ghttp.LiveConfigReadTimeout], "10s")
+		t.AssertNE(s.SetLiveConfig(g.Map{"unknown": 1}), nil)
+
+		// Reloading certificate fails as HTTPS is not enabled.
+		certResp, err := client.Get(ctx, "/debug/admin/reload-certificate")
+		t.AssertNil(err)
+		defer certResp.Close()
+		t.Assert(certResp.StatusCode, 500)
+	})
+}
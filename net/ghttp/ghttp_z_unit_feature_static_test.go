@@ -10,10 +10,12 @@ package ghttp_test
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -272,3 +274,139 @@ func Test_Static_Rewrite(t *testing.T) {
 		t.Assert(client.GetContent(ctx, "/my-test2"), "test2")
 	})
 }
+
+func Test_Static_Range(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/range/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/file.txt", "0123456789")
+		s.SetServerRoot(path)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Header(g.MapStrStr{"Range": "bytes=2-5"}).Get(ctx, "/file.txt")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, http.StatusPartialContent)
+		t.Assert(resp.Header.Get("Content-Range"), "bytes 2-5/10")
+		t.Assert(resp.ReadAllString(), "2345")
+	})
+}
+
+func Test_Static_Precompressed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/precompressed/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/app.js", "plain")
+		gfile.PutContents(path+"/app.js.br", "brotli")
+		gfile.PutContents(path+"/app.js.gz", "gzip")
+		gfile.PutContents(path+"/style.css", "style")
+		gfile.PutContents(path+"/style.css.gz", "gzip")
+		s.AddStaticPath("/assets", path, ghttp.StaticPathConfig{
+			Precompressed: true,
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Header(g.MapStrStr{"Accept-Encoding": "gzip, br"}).Get(ctx, "/assets/app.js")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "br")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.Assert(gstr.Contains(resp.Header.Get("Content-Type"), "javascript"), true)
+		t.Assert(resp.ReadAllString(), "brotli")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "gzip, br;q=0.5"}).Get(ctx, "/assets/app.js")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.ReadAllString(), "gzip")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "br"}).Get(ctx, "/assets/style.css")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), "style")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept-Encoding": "identity"}).Get(ctx, "/assets/app.js")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), "plain")
+		resp.Close()
+	})
+}
+
+func Test_Static_CacheRules(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/cache/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/index.html", "index")
+		gfile.PutContents(path+"/assets/app.123.js", "app")
+		gfile.PutContents(path+"/robots.txt", "robots")
+		s.SetServerRoot(path)
+		s.SetStaticConfig(ghttp.StaticPathConfig{
+			CacheRules: []ghttp.StaticCacheRule{
+				{Pattern: "/assets/*", CacheControl: "public, max-age=31536000, immutable"},
+				{Pattern: "*.html", CacheControl: "no-cache"},
+			},
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/assets/app.123.js")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Cache-Control"), "public, max-age=31536000, immutable")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Cache-Control"), "no-cache")
+		t.Assert(resp.ReadAllString(), "index")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/robots.txt")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Cache-Control"), "")
+		resp.Close()
+	})
+}
+
+func Test_Static_IndexTemplate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/template/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/files/a.txt", "a")
+		gfile.PutContents(path+"/files/sub/b.txt", "b")
+		gfile.PutContents(
+			path+"/index.tpl",
+			`{{.Path}}|{{.Parent}}|{{range .Files}}{{.Name}}:{{.Url}}:{{.IsDir}};{{end}}`,
+		)
+		s.AddStaticPath("/files", path+"/files", ghttp.StaticPathConfig{
+			IndexTemplate: path + "/index.tpl",
+		})
+		s.SetIndexFolder(true)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(
+			client.GetContent(ctx, "/files/"),
+			`/files|/|sub/:/files/sub/:true;a.txt:/files/a.txt:false;`,
+		)
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Tracing_Propagators(t *testing.T) {
+	const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write(r.Header.Get("b3"), "|", r.Header.Get("traceparent"), "|", gtrace.GetTraceID(r.Context()))
+	})
+	s.SetDumpRouterMap(false)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(s.SetConfigWithMap(g.Map{"tracingPropagators": g.Slice{"b3"}}))
+		t.AssertNE(s.SetTracingPropagators("invalid"), nil)
+	})
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			traceID, _ = trace.TraceIDFromHex(traceId)
+			spanID, _  = trace.SpanIDFromHex("00f067aa0ba902b7")
+			ctx        = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			}))
+			client = g.Client()
+		)
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		propagator, err := gtrace.NewTextMapPropagator(gtrace.PropagatorB3)
+		t.AssertNil(err)
+
+		array := gstr.Split(client.Propagator(propagator).GetContent(ctx, "/"), "|")
+		t.Assert(len(array), 3)
+		t.Assert(array[0] != "", true)
+		t.Assert(array[1], "")
+		t.Assert(array[2], traceId)
+	})
+}
+
+func Test_Tracing_IdInjection(t *testing.T) {
+	gtrace.SetIdInjection(true)
+	defer gtrace.SetIdInjection(false)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/", func(r *ghttp.Request) {
+		r.Response.Write(gtrace.GetTraceID(r.Context()))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+		var (
+			traceID, _ = trace.TraceIDFromHex(traceId)
+			spanID, _  = trace.SpanIDFromHex("00f067aa0ba902b7")
+			ctx        = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			}))
+			client = g.Client()
+		)
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), traceId)
+		t.Assert(resp.Header.Get(gtrace.DefaultIdInjectionHeader), traceId)
+	})
+}
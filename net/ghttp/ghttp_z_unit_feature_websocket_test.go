@@ -8,11 +8,14 @@ package ghttp_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -58,3 +61,144 @@ func Test_WebSocket(t *testing.T) {
 		t.Assert(data, msg)
 	})
 }
+
+func Test_WebSocketHub(t *testing.T) {
+	var (
+		connected    = gtype.NewInt()
+		disconnected = gtype.NewInt()
+	)
+	hub := ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+		OnConnect: func(conn *ghttp.WebSocketConn) {
+			connected.Add(1)
+			if room := conn.Request.Get("room").String(); room != "" {
+				conn.Join(room)
+			}
+		},
+		OnMessage: func(conn *ghttp.WebSocketConn, msgType int, data []byte) {
+			switch {
+			case strings.HasPrefix(string(data), "leave:"):
+				conn.Leave(strings.TrimPrefix(string(data), "leave:"))
+				_ = conn.SendText("left")
+			case strings.HasPrefix(string(data), "room:"):
+				for _, room := range conn.Rooms() {
+					conn.Hub().BroadcastToRoom(room, msgType, []byte(strings.TrimPrefix(string(data), "room:")))
+				}
+			default:
+				conn.Hub().Broadcast(msgType, data)
+			}
+		},
+		OnDisconnect: func(conn *ghttp.WebSocketConn, err error) {
+			disconnected.Add(1)
+		},
+	})
+	s := g.Server(guid.S())
+	s.BindHandler("/ws", hub.Handle)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	dial := func(room string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf(
+			"ws://127.0.0.1:%d/ws?room=%s", s.GetListenedPort(), room,
+		), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	read := func(conn *websocket.Conn) string {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err.Error()
+		}
+		return string(data)
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			conn1 = dial("a")
+			conn2 = dial("a")
+			conn3 = dial("b")
+		)
+		defer conn1.Close()
+		defer conn2.Close()
+		defer conn3.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.Count(), 3)
+		t.Assert(hub.Rooms(), g.Slice{"a", "b"})
+		t.Assert(hub.RoomCount("a"), 2)
+
+		t.AssertNil(conn1.WriteMessage(websocket.TextMessage, []byte("room:hello a")))
+		t.Assert(read(conn1), "hello a")
+		t.Assert(read(conn2), "hello a")
+
+		t.AssertNil(conn3.WriteMessage(websocket.TextMessage, []byte("hello all")))
+		t.Assert(read(conn1), "hello all")
+		t.Assert(read(conn2), "hello all")
+		t.Assert(read(conn3), "hello all")
+
+		t.AssertNil(conn2.WriteMessage(websocket.TextMessage, []byte("leave:a")))
+		t.Assert(read(conn2), "left")
+		t.Assert(hub.RoomCount("a"), 1)
+
+		t.Assert(hub.BroadcastToRoom("a", websocket.TextMessage, []byte("only conn1")), 1)
+		t.Assert(read(conn1), "only conn1")
+
+		_ = conn3.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.Count(), 2)
+		t.Assert(hub.Rooms(), g.Slice{"a"})
+		t.Assert(connected.Val(), 3)
+		t.Assert(disconnected.Val(), 1)
+
+		hub.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.Count(), 0)
+		t.Assert(disconnected.Val(), 3)
+		_, _, err := conn1.ReadMessage()
+		t.Assert(websocket.IsCloseError(err, websocket.CloseNormalClosure), true)
+	})
+}
+
+func Test_WebSocketHub_SlowConsumer(t *testing.T) {
+	var (
+		sendErr       error
+		disconnectErr = make(chan error, 1)
+	)
+	hub := ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+		SendQueueSize: 1,
+		OnConnect: func(conn *ghttp.WebSocketConn) {
+			// The connection starts sending after OnConnect, so the second message overflows the queue.
+			_ = conn.SendText("1")
+			sendErr = conn.SendText("2")
+		},
+		OnDisconnect: func(conn *ghttp.WebSocketConn, err error) {
+			disconnectErr <- err
+		},
+	})
+	s := g.Server(guid.S())
+	s.BindHandler("/ws", hub.Handle)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf(
+			"ws://127.0.0.1:%d/ws", s.GetListenedPort(),
+		), nil)
+		t.AssertNil(err)
+		defer conn.Close()
+
+		_, _, err = conn.ReadMessage()
+		t.Assert(websocket.IsCloseError(err, websocket.ClosePolicyViolation), true)
+		select {
+		case err = <-disconnectErr:
+		case <-time.After(time.Second):
+		}
+		t.Assert(gerror.Is(err, ghttp.ErrWebSocketSlowConsumer), true)
+		t.Assert(gerror.Is(sendErr, ghttp.ErrWebSocketSlowConsumer), true)
+	})
+}
@@ -82,7 +82,7 @@ func (w *BufferWriter) Flush() {
 		w.Writer.WriteHeader(w.Status)
 	}
 	// Default status text output.
-	if w.Status != http.StatusOK && w.buffer.Len() == 0 {
+	if w.Status != http.StatusOK && w.buffer.Len() == 0 && isBodyAllowedForStatus(w.Status) {
 		w.buffer.WriteString(http.StatusText(w.Status))
 	}
 	if w.buffer.Len() > 0 {
@@ -93,3 +93,14 @@ func (w *BufferWriter) Flush() {
 		}
 	}
 }
+
+// isBodyAllowedForStatus reports whether a given response status code permits a body.
+func isBodyAllowedForStatus(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
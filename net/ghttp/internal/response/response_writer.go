@@ -37,6 +37,16 @@ func (w *Writer) WriteHeader(status int) {
 	w.wroteHeader = true
 }
 
+// WriteInformational writes informational `status` like 103 Early Hints before the final response,
+// which does not mark the header written. It returns false if the header is already written.
+func (w *Writer) WriteInformational(status int) bool {
+	if w.wroteHeader || w.hijacked {
+		return false
+	}
+	w.ResponseWriter.WriteHeader(status)
+	return true
+}
+
 // BytesWritten returns the length that was written to response.
 func (w *Writer) BytesWritten() int64 {
 	return w.bytesWritten
@@ -25,16 +25,19 @@ import (
 // OpenApiV3 is the structure defined from:
 // https://swagger.io/specification/
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.0.md
+// https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.1.0.md
 type OpenApiV3 struct {
-	Config       Config                `json:"-"`
-	OpenAPI      string                `json:"openapi"`
-	Components   Components            `json:"components,omitempty"`
-	Info         Info                  `json:"info"`
-	Paths        Paths                 `json:"paths"`
-	Security     *SecurityRequirements `json:"security,omitempty"`
-	Servers      *Servers              `json:"servers,omitempty"`
-	Tags         *Tags                 `json:"tags,omitempty"`
-	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
+	Config            Config                `json:"-"`
+	OpenAPI           string                `json:"openapi"`
+	JsonSchemaDialect string                `json:"jsonSchemaDialect,omitempty"` // Since 3.1.
+	Components        Components            `json:"components,omitempty"`
+	Info              Info                  `json:"info"`
+	Paths             Paths                 `json:"paths"`
+	Webhooks          Paths                 `json:"webhooks,omitempty"` // Since 3.1.
+	Security          *SecurityRequirements `json:"security,omitempty"`
+	Servers           *Servers              `json:"servers,omitempty"`
+	Tags              *Tags                 `json:"tags,omitempty"`
+	ExternalDocs      *ExternalDocs         `json:"externalDocs,omitempty"`
 }
 
 const (
@@ -0,0 +1,141 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// AddOneOfInput is the structured parameter for function OpenApiV3.AddOneOf.
+type AddOneOfInput struct {
+	Interface    interface{}   // Interface is the nil pointer of interface type, like: (*Pet)(nil).
+	PropertyName string        // PropertyName specifies the discriminator property of the implementations, which is optional.
+	Objects      []interface{} // Objects are the struct implementations of the interface.
+}
+
+// AddOneOf adds the schema of an interface as oneOf its struct implementations, which is referenced
+// by the attributes of the interface type. As golang cannot enumerate the implementations of
+// an interface, they should be added by this function before adding the paths using the interface.
+//
+// If PropertyName is given, it also adds the discriminator to the schema of interface, and the property
+// becomes required for the implementations. The mapping value of each implementation is the default
+// value of the property in struct tag, like:
+//
+//	type Cat struct {
+//	    PetType string `json:"petType" d:"cat"`
+//	}
+func (oai *OpenApiV3) AddOneOf(in AddOneOfInput) error {
+	interfaceType := reflect.TypeOf(in.Interface)
+	if interfaceType == nil || interfaceType.Kind() != reflect.Ptr || interfaceType.Elem().Kind() != reflect.Interface {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid interface "%T", it should be nil pointer of interface type like: (*Pet)(nil)`,
+			in.Interface,
+		)
+	}
+	interfaceType = interfaceType.Elem()
+	if len(in.Objects) == 0 {
+		return gerror.NewCodef(
+			gcode.CodeMissingParameter,
+			`missing implementations of interface "%s"`,
+			interfaceType.String(),
+		)
+	}
+	var (
+		mapping = make(map[string]string)
+		schema  = &Schema{
+			XExtensions: make(XExtensions),
+			jsonSchema:  oai.isOpenApi31(),
+		}
+	)
+	for _, object := range in.Objects {
+		objectType := reflect.TypeOf(object)
+		if objectType == nil || !objectType.Implements(interfaceType) {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`"%T" does not implement interface "%s"`,
+				object, interfaceType.String(),
+			)
+		}
+		for objectType.Kind() == reflect.Ptr {
+			objectType = objectType.Elem()
+		}
+		if objectType.Kind() != reflect.Struct {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`unsupported implementation type "%T", only struct type is supported`,
+				object,
+			)
+		}
+		var (
+			objectInstance = reflect.New(objectType).Elem().Interface()
+			schemaName     = oai.golangTypeToSchemaName(objectType)
+		)
+		if err := oai.addSchema(objectInstance); err != nil {
+			return err
+		}
+		schema.OneOf = append(schema.OneOf, SchemaRef{Ref: schemaName})
+		if in.PropertyName == "" {
+			continue
+		}
+		value, err := oai.addDiscriminatorProperty(schemaName, objectInstance, in.PropertyName)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			mapping[value] = fmt.Sprintf(`#/components/schemas/%s`, schemaName)
+		}
+	}
+	if in.PropertyName != "" {
+		schema.Discriminator = &Discriminator{
+			PropertyName: in.PropertyName,
+		}
+		if len(mapping) > 0 {
+			schema.Discriminator.Mapping = mapping
+		}
+	}
+	oai.Components.Schemas.Set(oai.golangTypeToSchemaName(interfaceType), SchemaRef{
+		Value: schema,
+	})
+	return nil
+}
+
+// addDiscriminatorProperty makes the discriminator property `propertyName` required in schema `schemaName`
+// of `object`, and returns the mapping value of the schema, which is the default value of the property.
+func (oai *OpenApiV3) addDiscriminatorProperty(schemaName string, object interface{}, propertyName string) (string, error) {
+	structFields, _ := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         object,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	for _, structField := range structFields {
+		var fieldName = gstr.Split(gstr.Trim(structField.TagPriorityName()), ",")[0]
+		if fieldName == "" {
+			fieldName = structField.Name()
+		}
+		if fieldName != propertyName {
+			continue
+		}
+		if schemaRef := oai.Components.Schemas.Get(schemaName); schemaRef != nil && schemaRef.Value != nil {
+			if !gstr.InArray(schemaRef.Value.Required, propertyName) {
+				schemaRef.Value.Required = append(schemaRef.Value.Required, propertyName)
+			}
+		}
+		return oai.fillMapWithShortTags(structField.TagMap())[gtag.Default], nil
+	}
+	return "", gerror.NewCodef(
+		gcode.CodeInvalidParameter,
+		`discriminator property "%s" not found in struct "%s"`,
+		propertyName, schemaName,
+	)
+}
@@ -53,15 +53,23 @@ type addPathInput struct {
 	Prefix   string      // Route path prefix.
 	Method   string      // Route method.
 	Function interface{} // Uniformed function.
+	Webhook  bool        // Adds as webhook, in which the Path is the webhook name.
 }
 
 func (oai *OpenApiV3) addPath(in addPathInput) error {
 	if oai.Paths == nil {
 		oai.Paths = map[string]Path{}
 	}
+	var paths = oai.Paths
+	if in.Webhook {
+		if oai.Webhooks == nil {
+			oai.Webhooks = map[string]Path{}
+		}
+		paths = oai.Webhooks
+	}
 
 	var reflectType = reflect.TypeOf(in.Function)
-	if reflectType.NumIn() != 2 || reflectType.NumOut() != 2 {
+	if reflectType.NumIn() < 2 || reflectType.NumOut() != 2 {
 		return gerror.NewCodef(
 			gcode.CodeInvalidParameter,
 			`unsupported function "%s" for OpenAPI Path register, there should be input & output structures`,
@@ -113,7 +121,7 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 		)
 	}
 
-	if v, ok := oai.Paths[in.Path]; ok {
+	if v, ok := paths[in.Path]; ok {
 		path = v
 	}
 
@@ -121,6 +129,9 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 	if in.Method == "" {
 		in.Method = gmeta.Get(inputObject.Interface(), gtag.Method).String()
 	}
+	if in.Method == "" && in.Webhook {
+		in.Method = http.MethodPost
+	}
 	if in.Method == "" {
 		return gerror.NewCodef(
 			gcode.CodeMissingParameter,
@@ -318,7 +329,7 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 	default:
 		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid method "%s"`, in.Method)
 	}
-	oai.Paths[in.Path] = path
+	paths[in.Path] = path
 	return nil
 }
 
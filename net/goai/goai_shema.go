@@ -21,7 +21,8 @@ import (
 	"github.com/gogf/gf/v2/util/gvalid"
 )
 
-// Schema is specified by OpenAPI/Swagger 3.0 standard.
+// Schema is specified by OpenAPI/Swagger 3.0 standard,
+// which is produced as JSON Schema 2020-12 for OpenAPI 3.1.
 type Schema struct {
 	OneOf                SchemaRefs     `json:"oneOf,omitempty"`
 	AnyOf                SchemaRefs     `json:"anyOf,omitempty"`
@@ -61,8 +62,22 @@ type Schema struct {
 	Discriminator        *Discriminator `json:"discriminator,omitempty"`
 	XExtensions          XExtensions    `json:"-"`
 	ValidationRules      string         `json:"-"`
+
+	// JSON Schema 2020-12 keywords, which are available since OpenAPI 3.1.
+	Const             interface{}         `json:"const,omitempty"`
+	PrefixItems       SchemaRefs          `json:"prefixItems,omitempty"`
+	Contains          *SchemaRef          `json:"contains,omitempty"`
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+	ContentMediaType  string              `json:"contentMediaType,omitempty"`
+	ContentEncoding   string              `json:"contentEncoding,omitempty"`
+
+	jsonSchema bool // Whether it is produced as JSON Schema 2020-12 for OpenAPI 3.1.
 }
 
+const (
+	jsonSchemaTypeNull = `null`
+)
+
 // Clone only clones necessary attributes.
 // TODO clone all attributes, or improve package deepcopy.
 func (s *Schema) Clone() *Schema {
@@ -86,6 +101,14 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 	if err = json.Unmarshal(b, &m); err != nil {
 		return nil, err
 	}
+	if s.jsonSchema {
+		err = s.convertToJsonSchema(m)
+	} else {
+		err = s.removeJsonSchemaKeywords(m)
+	}
+	if err != nil {
+		return nil, err
+	}
 	for k, v := range s.XExtensions {
 		if b, err = json.Marshal(v); err != nil {
 			return nil, err
@@ -95,6 +118,62 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// convertToJsonSchema converts the OpenAPI 3.0 keywords in marshaled `m` to JSON Schema 2020-12.
+func (s Schema) convertToJsonSchema(m map[string]json.RawMessage) (err error) {
+	var schemaType = s.Type
+	// There's no file type, which is string with content media type.
+	if schemaType == TypeFile {
+		schemaType = TypeString
+		if s.ContentMediaType == "" {
+			m["contentMediaType"] = []byte(`"application/octet-stream"`)
+		}
+	}
+	if schemaType != "" {
+		if s.Nullable {
+			m["type"], err = json.Marshal([]string{schemaType, jsonSchemaTypeNull})
+		} else {
+			m["type"], err = json.Marshal(schemaType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	delete(m, "nullable")
+	// The exclusive boundaries are numbers instead of booleans.
+	delete(m, "exclusiveMinimum")
+	delete(m, "exclusiveMaximum")
+	if s.ExclusiveMin && s.Min != nil {
+		m["exclusiveMinimum"] = m["minimum"]
+		delete(m, "minimum")
+	}
+	if s.ExclusiveMax && s.Max != nil {
+		m["exclusiveMaximum"] = m["maximum"]
+		delete(m, "maximum")
+	}
+	// The example is deprecated in favor of examples.
+	if example, ok := m["example"]; ok {
+		m["examples"] = append(append([]byte(`[`), example...), ']')
+		delete(m, "example")
+	}
+	return nil
+}
+
+// removeJsonSchemaKeywords removes the JSON Schema 2020-12 keywords in marshaled `m`
+// which are not supported in OpenAPI 3.0.
+func (s Schema) removeJsonSchemaKeywords(m map[string]json.RawMessage) (err error) {
+	if s.Const != nil && len(s.Enum) == 0 {
+		if m["enum"], err = json.Marshal([]interface{}{s.Const}); err != nil {
+			return err
+		}
+	}
+	for _, keyword := range []string{
+		"const", "prefixItems", "contains", "dependentRequired", "contentMediaType", "contentEncoding",
+	} {
+		delete(m, keyword)
+	}
+	return nil
+}
+
 // Discriminator is specified by OpenAPI/Swagger standard version 3.0.
 type Discriminator struct {
 	PropertyName string            `json:"propertyName"`
@@ -148,6 +227,7 @@ func (oai *OpenApiV3) structToSchema(object interface{}) (*Schema, error) {
 		schema = &Schema{
 			Properties:  createSchemas(),
 			XExtensions: make(XExtensions),
+			jsonSchema:  oai.isOpenApi31(),
 		}
 		ignoreProperties []interface{}
 	)
@@ -50,6 +50,7 @@ func (oai *OpenApiV3) newSchemaRefWithGolangType(golangType reflect.Type, tagMap
 			Type:        oaiType,
 			Format:      oaiFormat,
 			XExtensions: make(XExtensions),
+			jsonSchema:  oai.isOpenApi31(),
 		}
 	)
 	if pkgPath == "" {
@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	// OpenApiVersion30 is the OpenAPI specification version 3.0, which is used in default.
+	OpenApiVersion30 = `3.0.0`
+
+	// OpenApiVersion31 is the OpenAPI specification version 3.1, in which the schemas are
+	// produced as JSON Schema 2020-12 and the webhooks are supported.
+	// See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.1.0.md
+	OpenApiVersion31 = `3.1.0`
+)
+
+// isOpenApi31 checks and returns whether the specification version of `oai` is 3.1.
+// Note that the version should be set before adding any object to `oai`.
+func (oai *OpenApiV3) isOpenApi31() bool {
+	return gstr.HasPrefix(oai.OpenAPI, `3.1`)
+}
+
+func (oai OpenApiV3) MarshalJSON() ([]byte, error) {
+	type tempOpenApiV3 OpenApiV3 // To prevent JSON marshal recursion error.
+	if !oai.isOpenApi31() {
+		// These are not supported before version 3.1.
+		oai.JsonSchemaDialect = ""
+		oai.Webhooks = nil
+	}
+	return json.Marshal(tempOpenApiV3(oai))
+}
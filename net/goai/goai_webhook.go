@@ -0,0 +1,43 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// AddWebhookInput is the structured parameter for function OpenApiV3.AddWebhook.
+type AddWebhookInput struct {
+	Name   string      // Name specifies the webhook name, like: `newPet`.
+	Method string      // Method specifies the HTTP method if this is not configured in Meta of struct tag, it's POST in default.
+	Object interface{} // Object is a route function, whose input is the request sent to the callers and output is the expected response.
+}
+
+// AddWebhook adds a webhook which is the request initiated by the API provider to the callers,
+// described by a route function like a path. Note that webhooks are produced since OpenAPI 3.1.
+func (oai *OpenApiV3) AddWebhook(in AddWebhookInput) error {
+	if in.Name == "" {
+		return gerror.NewCode(gcode.CodeMissingParameter, `webhook name should not be empty`)
+	}
+	reflectValue := reflect.ValueOf(in.Object)
+	if reflectValue.Kind() != reflect.Func {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`unsupported parameter type "%T" for webhook, only function type is supported`,
+			in.Object,
+		)
+	}
+	return oai.addPath(addPathInput{
+		Path:     in.Name,
+		Method:   in.Method,
+		Function: in.Object,
+		Webhook:  true,
+	})
+}
@@ -1345,3 +1345,183 @@ func Test_XExtension(t *testing.T) {
 		t.Assert(oai.String(), gtest.DataContent("XExtension", "expect.json"))
 	})
 }
+
+func Test_OpenApi31_JsonSchema(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/user" method:"post"`
+		Name   string  `json:"name" nullable:"true" eg:"john"`
+		Age    float64 `json:"age" min:"0" exclusiveMinimum:"true"`
+		Kind   string  `json:"kind" const:"user"`
+	}
+	type Res struct{}
+	f := func(ctx context.Context, req *Req) (res *Res, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.OpenAPI = goai.OpenApiVersion31
+		err := oai.Add(goai.AddInput{
+			Object: f,
+		})
+		t.AssertNil(err)
+
+		t.Assert(gjson.New(oai.String()).Get("openapi"), goai.OpenApiVersion31)
+		b, err := json.Marshal(oai.Components.Schemas.Get("github.com.gogf.gf.v2.net.goai_test.Req").Value)
+		t.AssertNil(err)
+		j, err := gjson.LoadContent(b)
+		t.AssertNil(err)
+		var prefix = "properties"
+		t.Assert(j.Get(prefix+".name.type"), g.Slice{"string", "null"})
+		t.Assert(j.Get(prefix+".name.nullable"), nil)
+		t.Assert(j.Get(prefix+".name.examples"), g.Slice{"john"})
+		t.Assert(j.Get(prefix+".name.example"), nil)
+		t.Assert(j.Get(prefix+".age.exclusiveMinimum"), 0)
+		t.Assert(j.Get(prefix+".age.minimum"), nil)
+		t.Assert(j.Get(prefix+".kind.const"), "user")
+	})
+	// OpenAPI 3.0.
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		err := oai.Add(goai.AddInput{
+			Object: f,
+		})
+		t.AssertNil(err)
+
+		t.Assert(gjson.New(oai.String()).Get("openapi"), goai.OpenApiVersion30)
+		b, err := json.Marshal(oai.Components.Schemas.Get("github.com.gogf.gf.v2.net.goai_test.Req").Value)
+		t.AssertNil(err)
+		j, err := gjson.LoadContent(b)
+		t.AssertNil(err)
+		var prefix = "properties"
+		t.Assert(j.Get(prefix+".name.type"), "string")
+		t.Assert(j.Get(prefix+".name.nullable"), true)
+		t.Assert(j.Get(prefix+".name.example"), "john")
+		t.Assert(j.Get(prefix+".age.exclusiveMinimum"), true)
+		t.Assert(j.Get(prefix+".kind.const"), nil)
+		t.Assert(j.Get(prefix+".kind.enum"), g.Slice{"user"})
+	})
+}
+
+func Test_OpenApi31_Webhook(t *testing.T) {
+	type NewPetReq struct {
+		g.Meta `summary:"A new pet is added."`
+		Name   string `json:"name" v:"required"`
+	}
+	type NewPetRes struct{}
+	f := func(ctx context.Context, req *NewPetReq) (res *NewPetRes, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.OpenAPI = goai.OpenApiVersion31
+		err := oai.AddWebhook(goai.AddWebhookInput{
+			Name:   "newPet",
+			Object: f,
+		})
+		t.AssertNil(err)
+		t.Assert(len(oai.Paths), 0)
+		t.AssertNE(oai.Webhooks["newPet"].Post, nil)
+		t.Assert(oai.Webhooks["newPet"].Post.Summary, "A new pet is added.")
+
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		t.Assert(j.Get("webhooks.newPet.post.summary"), "A new pet is added.")
+	})
+	// Webhooks are not produced before OpenAPI 3.1.
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		err := oai.AddWebhook(goai.AddWebhookInput{
+			Name:   "newPet",
+			Object: f,
+		})
+		t.AssertNil(err)
+		j, err := gjson.LoadContent([]byte(oai.String()))
+		t.AssertNil(err)
+		t.Assert(j.Contains("webhooks"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNE(oai.AddWebhook(goai.AddWebhookInput{Object: f}), nil)
+		t.AssertNE(oai.AddWebhook(goai.AddWebhookInput{Name: "newPet", Object: new(NewPetReq)}), nil)
+	})
+}
+
+type OneOfPet interface {
+	Kind() string
+}
+
+type OneOfCat struct {
+	PetType string `json:"petType" d:"cat"`
+	Meow    bool   `json:"meow"`
+}
+
+type OneOfDog struct {
+	PetType string `json:"petType" d:"dog"`
+	Bark    bool   `json:"bark"`
+}
+
+func (OneOfCat) Kind() string { return "cat" }
+
+func (OneOfDog) Kind() string { return "dog" }
+
+func Test_OneOf_Discriminator(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/pet" method:"post"`
+		Pet    OneOfPet   `json:"pet"`
+		Pets   []OneOfPet `json:"pets"`
+	}
+	type Res struct{}
+	f := func(ctx context.Context, req *Req) (res *Res, err error) {
+		return
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			oai      = goai.New()
+			petName  = "github.com.gogf.gf.v2.net.goai_test.OneOfPet"
+			catName  = "github.com.gogf.gf.v2.net.goai_test.OneOfCat"
+			dogName  = "github.com.gogf.gf.v2.net.goai_test.OneOfDog"
+			reqName  = "github.com.gogf.gf.v2.net.goai_test.Req"
+			err      error
+			petValue *goai.Schema
+		)
+		err = oai.AddOneOf(goai.AddOneOfInput{
+			Interface:    (*OneOfPet)(nil),
+			PropertyName: "petType",
+			Objects:      g.Slice{OneOfCat{}, &OneOfDog{}},
+		})
+		t.AssertNil(err)
+		err = oai.Add(goai.AddInput{
+			Object: f,
+		})
+		t.AssertNil(err)
+
+		petValue = oai.Components.Schemas.Get(petName).Value
+		t.Assert(len(petValue.OneOf), 2)
+		t.Assert(petValue.OneOf[0].Ref, catName)
+		t.Assert(petValue.OneOf[1].Ref, dogName)
+		t.Assert(petValue.Discriminator.PropertyName, "petType")
+		t.Assert(petValue.Discriminator.Mapping, g.MapStrStr{
+			"cat": "#/components/schemas/" + catName,
+			"dog": "#/components/schemas/" + dogName,
+		})
+		t.Assert(oai.Components.Schemas.Get(catName).Value.Required, g.SliceStr{"petType"})
+		t.Assert(oai.Components.Schemas.Get(reqName).Value.Properties.Get("pet").Ref, petName)
+		t.Assert(oai.Components.Schemas.Get(reqName).Value.Properties.Get("pets").Value.Items.Ref, petName)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.AssertNE(oai.AddOneOf(goai.AddOneOfInput{
+			Interface: OneOfCat{},
+			Objects:   g.Slice{OneOfCat{}},
+		}), nil)
+		t.AssertNE(oai.AddOneOf(goai.AddOneOfInput{
+			Interface: (*OneOfPet)(nil),
+			Objects:   g.Slice{1},
+		}), nil)
+		t.AssertNE(oai.AddOneOf(goai.AddOneOfInput{
+			Interface:    (*OneOfPet)(nil),
+			PropertyName: "type",
+			Objects:      g.Slice{OneOfCat{}},
+		}), nil)
+	})
+}
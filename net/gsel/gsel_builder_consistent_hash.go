@@ -0,0 +1,26 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+type builderConsistentHash struct {
+	config []ConsistentHashConfig
+}
+
+// NewBuilderConsistentHash creates and returns a Builder for consistent hashing selector.
+func NewBuilderConsistentHash(config ...ConsistentHashConfig) Builder {
+	return &builderConsistentHash{
+		config: config,
+	}
+}
+
+func (*builderConsistentHash) Name() string {
+	return "BalancerConsistentHash"
+}
+
+func (b *builderConsistentHash) Build() Selector {
+	return NewSelectorConsistentHash(b.config...)
+}
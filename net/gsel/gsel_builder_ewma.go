@@ -0,0 +1,26 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+type builderEWMA struct {
+	config []EWMAConfig
+}
+
+// NewBuilderEWMA creates and returns a Builder for latency-aware EWMA selector.
+func NewBuilderEWMA(config ...EWMAConfig) Builder {
+	return &builderEWMA{
+		config: config,
+	}
+}
+
+func (*builderEWMA) Name() string {
+	return "BalancerEWMA"
+}
+
+func (b *builderEWMA) Build() Selector {
+	return NewSelectorEWMA(b.config...)
+}
@@ -0,0 +1,29 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+type builderHealth struct {
+	builder Builder
+	config  HealthConfig
+}
+
+// NewBuilderHealth creates and returns a Builder that wraps the selectors built by `builder`
+// with health-based node filtering. See NewSelectorHealth.
+func NewBuilderHealth(builder Builder, config HealthConfig) Builder {
+	return &builderHealth{
+		builder: builder,
+		config:  config,
+	}
+}
+
+func (b *builderHealth) Name() string {
+	return b.builder.Name()
+}
+
+func (b *builderHealth) Build() Selector {
+	return NewSelectorHealth(b.builder.Build(), b.config)
+}
@@ -0,0 +1,34 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+type builderSlowStart struct {
+	builder Builder
+	config  []SlowStartConfig
+	nodes   *slowStartNodes // Time the nodes appear, shared by the built selectors.
+}
+
+// NewBuilderSlowStart creates and returns a Builder that wraps the selectors built by `builder`
+// with slow-start warm-up weighting. See NewSelectorSlowStart.
+//
+// The time nodes appear is shared by all the selectors it builds, so the warming nodes keep
+// warming up if the selector is rebuilt, like the picker of gRPC balancer rebuilt on node changes.
+func NewBuilderSlowStart(builder Builder, config ...SlowStartConfig) Builder {
+	return &builderSlowStart{
+		builder: builder,
+		config:  config,
+		nodes:   newSlowStartNodes(),
+	}
+}
+
+func (b *builderSlowStart) Name() string {
+	return b.builder.Name()
+}
+
+func (b *builderSlowStart) Build() Selector {
+	return newSelectorSlowStart(b.builder.Build(), b.nodes, b.config...)
+}
@@ -0,0 +1,29 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+type builderSubset struct {
+	builder Builder
+	config  []SubsetConfig
+}
+
+// NewBuilderSubset creates and returns a Builder for metadata-based subset routing selector,
+// which balances the subset nodes using selectors built by `builder`. See NewSelectorSubset.
+func NewBuilderSubset(builder Builder, config ...SubsetConfig) Builder {
+	return &builderSubset{
+		builder: builder,
+		config:  config,
+	}
+}
+
+func (b *builderSubset) Name() string {
+	return b.builder.Name()
+}
+
+func (b *builderSubset) Build() Selector {
+	return NewSelectorSubset(b.builder, b.config...)
+}
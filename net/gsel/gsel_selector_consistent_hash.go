@@ -0,0 +1,190 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// ConsistentHashConfig is the configuration for consistent hashing selector.
+type ConsistentHashConfig struct {
+	// VirtualNodes is the count of virtual nodes on the hash ring for each node. Default is 160.
+	VirtualNodes int
+
+	// BoundedLoadFactor enables consistent hashing with bounded loads if it is greater than 1.
+	// The in-flight requests of each node are limited to ceil(factor * average in-flight requests),
+	// and the request is forwarded to the next node on the ring if the node is overloaded.
+	// A commonly used value is 1.25. It is disabled in default.
+	BoundedLoadFactor float64
+
+	// KeyFunc retrieves the hash key from context.
+	// It uses the key set by WithHashKey in default.
+	KeyFunc func(ctx context.Context) string
+}
+
+type selectorConsistentHash struct {
+	mu       sync.RWMutex
+	config   ConsistentHashConfig
+	nodes    Nodes
+	ring     []consistentHashPoint // Sorted virtual nodes on the ring.
+	inflight map[string]*gtype.Int // Node address to its in-flight request count.
+	total    *gtype.Int            // Total in-flight request count.
+}
+
+// consistentHashPoint is a virtual node on the hash ring.
+type consistentHashPoint struct {
+	hash  uint64
+	index int // Index of the node in nodes.
+}
+
+// ctxKeyHashKey is the context key for hash key.
+type ctxKeyHashKey struct{}
+
+const (
+	defaultConsistentHashVirtualNodes = 160
+)
+
+// WithHashKey returns a new context carrying the hash key `key` for consistent hashing selector,
+// which pins the requests with the same key, like user id or tenant id, to the same node.
+func WithHashKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, ctxKeyHashKey{}, key)
+}
+
+// GetHashKey retrieves and returns the hash key from context, which is set by WithHashKey.
+func GetHashKey(ctx context.Context) string {
+	if v, ok := ctx.Value(ctxKeyHashKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// NewSelectorConsistentHash creates and returns a consistent hashing selector.
+// The requests without hash key are distributed randomly.
+func NewSelectorConsistentHash(config ...ConsistentHashConfig) Selector {
+	var c ConsistentHashConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.VirtualNodes <= 0 {
+		c.VirtualNodes = defaultConsistentHashVirtualNodes
+	}
+	if c.KeyFunc == nil {
+		c.KeyFunc = GetHashKey
+	}
+	return &selectorConsistentHash{
+		config:   c,
+		nodes:    make(Nodes, 0),
+		inflight: make(map[string]*gtype.Int),
+		total:    gtype.NewInt(),
+	}
+}
+
+func (s *selectorConsistentHash) Update(ctx context.Context, nodes Nodes) error {
+	intlog.Printf(ctx, `Update nodes: %s`, nodes.String())
+	var (
+		ring     = make([]consistentHashPoint, 0, len(nodes)*s.config.VirtualNodes)
+		inflight = make(map[string]*gtype.Int)
+	)
+	for i, node := range nodes {
+		address := node.Address()
+		for j := 0; j < s.config.VirtualNodes; j++ {
+			ring = append(ring, consistentHashPoint{
+				hash:  consistentHash(address + "#" + strconv.Itoa(j)),
+				index: i,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The in-flight counts of existing nodes are retained.
+	for _, node := range nodes {
+		if v, ok := s.inflight[node.Address()]; ok {
+			inflight[node.Address()] = v
+		} else {
+			inflight[node.Address()] = gtype.NewInt()
+		}
+	}
+	s.nodes = nodes
+	s.ring = ring
+	s.inflight = inflight
+	return nil
+}
+
+func (s *selectorConsistentHash) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.nodes) == 0 {
+		return nil, nil, nil
+	}
+	var (
+		key      = s.config.KeyFunc(ctx)
+		position int
+	)
+	if key == "" {
+		position = grand.Intn(len(s.ring))
+	} else {
+		hash := consistentHash(key)
+		position = sort.Search(len(s.ring), func(i int) bool {
+			return s.ring[i].hash >= hash
+		})
+	}
+	node = s.pickFromPosition(position)
+	var (
+		inflight = s.inflight[node.Address()]
+		total    = s.total
+	)
+	inflight.Add(1)
+	total.Add(1)
+	done = func(ctx context.Context, di DoneInfo) {
+		inflight.Add(-1)
+		total.Add(-1)
+	}
+	intlog.Printf(ctx, `Picked node: %s`, node.Address())
+	return node, done, nil
+}
+
+// pickFromPosition walks the ring clockwise from `position` and returns the first node
+// that is not overloaded.
+func (s *selectorConsistentHash) pickFromPosition(position int) Node {
+	if s.config.BoundedLoadFactor <= 1 {
+		return s.nodes[s.ring[position%len(s.ring)].index]
+	}
+	capacity := int(math.Ceil(s.config.BoundedLoadFactor * float64(s.total.Val()+1) / float64(len(s.nodes))))
+	for i := 0; i < len(s.ring); i++ {
+		node := s.nodes[s.ring[(position+i)%len(s.ring)].index]
+		if s.inflight[node.Address()].Val() < capacity {
+			return node
+		}
+	}
+	return s.nodes[s.ring[position%len(s.ring)].index]
+}
+
+// consistentHash returns the hash value of `key` on the ring.
+func consistentHash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	// The finalizer of murmur3 improves the distribution of similar keys.
+	v := h.Sum64()
+	v ^= v >> 33
+	v *= 0xff51afd7ed558ccd
+	v ^= v >> 33
+	v *= 0xc4ceb9fe1a85ec53
+	v ^= v >> 33
+	return v
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// EWMAConfig is the configuration for latency-aware EWMA selector.
+type EWMAConfig struct {
+	// DecayTime is the time constant of the exponentially weighted moving average of latency.
+	// The smaller it is, the faster the average reacts to latency changes. Default is 10s.
+	DecayTime time.Duration
+
+	// ErrorPenalty is the latency recorded for the request finished with error, which makes
+	// the failing node less preferred. Default is 1s.
+	ErrorPenalty time.Duration
+}
+
+// selectorEWMA picks the node with the least load using "power of two choices", the load of a node
+// is its EWMA latency multiplied by its in-flight request count plus one.
+type selectorEWMA struct {
+	mu     sync.RWMutex
+	config EWMAConfig
+	nodes  []*ewmaNode
+}
+
+type ewmaNode struct {
+	Node
+	mu       sync.Mutex
+	latency  float64 // EWMA latency in nanoseconds.
+	stamp    time.Time
+	inflight *gtype.Int
+}
+
+const (
+	defaultEWMADecayTime    = 10 * time.Second
+	defaultEWMAErrorPenalty = time.Second
+)
+
+// NewSelectorEWMA creates and returns a latency-aware selector, which tracks the in-flight requests
+// and the exponentially weighted moving average of response latency of each node using DoneFunc.
+//
+// Note that the DoneFunc returned by Pick must be called when the request is done.
+func NewSelectorEWMA(config ...EWMAConfig) Selector {
+	var c EWMAConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.DecayTime <= 0 {
+		c.DecayTime = defaultEWMADecayTime
+	}
+	if c.ErrorPenalty <= 0 {
+		c.ErrorPenalty = defaultEWMAErrorPenalty
+	}
+	return &selectorEWMA{
+		config: c,
+		nodes:  make([]*ewmaNode, 0),
+	}
+}
+
+func (s *selectorEWMA) Update(ctx context.Context, nodes Nodes) error {
+	intlog.Printf(ctx, `Update nodes: %s`, nodes.String())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		existing   = make(map[string]*ewmaNode, len(s.nodes))
+		newNodes   = make([]*ewmaNode, 0, len(nodes))
+		avgLatency float64
+	)
+	for _, v := range s.nodes {
+		existing[v.Address()] = v
+		avgLatency += v.getLatency()
+	}
+	if len(s.nodes) > 0 {
+		avgLatency /= float64(len(s.nodes))
+	}
+	for _, v := range nodes {
+		if node, ok := existing[v.Address()]; ok {
+			node.Node = v
+			newNodes = append(newNodes, node)
+			continue
+		}
+		// New node starts with the average latency of existing nodes,
+		// so that it is neither flooded nor starved.
+		newNodes = append(newNodes, &ewmaNode{
+			Node:     v,
+			latency:  avgLatency,
+			stamp:    time.Now(),
+			inflight: gtype.NewInt(),
+		})
+	}
+	s.nodes = newNodes
+	return nil
+}
+
+func (s *selectorEWMA) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var pickedNode *ewmaNode
+	switch len(s.nodes) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		pickedNode = s.nodes[0]
+	default:
+		// Power of two choices.
+		var (
+			i = grand.Intn(len(s.nodes))
+			j = grand.Intn(len(s.nodes) - 1)
+		)
+		if j >= i {
+			j++
+		}
+		pickedNode = s.nodes[i]
+		if s.nodes[j].load() < pickedNode.load() {
+			pickedNode = s.nodes[j]
+		}
+	}
+	var (
+		start   = time.Now()
+		penalty = s.config.ErrorPenalty
+		decay   = s.config.DecayTime
+	)
+	pickedNode.inflight.Add(1)
+	done = func(ctx context.Context, di DoneInfo) {
+		pickedNode.inflight.Add(-1)
+		latency := time.Since(start)
+		if di.Err != nil && latency < penalty {
+			latency = penalty
+		}
+		pickedNode.observe(latency, decay)
+	}
+	node = pickedNode.Node
+	intlog.Printf(ctx, `Picked node: %s`, node.Address())
+	return node, done, nil
+}
+
+// observe updates the EWMA latency with the latency of a finished request.
+// It uses peak EWMA that the latency higher than the average takes effect immediately,
+// so that the degraded node is avoided quickly and recovers gradually.
+func (n *ewmaNode) observe(latency, decay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if float64(latency) > n.latency {
+		n.latency = float64(latency)
+		n.stamp = time.Now()
+		return
+	}
+	var (
+		now     = time.Now()
+		elapsed = now.Sub(n.stamp)
+		weight  = math.Exp(-float64(elapsed) / float64(decay))
+	)
+	n.latency = n.latency*weight + float64(latency)*(1-weight)
+	n.stamp = now
+}
+
+// getLatency returns the EWMA latency of the node.
+func (n *ewmaNode) getLatency() float64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.latency
+}
+
+// load returns the load of the node for comparison.
+func (n *ewmaNode) load() float64 {
+	// Plus one to distinguish the nodes with zero latency by their in-flight requests.
+	return (n.getLatency() + 1) * float64(n.inflight.Val()+1)
+}
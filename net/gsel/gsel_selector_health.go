@@ -0,0 +1,222 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gsvc"
+)
+
+// HealthConfig is the configuration for health-based node filtering.
+type HealthConfig struct {
+	// Checker checks the health of each node. It is required.
+	Checker gsvc.HealthChecker
+
+	// Interval is the interval of health checking. Default is 10s.
+	Interval time.Duration
+
+	// Timeout is the timeout of each health checking. Default is 3s.
+	Timeout time.Duration
+
+	// FailureThreshold is the count of consecutive failures marking a node unhealthy. Default is 1.
+	FailureThreshold int
+
+	// SuccessThreshold is the count of consecutive successes re-admitting an unhealthy node. Default is 1.
+	SuccessThreshold int
+}
+
+// selectorHealth wraps a Selector, which periodically checks the health of nodes and only
+// updates the healthy nodes to the wrapped Selector.
+type selectorHealth struct {
+	mu        sync.Mutex
+	selector  Selector
+	config    HealthConfig
+	nodes     Nodes                   // All nodes updated.
+	states    map[string]*healthState // Node address to its health state.
+	picked    string                  // Addresses of nodes in wrapped Selector, for change detection.
+	checkedAt time.Time               // Time of the last health checking.
+	checking  *gtype.Bool             // Whether the health checking is running.
+	closed    *gtype.Bool
+}
+
+// healthState is the health state of a node.
+type healthState struct {
+	healthy   bool
+	failures  int // Consecutive failures.
+	successes int // Consecutive successes.
+}
+
+const (
+	defaultHealthInterval         = 10 * time.Second
+	defaultHealthTimeout          = 3 * time.Second
+	defaultHealthFailureThreshold = 1
+	defaultHealthSuccessThreshold = 1
+)
+
+// NewSelectorHealth creates and returns a Selector that wraps `selector` with health-based node
+// filtering. It checks the health of nodes periodically in background, and the unhealthy nodes are
+// excluded from selection until they recover. New nodes are treated healthy until checked.
+//
+// The checking is triggered by Update and Pick once the Interval elapses, so there's no goroutine
+// kept running for the selector, which might be replaced without closing, like the selectors built
+// for each picker of gRPC balancer.
+//
+// Note that all nodes are updated to the wrapped selector if all of them are unhealthy,
+// as failing requests is better than no available node. The returned Selector implements
+// io.Closer, which stops the health checking.
+func NewSelectorHealth(selector Selector, config HealthConfig) Selector {
+	if config.Interval <= 0 {
+		config.Interval = defaultHealthInterval
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultHealthTimeout
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultHealthFailureThreshold
+	}
+	if config.SuccessThreshold <= 0 {
+		config.SuccessThreshold = defaultHealthSuccessThreshold
+	}
+	return &selectorHealth{
+		selector: selector,
+		config:   config,
+		nodes:    make(Nodes, 0),
+		states:   make(map[string]*healthState),
+		checking: gtype.NewBool(),
+		closed:   gtype.NewBool(),
+	}
+}
+
+func (s *selectorHealth) Update(ctx context.Context, nodes Nodes) error {
+	s.mu.Lock()
+	states := make(map[string]*healthState, len(nodes))
+	for _, node := range nodes {
+		if state, ok := s.states[node.Address()]; ok {
+			states[node.Address()] = state
+		} else {
+			states[node.Address()] = &healthState{healthy: true}
+		}
+	}
+	s.nodes = nodes
+	s.states = states
+	s.picked = ""
+	s.mu.Unlock()
+	s.triggerCheck()
+	return s.updateHealthyNodes(ctx)
+}
+
+func (s *selectorHealth) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	s.triggerCheck()
+	return s.selector.Pick(ctx)
+}
+
+// Close stops the health checking, and closes the wrapped Selector if it implements io.Closer.
+func (s *selectorHealth) Close() error {
+	s.closed.Set(true)
+	if closer, ok := s.selector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// triggerCheck checks the health of nodes in background if the Interval elapses since the last checking,
+// and no checking is running.
+func (s *selectorHealth) triggerCheck() {
+	if s.config.Checker == nil || s.closed.Val() {
+		return
+	}
+	s.mu.Lock()
+	if !s.checkedAt.IsZero() && time.Since(s.checkedAt) < s.config.Interval {
+		s.mu.Unlock()
+		return
+	}
+	if !s.checking.Cas(false, true) {
+		s.mu.Unlock()
+		return
+	}
+	s.checkedAt = time.Now()
+	s.mu.Unlock()
+	go func() {
+		defer s.checking.Set(false)
+		s.checkNodes(context.Background())
+	}()
+}
+
+// checkNodes checks the health of all nodes concurrently and updates the healthy nodes
+// to the wrapped Selector if any health state changes.
+func (s *selectorHealth) checkNodes(ctx context.Context) {
+	s.mu.Lock()
+	nodes := s.nodes
+	s.mu.Unlock()
+	var (
+		wg      sync.WaitGroup
+		results = make([]error, len(nodes))
+	)
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node Node) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+			defer cancel()
+			results[i] = s.config.Checker.Check(checkCtx, node.Service(), node.Address())
+		}(i, node)
+	}
+	wg.Wait()
+	s.mu.Lock()
+	for i, node := range nodes {
+		state, ok := s.states[node.Address()]
+		if !ok {
+			continue
+		}
+		if results[i] != nil {
+			state.failures++
+			state.successes = 0
+			if state.healthy && state.failures >= s.config.FailureThreshold {
+				intlog.Printf(ctx, `node "%s" is unhealthy: %v`, node.Address(), results[i])
+				state.healthy = false
+			}
+		} else {
+			state.successes++
+			state.failures = 0
+			if !state.healthy && state.successes >= s.config.SuccessThreshold {
+				intlog.Printf(ctx, `node "%s" recovers healthy`, node.Address())
+				state.healthy = true
+			}
+		}
+	}
+	s.mu.Unlock()
+	if err := s.updateHealthyNodes(ctx); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// updateHealthyNodes updates the healthy nodes to the wrapped Selector if they change.
+func (s *selectorHealth) updateHealthyNodes(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	healthyNodes := make(Nodes, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		if s.states[node.Address()].healthy {
+			healthyNodes = append(healthyNodes, node)
+		}
+	}
+	if len(healthyNodes) == 0 {
+		healthyNodes = s.nodes
+	}
+	picked := healthyNodes.String()
+	if picked == s.picked {
+		return nil
+	}
+	s.picked = picked
+	return s.selector.Update(ctx, healthyNodes)
+}
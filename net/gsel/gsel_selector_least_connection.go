@@ -12,6 +12,7 @@ import (
 
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/grand"
 )
 
 type selectorLeastConnection struct {
@@ -32,16 +33,27 @@ func NewSelectorLeastConnection() Selector {
 
 func (s *selectorLeastConnection) Update(ctx context.Context, nodes Nodes) error {
 	intlog.Printf(ctx, `Update nodes: %s`, nodes.String())
-	var newNodes []*leastConnectionNode
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The in-flight counts of existing nodes are retained.
+	var (
+		newNodes = make([]*leastConnectionNode, 0, len(nodes))
+		inflight = make(map[string]*gtype.Int, len(s.nodes))
+	)
+	for _, v := range s.nodes {
+		inflight[v.Address()] = v.inflight
+	}
 	for _, v := range nodes {
 		node := v
+		counter, ok := inflight[node.Address()]
+		if !ok {
+			counter = gtype.NewInt()
+		}
 		newNodes = append(newNodes, &leastConnectionNode{
 			Node:     node,
-			inflight: gtype.NewInt(),
+			inflight: counter,
 		})
 	}
-	s.mu.Lock()
-	defer s.mu.Unlock()
 	s.nodes = newNodes
 	return nil
 }
@@ -49,14 +61,23 @@ func (s *selectorLeastConnection) Update(ctx context.Context, nodes Nodes) error
 func (s *selectorLeastConnection) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	var pickedNode *leastConnectionNode
-	if len(s.nodes) == 1 {
-		pickedNode = s.nodes[0]
-	} else {
-		for _, v := range s.nodes {
-			if pickedNode == nil {
-				pickedNode = v
-			} else if v.inflight.Val() < pickedNode.inflight.Val() {
+	if len(s.nodes) == 0 {
+		return nil, nil, nil
+	}
+	var (
+		pickedNode *leastConnectionNode
+		ties       int
+	)
+	// It picks randomly among the nodes having the same least in-flight requests,
+	// so that the first node is not always preferred.
+	for _, v := range s.nodes {
+		switch {
+		case pickedNode == nil || v.inflight.Val() < pickedNode.inflight.Val():
+			pickedNode = v
+			ties = 1
+		case v.inflight.Val() == pickedNode.inflight.Val():
+			ties++
+			if grand.Intn(ties) == 0 {
 				pickedNode = v
 			}
 		}
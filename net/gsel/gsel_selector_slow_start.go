@@ -0,0 +1,170 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// SlowStartConfig is the configuration for slow-start warm-up weighting.
+type SlowStartConfig struct {
+	// Window is the duration that the traffic share of a new node ramps up to full. Default is 30s.
+	Window time.Duration
+
+	// Aggression controls the ramping curve, the weight of a warming node is
+	// (elapsed / Window) ^ (1 / Aggression). The larger it is, the faster the traffic increases
+	// at the beginning. Default is 1.0, which is linear.
+	Aggression float64
+
+	// MinWeight is the minimum weight of a warming node in range (0, 1]. Default is 0.1.
+	MinWeight float64
+}
+
+// selectorSlowStart wraps a Selector, which gradually ramps up the traffic of new nodes.
+type selectorSlowStart struct {
+	selector Selector
+	config   SlowStartConfig
+	nodes    *slowStartNodes
+}
+
+// slowStartNodes records the time each node appears, which is shared by the selectors built by the same
+// Builder, as the selector might be rebuilt for node changes, like the pickers of gRPC balancer.
+type slowStartNodes struct {
+	mu       sync.RWMutex
+	services map[string]map[string]time.Time // Service name to its node addresses and the time they appear.
+}
+
+const (
+	defaultSlowStartWindow     = 30 * time.Second
+	defaultSlowStartAggression = 1.0
+	defaultSlowStartMinWeight  = 0.1
+	slowStartMaxRepick         = 3
+)
+
+// NewSelectorSlowStart creates and returns a Selector that wraps `selector` with slow-start warm-up
+// weighting, which is applicable to any selector. When a new or recovered node appears in Update,
+// the picked warming node is accepted by the probability of its weight, or else it picks again,
+// so that its traffic share ramps up gradually over the window to avoid cold-cache latency spikes.
+//
+// Note that the nodes in the first Update of each service are treated warmed, and the node removed
+// and then added again is treated as a recovered node.
+func NewSelectorSlowStart(selector Selector, config ...SlowStartConfig) Selector {
+	return newSelectorSlowStart(selector, newSlowStartNodes(), config...)
+}
+
+// newSelectorSlowStart creates and returns a slow-start Selector recording the time nodes appear in `nodes`.
+func newSelectorSlowStart(selector Selector, nodes *slowStartNodes, config ...SlowStartConfig) Selector {
+	var c SlowStartConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.Window <= 0 {
+		c.Window = defaultSlowStartWindow
+	}
+	if c.Aggression <= 0 {
+		c.Aggression = defaultSlowStartAggression
+	}
+	if c.MinWeight <= 0 || c.MinWeight > 1 {
+		c.MinWeight = defaultSlowStartMinWeight
+	}
+	return &selectorSlowStart{
+		selector: selector,
+		config:   c,
+		nodes:    nodes,
+	}
+}
+
+func (s *selectorSlowStart) Update(ctx context.Context, nodes Nodes) error {
+	s.nodes.update(ctx, nodes)
+	return s.selector.Update(ctx, nodes)
+}
+
+func (s *selectorSlowStart) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	for i := 0; ; i++ {
+		if node, done, err = s.selector.Pick(ctx); err != nil || node == nil {
+			return
+		}
+		if i >= slowStartMaxRepick {
+			return
+		}
+		weight := s.getWeight(node)
+		if weight >= 1 || grand.MeetProb(float32(weight)) {
+			return
+		}
+		// The rejected pick is done without error, as no request is sent.
+		if done != nil {
+			done(ctx, DoneInfo{})
+		}
+	}
+}
+
+// getWeight returns the warm-up weight of `node` in range [MinWeight, 1].
+func (s *selectorSlowStart) getWeight(node Node) float64 {
+	appeared, ok := s.nodes.appeared(node)
+	if !ok || appeared.IsZero() {
+		return 1
+	}
+	elapsed := time.Since(appeared)
+	if elapsed >= s.config.Window {
+		return 1
+	}
+	weight := math.Pow(float64(elapsed)/float64(s.config.Window), 1/s.config.Aggression)
+	return math.Max(weight, s.config.MinWeight)
+}
+
+// newSlowStartNodes creates and returns an empty slowStartNodes.
+func newSlowStartNodes() *slowStartNodes {
+	return &slowStartNodes{
+		services: make(map[string]map[string]time.Time),
+	}
+}
+
+// update records the time `nodes` appear, and removes the absent nodes of their services.
+// The nodes of the first update of the service are treated warmed with zero time.
+func (n *slowStartNodes) update(ctx context.Context, nodes Nodes) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var (
+		now     = time.Now()
+		updated = make(map[string]map[string]time.Time)
+	)
+	for _, node := range nodes {
+		var (
+			name    = node.Service().GetName()
+			address = node.Address()
+		)
+		if updated[name] == nil {
+			updated[name] = make(map[string]time.Time)
+		}
+		previous, started := n.services[name]
+		if t, ok := previous[address]; ok {
+			updated[name][address] = t
+		} else if started {
+			intlog.Printf(ctx, `node "%s" starts warming up`, address)
+			updated[name][address] = now
+		} else {
+			updated[name][address] = time.Time{}
+		}
+	}
+	for name, appeared := range updated {
+		n.services[name] = appeared
+	}
+}
+
+// appeared returns the time `node` appears.
+func (n *slowStartNodes) appeared(node Node) (t time.Time, ok bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	t, ok = n.services[node.Service().GetName()][node.Address()]
+	return
+}
@@ -0,0 +1,187 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gsvc"
+)
+
+// SubsetConfig is the configuration for metadata-based subset routing.
+type SubsetConfig struct {
+	// Keys are the route metadata keys used for filtering nodes in priority order, like
+	// []string{gsvc.MDVersion, gsvc.MDZone}. If no node matches all the keys, the keys are
+	// dropped from the last one by one until any node matches, which enables locality-aware
+	// routing with degradation. It uses all keys of route metadata in context if empty.
+	Keys []string
+
+	// ExclusiveKeys are the metadata flags marking the nodes exclusive, like gsvc.MDCanary.
+	// The node having any of these flags true only serves the requests having the same flag
+	// in route metadata.
+	ExclusiveKeys []string
+
+	// Strict specifies no node is picked if no node matches the route metadata,
+	// or else it falls back to all the candidate nodes.
+	Strict bool
+}
+
+// selectorSubset filters the nodes by route metadata from context and balances the
+// subset nodes using the selector built for the subset.
+type selectorSubset struct {
+	mu        sync.RWMutex
+	builder   Builder
+	config    SubsetConfig
+	nodes     Nodes
+	selectors map[string]Selector // Addresses of subset nodes to its selector.
+}
+
+// NewSelectorSubset creates and returns a selector that filters the candidate nodes by the route
+// metadata of the caller, which is set in context using gsvc.WithRouteMetadata. The subset
+// nodes are balanced by the selectors built by `builder`.
+func NewSelectorSubset(builder Builder, config ...SubsetConfig) Selector {
+	var c SubsetConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	return &selectorSubset{
+		builder:   builder,
+		config:    c,
+		nodes:     make(Nodes, 0),
+		selectors: make(map[string]Selector),
+	}
+}
+
+func (s *selectorSubset) Update(ctx context.Context, nodes Nodes) error {
+	intlog.Printf(ctx, `Update nodes: %s`, nodes.String())
+	s.mu.Lock()
+	replaced := s.selectors
+	s.nodes = nodes
+	s.selectors = make(map[string]Selector)
+	s.mu.Unlock()
+	closeSelectors(ctx, replaced)
+	return nil
+}
+
+func (s *selectorSubset) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	subset := s.filterNodes(gsvc.GetRouteMetadata(ctx))
+	if len(subset) == 0 {
+		return nil, nil, nil
+	}
+	selector, err := s.getSelector(ctx, subset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return selector.Pick(ctx)
+}
+
+// Close closes all the selectors built for subsets.
+func (s *selectorSubset) Close() error {
+	s.mu.Lock()
+	replaced := s.selectors
+	s.selectors = make(map[string]Selector)
+	s.mu.Unlock()
+	closeSelectors(context.Background(), replaced)
+	return nil
+}
+
+// filterNodes returns the nodes matching route metadata `md`.
+func (s *selectorSubset) filterNodes(md gsvc.Metadata) Nodes {
+	s.mu.RLock()
+	nodes := s.nodes
+	s.mu.RUnlock()
+	// Exclusive nodes are only for the requests having the same flag.
+	candidates := make(Nodes, 0, len(nodes))
+	for _, node := range nodes {
+		if s.isExcluded(node, md) {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	keys := s.config.Keys
+	if len(keys) == 0 {
+		for k := range md {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+	for n := len(keys); n > 0; n-- {
+		filter := make(gsvc.Metadata)
+		for _, k := range keys[:n] {
+			if v, ok := md[k]; ok {
+				filter[k] = v
+			}
+		}
+		if len(filter) == 0 {
+			continue
+		}
+		subset := make(Nodes, 0, len(candidates))
+		for _, node := range candidates {
+			if gsvc.MatchMetadata(node.Service(), filter) {
+				subset = append(subset, node)
+			}
+		}
+		if len(subset) > 0 || s.config.Strict {
+			return subset
+		}
+		// Only the complete metadata is matched in strict mode or without priority keys.
+		if len(s.config.Keys) == 0 {
+			break
+		}
+	}
+	return candidates
+}
+
+// isExcluded checks and returns whether `node` is exclusive for other requests.
+func (s *selectorSubset) isExcluded(node Node, md gsvc.Metadata) bool {
+	metadata := node.Service().GetMetadata()
+	for _, key := range s.config.ExclusiveKeys {
+		if v := metadata.Get(key); v != nil && v.Bool() {
+			if rv := md.Get(key); rv == nil || !rv.Bool() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getSelector returns the selector for `subset`, which is created and updated if not exist.
+func (s *selectorSubset) getSelector(ctx context.Context, subset Nodes) (Selector, error) {
+	key := subset.String()
+	s.mu.RLock()
+	selector, ok := s.selectors[key]
+	s.mu.RUnlock()
+	if ok {
+		return selector, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if selector, ok = s.selectors[key]; ok {
+		return selector, nil
+	}
+	selector = s.builder.Build()
+	if err := selector.Update(ctx, subset); err != nil {
+		return nil, err
+	}
+	s.selectors[key] = selector
+	return selector, nil
+}
+
+// closeSelectors closes `selectors` that implement io.Closer, like the selectors with health checking.
+func closeSelectors(ctx context.Context, selectors map[string]Selector) {
+	for _, selector := range selectors {
+		if closer, ok := selector.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				intlog.Errorf(ctx, `%+v`, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ConsistentHash(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewBuilderConsistentHash().Build()
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3")))
+
+		// The same key is pinned to the same node.
+		var (
+			keyCtx       = gsel.WithHashKey(ctx, "user-1")
+			node, _, err = selector.Pick(keyCtx)
+		)
+		t.AssertNil(err)
+		for i := 0; i < 10; i++ {
+			picked, done, err := selector.Pick(keyCtx)
+			t.AssertNil(err)
+			t.Assert(picked.Address(), node.Address())
+			done(ctx, gsel.DoneInfo{})
+		}
+
+		// Keys are distributed among nodes.
+		counts := make(map[string]int)
+		for i := 0; i < 3000; i++ {
+			picked, _, _ := selector.Pick(gsel.WithHashKey(ctx, fmt.Sprintf("user-%d", i)))
+			counts[picked.Address()]++
+		}
+		t.Assert(len(counts), 3)
+		for _, count := range counts {
+			t.AssertGT(count, 600)
+		}
+
+		// Only the keys of removed node are remapped.
+		var (
+			before = make(map[string]string)
+			moved  int
+		)
+		for i := 0; i < 300; i++ {
+			key := fmt.Sprintf("user-%d", i)
+			picked, _, _ := selector.Pick(gsel.WithHashKey(ctx, key))
+			before[key] = picked.Address()
+		}
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		for key, address := range before {
+			picked, _, _ := selector.Pick(gsel.WithHashKey(ctx, key))
+			if picked.Address() != address {
+				t.Assert(address, "127.0.0.1:3")
+				moved++
+			}
+		}
+		t.AssertGT(moved, 0)
+	})
+}
+
+func Test_ConsistentHash_BoundedLoad(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorConsistentHash(gsel.ConsistentHashConfig{
+			BoundedLoadFactor: 1.25,
+		})
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3")))
+		// All requests with the same key are spread if the pinned node is overloaded.
+		var (
+			keyCtx = gsel.WithHashKey(ctx, "hot-key")
+			counts = make(map[string]int)
+		)
+		for i := 0; i < 30; i++ {
+			picked, _, err := selector.Pick(keyCtx)
+			t.AssertNil(err)
+			counts[picked.Address()]++
+		}
+		t.Assert(len(counts), 3)
+		for _, count := range counts {
+			t.AssertLE(count, 13)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorConsistentHash(gsel.ConsistentHashConfig{
+			KeyFunc: func(ctx context.Context) string {
+				return "fixed"
+			},
+		})
+		node, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNil(node)
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		node, _, err = selector.Pick(ctx)
+		t.AssertNil(err)
+		for i := 0; i < 10; i++ {
+			picked, _, _ := selector.Pick(ctx)
+			t.Assert(picked.Address(), node.Address())
+		}
+	})
+}
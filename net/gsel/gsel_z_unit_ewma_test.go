@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_EWMA(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewBuilderEWMA().Build()
+		node, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNil(node)
+
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		// The failed node is penalized and avoided.
+		failed, done, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		done(ctx, gsel.DoneInfo{Err: errors.New("failed")})
+		for i := 0; i < 10; i++ {
+			picked, done, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			t.AssertNE(picked.Address(), failed.Address())
+			done(ctx, gsel.DoneInfo{})
+		}
+
+		// The latency of the new node is initialized with average latency.
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3")))
+		counts := make(map[string]int)
+		for i := 0; i < 100; i++ {
+			picked, done, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			counts[picked.Address()]++
+			done(ctx, gsel.DoneInfo{})
+		}
+		t.AssertGT(counts["127.0.0.1:3"], 0)
+		t.Assert(counts[failed.Address()] < counts["127.0.0.1:3"], true)
+	})
+	// In-flight requests are considered.
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorEWMA()
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		first, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		second, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNE(first.Address(), second.Address())
+	})
+}
+
+func Test_LeastConnection(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewBuilderLeastConnection().Build()
+		node, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNil(node)
+
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		first, firstDone, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		second, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNE(first.Address(), second.Address())
+
+		// In-flight counts are retained after updating.
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3")))
+		third, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.Assert(third.Address(), "127.0.0.1:3")
+
+		firstDone(ctx, gsel.DoneInfo{})
+		picked, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.Assert(picked.Address(), first.Address())
+	})
+}
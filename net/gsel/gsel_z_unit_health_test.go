@@ -0,0 +1,125 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Health(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			unhealthy = gset.NewStrSet(true)
+			checker   = gsvc.HealthCheckerFunc(func(ctx context.Context, service gsvc.Service, address string) error {
+				if unhealthy.Contains(address) {
+					return errors.New("unhealthy")
+				}
+				return nil
+			})
+			selector = gsel.NewBuilderHealth(gsel.NewBuilderRoundRobin(), gsel.HealthConfig{
+				Checker:          checker,
+				Interval:         20 * time.Millisecond,
+				SuccessThreshold: 2,
+			}).Build()
+			pickAll = func() []string {
+				// The health checking is triggered by picking after the interval.
+				_, _, err := selector.Pick(ctx)
+				t.AssertNil(err)
+				time.Sleep(50 * time.Millisecond)
+				var addresses = gset.NewStrSet()
+				for i := 0; i < 10; i++ {
+					node, _, err := selector.Pick(ctx)
+					t.AssertNil(err)
+					addresses.Add(node.Address())
+				}
+				return addresses.Slice()
+			}
+		)
+		defer selector.(io.Closer).Close()
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		t.Assert(len(pickAll()), 2)
+
+		// Unhealthy node is excluded.
+		unhealthy.Add("127.0.0.1:2")
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(pickAll(), []string{"127.0.0.1:1"})
+
+		// All nodes are used if all of them are unhealthy.
+		unhealthy.Add("127.0.0.1:1")
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(len(pickAll()), 2)
+
+		// Recovered node is re-admitted.
+		unhealthy.Remove("127.0.0.1:2")
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(pickAll(), []string{"127.0.0.1:2"})
+	})
+}
+
+func Test_Health_Metadata(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		nodes := newTestNodes("127.0.0.1:1", "127.0.0.1:2")
+		nodes[0].Service().GetMetadata().Set(gsvc.MDHealthy, false)
+		selector := gsel.NewSelectorHealth(gsel.NewSelectorRoundRobin(), gsel.HealthConfig{
+			Checker:  gsvc.NewHealthCheckerMetadata(),
+			Interval: 20 * time.Millisecond,
+		})
+		defer selector.(io.Closer).Close()
+		t.AssertNil(selector.Update(ctx, nodes))
+		time.Sleep(50 * time.Millisecond)
+		for i := 0; i < 5; i++ {
+			node, _, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			t.Assert(node.Address(), "127.0.0.1:2")
+		}
+	})
+}
+
+func Test_Health_Lazy(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			checks   = gtype.NewInt()
+			selector = gsel.NewSelectorHealth(gsel.NewSelectorRoundRobin(), gsel.HealthConfig{
+				Checker: gsvc.HealthCheckerFunc(func(ctx context.Context, service gsvc.Service, address string) error {
+					checks.Add(1)
+					return nil
+				}),
+				Interval: 20 * time.Millisecond,
+			})
+		)
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1")))
+		time.Sleep(100 * time.Millisecond)
+		// No checking without using the selector, so the replaced selector leaks nothing.
+		t.Assert(checks.Val(), 1)
+
+		_, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(checks.Val(), 2)
+
+		// No checking after closed.
+		t.AssertNil(selector.(io.Closer).Close())
+		time.Sleep(50 * time.Millisecond)
+		_, _, err = selector.Pick(ctx)
+		t.AssertNil(err)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(checks.Val(), 2)
+	})
+}
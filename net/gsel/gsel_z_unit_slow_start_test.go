@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_SlowStart(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			selector = gsel.NewBuilderSlowStart(gsel.NewBuilderRoundRobin(), gsel.SlowStartConfig{
+				Window: 300 * time.Millisecond,
+			}).Build()
+			countNew = func() int {
+				var count int
+				for i := 0; i < 300; i++ {
+					node, _, err := selector.Pick(ctx)
+					t.AssertNil(err)
+					if node.Address() == "127.0.0.1:3" {
+						count++
+					}
+				}
+				return count
+			}
+		)
+		// The nodes of first update are warmed.
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:3")))
+		t.AssertGE(countNew(), 140)
+
+		// The recovered node warms up.
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2")))
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3")))
+		t.AssertLT(countNew(), 50)
+
+		// Full share after the window.
+		time.Sleep(300 * time.Millisecond)
+		t.AssertGE(countNew(), 90)
+	})
+	// The warming nodes keep warming up in the rebuilt selector.
+	gtest.C(t, func(t *gtest.T) {
+		builder := gsel.NewBuilderSlowStart(gsel.NewBuilderRoundRobin(), gsel.SlowStartConfig{
+			Window: time.Minute,
+		})
+		t.AssertNil(builder.Build().Update(ctx, newTestNodes("127.0.0.1:1")))
+		t.AssertNil(builder.Build().Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:3")))
+		var (
+			count    int
+			selector = builder.Build()
+		)
+		t.AssertNil(selector.Update(ctx, newTestNodes("127.0.0.1:1", "127.0.0.1:3")))
+		for i := 0; i < 300; i++ {
+			node, _, err := selector.Pick(ctx)
+			t.AssertNil(err)
+			if node.Address() == "127.0.0.1:3" {
+				count++
+			}
+		}
+		t.AssertLT(count, 50)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorSlowStart(gsel.NewSelectorLeastConnection())
+		node, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		t.AssertNil(node)
+	})
+}
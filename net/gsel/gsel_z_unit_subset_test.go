@@ -0,0 +1,139 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// newSubsetTestNodes creates nodes with metadata:
+// 1: zone a, v1; 2: zone b, v1; 3: zone a, v2; 4: zone b, v2, canary.
+func newSubsetTestNodes() gsel.Nodes {
+	nodes := newTestNodes("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3", "127.0.0.1:4")
+	nodes[0].Service().GetMetadata().Sets(gsvc.Metadata{gsvc.MDZone: "a", gsvc.MDVersion: "v1"})
+	nodes[1].Service().GetMetadata().Sets(gsvc.Metadata{gsvc.MDZone: "b", gsvc.MDVersion: "v1"})
+	nodes[2].Service().GetMetadata().Sets(gsvc.Metadata{gsvc.MDZone: "a", gsvc.MDVersion: "v2"})
+	nodes[3].Service().GetMetadata().Sets(gsvc.Metadata{gsvc.MDZone: "b", gsvc.MDVersion: "v2", gsvc.MDCanary: true})
+	return nodes
+}
+
+func pickSubsetAddresses(t *gtest.T, ctx context.Context, selector gsel.Selector) []string {
+	addresses := gset.NewStrSet()
+	for i := 0; i < 20; i++ {
+		node, _, err := selector.Pick(ctx)
+		t.AssertNil(err)
+		if node != nil {
+			addresses.Add(node.Address())
+		}
+	}
+	slice := addresses.Slice()
+	sort.Strings(slice)
+	return slice
+}
+
+func Test_Subset(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewBuilderSubset(gsel.NewBuilderRoundRobin(), gsel.SubsetConfig{
+			Keys:          []string{gsvc.MDVersion, gsvc.MDZone},
+			ExclusiveKeys: []string{gsvc.MDCanary},
+		}).Build()
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+
+		// Canary node is excluded for requests without canary flag.
+		t.AssertIN(pickSubsetAddresses(t, ctx, selector), []string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"})
+		t.Assert(len(pickSubsetAddresses(t, ctx, selector)), 3)
+
+		// Exact match.
+		routeCtx := gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDVersion: "v1"})
+		routeCtx = gsvc.WithRouteMetadata(routeCtx, gsvc.Metadata{gsvc.MDZone: "b"})
+		t.Assert(pickSubsetAddresses(t, routeCtx, selector), []string{"127.0.0.1:2"})
+
+		// Degrades by dropping the last key.
+		routeCtx = gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDVersion: "v2", gsvc.MDZone: "b"})
+		t.Assert(pickSubsetAddresses(t, routeCtx, selector), []string{"127.0.0.1:3"})
+
+		// Canary requests.
+		routeCtx = gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDCanary: true})
+		selector = gsel.NewSelectorSubset(gsel.NewBuilderRoundRobin(), gsel.SubsetConfig{
+			ExclusiveKeys: []string{gsvc.MDCanary},
+		})
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+		t.Assert(pickSubsetAddresses(t, routeCtx, selector), []string{"127.0.0.1:4"})
+	})
+	// Strict mode.
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorSubset(gsel.NewBuilderRoundRobin(), gsel.SubsetConfig{
+			Strict: true,
+		})
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+		routeCtx := gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDVersion: "v3"})
+		t.Assert(len(pickSubsetAddresses(t, routeCtx, selector)), 0)
+		routeCtx = gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDZone: "a"})
+		t.Assert(len(pickSubsetAddresses(t, routeCtx, selector)), 2)
+	})
+	// Falls back to all nodes.
+	gtest.C(t, func(t *gtest.T) {
+		selector := gsel.NewSelectorSubset(gsel.NewBuilderRoundRobin())
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+		routeCtx := gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDVersion: "v3"})
+		t.Assert(len(pickSubsetAddresses(t, routeCtx, selector)), 4)
+	})
+}
+
+// closableBuilder builds the round-robin selectors counting the closing.
+type closableBuilder struct {
+	closed *gtype.Int
+}
+
+type closableSelector struct {
+	gsel.Selector
+	closed *gtype.Int
+}
+
+func (b *closableBuilder) Name() string {
+	return "closable"
+}
+
+func (b *closableBuilder) Build() gsel.Selector {
+	return &closableSelector{Selector: gsel.NewSelectorRoundRobin(), closed: b.closed}
+}
+
+func (s *closableSelector) Close() error {
+	s.closed.Add(1)
+	return nil
+}
+
+func Test_Subset_CloseReplaced(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			builder  = &closableBuilder{closed: gtype.NewInt()}
+			selector = gsel.NewSelectorSubset(builder)
+		)
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+		pickSubsetAddresses(t, gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDZone: "a"}), selector)
+		pickSubsetAddresses(t, gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDZone: "b"}), selector)
+		t.Assert(builder.closed.Val(), 0)
+
+		// The selectors of subsets are closed when replaced.
+		t.AssertNil(selector.Update(ctx, newSubsetTestNodes()))
+		t.Assert(builder.closed.Val(), 2)
+		pickSubsetAddresses(t, gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDZone: "a"}), selector)
+		t.AssertNil(selector.(io.Closer).Close())
+		t.Assert(builder.closed.Val(), 3)
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel_test
+
+import (
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+)
+
+type testNode struct {
+	service gsvc.Service
+	address string
+}
+
+func (n *testNode) Service() gsvc.Service {
+	return n.service
+}
+
+func (n *testNode) Address() string {
+	return n.address
+}
+
+// newTestNodes creates and returns nodes with given addresses.
+func newTestNodes(addresses ...string) gsel.Nodes {
+	nodes := make(gsel.Nodes, 0, len(addresses))
+	for _, address := range addresses {
+		nodes = append(nodes, &testNode{
+			service: &gsvc.LocalService{
+				Name:      "test",
+				Endpoints: gsvc.NewEndpoints(address),
+				Metadata:  make(gsvc.Metadata),
+			},
+			address: address,
+		})
+	}
+	return nodes
+}
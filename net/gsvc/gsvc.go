@@ -127,6 +127,10 @@ const (
 	MDProtocol                = `protocol`           // MDProtocol is the metadata key for protocol.
 	MDInsecure                = `insecure`           // MDInsecure is the metadata key for insecure.
 	MDWeight                  = `weight`             // MDWeight is the metadata key for weight.
+	MDHealthy                 = `healthy`            // MDHealthy is the metadata key for health flag.
+	MDVersion                 = `version`            // MDVersion is the metadata key for version, which matches the version of service if absent.
+	MDZone                    = `zone`               // MDZone is the metadata key for zone.
+	MDCanary                  = `canary`             // MDCanary is the metadata key for canary flag.
 	DefaultProtocol           = `http`               // DefaultProtocol is the default protocol of service.
 	DefaultSeparator          = "/"                  // DefaultSeparator is the default separator of service.
 	EndpointHostPortDelimiter = ":"                  // EndpointHostPortDelimiter is the delimiter of host and port.
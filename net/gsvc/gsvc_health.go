@@ -0,0 +1,123 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// HealthChecker checks the health of service instance.
+type HealthChecker interface {
+	// Check checks the health of the instance of `service` at `address`, which is like "ip:port".
+	// It returns nil if the instance is healthy.
+	Check(ctx context.Context, service Service, address string) error
+}
+
+// HealthCheckerFunc is the function implementing interface HealthChecker.
+type HealthCheckerFunc func(ctx context.Context, service Service, address string) error
+
+// healthCheckerTCP checks the health by TCP connecting.
+type healthCheckerTCP struct{}
+
+// healthCheckerHTTP checks the health by HTTP requesting.
+type healthCheckerHTTP struct {
+	path   string
+	client *http.Client
+}
+
+// healthCheckerMetadata checks the health by metadata flag.
+type healthCheckerMetadata struct {
+	key string
+}
+
+// Check implements interface HealthChecker.
+func (f HealthCheckerFunc) Check(ctx context.Context, service Service, address string) error {
+	return f(ctx, service, address)
+}
+
+// NewHealthCheckerTCP creates and returns a HealthChecker that treats the instance healthy
+// if TCP connection can be established to its address.
+func NewHealthCheckerTCP() HealthChecker {
+	return healthCheckerTCP{}
+}
+
+// Check implements interface HealthChecker.
+func (healthCheckerTCP) Check(ctx context.Context, service Service, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return gerror.Wrapf(err, `health check dial failed for address "%s"`, address)
+	}
+	return conn.Close()
+}
+
+// NewHealthCheckerHTTP creates and returns a HealthChecker that requests `path` of the instance
+// using HTTP GET method, and treats the instance healthy if the response status code is 2xx.
+// It uses https scheme if the protocol in metadata of service is https.
+func NewHealthCheckerHTTP(path string) HealthChecker {
+	return &healthCheckerHTTP{
+		path:   path,
+		client: &http.Client{},
+	}
+}
+
+// Check implements interface HealthChecker.
+func (c *healthCheckerHTTP) Check(ctx context.Context, service Service, address string) error {
+	scheme := "http"
+	if v := service.GetMetadata().Get(MDProtocol); v != nil && v.String() == "https" {
+		scheme = "https"
+	}
+	url := fmt.Sprintf(`%s://%s%s`, scheme, address, c.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return gerror.Wrapf(err, `health check create request failed for url "%s"`, url)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return gerror.Wrapf(err, `health check request failed for url "%s"`, url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed,
+			`health check failed for url "%s" with status code %d`,
+			url, resp.StatusCode,
+		)
+	}
+	return nil
+}
+
+// NewHealthCheckerMetadata creates and returns a HealthChecker that treats the instance unhealthy
+// if the metadata flag of its service is false. The optional parameter `key` specifies the metadata
+// key of the flag, which is MDHealthy in default. The instance is healthy if the flag is absent.
+func NewHealthCheckerMetadata(key ...string) HealthChecker {
+	c := &healthCheckerMetadata{
+		key: MDHealthy,
+	}
+	if len(key) > 0 && key[0] != "" {
+		c.key = key[0]
+	}
+	return c
+}
+
+// Check implements interface HealthChecker.
+func (c *healthCheckerMetadata) Check(ctx context.Context, service Service, address string) error {
+	if v := service.GetMetadata().Get(c.key); v != nil && !v.Bool() {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed,
+			`service "%s" is marked unhealthy by metadata "%s"`,
+			service.GetName(), c.key,
+		)
+	}
+	return nil
+}
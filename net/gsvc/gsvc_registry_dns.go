@@ -0,0 +1,279 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// DNSRecordType is the DNS record type used for service discovery.
+type DNSRecordType string
+
+const (
+	// DNSRecordSRV resolves service endpoints from SRV records, like Consul DNS or Kubernetes named ports.
+	DNSRecordSRV DNSRecordType = "SRV"
+
+	// DNSRecordA resolves service endpoints from A records with configured port,
+	// like Kubernetes headless services.
+	DNSRecordA DNSRecordType = "A"
+)
+
+// DNSRegistryConfig is the configuration for DNS registry.
+type DNSRegistryConfig struct {
+	// RecordType is the record type for resolving, which is DNSRecordSRV in default.
+	RecordType DNSRecordType
+
+	// Domain is the domain suffix appended to the service name, like "default.svc.cluster.local"
+	// or "service.consul". The service name is used as the full domain if it is empty.
+	Domain string
+
+	// SRVService and SRVProto are the service and protocol labels for SRV records, which produce
+	// the SRV domain like "_http._tcp.<name>.<domain>". The labels are not prepended if SRVService
+	// is empty. SRVProto is "tcp" in default.
+	SRVService string
+	SRVProto   string
+
+	// Port is the port of endpoints for A records, which is 80 in default.
+	Port int
+
+	// RefreshInterval is the interval re-resolving the records for watching if the TTL of records
+	// is unknown. The TTL of records is respected if it is known. Default is 30s.
+	RefreshInterval time.Duration
+
+	// Nameservers are the DNS servers like "10.0.0.10:53" for querying.
+	// It uses the nameservers in "/etc/resolv.conf" in default.
+	Nameservers []string
+
+	// Timeout is the timeout of each DNS query. Default is 3s.
+	Timeout time.Duration
+}
+
+// dnsRegistry is the Registry resolving services from DNS records.
+type dnsRegistry struct {
+	config   DNSRegistryConfig
+	resolver *dnsResolver
+}
+
+// dnsWatcher watches the DNS records changes by polling.
+type dnsWatcher struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	registry *dnsRegistry
+	name     string
+	last     string        // Last resolved endpoints string.
+	wait     time.Duration // Waiting duration before next resolving.
+}
+
+const (
+	defaultDNSRefreshInterval = 30 * time.Second
+	defaultDNSTimeout         = 3 * time.Second
+	defaultDNSPort            = 80
+	defaultDNSSRVProto        = "tcp"
+	minDNSRefreshInterval     = time.Second
+)
+
+// NewDNSRegistry creates and returns a Registry that discovers services from DNS records,
+// which needs no extra registry server for Kubernetes or Consul DNS users.
+//
+// Note that the DNS registry is read-only, registering and deregistering are not supported,
+// and only IPv4 addresses are resolved.
+func NewDNSRegistry(config ...DNSRegistryConfig) Registry {
+	var c DNSRegistryConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.RecordType == "" {
+		c.RecordType = DNSRecordSRV
+	}
+	if c.SRVProto == "" {
+		c.SRVProto = defaultDNSSRVProto
+	}
+	if c.Port <= 0 {
+		c.Port = defaultDNSPort
+	}
+	if c.RefreshInterval <= 0 {
+		c.RefreshInterval = defaultDNSRefreshInterval
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultDNSTimeout
+	}
+	return &dnsRegistry{
+		config:   c,
+		resolver: newDNSResolver(c.Nameservers, c.Timeout),
+	}
+}
+
+// Register implements interface Registrar, which is not supported by DNS registry.
+func (r *dnsRegistry) Register(ctx context.Context, service Service) (Service, error) {
+	return nil, gerror.NewCode(
+		gcode.CodeNotSupported,
+		`registering is not supported by DNS registry, services should be registered by DNS records`,
+	)
+}
+
+// Deregister implements interface Registrar, which is not supported by DNS registry.
+func (r *dnsRegistry) Deregister(ctx context.Context, service Service) error {
+	return gerror.NewCode(
+		gcode.CodeNotSupported,
+		`deregistering is not supported by DNS registry, services should be deregistered by DNS records`,
+	)
+}
+
+// Search resolves and returns the service by name from DNS records.
+// It returns empty result if no record is found.
+func (r *dnsRegistry) Search(ctx context.Context, in SearchInput) ([]Service, error) {
+	name := in.Name
+	if name == "" {
+		name = r.getNameFromPrefix(in.Prefix)
+	}
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, `service name is required for DNS registry`)
+	}
+	endpoints, _, err := r.resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	service := r.newService(name, endpoints)
+	if len(in.Metadata) > 0 && !MatchMetadata(service, in.Metadata) {
+		return nil, nil
+	}
+	return []Service{service}, nil
+}
+
+// Watch watches the DNS records changes of service with `key` prefix.
+func (r *dnsRegistry) Watch(ctx context.Context, key string) (Watcher, error) {
+	name := r.getNameFromPrefix(key)
+	if name == "" {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid watch key "%s"`, key)
+	}
+	endpoints, ttl, err := r.resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	watcherCtx, cancel := context.WithCancel(context.Background())
+	return &dnsWatcher{
+		ctx:      watcherCtx,
+		cancel:   cancel,
+		registry: r,
+		name:     name,
+		last:     endpoints.String(),
+		wait:     r.getRefreshInterval(ttl),
+	}, nil
+}
+
+// resolve resolves and returns the sorted endpoints of service `name` and the TTL of records.
+func (r *dnsRegistry) resolve(ctx context.Context, name string) (Endpoints, time.Duration, error) {
+	var (
+		addresses []string
+		ttl       time.Duration
+		err       error
+		domain    = name
+	)
+	if r.config.Domain != "" {
+		domain = name + "." + strings.Trim(r.config.Domain, ".")
+	}
+	switch r.config.RecordType {
+	case DNSRecordA:
+		var ips []string
+		if ips, ttl, err = r.resolver.LookupA(ctx, domain); err != nil {
+			return nil, 0, err
+		}
+		for _, ip := range ips {
+			addresses = append(addresses, ip+EndpointHostPortDelimiter+strconv.Itoa(r.config.Port))
+		}
+
+	case DNSRecordSRV:
+		if r.config.SRVService != "" {
+			domain = "_" + r.config.SRVService + "._" + r.config.SRVProto + "." + domain
+		}
+		if addresses, ttl, err = r.resolver.LookupSRV(ctx, domain); err != nil {
+			return nil, 0, err
+		}
+
+	default:
+		return nil, 0, gerror.NewCodef(
+			gcode.CodeInvalidConfiguration, `unsupported DNS record type "%s"`, r.config.RecordType,
+		)
+	}
+	sort.Strings(addresses)
+	endpoints := make(Endpoints, 0, len(addresses))
+	for _, address := range addresses {
+		endpoints = append(endpoints, NewEndpoint(address))
+	}
+	return endpoints, ttl, nil
+}
+
+// newService creates and returns the service with resolved endpoints.
+func (r *dnsRegistry) newService(name string, endpoints Endpoints) Service {
+	service := NewServiceWithName(name).(*LocalService)
+	service.Endpoints = endpoints
+	return service
+}
+
+// getNameFromPrefix retrieves the service name from service key prefix like
+// "/service/default/default/name/latest", or it returns the prefix itself as the name
+// if it is not in service key pattern.
+func (r *dnsRegistry) getNameFromPrefix(prefix string) string {
+	prefix = gstr.Trim(prefix, DefaultSeparator)
+	array := gstr.Split(prefix, DefaultSeparator)
+	if len(array) >= 4 {
+		return array[3]
+	}
+	return prefix
+}
+
+// getRefreshInterval returns the interval for next resolving with `ttl` of records.
+func (r *dnsRegistry) getRefreshInterval(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return r.config.RefreshInterval
+	}
+	if ttl < minDNSRefreshInterval {
+		return minDNSRefreshInterval
+	}
+	return ttl
+}
+
+// Proceed blocks until the resolved endpoints change and returns the service.
+func (w *dnsWatcher) Proceed() ([]Service, error) {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil, gerror.NewCode(gcode.CodeInvalidOperation, `watcher closed`)
+		case <-time.After(w.wait):
+		}
+		endpoints, ttl, err := w.registry.resolve(w.ctx, w.name)
+		if err != nil {
+			w.wait = w.registry.config.RefreshInterval
+			return nil, err
+		}
+		w.wait = w.registry.getRefreshInterval(ttl)
+		if current := endpoints.String(); current != w.last {
+			w.last = current
+			if len(endpoints) == 0 {
+				return []Service{}, nil
+			}
+			return []Service{w.registry.newService(w.name, endpoints)}, nil
+		}
+	}
+}
+
+// Close closes the watcher.
+func (w *dnsWatcher) Close() error {
+	w.cancel()
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// dnsResolver queries DNS records with their TTL from nameservers.
+// It falls back to the resolver of standard library without TTL if no nameserver is available.
+type dnsResolver struct {
+	nameservers []string
+	timeout     time.Duration
+}
+
+const (
+	dnsResolvConfPath = "/etc/resolv.conf"
+	dnsDefaultPort    = "53"
+	dnsMaxUDPSize     = 4096
+)
+
+// newDNSResolver creates and returns a resolver querying `nameservers`.
+// It uses the nameservers in "/etc/resolv.conf" if `nameservers` is empty.
+func newDNSResolver(nameservers []string, timeout time.Duration) *dnsResolver {
+	if len(nameservers) == 0 {
+		nameservers = readResolvConfNameservers(dnsResolvConfPath)
+	}
+	return &dnsResolver{
+		nameservers: nameservers,
+		timeout:     timeout,
+	}
+}
+
+// readResolvConfNameservers reads and returns the nameservers from resolv.conf file at `path`.
+func readResolvConfNameservers(path string) []string {
+	var nameservers []string
+	for _, line := range strings.Split(gfile.GetContents(path), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			nameservers = append(nameservers, net.JoinHostPort(fields[1], dnsDefaultPort))
+		}
+	}
+	return nameservers
+}
+
+// LookupA resolves and returns the IPv4 addresses of `domain` and the minimum TTL of records.
+func (r *dnsResolver) LookupA(ctx context.Context, domain string) (ips []string, ttl time.Duration, err error) {
+	if len(r.nameservers) == 0 {
+		var addrs []net.IP
+		if addrs, err = net.DefaultResolver.LookupIP(ctx, "ip4", domain); err != nil {
+			return nil, 0, r.wrapLookupError(err, domain)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.String())
+		}
+		return ips, 0, nil
+	}
+	msg, err := r.query(ctx, domain, dnsmessage.TypeA)
+	if err != nil || msg == nil {
+		return nil, 0, err
+	}
+	for _, answer := range msg.Answers {
+		if a, ok := answer.Body.(*dnsmessage.AResource); ok {
+			ips = append(ips, net.IP(a.A[:]).String())
+			ttl = minTTL(ttl, answer.Header.TTL)
+		}
+	}
+	return ips, ttl, nil
+}
+
+// LookupSRV resolves and returns the addresses like "ip:port" of SRV records of `domain`
+// and the minimum TTL of records.
+func (r *dnsResolver) LookupSRV(ctx context.Context, domain string) (addresses []string, ttl time.Duration, err error) {
+	if len(r.nameservers) == 0 {
+		var records []*net.SRV
+		if _, records, err = net.DefaultResolver.LookupSRV(ctx, "", "", domain); err != nil {
+			return nil, 0, r.wrapLookupError(err, domain)
+		}
+		for _, record := range records {
+			var ips []string
+			if ips, _, err = r.LookupA(ctx, record.Target); err != nil {
+				return nil, 0, err
+			}
+			for _, ip := range ips {
+				addresses = append(addresses, ip+EndpointHostPortDelimiter+strconv.Itoa(int(record.Port)))
+			}
+		}
+		return addresses, 0, nil
+	}
+	msg, err := r.query(ctx, domain, dnsmessage.TypeSRV)
+	if err != nil || msg == nil {
+		return nil, 0, err
+	}
+	// The addresses of targets are commonly in the additional section.
+	var targetIPs = make(map[string][]string)
+	for _, additional := range msg.Additionals {
+		if a, ok := additional.Body.(*dnsmessage.AResource); ok {
+			target := strings.ToLower(additional.Header.Name.String())
+			targetIPs[target] = append(targetIPs[target], net.IP(a.A[:]).String())
+			ttl = minTTL(ttl, additional.Header.TTL)
+		}
+	}
+	for _, answer := range msg.Answers {
+		srv, ok := answer.Body.(*dnsmessage.SRVResource)
+		if !ok {
+			continue
+		}
+		ttl = minTTL(ttl, answer.Header.TTL)
+		var (
+			target = strings.ToLower(srv.Target.String())
+			ips    = targetIPs[target]
+		)
+		if len(ips) == 0 {
+			var targetTTL time.Duration
+			if ips, targetTTL, err = r.LookupA(ctx, target); err != nil {
+				return nil, 0, err
+			}
+			if targetTTL > 0 && (ttl == 0 || targetTTL < ttl) {
+				ttl = targetTTL
+			}
+		}
+		for _, ip := range ips {
+			addresses = append(addresses, ip+EndpointHostPortDelimiter+strconv.Itoa(int(srv.Port)))
+		}
+	}
+	return addresses, ttl, nil
+}
+
+// query queries the records of `domain` in type `qtype` from the nameservers in order.
+// It returns nil message without error if the domain does not exist.
+func (r *dnsResolver) query(ctx context.Context, domain string, qtype dnsmessage.Type) (*dnsmessage.Message, error) {
+	name, err := dnsmessage.NewName(dnsFQDN(domain))
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid domain "%s"`, domain)
+	}
+	request := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               uint16(grand.N(0, 0xFFFF)),
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  qtype,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	packed, err := request.Pack()
+	if err != nil {
+		return nil, gerror.Wrapf(err, `pack DNS query failed for domain "%s"`, domain)
+	}
+	for _, nameserver := range r.nameservers {
+		var response *dnsmessage.Message
+		if response, err = r.exchange(ctx, nameserver, packed, request.Header.ID); err != nil {
+			continue
+		}
+		switch response.Header.RCode {
+		case dnsmessage.RCodeSuccess:
+			return response, nil
+		case dnsmessage.RCodeNameError:
+			return nil, nil
+		default:
+			err = gerror.NewCodef(
+				gcode.CodeOperationFailed,
+				`DNS query failed for domain "%s" with code "%s"`,
+				domain, response.Header.RCode.String(),
+			)
+		}
+	}
+	return nil, gerror.Wrapf(err, `DNS query failed for domain "%s"`, domain)
+}
+
+// exchange sends the query to `nameserver` using UDP, and retries using TCP if the response is truncated.
+func (r *dnsResolver) exchange(ctx context.Context, nameserver string, packed []byte, id uint16) (*dnsmessage.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	response, err := r.exchangeWithNetwork(ctx, "udp", nameserver, packed, id)
+	if err == nil && response.Header.Truncated {
+		response, err = r.exchangeWithNetwork(ctx, "tcp", nameserver, packed, id)
+	}
+	return response, err
+}
+
+// exchangeWithNetwork sends the query and receives the response using `network`.
+func (r *dnsResolver) exchangeWithNetwork(
+	ctx context.Context, network, nameserver string, packed []byte, id uint16,
+) (*dnsmessage.Message, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, nameserver)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `dial nameserver "%s" failed`, nameserver)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	var buffer []byte
+	if network == "tcp" {
+		// DNS message over TCP is prefixed with 2 bytes length.
+		request := make([]byte, 2+len(packed))
+		binary.BigEndian.PutUint16(request, uint16(len(packed)))
+		copy(request[2:], packed)
+		if _, err = conn.Write(request); err != nil {
+			return nil, gerror.Wrapf(err, `write DNS query to "%s" failed`, nameserver)
+		}
+		length := make([]byte, 2)
+		if _, err = io.ReadFull(conn, length); err != nil {
+			return nil, gerror.Wrapf(err, `read DNS response from "%s" failed`, nameserver)
+		}
+		buffer = make([]byte, binary.BigEndian.Uint16(length))
+		if _, err = io.ReadFull(conn, buffer); err != nil {
+			return nil, gerror.Wrapf(err, `read DNS response from "%s" failed`, nameserver)
+		}
+	} else {
+		if _, err = conn.Write(packed); err != nil {
+			return nil, gerror.Wrapf(err, `write DNS query to "%s" failed`, nameserver)
+		}
+		buffer = make([]byte, dnsMaxUDPSize)
+		var n int
+		if n, err = conn.Read(buffer); err != nil {
+			return nil, gerror.Wrapf(err, `read DNS response from "%s" failed`, nameserver)
+		}
+		buffer = buffer[:n]
+	}
+	var response dnsmessage.Message
+	if err = response.Unpack(buffer); err != nil {
+		return nil, gerror.Wrapf(err, `unpack DNS response from "%s" failed`, nameserver)
+	}
+	if response.Header.ID != id {
+		return nil, gerror.NewCodef(gcode.CodeInvalidOperation, `mismatched DNS response id from "%s"`, nameserver)
+	}
+	return &response, nil
+}
+
+// wrapLookupError wraps the lookup error of standard library.
+// It returns nil if the domain does not exist.
+func (r *dnsResolver) wrapLookupError(err error, domain string) error {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return nil
+	}
+	return gerror.Wrapf(err, `DNS lookup failed for domain "%s"`, domain)
+}
+
+// dnsFQDN returns the fully qualified domain name of `domain`.
+func dnsFQDN(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+	return domain + "."
+}
+
+// minTTL returns the minimum of `ttl` and `seconds`, in which zero `ttl` means unset.
+func minTTL(ttl time.Duration, seconds uint32) time.Duration {
+	d := time.Duration(seconds) * time.Second
+	if ttl == 0 || d < ttl {
+		return d
+	}
+	return ttl
+}
@@ -0,0 +1,300 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// MultiRegistryMode is the mode how multiple registries present the services.
+type MultiRegistryMode int
+
+const (
+	// MultiRegistryModeMerge merges the services from all registries.
+	MultiRegistryModeMerge MultiRegistryMode = iota
+
+	// MultiRegistryModeFailover uses the services from the first registry in priority order
+	// that works and has the services, the latter registries are the fallbacks.
+	MultiRegistryModeFailover
+)
+
+// multiRegistry federates multiple registries as a single Registry.
+type multiRegistry struct {
+	mode       MultiRegistryMode
+	registries []Registry
+}
+
+// multiWatcher federates the watchers of multiple registries.
+type multiWatcher struct {
+	registry  *multiRegistry
+	watchers  []Watcher
+	mu        sync.Mutex
+	latest    [][]Service // Latest services of each registry, nil for failed registry.
+	events    chan multiWatchEvent
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// multiWatchEvent is the change event from a watcher.
+type multiWatchEvent struct {
+	index    int
+	services []Service
+	err      error
+}
+
+const (
+	multiWatcherRetryInterval = time.Second
+)
+
+// NewMultiRegistry creates and returns a Registry federating multiple `registries`, which presents
+// a single unified registration, searching and watching API to consumers.
+//
+// The service is registered to and deregistered from all the registries. The services are searched
+// and watched from the registries according to `mode`, in which the former registries have higher
+// priority in MultiRegistryModeFailover, like primary etcd and fallback static file registry.
+func NewMultiRegistry(mode MultiRegistryMode, registries ...Registry) Registry {
+	return &multiRegistry{
+		mode:       mode,
+		registries: registries,
+	}
+}
+
+// Register registers `service` to all registries.
+// It returns error if registering fails for any registry.
+func (r *multiRegistry) Register(ctx context.Context, service Service) (registered Service, err error) {
+	registered = service
+	for i, registry := range r.registries {
+		var result Service
+		if result, err = registry.Register(ctx, service); err != nil {
+			return nil, gerror.Wrapf(err, `register service to registry %d failed`, i)
+		}
+		if i == 0 && result != nil {
+			registered = result
+		}
+	}
+	return registered, nil
+}
+
+// Deregister removes `service` from all registries.
+// It continues deregistering from other registries if any fails, and returns the last error.
+func (r *multiRegistry) Deregister(ctx context.Context, service Service) (err error) {
+	for i, registry := range r.registries {
+		if e := registry.Deregister(ctx, service); e != nil {
+			err = gerror.Wrapf(e, `deregister service from registry %d failed`, i)
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+	return err
+}
+
+// Search searches and returns services from registries according to the mode.
+// It returns error only if all registries fail.
+func (r *multiRegistry) Search(ctx context.Context, in SearchInput) ([]Service, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]Service, len(r.registries))
+		errs    = make([]error, len(r.registries))
+	)
+	for i, registry := range r.registries {
+		wg.Add(1)
+		go func(i int, registry Registry) {
+			defer wg.Done()
+			results[i], errs[i] = registry.Search(ctx, in)
+		}(i, registry)
+	}
+	wg.Wait()
+	var (
+		err    error
+		failed int
+	)
+	for i, e := range errs {
+		if e != nil {
+			err = gerror.Wrapf(e, `search services from registry %d failed`, i)
+			intlog.Errorf(ctx, `%+v`, err)
+			results[i] = nil
+			failed++
+		}
+	}
+	if len(r.registries) > 0 && failed == len(r.registries) {
+		return nil, err
+	}
+	return r.unify(results), nil
+}
+
+// Watch watches the services with `key` prefix in all registries.
+// The registries failing watching are skipped and retried in background until they work,
+// and it returns error only if all registries fail.
+func (r *multiRegistry) Watch(ctx context.Context, key string) (Watcher, error) {
+	if len(r.registries) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidConfiguration, `no registry configured`)
+	}
+	w := &multiWatcher{
+		registry: r,
+		watchers: make([]Watcher, len(r.registries)),
+		latest:   make([][]Service, len(r.registries)),
+		events:   make(chan multiWatchEvent),
+		closed:   make(chan struct{}),
+	}
+	var (
+		err    error
+		failed = make([]int, 0)
+	)
+	for i, registry := range r.registries {
+		watcher, e := registry.Watch(ctx, key)
+		if e != nil {
+			err = gerror.Wrapf(e, `watch services from registry %d failed`, i)
+			intlog.Errorf(ctx, `%+v`, err)
+			failed = append(failed, i)
+			continue
+		}
+		w.watchers[i] = watcher
+		// Initial services for unifying with the changes of other registries.
+		if services, e := registry.Search(ctx, SearchInput{Prefix: key}); e == nil {
+			w.latest[i] = services
+		}
+	}
+	if len(failed) == len(r.registries) {
+		return nil, err
+	}
+	for i, watcher := range w.watchers {
+		if watcher != nil {
+			go w.proceedLoop(i, watcher)
+		}
+	}
+	// The context might be done after Watch returns, but the retrying lasts until the watcher closed.
+	retryCtx := gctx.NeverDone(ctx)
+	for _, i := range failed {
+		go w.retryLoop(retryCtx, i, key)
+	}
+	return w, nil
+}
+
+// unify unifies the services from registries according to the mode.
+func (r *multiRegistry) unify(results [][]Service) []Service {
+	if r.mode == MultiRegistryModeFailover {
+		for _, services := range results {
+			if len(services) > 0 {
+				return services
+			}
+		}
+		return nil
+	}
+	var (
+		merged = make([]Service, 0)
+		keys   = make(map[string]struct{})
+	)
+	for _, services := range results {
+		for _, service := range services {
+			if _, ok := keys[service.GetKey()]; ok {
+				continue
+			}
+			keys[service.GetKey()] = struct{}{}
+			merged = append(merged, service)
+		}
+	}
+	return merged
+}
+
+// Proceed proceeds watch in blocking way.
+// It returns the unified services of all registries if any registry changes.
+func (w *multiWatcher) Proceed() ([]Service, error) {
+	select {
+	case <-w.closed:
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, `watcher closed`)
+	case event := <-w.events:
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if event.err != nil {
+			// The failed registry is skipped until it recovers.
+			intlog.Errorf(context.Background(), `%+v`, event.err)
+			w.latest[event.index] = nil
+		} else {
+			w.latest[event.index] = event.services
+		}
+		return w.registry.unify(w.latest), nil
+	}
+}
+
+// Close closes all the watchers.
+func (w *multiWatcher) Close() (err error) {
+	w.closeOnce.Do(func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		close(w.closed)
+		for _, watcher := range w.watchers {
+			if watcher == nil {
+				continue
+			}
+			if e := watcher.Close(); e != nil {
+				err = e
+			}
+		}
+	})
+	return
+}
+
+// proceedLoop proceeds the watcher of registry at `index` until closed.
+func (w *multiWatcher) proceedLoop(index int, watcher Watcher) {
+	for {
+		services, err := watcher.Proceed()
+		select {
+		case <-w.closed:
+			return
+		case w.events <- multiWatchEvent{index: index, services: services, err: err}:
+		}
+		if err != nil {
+			select {
+			case <-w.closed:
+				return
+			case <-time.After(multiWatcherRetryInterval):
+			}
+		}
+	}
+}
+
+// retryLoop retries watching the registry at `index` periodically until it works or the watcher closed,
+// and then proceeds its watcher with the initial services notified as change.
+func (w *multiWatcher) retryLoop(ctx context.Context, index int, key string) {
+	registry := w.registry.registries[index]
+	for {
+		select {
+		case <-w.closed:
+			return
+		case <-time.After(multiWatcherRetryInterval):
+		}
+		watcher, err := registry.Watch(ctx, key)
+		if err != nil {
+			intlog.Errorf(ctx, `%+v`, gerror.Wrapf(err, `retry watching services from registry %d failed`, index))
+			continue
+		}
+		w.mu.Lock()
+		select {
+		case <-w.closed:
+			w.mu.Unlock()
+			_ = watcher.Close()
+			return
+		default:
+			w.watchers[index] = watcher
+		}
+		w.mu.Unlock()
+		services, err := registry.Search(ctx, SearchInput{Prefix: key})
+		select {
+		case <-w.closed:
+			return
+		case w.events <- multiWatchEvent{index: index, services: services, err: err}:
+		}
+		w.proceedLoop(index, watcher)
+		return
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ctxKeyRouteMetadata is the context key for route metadata.
+type ctxKeyRouteMetadata struct{}
+
+// WithRouteMetadata returns a new context carrying the route metadata `md`, which is used by
+// the caller to select service instances having the same metadata, like version, zone or canary flag.
+// The metadata is merged with the route metadata already in `ctx`.
+func WithRouteMetadata(ctx context.Context, md Metadata) context.Context {
+	merged := make(Metadata)
+	merged.Sets(GetRouteMetadata(ctx))
+	merged.Sets(md)
+	return context.WithValue(ctx, ctxKeyRouteMetadata{}, merged)
+}
+
+// GetRouteMetadata retrieves and returns the route metadata from context, which is set by WithRouteMetadata.
+// It returns nil if no route metadata is set.
+func GetRouteMetadata(ctx context.Context) Metadata {
+	if v, ok := ctx.Value(ctxKeyRouteMetadata{}).(Metadata); ok {
+		return v
+	}
+	return nil
+}
+
+// MatchMetadata checks and returns whether `service` matches all key-value pairs of `md`.
+// The values are compared as string. The key MDVersion matches the version of service
+// if it is absent in the metadata of service.
+func MatchMetadata(service Service, md Metadata) bool {
+	serviceMetadata := service.GetMetadata()
+	for k, v := range md {
+		var value interface{}
+		if serviceValue, ok := serviceMetadata[k]; ok {
+			value = serviceValue
+		} else if k == MDVersion {
+			value = service.GetVersion()
+		} else {
+			return false
+		}
+		if gconv.String(value) != gconv.String(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_HealthChecker_TCP(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		t.AssertNil(err)
+		var (
+			address = ln.Addr().String()
+			checker = gsvc.NewHealthCheckerTCP()
+			service = gsvc.NewServiceWithName("test")
+		)
+		t.AssertNil(checker.Check(ctx, service, address))
+		t.AssertNil(ln.Close())
+		t.AssertNE(checker.Check(ctx, service, address), nil)
+	})
+}
+
+func Test_HealthChecker_HTTP(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var healthy = true
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" || !healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+		var (
+			address = server.Listener.Addr().String()
+			service = gsvc.NewServiceWithName("test")
+		)
+		t.AssertNil(gsvc.NewHealthCheckerHTTP("/health").Check(ctx, service, address))
+		t.AssertNE(gsvc.NewHealthCheckerHTTP("/other").Check(ctx, service, address), nil)
+		healthy = false
+		t.AssertNE(gsvc.NewHealthCheckerHTTP("/health").Check(ctx, service, address), nil)
+	})
+}
+
+func Test_HealthChecker_Metadata(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			checker = gsvc.NewHealthCheckerMetadata()
+			service = gsvc.NewServiceWithName("test")
+		)
+		t.AssertNil(checker.Check(ctx, service, "127.0.0.1:80"))
+		service.GetMetadata().Set(gsvc.MDHealthy, false)
+		t.AssertNE(checker.Check(ctx, service, "127.0.0.1:80"), nil)
+		service.GetMetadata().Set(gsvc.MDHealthy, true)
+		t.AssertNil(checker.Check(ctx, service, "127.0.0.1:80"))
+	})
+}
@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// testDNSServer is a DNS server answering A and SRV queries from memory for testing.
+type testDNSServer struct {
+	mu      sync.Mutex
+	conn    net.PacketConn
+	records map[string][]string // Domain to IPv4 addresses.
+}
+
+func startTestDNSServer(t *gtest.T, records map[string][]string) *testDNSServer {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	t.AssertNil(err)
+	s := &testDNSServer{conn: conn, records: records}
+	go s.serve()
+	return s
+}
+
+func (s *testDNSServer) Address() string {
+	return s.conn.LocalAddr().String()
+}
+
+func (s *testDNSServer) SetRecords(domain string, ips []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[domain] = ips
+}
+
+func (s *testDNSServer) Close() {
+	_ = s.conn.Close()
+}
+
+func (s *testDNSServer) serve() {
+	buffer := make([]byte, 4096)
+	for {
+		n, addr, err := s.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		var request dnsmessage.Message
+		if err = request.Unpack(buffer[:n]); err != nil || len(request.Questions) == 0 {
+			continue
+		}
+		var (
+			question = request.Questions[0]
+			response = dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: request.Header.ID, Response: true},
+				Questions: request.Questions,
+			}
+		)
+		s.mu.Lock()
+		switch question.Type {
+		case dnsmessage.TypeA:
+			ips, ok := s.records[question.Name.String()]
+			if !ok {
+				response.Header.RCode = dnsmessage.RCodeNameError
+			}
+			for _, ip := range ips {
+				var a [4]byte
+				copy(a[:], net.ParseIP(ip).To4())
+				response.Answers = append(response.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 1},
+					Body:   &dnsmessage.AResource{A: a},
+				})
+			}
+		case dnsmessage.TypeSRV:
+			// SRV records point to "node.<domain>" with port 8000, the target address is in additional section.
+			target := dnsmessage.MustNewName("node." + question.Name.String())
+			response.Answers = append(response.Answers, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 10},
+				Body:   &dnsmessage.SRVResource{Target: target, Port: 8000},
+			})
+			response.Additionals = append(response.Additionals, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 10},
+				Body:   &dnsmessage.AResource{A: [4]byte{127, 0, 0, 9}},
+			})
+		}
+		s.mu.Unlock()
+		packed, err := response.Pack()
+		if err == nil {
+			_, _ = s.conn.WriteTo(packed, addr)
+		}
+	}
+}
+
+func Test_DNSRegistry_A(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		server := startTestDNSServer(t, map[string][]string{
+			"test.svc.local.": {"127.0.0.2", "127.0.0.1"},
+		})
+		defer server.Close()
+		registry := gsvc.NewDNSRegistry(gsvc.DNSRegistryConfig{
+			RecordType:  gsvc.DNSRecordA,
+			Domain:      "svc.local",
+			Port:        8000,
+			Nameservers: []string{server.Address()},
+		})
+		services, err := registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(services), 1)
+		t.Assert(services[0].GetName(), "test")
+		t.Assert(services[0].GetEndpoints().String(), "127.0.0.1:8000,127.0.0.2:8000")
+
+		// Not found.
+		services, err = registry.Search(ctx, gsvc.SearchInput{Name: "none"})
+		t.AssertNil(err)
+		t.Assert(len(services), 0)
+
+		// Not supported.
+		_, err = registry.Register(ctx, gsvc.NewServiceWithName("test"))
+		t.AssertNE(err, nil)
+
+		// Watching with TTL respected.
+		watcher, err := registry.Watch(ctx, gsvc.NewServiceWithName("test").GetPrefix())
+		t.AssertNil(err)
+		defer watcher.Close()
+		server.SetRecords("test.svc.local.", []string{"127.0.0.3"})
+		services, err = watcher.Proceed()
+		t.AssertNil(err)
+		t.Assert(len(services), 1)
+		t.Assert(services[0].GetEndpoints().String(), "127.0.0.3:8000")
+	})
+}
+
+func Test_DNSRegistry_SRV(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		server := startTestDNSServer(t, map[string][]string{})
+		defer server.Close()
+		registry := gsvc.NewDNSRegistry(gsvc.DNSRegistryConfig{
+			Domain:      "svc.local",
+			SRVService:  "http",
+			Nameservers: []string{server.Address()},
+		})
+		services, err := registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(services), 1)
+		t.Assert(services[0].GetEndpoints().String(), "127.0.0.9:8000")
+	})
+}
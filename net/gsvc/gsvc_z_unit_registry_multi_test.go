@@ -0,0 +1,238 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// memoryRegistry is a registry storing services in memory for testing.
+type memoryRegistry struct {
+	mu       sync.Mutex
+	services map[string]gsvc.Service
+	watchers []chan struct{}
+	failed   bool
+	// watchFailed makes Watch fail.
+	watchFailed bool
+}
+
+type memoryWatcher struct {
+	registry *memoryRegistry
+	prefix   string
+	ch       chan struct{}
+}
+
+func newMemoryRegistry() *memoryRegistry {
+	return &memoryRegistry{services: make(map[string]gsvc.Service)}
+}
+
+func (r *memoryRegistry) Register(ctx context.Context, service gsvc.Service) (gsvc.Service, error) {
+	r.mu.Lock()
+	r.services[service.GetKey()] = service
+	r.mu.Unlock()
+	r.notify()
+	return service, nil
+}
+
+func (r *memoryRegistry) Deregister(ctx context.Context, service gsvc.Service) error {
+	r.mu.Lock()
+	delete(r.services, service.GetKey())
+	r.mu.Unlock()
+	r.notify()
+	return nil
+}
+
+func (r *memoryRegistry) Search(ctx context.Context, in gsvc.SearchInput) ([]gsvc.Service, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failed {
+		return nil, errors.New("registry failed")
+	}
+	var result []gsvc.Service
+	for key, service := range r.services {
+		if strings.HasPrefix(key, in.Prefix) && (in.Name == "" || service.GetName() == in.Name) {
+			result = append(result, service)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRegistry) Watch(ctx context.Context, key string) (gsvc.Watcher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watchFailed {
+		return nil, errors.New("watch failed")
+	}
+	ch := make(chan struct{}, 10)
+	r.watchers = append(r.watchers, ch)
+	return &memoryWatcher{registry: r, prefix: key, ch: ch}, nil
+}
+
+func (r *memoryRegistry) notify() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ch := range r.watchers {
+		ch <- struct{}{}
+	}
+}
+
+func (w *memoryWatcher) Proceed() ([]gsvc.Service, error) {
+	if _, ok := <-w.ch; !ok {
+		return nil, errors.New("closed")
+	}
+	return w.registry.Search(context.Background(), gsvc.SearchInput{Prefix: w.prefix})
+}
+
+func (w *memoryWatcher) Close() error {
+	return nil
+}
+
+func newTestService(name, address string) gsvc.Service {
+	return &gsvc.LocalService{
+		Name:      name,
+		Endpoints: gsvc.NewEndpoints(address),
+		Metadata:  make(gsvc.Metadata),
+	}
+}
+
+func Test_MultiRegistry_Merge(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r1       = newMemoryRegistry()
+			r2       = newMemoryRegistry()
+			registry = gsvc.NewMultiRegistry(gsvc.MultiRegistryModeMerge, r1, r2)
+			s1       = newTestService("test", "127.0.0.1:1")
+			s2       = newTestService("test", "127.0.0.1:2")
+		)
+		// Registered to all registries.
+		_, err := registry.Register(ctx, s1)
+		t.AssertNil(err)
+		_, err = r2.Register(ctx, s2)
+		t.AssertNil(err)
+		result, err := r1.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+
+		// Merged and deduplicated.
+		result, err = registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(result), 2)
+
+		// Partial failure is tolerated.
+		r2.failed = true
+		result, err = registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+		r1.failed = true
+		_, err = registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_MultiRegistry_Failover(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			primary  = newMemoryRegistry()
+			fallback = newMemoryRegistry()
+			registry = gsvc.NewMultiRegistry(gsvc.MultiRegistryModeFailover, primary, fallback)
+			s1       = newTestService("test", "127.0.0.1:1")
+			s2       = newTestService("test", "127.0.0.1:2")
+		)
+		_, err := primary.Register(ctx, s1)
+		t.AssertNil(err)
+		_, err = fallback.Register(ctx, s2)
+		t.AssertNil(err)
+
+		result, err := registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+		t.Assert(result[0].GetEndpoints().String(), "127.0.0.1:1")
+
+		watcher, err := registry.Watch(ctx, s1.GetPrefix())
+		t.AssertNil(err)
+		defer watcher.Close()
+
+		// Fallback changes do not affect while primary works.
+		_, err = fallback.Register(ctx, newTestService("test", "127.0.0.1:3"))
+		t.AssertNil(err)
+		result, err = watcher.Proceed()
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+		t.Assert(result[0].GetEndpoints().String(), "127.0.0.1:1")
+
+		// Falls back if primary has no services.
+		t.AssertNil(primary.Deregister(ctx, s1))
+		result, err = watcher.Proceed()
+		t.AssertNil(err)
+		t.Assert(len(result), 2)
+
+		// Falls back if primary fails.
+		primary.failed = true
+		result, err = registry.Search(ctx, gsvc.SearchInput{Name: "test"})
+		t.AssertNil(err)
+		t.Assert(len(result), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		watcher, err := gsvc.NewMultiRegistry(gsvc.MultiRegistryModeMerge).Watch(ctx, "/")
+		t.AssertNil(watcher)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_MultiRegistry_WatchFailure(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r1       = newMemoryRegistry()
+			r2       = newMemoryRegistry()
+			registry = gsvc.NewMultiRegistry(gsvc.MultiRegistryModeMerge, r1, r2)
+			s1       = newTestService("test", "127.0.0.1:1")
+			s2       = newTestService("test", "127.0.0.1:2")
+		)
+		_, err := r2.Register(ctx, s2)
+		t.AssertNil(err)
+		r2.watchFailed = true
+
+		// The failed registry is skipped.
+		watcher, err := registry.Watch(ctx, s1.GetPrefix())
+		t.AssertNil(err)
+		defer watcher.Close()
+		_, err = r1.Register(ctx, s1)
+		t.AssertNil(err)
+		result, err := watcher.Proceed()
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+
+		// The failed registry is retried in background.
+		r2.mu.Lock()
+		r2.watchFailed = false
+		r2.mu.Unlock()
+		result, err = watcher.Proceed()
+		t.AssertNil(err)
+		t.Assert(len(result), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			r1 = newMemoryRegistry()
+			r2 = newMemoryRegistry()
+		)
+		r1.watchFailed = true
+		r2.watchFailed = true
+		watcher, err := gsvc.NewMultiRegistry(gsvc.MultiRegistryModeMerge, r1, r2).Watch(ctx, "/")
+		t.AssertNil(watcher)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsvc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_RouteMetadata(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		t.AssertNil(gsvc.GetRouteMetadata(ctx))
+		ctx = gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDZone: "a"})
+		ctx = gsvc.WithRouteMetadata(ctx, gsvc.Metadata{gsvc.MDCanary: true})
+		t.Assert(gsvc.GetRouteMetadata(ctx), gsvc.Metadata{gsvc.MDZone: "a", gsvc.MDCanary: true})
+	})
+}
+
+func Test_MatchMetadata(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		service := &gsvc.LocalService{
+			Name:     "test",
+			Version:  "v1",
+			Metadata: gsvc.Metadata{gsvc.MDZone: "a", gsvc.MDWeight: 10},
+		}
+		t.Assert(gsvc.MatchMetadata(service, nil), true)
+		t.Assert(gsvc.MatchMetadata(service, gsvc.Metadata{gsvc.MDZone: "a"}), true)
+		t.Assert(gsvc.MatchMetadata(service, gsvc.Metadata{gsvc.MDWeight: "10"}), true)
+		t.Assert(gsvc.MatchMetadata(service, gsvc.Metadata{gsvc.MDVersion: "v1", gsvc.MDZone: "a"}), true)
+		t.Assert(gsvc.MatchMetadata(service, gsvc.Metadata{gsvc.MDVersion: "v2"}), false)
+		t.Assert(gsvc.MatchMetadata(service, gsvc.Metadata{gsvc.MDCanary: true}), false)
+	})
+}
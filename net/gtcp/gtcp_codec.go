@@ -0,0 +1,278 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// Codec is the interface for message framing, which splits the TCP byte stream into messages.
+type Codec interface {
+	// Encode frames the message `data` and returns the bytes to be written to connection.
+	Encode(data []byte) ([]byte, error)
+
+	// Decode reads and returns one message from `reader`.
+	Decode(reader *bufio.Reader) ([]byte, error)
+}
+
+// PayloadCodec is the interface for message payload serialization, like JSON, protobuf or msgpack.
+// Only JSON is provided in default as PayloadCodecJSON, other serializations can be plugged in by
+// implementing this interface.
+type PayloadCodec interface {
+	// Marshal serializes `v` to bytes.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal deserializes `data` to `v`.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// LengthFieldConfig is the configuration for length field based codec.
+type LengthFieldConfig struct {
+	// HeaderSize is the bytes size of the length field, which can be 1, 2, 3, 4 or 8. Default is 2.
+	HeaderSize int
+
+	// ByteOrder is the byte order of the length field, which can be binary.BigEndian or binary.LittleEndian.
+	// Default is binary.BigEndian.
+	ByteOrder binary.ByteOrder
+
+	// MaxDataSize is the max data size in bytes for validation.
+	// It is automatically set correspondingly with HeaderSize if it's not set.
+	MaxDataSize int
+
+	// LengthIncludesHeader specifies whether the length field value includes the header size.
+	LengthIncludesHeader bool
+}
+
+// lengthFieldCodec is the codec that prefixes each message with its length.
+type lengthFieldCodec struct {
+	config LengthFieldConfig
+}
+
+// delimiterCodec is the codec that ends each message with a delimiter.
+type delimiterCodec struct {
+	delimiter   []byte
+	maxDataSize int
+}
+
+// payloadCodecJSON is the JSON PayloadCodec.
+type payloadCodecJSON struct{}
+
+const (
+	defaultDelimiterMaxDataSize = 0xFFFF
+)
+
+var (
+	// PayloadCodecJSON is the JSON payload codec, which is the default payload codec of connection.
+	PayloadCodecJSON PayloadCodec = payloadCodecJSON{}
+)
+
+// NewLengthFieldCodec creates and returns a codec that prefixes each message with a length field.
+//
+// Note that the codec with default configuration is compatible with the simple package protocol
+// of SendPkg/RecvPkg in default option.
+func NewLengthFieldCodec(config ...LengthFieldConfig) (Codec, error) {
+	var c LengthFieldConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.HeaderSize == 0 {
+		c.HeaderSize = pkgHeaderSizeDefault
+	}
+	switch c.ByteOrder {
+	case nil:
+		c.ByteOrder = binary.BigEndian
+	case binary.BigEndian, binary.LittleEndian:
+	default:
+		// The header might be shorter than 8 bytes, which needs the byte order to locate its bytes.
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `unsupported length field byte order %s`, c.ByteOrder,
+		)
+	}
+	var maxAllowed int
+	switch c.HeaderSize {
+	case 1:
+		maxAllowed = 0xFF
+	case 2:
+		maxAllowed = 0xFFFF
+	case 3:
+		maxAllowed = 0xFFFFFF
+	case 4, 8:
+		maxAllowed = 0x7FFFFFFF
+	default:
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid length field header size %d`, c.HeaderSize,
+		)
+	}
+	if c.LengthIncludesHeader && maxAllowed != 0x7FFFFFFF {
+		maxAllowed -= c.HeaderSize
+	}
+	if c.MaxDataSize <= 0 || c.MaxDataSize > maxAllowed {
+		c.MaxDataSize = maxAllowed
+	}
+	return &lengthFieldCodec{config: c}, nil
+}
+
+// MustNewLengthFieldCodec performs as NewLengthFieldCodec, but it panics if any error occurs.
+func MustNewLengthFieldCodec(config ...LengthFieldConfig) Codec {
+	codec, err := NewLengthFieldCodec(config...)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// Encode implements the interface Codec.
+func (c *lengthFieldCodec) Encode(data []byte) ([]byte, error) {
+	length := len(data)
+	if length > c.config.MaxDataSize {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`data too long, data size %d exceeds allowed max data size %d`,
+			length, c.config.MaxDataSize,
+		)
+	}
+	var (
+		headerSize = c.config.HeaderSize
+		buffer     = make([]byte, headerSize+length)
+		value      = uint64(length)
+	)
+	if c.config.LengthIncludesHeader {
+		value += uint64(headerSize)
+	}
+	c.putLength(buffer[:headerSize], value)
+	copy(buffer[headerSize:], data)
+	return buffer, nil
+}
+
+// Decode implements the interface Codec.
+func (c *lengthFieldCodec) Decode(reader *bufio.Reader) ([]byte, error) {
+	header := make([]byte, c.config.HeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+	length := int64(c.getLength(header))
+	if c.config.LengthIncludesHeader {
+		length -= int64(c.config.HeaderSize)
+	}
+	if length < 0 || length > int64(c.config.MaxDataSize) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid package size %d`, length)
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// putLength writes length `value` to `header` in configured byte order.
+func (c *lengthFieldCodec) putLength(header []byte, value uint64) {
+	var (
+		size   = len(header)
+		buffer = make([]byte, 8)
+	)
+	if c.config.ByteOrder == binary.LittleEndian {
+		binary.LittleEndian.PutUint64(buffer, value)
+		copy(header, buffer[:size])
+		return
+	}
+	binary.BigEndian.PutUint64(buffer, value)
+	copy(header, buffer[8-size:])
+}
+
+// getLength reads length value from `header` in configured byte order.
+func (c *lengthFieldCodec) getLength(header []byte) uint64 {
+	var (
+		size   = len(header)
+		buffer = make([]byte, 8)
+	)
+	if c.config.ByteOrder == binary.LittleEndian {
+		copy(buffer, header)
+		return binary.LittleEndian.Uint64(buffer)
+	}
+	copy(buffer[8-size:], header)
+	return binary.BigEndian.Uint64(buffer)
+}
+
+// NewDelimiterCodec creates and returns a codec that ends each message with `delimiter`, like "\n".
+// The optional parameter `maxDataSize` specifies the max data size of each message in bytes,
+// which is 65535 in default.
+//
+// Note that the message data should not contain the delimiter.
+// It panics if `delimiter` is empty, as no message could be split from the stream.
+func NewDelimiterCodec(delimiter []byte, maxDataSize ...int) Codec {
+	if len(delimiter) == 0 {
+		panic(gerror.NewCode(gcode.CodeInvalidParameter, `delimiter should not be empty`))
+	}
+	c := &delimiterCodec{
+		delimiter:   delimiter,
+		maxDataSize: defaultDelimiterMaxDataSize,
+	}
+	if len(maxDataSize) > 0 && maxDataSize[0] > 0 {
+		c.maxDataSize = maxDataSize[0]
+	}
+	return c
+}
+
+// Encode implements the interface Codec.
+func (c *delimiterCodec) Encode(data []byte) ([]byte, error) {
+	if len(data) > c.maxDataSize {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`data too long, data size %d exceeds allowed max data size %d`,
+			len(data), c.maxDataSize,
+		)
+	}
+	if bytes.Contains(data, c.delimiter) {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `data contains delimiter`)
+	}
+	buffer := make([]byte, 0, len(data)+len(c.delimiter))
+	buffer = append(buffer, data...)
+	return append(buffer, c.delimiter...), nil
+}
+
+// Decode implements the interface Codec.
+func (c *delimiterCodec) Decode(reader *bufio.Reader) ([]byte, error) {
+	var (
+		data []byte
+		last = c.delimiter[len(c.delimiter)-1]
+	)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, b)
+		if b == last && bytes.HasSuffix(data, c.delimiter) {
+			return data[:len(data)-len(c.delimiter)], nil
+		}
+		if len(data) > c.maxDataSize+len(c.delimiter) {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter, `invalid package size exceeds %d`, c.maxDataSize,
+			)
+		}
+	}
+}
+
+// Marshal implements the interface PayloadCodec.
+func (payloadCodecJSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements the interface PayloadCodec.
+func (payloadCodecJSON) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
@@ -9,9 +9,11 @@ package gtcp
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -19,11 +21,18 @@ import (
 
 // Conn is the TCP connection object.
 type Conn struct {
-	net.Conn                     // Underlying TCP connection object.
-	reader         *bufio.Reader // Buffer reader for connection.
-	deadlineRecv   time.Time     // Timeout point for reading.
-	deadlineSend   time.Time     // Timeout point for writing.
-	bufferWaitRecv time.Duration // Interval duration for reading buffer.
+	net.Conn                       // Underlying TCP connection object.
+	reader         *bufio.Reader   // Buffer reader for connection.
+	deadlineRecv   time.Time       // Timeout point for reading.
+	deadlineSend   time.Time       // Timeout point for writing.
+	bufferWaitRecv time.Duration   // Interval duration for reading buffer.
+	queueMu        sync.Mutex      // Mutex for sendQueue.
+	sendQueue      *sendQueue      // Asynchronous send queue, which is nil if not enabled.
+	codec          Codec           // Message framing codec, which uses defaultCodec if nil.
+	payloadCodec   PayloadCodec    // Message payload codec, which uses PayloadCodecJSON if nil.
+	stats          *connStats      // Statistics of the connection.
+	serverStats    *serverStats    // Statistics of the server that accepts this connection, which is nil for client.
+	ctx            context.Context // Context of the server that accepts this connection, which is nil for client.
 }
 
 const (
@@ -62,13 +71,15 @@ func NewConnKeyCrt(addr, crtFile, keyFile string) (*Conn, error) {
 
 // NewConnByNetConn creates and returns a TCP connection object with given net.Conn object.
 func NewConnByNetConn(conn net.Conn) *Conn {
-	return &Conn{
+	c := &Conn{
 		Conn:           conn,
-		reader:         bufio.NewReader(conn),
 		deadlineRecv:   time.Time{},
 		deadlineSend:   time.Time{},
 		bufferWaitRecv: receiveAllWaitTimeout,
+		stats:          newConnStats(),
 	}
+	c.reader = bufio.NewReader(connStatsReader{conn: c})
+	return c
 }
 
 // Send writes data to remote address.
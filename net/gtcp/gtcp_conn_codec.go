@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+var (
+	// defaultCodec is the default message framing codec, which is compatible with simple package protocol.
+	defaultCodec = MustNewLengthFieldCodec()
+)
+
+// SetCodec sets the message framing codec for the connection, which is used by SendMsg/RecvMsg
+// and SendFrame/RecvFrame. The default codec is compatible with SendPkg/RecvPkg in default option.
+func (c *Conn) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
+// SetPayloadCodec sets the payload serialization codec for the connection, which is used by
+// SendMsg/RecvMsg. The default payload codec is PayloadCodecJSON.
+func (c *Conn) SetPayloadCodec(payloadCodec PayloadCodec) {
+	c.payloadCodec = payloadCodec
+}
+
+// SendFrame writes `data` as one message to the connection using the framing codec.
+func (c *Conn) SendFrame(data []byte, retry ...Retry) error {
+	buffer, err := c.getCodec().Encode(data)
+	if err != nil {
+		return err
+	}
+	return c.Send(buffer, retry...)
+}
+
+// SendFrameAsync puts `data` as one message to the send queue using the framing codec.
+// See SendAsync.
+func (c *Conn) SendFrameAsync(data []byte) error {
+	buffer, err := c.getCodec().Encode(data)
+	if err != nil {
+		return err
+	}
+	return c.SendAsync(buffer)
+}
+
+// RecvFrame receives and returns one message from the connection using the framing codec.
+func (c *Conn) RecvFrame() ([]byte, error) {
+	data, err := c.getCodec().Decode(c.reader)
+	if err != nil {
+		err = gerror.Wrap(err, `receive frame failed`)
+	}
+	return data, err
+}
+
+// SendMsg serializes `v` using the payload codec and writes it as one message using the framing codec.
+func (c *Conn) SendMsg(v interface{}, retry ...Retry) error {
+	data, err := c.getPayloadCodec().Marshal(v)
+	if err != nil {
+		return gerror.Wrap(err, `marshal message failed`)
+	}
+	return c.SendFrame(data, retry...)
+}
+
+// RecvMsg receives one message using the framing codec and deserializes it to `v` using the
+// payload codec.
+func (c *Conn) RecvMsg(v interface{}) error {
+	data, err := c.RecvFrame()
+	if err != nil {
+		return err
+	}
+	if err = c.getPayloadCodec().Unmarshal(data, v); err != nil {
+		return gerror.Wrap(err, `unmarshal message failed`)
+	}
+	return nil
+}
+
+// getCodec returns the framing codec of the connection.
+func (c *Conn) getCodec() Codec {
+	if c.codec != nil {
+		return c.codec
+	}
+	return defaultCodec
+}
+
+// getPayloadCodec returns the payload codec of the connection.
+func (c *Conn) getPayloadCodec() PayloadCodec {
+	if c.payloadCodec != nil {
+		return c.payloadCodec
+	}
+	return PayloadCodecJSON
+}
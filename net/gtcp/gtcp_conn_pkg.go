@@ -43,13 +43,25 @@ type PkgOption struct {
 // 1. The DataLength is the length of DataField, which does not contain the header size.
 // 2. The integer bytes of the package are encoded using BigEndian order.
 func (c *Conn) SendPkg(data []byte, option ...PkgOption) error {
-	pkgOption, err := getPkgOption(option...)
+	buffer, pkgOption, err := packPkg(data, option...)
 	if err != nil {
 		return err
 	}
+	if pkgOption.Retry.Count > 0 {
+		return c.Send(buffer, pkgOption.Retry)
+	}
+	return c.Send(buffer)
+}
+
+// packPkg packs data using simple package protocol and returns the package bytes.
+func packPkg(data []byte, option ...PkgOption) ([]byte, *PkgOption, error) {
+	pkgOption, err := getPkgOption(option...)
+	if err != nil {
+		return nil, nil, err
+	}
 	length := len(data)
 	if length > pkgOption.MaxDataSize {
-		return gerror.NewCodef(
+		return nil, nil, gerror.NewCodef(
 			gcode.CodeInvalidParameter,
 			`data too long, data size %d exceeds allowed max data size %d`,
 			length, pkgOption.MaxDataSize,
@@ -59,10 +71,7 @@ func (c *Conn) SendPkg(data []byte, option ...PkgOption) error {
 	buffer := make([]byte, pkgHeaderSizeMax+len(data))
 	binary.BigEndian.PutUint32(buffer[0:], uint32(length))
 	copy(buffer[pkgHeaderSizeMax:], data)
-	if pkgOption.Retry.Count > 0 {
-		return c.Send(buffer[offset:], pkgOption.Retry)
-	}
-	return c.Send(buffer[offset:])
+	return buffer[offset:], pkgOption, nil
 }
 
 // SendPkgWithTimeout writes data to connection with timeout using simple package protocol.
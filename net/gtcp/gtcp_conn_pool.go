@@ -0,0 +1,236 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Pool is a managed connection pool for TCP client connections to a single address.
+//
+// Different from PoolConn, which is a reusable connection object, Pool limits the total
+// count of open connections, closes connections that stay idle too long, and validates
+// connections on checkout, replacing the dead ones automatically.
+type Pool struct {
+	addr   string          // Remote address.
+	config PoolConfig      // Pool configuration.
+	idle   chan *poolEntry // Idle connections.
+	slots  chan struct{}   // Semaphore limiting the count of open connections.
+	closed *gtype.Bool     // Whether the pool is closed.
+}
+
+// PoolConfig is the configuration for Pool.
+type PoolConfig struct {
+	MaxSize     int                   // Max count of open connections, including in-use and idle ones. Default is 10.
+	MaxIdleTime time.Duration         // Max duration a connection can stay idle in pool. Default is 60 seconds.
+	DialTimeout time.Duration         // Timeout for dialing new connection. Default is 30 seconds.
+	TLSConfig   *tls.Config           // TLS configuration, which creates TLS connections if it is not nil.
+	Validate    PoolValidateFunc      // Validation hook on checkout. Default is DefaultPoolValidate.
+	Dial        func() (*Conn, error) // Custom dialing function, which overwrites the default dialing if given.
+}
+
+// PoolValidateFunc validates an idle connection before it is checked out from pool.
+// The connection is closed and replaced with a new one if it returns error.
+type PoolValidateFunc func(conn *Conn) error
+
+// poolEntry is an idle connection in pool.
+type poolEntry struct {
+	conn      *Conn
+	idleSince time.Time
+}
+
+const (
+	defaultPoolMaxSize     = 10
+	defaultPoolMaxIdleTime = 60 * time.Second
+	poolValidateTimeout    = time.Millisecond
+)
+
+// NewPool creates and returns a managed connection pool for address `addr`.
+func NewPool(addr string, config ...PoolConfig) *Pool {
+	var c PoolConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = defaultPoolMaxSize
+	}
+	if c.MaxIdleTime <= 0 {
+		c.MaxIdleTime = defaultPoolMaxIdleTime
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = defaultConnTimeout
+	}
+	if c.Validate == nil {
+		c.Validate = DefaultPoolValidate
+	}
+	return &Pool{
+		addr:   addr,
+		config: c,
+		idle:   make(chan *poolEntry, c.MaxSize),
+		slots:  make(chan struct{}, c.MaxSize),
+		closed: gtype.NewBool(),
+	}
+}
+
+// Get checks out a connection from pool, or dials a new one if there's no idle connection.
+// It blocks waiting if the pool reaches its max size until any connection is given back
+// or `ctx` is done.
+//
+// The returned connection should be given back using Put after use,
+// or Discard if it is no longer usable.
+func (p *Pool) Get(ctx context.Context) (*Conn, error) {
+	for {
+		if p.closed.Val() {
+			return nil, gerror.NewCode(gcode.CodeInvalidOperation, "pool is closed")
+		}
+		var entry *poolEntry
+		select {
+		case entry = <-p.idle:
+		default:
+			select {
+			case entry = <-p.idle:
+			case p.slots <- struct{}{}:
+				conn, err := p.dial()
+				if err != nil {
+					<-p.slots
+					return nil, err
+				}
+				return conn, nil
+			case <-ctx.Done():
+				return nil, gerror.WrapCode(
+					gcode.CodeOperationFailed, ctx.Err(), "waiting for free connection failed",
+				)
+			}
+		}
+		if !p.isUsable(entry) {
+			// Replace the dead connection with a new one in next loop.
+			p.Discard(entry.conn)
+			continue
+		}
+		return entry.conn, nil
+	}
+}
+
+// Put gives back connection `conn` to pool for later reuse.
+func (p *Pool) Put(conn *Conn) {
+	if conn == nil {
+		return
+	}
+	if p.closed.Val() {
+		p.Discard(conn)
+		return
+	}
+	select {
+	case p.idle <- &poolEntry{conn: conn, idleSince: time.Now()}:
+	default:
+		// It should not happen unless the connection does not belong to this pool.
+		_ = conn.Close()
+	}
+}
+
+// Discard closes connection `conn` and releases its slot in pool.
+// It should be called instead of Put if the connection is broken.
+func (p *Pool) Discard(conn *Conn) {
+	if conn == nil {
+		return
+	}
+	_ = conn.Close()
+	select {
+	case <-p.slots:
+	default:
+	}
+}
+
+// Clean closes and removes the idle connections that exceed the max idle time.
+func (p *Pool) Clean() {
+	for i := len(p.idle); i > 0; i-- {
+		select {
+		case entry := <-p.idle:
+			if time.Since(entry.idleSince) > p.config.MaxIdleTime {
+				p.Discard(entry.conn)
+			} else {
+				p.idle <- entry
+			}
+		default:
+			return
+		}
+	}
+}
+
+// Len returns the count of open connections, including in-use and idle ones.
+func (p *Pool) Len() int {
+	return len(p.slots)
+}
+
+// IdleLen returns the count of idle connections in pool.
+func (p *Pool) IdleLen() int {
+	return len(p.idle)
+}
+
+// Close closes all idle connections and marks the pool closed.
+// The in-use connections are closed when they are given back.
+func (p *Pool) Close() {
+	p.closed.Set(true)
+	for {
+		select {
+		case entry := <-p.idle:
+			p.Discard(entry.conn)
+		default:
+			return
+		}
+	}
+}
+
+// isUsable checks whether the idle connection can be checked out.
+func (p *Pool) isUsable(entry *poolEntry) bool {
+	if time.Since(entry.idleSince) > p.config.MaxIdleTime {
+		return false
+	}
+	return p.config.Validate(entry.conn) == nil
+}
+
+// dial creates a new connection using the pool configuration.
+func (p *Pool) dial() (*Conn, error) {
+	if p.config.Dial != nil {
+		return p.config.Dial()
+	}
+	if p.config.TLSConfig != nil {
+		conn, err := NewNetConnTLS(p.addr, p.config.TLSConfig, p.config.DialTimeout)
+		if err != nil {
+			return nil, err
+		}
+		return NewConnByNetConn(conn), nil
+	}
+	return NewConn(p.addr, p.config.DialTimeout)
+}
+
+// DefaultPoolValidate is the default validation for pool connections, which checks whether the
+// connection has been closed by the remote peer using a non-blocking read.
+func DefaultPoolValidate(conn *Conn) error {
+	if err := conn.SetReadDeadline(time.Now().Add(poolValidateTimeout)); err != nil {
+		return err
+	}
+	_, err := conn.reader.Peek(1)
+	if resetErr := conn.SetReadDeadline(conn.deadlineRecv); resetErr != nil {
+		return resetErr
+	}
+	switch {
+	case err == nil, isTimeout(err):
+		return nil
+	case err == io.EOF:
+		return gerror.NewCode(gcode.CodeInvalidOperation, "connection closed by remote peer")
+	default:
+		return err
+	}
+}
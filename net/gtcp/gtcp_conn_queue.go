@@ -0,0 +1,274 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SendQueuePolicy is the policy applied when the asynchronous send queue is full,
+// which usually means the remote peer is a slow consumer.
+type SendQueuePolicy int
+
+const (
+	SendQueuePolicyBlock SendQueuePolicy = iota // Blocks the caller until the queue has free space.
+	SendQueuePolicyDrop                         // Drops the data and returns error immediately.
+	SendQueuePolicyClose                        // Closes the connection and returns error immediately.
+)
+
+// SendQueueConfig is the configuration for asynchronous send queue of connection.
+type SendQueueConfig struct {
+	Size         int             // Max count of pending data in queue. Default is 1024.
+	Policy       SendQueuePolicy // Policy when the queue is full. Default is SendQueuePolicyBlock.
+	MaxCoalesce  int             // Max bytes of pending data merged into one write. Default is 64KB, and -1 disables coalescing.
+	WriteTimeout time.Duration   // Timeout for each write, the connection is closed if writing timeouts. Default is no timeout.
+	ErrorHandler func(err error) // Optional callback for the error that stops the queue writing.
+}
+
+// SendQueueStats holds the statistics of asynchronous send queue.
+type SendQueueStats struct {
+	Depth    int   // Count of pending data in queue.
+	Capacity int   // Capacity of the queue.
+	Sent     int64 // Count of data that were written to connection.
+	Dropped  int64 // Count of data that were dropped by SendQueuePolicyDrop.
+	Writes   int64 // Count of writes to connection, which is lesser than Sent if data are coalesced.
+}
+
+// sendQueue is the asynchronous send queue of connection.
+type sendQueue struct {
+	conn      *Conn
+	config    SendQueueConfig
+	items     chan []byte   // Pending data.
+	closing   chan struct{} // Closed when the queue is closing, the writer flushes pending data then exits.
+	done      chan struct{} // Closed when the writer exits.
+	closeOnce sync.Once
+	sent      *gtype.Int64
+	dropped   *gtype.Int64
+	writes    *gtype.Int64
+}
+
+const (
+	defaultSendQueueSize         = 1024
+	defaultSendQueueMaxCoalesce  = 64 * 1024
+	defaultSendQueueFlushTimeout = 5 * time.Second
+)
+
+// EnableSendQueue enables asynchronous send queue for the connection, which is used by SendAsync.
+// A standalone goroutine writes the queued data to the connection in order, so that a stalled
+// remote peer does not block the callers, and the behavior on full queue is specified by the policy.
+//
+// It does nothing if the send queue is already enabled.
+func (c *Conn) EnableSendQueue(config ...SendQueueConfig) {
+	var queueConfig SendQueueConfig
+	if len(config) > 0 {
+		queueConfig = config[0]
+	}
+	if queueConfig.Size <= 0 {
+		queueConfig.Size = defaultSendQueueSize
+	}
+	if queueConfig.MaxCoalesce == 0 {
+		queueConfig.MaxCoalesce = defaultSendQueueMaxCoalesce
+	}
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	if c.sendQueue != nil {
+		return
+	}
+	c.sendQueue = &sendQueue{
+		conn:    c,
+		config:  queueConfig,
+		items:   make(chan []byte, queueConfig.Size),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+		sent:    gtype.NewInt64(),
+		dropped: gtype.NewInt64(),
+		writes:  gtype.NewInt64(),
+	}
+	go c.sendQueue.run()
+}
+
+// SendAsync puts data to the send queue, which is written to connection asynchronously.
+// It enables the send queue with default configuration if it is not enabled.
+//
+// Note that the `data` should not be modified after it is put to queue.
+func (c *Conn) SendAsync(data []byte) error {
+	return c.getSendQueue().put(data)
+}
+
+// SendPkgAsync puts data to the send queue using simple package protocol.
+// See SendAsync and SendPkg.
+func (c *Conn) SendPkgAsync(data []byte, option ...PkgOption) error {
+	buffer, _, err := packPkg(data, option...)
+	if err != nil {
+		return err
+	}
+	return c.SendAsync(buffer)
+}
+
+// SendQueueStats returns the statistics of the send queue.
+// It returns empty statistics if the send queue is not enabled.
+func (c *Conn) SendQueueStats() SendQueueStats {
+	c.queueMu.Lock()
+	q := c.sendQueue
+	c.queueMu.Unlock()
+	if q == nil {
+		return SendQueueStats{}
+	}
+	return SendQueueStats{
+		Depth:    len(q.items),
+		Capacity: cap(q.items),
+		Sent:     q.sent.Val(),
+		Dropped:  q.dropped.Val(),
+		Writes:   q.writes.Val(),
+	}
+}
+
+// Close closes the connection.
+// It flushes the pending data in send queue before closing if the send queue is enabled.
+func (c *Conn) Close() error {
+	c.queueMu.Lock()
+	q := c.sendQueue
+	c.queueMu.Unlock()
+	if q != nil {
+		q.close()
+	}
+	return c.Conn.Close()
+}
+
+// getSendQueue returns the send queue, which enables it with default configuration if necessary.
+func (c *Conn) getSendQueue() *sendQueue {
+	c.queueMu.Lock()
+	q := c.sendQueue
+	c.queueMu.Unlock()
+	if q == nil {
+		c.EnableSendQueue()
+		c.queueMu.Lock()
+		q = c.sendQueue
+		c.queueMu.Unlock()
+	}
+	return q
+}
+
+// put puts data to the queue, applying the policy if the queue is full.
+func (q *sendQueue) put(data []byte) error {
+	select {
+	case <-q.closing:
+		return gerror.NewCode(gcode.CodeInvalidOperation, "send queue is closed")
+	case <-q.done:
+		return gerror.NewCode(gcode.CodeInvalidOperation, "send queue is closed")
+	default:
+	}
+	select {
+	case q.items <- data:
+		return nil
+	default:
+	}
+	switch q.config.Policy {
+	case SendQueuePolicyDrop:
+		q.dropped.Add(1)
+		return gerror.NewCode(gcode.CodeOperationFailed, "send queue is full, data dropped")
+
+	case SendQueuePolicyClose:
+		q.abort()
+		return gerror.NewCode(gcode.CodeOperationFailed, "send queue is full, connection closed")
+
+	default:
+		select {
+		case q.items <- data:
+			return nil
+		case <-q.closing:
+			return gerror.NewCode(gcode.CodeInvalidOperation, "send queue is closed")
+		case <-q.done:
+			return gerror.NewCode(gcode.CodeInvalidOperation, "send queue is closed")
+		}
+	}
+}
+
+// run is the writing loop of the queue.
+func (q *sendQueue) run() {
+	defer close(q.done)
+	for {
+		select {
+		case data := <-q.items:
+			if !q.write(data) {
+				return
+			}
+		case <-q.closing:
+			// Flushes pending data before exiting.
+			for {
+				select {
+				case data := <-q.items:
+					if !q.write(data) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes data to connection, which merges the pending data into one write if possible.
+// It returns false if writing fails, and the connection is closed in that case.
+func (q *sendQueue) write(data []byte) bool {
+	var count int64 = 1
+	if q.config.MaxCoalesce > 0 {
+	coalesce:
+		for len(data) < q.config.MaxCoalesce {
+			select {
+			case next := <-q.items:
+				if count == 1 {
+					data = append(make([]byte, 0, len(data)+len(next)), data...)
+				}
+				data = append(data, next...)
+				count++
+			default:
+				break coalesce
+			}
+		}
+	}
+	if q.config.WriteTimeout > 0 {
+		_ = q.conn.Conn.SetWriteDeadline(time.Now().Add(q.config.WriteTimeout))
+	}
+	q.writes.Add(1)
+	if _, err := q.conn.Write(data); err != nil {
+		if q.config.ErrorHandler != nil {
+			q.config.ErrorHandler(gerror.Wrap(err, `send queue write data failed`))
+		}
+		_ = q.conn.Conn.Close()
+		return false
+	}
+	q.sent.Add(count)
+	return true
+}
+
+// close closes the queue and waits until the pending data are flushed.
+// The flushing is limited by the write timeout, or defaultSendQueueFlushTimeout if no write
+// timeout configured, in case of the remote peer is stalled.
+func (q *sendQueue) close() {
+	q.closeOnce.Do(func() {
+		if q.config.WriteTimeout == 0 {
+			_ = q.conn.Conn.SetWriteDeadline(time.Now().Add(defaultSendQueueFlushTimeout))
+		}
+		close(q.closing)
+	})
+	<-q.done
+}
+
+// abort closes the queue and the connection immediately without flushing.
+func (q *sendQueue) abort() {
+	q.closeOnce.Do(func() {
+		close(q.closing)
+	})
+	_ = q.conn.Conn.Close()
+}
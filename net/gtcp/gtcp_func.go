@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/errors/gerror"
-	"github.com/gogf/gf/v2/os/gfile"
 )
 
 const (
@@ -142,21 +141,10 @@ func isTimeout(err error) bool {
 
 // LoadKeyCrt creates and returns a TLS configuration object with given certificate and key files.
 func LoadKeyCrt(crtFile, keyFile string) (*tls.Config, error) {
-	crtPath, err := gfile.Search(crtFile)
+	crt, err := loadX509KeyPair(crtFile, keyFile)
 	if err != nil {
 		return nil, err
 	}
-	keyPath, err := gfile.Search(keyFile)
-	if err != nil {
-		return nil, err
-	}
-	crt, err := tls.LoadX509KeyPair(crtPath, keyPath)
-	if err != nil {
-		return nil, gerror.Wrapf(err,
-			`tls.LoadX509KeyPair failed for certFile "%s" and keyFile "%s"`,
-			crtPath, keyPath,
-		)
-	}
 	tlsConfig := &tls.Config{}
 	tlsConfig.Certificates = []tls.Certificate{crt}
 	tlsConfig.Time = time.Now
@@ -7,12 +7,15 @@
 package gtcp
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
 
 	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -30,11 +33,19 @@ const (
 
 // Server is a TCP server.
 type Server struct {
-	mu        sync.Mutex   // Used for Server.listen concurrent safety. -- The golang test with data race checks this.
-	listen    net.Listener // TCP address listener.
-	address   string       // Server listening address.
-	handler   func(*Conn)  // Connection handler.
-	tlsConfig *tls.Config  // TLS configuration.
+	mu           sync.Mutex      // Used for Server.listen concurrent safety. -- The golang test with data race checks this.
+	listen       net.Listener    // TCP address listener.
+	address      string          // Server listening address.
+	handler      func(*Conn)     // Connection handler.
+	tlsConfig    *tls.Config     // TLS configuration.
+	sni          sniCertificates // Certificates selected by SNI for TLS server.
+	codec        Codec           // Message framing codec applied to each connection.
+	payloadCodec PayloadCodec    // Message payload codec applied to each connection.
+	stats        *serverStats    // Statistics of the server.
+	conns        *gset.Set       // Connections being handled.
+	ctx          context.Context // Context of current running, which is done when server is shutting down.
+	cancel       context.CancelFunc
+	shutdown     *gtype.Bool // Marks the server is shutting down.
 }
 
 // Map for name to server, for singleton purpose.
@@ -57,8 +68,11 @@ func GetServer(name ...interface{}) *Server {
 // The parameter `name` is optional, which is used to specify the instance name of the server.
 func NewServer(address string, handler func(*Conn), name ...string) *Server {
 	s := &Server{
-		address: address,
-		handler: handler,
+		address:  address,
+		handler:  handler,
+		stats:    newServerStats(),
+		conns:    gset.New(true),
+		shutdown: gtype.NewBool(),
 	}
 	if len(name) > 0 && name[0] != "" {
 		serverMapping.Set(name[0], s)
@@ -99,6 +113,18 @@ func (s *Server) SetHandler(handler func(*Conn)) {
 	s.handler = handler
 }
 
+// SetCodec sets the message framing codec applied to each accepted connection.
+// See Conn.SetCodec.
+func (s *Server) SetCodec(codec Codec) {
+	s.codec = codec
+}
+
+// SetPayloadCodec sets the message payload codec applied to each accepted connection.
+// See Conn.SetPayloadCodec.
+func (s *Server) SetPayloadCodec(payloadCodec PayloadCodec) {
+	s.payloadCodec = payloadCodec
+}
+
 // SetTLSKeyCrt sets the certificate and key file for TLS configuration of server.
 func (s *Server) SetTLSKeyCrt(crtFile, keyFile string) error {
 	tlsConfig, err := LoadKeyCrt(crtFile, keyFile)
@@ -121,6 +147,7 @@ func (s *Server) Close() error {
 	if s.listen == nil {
 		return nil
 	}
+	runningServers.Remove(s)
 	return s.listen.Close()
 }
 
@@ -130,10 +157,15 @@ func (s *Server) Run() (err error) {
 		err = gerror.NewCode(gcode.CodeMissingConfiguration, "start running failed: socket handler not defined")
 		return
 	}
-	if s.tlsConfig != nil {
+	// The context is created for each running, so that the server can be run again after shutdown.
+	s.mu.Lock()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.mu.Unlock()
+	s.shutdown.Set(false)
+	if tlsConfig := s.getTLSConfig(); tlsConfig != nil {
 		// TLS Server
 		s.mu.Lock()
-		s.listen, err = tls.Listen("tcp", s.address, s.tlsConfig)
+		s.listen, err = tls.Listen("tcp", s.address, tlsConfig)
 		s.mu.Unlock()
 		if err != nil {
 			err = gerror.Wrapf(err, `tls.Listen failed for address "%s"`, s.address)
@@ -154,14 +186,22 @@ func (s *Server) Run() (err error) {
 			return err
 		}
 	}
+	runningServers.Add(s)
 	// Listening loop.
 	for {
 		var conn net.Conn
 		if conn, err = s.listen.Accept(); err != nil {
+			if s.shutdown.Val() {
+				return nil
+			}
 			err = gerror.Wrapf(err, `Listener.Accept failed`)
 			return err
 		} else if conn != nil {
-			go s.handler(NewConnByNetConn(conn))
+			c := s.newConn(conn)
+			s.stats.accepted.Add(1)
+			s.stats.active.Add(1)
+			s.conns.Add(c)
+			go s.handleConn(c)
 		}
 	}
 }
@@ -188,3 +228,22 @@ func (s *Server) GetListenedPort() int {
 	}
 	return -1
 }
+
+// newConn creates and returns a connection object for accepted net.Conn with server configurations.
+func (s *Server) newConn(netConn net.Conn) *Conn {
+	conn := NewConnByNetConn(netConn)
+	conn.codec = s.codec
+	conn.payloadCodec = s.payloadCodec
+	conn.serverStats = s.stats
+	conn.ctx = s.ctx
+	return conn
+}
+
+// handleConn calls the handler for accepted connection.
+func (s *Server) handleConn(conn *Conn) {
+	defer func() {
+		s.conns.Remove(conn)
+		s.stats.active.Add(-1)
+	}()
+	s.handler(conn)
+}
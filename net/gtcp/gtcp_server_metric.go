@@ -0,0 +1,103 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+const (
+	instrumentName             = "github.com/gogf/gf/v2/net/gtcp.Server"
+	metricAttrKeyServerAddress = "server.address"
+)
+
+var (
+	// runningServers holds the running servers, whose statistics are exported as metrics.
+	runningServers = gset.New(true)
+
+	// meter for tcp server metrics, the metrics are exported only if the metric feature is enabled.
+	meter = gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        instrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+
+	_ = meter.MustObservableCounter(
+		"tcp.server.connection.accepted",
+		gmetric.MetricOption{
+			Help:     "Total accepted connection number.",
+			Callback: newServerMetricCallback(func(stats ServerStats) int64 { return stats.Accepted }),
+		},
+	)
+	_ = meter.MustObservableGauge(
+		"tcp.server.connection.active",
+		gmetric.MetricOption{
+			Help:     "Number of active server connections.",
+			Callback: newServerMetricCallback(func(stats ServerStats) int64 { return stats.Active }),
+		},
+	)
+	_ = meter.MustObservableCounter(
+		"tcp.server.read_size",
+		gmetric.MetricOption{
+			Help:     "Total bytes read from connections.",
+			Unit:     "bytes",
+			Callback: newServerMetricCallback(func(stats ServerStats) int64 { return stats.BytesRead }),
+		},
+	)
+	_ = meter.MustObservableCounter(
+		"tcp.server.written_size",
+		gmetric.MetricOption{
+			Help:     "Total bytes written to connections.",
+			Unit:     "bytes",
+			Callback: newServerMetricCallback(func(stats ServerStats) int64 { return stats.BytesWritten }),
+		},
+	)
+	_ = meter.MustObservableGauge(
+		"tcp.server.send_queue.depth",
+		gmetric.MetricOption{
+			Help: "Total count of pending data in asynchronous send queues of connections.",
+			Callback: func(ctx context.Context, obs gmetric.MetricObserver) error {
+				runningServers.Iterator(func(v interface{}) bool {
+					var (
+						server = v.(*Server)
+						depth  int
+					)
+					for _, conn := range server.Connections() {
+						depth += conn.SendQueueStats().Depth
+					}
+					obs.Observe(float64(depth), server.getMetricOption())
+					return true
+				})
+				return nil
+			},
+		},
+	)
+)
+
+// newServerMetricCallback creates and returns a metric callback observing value of each running server.
+func newServerMetricCallback(valueFunc func(stats ServerStats) int64) gmetric.MetricCallback {
+	return func(ctx context.Context, obs gmetric.MetricObserver) error {
+		runningServers.Iterator(func(v interface{}) bool {
+			server := v.(*Server)
+			obs.Observe(float64(valueFunc(server.Stats())), server.getMetricOption())
+			return true
+		})
+		return nil
+	}
+}
+
+// getMetricOption returns the metric option with server attributes.
+func (s *Server) getMetricOption() gmetric.Option {
+	return gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyServerAddress, s.GetListenedAddress()),
+		},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// shutdownPollInterval is the interval checking whether all connection handlers are done.
+	shutdownPollInterval = 10 * time.Millisecond
+)
+
+// Context returns the context of the connection.
+// For connection accepted by server, the context is done when the server is shutting down,
+// which notifies the connection handler to finish its work.
+// For client connection, it returns context.Background.
+func (c *Conn) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// Shutdown gracefully shuts down the server without interrupting active connections.
+// It closes the listener to stop accepting new connections, notifies the connection handlers
+// by cancelling the context of each connection (see Conn.Context), and then waits for all
+// connection handlers to finish.
+//
+// If `ctx` is done before all handlers finish, it closes the remaining connections forcibly
+// and returns the error of `ctx`.
+//
+// The server can be run again by Run after it is shut down.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.shutdown.Set(true)
+	// The listener might be already closed by Close, the error is ignored here.
+	_ = s.Close()
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for {
+		if s.conns.Size() == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			for _, conn := range s.Connections() {
+				_ = conn.Conn.Close()
+			}
+			return gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `server shutdown timeout`)
+		case <-ticker.C:
+		}
+	}
+}
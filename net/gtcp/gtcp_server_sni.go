@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"crypto/tls"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// sniCertificates manages the certificates selected by SNI(Server Name Indication).
+type sniCertificates struct {
+	mu           sync.RWMutex
+	certificates map[string]*tls.Certificate // Domain to certificate, the domain can be wildcard like "*.goframe.org".
+	defaultName  string                      // Domain of the certificate used when no certificate matches.
+}
+
+// AddTLSCertificate registers certificate `cert` for domain `domain`, which is selected by SNI
+// in TLS handshake. The `domain` can be a wildcard domain like "*.goframe.org", which matches
+// exactly one level of subdomain.
+//
+// The first registered certificate is used as the default certificate if no certificate
+// matches the requested server name. Registering a certificate for an existing domain replaces
+// the old one, which takes effect for new connections without restarting the server.
+func (s *Server) AddTLSCertificate(domain string, cert tls.Certificate) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	s.sni.mu.Lock()
+	defer s.sni.mu.Unlock()
+	if s.sni.certificates == nil {
+		s.sni.certificates = make(map[string]*tls.Certificate)
+	}
+	if len(s.sni.certificates) == 0 {
+		s.sni.defaultName = domain
+	}
+	s.sni.certificates[domain] = &cert
+}
+
+// AddTLSKeyCrt loads certificate and key file and registers the certificate for domain `domain`.
+// See AddTLSCertificate.
+func (s *Server) AddTLSKeyCrt(domain, crtFile, keyFile string) error {
+	cert, err := loadX509KeyPair(crtFile, keyFile)
+	if err != nil {
+		return err
+	}
+	s.AddTLSCertificate(domain, cert)
+	return nil
+}
+
+// ReloadTLSKeyCrt reloads certificate and key file for registered domain `domain`.
+// It returns error if the domain is not registered, or the files are invalid, in which
+// case the old certificate is kept serving.
+func (s *Server) ReloadTLSKeyCrt(domain, crtFile, keyFile string) error {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	s.sni.mu.RLock()
+	_, ok := s.sni.certificates[domain]
+	s.sni.mu.RUnlock()
+	if !ok {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `no certificate registered for domain "%s"`, domain)
+	}
+	return s.AddTLSKeyCrt(domain, crtFile, keyFile)
+}
+
+// RemoveTLSCertificate removes the registered certificate for domain `domain`.
+func (s *Server) RemoveTLSCertificate(domain string) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	s.sni.mu.Lock()
+	defer s.sni.mu.Unlock()
+	delete(s.sni.certificates, domain)
+	if s.sni.defaultName == domain {
+		s.sni.defaultName = ""
+		for name := range s.sni.certificates {
+			s.sni.defaultName = name
+			break
+		}
+	}
+}
+
+// hasSNICertificates checks whether there's any certificate registered for SNI.
+func (s *Server) hasSNICertificates() bool {
+	s.sni.mu.RLock()
+	defer s.sni.mu.RUnlock()
+	return len(s.sni.certificates) > 0
+}
+
+// getTLSConfig returns the TLS configuration for listening, which is nil for normal server.
+func (s *Server) getTLSConfig() *tls.Config {
+	if !s.hasSNICertificates() {
+		return s.tlsConfig
+	}
+	var tlsConfig *tls.Config
+	if s.tlsConfig != nil {
+		tlsConfig = s.tlsConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.GetCertificate == nil {
+		tlsConfig.GetCertificate = s.getCertificate
+	}
+	return tlsConfig
+}
+
+// getCertificate selects the certificate by server name in TLS handshake.
+func (s *Server) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	var name = strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	s.sni.mu.RLock()
+	defer s.sni.mu.RUnlock()
+	if name != "" {
+		if cert, ok := s.sni.certificates[name]; ok {
+			return cert, nil
+		}
+		// Wildcard matching, like "*.goframe.org" for "www.goframe.org".
+		if pos := strings.IndexByte(name, '.'); pos > 0 {
+			if cert, ok := s.sni.certificates["*"+name[pos:]]; ok {
+				return cert, nil
+			}
+		}
+	}
+	// The certificates in TLS configuration take precedence over the default certificate.
+	if s.tlsConfig != nil && len(s.tlsConfig.Certificates) > 0 {
+		return &s.tlsConfig.Certificates[0], nil
+	}
+	if cert, ok := s.sni.certificates[s.sni.defaultName]; ok {
+		return cert, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotFound, `no certificate found for server name "%s"`, hello.ServerName)
+}
+
+// loadX509KeyPair searches and loads the certificate and key file.
+func loadX509KeyPair(crtFile, keyFile string) (tls.Certificate, error) {
+	crtPath, err := gfile.Search(crtFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPath, err := gfile.Search(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, err := tls.LoadX509KeyPair(crtPath, keyPath)
+	if err != nil {
+		return tls.Certificate{}, gerror.Wrapf(err,
+			`tls.LoadX509KeyPair failed for certFile "%s" and keyFile "%s"`,
+			crtPath, keyPath,
+		)
+	}
+	return cert, nil
+}
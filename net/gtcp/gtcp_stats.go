@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+)
+
+// ConnStats holds the statistics of a connection.
+type ConnStats struct {
+	BytesRead    int64         // Total bytes read from connection.
+	BytesWritten int64         // Total bytes written to connection.
+	CreatedAt    time.Time     // Creation time of the connection object.
+	LastActiveAt time.Time     // Last time of reading or writing data.
+	IdleTime     time.Duration // Duration since last reading or writing.
+}
+
+// ServerStats holds the statistics of a server.
+type ServerStats struct {
+	Accepted     int64 // Total count of accepted connections.
+	Active       int64 // Count of connections being handled.
+	BytesRead    int64 // Total bytes read from all connections.
+	BytesWritten int64 // Total bytes written to all connections.
+}
+
+// connStats holds the counters of a connection.
+type connStats struct {
+	bytesRead    *gtype.Int64
+	bytesWritten *gtype.Int64
+	createdAt    time.Time
+	lastActiveAt *gtype.Int64 // Unix nanoseconds.
+}
+
+// serverStats holds the counters of a server.
+type serverStats struct {
+	accepted     *gtype.Int64
+	active       *gtype.Int64
+	bytesRead    *gtype.Int64
+	bytesWritten *gtype.Int64
+}
+
+// connStatsReader counts the bytes read from connection.
+type connStatsReader struct {
+	conn *Conn
+}
+
+func newConnStats() *connStats {
+	now := time.Now()
+	return &connStats{
+		bytesRead:    gtype.NewInt64(),
+		bytesWritten: gtype.NewInt64(),
+		createdAt:    now,
+		lastActiveAt: gtype.NewInt64(now.UnixNano()),
+	}
+}
+
+func newServerStats() *serverStats {
+	return &serverStats{
+		accepted:     gtype.NewInt64(),
+		active:       gtype.NewInt64(),
+		bytesRead:    gtype.NewInt64(),
+		bytesWritten: gtype.NewInt64(),
+	}
+}
+
+// Stats returns the statistics of the connection.
+func (c *Conn) Stats() ConnStats {
+	lastActiveAt := time.Unix(0, c.stats.lastActiveAt.Val())
+	return ConnStats{
+		BytesRead:    c.stats.bytesRead.Val(),
+		BytesWritten: c.stats.bytesWritten.Val(),
+		CreatedAt:    c.stats.createdAt,
+		LastActiveAt: lastActiveAt,
+		IdleTime:     time.Since(lastActiveAt),
+	}
+}
+
+// Write writes data to the connection, which implements the interface io.Writer.
+// It overwrites the Write of underlying net.Conn for statistics.
+func (c *Conn) Write(b []byte) (n int, err error) {
+	n, err = c.Conn.Write(b)
+	if n > 0 {
+		c.stats.bytesWritten.Add(int64(n))
+		c.stats.lastActiveAt.Set(time.Now().UnixNano())
+		if c.serverStats != nil {
+			c.serverStats.bytesWritten.Add(int64(n))
+		}
+	}
+	return
+}
+
+// Read implements the interface io.Reader for the buffer reader of connection.
+func (r connStatsReader) Read(b []byte) (n int, err error) {
+	n, err = r.conn.Conn.Read(b)
+	if n > 0 {
+		r.conn.stats.bytesRead.Add(int64(n))
+		r.conn.stats.lastActiveAt.Set(time.Now().UnixNano())
+		if r.conn.serverStats != nil {
+			r.conn.serverStats.bytesRead.Add(int64(n))
+		}
+	}
+	return
+}
+
+// Stats returns the statistics of the server.
+func (s *Server) Stats() ServerStats {
+	return ServerStats{
+		Accepted:     s.stats.accepted.Val(),
+		Active:       s.stats.active.Val(),
+		BytesRead:    s.stats.bytesRead.Val(),
+		BytesWritten: s.stats.bytesWritten.Val(),
+	}
+}
+
+// Connections returns the connections being handled by the server.
+func (s *Server) Connections() []*Conn {
+	var conns = make([]*Conn, 0)
+	s.conns.Iterator(func(k interface{}) bool {
+		conns = append(conns, k.(*Conn))
+		return true
+	})
+	return conns
+}
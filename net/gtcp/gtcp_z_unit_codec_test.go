@@ -0,0 +1,153 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// customByteOrder is the byte order not supported by length field codec.
+type customByteOrder struct {
+	binary.ByteOrder
+}
+
+func Test_Codec_LengthField(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		codec, err := gtcp.NewLengthFieldCodec()
+		t.AssertNil(err)
+		buffer, err := codec.Encode([]byte("hello"))
+		t.AssertNil(err)
+		t.Assert(buffer, []byte{0, 5, 'h', 'e', 'l', 'l', 'o'})
+		data, err := codec.Decode(bufio.NewReader(bytes.NewReader(buffer)))
+		t.AssertNil(err)
+		t.Assert(data, "hello")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		codec, err := gtcp.NewLengthFieldCodec(gtcp.LengthFieldConfig{
+			HeaderSize:           4,
+			ByteOrder:            binary.LittleEndian,
+			LengthIncludesHeader: true,
+		})
+		t.AssertNil(err)
+		buffer, err := codec.Encode([]byte("hi"))
+		t.AssertNil(err)
+		t.Assert(buffer, []byte{6, 0, 0, 0, 'h', 'i'})
+		data, err := codec.Decode(bufio.NewReader(bytes.NewReader(buffer)))
+		t.AssertNil(err)
+		t.Assert(data, "hi")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gtcp.NewLengthFieldCodec(gtcp.LengthFieldConfig{HeaderSize: 5})
+		t.AssertNE(err, nil)
+		_, err = gtcp.NewLengthFieldCodec(gtcp.LengthFieldConfig{ByteOrder: customByteOrder{binary.BigEndian}})
+		t.AssertNE(err, nil)
+
+		codec := gtcp.MustNewLengthFieldCodec(gtcp.LengthFieldConfig{HeaderSize: 1, MaxDataSize: 3})
+		_, err = codec.Encode([]byte("hello"))
+		t.AssertNE(err, nil)
+		_, err = codec.Decode(bufio.NewReader(bytes.NewReader([]byte{5, 'h', 'e', 'l', 'l', 'o'})))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Codec_Delimiter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		codec := gtcp.NewDelimiterCodec([]byte("\r\n"))
+		buffer, err := codec.Encode([]byte("hello"))
+		t.AssertNil(err)
+		t.Assert(buffer, "hello\r\n")
+		_, err = codec.Encode([]byte("he\r\nllo"))
+		t.AssertNE(err, nil)
+
+		reader := bufio.NewReader(bytes.NewReader([]byte("a\rb\r\nc\r\n")))
+		data, err := codec.Decode(reader)
+		t.AssertNil(err)
+		t.Assert(data, "a\rb")
+		data, err = codec.Decode(reader)
+		t.AssertNil(err)
+		t.Assert(data, "c")
+		_, err = codec.Decode(reader)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		codec := gtcp.NewDelimiterCodec([]byte("\n"), 2)
+		_, err := codec.Decode(bufio.NewReader(bytes.NewReader([]byte("hello\n"))))
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			err, ok := recover().(error)
+			t.Assert(ok, true)
+			t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+		}()
+		gtcp.NewDelimiterCodec(nil)
+	})
+}
+
+func Test_Conn_Msg(t *testing.T) {
+	type Message struct {
+		Id   int
+		Name string
+	}
+	codec := gtcp.NewDelimiterCodec([]byte("\n"))
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			var msg *Message
+			if err := conn.RecvMsg(&msg); err != nil {
+				break
+			}
+			msg.Id++
+			conn.SendMsg(msg)
+		}
+	})
+	s.SetCodec(codec)
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		conn.SetCodec(codec)
+		conn.SetPayloadCodec(gtcp.PayloadCodecJSON)
+		var result *Message
+		t.AssertNil(conn.SendMsg(Message{Id: 1, Name: "john"}))
+		t.AssertNil(conn.RecvMsg(&result))
+		t.Assert(result, &Message{Id: 2, Name: "john"})
+
+		// Raw frames with the same codec.
+		t.AssertNil(conn.SendFrameAsync([]byte(`{"Id":10}`)))
+		data, err := conn.RecvFrame()
+		t.AssertNil(err)
+		t.Assert(data, `{"Id":11,"Name":""}`)
+	})
+}
+
+func Test_Conn_Frame_DefaultCodec(t *testing.T) {
+	s := startTCPPkgServer(gtcp.FreePortAddress)
+	defer s.Close()
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		// Default codec is compatible with simple package protocol.
+		t.AssertNil(conn.SendFrame([]byte("hello")))
+		data, err := conn.RecvFrame()
+		t.AssertNil(err)
+		t.Assert(data, "hello")
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ConnPool_Basic(t *testing.T) {
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			data, err := conn.RecvPkg()
+			if err != nil {
+				break
+			}
+			conn.SendPkg(data)
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			pool = gtcp.NewPool(s.GetListenedAddress(), gtcp.PoolConfig{MaxSize: 2})
+		)
+		defer pool.Close()
+		conn1, err := pool.Get(ctx)
+		t.AssertNil(err)
+		result, err := conn1.SendRecvPkg([]byte("john"))
+		t.AssertNil(err)
+		t.Assert(result, "john")
+
+		conn2, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(pool.Len(), 2)
+
+		// Pool exhausted.
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err = pool.Get(timeoutCtx)
+		t.AssertNE(err, nil)
+
+		// Waiting checkout gets the connection given back.
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			pool.Put(conn1)
+		}()
+		conn3, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn3 == conn1, true)
+
+		pool.Put(conn2)
+		pool.Put(conn3)
+		t.Assert(pool.Len(), 2)
+		t.Assert(pool.IdleLen(), 2)
+	})
+}
+
+func Test_ConnPool_ReplaceDead(t *testing.T) {
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		// Closes connection after one message.
+		defer conn.Close()
+		data, err := conn.RecvPkg()
+		if err != nil {
+			return
+		}
+		conn.SendPkg(data)
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			pool = gtcp.NewPool(s.GetListenedAddress(), gtcp.PoolConfig{MaxSize: 1})
+		)
+		defer pool.Close()
+		conn1, err := pool.Get(ctx)
+		t.AssertNil(err)
+		_, err = conn1.SendRecvPkg([]byte("john"))
+		t.AssertNil(err)
+		pool.Put(conn1)
+		time.Sleep(100 * time.Millisecond)
+
+		conn2, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn2 != conn1, true)
+		result, err := conn2.SendRecvPkg([]byte("smith"))
+		t.AssertNil(err)
+		t.Assert(result, "smith")
+		pool.Discard(conn2)
+		t.Assert(pool.Len(), 0)
+	})
+}
+
+func Test_ConnPool_ValidateAndIdle(t *testing.T) {
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		conn.RecvPkg()
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			validate = 0
+			pool     = gtcp.NewPool(s.GetListenedAddress(), gtcp.PoolConfig{
+				MaxIdleTime: 100 * time.Millisecond,
+				Validate: func(conn *gtcp.Conn) error {
+					validate++
+					return gerror.New("invalid")
+				},
+			})
+		)
+		defer pool.Close()
+		conn1, err := pool.Get(ctx)
+		t.AssertNil(err)
+		pool.Put(conn1)
+		conn2, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn2 != conn1, true)
+		t.Assert(validate, 1)
+		t.Assert(pool.Len(), 1)
+
+		pool.Put(conn2)
+		time.Sleep(150 * time.Millisecond)
+		pool.Clean()
+		t.Assert(pool.IdleLen(), 0)
+		t.Assert(pool.Len(), 0)
+	})
+}
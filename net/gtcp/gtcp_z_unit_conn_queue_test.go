@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Conn_SendAsync(t *testing.T) {
+	s := startTCPPkgServer(gtcp.FreePortAddress)
+	defer s.Close()
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		for i := 0; i < 10; i++ {
+			t.AssertNil(conn.SendPkgAsync([]byte("hello")))
+		}
+		for i := 0; i < 10; i++ {
+			data, err := conn.RecvPkgWithTimeout(time.Second)
+			t.AssertNil(err)
+			t.Assert(data, "hello")
+		}
+		stats := conn.SendQueueStats()
+		t.Assert(stats.Sent, 10)
+		t.Assert(stats.Depth, 0)
+		t.Assert(stats.Capacity, 1024)
+		t.AssertLE(stats.Writes, 10)
+	})
+}
+
+func Test_Conn_SendAsync_FlushOnClose(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client, server = net.Pipe()
+			conn           = gtcp.NewConnByNetConn(client)
+			received       = make(chan []byte, 1)
+		)
+		go func() {
+			data, _ := gtcp.NewConnByNetConn(server).Recv(10)
+			received <- data
+		}()
+		conn.EnableSendQueue(gtcp.SendQueueConfig{MaxCoalesce: -1})
+		t.AssertNil(conn.SendAsync([]byte("hello")))
+		t.AssertNil(conn.SendAsync([]byte("world")))
+		t.AssertNil(conn.Close())
+		t.Assert(<-received, "helloworld")
+		t.AssertNE(conn.SendAsync([]byte("closed")), nil)
+	})
+}
+
+func Test_Conn_SendAsync_Policy(t *testing.T) {
+	// Drop.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client, server = net.Pipe()
+			conn           = gtcp.NewConnByNetConn(client)
+		)
+		defer server.Close()
+		conn.EnableSendQueue(gtcp.SendQueueConfig{
+			Size:   1,
+			Policy: gtcp.SendQueuePolicyDrop,
+		})
+		// Nobody reads from the pipe, the first data blocks the writer and the second fills the queue.
+		t.AssertNil(conn.SendAsync([]byte("1")))
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(conn.SendAsync([]byte("2")))
+		t.AssertNE(conn.SendAsync([]byte("3")), nil)
+		t.Assert(conn.SendQueueStats().Dropped, 1)
+		t.Assert(conn.SendQueueStats().Depth, 1)
+		_ = client.Close()
+	})
+	// Close.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client, server = net.Pipe()
+			conn           = gtcp.NewConnByNetConn(client)
+			closed         = make(chan error, 1)
+		)
+		conn.EnableSendQueue(gtcp.SendQueueConfig{
+			Size:   1,
+			Policy: gtcp.SendQueuePolicyClose,
+			ErrorHandler: func(err error) {
+				closed <- err
+			},
+		})
+		t.AssertNil(conn.SendAsync([]byte("1")))
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(conn.SendAsync([]byte("2")))
+		t.AssertNE(conn.SendAsync([]byte("3")), nil)
+		t.AssertNE(<-closed, nil)
+		_, err := server.Read(make([]byte, 1))
+		t.AssertNE(err, nil)
+	})
+	// Block with write timeout.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client, server = net.Pipe()
+			conn           = gtcp.NewConnByNetConn(client)
+		)
+		defer server.Close()
+		conn.EnableSendQueue(gtcp.SendQueueConfig{
+			Size:         1,
+			WriteTimeout: 100 * time.Millisecond,
+		})
+		t.AssertNil(conn.SendAsync([]byte("1")))
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(conn.SendAsync([]byte("2")))
+		// It blocks until the writer fails with timeout and the queue stops.
+		t.AssertNE(conn.SendAsync([]byte("3")), nil)
+	})
+}
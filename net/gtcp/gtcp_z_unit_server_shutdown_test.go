@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Server_Shutdown(t *testing.T) {
+	// Handlers finish after context cancelled.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			finished = gtype.NewInt()
+			runErr   = make(chan error, 1)
+		)
+		s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+			defer conn.Close()
+			<-conn.Context().Done()
+			time.Sleep(50 * time.Millisecond)
+			finished.Add(1)
+		})
+		go func() {
+			runErr <- s.Run()
+		}()
+		time.Sleep(simpleTimeout)
+		for i := 0; i < 2; i++ {
+			conn, err := gtcp.NewConn(s.GetListenedAddress())
+			t.AssertNil(err)
+			defer conn.Close()
+		}
+		time.Sleep(simpleTimeout)
+		t.Assert(s.Stats().Active, 2)
+
+		t.AssertNil(s.Shutdown(context.Background()))
+		t.Assert(finished.Val(), 2)
+		t.Assert(s.Stats().Active, 0)
+		t.AssertNil(<-runErr)
+
+		_, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNE(err, nil)
+	})
+	// Remaining connections are closed forcibly if timeout.
+	gtest.C(t, func(t *gtest.T) {
+		s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+			defer conn.Close()
+			// Ignores the context and blocks reading.
+			_, _ = conn.RecvPkg()
+		})
+		go s.Run()
+		time.Sleep(simpleTimeout)
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		time.Sleep(simpleTimeout)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		t.AssertNE(s.Shutdown(ctx), nil)
+		_, err = conn.RecvWithTimeout(1, time.Second)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Server_Shutdown_Rerun(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+			defer conn.Close()
+			data, err := conn.Recv(-1)
+			if err != nil {
+				return
+			}
+			select {
+			case <-conn.Context().Done():
+				// The context of the new running should not be done.
+			default:
+				_ = conn.Send(data)
+			}
+		})
+		go s.Run()
+		time.Sleep(simpleTimeout)
+		t.AssertNil(s.Shutdown(context.Background()))
+
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- s.Run()
+		}()
+		time.Sleep(simpleTimeout)
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		defer conn.Close()
+		data, err := conn.SendRecv([]byte("hello"), -1)
+		t.AssertNil(err)
+		t.Assert(data, "hello")
+
+		t.AssertNil(s.Shutdown(context.Background()))
+		t.AssertNil(<-runErr)
+	})
+}
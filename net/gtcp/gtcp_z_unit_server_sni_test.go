@@ -0,0 +1,92 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func newTestCertificate(commonName string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{commonName},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func Test_Server_SNI(t *testing.T) {
+	s := gtcp.NewServer(gtcp.FreePortAddress, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		data, err := conn.RecvPkg()
+		if err != nil {
+			return
+		}
+		conn.SendPkg(data)
+	})
+	s.AddTLSCertificate("a.goframe.org", newTestCertificate("a.goframe.org"))
+	s.AddTLSCertificate("*.example.com", newTestCertificate("*.example.com"))
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	peerName := func(serverName string) string {
+		conn, err := gtcp.NewConnTLS(s.GetListenedAddress(), &tls.Config{
+			ServerName:         serverName,
+			InsecureSkipVerify: true,
+		})
+		if err != nil {
+			return err.Error()
+		}
+		defer conn.Close()
+		if _, err = conn.SendRecvPkg([]byte("hello")); err != nil {
+			return err.Error()
+		}
+		return conn.Conn.(*tls.Conn).ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(peerName("a.goframe.org"), "a.goframe.org")
+		t.Assert(peerName("www.example.com"), "*.example.com")
+		// Wildcard matches only one level.
+		t.Assert(peerName("a.b.example.com"), "a.goframe.org")
+		// Default certificate.
+		t.Assert(peerName("unknown.org"), "a.goframe.org")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Replacing certificate for new connections.
+		s.AddTLSCertificate("a.goframe.org", newTestCertificate("a2.goframe.org"))
+		t.Assert(peerName("a.goframe.org"), "a2.goframe.org")
+
+		t.AssertNE(s.ReloadTLSKeyCrt("none.goframe.org", crtFile, keyFile), nil)
+		t.AssertNil(s.ReloadTLSKeyCrt("*.example.com", crtFile, keyFile))
+		t.Assert(peerName("www.example.com"), "")
+
+		s.RemoveTLSCertificate("a.goframe.org")
+		t.Assert(peerName("a.goframe.org"), "")
+	})
+}
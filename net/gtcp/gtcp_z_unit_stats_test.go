@@ -0,0 +1,48 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Stats(t *testing.T) {
+	s := startTCPPkgServer(gtcp.FreePortAddress)
+	defer s.Close()
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		t.AssertNil(conn.SendPkg([]byte("hello")))
+		data, err := conn.RecvPkg()
+		t.AssertNil(err)
+		t.Assert(data, "hello")
+
+		stats := conn.Stats()
+		t.Assert(stats.BytesWritten, 7)
+		t.Assert(stats.BytesRead, 7)
+		t.AssertLE(stats.CreatedAt.UnixNano(), stats.LastActiveAt.UnixNano())
+		time.Sleep(50 * time.Millisecond)
+		t.AssertGE(conn.Stats().IdleTime, 50*time.Millisecond)
+
+		serverStats := s.Stats()
+		t.Assert(serverStats.Accepted, 1)
+		t.Assert(serverStats.Active, 1)
+		t.Assert(serverStats.BytesRead, 7)
+		t.Assert(serverStats.BytesWritten, 7)
+		t.Assert(len(s.Connections()), 1)
+
+		t.AssertNil(conn.Close())
+		time.Sleep(simpleTimeout)
+		t.Assert(s.Stats().Active, 0)
+		t.Assert(s.Stats().Accepted, 1)
+		t.Assert(len(s.Connections()), 0)
+	})
+}
@@ -34,6 +34,7 @@ const (
 	tracingCommonKeyIpHostname        = `hostname`
 	commandEnvKeyForMaxContentLogSize = "gf.gtrace.max.content.log.size" // To avoid too big tracing content.
 	commandEnvKeyForTracingInternal   = "gf.gtrace.tracing.internal"     // For detailed controlling for tracing content.
+	commandEnvKeyForIdInjection       = "gf.gtrace.id.injection"         // For injecting trace/span ids into logs and responses.
 )
 
 var (
@@ -51,6 +52,7 @@ var (
 
 func init() {
 	tracingInternal = gconv.Bool(command.GetOptWithEnv(commandEnvKeyForTracingInternal, "true"))
+	idInjection.Set(gconv.Bool(command.GetOptWithEnv(commandEnvKeyForIdInjection, "false")))
 	if maxContentLogSize := gconv.Int(command.GetOptWithEnv(commandEnvKeyForMaxContentLogSize)); maxContentLogSize > 0 {
 		tracingMaxContentLogSize = maxContentLogSize
 	}
@@ -136,6 +138,12 @@ func SetBaggageMap(ctx context.Context, data map[string]interface{}) context.Con
 	return NewBaggage(ctx).SetMap(data)
 }
 
+// SetBaggageMember validates and adds one key-value pair to baggage, which keeps the existing members.
+// It returns error if the key is invalid or the baggage exceeds the size limits.
+func SetBaggageMember(ctx context.Context, key string, value interface{}) (context.Context, error) {
+	return NewBaggage(ctx).SetMember(key, value)
+}
+
 // GetBaggageMap retrieves and returns the baggage values as map.
 func GetBaggageMap(ctx context.Context) *gmap.StrAnyMap {
 	return NewBaggage(ctx).GetMap()
@@ -13,9 +13,22 @@ import (
 
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
+// BaggageKey is the typed key of baggage member, which is usually defined as constant
+// for setting and reading the baggage member in type-safe way.
+type BaggageKey string
+
+// The size limits of baggage according to W3C Baggage specification.
+const (
+	BaggageMaxMembers     = 64   // Max count of members in baggage.
+	BaggageMaxMemberBytes = 4096 // Max bytes of a single member in encoded format.
+	BaggageMaxBytes       = 8192 // Max bytes of the whole baggage in encoded format.
+)
+
 // Baggage holds the data through all tracing spans.
 type Baggage struct {
 	ctx context.Context
@@ -73,3 +86,79 @@ func (b *Baggage) GetVar(key string) *gvar.Var {
 	value := baggage.FromContext(b.ctx).Member(key).Value()
 	return gvar.New(value)
 }
+
+// SetMember validates and adds one key-value pair to baggage, which keeps the existing members
+// and replaces the member of the same key. The value is percent-encoded in propagation, so it can
+// contain any characters.
+//
+// It returns error and keeps the baggage unchanged if the key is invalid, or the baggage exceeds the
+// size limits BaggageMaxMembers, BaggageMaxMemberBytes and BaggageMaxBytes after adding.
+func (b *Baggage) SetMember(key string, value interface{}) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, gconv.String(value))
+	if err != nil {
+		return b.ctx, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid baggage member key "%s"`, key)
+	}
+	if size := len(member.String()); size > BaggageMaxMemberBytes {
+		return b.ctx, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`baggage member "%s" size %d exceeds the limit %d`,
+			key, size, BaggageMaxMemberBytes,
+		)
+	}
+	bag, err := baggage.FromContext(b.ctx).SetMember(member)
+	if err != nil {
+		return b.ctx, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `set baggage member "%s" failed`, key)
+	}
+	if bag.Len() > BaggageMaxMembers {
+		return b.ctx, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`baggage member count %d exceeds the limit %d`,
+			bag.Len(), BaggageMaxMembers,
+		)
+	}
+	if size := len(bag.String()); size > BaggageMaxBytes {
+		return b.ctx, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`baggage size %d exceeds the limit %d`,
+			size, BaggageMaxBytes,
+		)
+	}
+	b.ctx = baggage.ContextWithBaggage(b.ctx, bag)
+	return b.ctx, nil
+}
+
+// DeleteMember removes the member of `key` from baggage.
+func (b *Baggage) DeleteMember(key string) context.Context {
+	bag := baggage.FromContext(b.ctx)
+	if bag.Member(key).Key() == "" {
+		return b.ctx
+	}
+	b.ctx = baggage.ContextWithBaggage(b.ctx, bag.DeleteMember(key))
+	return b.ctx
+}
+
+// Contains checks and returns whether the member of `key` exists in baggage.
+func (b *Baggage) Contains(key string) bool {
+	return baggage.FromContext(b.ctx).Member(key).Key() != ""
+}
+
+// Set validates and adds the key-value pair to the baggage of `ctx`, see Baggage.SetMember.
+func (k BaggageKey) Set(ctx context.Context, value interface{}) (context.Context, error) {
+	return NewBaggage(ctx).SetMember(string(k), value)
+}
+
+// Get retrieves and returns the value of the key from the baggage of `ctx` as *gvar.Var,
+// which can be converted to any type like Int, Bool, Time, etc.
+func (k BaggageKey) Get(ctx context.Context) *gvar.Var {
+	return NewBaggage(ctx).GetVar(string(k))
+}
+
+// Exists checks and returns whether the key exists in the baggage of `ctx`.
+func (k BaggageKey) Exists(ctx context.Context) bool {
+	return NewBaggage(ctx).Contains(string(k))
+}
+
+// Delete removes the key from the baggage of `ctx`.
+func (k BaggageKey) Delete(ctx context.Context) context.Context {
+	return NewBaggage(ctx).DeleteMember(string(k))
+}
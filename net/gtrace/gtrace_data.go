@@ -0,0 +1,194 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// redactionRule is the rule replacing the sensitive content of span attributes.
+type redactionRule struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+const (
+	// DataStatementPlaceholder is the placeholder replacing the literals in sanitized statements.
+	DataStatementPlaceholder = "?"
+
+	dataAttrRowsAffected = "db.rows_affected"
+	dataAttrRetryCount   = "retry.count"
+)
+
+var (
+	// ansiQuotesSystems are the database systems using double quotes for identifiers rather than string literals.
+	ansiQuotesSystems = map[string]bool{
+		"pgsql":      true,
+		"postgres":   true,
+		"postgresql": true,
+		"oracle":     true,
+		"sqlite":     true,
+		"mssql":      true,
+		"dm":         true,
+		"clickhouse": true,
+	}
+
+	redactionRules   []redactionRule
+	redactionRulesMu sync.RWMutex
+)
+
+// AddRedactionRule adds a global redaction rule replacing the content matching regular expression
+// `pattern` with `replacement` in span attributes of data-access operations, like statements and arguments.
+// The `replacement` supports group references like "$1".
+//
+// Example:
+// AddRedactionRule(`(?i)(password\s*=\s*)\S+`, "${1}***").
+func AddRedactionRule(pattern string, replacement string) error {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid redaction pattern "%s"`, pattern)
+	}
+	redactionRulesMu.Lock()
+	defer redactionRulesMu.Unlock()
+	redactionRules = append(redactionRules, redactionRule{
+		regex:       regex,
+		replacement: replacement,
+	})
+	return nil
+}
+
+// Redact replaces the sensitive content of `content` using the global redaction rules.
+func Redact(content string) string {
+	redactionRulesMu.RLock()
+	defer redactionRulesMu.RUnlock()
+	for _, rule := range redactionRules {
+		content = rule.regex.ReplaceAllString(content, rule.replacement)
+	}
+	return content
+}
+
+// SanitizeStatement strips the string and numeric literals from statement like SQL,
+// replacing them with DataStatementPlaceholder, and then applies the global redaction rules.
+//
+// The quoted identifiers like `name` are kept. The double-quoted tokens like "name" are taken as string
+// literals and stripped, as they are string literals in MySQL without ANSI_QUOTES, unless the optional
+// parameter `system` specifies the database system known to use ANSI quoted identifiers, like "pgsql".
+//
+// Example:
+// SanitizeStatement("SELECT * FROM user WHERE id=1 AND name='john'")
+// -> "SELECT * FROM user WHERE id=? AND name=?".
+func SanitizeStatement(statement string, system ...string) string {
+	var (
+		builder      strings.Builder
+		length       = len(statement)
+		ansiQuotes   = len(system) > 0 && ansiQuotesSystems[strings.ToLower(system[0])]
+		literalQuote = func(c byte) bool { return c == '\'' || (c == '"' && !ansiQuotes) }
+	)
+	builder.Grow(length)
+	for i := 0; i < length; i++ {
+		c := statement[i]
+		switch {
+		case literalQuote(c):
+			// String literal, in which the quote is escaped by doubling it or backslash.
+			i++
+			for ; i < length; i++ {
+				if statement[i] == '\\' {
+					i++
+					continue
+				}
+				if statement[i] == c {
+					if i+1 < length && statement[i+1] == c {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			builder.WriteString(DataStatementPlaceholder)
+
+		case c == '"' || c == '`':
+			// Quoted identifier.
+			end := strings.IndexByte(statement[i+1:], c)
+			if end < 0 {
+				builder.WriteString(statement[i:])
+				i = length
+				continue
+			}
+			builder.WriteString(statement[i : i+end+2])
+			i += end + 1
+
+		case isDigit(c) && (i == 0 || !isIdentifierChar(statement[i-1])):
+			// Numeric literal, like 1, 1.5, 1e10 and 0xFF.
+			for i+1 < length && (isIdentifierChar(statement[i+1]) || statement[i+1] == '.') {
+				i++
+			}
+			builder.WriteString(DataStatementPlaceholder)
+
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return Redact(builder.String())
+}
+
+// DataAttributes returns the normalized attributes of data-access operation:
+// db.system and db.statement, in which the statement is sanitized by SanitizeStatement of `system`.
+func DataAttributes(system string, statement string) []attribute.KeyValue {
+	attributes := make([]attribute.KeyValue, 0, 2)
+	if system != "" {
+		attributes = append(attributes, semconv.DBSystemKey.String(system))
+	}
+	if statement != "" {
+		attributes = append(attributes, semconv.DBStatement(SanitizeStatement(statement, system)))
+	}
+	return attributes
+}
+
+// PeerAttributes returns the normalized attributes of the remote peer `address` like "host:port":
+// net.peer.name and net.peer.port.
+func PeerAttributes(address string) []attribute.KeyValue {
+	if address == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return []attribute.KeyValue{semconv.NetPeerName(address)}
+	}
+	attributes := []attribute.KeyValue{semconv.NetPeerName(host)}
+	if portInt, err := strconv.Atoi(port); err == nil {
+		attributes = append(attributes, semconv.NetPeerPort(portInt))
+	}
+	return attributes
+}
+
+// RowsAffectedAttribute returns the attribute db.rows_affected of data-access operation.
+func RowsAffectedAttribute(rows int64) attribute.KeyValue {
+	return attribute.Int64(dataAttrRowsAffected, rows)
+}
+
+// RetryCountAttribute returns the attribute retry.count of the operation that is retried.
+func RetryCountAttribute(count int) attribute.KeyValue {
+	return attribute.Int(dataAttrRetryCount, count)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
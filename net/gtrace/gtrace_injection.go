@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"github.com/gogf/gf/v2/container/gtype"
+)
+
+const (
+	// DefaultIdInjectionHeader is the default response header name carrying the trace id
+	// if the id injection is enabled.
+	DefaultIdInjectionHeader = "X-Trace-Id"
+)
+
+var (
+	// idInjection enables injecting trace/span ids into logs and responses.
+	idInjection = gtype.NewBool()
+	// idInjectionHeader is the response header name carrying the trace id.
+	idInjectionHeader = gtype.NewString(DefaultIdInjectionHeader)
+)
+
+// SetIdInjection enables or disables the injection of trace/span ids, which is disabled in default.
+// It can also be enabled by command option or environment "gf.gtrace.id.injection".
+//
+// If it is enabled, the trace and span ids are injected as fields of all glog entries carrying the context,
+// and the trace id is injected as response header of ghttp server, which is "X-Trace-Id" in default.
+// It closes the loop between logs, traces and the error reports of clients.
+func SetIdInjection(enabled bool) {
+	idInjection.Set(enabled)
+}
+
+// IsIdInjectionEnabled checks and returns whether the injection of trace/span ids is enabled.
+func IsIdInjectionEnabled() bool {
+	return idInjection.Val()
+}
+
+// SetIdInjectionHeader sets the response header name carrying the trace id in id injection.
+// It resets the header name to DefaultIdInjectionHeader if `header` is empty.
+func SetIdInjectionHeader(header string) {
+	if header == "" {
+		header = DefaultIdInjectionHeader
+	}
+	idInjectionHeader.Set(header)
+}
+
+// GetIdInjectionHeader returns the response header name carrying the trace id in id injection.
+func GetIdInjectionHeader() string {
+	return idInjectionHeader.Val()
+}
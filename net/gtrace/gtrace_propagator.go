@@ -0,0 +1,57 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Propagator names, which are the same as the values of environment "OTEL_PROPAGATORS".
+const (
+	PropagatorTraceContext = "tracecontext" // W3C Trace Context.
+	PropagatorBaggage      = "baggage"      // W3C Baggage.
+	PropagatorB3           = "b3"           // B3 single header.
+	PropagatorB3Multi      = "b3multi"      // B3 multiple headers.
+	PropagatorNone         = "none"         // No propagation.
+)
+
+// NewTextMapPropagator creates and returns a composite propagator by propagator `names`,
+// which can be configured for certain client or server instead of the global propagator.
+// The names are case-insensitive, and the available names are: tracecontext, baggage, b3, b3multi and none.
+// It returns the global propagator if `names` is empty.
+func NewTextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
+	if len(names) == 0 {
+		return otel.GetTextMapPropagator(), nil
+	}
+	var propagators = make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case PropagatorTraceContext:
+			propagators = append(propagators, propagation.TraceContext{})
+		case PropagatorBaggage:
+			propagators = append(propagators, propagation.Baggage{})
+		case PropagatorB3:
+			propagators = append(propagators, B3Propagator{})
+		case PropagatorB3Multi:
+			propagators = append(propagators, B3Propagator{MultipleHeaders: true})
+		case PropagatorNone:
+		default:
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`invalid propagator name "%s", available names: %s, %s, %s, %s, %s`,
+				name, PropagatorTraceContext, PropagatorBaggage, PropagatorB3, PropagatorB3Multi, PropagatorNone,
+			)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
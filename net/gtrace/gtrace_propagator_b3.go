@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// B3Propagator propagates the span context in B3 format used by Zipkin,
+// see https://github.com/openzipkin/b3-propagation.
+//
+// It extracts the span context from both the single "b3" header and the multiple "X-B3-*" headers,
+// and injects the span context into the single header in default.
+type B3Propagator struct {
+	// MultipleHeaders specifies injecting the span context into multiple "X-B3-*" headers
+	// instead of the single "b3" header, which is for the peers not supporting the single header.
+	MultipleHeaders bool
+}
+
+const (
+	b3HeaderSingle       = "b3"
+	b3HeaderTraceId      = "x-b3-traceid"
+	b3HeaderSpanId       = "x-b3-spanid"
+	b3HeaderSampled      = "x-b3-sampled"
+	b3HeaderFlags        = "x-b3-flags"
+	b3Sampled            = "1"
+	b3NotSampled         = "0"
+	b3Debug              = "d"
+	b3TraceId64BitPadded = "0000000000000000"
+)
+
+var (
+	// Check the implements for interface TextMapPropagator.
+	_ propagation.TextMapPropagator = B3Propagator{}
+)
+
+// Inject sets the span context from `ctx` into `carrier` in B3 format.
+func (p B3Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	sampled := b3NotSampled
+	if sc.IsSampled() {
+		sampled = b3Sampled
+	}
+	if p.MultipleHeaders {
+		carrier.Set(b3HeaderTraceId, sc.TraceID().String())
+		carrier.Set(b3HeaderSpanId, sc.SpanID().String())
+		carrier.Set(b3HeaderSampled, sampled)
+		return
+	}
+	carrier.Set(b3HeaderSingle, sc.TraceID().String()+"-"+sc.SpanID().String()+"-"+sampled)
+}
+
+// Extract reads the span context in B3 format from `carrier` into the returned context.
+// It returns `ctx` itself if there's no valid B3 span context in `carrier`.
+func (p B3Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var (
+		sc trace.SpanContext
+		ok bool
+	)
+	if value := carrier.Get(b3HeaderSingle); value != "" {
+		sc, ok = b3ExtractSingle(value)
+	} else {
+		sc, ok = b3ExtractMultiple(
+			carrier.Get(b3HeaderTraceId),
+			carrier.Get(b3HeaderSpanId),
+			carrier.Get(b3HeaderSampled),
+			carrier.Get(b3HeaderFlags),
+		)
+	}
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the keys whose values are set with Inject.
+func (p B3Propagator) Fields() []string {
+	if p.MultipleHeaders {
+		return []string{b3HeaderTraceId, b3HeaderSpanId, b3HeaderSampled}
+	}
+	return []string{b3HeaderSingle}
+}
+
+// b3ExtractSingle parses the span context from single header value like
+// "{TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}", in which the last two parts are optional.
+func b3ExtractSingle(value string) (trace.SpanContext, bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		// It might be the sampling state only, which carries no span context.
+		return trace.SpanContext{}, false
+	}
+	var sampled, flags string
+	if len(parts) > 2 {
+		if parts[2] == b3Debug {
+			flags = b3Sampled
+		} else {
+			sampled = parts[2]
+		}
+	}
+	return b3ExtractMultiple(parts[0], parts[1], sampled, flags)
+}
+
+// b3ExtractMultiple parses the span context from multiple header values.
+func b3ExtractMultiple(traceIdStr, spanIdStr, sampled, flags string) (trace.SpanContext, bool) {
+	if len(traceIdStr) == 16 {
+		traceIdStr = b3TraceId64BitPadded + traceIdStr
+	}
+	traceId, err := trace.TraceIDFromHex(traceIdStr)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanId, err := trace.SpanIDFromHex(spanIdStr)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	var traceFlags trace.TraceFlags
+	switch {
+	case flags == b3Sampled:
+		traceFlags = trace.FlagsSampled
+	case sampled == b3Sampled || strings.EqualFold(sampled, "true"):
+		traceFlags = trace.FlagsSampled
+	case sampled == "" || sampled == b3NotSampled || strings.EqualFold(sampled, "false"):
+	default:
+		return trace.SpanContext{}, false
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: traceFlags,
+		Remote:     true,
+	})
+	return sc, sc.IsValid()
+}
@@ -0,0 +1,182 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingRule is the rule deciding whether sampling the spans of certain operations.
+// A rule matches a span only if all its non-empty conditions match.
+type SamplingRule struct {
+	// Name is the span name pattern, which supports wildcard '*' matching any characters,
+	// like "/healthz", "/api/*" for HTTP routes or "/user.UserService/*" for RPC methods.
+	// It matches any span name if it is empty.
+	Name string
+
+	// Kind is the span kind, like trace.SpanKindServer.
+	// It matches any span kind if it is trace.SpanKindUnspecified.
+	Kind trace.SpanKind
+
+	// Attributes are the attribute key-value patterns given on span start, which supports wildcard '*'
+	// in value patterns. All the attributes should match.
+	Attributes map[string]string
+
+	// Ratio is the sampling ratio of matched spans in range [0, 1],
+	// in which 0 means never sampling and 1 means always sampling.
+	Ratio float64
+}
+
+// SamplerConfig is the configuration for rule based sampler.
+type SamplerConfig struct {
+	// Rules are evaluated in order for the root spans and the spans of remote parents,
+	// the first matched rule decides the sampling. The spans of local parents follow their parents,
+	// which keeps the trace integrity in process.
+	Rules []SamplingRule
+
+	// Fallback is the sampler for the spans matching no rule. It is sdkTrace.AlwaysSample in default.
+	Fallback sdkTrace.Sampler
+
+	// SampleErrors enables recording the spans that are not sampled, so the error spans can be exported
+	// by the processor created by NewErrorSpanProcessor, which always samples errors.
+	// Note that recording the spans that are not sampled costs more resources.
+	SampleErrors bool
+}
+
+// ruleSampler is a sampler deciding by the sampling rules.
+type ruleSampler struct {
+	config   SamplerConfig
+	samplers []sdkTrace.Sampler // Samplers of the rules in order.
+}
+
+var (
+	// globalSampler is the sampler for the tracer providers created by GoFrame.
+	globalSampler   sdkTrace.Sampler = sdkTrace.AlwaysSample()
+	globalSamplerMu sync.RWMutex
+)
+
+// NewSampler creates and returns a sampler deciding by the sampling rules of `config`,
+// which can be used in the creation of tracer provider, like:
+//
+//	sdkTrace.NewTracerProvider(sdkTrace.WithSampler(gtrace.NewSampler(config)))
+func NewSampler(config SamplerConfig) sdkTrace.Sampler {
+	if config.Fallback == nil {
+		config.Fallback = sdkTrace.AlwaysSample()
+	}
+	s := &ruleSampler{
+		config:   config,
+		samplers: make([]sdkTrace.Sampler, len(config.Rules)),
+	}
+	for i, rule := range config.Rules {
+		switch {
+		case rule.Ratio <= 0:
+			s.samplers[i] = sdkTrace.NeverSample()
+		case rule.Ratio >= 1:
+			s.samplers[i] = sdkTrace.AlwaysSample()
+		default:
+			s.samplers[i] = sdkTrace.TraceIDRatioBased(rule.Ratio)
+		}
+	}
+	return s
+}
+
+// SetSampler sets the global sampler, which is used by the tracer providers created by GoFrame,
+// like the tracer providers of tracing contrib packages.
+func SetSampler(sampler sdkTrace.Sampler) {
+	globalSamplerMu.Lock()
+	defer globalSamplerMu.Unlock()
+	if sampler == nil {
+		sampler = sdkTrace.AlwaysSample()
+	}
+	globalSampler = sampler
+}
+
+// GetSampler returns the global sampler, which is sdkTrace.AlwaysSample in default.
+func GetSampler() sdkTrace.Sampler {
+	globalSamplerMu.RLock()
+	defer globalSamplerMu.RUnlock()
+	return globalSampler
+}
+
+// ShouldSample implements interface sdkTrace.Sampler.
+func (s *ruleSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	var (
+		result   sdkTrace.SamplingResult
+		parentSc = trace.SpanContextFromContext(p.ParentContext)
+	)
+	if parentSc.IsValid() && !parentSc.IsRemote() {
+		result = sdkTrace.SamplingResult{Tracestate: parentSc.TraceState()}
+		if parentSc.IsSampled() {
+			result.Decision = sdkTrace.RecordAndSample
+		}
+	} else {
+		result = s.config.Fallback.ShouldSample(p)
+		for i, rule := range s.config.Rules {
+			if rule.match(p) {
+				result = s.samplers[i].ShouldSample(p)
+				break
+			}
+		}
+	}
+	if s.config.SampleErrors && result.Decision == sdkTrace.Drop {
+		result.Decision = sdkTrace.RecordOnly
+	}
+	return result
+}
+
+// Description implements interface sdkTrace.Sampler.
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf(`RuleSampler{rules:%d,fallback:%s}`, len(s.config.Rules), s.config.Fallback.Description())
+}
+
+// match checks whether the span of sampling parameters `p` matches the rule.
+func (r SamplingRule) match(p sdkTrace.SamplingParameters) bool {
+	if r.Kind != trace.SpanKindUnspecified && r.Kind != p.Kind {
+		return false
+	}
+	if r.Name != "" && !matchWildcard(r.Name, p.Name) {
+		return false
+	}
+	for key, pattern := range r.Attributes {
+		var matched bool
+		for _, attr := range p.Attributes {
+			if string(attr.Key) == key {
+				matched = matchWildcard(pattern, attr.Value.Emit())
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWildcard checks whether `s` matches `pattern`, in which wildcard '*' matches any characters.
+func matchWildcard(pattern, s string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == s
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(s, part)
+		if index < 0 {
+			return false
+		}
+		s = s[index+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,122 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// errorSpanProcessor exports the error spans that are recorded but not sampled.
+type errorSpanProcessor struct {
+	exporter sdkTrace.SpanExporter
+	queue    chan sdkTrace.ReadOnlySpan
+	closed   chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	exportMu sync.Mutex // Exporter does not support concurrent exporting.
+}
+
+const (
+	errorSpanQueueSize = 1024
+)
+
+// NewErrorSpanProcessor creates and returns a span processor exporting the spans which have error status
+// but are not sampled, which works with the sampler created by NewSampler with SamplerConfig.SampleErrors
+// enabled to always sample errors. The sampled spans are not exported by this processor, which should be
+// processed by other processors like sdkTrace.NewBatchSpanProcessor.
+//
+// The error spans are exported asynchronously, and they are dropped if the exporting queue is full.
+// Note that the `exporter` is not shut down by this processor, as it is usually shared with other processors.
+func NewErrorSpanProcessor(exporter sdkTrace.SpanExporter) sdkTrace.SpanProcessor {
+	p := &errorSpanProcessor{
+		exporter: exporter,
+		queue:    make(chan sdkTrace.ReadOnlySpan, errorSpanQueueSize),
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go p.exportLoop()
+	return p
+}
+
+// OnStart implements interface sdkTrace.SpanProcessor.
+func (p *errorSpanProcessor) OnStart(parent context.Context, s sdkTrace.ReadWriteSpan) {}
+
+// OnEnd implements interface sdkTrace.SpanProcessor.
+func (p *errorSpanProcessor) OnEnd(s sdkTrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() || s.Status().Code != codes.Error {
+		return
+	}
+	select {
+	case <-p.closed:
+	case p.queue <- s:
+	default:
+		intlog.Printf(context.Background(), `error span queue is full, span "%s" dropped`, s.Name())
+	}
+}
+
+// Shutdown implements interface sdkTrace.SpanProcessor.
+func (p *errorSpanProcessor) Shutdown(ctx context.Context) error {
+	p.once.Do(func() {
+		close(p.closed)
+	})
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// ForceFlush implements interface sdkTrace.SpanProcessor.
+func (p *errorSpanProcessor) ForceFlush(ctx context.Context) error {
+	p.exportQueued(ctx)
+	return nil
+}
+
+// exportLoop exports the queued error spans until the processor is shut down.
+func (p *errorSpanProcessor) exportLoop() {
+	defer close(p.done)
+	for {
+		select {
+		case <-p.closed:
+			p.exportQueued(context.Background())
+			return
+		case s := <-p.queue:
+			p.export(context.Background(), []sdkTrace.ReadOnlySpan{s})
+		}
+	}
+}
+
+// exportQueued exports all the spans in queue.
+func (p *errorSpanProcessor) exportQueued(ctx context.Context) {
+	var spans []sdkTrace.ReadOnlySpan
+	for {
+		select {
+		case s := <-p.queue:
+			spans = append(spans, s)
+		default:
+			if len(spans) > 0 {
+				p.export(ctx, spans)
+			}
+			return
+		}
+	}
+}
+
+func (p *errorSpanProcessor) export(ctx context.Context, spans []sdkTrace.ReadOnlySpan) {
+	p.exportMu.Lock()
+	defer p.exportMu.Unlock()
+	if err := p.exporter.ExportSpans(ctx, spans); err != nil {
+		intlog.Errorf(ctx, `export error spans failed: %+v`, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_SanitizeStatement(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(
+			gtrace.SanitizeStatement("SELECT * FROM user WHERE id=1 AND name='john'"),
+			"SELECT * FROM user WHERE id=? AND name=?",
+		)
+		t.Assert(
+			gtrace.SanitizeStatement("SELECT `id` FROM `user_2` WHERE score>1.5 LIMIT 10"),
+			"SELECT `id` FROM `user_2` WHERE score>? LIMIT ?",
+		)
+		// The double-quoted tokens are string literals unless the system uses ANSI quoted identifiers.
+		t.Assert(
+			gtrace.SanitizeStatement(`SELECT * FROM user WHERE name="john" AND note="say ""hi"" \"ok\""`),
+			"SELECT * FROM user WHERE name=? AND note=?",
+		)
+		t.Assert(gtrace.SanitizeStatement(`SELECT * FROM user WHERE name="john"`, "mysql"), "SELECT * FROM user WHERE name=?")
+		t.Assert(
+			gtrace.SanitizeStatement(`SELECT "name" FROM "user" WHERE id=1`, "pgsql"),
+			`SELECT "name" FROM "user" WHERE id=?`,
+		)
+		t.Assert(
+			gtrace.SanitizeStatement(`UPDATE user SET note='it''s \'ok\'' WHERE id IN(1,2,0xFF)`),
+			"UPDATE user SET note=? WHERE id IN(?,?,?)",
+		)
+		t.Assert(gtrace.SanitizeStatement("SELECT * FROM t1 WHERE v='unclosed"), "SELECT * FROM t1 WHERE v=?")
+		t.Assert(gtrace.SanitizeStatement(""), "")
+	})
+}
+
+func Test_Redact(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gtrace.Redact("AUTH secret-token-abc"), "AUTH secret-token-abc")
+
+		err := gtrace.AddRedactionRule(`secret-token-\w+`, "***")
+		t.AssertNil(err)
+		t.Assert(gtrace.Redact("AUTH secret-token-abc"), "AUTH ***")
+		t.Assert(gtrace.SanitizeStatement("SELECT secret-token-abc"), "SELECT ***")
+
+		err = gtrace.AddRedactionRule(`(`, "")
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+	})
+}
+
+func Test_DataAttributes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		attrs := gtrace.DataAttributes("mysql", "SELECT * FROM user WHERE id=1")
+		t.Assert(len(attrs), 2)
+		t.Assert(attrs[0], attribute.String("db.system", "mysql"))
+		t.Assert(attrs[1], attribute.String("db.statement", "SELECT * FROM user WHERE id=?"))
+		t.Assert(len(gtrace.DataAttributes("", "")), 0)
+
+		attrs = gtrace.DataAttributes("pgsql", `SELECT "name" FROM "user" WHERE id=1`)
+		t.Assert(attrs[1], attribute.String("db.statement", `SELECT "name" FROM "user" WHERE id=?`))
+	})
+}
+
+func Test_PeerAttributes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		attrs := gtrace.PeerAttributes("127.0.0.1:3306")
+		t.Assert(len(attrs), 2)
+		t.Assert(attrs[0], attribute.String("net.peer.name", "127.0.0.1"))
+		t.Assert(attrs[1], attribute.Int("net.peer.port", 3306))
+
+		attrs = gtrace.PeerAttributes("localhost")
+		t.Assert(len(attrs), 1)
+		t.Assert(attrs[0], attribute.String("net.peer.name", "localhost"))
+
+		t.Assert(len(gtrace.PeerAttributes("")), 0)
+	})
+}
+
+func Test_CountAttributes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gtrace.RowsAffectedAttribute(10), attribute.Int64("db.rows_affected", 10))
+		t.Assert(gtrace.RetryCountAttribute(2), attribute.Int("retry.count", 2))
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+const (
+	testTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanId  = "00f067aa0ba902b7"
+)
+
+func newTestSpanContext(sampled bool) context.Context {
+	var (
+		traceId, _ = trace.TraceIDFromHex(testTraceId)
+		spanId, _  = trace.SpanIDFromHex(testSpanId)
+		flags      trace.TraceFlags
+	)
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: flags,
+	}))
+}
+
+func Test_Baggage_Member(t *testing.T) {
+	const (
+		keyUserId gtrace.BaggageKey = "user-id"
+		keyTenant gtrace.BaggageKey = "tenant"
+	)
+	gtest.C(t, func(t *gtest.T) {
+		ctx, err := keyUserId.Set(context.Background(), 100)
+		t.AssertNil(err)
+		ctx, err = keyTenant.Set(ctx, "a b,c;d")
+		t.AssertNil(err)
+		// Existing members are kept.
+		t.Assert(keyUserId.Get(ctx).Int(), 100)
+		t.Assert(keyTenant.Get(ctx).String(), "a b,c;d")
+		t.Assert(keyUserId.Exists(ctx), true)
+
+		ctx = keyUserId.Delete(ctx)
+		t.Assert(keyUserId.Exists(ctx), false)
+		t.Assert(keyTenant.Exists(ctx), true)
+		t.Assert(gtrace.GetBaggageMap(ctx).Size(), 1)
+	})
+	// Invalid key.
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		newCtx, err := gtrace.SetBaggageMember(ctx, "invalid key", 1)
+		t.AssertNE(err, nil)
+		t.Assert(newCtx == ctx, true)
+	})
+	// Size limits.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gtrace.SetBaggageMember(context.Background(), "key", strings.Repeat("a", gtrace.BaggageMaxMemberBytes))
+		t.AssertNE(err, nil)
+
+		ctx := context.Background()
+		for i := 0; i < gtrace.BaggageMaxMembers; i++ {
+			ctx, err = gtrace.SetBaggageMember(ctx, "key"+strings.Repeat("k", i), i)
+			t.AssertNil(err)
+		}
+		_, err = gtrace.SetBaggageMember(ctx, "overflow", 1)
+		t.AssertNE(err, nil)
+
+		ctx = context.Background()
+		for i := 0; i < 3; i++ {
+			ctx, err = gtrace.SetBaggageMember(ctx, "key"+strings.Repeat("k", i), strings.Repeat("v", 2500))
+			t.AssertNil(err)
+		}
+		_, err = gtrace.SetBaggageMember(ctx, "overflow", strings.Repeat("v", 2500))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_NewTextMapPropagator(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		propagator, err := gtrace.NewTextMapPropagator("tracecontext", "Baggage", "b3")
+		t.AssertNil(err)
+		fields := propagator.Fields()
+		sort.Strings(fields)
+		t.Assert(fields, []string{"b3", "baggage", "traceparent", "tracestate"})
+
+		propagator, err = gtrace.NewTextMapPropagator("none")
+		t.AssertNil(err)
+		t.Assert(len(propagator.Fields()), 0)
+
+		propagator, err = gtrace.NewTextMapPropagator()
+		t.AssertNil(err)
+		t.AssertNE(propagator, nil)
+
+		_, err = gtrace.NewTextMapPropagator("jaeger")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_B3Propagator(t *testing.T) {
+	// Single header.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			carrier    = propagation.MapCarrier{}
+			propagator = gtrace.B3Propagator{}
+		)
+		propagator.Inject(newTestSpanContext(true), carrier)
+		t.Assert(carrier.Get("b3"), testTraceId+"-"+testSpanId+"-1")
+
+		sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), testTraceId)
+		t.Assert(sc.SpanID().String(), testSpanId)
+		t.Assert(sc.IsSampled(), true)
+		t.Assert(sc.IsRemote(), true)
+	})
+	// Multiple headers.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			carrier    = propagation.MapCarrier{}
+			propagator = gtrace.B3Propagator{MultipleHeaders: true}
+		)
+		propagator.Inject(newTestSpanContext(false), carrier)
+		t.Assert(carrier.Get("x-b3-traceid"), testTraceId)
+		t.Assert(carrier.Get("x-b3-spanid"), testSpanId)
+		t.Assert(carrier.Get("x-b3-sampled"), "0")
+
+		sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), testTraceId)
+		t.Assert(sc.IsSampled(), false)
+	})
+	// 64 bits trace id, debug flag and invalid values.
+	gtest.C(t, func(t *gtest.T) {
+		var propagator = gtrace.B3Propagator{}
+		sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.MapCarrier{
+			"b3": "a3ce929d0e0e4736-" + testSpanId + "-d-" + testSpanId,
+		}))
+		t.Assert(sc.TraceID().String(), "0000000000000000a3ce929d0e0e4736")
+		t.Assert(sc.IsSampled(), true)
+
+		for _, value := range []string{"0", "invalid-" + testSpanId, testTraceId + "-" + testSpanId + "-x"} {
+			sc = trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.MapCarrier{
+				"b3": value,
+			}))
+			t.Assert(sc.IsValid(), false)
+		}
+		carrier := propagation.MapCarrier{}
+		propagator.Inject(context.Background(), carrier)
+		t.Assert(len(carrier), 0)
+	})
+}
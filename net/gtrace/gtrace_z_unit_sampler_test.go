@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testSpanExporter struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (e *testSpanExporter) ExportSpans(ctx context.Context, spans []sdkTrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, span := range spans {
+		e.names = append(e.names, span.Name())
+	}
+	return nil
+}
+
+func (e *testSpanExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func Test_Sampler_Rules(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			sampler = gtrace.NewSampler(gtrace.SamplerConfig{
+				Rules: []gtrace.SamplingRule{
+					{Name: "/healthz", Ratio: 0},
+					{Name: "/api/*/debug", Kind: trace.SpanKindServer, Ratio: 0},
+					{Attributes: map[string]string{"db.system": "redis"}, Ratio: 0},
+					{Name: "/api/*", Ratio: 1},
+				},
+				Fallback: sdkTrace.NeverSample(),
+			})
+			provider = sdkTrace.NewTracerProvider(sdkTrace.WithSampler(sampler))
+			tracer   = provider.Tracer("test")
+			sampled  = func(ctx context.Context, name string, options ...trace.SpanStartOption) bool {
+				_, span := tracer.Start(ctx, name, options...)
+				defer span.End()
+				return span.SpanContext().IsSampled()
+			}
+			ctx = context.Background()
+		)
+		t.Assert(sampled(ctx, "/healthz"), false)
+		t.Assert(sampled(ctx, "/api/user/debug", trace.WithSpanKind(trace.SpanKindServer)), false)
+		t.Assert(sampled(ctx, "/api/user/debug", trace.WithSpanKind(trace.SpanKindClient)), true)
+		t.Assert(sampled(ctx, "/api/user", trace.WithAttributes(attribute.String("db.system", "redis"))), false)
+		t.Assert(sampled(ctx, "/api/user", trace.WithAttributes(attribute.String("db.system", "mysql"))), true)
+		t.Assert(sampled(ctx, "/other"), false)
+
+		// Local child spans follow the parent.
+		parentCtx, parent := tracer.Start(ctx, "/api/user")
+		t.Assert(sampled(parentCtx, "/healthz"), true)
+		parent.End()
+		// Remote parent spans are evaluated by rules.
+		remoteCtx := trace.ContextWithRemoteSpanContext(ctx, trace.SpanContextFromContext(newTestSpanContext(true)))
+		t.Assert(sampled(remoteCtx, "/healthz"), false)
+		t.AssertNE(sampler.Description(), "")
+	})
+}
+
+func Test_Sampler_SampleErrors(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			exporter = &testSpanExporter{}
+			sampler  = gtrace.NewSampler(gtrace.SamplerConfig{
+				Fallback:     sdkTrace.NeverSample(),
+				SampleErrors: true,
+			})
+			provider = sdkTrace.NewTracerProvider(
+				sdkTrace.WithSampler(sampler),
+				sdkTrace.WithSpanProcessor(gtrace.NewErrorSpanProcessor(exporter)),
+			)
+			tracer = provider.Tracer("test")
+			ctx    = context.Background()
+		)
+		_, span := tracer.Start(ctx, "success")
+		t.Assert(span.SpanContext().IsSampled(), false)
+		t.Assert(span.IsRecording(), true)
+		span.End()
+
+		_, span = tracer.Start(ctx, "failure")
+		span.SetStatus(codes.Error, "failure")
+		span.End()
+
+		t.AssertNil(provider.Shutdown(ctx))
+		t.Assert(exporter.names, []string{"failure"})
+	})
+}
+
+func Test_SetSampler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		defer gtrace.SetSampler(nil)
+		t.Assert(gtrace.GetSampler().Description(), sdkTrace.AlwaysSample().Description())
+		gtrace.SetSampler(sdkTrace.NeverSample())
+		t.Assert(gtrace.GetSampler().Description(), sdkTrace.NeverSample().Description())
+	})
+}
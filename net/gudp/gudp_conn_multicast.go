@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp
+
+import (
+	"net"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// JoinGroup joins the multicast group `group` like "239.0.0.1" or "ff02::1" on the connection.
+// The optional parameter `ifaceName` specifies the name of the network interface for joining,
+// it uses the system default interface if it is not given.
+func (c *localConn) JoinGroup(group string, ifaceName ...string) error {
+	groupIP, iface, err := parseMulticastGroup(group, ifaceName...)
+	if err != nil {
+		return err
+	}
+	groupAddr := &net.UDPAddr{IP: groupIP}
+	if groupIP.To4() != nil {
+		err = ipv4.NewPacketConn(c.UDPConn).JoinGroup(iface, groupAddr)
+	} else {
+		err = ipv6.NewPacketConn(c.UDPConn).JoinGroup(iface, groupAddr)
+	}
+	if err != nil {
+		err = gerror.Wrapf(err, `join multicast group "%s" failed`, group)
+	}
+	return err
+}
+
+// LeaveGroup leaves the multicast group `group` which was joined with JoinGroup.
+func (c *localConn) LeaveGroup(group string, ifaceName ...string) error {
+	groupIP, iface, err := parseMulticastGroup(group, ifaceName...)
+	if err != nil {
+		return err
+	}
+	groupAddr := &net.UDPAddr{IP: groupIP}
+	if groupIP.To4() != nil {
+		err = ipv4.NewPacketConn(c.UDPConn).LeaveGroup(iface, groupAddr)
+	} else {
+		err = ipv6.NewPacketConn(c.UDPConn).LeaveGroup(iface, groupAddr)
+	}
+	if err != nil {
+		err = gerror.Wrapf(err, `leave multicast group "%s" failed`, group)
+	}
+	return err
+}
+
+// SetMulticastTTL sets the TTL(hop limit for IPv6) of outgoing multicast packets.
+func (c *localConn) SetMulticastTTL(ttl int) error {
+	err := c.doMulticastOption(
+		func(p *ipv4.PacketConn) error { return p.SetMulticastTTL(ttl) },
+		func(p *ipv6.PacketConn) error { return p.SetMulticastHopLimit(ttl) },
+	)
+	if err != nil {
+		err = gerror.Wrapf(err, `SetMulticastTTL failed with ttl "%d"`, ttl)
+	}
+	return err
+}
+
+// SetMulticastInterface sets the network interface for outgoing multicast packets by its name.
+func (c *localConn) SetMulticastInterface(ifaceName string) error {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return gerror.Wrapf(err, `net.InterfaceByName failed for name "%s"`, ifaceName)
+	}
+	err = c.doMulticastOption(
+		func(p *ipv4.PacketConn) error { return p.SetMulticastInterface(iface) },
+		func(p *ipv6.PacketConn) error { return p.SetMulticastInterface(iface) },
+	)
+	if err != nil {
+		err = gerror.Wrapf(err, `SetMulticastInterface failed with interface "%s"`, ifaceName)
+	}
+	return err
+}
+
+// SetMulticastLoopback sets whether the outgoing multicast packets are looped back to local sockets.
+func (c *localConn) SetMulticastLoopback(enabled bool) error {
+	err := c.doMulticastOption(
+		func(p *ipv4.PacketConn) error { return p.SetMulticastLoopback(enabled) },
+		func(p *ipv6.PacketConn) error { return p.SetMulticastLoopback(enabled) },
+	)
+	if err != nil {
+		err = gerror.Wrapf(err, `SetMulticastLoopback failed with "%t"`, enabled)
+	}
+	return err
+}
+
+// doMulticastOption applies multicast socket option according to the address family of the
+// connection. It tries IPv4 first for unspecified local address, as the socket may be dual-stack.
+func (c *localConn) doMulticastOption(v4 func(p *ipv4.PacketConn) error, v6 func(p *ipv6.PacketConn) error) error {
+	localAddr, _ := c.LocalAddr().(*net.UDPAddr)
+	if localAddr != nil && localAddr.IP.To4() == nil && !localAddr.IP.IsUnspecified() {
+		return v6(ipv6.NewPacketConn(c.UDPConn))
+	}
+	err := v4(ipv4.NewPacketConn(c.UDPConn))
+	if err != nil && localAddr != nil && localAddr.IP.To4() == nil {
+		if v6Err := v6(ipv6.NewPacketConn(c.UDPConn)); v6Err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// parseMulticastGroup parses and returns the multicast group ip and network interface.
+func parseMulticastGroup(group string, ifaceName ...string) (groupIP net.IP, iface *net.Interface, err error) {
+	if groupIP = net.ParseIP(group); groupIP == nil || !groupIP.IsMulticast() {
+		return nil, nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid multicast group "%s"`, group)
+	}
+	if len(ifaceName) > 0 && ifaceName[0] != "" {
+		if iface, err = net.InterfaceByName(ifaceName[0]); err != nil {
+			return nil, nil, gerror.Wrapf(err, `net.InterfaceByName failed for name "%s"`, ifaceName[0])
+		}
+	}
+	return
+}
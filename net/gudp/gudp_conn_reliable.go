@@ -0,0 +1,394 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp
+
+import (
+	"encoding/binary"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// ReliableConfig is the configuration for ReliableConn.
+type ReliableConfig struct {
+	// RetransmitTimeout is the duration waiting for the ack before retransmitting. Default is 200ms.
+	RetransmitTimeout time.Duration
+
+	// MaxRetransmits is the max retransmitting count before sending fails. Default is 5.
+	MaxRetransmits int
+
+	// RecvBufferSize is the max size of a datagram in bytes. Default is 65535.
+	RecvBufferSize int
+
+	// RecvQueueSize is the size of the queue holding received messages that are not consumed by Recv.
+	// The message is dropped without ack if the queue is full, so that the sender retransmits it later.
+	// Default is 1024.
+	RecvQueueSize int
+
+	// DuplicateWindow is the max count of out-of-order sequences remembered for each peer for
+	// duplicate suppression. Default is 1024.
+	DuplicateWindow int
+
+	// PeerIdleTimeout is the duration after which the duplicate suppression state of an idle peer
+	// is cleaned. Default is 5 minutes.
+	PeerIdleTimeout time.Duration
+}
+
+// ReliableConn is an opt-in reliable messaging connection over UDP, which is suitable for
+// control-plane messages on lossy networks where full TCP is undesirable.
+//
+// Each message is sent in one datagram with a sequence number, and it is retransmitted until
+// an ack is received from the peer or the max retransmitting count is reached. The received
+// duplicate messages are suppressed. Note that the delivery order of messages is not guaranteed,
+// and both sides of the communication should use ReliableConn.
+type ReliableConn struct {
+	conn      *net.UDPConn
+	connected bool // Whether conn is connected to a remote address, which cannot use WriteToUDP.
+	config    ReliableConfig
+	session   uint32                   // Random session id distinguishing the restarted peer.
+	seq       *gtype.Uint64            // Sequence of the last sent message.
+	mu        sync.Mutex               // Mutex for pending and peers.
+	pending   map[uint64]chan struct{} // Sequence to ack notifying channel of sending messages.
+	peers     map[string]*reliablePeer // Peer key to duplicate suppression state.
+	cleanedAt time.Time                // Last time cleaning idle peers.
+	messages  chan reliableMessage     // Received messages.
+	closed    chan struct{}            // Closed when the connection is closed.
+	closeOnce sync.Once
+	err       *gtype.Interface // Reading error which stops the connection.
+}
+
+// reliablePeer is the duplicate suppression state of a peer session.
+type reliablePeer struct {
+	next     uint64              // All sequences less than next are received.
+	seen     map[uint64]struct{} // Received sequences greater than or equal to next.
+	activeAt time.Time           // Last time receiving message from the peer.
+}
+
+// reliableMessage is a received message.
+type reliableMessage struct {
+	data       []byte
+	remoteAddr *net.UDPAddr
+}
+
+const (
+	reliablePacketData      byte = 0x01
+	reliablePacketAck       byte = 0x02
+	reliablePacketMagic     byte = 0xAC
+	reliablePacketHeaderLen      = 14 // Magic(1) + Type(1) + Session(4) + Sequence(8).
+
+	defaultReliableRetransmitTimeout = 200 * time.Millisecond
+	defaultReliableMaxRetransmits    = 5
+	defaultReliableRecvBufferSize    = 65535
+	defaultReliableRecvQueueSize     = 1024
+	defaultReliableDuplicateWindow   = 1024
+	defaultReliablePeerIdleTimeout   = 5 * time.Minute
+)
+
+// NewReliableConn creates and returns a reliable connection over `conn`.
+// It starts a goroutine reading from `conn`, so that `conn` should not be read by others.
+// The returned connection takes the ownership of `conn`, which is closed by ReliableConn.Close.
+func NewReliableConn(conn *net.UDPConn, config ...ReliableConfig) *ReliableConn {
+	var c ReliableConfig
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.RetransmitTimeout <= 0 {
+		c.RetransmitTimeout = defaultReliableRetransmitTimeout
+	}
+	if c.MaxRetransmits <= 0 {
+		c.MaxRetransmits = defaultReliableMaxRetransmits
+	}
+	if c.RecvBufferSize <= 0 {
+		c.RecvBufferSize = defaultReliableRecvBufferSize
+	}
+	if c.RecvQueueSize <= 0 {
+		c.RecvQueueSize = defaultReliableRecvQueueSize
+	}
+	if c.DuplicateWindow <= 0 {
+		c.DuplicateWindow = defaultReliableDuplicateWindow
+	}
+	if c.PeerIdleTimeout <= 0 {
+		c.PeerIdleTimeout = defaultReliablePeerIdleTimeout
+	}
+	rc := &ReliableConn{
+		conn:      conn,
+		connected: conn.RemoteAddr() != nil,
+		config:    c,
+		session:   binary.BigEndian.Uint32(grand.B(4)),
+		seq:       gtype.NewUint64(),
+		pending:   make(map[uint64]chan struct{}),
+		peers:     make(map[string]*reliablePeer),
+		cleanedAt: time.Now(),
+		messages:  make(chan reliableMessage, c.RecvQueueSize),
+		closed:    make(chan struct{}),
+		err:       gtype.NewInterface(),
+	}
+	go rc.readLoop()
+	return rc
+}
+
+// Reliable creates and returns a reliable connection over current client connection.
+// See NewReliableConn.
+func (c *ClientConn) Reliable(config ...ReliableConfig) *ReliableConn {
+	return NewReliableConn(c.UDPConn, config...)
+}
+
+// Reliable creates and returns a reliable connection over current server connection.
+// See NewReliableConn.
+func (c *ServerConn) Reliable(config ...ReliableConfig) *ReliableConn {
+	return NewReliableConn(c.UDPConn, config...)
+}
+
+// Send writes `data` as one message to the connected remote address, and blocks until the
+// ack is received from the peer. It is used for the connection created by NewClientConn.
+func (c *ReliableConn) Send(data []byte) error {
+	return c.SendTo(data, nil)
+}
+
+// SendTo writes `data` as one message to `remoteAddr`, and blocks until the ack is received
+// from the peer. It retransmits the message if no ack is received in RetransmitTimeout, and it
+// returns error if no ack is received after MaxRetransmits retransmitting.
+func (c *ReliableConn) SendTo(data []byte, remoteAddr *net.UDPAddr) error {
+	if len(data)+reliablePacketHeaderLen > c.config.RecvBufferSize {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`data too long, data size %d exceeds allowed max data size %d`,
+			len(data), c.config.RecvBufferSize-reliablePacketHeaderLen,
+		)
+	}
+	var (
+		seq    = c.seq.Add(1)
+		acked  = make(chan struct{})
+		packet = c.newPacket(reliablePacketData, c.session, seq, data)
+		timer  = time.NewTimer(c.config.RetransmitTimeout)
+	)
+	defer timer.Stop()
+	c.mu.Lock()
+	c.pending[seq] = acked
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, seq)
+		c.mu.Unlock()
+	}()
+	for i := 0; i <= c.config.MaxRetransmits; i++ {
+		if err := c.write(packet, remoteAddr); err != nil {
+			return err
+		}
+		select {
+		case <-acked:
+			return nil
+		case <-c.closed:
+			return gerror.NewCode(gcode.CodeInvalidOperation, `connection closed`)
+		case <-timer.C:
+			timer.Reset(c.config.RetransmitTimeout)
+		}
+	}
+	return gerror.NewCodef(
+		gcode.CodeOperationFailed,
+		`no ack received for message after %d retransmits`,
+		c.config.MaxRetransmits,
+	)
+}
+
+// Recv blocks receiving and returns one message and its remote address.
+// It returns error if the connection is closed.
+func (c *ReliableConn) Recv() ([]byte, *net.UDPAddr, error) {
+	select {
+	case msg := <-c.messages:
+		return msg.data, msg.remoteAddr, nil
+	case <-c.closed:
+		// The messages received before closing are still delivered.
+		select {
+		case msg := <-c.messages:
+			return msg.data, msg.remoteAddr, nil
+		default:
+		}
+		if err, ok := c.err.Val().(error); ok {
+			return nil, nil, err
+		}
+		return nil, nil, gerror.NewCode(gcode.CodeInvalidOperation, `connection closed`)
+	}
+}
+
+// LocalAddr returns the local network address.
+func (c *ReliableConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// Close closes the connection and the underlying UDP connection.
+func (c *ReliableConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.conn.Close()
+	})
+	return err
+}
+
+// readLoop reads and handles datagrams until the connection is closed.
+func (c *ReliableConn) readLoop() {
+	buffer := make([]byte, c.config.RecvBufferSize)
+	for {
+		n, remoteAddr, err := c.conn.ReadFromUDP(buffer)
+		if err != nil {
+			select {
+			case <-c.closed:
+			default:
+				c.err.Set(gerror.Wrap(err, `ReadFromUDP failed`))
+				_ = c.Close()
+			}
+			return
+		}
+		c.handlePacket(buffer[:n], remoteAddr)
+	}
+}
+
+// handlePacket handles one received datagram.
+func (c *ReliableConn) handlePacket(packet []byte, remoteAddr *net.UDPAddr) {
+	// Datagram not in reliable protocol is ignored.
+	if len(packet) < reliablePacketHeaderLen || packet[0] != reliablePacketMagic {
+		return
+	}
+	var (
+		session = binary.BigEndian.Uint32(packet[2:6])
+		seq     = binary.BigEndian.Uint64(packet[6:14])
+	)
+	switch packet[1] {
+	case reliablePacketAck:
+		if session != c.session {
+			return
+		}
+		c.mu.Lock()
+		if acked, ok := c.pending[seq]; ok {
+			close(acked)
+			delete(c.pending, seq)
+		}
+		c.mu.Unlock()
+
+	case reliablePacketData:
+		if !c.isDuplicate(remoteAddr, session, seq) {
+			data := make([]byte, len(packet)-reliablePacketHeaderLen)
+			copy(data, packet[reliablePacketHeaderLen:])
+			select {
+			case c.messages <- reliableMessage{data: data, remoteAddr: remoteAddr}:
+			default:
+				// No ack for the dropped message, the peer retransmits it later.
+				c.forget(remoteAddr, session, seq)
+				return
+			}
+		}
+		// The ack is also sent for duplicate message, as the previous ack might be lost.
+		_ = c.write(c.newPacket(reliablePacketAck, session, seq, nil), remoteAddr)
+	}
+}
+
+// isDuplicate checks and marks the message from `remoteAddr` received.
+// It returns true if the message was already received.
+func (c *ReliableConn) isDuplicate(remoteAddr *net.UDPAddr, session uint32, seq uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var (
+		now = time.Now()
+		key = c.peerKey(remoteAddr, session)
+	)
+	if now.Sub(c.cleanedAt) > c.config.PeerIdleTimeout {
+		for k, peer := range c.peers {
+			if now.Sub(peer.activeAt) > c.config.PeerIdleTimeout {
+				delete(c.peers, k)
+			}
+		}
+		c.cleanedAt = now
+	}
+	peer, ok := c.peers[key]
+	if !ok {
+		peer = &reliablePeer{
+			next: 1,
+			seen: make(map[uint64]struct{}),
+		}
+		c.peers[key] = peer
+	}
+	peer.activeAt = now
+	if seq < peer.next {
+		return true
+	}
+	if _, ok = peer.seen[seq]; ok {
+		return true
+	}
+	peer.seen[seq] = struct{}{}
+	// The lost sequences that the peer gave up are skipped if the window is full.
+	if len(peer.seen) > c.config.DuplicateWindow {
+		peer.next = seq
+		for s := range peer.seen {
+			if s < peer.next {
+				peer.next = s
+			}
+		}
+	}
+	for {
+		if _, ok = peer.seen[peer.next]; !ok {
+			break
+		}
+		delete(peer.seen, peer.next)
+		peer.next++
+	}
+	return false
+}
+
+// forget removes the received mark of the message, so that it can be received again.
+func (c *ReliableConn) forget(remoteAddr *net.UDPAddr, session uint32, seq uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	peer, ok := c.peers[c.peerKey(remoteAddr, session)]
+	if !ok {
+		return
+	}
+	if seq >= peer.next {
+		delete(peer.seen, seq)
+		return
+	}
+	// The sequence was merged into next, it moves the others back to seen.
+	for s := seq + 1; s < peer.next; s++ {
+		peer.seen[s] = struct{}{}
+	}
+	peer.next = seq
+}
+
+// write writes `packet` to `remoteAddr`, or the connected address if the connection is connected.
+func (c *ReliableConn) write(packet []byte, remoteAddr *net.UDPAddr) (err error) {
+	if c.connected || remoteAddr == nil {
+		_, err = c.conn.Write(packet)
+	} else {
+		_, err = c.conn.WriteToUDP(packet, remoteAddr)
+	}
+	if err != nil {
+		err = gerror.Wrap(err, `Write data failed`)
+	}
+	return
+}
+
+// newPacket creates and returns a packet with header.
+func (c *ReliableConn) newPacket(packetType byte, session uint32, seq uint64, data []byte) []byte {
+	packet := make([]byte, reliablePacketHeaderLen+len(data))
+	packet[0] = reliablePacketMagic
+	packet[1] = packetType
+	binary.BigEndian.PutUint32(packet[2:6], session)
+	binary.BigEndian.PutUint64(packet[6:14], seq)
+	copy(packet[reliablePacketHeaderLen:], data)
+	return packet
+}
+
+// peerKey returns the key of the peer session.
+func (c *ReliableConn) peerKey(remoteAddr *net.UDPAddr, session uint32) string {
+	return remoteAddr.String() + "/" + strconv.FormatUint(uint64(session), 10)
+}
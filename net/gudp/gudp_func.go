@@ -71,6 +71,24 @@ func SendRecv(address string, data []byte, receive int, retry ...Retry) ([]byte,
 	return conn.SendRecv(data, receive, retry...)
 }
 
+// SendBroadcast writes data to all hosts of local network at `port` using IPv4 limited broadcast
+// address "255.255.255.255". Note that it is used for short connection usage.
+//
+// To broadcast to a specified subnet, use Send with the subnet directed broadcast address like
+// "192.168.1.255:8000" instead.
+func SendBroadcast(port int, data []byte, retry ...Retry) error {
+	var (
+		network = `udp4`
+		address = &net.UDPAddr{IP: net.IPv4bcast, Port: port}
+	)
+	conn, err := net.ListenUDP(network, nil)
+	if err != nil {
+		return gerror.Wrapf(err, `net.ListenUDP failed for network "%s"`, network)
+	}
+	defer conn.Close()
+	return NewServerConn(conn).Send(data, address, retry...)
+}
+
 // MustGetFreePort performs as GetFreePort, but it panics if any error occurs.
 // Deprecated: the port might be used soon after they were returned, please use `:0` as the listening
 // address which asks system to assign a free port instead.
@@ -41,6 +41,15 @@ type Server struct {
 
 	// Handler for UDP connection.
 	handler ServerHandler
+
+	// Multicast groups joined when server starts.
+	multicastGroups []multicastGroup
+}
+
+// multicastGroup is the multicast group joined by server.
+type multicastGroup struct {
+	group     string // Multicast group address.
+	ifaceName string // Network interface name, which can be empty.
 }
 
 // ServerHandler handles all server connections.
@@ -89,6 +98,21 @@ func (s *Server) SetHandler(handler ServerHandler) {
 	s.handler = handler
 }
 
+// AddMulticastGroup adds multicast group `group` like "239.0.0.1" that the server joins when it starts,
+// so that the server receives datagrams sent to the group. The optional parameter `ifaceName`
+// specifies the name of the network interface for joining.
+//
+// Note that the listening port of the server should be the port that the group datagrams are sent to.
+func (s *Server) AddMulticastGroup(group string, ifaceName ...string) {
+	item := multicastGroup{group: group}
+	if len(ifaceName) > 0 {
+		item.ifaceName = ifaceName[0]
+	}
+	s.mu.Lock()
+	s.multicastGroups = append(s.multicastGroups, item)
+	s.mu.Unlock()
+}
+
 // Close closes the connection.
 // It will make server shutdowns immediately.
 func (s *Server) Close() (err error) {
@@ -119,8 +143,16 @@ func (s *Server) Run() error {
 		err = gerror.Wrapf(err, `net.ListenUDP failed for address "%s"`, s.address)
 		return err
 	}
+	serverConn := NewServerConn(listenedConn)
 	s.mu.Lock()
-	s.conn = NewServerConn(listenedConn)
+	for _, item := range s.multicastGroups {
+		if err = serverConn.JoinGroup(item.group, item.ifaceName); err != nil {
+			s.mu.Unlock()
+			_ = listenedConn.Close()
+			return err
+		}
+	}
+	s.conn = serverConn
 	s.mu.Unlock()
 	s.handler(s.conn)
 	return nil
@@ -0,0 +1,95 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/net/gudp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Multicast(t *testing.T) {
+	var (
+		group    = "239.255.77.77"
+		received = garray.NewStrArray(true)
+	)
+	s := gudp.NewServer("0.0.0.0:0", func(conn *gudp.ServerConn) {
+		defer conn.Close()
+		for {
+			data, _, err := conn.Recv(-1)
+			if err != nil {
+				break
+			}
+			received.Append(string(data))
+		}
+	})
+	s.AddMulticastGroup(group)
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		port := s.GetListenedPort()
+		if port == -1 {
+			t.Log("multicast is not supported in current environment")
+			return
+		}
+		conn, err := gudp.NewClientConn(fmt.Sprintf("%s:%d", group, port))
+		t.AssertNil(err)
+		defer conn.Close()
+		t.AssertNil(conn.SetMulticastTTL(1))
+		t.AssertNil(conn.SetMulticastLoopback(true))
+		t.AssertNil(conn.Send([]byte("hello")))
+		time.Sleep(simpleTimeout)
+		t.Assert(received.Slice(), []string{"hello"})
+	})
+}
+
+func Test_Multicast_InvalidGroup(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gudp.NewServer("127.0.0.1:0", func(conn *gudp.ServerConn) {})
+		s.AddMulticastGroup("127.0.0.1")
+		t.AssertNE(s.Run(), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gudp.NewClientConn("127.0.0.1:9999")
+		t.AssertNil(err)
+		defer conn.Close()
+		t.AssertNE(conn.JoinGroup("239.255.77.77", "nonexistent-interface"), nil)
+		t.AssertNE(conn.LeaveGroup("not-an-ip"), nil)
+		t.AssertNE(conn.SetMulticastInterface("nonexistent-interface"), nil)
+	})
+}
+
+func Test_SendBroadcast(t *testing.T) {
+	var received = garray.NewStrArray(true)
+	s := gudp.NewServer("0.0.0.0:0", func(conn *gudp.ServerConn) {
+		defer conn.Close()
+		for {
+			data, _, err := conn.Recv(-1)
+			if err != nil {
+				break
+			}
+			received.Append(string(data))
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	if err := gudp.SendBroadcast(s.GetListenedPort(), []byte("hello")); err != nil {
+		t.Skipf("broadcast is not supported in current environment: %v", err)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		time.Sleep(simpleTimeout)
+		t.Assert(received.Slice(), []string{"hello"})
+	})
+}
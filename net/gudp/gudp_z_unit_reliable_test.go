@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gudp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Reliable_Echo(t *testing.T) {
+	s := gudp.NewServer(gudp.FreePortAddress, func(conn *gudp.ServerConn) {
+		rc := conn.Reliable()
+		defer rc.Close()
+		for {
+			data, remote, err := rc.Recv()
+			if err != nil {
+				break
+			}
+			if err = rc.SendTo(append([]byte("> "), data...), remote); err != nil {
+				break
+			}
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gudp.NewClientConn(s.GetListenedAddress())
+		t.AssertNil(err)
+		rc := conn.Reliable()
+		defer rc.Close()
+		for i := 0; i < 10; i++ {
+			t.AssertNil(rc.Send([]byte("hello")))
+			data, _, err := rc.Recv()
+			t.AssertNil(err)
+			t.Assert(data, "> hello")
+		}
+	})
+}
+
+func Test_Reliable_Retransmit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// The peer receives but never acks.
+		peer, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		t.AssertNil(err)
+		defer peer.Close()
+
+		conn, err := gudp.NewClientConn(peer.LocalAddr().String())
+		t.AssertNil(err)
+		rc := conn.Reliable(gudp.ReliableConfig{
+			RetransmitTimeout: 20 * time.Millisecond,
+			MaxRetransmits:    3,
+		})
+		defer rc.Close()
+		t.AssertNE(rc.Send([]byte("hello")), nil)
+
+		var (
+			count  int
+			buffer = make([]byte, 1024)
+		)
+		_ = peer.SetReadDeadline(time.Now().Add(simpleTimeout))
+		for {
+			if _, _, err = peer.ReadFromUDP(buffer); err != nil {
+				break
+			}
+			count++
+		}
+		t.Assert(count, 4)
+	})
+}
+
+func Test_Reliable_Duplicate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// Captures one message packet sent by reliable connection.
+		raw, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		t.AssertNil(err)
+		defer raw.Close()
+		sender, err := gudp.NewClientConn(raw.LocalAddr().String())
+		t.AssertNil(err)
+		rs := sender.Reliable(gudp.ReliableConfig{MaxRetransmits: 1})
+		defer rs.Close()
+		go rs.Send([]byte("hello"))
+		buffer := make([]byte, 1024)
+		n, _, err := raw.ReadFromUDP(buffer)
+		t.AssertNil(err)
+		packet := buffer[:n]
+
+		// Replays the packet twice to a reliable receiver.
+		receiverConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		t.AssertNil(err)
+		receiver := gudp.NewReliableConn(receiverConn)
+		defer receiver.Close()
+		for i := 0; i < 2; i++ {
+			_, err = raw.WriteToUDP(packet, receiver.LocalAddr().(*net.UDPAddr))
+			t.AssertNil(err)
+		}
+		data, remote, err := receiver.Recv()
+		t.AssertNil(err)
+		t.Assert(data, "hello")
+		t.Assert(remote.Port, raw.LocalAddr().(*net.UDPAddr).Port)
+
+		// Both of the packets are acked.
+		var acks int
+		_ = raw.SetReadDeadline(time.Now().Add(simpleTimeout))
+		for {
+			if _, _, err = raw.ReadFromUDP(buffer); err != nil {
+				break
+			}
+			acks++
+		}
+		// The retransmitted message packet from sender might also be received.
+		t.AssertGE(acks, 2)
+
+		// The duplicate is not delivered.
+		received := make(chan []byte, 1)
+		go func() {
+			data, _, _ := receiver.Recv()
+			received <- data
+		}()
+		select {
+		case data = <-received:
+			t.Error("unexpected duplicate message:", string(data))
+		case <-time.After(simpleTimeout):
+		}
+	})
+}
@@ -9,6 +9,7 @@ package gcfg
 
 import (
 	"context"
+	"sync"
 
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -21,7 +22,10 @@ import (
 
 // Config is the configuration management object.
 type Config struct {
-	adapter Adapter
+	adapter   Adapter
+	envMu     sync.Mutex
+	envFiles  []string // The .env files loaded before retrieving environment values.
+	envLoaded bool     // Whether the .env files are loaded.
 }
 
 const (
@@ -79,6 +83,35 @@ func (c *Config) GetAdapter() Adapter {
 	return c.adapter
 }
 
+// SetEnvFiles sets the .env `files` which are loaded into the environment by genv.LoadWithOption
+// before GetWithEnv retrieves the environment values, which loads genv.DefaultEnvFile if no file is given.
+// The files are loaded only once without overriding the existing environment variables,
+// and the files that do not exist are ignored, like:
+// SetEnvFiles(".env", ".env.local")
+func (c *Config) SetEnvFiles(files ...string) {
+	if len(files) == 0 {
+		files = []string{genv.DefaultEnvFile}
+	}
+	c.envMu.Lock()
+	defer c.envMu.Unlock()
+	c.envFiles = files
+	c.envLoaded = false
+}
+
+// loadEnvFiles loads the .env files set by SetEnvFiles into the environment if they are not loaded.
+func (c *Config) loadEnvFiles() error {
+	c.envMu.Lock()
+	defer c.envMu.Unlock()
+	if c.envLoaded || len(c.envFiles) == 0 {
+		return nil
+	}
+	if err := genv.LoadWithOption(genv.LoadOption{IgnoreNotExist: true}, c.envFiles...); err != nil {
+		return err
+	}
+	c.envLoaded = true
+	return nil
+}
+
 // Available checks and returns the configuration service is available.
 // The optional parameter `pattern` specifies certain configuration resource.
 //
@@ -116,12 +149,16 @@ func (c *Config) Get(ctx context.Context, pattern string, def ...interface{}) (*
 // It returns the default value `def` if none of them exists.
 //
 // Fetching Rules: Environment arguments are in uppercase format, eg: GF_PACKAGE_VARIABLE.
+// The environment variables loaded from .env files set by SetEnvFiles or genv.Load are also retrieved.
 func (c *Config) GetWithEnv(ctx context.Context, pattern string, def ...interface{}) (*gvar.Var, error) {
 	value, err := c.Get(ctx, pattern)
 	if err != nil && gerror.Code(err) != gcode.CodeNotFound {
 		return nil, err
 	}
 	if value == nil {
+		if err = c.loadEnvFiles(); err != nil {
+			return nil, err
+		}
 		if v := genv.Get(utils.FormatEnvKey(pattern)); v != nil {
 			return v, nil
 		}
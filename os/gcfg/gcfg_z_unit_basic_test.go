@@ -202,6 +202,29 @@ array = [1,2,3]
 	})
 }
 
+func Test_GetWithEnv_EnvFiles(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir       = gfile.Temp(gtime.TimestampNanoStr())
+			envFile   = gfile.Join(dir, ".env")
+			localFile = gfile.Join(dir, ".env.local")
+		)
+		t.AssertNil(gfile.PutContents(envFile, "REDIS_PASS=pass\nREDIS_USER=user"))
+		t.AssertNil(gfile.PutContents(localFile, "REDIS_USER=local"))
+		defer gfile.Remove(dir)
+		defer genv.Remove("REDIS_PASS", "REDIS_USER")
+
+		c, err := gcfg.New()
+		t.AssertNil(err)
+		c.GetAdapter().(*gcfg.AdapterFile).SetContent(`redis.pass = "config"`)
+		defer c.GetAdapter().(*gcfg.AdapterFile).ClearContent()
+		c.SetEnvFiles(envFile, localFile, gfile.Join(dir, ".env.notexist"))
+		t.Assert(c.MustGetWithEnv(ctx, `redis.user`), `local`)
+		t.Assert(c.MustGetWithEnv(ctx, `redis.pass`), `config`)
+		t.Assert(genv.Get("REDIS_PASS"), `pass`)
+	})
+}
+
 func Test_GetWithCmd(t *testing.T) {
 	content := `
 v1    = 1
@@ -0,0 +1,206 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genv
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// DefaultEnvFile is the default .env file loaded if no file is given.
+const DefaultEnvFile = ".env"
+
+// LoadOption is the option for loading .env files.
+type LoadOption struct {
+	// Override overrides the existing environment variables with the values in files,
+	// or else the existing environment variables take precedence.
+	Override bool
+
+	// IgnoreNotExist ignores the files that do not exist, which is useful for optional files
+	// like ".env.local".
+	IgnoreNotExist bool
+}
+
+// Load loads the .env `files` into the environment without overriding the existing environment
+// variables. The later files take precedence over the earlier ones, like .env.local over .env.
+// It loads DefaultEnvFile if no file is given.
+//
+// The values support interpolation of ${KEY}, $KEY and ${KEY:-default}, in which the KEY is resolved
+// from the loaded values and the environment.
+func Load(files ...string) error {
+	return LoadWithOption(LoadOption{}, files...)
+}
+
+// Overload loads the .env `files` into the environment, overriding the existing environment variables.
+// See Load.
+func Overload(files ...string) error {
+	return LoadWithOption(LoadOption{Override: true}, files...)
+}
+
+// LoadWithOption loads the .env `files` into the environment with `option`.
+// See Load.
+func LoadWithOption(option LoadOption, files ...string) error {
+	if len(files) == 0 {
+		files = []string{DefaultEnvFile}
+	}
+	var (
+		keys   []string
+		values = make(map[string]string)
+	)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			if option.IgnoreNotExist && os.IsNotExist(err) {
+				continue
+			}
+			return gerror.Wrapf(err, `read env file "%s" failed`, file)
+		}
+		fileValues, fileKeys, err := parseDotEnv(string(content), func(key string) (string, bool) {
+			if v, ok := os.LookupEnv(key); ok && !option.Override {
+				return v, true
+			}
+			if v, ok := values[key]; ok {
+				return v, true
+			}
+			return os.LookupEnv(key)
+		})
+		if err != nil {
+			return gerror.Wrapf(err, `parse env file "%s" failed`, file)
+		}
+		for _, key := range fileKeys {
+			if _, ok := values[key]; !ok {
+				keys = append(keys, key)
+			}
+			values[key] = fileValues[key]
+		}
+	}
+	for _, key := range keys {
+		if !option.Override && Contains(key) {
+			continue
+		}
+		if err := Set(key, values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse parses the .env `content` and returns the key-value pairs,
+// in which the values are interpolated with the parsed values and the environment.
+func Parse(content string) (map[string]string, error) {
+	values, _, err := parseDotEnv(content, os.LookupEnv)
+	return values, err
+}
+
+// parseDotEnv parses the .env `content` and returns the key-value pairs and the keys in order.
+// The `lookup` function resolves the key of interpolation excepting the keys parsed in `content`.
+func parseDotEnv(content string, lookup func(key string) (string, bool)) (map[string]string, []string, error) {
+	var (
+		keys    []string
+		values  = make(map[string]string)
+		scanner = bufio.NewScanner(strings.NewReader(content))
+		lineNo  = 0
+		resolve = func(key string) (string, bool) {
+			if v, ok := values[key]; ok {
+				return v, true
+			}
+			return lookup(key)
+		}
+	)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid line %d: %s`, lineNo, line)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `'`):
+			// Single-quoted value is literal.
+			end := strings.Index(value[1:], `'`)
+			if end == -1 {
+				return nil, nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unclosed quote at line %d`, lineNo)
+			}
+			value = value[1 : end+1]
+
+		case strings.HasPrefix(value, `"`):
+			// Double-quoted value supports escapes, interpolation and multiple lines.
+			for !hasClosingQuote(value[1:]) && scanner.Scan() {
+				lineNo++
+				value += "\n" + scanner.Text()
+			}
+			if !hasClosingQuote(value[1:]) {
+				return nil, nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unclosed quote at line %d`, lineNo)
+			}
+			value = expandDotEnvValue(unescapeDotEnvValue(value[1:closingQuoteIndex(value[1:])+1]), resolve)
+
+		default:
+			// Unquoted value supports inline comment and interpolation.
+			if pos := strings.Index(value, " #"); pos != -1 {
+				value = strings.TrimSpace(value[:pos])
+			}
+			value = expandDotEnvValue(value, resolve)
+		}
+		if _, ok = values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, gerror.Wrap(err, `scan env content failed`)
+	}
+	return values, keys, nil
+}
+
+// hasClosingQuote checks whether `s` contains unescaped double quote.
+func hasClosingQuote(s string) bool {
+	return closingQuoteIndex(s) != -1
+}
+
+// closingQuoteIndex returns the index of first unescaped double quote in `s`, or -1 if not found.
+func closingQuoteIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeDotEnvValue unescapes the escape sequences of double-quoted value.
+func unescapeDotEnvValue(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`, `\$`, "\x00").Replace(s)
+}
+
+// expandDotEnvValue expands the ${KEY}, $KEY and ${KEY:-default} in `s` using `resolve`.
+// The escaped dollar sign is marked as "\x00" by unescapeDotEnvValue, which is restored here.
+func expandDotEnvValue(s string, resolve func(key string) (string, bool)) string {
+	expanded := os.Expand(s, func(key string) string {
+		if name, def, ok := strings.Cut(key, ":-"); ok {
+			if v, found := resolve(name); found && v != "" {
+				return v
+			}
+			return def
+		}
+		v, _ := resolve(key)
+		return v
+	})
+	return strings.ReplaceAll(expanded, "\x00", "$")
+}
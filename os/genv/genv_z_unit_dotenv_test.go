@@ -0,0 +1,99 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package genv_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/genv"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_GEnv_Parse(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		values, err := genv.Parse(`
+# comment
+export APP_NAME=goframe
+APP_HOST = 127.0.0.1 # inline comment
+APP_ADDR=${APP_HOST}:8000
+APP_URL="http://$APP_ADDR/\${path}"
+APP_RAW='${APP_HOST}'
+APP_LINES="line1
+line2\tend"
+APP_PORT=${GENV_UNDEFINED_PORT:-8080}
+APP_EMPTY=
+`)
+		t.AssertNil(err)
+		t.Assert(values["APP_NAME"], "goframe")
+		t.Assert(values["APP_HOST"], "127.0.0.1")
+		t.Assert(values["APP_ADDR"], "127.0.0.1:8000")
+		t.Assert(values["APP_URL"], "http://127.0.0.1:8000/${path}")
+		t.Assert(values["APP_RAW"], "${APP_HOST}")
+		t.Assert(values["APP_LINES"], "line1\nline2\tend")
+		t.Assert(values["APP_PORT"], "8080")
+		t.Assert(values["APP_EMPTY"], "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := genv.Parse("INVALID")
+		t.AssertNE(err, nil)
+		_, err = genv.Parse(`KEY="unclosed`)
+		t.AssertNE(err, nil)
+		_, err = genv.Parse(`KEY='unclosed`)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_GEnv_Load(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		envFile   = filepath.Join(dir, ".env")
+		localFile = filepath.Join(dir, ".env.local")
+		keys      = []string{"GENV_LOAD_NAME", "GENV_LOAD_HOST", "GENV_LOAD_ADDR", "GENV_LOAD_EXISTING"}
+	)
+	defer genv.Remove(keys...)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(os.WriteFile(envFile, []byte(`
+GENV_LOAD_NAME=base
+GENV_LOAD_HOST=127.0.0.1
+GENV_LOAD_ADDR=${GENV_LOAD_HOST}:${GENV_LOAD_PORT:-80}
+GENV_LOAD_EXISTING=file
+`), 0600))
+		t.AssertNil(os.WriteFile(localFile, []byte(`
+GENV_LOAD_HOST=localhost
+GENV_LOAD_ADDR=${GENV_LOAD_HOST}:8000
+`), 0600))
+		t.AssertNil(genv.Set("GENV_LOAD_EXISTING", "process"))
+
+		t.AssertNil(genv.Load(envFile, localFile))
+		t.Assert(genv.Get("GENV_LOAD_NAME"), "base")
+		t.Assert(genv.Get("GENV_LOAD_HOST"), "localhost")
+		t.Assert(genv.Get("GENV_LOAD_ADDR"), "localhost:8000")
+		t.Assert(genv.Get("GENV_LOAD_EXISTING"), "process")
+
+		// Integration with configuration.
+		cfg, err := gcfg.NewAdapterContent(`{"genv": {"load": {"name": "config"}}}`)
+		t.AssertNil(err)
+		c := gcfg.NewWithAdapter(cfg)
+		t.Assert(c.MustGetWithEnv(context.Background(), "genv.load.name"), "config")
+		t.Assert(c.MustGetWithEnv(context.Background(), "genv.load.host"), "localhost")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(genv.Overload(envFile))
+		t.Assert(genv.Get("GENV_LOAD_HOST"), "127.0.0.1")
+		t.Assert(genv.Get("GENV_LOAD_ADDR"), "127.0.0.1:80")
+		t.Assert(genv.Get("GENV_LOAD_EXISTING"), "file")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		notExist := filepath.Join(dir, ".env.not-exist")
+		t.AssertNE(genv.Load(notExist), nil)
+		t.AssertNil(genv.LoadWithOption(genv.LoadOption{IgnoreNotExist: true}, envFile, notExist))
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/gogf/gf/v2/internal/consts"
 	"github.com/gogf/gf/v2/internal/errors"
 	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gfpool"
@@ -105,6 +106,7 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 		}
 	}
 
+	values, fields := splitFields(values)
 	var (
 		now   = time.Now()
 		input = &HandlerInput{
@@ -118,6 +120,7 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 			Level:  level,
 			Stack:  stack,
 			Values: values,
+			Fields: fields,
 		}
 	)
 
@@ -189,6 +192,9 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 		spanCtx := trace.SpanContextFromContext(ctx)
 		if traceId := spanCtx.TraceID(); traceId.IsValid() {
 			input.TraceId = traceId.String()
+			if spanId := spanCtx.SpanID(); spanId.IsValid() && gtrace.IsIdInjectionEnabled() {
+				input.SpanId = spanId.String()
+			}
 		}
 		// Context values.
 		if len(l.config.CtxKeys) > 0 {
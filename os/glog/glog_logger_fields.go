@@ -0,0 +1,82 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Fields is the structured key-value data for logging, which is rendered as structured data
+// by handlers rather than the values concatenated to content, like:
+//
+//	g.Log().Error(ctx, "order creation failed", glog.Fields{"order_id": id})
+//
+// Note that the fields of errors attached by gerror.WithFields are also rendered in logging.
+type Fields map[string]any
+
+// FieldsContent converts and returns fields as string content like "k1=v1 k2=v2", sorted by keys.
+func (in *HandlerInput) FieldsContent() string {
+	var (
+		buffer = bytes.NewBuffer(nil)
+		keys   = in.fieldKeys()
+	)
+	for _, k := range keys {
+		if buffer.Len() > 0 {
+			buffer.WriteByte(' ')
+		}
+		buffer.WriteString(k + "=" + gconv.String(in.Fields[k]))
+	}
+	return buffer.String()
+}
+
+// fieldKeys returns the sorted keys of fields.
+func (in *HandlerInput) fieldKeys() []string {
+	var keys = make([]string, 0, len(in.Fields))
+	for k := range in.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitFields retrieves and returns the fields from `values`, and the values without Fields.
+// The errors in `values` are kept, but their fields attached by gerror.WithFields are retrieved.
+func splitFields(values []any) ([]any, Fields) {
+	var fields Fields
+	for _, v := range values {
+		switch value := v.(type) {
+		case Fields:
+			if fields == nil {
+				fields = make(Fields)
+			}
+			for k, fv := range value {
+				fields[k] = fv
+			}
+		case error:
+			for k, fv := range gerror.Fields(value) {
+				if fields == nil {
+					fields = make(Fields)
+				}
+				fields[k] = fv
+			}
+		}
+	}
+	if fields == nil {
+		return values, nil
+	}
+	var filtered = make([]any, 0, len(values))
+	for _, v := range values {
+		if _, ok := v.(Fields); !ok {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered, fields
+}
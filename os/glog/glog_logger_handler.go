@@ -20,7 +20,7 @@ type Handler func(ctx context.Context, in *HandlerInput)
 // HandlerInput is the input parameter struct for logging Handler.
 //
 // The logging content is consisted in:
-// TimeFormat [LevelFormat] {TraceId} {CtxStr} Prefix CallerFunc CallerPath Content Values Stack
+// TimeFormat [LevelFormat] {TraceId} {CtxStr} Prefix CallerFunc CallerPath Content Values Fields Stack
 //
 // The header in the logging content is:
 // TimeFormat [LevelFormat] {TraceId} {CtxStr} Prefix CallerFunc CallerPath
@@ -65,6 +65,9 @@ type HandlerInput struct {
 	// Trace id, only available if OpenTelemetry is enabled, or else it's an empty string.
 	TraceId string
 
+	// Span id, only available if OpenTelemetry is enabled and gtrace.IsIdInjectionEnabled, or else it's an empty string.
+	SpanId string
+
 	// Custom prefix string in logging content header part.
 	// Note that, it takes no effect if HeaderPrint is disabled.
 	Prefix string
@@ -75,6 +78,9 @@ type HandlerInput struct {
 	// The passed un-formatted values array to logger.
 	Values []any
 
+	// Structured fields from the Fields values and the fields of errors passed to logger.
+	Fields Fields
+
 	// Stack string produced by logger, only available if Config.StStatus configured.
 	// Note that there are usually multiple lines in stack content.
 	Stack string
@@ -173,7 +179,11 @@ func (in *HandlerInput) getDefaultBuffer(withColor bool) *bytes.Buffer {
 		}
 	}
 	if in.TraceId != "" {
-		in.addStringToBuffer(buffer, "{"+in.TraceId+"}")
+		if in.SpanId != "" {
+			in.addStringToBuffer(buffer, "{"+in.TraceId+"/"+in.SpanId+"}")
+		} else {
+			in.addStringToBuffer(buffer, "{"+in.TraceId+"}")
+		}
 	}
 	if in.CtxStr != "" {
 		in.addStringToBuffer(buffer, "{"+in.CtxStr+"}")
@@ -198,6 +208,10 @@ func (in *HandlerInput) getDefaultBuffer(withColor bool) *bytes.Buffer {
 		in.addStringToBuffer(buffer, in.ValuesContent())
 	}
 
+	if len(in.Fields) > 0 {
+		in.addStringToBuffer(buffer, in.FieldsContent())
+	}
+
 	if in.Stack != "" {
 		in.addStringToBuffer(buffer, "\nStack:\n"+in.Stack)
 	}
@@ -16,12 +16,14 @@ import (
 type HandlerOutputJson struct {
 	Time       string `json:""`           // Formatted time string, like "2016-01-09 12:00:00".
 	TraceId    string `json:",omitempty"` // Trace id, only available if tracing is enabled.
+	SpanId     string `json:",omitempty"` // Span id, only available if tracing and id injection are enabled.
 	CtxStr     string `json:",omitempty"` // The retrieved context value string from context, only available if Config.CtxKeys configured.
 	Level      string `json:""`           // Formatted level string, like "DEBU", "ERRO", etc. Eg: ERRO
 	CallerPath string `json:",omitempty"` // The source file path and its line number that calls logging, only available if F_FILE_SHORT or F_FILE_LONG set.
 	CallerFunc string `json:",omitempty"` // The source function name that calls logging, only available if F_CALLER_FN set.
 	Prefix     string `json:",omitempty"` // Custom prefix string for logging content.
 	Content    string `json:""`           // Content is the main logging content, containing error stack string produced by logger.
+	Fields     Fields `json:",omitempty"` // Structured fields, only available if fields passed to logger.
 	Stack      string `json:",omitempty"` // Stack string produced by logger, only available if Config.StStatus configured.
 }
 
@@ -30,12 +32,14 @@ func HandlerJson(ctx context.Context, in *HandlerInput) {
 	output := HandlerOutputJson{
 		Time:       in.TimeFormat,
 		TraceId:    in.TraceId,
+		SpanId:     in.SpanId,
 		CtxStr:     in.CtxStr,
 		Level:      in.LevelFormat,
 		CallerFunc: in.CallerFunc,
 		CallerPath: in.CallerPath,
 		Prefix:     in.Prefix,
 		Content:    in.Content,
+		Fields:     in.Fields,
 		Stack:      in.Stack,
 	}
 	if len(in.Values) > 0 {
@@ -27,6 +27,7 @@ const (
 	structureKeyPrefix     = "Prefix"
 	structureKeyContent    = "Content"
 	structureKeyTraceId    = "TraceId"
+	structureKeySpanId     = "SpanId"
 	structureKeyCallerFunc = "CallerFunc"
 	structureKeyCallerPath = "CallerPath"
 	structureKeyCtxStr     = "CtxStr"
@@ -160,6 +161,9 @@ func (buf *structuredBuffer) Bytes() []byte {
 	if buf.in.TraceId != "" {
 		buf.addValue(structureKeyTraceId, buf.in.TraceId)
 	}
+	if buf.in.SpanId != "" {
+		buf.addValue(structureKeySpanId, buf.in.SpanId)
+	}
 	if buf.in.CtxStr != "" {
 		buf.addValue(structureKeyCtxStr, buf.in.CtxStr)
 	}
@@ -191,6 +195,10 @@ func (buf *structuredBuffer) Bytes() []byte {
 	for i := 0; i < len(values); i += 2 {
 		buf.addValue(values[i], values[i+1])
 	}
+	// Structured fields.
+	for _, k := range buf.in.fieldKeys() {
+		buf.addValue(k, buf.in.Fields[k])
+	}
 	if buf.in.Stack != "" {
 		buf.addValue(structureKeyStack, buf.in.Stack)
 	}
@@ -11,7 +11,11 @@ import (
 	"context"
 	"testing"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -128,3 +132,62 @@ func Test_SetDefaultHandler(t *testing.T) {
 		t.Assert(gstr.Count(w.String(), `"DEBU"`), 1)
 	})
 }
+
+func TestLogger_SetHandlers_IdInjection(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			traceId, _ = trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+			spanId, _  = trace.SpanIDFromHex("00f067aa0ba902b7")
+			ctx        = trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceId,
+				SpanID:  spanId,
+			}))
+			w = bytes.NewBuffer(nil)
+			l = glog.NewWithWriter(w)
+		)
+		l.Print(ctx, 1)
+		t.Assert(gstr.Contains(w.String(), "{4bf92f3577b34da6a3ce929d0e0e4736}"), true)
+		t.Assert(gstr.Contains(w.String(), "00f067aa0ba902b7"), false)
+
+		gtrace.SetIdInjection(true)
+		defer gtrace.SetIdInjection(false)
+
+		w.Reset()
+		l.Print(ctx, 1)
+		t.Assert(gstr.Contains(w.String(), "{4bf92f3577b34da6a3ce929d0e0e4736/00f067aa0ba902b7}"), true)
+
+		w.Reset()
+		l.SetHandlers(glog.HandlerJson)
+		l.Print(ctx, 1)
+		t.Assert(gstr.Contains(w.String(), `"SpanId":"00f067aa0ba902b7"`), true)
+
+		w.Reset()
+		l.SetHandlers(glog.HandlerStructure)
+		l.Print(ctx, 1)
+		t.Assert(gstr.Contains(w.String(), `SpanId=00f067aa0ba902b7`), true)
+	})
+}
+
+func TestLogger_Fields(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx = context.Background()
+			w   = bytes.NewBuffer(nil)
+			l   = glog.NewWithWriter(w)
+			err = gerror.Wrap(gerror.WithFields(gerror.New("failed"), map[string]interface{}{"order_id": 1}), "wrapped")
+		)
+		l.SetLevelPrint(false)
+		l.Print(ctx, "content", err, glog.Fields{"user_id": 2})
+		t.Assert(gstr.Contains(w.String(), " content wrapped: failed order_id=1 user_id=2\n"), true)
+
+		w.Reset()
+		l.SetHandlers(glog.HandlerJson)
+		l.Print(ctx, err)
+		t.Assert(gstr.Contains(w.String(), `"Content":"wrapped: failed","Fields":{"order_id":1}`), true)
+
+		w.Reset()
+		l.SetHandlers(glog.HandlerStructure)
+		l.Print(ctx, "content", glog.Fields{"user_id": 2, "order_id": 1})
+		t.Assert(gstr.Contains(w.String(), `Content=content order_id=1 user_id=2`), true)
+	})
+}
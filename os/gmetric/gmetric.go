@@ -100,8 +100,14 @@ type MetricOption struct {
 	// Buckets defines the buckets into which observations are counted.
 	// For Histogram metric only.
 	// A histogram metric uses default buckets if no explicit buckets configured.
+	// The helpers LinearBuckets and ExponentialBuckets can be used to generate the buckets.
 	Buckets []float64
 
+	// ExponentialHistogram enables the base2 exponential histogram that adjusts buckets automatically,
+	// which takes precedence over Buckets if the metric implement supports it.
+	// For Histogram metric only.
+	ExponentialHistogram *ExponentialHistogramOption
+
 	// Callback function for metric, which is called when metric value changes.
 	// For observable metric only.
 	// If an observable metric has either Callback attribute nor global callback configured, it does nothing.
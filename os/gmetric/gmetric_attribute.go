@@ -31,6 +31,11 @@ type AttributeKey string
 type Option struct {
 	// Attributes holds the dynamic key-value pair metadata.
 	Attributes Attributes
+
+	// Exemplar is the optional exemplar attached to the recording, which is usually created by
+	// ExemplarFromContext. It is for the operations having no context parameter like Histogram.Record,
+	// as the exemplar of other operations is retrieved from their context parameter.
+	Exemplar *Exemplar
 }
 
 // localAttribute implements interface Attribute.
@@ -0,0 +1,63 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exemplar is an example recording attached to a metric value, which links the value to the trace
+// that produced it, so metric backends can deep-link from a latency bucket to an example trace.
+type Exemplar struct {
+	TraceId   string    // TraceId is the hex string trace id of the recording.
+	SpanId    string    // SpanId is the hex string span id of the recording.
+	Timestamp time.Time // Timestamp is the time when the recording was made.
+}
+
+// ExemplarFromContext creates and returns an Exemplar from the span in `ctx`.
+// It returns nil if there's no valid span in `ctx`, or the span is not sampled,
+// as the exemplar of a not sampled trace links to nothing in trace backends.
+func ExemplarFromContext(ctx context.Context) *Exemplar {
+	if ctx == nil {
+		return nil
+	}
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() || !spanCtx.IsSampled() {
+		return nil
+	}
+	return &Exemplar{
+		TraceId:   spanCtx.TraceID().String(),
+		SpanId:    spanCtx.SpanID().String(),
+		Timestamp: time.Now(),
+	}
+}
+
+// SpanContext converts and returns the span context of the exemplar, which can be used to
+// create a context carrying the exemplar for the metric implements.
+// It returns an invalid span context if the trace id or span id is invalid.
+func (e *Exemplar) SpanContext() trace.SpanContext {
+	if e == nil {
+		return trace.SpanContext{}
+	}
+	traceId, err := trace.TraceIDFromHex(e.TraceId)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+	spanId, err := trace.SpanIDFromHex(e.SpanId)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
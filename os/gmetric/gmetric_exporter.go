@@ -0,0 +1,148 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ExporterConfig is the configuration for pushing metrics to the backend periodically,
+// which can be configured in configuration file like:
+//
+//	metric:
+//	  exporter:    "otlp"
+//	  protocol:    "grpc"
+//	  endpoint:    "127.0.0.1:4317"
+//	  interval:    "30s"
+//	  serviceName: "user-service"
+//	  resourceAttributes:
+//	    deployment.environment: "prod"
+//	  histograms:
+//	    http.server.request.duration:
+//	      buckets: [1, 5, 10, 50, 100, 500, 1000]
+type ExporterConfig struct {
+	// Exporter is the registered exporter name, which is "otlp" in default.
+	Exporter string `json:"exporter"`
+
+	// Protocol is the transport protocol of exporter, like "grpc" or "http" for OTLP exporter.
+	// It is "http" in default.
+	Protocol string `json:"protocol"`
+
+	// Endpoint is the address of the backend like "127.0.0.1:4318".
+	Endpoint string `json:"endpoint"`
+
+	// Path is the URL path for the exporter using HTTP protocol, like "/v1/metrics".
+	Path string `json:"path"`
+
+	// Insecure disables the transport security of exporter.
+	Insecure bool `json:"insecure"`
+
+	// Headers are the custom headers or metadata sent with each exporting request, like authentication token.
+	Headers map[string]string `json:"headers"`
+
+	// Interval is the interval of pushing metrics, which is 60s in default.
+	Interval time.Duration `json:"interval"`
+
+	// Timeout is the timeout of each pushing, which is 10s in default.
+	Timeout time.Duration `json:"timeout"`
+
+	// ServiceName is the service name in resource attributes of metrics.
+	ServiceName string `json:"serviceName"`
+
+	// ResourceAttributes are the extra resource attributes of metrics, like "deployment.environment".
+	ResourceAttributes map[string]string `json:"resourceAttributes"`
+
+	// Histograms are the bucket configurations of histograms mapping metric name to option,
+	// which are set by SetHistogramOption in Bootstrap.
+	Histograms map[string]HistogramOption `json:"histograms"`
+}
+
+// ExporterProviderFunc creates and returns a Provider pushing metrics by `config`,
+// which is implemented and registered by metric implements like otelmetric.
+type ExporterProviderFunc func(ctx context.Context, config ExporterConfig) (Provider, error)
+
+const (
+	// DefaultExporter is the default exporter name.
+	DefaultExporter = "otlp"
+
+	defaultExporterProtocol = "http"
+	defaultExporterInterval = 60 * time.Second
+	defaultExporterTimeout  = 10 * time.Second
+)
+
+var (
+	exporterFuncs   = make(map[string]ExporterProviderFunc)
+	exporterFuncsMu sync.RWMutex
+)
+
+// RegisterExporter registers the Provider creating function of exporter `name`,
+// which is usually called in the init function of metric implements.
+func RegisterExporter(name string, fn ExporterProviderFunc) {
+	exporterFuncsMu.Lock()
+	defer exporterFuncsMu.Unlock()
+	exporterFuncs[name] = fn
+}
+
+// ExporterConfigFromMap creates and returns an ExporterConfig from map `m`,
+// the duration items can be configured using string like "30s".
+func ExporterConfigFromMap(m map[string]interface{}) (ExporterConfig, error) {
+	var config ExporterConfig
+	if err := gconv.Struct(m, &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// Bootstrap creates the Provider pushing metrics by `config` and sets it as the global provider,
+// so the metrics are pushed periodically without writing the setup code of metric implements.
+// The returned Provider should be shut down when the process exits, which pushes the pending metrics.
+//
+// Note that the exporter should be registered by importing the metric implement package, like:
+//
+//	import _ "github.com/gogf/gf/contrib/metric/otelmetric/v2"
+func Bootstrap(ctx context.Context, config ExporterConfig) (Provider, error) {
+	if config.Exporter == "" {
+		config.Exporter = DefaultExporter
+	}
+	if config.Protocol == "" {
+		config.Protocol = defaultExporterProtocol
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultExporterInterval
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultExporterTimeout
+	}
+	if config.Endpoint == "" {
+		return nil, gerror.NewCode(gcode.CodeMissingConfiguration, `exporter endpoint is required`)
+	}
+	exporterFuncsMu.RLock()
+	fn, ok := exporterFuncs[config.Exporter]
+	exporterFuncsMu.RUnlock()
+	if !ok {
+		return nil, gerror.NewCodef(
+			gcode.CodeNotFound,
+			`exporter "%s" is not registered, the metric implement package might not be imported`,
+			config.Exporter,
+		)
+	}
+	for name, option := range config.Histograms {
+		SetHistogramOption(name, option)
+	}
+	provider, err := fn(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	provider.SetAsGlobal()
+	return provider, nil
+}
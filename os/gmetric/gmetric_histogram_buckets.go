@@ -0,0 +1,109 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric
+
+import (
+	"sync"
+)
+
+// ExponentialHistogramOption holds the options for base2 exponential histogram.
+type ExponentialHistogramOption struct {
+	// MaxSize is the maximum number of buckets for positive or negative values.
+	// It is 160 in default if it is not positive.
+	MaxSize int32 `json:"maxSize"`
+
+	// MaxScale is the maximum resolution scale in range [-10, 20].
+	// It is 20 in default if it is not positive.
+	MaxScale int32 `json:"maxScale"`
+}
+
+// HistogramOption holds the bucket configuration of a histogram,
+// which overwrites the bucket configuration in MetricOption of the histogram.
+type HistogramOption struct {
+	// Buckets are the explicit bucket boundaries of histogram.
+	Buckets []float64 `json:"buckets"`
+
+	// Exponential enables the base2 exponential histogram, which takes precedence over Buckets.
+	Exponential *ExponentialHistogramOption `json:"exponential"`
+}
+
+var (
+	// histogramOptions is the configured bucket configurations of histograms, mapping metric name to option.
+	histogramOptions   = make(map[string]HistogramOption)
+	histogramOptionsMu sync.RWMutex
+)
+
+// SetHistogramOption sets the bucket configuration of histogram `name` in configuration time,
+// which is usually used for tuning the buckets of histograms created by components, like the
+// histograms of HTTP server and client.
+//
+// Note that it takes effect only for the histograms that are not initialized by Provider yet,
+// so it should be called before the Provider creation.
+func SetHistogramOption(name string, option HistogramOption) {
+	histogramOptionsMu.Lock()
+	defer histogramOptionsMu.Unlock()
+	histogramOptions[name] = option
+}
+
+// GetHistogramOption returns the bucket configuration of histogram `name` set by SetHistogramOption.
+func GetHistogramOption(name string) (option HistogramOption, ok bool) {
+	histogramOptionsMu.RLock()
+	defer histogramOptionsMu.RUnlock()
+	option, ok = histogramOptions[name]
+	return
+}
+
+// LinearBuckets creates and returns `count` buckets, in which the lowest bucket is `start`
+// and each following bucket is `width` larger than the previous one.
+//
+// Example:
+// LinearBuckets(10, 10, 5) -> [10, 20, 30, 40, 50].
+func LinearBuckets(start, width float64, count int) []float64 {
+	if count < 1 {
+		return nil
+	}
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start + float64(i)*width
+	}
+	return buckets
+}
+
+// ExponentialBuckets creates and returns `count` buckets, in which the lowest bucket is `start`
+// and each following bucket is `factor` times the previous one.
+// It returns nil if `start` is not positive or `factor` is not greater than 1.
+//
+// Example:
+// ExponentialBuckets(1, 10, 4) -> [1, 10, 100, 1000].
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	if count < 1 || start <= 0 || factor <= 1 {
+		return nil
+	}
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// mergeHistogramOption returns the metric option of histogram `name` merged with the bucket configuration
+// set by SetHistogramOption.
+func mergeHistogramOption(name string, option MetricOption) MetricOption {
+	histogramOption, ok := GetHistogramOption(name)
+	if !ok {
+		return option
+	}
+	if len(histogramOption.Buckets) > 0 {
+		option.Buckets = histogramOption.Buckets
+		option.ExponentialHistogram = nil
+	}
+	if histogramOption.Exponential != nil {
+		option.ExponentialHistogram = histogramOption.Exponential
+	}
+	return option
+}
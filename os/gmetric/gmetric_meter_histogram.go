@@ -58,6 +58,7 @@ func (l *localHistogram) Init(provider Provider) (err error) {
 		// already initialized.
 		return
 	}
+	l.MetricOption = mergeHistogramOption(l.Info().Name(), l.MetricOption)
 	l.HistogramPerformer, err = provider.MeterPerformer(l.MeterOption).HistogramPerformer(
 		l.Info().Name(),
 		l.MetricOption,
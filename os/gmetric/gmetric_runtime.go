@@ -0,0 +1,317 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric
+
+import (
+	"context"
+	"math"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// runtimeMetricsReader reads and caches the samples of runtime/metrics,
+// as the observable metrics are read in batch in one collection.
+type runtimeMetricsReader struct {
+	mu       sync.Mutex
+	samples  []metrics.Sample
+	index    map[string]int // Sample name to its index in samples.
+	readTime time.Time
+}
+
+const (
+	runtimeMetricsInstrument   = "github.com/gogf/gf/v2/os/gmetric.Runtime"
+	runtimeMetricsReadInterval = time.Second
+
+	runtimeSampleGoroutines = "/sched/goroutines:goroutines"
+	runtimeSampleGoMaxProcs = "/sched/gomaxprocs:threads"
+	runtimeSampleSchedule   = "/sched/latencies:seconds"
+	runtimeSampleGCCycles   = "/gc/cycles/total:gc-cycles"
+	runtimeSampleGCPauses   = "/gc/pauses:seconds"
+	runtimeSampleHeap       = "/memory/classes/heap/objects:bytes"
+	runtimeSampleHeapStacks = "/memory/classes/heap/stacks:bytes"
+	runtimeSampleOSStacks   = "/memory/classes/os-stacks:bytes"
+	runtimeSampleTotal      = "/memory/classes/total:bytes"
+	runtimeSampleReleased   = "/memory/classes/heap/released:bytes"
+)
+
+var (
+	runtimeMetricsOnce  sync.Once
+	runtimeMetricsError error
+
+	// runtimeQuantiles are the quantiles reported for the runtime histograms.
+	runtimeQuantiles = []float64{0.5, 0.9, 0.99, 1}
+)
+
+// EnableRuntimeMetrics creates the built-in Go runtime metrics on global provider, which exposes
+// the GC pauses, heap and stack bytes, goroutine count, thread count and scheduler latency under
+// standard "go.*" names. It is safe to be called multiple times, only the first call takes effect.
+//
+// The runtime metrics are read from runtime/metrics without stopping the world, and the metric values
+// are cumulative since the process starts. The histograms of GC pauses and scheduler latency are reported
+// as quantile gauges with attribute "quantile", together with the count and approximate total duration.
+func EnableRuntimeMetrics() error {
+	runtimeMetricsOnce.Do(func() {
+		runtimeMetricsError = createRuntimeMetrics()
+	})
+	return runtimeMetricsError
+}
+
+// createRuntimeMetrics creates the runtime metrics with callbacks reading from runtime/metrics.
+func createRuntimeMetrics() (err error) {
+	var (
+		reader = newRuntimeMetricsReader()
+		meter  = GetGlobalProvider().Meter(MeterOption{
+			Instrument: runtimeMetricsInstrument,
+		})
+		gaugeValue = func(sampleName string) MetricCallback {
+			return func(ctx context.Context, obs MetricObserver) error {
+				obs.Observe(reader.Value(sampleName))
+				return nil
+			}
+		}
+	)
+	var gauges = []struct {
+		Name   string
+		Option MetricOption
+	}{
+		{"go.goroutine.count", MetricOption{
+			Help:     "Count of live goroutines.",
+			Unit:     "{goroutine}",
+			Callback: gaugeValue(runtimeSampleGoroutines),
+		}},
+		{"go.thread.count", MetricOption{
+			Help: "Count of OS threads created by the runtime.",
+			Unit: "{thread}",
+			Callback: func(ctx context.Context, obs MetricObserver) error {
+				obs.Observe(float64(pprof.Lookup("threadcreate").Count()))
+				return nil
+			},
+		}},
+		{"go.processor.limit", MetricOption{
+			Help:     "Count of OS threads that can execute user-level Go code simultaneously, the GOMAXPROCS.",
+			Unit:     "{thread}",
+			Callback: gaugeValue(runtimeSampleGoMaxProcs),
+		}},
+		{"go.memory.heap.used", MetricOption{
+			Help:     "Memory occupied by live objects and dead objects that have not yet been freed by GC.",
+			Unit:     "By",
+			Callback: gaugeValue(runtimeSampleHeap),
+		}},
+		{"go.memory.stack.used", MetricOption{
+			Help: "Memory allocated for goroutine stacks and stacks of OS threads.",
+			Unit: "By",
+			Callback: func(ctx context.Context, obs MetricObserver) error {
+				obs.Observe(reader.Value(runtimeSampleHeapStacks) + reader.Value(runtimeSampleOSStacks))
+				return nil
+			},
+		}},
+		{"go.memory.used", MetricOption{
+			Help: "Memory mapped by the runtime excluding the memory released to OS.",
+			Unit: "By",
+			Callback: func(ctx context.Context, obs MetricObserver) error {
+				obs.Observe(reader.Value(runtimeSampleTotal) - reader.Value(runtimeSampleReleased))
+				return nil
+			},
+		}},
+		{"go.gc.pause", MetricOption{
+			Help:     "Quantiles of GC stop-the-world pause latencies.",
+			Unit:     "s",
+			Callback: reader.quantileCallback(runtimeSampleGCPauses),
+		}},
+		{"go.schedule.latency", MetricOption{
+			Help:     "Quantiles of the time goroutines spent in runnable state before actually running.",
+			Unit:     "s",
+			Callback: reader.quantileCallback(runtimeSampleSchedule),
+		}},
+	}
+	for _, gauge := range gauges {
+		if _, err = meter.ObservableGauge(gauge.Name, gauge.Option); err != nil {
+			return err
+		}
+	}
+	var counters = []struct {
+		Name   string
+		Option MetricOption
+	}{
+		{"go.gc.count", MetricOption{
+			Help:     "Count of completed GC cycles.",
+			Unit:     "{gc_cycle}",
+			Callback: gaugeValue(runtimeSampleGCCycles),
+		}},
+		{"go.gc.pause.count", MetricOption{
+			Help: "Count of GC stop-the-world pauses.",
+			Unit: "{pause}",
+			Callback: func(ctx context.Context, obs MetricObserver) error {
+				count, _ := reader.HistogramSummary(runtimeSampleGCPauses)
+				obs.Observe(count)
+				return nil
+			},
+		}},
+		{"go.gc.pause.duration", MetricOption{
+			Help: "Approximate total duration of GC stop-the-world pauses.",
+			Unit: "s",
+			Callback: func(ctx context.Context, obs MetricObserver) error {
+				_, sum := reader.HistogramSummary(runtimeSampleGCPauses)
+				obs.Observe(sum)
+				return nil
+			},
+		}},
+	}
+	for _, counter := range counters {
+		if _, err = meter.ObservableCounter(counter.Name, counter.Option); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newRuntimeMetricsReader creates and returns a reader for the runtime samples that are supported
+// by current Go version.
+func newRuntimeMetricsReader() *runtimeMetricsReader {
+	var (
+		supported = make(map[string]struct{})
+		reader    = &runtimeMetricsReader{
+			index: make(map[string]int),
+		}
+	)
+	for _, desc := range metrics.All() {
+		supported[desc.Name] = struct{}{}
+	}
+	for _, name := range []string{
+		runtimeSampleGoroutines, runtimeSampleGoMaxProcs, runtimeSampleSchedule,
+		runtimeSampleGCCycles, runtimeSampleGCPauses, runtimeSampleHeap,
+		runtimeSampleHeapStacks, runtimeSampleOSStacks, runtimeSampleTotal, runtimeSampleReleased,
+	} {
+		if _, ok := supported[name]; !ok {
+			continue
+		}
+		reader.index[name] = len(reader.samples)
+		reader.samples = append(reader.samples, metrics.Sample{Name: name})
+	}
+	return reader
+}
+
+// sample returns the latest sample value of `name`, which re-reads the samples if they are expired.
+// Note that the caller must hold r.mu, as the samples are re-used by the next metrics.Read.
+func (r *runtimeMetricsReader) sample(name string) (metrics.Value, bool) {
+	if time.Since(r.readTime) >= runtimeMetricsReadInterval {
+		metrics.Read(r.samples)
+		r.readTime = time.Now()
+	}
+	index, ok := r.index[name]
+	if !ok {
+		return metrics.Value{}, false
+	}
+	return r.samples[index].Value, true
+}
+
+// Value returns the scalar value of sample `name`, or 0 if it is not a scalar.
+func (r *runtimeMetricsReader) Value(name string) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.sample(name)
+	if !ok {
+		return 0
+	}
+	switch value.Kind() {
+	case metrics.KindUint64:
+		return float64(value.Uint64())
+	case metrics.KindFloat64:
+		return value.Float64()
+	default:
+		return 0
+	}
+}
+
+// HistogramSummary returns the total count and approximate sum of histogram sample `name`,
+// in which each value is approximated by the middle of its bucket.
+func (r *runtimeMetricsReader) HistogramSummary(name string) (count, sum float64) {
+	histogram := r.histogram(name)
+	if histogram == nil {
+		return 0, 0
+	}
+	for i, c := range histogram.Counts {
+		if c == 0 {
+			continue
+		}
+		count += float64(c)
+		sum += float64(c) * runtimeBucketMiddle(histogram.Buckets[i], histogram.Buckets[i+1])
+	}
+	return count, sum
+}
+
+// Quantile returns the upper bound of the bucket in which quantile `q` of histogram sample `name` is.
+func (r *runtimeMetricsReader) Quantile(name string, q float64) float64 {
+	histogram := r.histogram(name)
+	if histogram == nil {
+		return 0
+	}
+	var total uint64
+	for _, c := range histogram.Counts {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	var (
+		cumulative uint64
+		threshold  = uint64(math.Ceil(q * float64(total)))
+	)
+	for i, c := range histogram.Counts {
+		cumulative += c
+		if c > 0 && cumulative >= threshold {
+			if upper := histogram.Buckets[i+1]; !math.IsInf(upper, 1) {
+				return upper
+			}
+			return histogram.Buckets[i]
+		}
+	}
+	return 0
+}
+
+// quantileCallback returns the callback observing the quantiles of histogram sample `name`.
+func (r *runtimeMetricsReader) quantileCallback(name string) MetricCallback {
+	return func(ctx context.Context, obs MetricObserver) error {
+		for _, q := range runtimeQuantiles {
+			obs.Observe(r.Quantile(name, q), Option{
+				Attributes: Attributes{NewAttribute("quantile", q)},
+			})
+		}
+		return nil
+	}
+}
+
+// histogram returns a copy of histogram sample `name`, which is copied under r.mu as
+// the Counts and Buckets of the sample are overwritten in place by the next metrics.Read.
+func (r *runtimeMetricsReader) histogram(name string) *metrics.Float64Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.sample(name)
+	if !ok || value.Kind() != metrics.KindFloat64Histogram {
+		return nil
+	}
+	histogram := value.Float64Histogram()
+	return &metrics.Float64Histogram{
+		Counts:  append([]uint64(nil), histogram.Counts...),
+		Buckets: append([]float64(nil), histogram.Buckets...),
+	}
+}
+
+// runtimeBucketMiddle returns the middle value of bucket [lower, upper), which uses the finite bound
+// if the other one is infinite.
+func runtimeBucketMiddle(lower, upper float64) float64 {
+	switch {
+	case math.IsInf(lower, -1):
+		return upper
+	case math.IsInf(upper, 1):
+		return lower
+	default:
+		return (lower + upper) / 2
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ExemplarFromContext(t *testing.T) {
+	var (
+		traceId, _ = trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanId, _  = trace.SpanIDFromHex("00f067aa0ba902b7")
+	)
+	// Sampled span.
+	gtest.C(t, func(t *gtest.T) {
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceId,
+			SpanID:     spanId,
+			TraceFlags: trace.FlagsSampled,
+		}))
+		exemplar := gmetric.ExemplarFromContext(ctx)
+		t.AssertNE(exemplar, nil)
+		t.Assert(exemplar.TraceId, traceId.String())
+		t.Assert(exemplar.SpanId, spanId.String())
+		t.Assert(exemplar.Timestamp.IsZero(), false)
+
+		spanCtx := exemplar.SpanContext()
+		t.Assert(spanCtx.IsValid(), true)
+		t.Assert(spanCtx.IsSampled(), true)
+		t.Assert(spanCtx.TraceID().String(), traceId.String())
+		t.Assert(spanCtx.SpanID().String(), spanId.String())
+	})
+	// Not sampled span.
+	gtest.C(t, func(t *gtest.T) {
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceId,
+			SpanID:  spanId,
+		}))
+		t.Assert(gmetric.ExemplarFromContext(ctx) == nil, true)
+	})
+	// No span.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gmetric.ExemplarFromContext(context.Background()) == nil, true)
+		t.Assert(gmetric.ExemplarFromContext(nil) == nil, true)
+	})
+	// Invalid exemplar.
+	gtest.C(t, func(t *gtest.T) {
+		var exemplar *gmetric.Exemplar
+		t.Assert(exemplar.SpanContext().IsValid(), false)
+		exemplar = &gmetric.Exemplar{TraceId: "invalid", SpanId: spanId.String()}
+		t.Assert(exemplar.SpanContext().IsValid(), false)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ExporterConfigFromMap(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		config, err := gmetric.ExporterConfigFromMap(g.Map{
+			"protocol":    "grpc",
+			"endpoint":    "127.0.0.1:4317",
+			"interval":    "30s",
+			"serviceName": "user-service",
+			"resourceAttributes": g.Map{
+				"deployment.environment": "prod",
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(config.Protocol, "grpc")
+		t.Assert(config.Endpoint, "127.0.0.1:4317")
+		t.Assert(config.Interval, 30*time.Second)
+		t.Assert(config.ServiceName, "user-service")
+		t.Assert(config.ResourceAttributes["deployment.environment"], "prod")
+	})
+}
+
+func Test_Bootstrap(t *testing.T) {
+	var ctx = context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gmetric.Bootstrap(ctx, gmetric.ExporterConfig{})
+		t.AssertNE(err, nil)
+
+		_, err = gmetric.Bootstrap(ctx, gmetric.ExporterConfig{Exporter: "not-exist", Endpoint: "127.0.0.1:4318"})
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			received gmetric.ExporterConfig
+			errTest  = gerror.New("test")
+		)
+		gmetric.RegisterExporter("test", func(ctx context.Context, config gmetric.ExporterConfig) (gmetric.Provider, error) {
+			received = config
+			return nil, errTest
+		})
+		_, err := gmetric.Bootstrap(ctx, gmetric.ExporterConfig{Exporter: "test", Endpoint: "127.0.0.1:4318"})
+		t.Assert(err, errTest)
+		t.Assert(received.Protocol, "http")
+		t.Assert(received.Interval, 60*time.Second)
+		t.Assert(received.Timeout, 10*time.Second)
+	})
+}
@@ -107,3 +107,35 @@ func Test_GlobalAttributes(t *testing.T) {
 		}), Attributes{})
 	})
 }
+
+func Test_HistogramOption(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(LinearBuckets(10, 10, 5), []float64{10, 20, 30, 40, 50})
+		t.Assert(LinearBuckets(10, 10, 0), nil)
+		t.Assert(ExponentialBuckets(1, 10, 4), []float64{1, 10, 100, 1000})
+		t.Assert(ExponentialBuckets(0, 10, 4), nil)
+		t.Assert(ExponentialBuckets(1, 1, 4), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name   = "gmetric.unit.histogram.option"
+			option = MetricOption{Unit: "ms", Buckets: []float64{1, 2}}
+		)
+		t.Assert(mergeHistogramOption(name, option).Buckets, []float64{1, 2})
+
+		SetHistogramOption(name, HistogramOption{Buckets: []float64{10, 20}})
+		merged := mergeHistogramOption(name, option)
+		t.Assert(merged.Unit, "ms")
+		t.Assert(merged.Buckets, []float64{10, 20})
+		t.Assert(merged.ExponentialHistogram == nil, true)
+
+		SetHistogramOption(name, HistogramOption{Exponential: &ExponentialHistogramOption{MaxSize: 10}})
+		merged = mergeHistogramOption(name, option)
+		t.Assert(merged.Buckets, []float64{1, 2})
+		t.Assert(merged.ExponentialHistogram.MaxSize, 10)
+
+		histogramOption, ok := GetHistogramOption(name)
+		t.Assert(ok, true)
+		t.Assert(histogramOption.Exponential.MaxSize, 10)
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmetric
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testRuntimeObserver struct {
+	values []float64
+}
+
+func (o *testRuntimeObserver) Observe(value float64, option ...Option) {
+	o.values = append(o.values, value)
+}
+
+func Test_EnableRuntimeMetrics(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(EnableRuntimeMetrics())
+		t.AssertNil(EnableRuntimeMetrics())
+		var names = make(map[string]int)
+		for _, m := range GetAllMetrics() {
+			if m.Info().Instrument().Name() == runtimeMetricsInstrument {
+				names[m.Info().Name()]++
+			}
+		}
+		t.Assert(len(names), 11)
+		t.Assert(names["go.goroutine.count"], 1)
+		t.Assert(names["go.gc.pause"], 1)
+		t.Assert(names["go.schedule.latency"], 1)
+	})
+}
+
+func Test_RuntimeMetricsReader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		runtime.GC()
+		var reader = newRuntimeMetricsReader()
+		t.AssertGT(reader.Value(runtimeSampleGoroutines), 0)
+		t.AssertGT(reader.Value(runtimeSampleHeap), 0)
+		t.AssertGT(reader.Value(runtimeSampleGCCycles), 0)
+		t.Assert(reader.Value("/not/exist:unit"), 0)
+
+		count, sum := reader.HistogramSummary(runtimeSampleGCPauses)
+		t.AssertGT(count, 0)
+		t.Assert(sum > 0, true)
+		var (
+			p50 = reader.Quantile(runtimeSampleGCPauses, 0.5)
+			max = reader.Quantile(runtimeSampleGCPauses, 1)
+		)
+		t.Assert(p50 > 0, true)
+		t.Assert(max >= p50, true)
+
+		var observer = &testRuntimeObserver{}
+		t.AssertNil(reader.quantileCallback(runtimeSampleGCPauses)(context.Background(), observer))
+		t.Assert(len(observer.values), len(runtimeQuantiles))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(runtimeBucketMiddle(1, 3), 2)
+		t.Assert(runtimeBucketMiddle(math.Inf(-1), 3), 3)
+		t.Assert(runtimeBucketMiddle(1, math.Inf(1)), 1)
+	})
+}
+
+func Test_RuntimeMetricsReader_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     sync.WaitGroup
+			reader = newRuntimeMetricsReader()
+		)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					// Expires the samples to have them re-read by the concurrent collections.
+					reader.mu.Lock()
+					reader.readTime = time.Time{}
+					reader.mu.Unlock()
+					reader.HistogramSummary(runtimeSampleGCPauses)
+					reader.Quantile(runtimeSampleSchedule, 0.99)
+					reader.Value(runtimeSampleGoroutines)
+				}
+			}()
+		}
+		wg.Wait()
+		count, _ := reader.HistogramSummary(runtimeSampleGCPauses)
+		t.Assert(count >= 0, true)
+	})
+}
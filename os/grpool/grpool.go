@@ -9,10 +9,13 @@ package grpool
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/container/glist"
 	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/gtimer"
 	"github.com/gogf/gf/v2/util/grand"
 )
@@ -23,12 +26,73 @@ type Func func(ctx context.Context)
 // RecoverFunc is the pool runtime panic recover function which contains context parameter.
 type RecoverFunc func(ctx context.Context, exception error)
 
+// PanicHandler is the handler for panics of jobs, which receives the job context,
+// the panic exception and the stack of panic.
+type PanicHandler func(ctx context.Context, exception error, stack string)
+
+// RejectPolicy is the policy for adding job when the job queue is full.
+type RejectPolicy int
+
+const (
+	// RejectPolicyError rejects the job with ErrQueueFull, which is the default policy.
+	RejectPolicyError RejectPolicy = iota
+	// RejectPolicyCallerRuns runs the job synchronously in the goroutine of caller.
+	RejectPolicyCallerRuns
+	// RejectPolicyDiscardOldest discards the oldest job in queue and adds the job.
+	RejectPolicyDiscardOldest
+)
+
+// Option is the option for creating pool.
+type Option struct {
+	// MinWorkers is the min count of workers kept alive waiting for jobs, which is 0 in default.
+	MinWorkers int
+
+	// MaxWorkers is the max count of workers, no limit if <= 0.
+	// The workers are forked automatically when the queued jobs exceed the idle workers.
+	MaxWorkers int
+
+	// IdleTimeout is the duration that the workers beyond MinWorkers wait for new jobs before exiting,
+	// in which the workers exit immediately if there's no job in default.
+	IdleTimeout time.Duration
+
+	// QueueSize is the max count of queued jobs, no limit if <= 0.
+	QueueSize int
+
+	// RejectPolicy is the policy for adding job when the job queue is full.
+	RejectPolicy RejectPolicy
+
+	// PanicHandler handles the panics of jobs. The panic of job is propagated if it is nil.
+	PanicHandler PanicHandler
+}
+
+// Metrics is the runtime metrics of pool.
+type Metrics struct {
+	Workers   int   // Current worker count.
+	Idle      int   // Current idle worker count.
+	Queued    int   // Current queued job count.
+	Running   int   // Current running job count.
+	Completed int64 // Total completed job count, including the panicked ones.
+	Panics    int64 // Total panicked job count.
+	Rejected  int64 // Total rejected job count, including the discarded ones.
+}
+
 // Pool manages the goroutines using pool.
 type Pool struct {
-	limit  int         // Max goroutine count limit.
-	count  *gtype.Int  // Current running goroutine count.
-	list   *glist.List // List for asynchronous job adding purpose.
-	closed *gtype.Bool // Is pool closed or not.
+	limit        int              // Max goroutine count limit.
+	option       Option           // Option of pool.
+	count        *gtype.Int       // Current running goroutine count.
+	idle         *gtype.Int       // Current idle goroutine count.
+	running      *gtype.Int       // Current running job count.
+	completed    *gtype.Int64     // Total completed job count.
+	panics       *gtype.Int64     // Total panicked job count.
+	rejected     *gtype.Int64     // Total rejected job count.
+	list         *glist.List      // List for asynchronous job adding purpose.
+	queueMu      sync.Mutex       // Makes the queue size checking and job adding atomic.
+	panicHandler *gtype.Interface // Handler for panics of jobs.
+	wakeup       chan struct{}    // Wakes up the idle workers for new jobs.
+	closed       *gtype.Bool      // Is pool closed or not.
+	closeChan    chan struct{}    // Closed when pool is closed.
+	closeOnce    sync.Once        // Makes closeChan closed only once.
 }
 
 // localPoolItem is the job item storing in job list.
@@ -42,29 +106,62 @@ const (
 	maxSupervisorTimerDuration = 1500 * time.Millisecond
 )
 
-// Default goroutine pool.
 var (
+	// Default goroutine pool.
 	defaultPool = New()
+
+	// ErrQueueFull is returned if the job is rejected as the job queue is full.
+	ErrQueueFull = gerror.NewWithOption(gerror.Option{
+		Text: "goroutine pool job queue is full",
+		Code: gcode.CodeOperationFailed,
+	})
 )
 
 // New creates and returns a new goroutine pool object.
 // The parameter `limit` is used to limit the max goroutine count,
 // which is not limited in default.
 func New(limit ...int) *Pool {
+	var option Option
+	if len(limit) > 0 {
+		option.MaxWorkers = limit[0]
+	}
+	return NewWithOption(option)
+}
+
+// NewWithOption creates and returns a new goroutine pool object with `option`.
+func NewWithOption(option Option) *Pool {
 	var (
 		pool = &Pool{
-			limit:  -1,
-			count:  gtype.NewInt(),
-			list:   glist.New(true),
-			closed: gtype.NewBool(),
+			limit:        -1,
+			option:       option,
+			count:        gtype.NewInt(),
+			idle:         gtype.NewInt(),
+			running:      gtype.NewInt(),
+			completed:    gtype.NewInt64(),
+			panics:       gtype.NewInt64(),
+			rejected:     gtype.NewInt64(),
+			list:         glist.New(true),
+			panicHandler: gtype.NewInterface(),
+			wakeup:       make(chan struct{}, 1),
+			closed:       gtype.NewBool(),
+			closeChan:    make(chan struct{}),
 		}
 		timerDuration = grand.D(
 			minSupervisorTimerDuration,
 			maxSupervisorTimerDuration,
 		)
 	)
-	if len(limit) > 0 && limit[0] > 0 {
-		pool.limit = limit[0]
+	if option.MaxWorkers > 0 {
+		pool.limit = option.MaxWorkers
+		if pool.option.MinWorkers > pool.limit {
+			pool.option.MinWorkers = pool.limit
+		}
+	}
+	if option.PanicHandler != nil {
+		pool.panicHandler.Set(option.PanicHandler)
+	}
+	for i := 0; i < pool.option.MinWorkers; i++ {
+		pool.checkAndForkNewGoroutineWorker()
 	}
 	gtimer.Add(context.Background(), timerDuration, pool.supervisor)
 	return pool
@@ -94,3 +191,13 @@ func Size() int {
 func Jobs() int {
 	return defaultPool.Jobs()
 }
+
+// SetPanicHandler sets the panic handler of default goroutine pool.
+func SetPanicHandler(handler PanicHandler) {
+	defaultPool.SetPanicHandler(handler)
+}
+
+// GetMetrics returns the runtime metrics of default goroutine pool.
+func GetMetrics() Metrics {
+	return defaultPool.Metrics()
+}
@@ -8,6 +8,8 @@ package grpool
 
 import (
 	"context"
+	"runtime/debug"
+	"time"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -22,12 +24,34 @@ func (p *Pool) Add(ctx context.Context, f Func) error {
 			"goroutine defaultPool is already closed",
 		)
 	}
-	p.list.PushFront(&localPoolItem{
+	var item = &localPoolItem{
 		Ctx:  ctx,
 		Func: f,
-	})
-	// Check and fork new worker.
-	p.checkAndForkNewGoroutineWorker()
+	}
+	p.queueMu.Lock()
+	if p.option.QueueSize > 0 && p.list.Size() >= p.option.QueueSize {
+		p.rejected.Add(1)
+		switch p.option.RejectPolicy {
+		case RejectPolicyCallerRuns:
+			p.queueMu.Unlock()
+			p.runJob(item)
+			return nil
+
+		case RejectPolicyDiscardOldest:
+			p.list.PopBack()
+
+		default:
+			p.queueMu.Unlock()
+			return gerror.Wrapf(ErrQueueFull, `queue size %d exceeded`, p.option.QueueSize)
+		}
+	}
+	p.list.PushFront(item)
+	p.queueMu.Unlock()
+	// Wake up an idle worker, or fork new worker if there are more jobs than idle workers.
+	p.notifyWorker()
+	if p.idle.Val() < p.list.Size() {
+		p.checkAndForkNewGoroutineWorker()
+	}
 	return nil
 }
 
@@ -71,6 +95,27 @@ func (p *Pool) Jobs() int {
 	return p.list.Size()
 }
 
+// SetPanicHandler sets the panic handler of the pool, which is called with the job context,
+// the panic exception and the stack when any job panics.
+// The panic of job is propagated if no panic handler is set.
+func (p *Pool) SetPanicHandler(handler PanicHandler) {
+	p.panicHandler.Set(handler)
+}
+
+// Metrics returns the runtime metrics of the pool.
+// Note that the panics recovered by AddWithRecover are not counted in Metrics.Panics.
+func (p *Pool) Metrics() Metrics {
+	return Metrics{
+		Workers:   p.count.Val(),
+		Idle:      p.idle.Val(),
+		Queued:    p.list.Size(),
+		Running:   p.running.Val(),
+		Completed: p.completed.Val(),
+		Panics:    p.panics.Val(),
+		Rejected:  p.rejected.Val(),
+	}
+}
+
 // IsClosed returns if pool is closed.
 func (p *Pool) IsClosed() bool {
 	return p.closed.Val()
@@ -79,6 +124,9 @@ func (p *Pool) IsClosed() bool {
 // Close closes the goroutine pool, which makes all goroutines exit.
 func (p *Pool) Close() {
 	p.closed.Set(true)
+	p.closeOnce.Do(func() {
+		close(p.closeChan)
+	})
 }
 
 // checkAndForkNewGoroutineWorker checks and creates a new goroutine worker.
@@ -102,20 +150,100 @@ func (p *Pool) checkAndForkNewGoroutineWorker() {
 	go p.asynchronousWorker()
 }
 
+// asynchronousWorker handles the jobs one by one, and waits for new jobs if it is
+// one of the MinWorkers or the IdleTimeout is configured.
 func (p *Pool) asynchronousWorker() {
-	defer p.count.Add(-1)
+	var exited bool
+	defer func() {
+		if !exited {
+			p.count.Add(-1)
+		}
+	}()
 
-	var (
-		listItem interface{}
-		poolItem *localPoolItem
-	)
-	// Harding working, one by one, job never empty, worker never die.
+	var listItem interface{}
 	for !p.closed.Val() {
-		listItem = p.list.PopBack()
-		if listItem == nil {
+		if listItem = p.list.PopBack(); listItem != nil {
+			// Passes the wakeup to other idle workers if there are still jobs.
+			if p.list.Size() > 0 {
+				p.notifyWorker()
+			}
+			p.runJob(listItem.(*localPoolItem))
+			continue
+		}
+		if p.option.IdleTimeout <= 0 && p.tryExitWorker() {
+			exited = true
+			return
+		}
+		if !p.waitForJob() && p.tryExitWorker() {
+			exited = true
 			return
 		}
-		poolItem = listItem.(*localPoolItem)
-		poolItem.Func(poolItem.Ctx)
 	}
 }
+
+// waitForJob waits for new jobs, which returns false if it times out for idle.
+// The workers in MinWorkers never time out.
+func (p *Pool) waitForJob() bool {
+	var timeout <-chan time.Time
+	if p.option.IdleTimeout > 0 && p.count.Val() > p.option.MinWorkers {
+		timer := time.NewTimer(p.option.IdleTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	p.idle.Add(1)
+	defer p.idle.Add(-1)
+	select {
+	case <-p.wakeup:
+		return true
+	case <-p.closeChan:
+		return true
+	case <-timeout:
+		return false
+	}
+}
+
+// tryExitWorker decreases the worker count if there are more workers than MinWorkers,
+// which returns true if the worker should exit.
+func (p *Pool) tryExitWorker() bool {
+	for {
+		n := p.count.Val()
+		if n <= p.option.MinWorkers {
+			return false
+		}
+		if p.count.Cas(n, n-1) {
+			return true
+		}
+	}
+}
+
+// notifyWorker wakes up an idle worker without blocking.
+func (p *Pool) notifyWorker() {
+	select {
+	case p.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// runJob executes the job and handles its panic with the panic handler.
+func (p *Pool) runJob(item *localPoolItem) {
+	p.running.Add(1)
+	defer func() {
+		p.running.Add(-1)
+		p.completed.Add(1)
+		if exception := recover(); exception != nil {
+			p.panics.Add(1)
+			handler, _ := p.panicHandler.Val().(PanicHandler)
+			if handler == nil {
+				panic(exception)
+			}
+			var err error
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+			handler(item.Ctx, err, string(debug.Stack()))
+		}
+	}()
+	item.Func(item.Ctx)
+}
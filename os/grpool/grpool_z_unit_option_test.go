@@ -0,0 +1,171 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpool_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/grpool"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Option_Workers(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		pool := grpool.NewWithOption(grpool.Option{
+			MinWorkers:  2,
+			MaxWorkers:  5,
+			IdleTimeout: 200 * time.Millisecond,
+		})
+		defer pool.Close()
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(pool.Size(), 2)
+		t.Assert(pool.Metrics().Idle, 2)
+
+		var (
+			wg      = sync.WaitGroup{}
+			release = make(chan struct{})
+			size    = 10
+		)
+		wg.Add(size)
+		for i := 0; i < size; i++ {
+			t.AssertNil(pool.Add(ctx, func(ctx context.Context) {
+				defer wg.Done()
+				<-release
+			}))
+		}
+		time.Sleep(100 * time.Millisecond)
+		metrics := pool.Metrics()
+		t.Assert(metrics.Workers, 5)
+		t.Assert(metrics.Running, 5)
+		t.Assert(metrics.Queued, 5)
+		close(release)
+		wg.Wait()
+
+		// The workers beyond MinWorkers exit after idle timeout.
+		time.Sleep(500 * time.Millisecond)
+		metrics = pool.Metrics()
+		t.Assert(metrics.Workers, 2)
+		t.Assert(metrics.Running, 0)
+		t.Assert(metrics.Queued, 0)
+		t.Assert(metrics.Completed, size)
+	})
+}
+
+func Test_Option_RejectPolicy(t *testing.T) {
+	// RejectPolicyError.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			pool    = grpool.NewWithOption(grpool.Option{
+				MaxWorkers: 1,
+				QueueSize:  2,
+			})
+		)
+		defer pool.Close()
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { <-release }))
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) {}))
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) {}))
+		err := pool.Add(ctx, func(ctx context.Context) {})
+		t.Assert(gerror.Is(err, grpool.ErrQueueFull), true)
+		t.Assert(pool.Metrics().Rejected, 1)
+		close(release)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(pool.Metrics().Completed, 3)
+	})
+	// RejectPolicyCallerRuns.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			array   = garray.NewArray(true)
+			pool    = grpool.NewWithOption(grpool.Option{
+				MaxWorkers:   1,
+				QueueSize:    1,
+				RejectPolicy: grpool.RejectPolicyCallerRuns,
+			})
+		)
+		defer pool.Close()
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { <-release }))
+		time.Sleep(50 * time.Millisecond)
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { array.Append(1) }))
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { array.Append(2) }))
+		t.Assert(array.Slice(), []int{2})
+		close(release)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(array.Slice(), []int{2, 1})
+	})
+	// RejectPolicyDiscardOldest.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			release = make(chan struct{})
+			array   = garray.NewArray(true)
+			pool    = grpool.NewWithOption(grpool.Option{
+				MaxWorkers:   1,
+				QueueSize:    2,
+				RejectPolicy: grpool.RejectPolicyDiscardOldest,
+			})
+		)
+		defer pool.Close()
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) { <-release }))
+		time.Sleep(50 * time.Millisecond)
+		for i := 1; i <= 4; i++ {
+			v := i
+			t.AssertNil(pool.Add(ctx, func(ctx context.Context) { array.Append(v) }))
+		}
+		close(release)
+		time.Sleep(50 * time.Millisecond)
+		t.Assert(array.Slice(), []int{3, 4})
+		t.Assert(pool.Metrics().Rejected, 2)
+	})
+}
+
+func Test_Option_PanicHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		type ctxKey struct{}
+		var (
+			wg    = sync.WaitGroup{}
+			value interface{}
+			stack string
+			pool  = grpool.NewWithOption(grpool.Option{
+				PanicHandler: func(ctx context.Context, exception error, s string) {
+					defer wg.Done()
+					value = ctx.Value(ctxKey{})
+					stack = s
+					t.Assert(exception.Error(), "job panic")
+				},
+			})
+		)
+		defer pool.Close()
+		wg.Add(1)
+		t.AssertNil(pool.Add(context.WithValue(ctx, ctxKey{}, "job"), func(ctx context.Context) {
+			panic("job panic")
+		}))
+		wg.Wait()
+		t.Assert(value, "job")
+		t.Assert(strings.Contains(stack, "Test_Option_PanicHandler"), true)
+
+		// The worker survives the panic.
+		wg.Add(1)
+		pool.SetPanicHandler(func(ctx context.Context, exception error, stack string) {
+			wg.Done()
+		})
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) {
+			panic(gerror.New("error panic"))
+		}))
+		wg.Wait()
+		time.Sleep(50 * time.Millisecond)
+		metrics := pool.Metrics()
+		t.Assert(metrics.Panics, 2)
+		t.Assert(metrics.Completed, 2)
+	})
+}
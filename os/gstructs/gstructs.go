@@ -68,6 +68,12 @@ const (
 
 // Fields retrieves and returns the fields of `pointer` as slice.
 func Fields(in FieldsInput) ([]Field, error) {
+	return doFields(in, nil)
+}
+
+// doFields retrieves and returns the fields of `pointer` as slice, in which the `embeddedTypes`
+// records the embedded struct types of current recursion path.
+func doFields(in FieldsInput, embeddedTypes map[reflect.Type]struct{}) ([]Field, error) {
 	var (
 		ok                   bool
 		fieldFilterMap       = make(map[string]struct{})
@@ -91,6 +97,17 @@ func Fields(in FieldsInput) ([]Field, error) {
 		}
 		if field.IsEmbedded() {
 			if in.RecursiveOption != RecursiveOptionNone {
+				var embeddedType = field.OriginalType()
+				// The embedded attribute which is not struct is retrieved as normal attribute.
+				if embeddedType.Kind() != reflect.Struct {
+					fieldFilterMap[field.Name()] = struct{}{}
+					retrievedFields = append(retrievedFields, field)
+					continue
+				}
+				// It avoids infinite recursion of the struct embedding itself.
+				if _, ok = embeddedTypes[embeddedType]; ok {
+					continue
+				}
 				switch in.RecursiveOption {
 				case RecursiveOptionEmbeddedNoTag:
 					if field.TagStr() != "" {
@@ -99,10 +116,10 @@ func Fields(in FieldsInput) ([]Field, error) {
 					fallthrough
 
 				case RecursiveOptionEmbedded:
-					structFields, err := Fields(FieldsInput{
+					structFields, err := doFields(FieldsInput{
 						Pointer:         field.Value,
 						RecursiveOption: in.RecursiveOption,
-					})
+					}, withEmbeddedType(embeddedTypes, embeddedType))
 					if err != nil {
 						return nil, err
 					}
@@ -143,8 +160,15 @@ func Fields(in FieldsInput) ([]Field, error) {
 // The parameter `recursive` specifies whether retrieving the fields recursively if the attribute
 // is an embedded struct.
 //
-// Note that it only retrieves the exported attributes with first letter upper-case from struct.
+// Note that it only retrieves the exported attributes with first letter upper-case from struct,
+// in which the exported attributes of unexported embedded struct are also retrieved recursively.
 func FieldMap(in FieldMapInput) (map[string]Field, error) {
+	return doFieldMap(in, nil)
+}
+
+// doFieldMap retrieves and returns struct field as map[name/tag]Field from `pointer`, in which the
+// `embeddedTypes` records the embedded struct types of current recursion path.
+func doFieldMap(in FieldMapInput, embeddedTypes map[reflect.Type]struct{}) (map[string]Field, error) {
 	fields, err := getFieldValues(in.Pointer)
 	if err != nil {
 		return nil, err
@@ -154,12 +178,21 @@ func FieldMap(in FieldMapInput) (map[string]Field, error) {
 		mapField = make(map[string]Field)
 	)
 	for _, field := range fields {
-		// Only retrieve exported attributes.
-		if !field.IsExported() {
+		var (
+			embeddedType      = field.OriginalType()
+			isRecursiveStruct = in.RecursiveOption != RecursiveOptionNone &&
+				field.IsEmbedded() && embeddedType.Kind() == reflect.Struct
+		)
+		// Only retrieve exported attributes,
+		// excepting the unexported embedded struct whose exported attributes are promoted.
+		if !field.IsExported() && !isRecursiveStruct {
 			continue
 		}
 		tagValue = ""
 		for _, p := range in.PriorityTagArray {
+			if !field.IsExported() {
+				break
+			}
 			tagValue = field.Tag(p)
 			if tagValue != "" && tagValue != "-" {
 				break
@@ -170,21 +203,27 @@ func FieldMap(in FieldMapInput) (map[string]Field, error) {
 		if tagValue != "" {
 			mapField[tagValue] = tempField
 		} else {
-			if in.RecursiveOption != RecursiveOptionNone && field.IsEmbedded() {
+			if isRecursiveStruct {
 				switch in.RecursiveOption {
 				case RecursiveOptionEmbeddedNoTag:
 					if field.TagStr() != "" {
-						mapField[field.Name()] = tempField
+						if field.IsExported() {
+							mapField[field.Name()] = tempField
+						}
 						break
 					}
 					fallthrough
 
 				case RecursiveOptionEmbedded:
-					m, err := FieldMap(FieldMapInput{
+					// It avoids infinite recursion of the struct embedding itself.
+					if _, ok := embeddedTypes[embeddedType]; ok {
+						break
+					}
+					m, err := doFieldMap(FieldMapInput{
 						Pointer:          field.Value,
 						PriorityTagArray: in.PriorityTagArray,
 						RecursiveOption:  in.RecursiveOption,
-					})
+					}, withEmbeddedType(embeddedTypes, embeddedType))
 					if err != nil {
 						return nil, err
 					}
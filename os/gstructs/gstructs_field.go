@@ -96,6 +96,16 @@ func (f *Field) OriginalKind() reflect.Kind {
 	return reflectKind
 }
 
+// OriginalType retrieves and returns the original reflect.Type of Field `f`, which dereferences
+// the pointer type, like returning type of struct for field of *struct.
+func (f *Field) OriginalType() reflect.Type {
+	var reflectType = f.Field.Type
+	for reflectType.Kind() == reflect.Ptr {
+		reflectType = reflectType.Elem()
+	}
+	return reflectType
+}
+
 // OriginalValue retrieves and returns the original reflect.Value of Field `f`.
 func (f *Field) OriginalValue() reflect.Value {
 	var (
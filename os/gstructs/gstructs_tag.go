@@ -77,7 +77,7 @@ func ParseTag(tag string) map[string]string {
 // 1. It only retrieves the exported attributes with first letter upper-case from struct.
 // 2. The parameter `priority` should be given, it only retrieves fields that has given tag.
 func TagFields(pointer interface{}, priority []string) ([]Field, error) {
-	return getFieldValuesByTagPriority(pointer, priority, map[string]struct{}{})
+	return getFieldValuesByTagPriority(pointer, priority, map[string]struct{}{}, nil)
 }
 
 // TagMapName retrieves and returns struct tags as map[tag]attribute from `pointer`.
@@ -170,7 +170,7 @@ exitLoop:
 	for i := 0; i < length; i++ {
 		fields[i] = Field{
 			Value: reflectValue.Field(i),
-			Field: structType.Field(i),
+			Field: ProcessTag(structType.Field(i)),
 		}
 	}
 	return fields, nil
@@ -178,6 +178,7 @@ exitLoop:
 
 func getFieldValuesByTagPriority(
 	pointer interface{}, priority []string, repeatedTagFilteringMap map[string]struct{},
+	embeddedTypes map[reflect.Type]struct{},
 ) ([]Field, error) {
 	fields, err := getFieldValues(pointer)
 	if err != nil {
@@ -189,12 +190,16 @@ func getFieldValuesByTagPriority(
 		tagFields = make([]Field, 0)
 	)
 	for _, field := range fields {
-		// Only retrieve exported attributes.
-		if !field.IsExported() {
+		// Only retrieve exported attributes,
+		// excepting the unexported embedded struct whose exported attributes are promoted.
+		if !field.IsExported() && !field.IsEmbedded() {
 			continue
 		}
 		tagValue = ""
 		for _, p := range priority {
+			if !field.IsExported() {
+				break
+			}
 			tagName = p
 			tagValue = field.Tag(p)
 			if tagValue != "" && tagValue != "-" {
@@ -213,7 +218,14 @@ func getFieldValuesByTagPriority(
 		}
 		// If this is an embedded attribute, it retrieves the tags recursively.
 		if field.IsEmbedded() && field.OriginalKind() == reflect.Struct {
-			subTagFields, err := getFieldValuesByTagPriority(field.Value, priority, repeatedTagFilteringMap)
+			embeddedType := field.OriginalType()
+			if _, ok := embeddedTypes[embeddedType]; ok {
+				// It avoids infinite recursion of the struct embedding itself.
+				continue
+			}
+			subTagFields, err := getFieldValuesByTagPriority(
+				field.Value, priority, repeatedTagFilteringMap, withEmbeddedType(embeddedTypes, embeddedType),
+			)
 			if err != nil {
 				return nil, err
 			} else {
@@ -223,3 +235,14 @@ func getFieldValuesByTagPriority(
 	}
 	return tagFields, nil
 }
+
+// withEmbeddedType returns a copy of `embeddedTypes` with `embeddedType` added, which records
+// the embedded struct types of current recursion path.
+func withEmbeddedType(embeddedTypes map[reflect.Type]struct{}, embeddedType reflect.Type) map[reflect.Type]struct{} {
+	newEmbeddedTypes := make(map[reflect.Type]struct{}, len(embeddedTypes)+1)
+	for t := range embeddedTypes {
+		newEmbeddedTypes[t] = struct{}{}
+	}
+	newEmbeddedTypes[embeddedType] = struct{}{}
+	return newEmbeddedTypes
+}
@@ -0,0 +1,106 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// TagProcessor processes the custom tag of struct field during struct parsing, which returns
+// the tags that are added to the field, like converting custom tag `rule:"required"` into
+// standard tag `v:"required"`. The returned tags do not override the existing tags of the field.
+//
+// The parameter `tagValue` is the raw value of the custom tag of the field.
+type TagProcessor func(field reflect.StructField, tagValue string) map[string]string
+
+var (
+	// tagProcessorMu protects tagProcessorNames and tagProcessorMap.
+	tagProcessorMu sync.RWMutex
+
+	// tagProcessorNames keeps the tag names of processors in registering order.
+	tagProcessorNames []string
+
+	// tagProcessorMap is the map of tag name to processor.
+	tagProcessorMap = make(map[string]TagProcessor)
+
+	// tagProcessorCount is the count of processors for quick checking without locking.
+	tagProcessorCount int32
+)
+
+// RegisterTagProcessor registers the `processor` for custom tag `tagName`, which is called
+// for the fields having the tag during struct parsing of gstructs, and also the struct
+// converting of gconv, the struct validation of gvalid and the request binding of ghttp.
+// The processors are called in registering order, and the later registered one replaces
+// the former one with the same tag name.
+//
+// Note that it should be called before any struct parsing, commonly in the boot of process,
+// as the parsing result of struct might be cached by the components.
+func RegisterTagProcessor(tagName string, processor TagProcessor) {
+	tagProcessorMu.Lock()
+	defer tagProcessorMu.Unlock()
+	if _, ok := tagProcessorMap[tagName]; !ok {
+		tagProcessorNames = append(tagProcessorNames, tagName)
+	}
+	tagProcessorMap[tagName] = processor
+	atomic.StoreInt32(&tagProcessorCount, int32(len(tagProcessorNames)))
+}
+
+// UnregisterTagProcessor removes the processor of custom tag `tagName`.
+func UnregisterTagProcessor(tagName string) {
+	tagProcessorMu.Lock()
+	defer tagProcessorMu.Unlock()
+	if _, ok := tagProcessorMap[tagName]; !ok {
+		return
+	}
+	delete(tagProcessorMap, tagName)
+	for i, name := range tagProcessorNames {
+		if name == tagName {
+			tagProcessorNames = append(tagProcessorNames[:i:i], tagProcessorNames[i+1:]...)
+			break
+		}
+	}
+	atomic.StoreInt32(&tagProcessorCount, int32(len(tagProcessorNames)))
+}
+
+// ProcessTag calls the registered tag processors for `field`, and returns the field with
+// the tags returned by processors added. It returns `field` unchanged if no processor matches.
+func ProcessTag(field reflect.StructField) reflect.StructField {
+	if atomic.LoadInt32(&tagProcessorCount) == 0 || field.Tag == "" {
+		return field
+	}
+	tagProcessorMu.RLock()
+	defer tagProcessorMu.RUnlock()
+	for _, tagName := range tagProcessorNames {
+		tagValue, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		var (
+			tags = tagProcessorMap[tagName](field, tagValue)
+			keys = make([]string, 0, len(tags))
+		)
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		// Sorted for stable tag string.
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok = field.Tag.Lookup(key); ok {
+				continue
+			}
+			field.Tag = reflect.StructTag(strings.TrimSpace(
+				string(field.Tag) + " " + key + ":" + strconv.Quote(tags[key]),
+			))
+		}
+	}
+	return field
+}
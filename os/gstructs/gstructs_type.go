@@ -6,6 +6,8 @@
 
 package gstructs
 
+import "strings"
+
 // Signature returns a unique string as this type.
 func (t Type) Signature() string {
 	return t.PkgPath() + "/" + t.String()
@@ -19,3 +21,48 @@ func (t Type) FieldKeys() []string {
 	}
 	return keys
 }
+
+// IsGeneric checks and returns whether the type is an instantiated generic type, like `Page[User]`.
+func (t Type) IsGeneric() bool {
+	return strings.HasSuffix(t.Name(), "]") && strings.Contains(t.Name(), "[")
+}
+
+// BaseName returns the type name without type arguments, like `Page` for `Page[User]`.
+// It returns the type name directly if it is not a generic type.
+func (t Type) BaseName() string {
+	name := t.Name()
+	if pos := strings.Index(name, "["); pos != -1 && t.IsGeneric() {
+		return name[:pos]
+	}
+	return name
+}
+
+// TypeArguments returns the type arguments of generic type, like ["github.com/x/user.User", "int"]
+// for `Page[github.com/x/user.User,int]`. It returns nil if it is not a generic type.
+func (t Type) TypeArguments() []string {
+	if !t.IsGeneric() {
+		return nil
+	}
+	var (
+		name      = t.Name()
+		arguments = name[strings.Index(name, "[")+1 : len(name)-1]
+		result    []string
+		depth     int
+		start     int
+	)
+	// The arguments might be generic types too, like `Page[Pair[int,string],int]`.
+	for i := 0; i < len(arguments); i++ {
+		switch arguments[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, strings.TrimSpace(arguments[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(result, strings.TrimSpace(arguments[start:]))
+}
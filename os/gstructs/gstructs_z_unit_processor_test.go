@@ -0,0 +1,191 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_TagProcessor(t *testing.T) {
+	gstructs.RegisterTagProcessor("alias", func(field reflect.StructField, tagValue string) map[string]string {
+		return map[string]string{
+			"json":  tagValue,
+			"param": tagValue,
+		}
+	})
+	defer gstructs.UnregisterTagProcessor("alias")
+
+	type User struct {
+		Id   int    `alias:"user_id"`
+		Name string `alias:"user_name" json:"name"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		m, err := gstructs.TagMapName(&User{}, []string{"json"})
+		t.AssertNil(err)
+		t.Assert(m, g.MapStrStr{"user_id": "Id", "name": "Name"})
+
+		fields, err := gstructs.Fields(gstructs.FieldsInput{Pointer: &User{}})
+		t.AssertNil(err)
+		t.Assert(fields[0].TagParam(), "user_id")
+		t.Assert(fields[1].TagParam(), "user_name")
+		t.Assert(fields[1].TagJsonName(), "name")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var user *User
+		err := gconv.Struct(g.Map{"user_id": 1, "name": "john"}, &user)
+		t.AssertNil(err)
+		t.Assert(user.Id, 1)
+		t.Assert(user.Name, "john")
+		t.Assert(gconv.Map(user), g.Map{"user_id": 1, "user_name": "john"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		field := reflect.StructField{Name: "Id", Tag: `alias:"id"`}
+		t.Assert(gstructs.ProcessTag(field).Tag, `alias:"id" json:"id" param:"id"`)
+		gstructs.UnregisterTagProcessor("alias")
+		t.Assert(gstructs.ProcessTag(field).Tag, `alias:"id"`)
+	})
+}
+
+type genericPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type genericPair[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+type genericItem struct {
+	Name string `json:"name"`
+}
+
+func Test_Generic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		type Res struct {
+			genericPage[genericItem]
+			Extra string `json:"extra"`
+		}
+		m, err := gstructs.TagMapName(&Res{}, []string{"json"})
+		t.AssertNil(err)
+		t.Assert(m, g.MapStrStr{"items": "Items", "total": "Total", "extra": "Extra"})
+
+		fieldMap, err := gstructs.FieldMap(gstructs.FieldMapInput{
+			Pointer:          &Res{},
+			PriorityTagArray: []string{"json"},
+			RecursiveOption:  gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fieldMap), 3)
+		itemsField := fieldMap["items"]
+		t.Assert(itemsField.OriginalType().String(), "[]gstructs_test.genericItem")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		structType, err := gstructs.StructType(genericPage[genericPair[string, []int]]{})
+		t.AssertNil(err)
+		t.Assert(structType.IsGeneric(), true)
+		t.Assert(structType.BaseName(), "genericPage")
+		t.Assert(structType.TypeArguments(), []string{
+			"github.com/gogf/gf/v2/os/gstructs_test.genericPair[string,[]int]",
+		})
+
+		structType, err = gstructs.StructType(genericPair[int, map[string]int]{})
+		t.AssertNil(err)
+		t.Assert(structType.TypeArguments(), []string{"int", "map[string]int"})
+
+		structType, err = gstructs.StructType(genericItem{})
+		t.AssertNil(err)
+		t.Assert(structType.IsGeneric(), false)
+		t.Assert(structType.BaseName(), "genericItem")
+		t.Assert(structType.TypeArguments(), nil)
+	})
+}
+
+type embeddedNode struct {
+	*embeddedNode
+	Name string `json:"name"`
+}
+
+type embeddedInner struct {
+	Inner string `json:"inner" v:"required"`
+}
+
+type embeddedMiddle struct {
+	*embeddedInner
+	Middle string `json:"middle"`
+}
+
+func Test_Fields_DeeplyEmbedded(t *testing.T) {
+	// Unexported embedded struct.
+	gtest.C(t, func(t *gtest.T) {
+		type Outer struct {
+			embeddedMiddle
+			Outer string `json:"outer"`
+		}
+		m, err := gstructs.TagMapName(&Outer{}, []string{"json"})
+		t.AssertNil(err)
+		t.Assert(m, g.MapStrStr{"inner": "Inner", "middle": "Middle", "outer": "Outer"})
+
+		fieldMap, err := gstructs.FieldMap(gstructs.FieldMapInput{
+			Pointer:          &Outer{},
+			PriorityTagArray: []string{"json"},
+			RecursiveOption:  gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fieldMap), 3)
+		innerField := fieldMap["inner"]
+		t.Assert(innerField.TagValid(), "required")
+	})
+	// Struct embedding itself.
+	gtest.C(t, func(t *gtest.T) {
+		m, err := gstructs.TagMapName(&embeddedNode{}, []string{"json"})
+		t.AssertNil(err)
+		t.Assert(m, g.MapStrStr{"name": "Name"})
+
+		fields, err := gstructs.Fields(gstructs.FieldsInput{
+			Pointer:         &embeddedNode{},
+			RecursiveOption: gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fields), 1)
+
+		fieldMap, err := gstructs.FieldMap(gstructs.FieldMapInput{
+			Pointer:         &embeddedNode{},
+			RecursiveOption: gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fieldMap), 1)
+	})
+	// Embedded non-struct.
+	gtest.C(t, func(t *gtest.T) {
+		type Status int
+		type Entity struct {
+			Status
+			Name string
+		}
+		fields, err := gstructs.Fields(gstructs.FieldsInput{
+			Pointer:         &Entity{},
+			RecursiveOption: gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fields), 2)
+		t.Assert(fields[0].Name(), "Status")
+
+		fieldMap, err := gstructs.FieldMap(gstructs.FieldMapInput{
+			Pointer:         &Entity{},
+			RecursiveOption: gstructs.RecursiveOptionEmbedded,
+		})
+		t.AssertNil(err)
+		t.Assert(len(fieldMap), 2)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/debug/gdebug"
+)
+
+// defaultGoroutineLeakTimeout is the default timeout waiting for goroutines exiting
+// in goroutine leak detection.
+const defaultGoroutineLeakTimeout = time.Second
+
+// CheckGoroutineLeak checks the goroutine leak of test `t`, which takes the snapshot of goroutines
+// and fails `t` with the leaked goroutines stacks if there are goroutines created and not exited
+// when `t` finishes. The long-lived goroutines of framework are ignored, see gdebug.IgnoreGoroutines.
+// The optional parameter `timeout` specifies the timeout waiting for goroutines exiting,
+// which is 1 second in default.
+//
+// It should be called at the beginning of test, like:
+//
+//	func Test_Server(t *testing.T) {
+//		gtest.CheckGoroutineLeak(t)
+//		...
+//	}
+func CheckGoroutineLeak(t testing.TB, timeout ...time.Duration) {
+	var (
+		snapshot    = gdebug.SnapshotGoroutines()
+		waitTimeout = defaultGoroutineLeakTimeout
+	)
+	if len(timeout) > 0 {
+		waitTimeout = timeout[0]
+	}
+	t.Cleanup(func() {
+		if leaked := snapshot.Leaked(waitTimeout); len(leaked) > 0 {
+			t.Errorf(
+				"[LEAK] %d goroutines leaked:\n%s",
+				len(leaked), gdebug.FormatGoroutines(leaked),
+			)
+		}
+	})
+}
@@ -8,9 +8,12 @@ package gtest_test
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogf/gf/v2/test/gtest"
 )
@@ -409,3 +412,45 @@ func TestDataContent(t *testing.T) {
 		t.Assert(gtest.DataContent(""), "")
 	})
 }
+
+type leakTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (tb *leakTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *leakTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckGoroutineLeak(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			tb = &leakTB{TB: t}
+			ch = make(chan struct{})
+		)
+		gtest.CheckGoroutineLeak(tb, 50*time.Millisecond)
+		go func() {
+			<-ch
+		}()
+		tb.cleanups[0]()
+		t.Assert(len(tb.errors), 1)
+		t.Assert(strings.HasPrefix(tb.errors[0], "[LEAK] 1 goroutines leaked"), true)
+		close(ch)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		tb := &leakTB{TB: t}
+		gtest.CheckGoroutineLeak(tb)
+		done := make(chan struct{})
+		go func() {
+			close(done)
+		}()
+		<-done
+		tb.cleanups[0]()
+		t.Assert(len(tb.errors), 0)
+	})
+}
@@ -9,20 +9,55 @@ package gregex
 import (
 	"regexp"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
+const (
+	// DefaultCacheSize is the default max number of compiled patterns in cache.
+	DefaultCacheSize = 10000
+
+	// regexEvictSamples is the number of sampled patterns for each eviction.
+	regexEvictSamples = 5
+)
+
 var (
 	regexMu = sync.RWMutex{}
 
-	// Cache for regex object.
+	// Cache for regex object, which is an approximate LRU cache limited by regexCacheSize.
 	// Note that:
-	// 1. It uses sync.RWMutex ensuring the concurrent safety.
-	// 2. There's no expiring logic for this map.
-	regexMap = make(map[string]*regexp.Regexp)
+	// 1. It uses sync.RWMutex ensuring the concurrent safety, and the cache hits only take the read lock.
+	// 2. Each item is stamped with the clock when it is used, and the clock ticks when a pattern is added.
+	// 3. The least recently used one among some sampled patterns is evicted if the cache is full.
+	regexMap       = make(map[string]*regexCacheItem)
+	regexClock     = atomic.Int64{}
+	regexCacheSize = DefaultCacheSize
 )
 
+// regexCacheItem is the item of regex cache.
+type regexCacheItem struct {
+	regex *regexp.Regexp
+	used  atomic.Int64 // Clock when it is used lastly.
+}
+
+// SetCacheSize sets the max number of compiled patterns in cache, which is DefaultCacheSize in default.
+// The least recently used patterns are approximately evicted if the cache is full.
+// The cache is unlimited if `size` <= 0.
+func SetCacheSize(size int) {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	regexCacheSize = size
+	evictRegexCache()
+}
+
+// CacheLen returns the number of compiled patterns in cache.
+func CacheLen() int {
+	regexMu.RLock()
+	defer regexMu.RUnlock()
+	return len(regexMap)
+}
+
 // getRegexp returns *regexp.Regexp object with given `pattern`.
 // It uses cache to enhance the performance for compiling regular expression pattern,
 // which means, it will return the same *regexp.Regexp object with the same regular
@@ -30,12 +65,13 @@ var (
 //
 // It is concurrent-safe for multiple goroutines.
 func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
-	// Retrieve the regular expression object using reading lock.
+	// Retrieve the regular expression object and mark it as recently used.
 	regexMu.RLock()
-	regex = regexMap[pattern]
+	item := regexMap[pattern]
 	regexMu.RUnlock()
-	if regex != nil {
-		return
+	if item != nil {
+		item.touch()
+		return item.regex, nil
 	}
 	// If it does not exist in the cache,
 	// it compiles the pattern and creates one.
@@ -43,9 +79,50 @@ func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
 		err = gerror.Wrapf(err, `regexp.Compile failed for pattern "%s"`, pattern)
 		return
 	}
-	// Cache the result object using writing lock.
+	// Cache the result object.
 	regexMu.Lock()
-	regexMap[pattern] = regex
-	regexMu.Unlock()
+	defer regexMu.Unlock()
+	if item = regexMap[pattern]; item != nil {
+		item.touch()
+		return item.regex, nil
+	}
+	item = &regexCacheItem{regex: regex}
+	item.used.Store(regexClock.Add(1))
+	regexMap[pattern] = item
+	evictRegexCache()
 	return
 }
+
+// touch marks the item as recently used. It only writes the item once for each clock tick,
+// so the concurrent cache hits do not contend on writing the same memory.
+func (item *regexCacheItem) touch() {
+	if now := regexClock.Load(); item.used.Load() != now {
+		item.used.Store(now)
+	}
+}
+
+// evictRegexCache evicts the approximately least recently used patterns if the cache is full,
+// which evicts the least recently used one among regexEvictSamples patterns each time.
+// It should be called with lock.
+func evictRegexCache() {
+	if regexCacheSize <= 0 {
+		return
+	}
+	for len(regexMap) > regexCacheSize {
+		var (
+			evictPattern string
+			evictUsed    int64
+			sampled      int
+		)
+		// The iteration order of map is random, which is used for sampling.
+		for pattern, item := range regexMap {
+			if used := item.used.Load(); sampled == 0 || used < evictUsed {
+				evictPattern, evictUsed = pattern, used
+			}
+			if sampled++; sampled >= regexEvictSamples {
+				break
+			}
+		}
+		delete(regexMap, evictPattern)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Limit is the limit for matching, which guards against pathological inputs from user-supplied patterns.
+type Limit struct {
+	// Timeout is the max duration of each matching, no limit if <= 0.
+	// Note that the matching cannot be interrupted in Go, so the timed out matching still runs
+	// in background until it finishes, but its caller returns in time.
+	Timeout time.Duration
+
+	// MaxSteps is the max estimated steps of each matching, no limit if <= 0.
+	// The steps are estimated as the number of program instructions of pattern multiplied
+	// by the length of input, which is the upper bound of the linear time matching.
+	MaxSteps int64
+}
+
+// Matcher matches the input with compiled pattern under Limit.
+type Matcher struct {
+	regex *regexp.Regexp
+	insts int64 // Number of program instructions of pattern.
+	limit Limit
+}
+
+var (
+	// ErrMatchTimeout is returned if the matching does not finish in Limit.Timeout.
+	ErrMatchTimeout = gerror.NewWithOption(gerror.Option{
+		Text: "regex matching timeout",
+		Code: gcode.CodeOperationFailed,
+	})
+	// ErrMatchStepsExceeded is returned if the estimated steps of matching exceed Limit.MaxSteps.
+	ErrMatchStepsExceeded = gerror.NewWithOption(gerror.Option{
+		Text: "regex matching steps exceeded",
+		Code: gcode.CodeInvalidParameter,
+	})
+)
+
+// NewMatcher creates and returns a Matcher of `pattern` with `limit`.
+// The compiled pattern is shared with the cache of package functions.
+func NewMatcher(pattern string, limit Limit) (*Matcher, error) {
+	regex, err := getRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `syntax.Parse failed for pattern "%s"`, pattern)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, gerror.Wrapf(err, `syntax.Compile failed for pattern "%s"`, pattern)
+	}
+	return &Matcher{
+		regex: regex,
+		insts: int64(len(prog.Inst)),
+		limit: limit,
+	}, nil
+}
+
+// Regexp returns the compiled *regexp.Regexp object of Matcher.
+func (m *Matcher) Regexp() *regexp.Regexp {
+	return m.regex
+}
+
+// IsMatchString checks whether given string `src` matches the pattern.
+func (m *Matcher) IsMatchString(src string) (bool, error) {
+	var matched bool
+	if err := m.run(len(src), func() { matched = m.regex.MatchString(src) }); err != nil {
+		return false, err
+	}
+	return matched, nil
+}
+
+// MatchString return strings that matched the pattern.
+func (m *Matcher) MatchString(src string) ([]string, error) {
+	var match []string
+	if err := m.run(len(src), func() { match = m.regex.FindStringSubmatch(src) }); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// MatchAllString return all strings that matched the pattern.
+func (m *Matcher) MatchAllString(src string) ([][]string, error) {
+	var matches [][]string
+	if err := m.run(len(src), func() { matches = m.regex.FindAllStringSubmatch(src, -1) }); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// ReplaceString replace all matched pattern in string `src` with string `replace`.
+func (m *Matcher) ReplaceString(replace, src string) (string, error) {
+	var result string
+	if err := m.run(len(src), func() { result = m.regex.ReplaceAllString(src, replace) }); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// run checks the estimated steps of matching input of length `srcLen`,
+// and runs `f` with the timeout of limit.
+func (m *Matcher) run(srcLen int, f func()) error {
+	if m.limit.MaxSteps > 0 {
+		if steps := m.insts * int64(srcLen+1); steps > m.limit.MaxSteps {
+			return gerror.Wrapf(
+				ErrMatchStepsExceeded,
+				`estimated steps %d exceed limit %d for pattern "%s"`,
+				steps, m.limit.MaxSteps, m.regex.String(),
+			)
+		}
+	}
+	if m.limit.Timeout <= 0 {
+		f()
+		return nil
+	}
+	var (
+		done  = make(chan struct{})
+		timer = time.NewTimer(m.limit.Timeout)
+	)
+	defer timer.Stop()
+	go func() {
+		defer close(done)
+		f()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return gerror.Wrapf(
+			ErrMatchTimeout, `matching exceeded %s for pattern "%s"`, m.limit.Timeout, m.regex.String(),
+		)
+	}
+}
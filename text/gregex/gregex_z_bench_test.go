@@ -31,6 +31,14 @@ func Benchmark_GF_MatchString(b *testing.B) {
 	}
 }
 
+func Benchmark_GF_IsMatchString_Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			gregex.IsMatchString(pattern, src)
+		}
+	})
+}
+
 func Benchmark_Compile(b *testing.B) {
 	var wcdRegexp = regexp.MustCompile(pattern)
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+func Test_CacheSize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		defer gregex.SetCacheSize(gregex.DefaultCacheSize)
+		gregex.SetCacheSize(10)
+		for i := 0; i < 100; i++ {
+			t.Assert(gregex.IsMatchString(fmt.Sprintf(`^cache%d$`, i), fmt.Sprintf("cache%d", i)), true)
+		}
+		t.Assert(gregex.CacheLen(), 10)
+
+		gregex.SetCacheSize(5)
+		t.Assert(gregex.CacheLen(), 5)
+		t.Assert(gregex.IsMatchString(`^cache1$`, "cache1"), true)
+		t.Assert(gregex.CacheLen(), 5)
+		t.AssertNE(gregex.Validate(PatternErr), nil)
+		t.Assert(gregex.CacheLen(), 5)
+	})
+}
+
+func Test_Matcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m, err := gregex.NewMatcher(`(\w+)@(\w+)\.com`, gregex.Limit{
+			Timeout:  time.Second,
+			MaxSteps: 100000,
+		})
+		t.AssertNil(err)
+		t.Assert(m.Regexp().String(), `(\w+)@(\w+)\.com`)
+
+		matched, err := m.IsMatchString("john@goframe.com")
+		t.AssertNil(err)
+		t.Assert(matched, true)
+
+		match, err := m.MatchString("john@goframe.com")
+		t.AssertNil(err)
+		t.Assert(match, []string{"john@goframe.com", "john", "goframe"})
+
+		matches, err := m.MatchAllString("john@goframe.com, jane@example.com")
+		t.AssertNil(err)
+		t.Assert(len(matches), 2)
+		t.Assert(matches[1][1], "jane")
+
+		result, err := m.ReplaceString("$2", "john@goframe.com")
+		t.AssertNil(err)
+		t.Assert(result, "goframe")
+
+		_, err = m.IsMatchString(strings.Repeat("a", 100000))
+		t.Assert(gerror.Is(err, gregex.ErrMatchStepsExceeded), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		m, err := gregex.NewMatcher(`(a|b|c)*d`, gregex.Limit{Timeout: time.Nanosecond})
+		t.AssertNil(err)
+		_, err = m.MatchAllString(strings.Repeat("abc", 10000000))
+		t.Assert(gerror.Is(err, gregex.ErrMatchTimeout), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gregex.NewMatcher(PatternErr, gregex.Limit{})
+		t.AssertNE(err, nil)
+	})
+}
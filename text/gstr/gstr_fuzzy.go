@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FuzzyMatch is the matched candidate of FuzzySearch.
+type FuzzyMatch struct {
+	Value string  // Value of candidate.
+	Index int     // Index of candidate in the candidates slice.
+	Score float64 // Similarity score in [0, 1], the higher the more similar.
+}
+
+// EditDistance calculates the Levenshtein distance between two strings by unicode characters,
+// which is the minimum number of single-character insertions, deletions and substitutions
+// required to change one string into the other.
+//
+// Unlike Levenshtein, it counts unicode characters instead of bytes and has no length limit.
+func EditDistance(str1, str2 string) int {
+	var (
+		r1   = []rune(str1)
+		r2   = []rune(str2)
+		prev = make([]int, len(r2)+1)
+		curr = make([]int, len(r2)+1)
+	)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(r2)]
+}
+
+// DamerauDistance calculates the Damerau-Levenshtein distance between two strings by unicode
+// characters, which also counts the transposition of two adjacent characters as one edit,
+// like "ab" and "ba".
+//
+// It is the optimal string alignment distance, in which no substring is edited more than once.
+func DamerauDistance(str1, str2 string) int {
+	var (
+		r1 = []rune(str1)
+		r2 = []rune(str2)
+		d  = make([][]int, len(r1)+1)
+	)
+	for i := range d {
+		d[i] = make([]int, len(r2)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] && r1[i-2] == r2[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(r1)][len(r2)]
+}
+
+// JaroWinkler calculates the Jaro-Winkler similarity between two strings by unicode characters,
+// which is in [0, 1], 1 for the same strings and 0 for completely different strings.
+// It favors the strings sharing a common prefix, which makes it suitable for short strings
+// like names and commands.
+func JaroWinkler(str1, str2 string) float64 {
+	jaro := jaroSimilarity([]rune(str1), []rune(str2))
+	if jaro <= 0.7 {
+		return jaro
+	}
+	// Common prefix up to 4 characters.
+	var prefix int
+	for i, r := range str1 {
+		if prefix >= 4 || i >= len(str2) {
+			break
+		}
+		r2, _ := utf8.DecodeRuneInString(str2[i:])
+		if r != r2 {
+			break
+		}
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// FuzzySearch searches `query` in `candidates` case-insensitively, and returns the matched
+// candidates whose score is not less than `minScore`, sorted by score in descending order.
+// The candidates containing `query` are scored higher than the others, and the score is based
+// on Jaro-Winkler similarity. It is useful for CLI suggestions and search-as-you-type.
+//
+// Example:
+// FuzzySearch("stat", []string{"start", "status", "stop"}, 0.8)
+// returns "status", "start".
+func FuzzySearch(query string, candidates []string, minScore float64) []FuzzyMatch {
+	var (
+		matches    = make([]FuzzyMatch, 0)
+		lowerQuery = strings.ToLower(query)
+	)
+	for i, candidate := range candidates {
+		var (
+			lowerCandidate = strings.ToLower(candidate)
+			score          = JaroWinkler(lowerQuery, lowerCandidate)
+		)
+		if lowerQuery != "" && strings.Contains(lowerCandidate, lowerQuery) {
+			// Containing score in [0.9, 1], the shorter candidate the higher.
+			containing := 0.9 + 0.1*float64(utf8.RuneCountInString(lowerQuery))/float64(utf8.RuneCountInString(lowerCandidate))
+			if containing > score {
+				score = containing
+			}
+		}
+		if score >= minScore {
+			matches = append(matches, FuzzyMatch{
+				Value: candidate,
+				Index: i,
+				Score: score,
+			})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// jaroSimilarity calculates the Jaro similarity between two rune slices.
+func jaroSimilarity(r1, r2 []rune) float64 {
+	if len(r1) == 0 && len(r2) == 0 {
+		return 1
+	}
+	if len(r1) == 0 || len(r2) == 0 {
+		return 0
+	}
+	matchDistance := maxInt(len(r1), len(r2))/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+	var (
+		matches1 = make([]bool, len(r1))
+		matches2 = make([]bool, len(r2))
+		matches  int
+	)
+	for i := range r1 {
+		start, end := maxInt(0, i-matchDistance), minInt(len(r2), i+matchDistance+1)
+		for j := start; j < end; j++ {
+			if matches2[j] || r1[i] != r2[j] {
+				continue
+			}
+			matches1[i], matches2[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	var transpositions, k int
+	for i := range r1 {
+		if !matches1[i] {
+			continue
+		}
+		for !matches2[k] {
+			k++
+		}
+		if r1[i] != r2[k] {
+			transpositions++
+		}
+		k++
+	}
+	m := float64(matches)
+	return (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,66 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeNFC returns the Unicode Normalization Form C of `str`, which composes the characters
+// by canonical equivalence, like "é" to "é".
+func NormalizeNFC(str string) string {
+	return norm.NFC.String(str)
+}
+
+// NormalizeNFD returns the Unicode Normalization Form D of `str`, which decomposes the characters
+// by canonical equivalence, like "é" to "é".
+func NormalizeNFD(str string) string {
+	return norm.NFD.String(str)
+}
+
+// NormalizeNFKC returns the Unicode Normalization Form KC of `str`, which composes the characters
+// by compatibility equivalence, like "ﬁ" to "fi".
+func NormalizeNFKC(str string) string {
+	return norm.NFKC.String(str)
+}
+
+// NormalizeNFKD returns the Unicode Normalization Form KD of `str`, which decomposes the characters
+// by compatibility equivalence.
+func NormalizeNFKD(str string) string {
+	return norm.NFKD.String(str)
+}
+
+// IsNormalizedNFC checks whether `str` is in Unicode Normalization Form C.
+func IsNormalizedNFC(str string) bool {
+	return norm.NFC.IsNormalString(str)
+}
+
+// CaseFold returns the full Unicode case folding of `str`, which is used for caseless matching,
+// like "Straße" to "strasse" and "ΣΊΣΥΦΟΣ" to "σίσυφοσ".
+func CaseFold(str string) string {
+	return cases.Fold().String(str)
+}
+
+// FoldKey returns the canonical caseless key of `str`, which is the NFC form of full case folding
+// of canonical decomposition. The strings of the same key are equal in canonical caseless matching,
+// so it can be used for deduplication and map keys.
+func FoldKey(str string) string {
+	return norm.NFC.String(cases.Fold().String(norm.NFD.String(str)))
+}
+
+// EqualFold reports whether `a` and `b` are equal under full Unicode case folding and canonical
+// equivalence, like "Straße" and "STRASSE", or "é" and "É".
+//
+// Note that Equal uses the simple case folding, which does not match the characters whose
+// folding changes the length, like "ß" and "ss".
+func EqualFold(a, b string) bool {
+	if a == b {
+		return true
+	}
+	return FoldKey(a) == FoldKey(b)
+}
@@ -0,0 +1,184 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugOption is the option for Slug.
+type SlugOption struct {
+	// Separator joins the words of slug, which is "-" in default.
+	Separator string
+
+	// MaxLength is the max characters of slug, no limit if <= 0.
+	// The slug is truncated at word boundary if possible.
+	MaxLength int
+
+	// KeepCase keeps the case of letters, or else the slug is lowercase.
+	KeepCase bool
+
+	// KeepUnicode keeps the unicode letters and digits that cannot be transliterated,
+	// like CJK characters, or else they are removed.
+	// Note that they are always kept if the slug is empty after removing them,
+	// like the slug of pure Chinese text without Transliterator.
+	KeepUnicode bool
+
+	// Transliterator transliterates the character into ASCII before the built-in transliteration,
+	// which returns false if it cannot transliterate the character.
+	// There's no built-in pinyin dictionary, and it is the option for CJK characters,
+	// like converting Chinese characters to pinyin with a third-party library:
+	// func(r rune) (string, bool) { return pinyin(r) }
+	// in which the result is taken as a separate word.
+	Transliterator func(r rune) (string, bool)
+}
+
+// slugTransliterations are the built-in transliterations of characters which are not transliterated
+// by decomposition, including the special latin letters, Greek and Cyrillic letters.
+var slugTransliterations = map[rune]string{
+	// Latin.
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Ø': "O", 'ø': "o", 'Œ': "OE", 'œ': "oe",
+	'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d", 'Ł': "L", 'ł': "l", 'Þ': "TH", 'þ': "th",
+	'Ħ': "H", 'ħ': "h", 'ı': "i", 'Ŋ': "N", 'ŋ': "n", 'ſ': "s",
+	// Greek.
+	'Α': "A", 'Β': "V", 'Γ': "G", 'Δ': "D", 'Ε': "E", 'Ζ': "Z", 'Η': "I", 'Θ': "TH",
+	'Ι': "I", 'Κ': "K", 'Λ': "L", 'Μ': "M", 'Ν': "N", 'Ξ': "X", 'Ο': "O", 'Π': "P",
+	'Ρ': "R", 'Σ': "S", 'Τ': "T", 'Υ': "Y", 'Φ': "F", 'Χ': "CH", 'Ψ': "PS", 'Ω': "O",
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	// Cyrillic.
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Е': "E", 'Ё': "YO", 'Ж': "ZH",
+	'З': "Z", 'И': "I", 'Й': "Y", 'К': "K", 'Л': "L", 'М': "M", 'Н': "N", 'О': "O",
+	'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'У': "U", 'Ф': "F", 'Х': "KH", 'Ц': "TS",
+	'Ч': "CH", 'Ш': "SH", 'Щ': "SHCH", 'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "YU",
+	'Я': "YA", 'Є': "YE", 'І': "I", 'Ї': "YI", 'Ґ': "G",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+}
+
+// Slug returns the URL-safe slug of `str`, in which the words are transliterated into ASCII
+// and joined with separator, like "Héllo, Wörld!" to "hello-world".
+// The latin letters with diacritics, Greek and Cyrillic letters are transliterated in built-in,
+// and the other characters like CJK can be transliterated by SlugOption.Transliterator.
+func Slug(str string, option ...SlugOption) string {
+	var opt SlugOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Separator == "" {
+		opt.Separator = "-"
+	}
+	slug, removed := makeSlug(str, opt)
+	if slug == "" && removed {
+		// It keeps the characters that cannot be transliterated instead of returning empty slug.
+		opt.KeepUnicode = true
+		slug, _ = makeSlug(str, opt)
+	}
+	return slug
+}
+
+// makeSlug makes the slug of `str` with `opt`, and returns whether any unicode letter or digit
+// is removed as it cannot be transliterated.
+func makeSlug(str string, opt SlugOption) (string, bool) {
+	var (
+		removed bool
+		words   []string
+		word    strings.Builder
+		flush   = func() {
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		}
+		appendASCII = func(s string) {
+			for _, r := range s {
+				if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					word.WriteRune(r)
+				} else {
+					flush()
+				}
+			}
+		}
+	)
+	for _, r := range norm.NFC.String(str) {
+		if opt.Transliterator != nil {
+			if s, ok := opt.Transliterator(r); ok {
+				flush()
+				appendASCII(s)
+				flush()
+				continue
+			}
+		}
+		if r < utf8.RuneSelf {
+			appendASCII(string(r))
+			continue
+		}
+		// Removes the diacritics by decomposition, like "é" to "e" and "έ" to "ε".
+		var transliterated bool
+		for _, d := range norm.NFKD.String(string(r)) {
+			if s, ok := slugTransliterations[d]; ok {
+				word.WriteString(s)
+				transliterated = true
+			} else if d < utf8.RuneSelf && (unicode.IsLetter(d) || unicode.IsDigit(d)) {
+				word.WriteRune(d)
+				transliterated = true
+			}
+		}
+		if transliterated {
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if opt.KeepUnicode {
+				word.WriteRune(r)
+				continue
+			}
+			removed = true
+		}
+		if !unicode.Is(unicode.Mn, r) {
+			flush()
+		}
+	}
+	flush()
+
+	var (
+		slug   strings.Builder
+		length int
+	)
+	for _, w := range words {
+		if !opt.KeepCase {
+			w = strings.ToLower(w)
+		}
+		var (
+			wordLength = utf8.RuneCountInString(w)
+			sepLength  = 0
+		)
+		if slug.Len() > 0 {
+			sepLength = utf8.RuneCountInString(opt.Separator)
+		}
+		if opt.MaxLength > 0 && length+sepLength+wordLength > opt.MaxLength {
+			// The first word is truncated if it is longer than the max length.
+			if slug.Len() == 0 {
+				slug.WriteString(string([]rune(w)[:opt.MaxLength]))
+			}
+			break
+		}
+		if sepLength > 0 {
+			slug.WriteString(opt.Separator)
+		}
+		slug.WriteString(w)
+		length += sepLength + wordLength
+	}
+	return slug.String(), removed
+}
@@ -0,0 +1,68 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_EditDistance(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.EditDistance("", ""), 0)
+		t.Assert(gstr.EditDistance("", "abc"), 3)
+		t.Assert(gstr.EditDistance("kitten", "sitting"), 3)
+		t.Assert(gstr.EditDistance("ab", "ba"), 2)
+		t.Assert(gstr.EditDistance("你好世界", "你好"), 2)
+	})
+}
+
+func Test_DamerauDistance(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.DamerauDistance("", "abc"), 3)
+		t.Assert(gstr.DamerauDistance("kitten", "sitting"), 3)
+		t.Assert(gstr.DamerauDistance("ab", "ba"), 1)
+		t.Assert(gstr.DamerauDistance("ca", "abc"), 3)
+		t.Assert(gstr.DamerauDistance("世界", "界世"), 1)
+	})
+}
+
+func Test_JaroWinkler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.JaroWinkler("", ""), 1)
+		t.Assert(gstr.JaroWinkler("abc", ""), 0)
+		t.Assert(gstr.JaroWinkler("abc", "xyz"), 0)
+		t.Assert(gstr.JaroWinkler("goframe", "goframe"), 1)
+		t.Assert(fmt.Sprintf("%.4f", gstr.JaroWinkler("MARTHA", "MARHTA")), "0.9611")
+		t.Assert(fmt.Sprintf("%.4f", gstr.JaroWinkler("DIXON", "DICKSONX")), "0.8133")
+		t.Assert(fmt.Sprintf("%.4f", gstr.JaroWinkler("DWAYNE", "DUANE")), "0.8400")
+	})
+}
+
+func Test_FuzzySearch(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		matches := gstr.FuzzySearch("stat", []string{"start", "status", "stop", "restart"}, 0.8)
+		var values []string
+		for _, match := range matches {
+			values = append(values, match.Value)
+		}
+		t.Assert(values, []string{"status", "start"})
+		t.Assert(matches[0].Index, 1)
+		t.Assert(matches[1].Index, 0)
+
+		matches = gstr.FuzzySearch("BUILD", []string{"build", "rebuild", "guild"}, 0.5)
+		t.Assert(len(matches), 3)
+		t.Assert(matches[0].Value, "build")
+		t.Assert(matches[0].Score, 1)
+		t.Assert(matches[1].Value, "rebuild")
+
+		t.Assert(len(gstr.FuzzySearch("xyz", []string{"build"}, 0.5)), 0)
+	})
+}
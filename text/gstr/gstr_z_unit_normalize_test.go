@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_Normalize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			composed   = "café"
+			decomposed = "café"
+		)
+		t.Assert(gstr.NormalizeNFC(decomposed), composed)
+		t.Assert(gstr.NormalizeNFD(composed), decomposed)
+		t.Assert(gstr.NormalizeNFKC("ﬁle"), "file")
+		t.Assert(gstr.NormalizeNFKD("½"), "1⁄2")
+		t.Assert(gstr.IsNormalizedNFC(composed), true)
+		t.Assert(gstr.IsNormalizedNFC(decomposed), false)
+	})
+}
+
+func Test_CaseFold(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.CaseFold("Straße"), "strasse")
+		t.Assert(gstr.CaseFold("ΣΊΣΥΦΟΣ"), "σίσυφοσ")
+		t.Assert(gstr.CaseFold("GoFrame"), "goframe")
+	})
+}
+
+func Test_EqualFold(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.EqualFold("Straße", "STRASSE"), true)
+		t.Assert(gstr.EqualFold("café", "CAFÉ"), true)
+		t.Assert(gstr.EqualFold("ΣΊΣΥΦΟΣ", "σίσυφος"), true)
+		t.Assert(gstr.EqualFold("Привет", "ПРИВЕТ"), true)
+		t.Assert(gstr.EqualFold("GoFrame", "goframe"), true)
+		t.Assert(gstr.EqualFold("GoFrame", "go-frame"), false)
+		// Simple case folding does not match.
+		t.Assert(gstr.Equal("Straße", "STRASSE"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			keys   = make(map[string]struct{})
+			values = []string{"Straße", "STRASSE", "strasse", "café", "CAFÉ"}
+		)
+		for _, v := range values {
+			keys[gstr.FoldKey(v)] = struct{}{}
+		}
+		t.Assert(len(keys), 2)
+	})
+}
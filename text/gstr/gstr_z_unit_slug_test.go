@@ -0,0 +1,48 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_Slug(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.Slug(""), "")
+		t.Assert(gstr.Slug("Hello, World!"), "hello-world")
+		t.Assert(gstr.Slug("  --GoFrame   v2.7 released--  "), "goframe-v2-7-released")
+		t.Assert(gstr.Slug("Héllo Wörld, ça va?"), "hello-world-ca-va")
+		t.Assert(gstr.Slug("Straße Æsir Łódź"), "strasse-aesir-lodz")
+		t.Assert(gstr.Slug("Привет мир"), "privet-mir")
+		t.Assert(gstr.Slug("Καλημέρα κόσμε"), "kalimera-kosme")
+		t.Assert(gstr.Slug("GoFrame 框架"), "goframe")
+		t.Assert(gstr.Slug("你好，世界"), "你好-世界")
+		t.Assert(gstr.Slug("！？"), "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.Slug("Hello World", gstr.SlugOption{Separator: "_"}), "hello_world")
+		t.Assert(gstr.Slug("Hello World", gstr.SlugOption{KeepCase: true}), "Hello-World")
+		t.Assert(gstr.Slug("GoFrame 框架", gstr.SlugOption{KeepUnicode: true}), "goframe-框架")
+		t.Assert(gstr.Slug("the quick brown fox", gstr.SlugOption{MaxLength: 15}), "the-quick-brown")
+		t.Assert(gstr.Slug("the quick brown fox", gstr.SlugOption{MaxLength: 14}), "the-quick")
+		t.Assert(gstr.Slug("supercalifragilistic", gstr.SlugOption{MaxLength: 5}), "super")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		pinyin := map[rune]string{'框': "kuang", '架': "jia", '你': "ni", '好': "hao"}
+		option := gstr.SlugOption{
+			Transliterator: func(r rune) (string, bool) {
+				s, ok := pinyin[r]
+				return s, ok
+			},
+		}
+		t.Assert(gstr.Slug("GoFrame框架", option), "goframe-kuang-jia")
+		t.Assert(gstr.Slug("你好, World", option), "ni-hao-world")
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gstructs"
 	"github.com/gogf/gf/v2/util/gconv/internal/localinterface"
 	"github.com/gogf/gf/v2/util/gtag"
 )
@@ -363,7 +364,7 @@ func doMapConvertForMapOrStructValue(in doMapConvertForMapOrStructValueInput) in
 			mapKey      = ""                  // mapKey may be the tag name or the struct attribute name.
 		)
 		for i := 0; i < reflectValue.NumField(); i++ {
-			rtField = reflectType.Field(i)
+			rtField = gstructs.ProcessTag(reflectType.Field(i))
 			rvField = reflectValue.Field(i)
 			// Only convert the public attributes.
 			fieldName := rtField.Name
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gstructs"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/util/gtag"
 )
@@ -108,7 +109,8 @@ func parseStructToCachedStructInfo(
 	//  but the [FieldIndex] needs to be reset.
 	//  We will not implement it temporarily because it is somewhat complex
 	for i := 0; i < structType.NumField(); i++ {
-		structField = structType.Field(i)
+		// It applies the custom tag processors registered in gstructs.
+		structField = gstructs.ProcessTag(structType.Field(i))
 		fieldType = structField.Type
 		fieldName = structField.Name
 		// Only do converting to public attributes.